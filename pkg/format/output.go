@@ -111,6 +111,18 @@ func init() {
 
 	})
 
+	// to_json prints an object or array as compact JSON, e.g. to show a whole message in one column
+	ajson.AddFunction("to_json", func(node *ajson.Node) (result *ajson.Node, err error) {
+		if node.IsNull() {
+			return ajson.StringNode("", ""), nil
+		}
+		document, err := ajson.Marshal(node)
+		if err != nil {
+			return node, err
+		}
+		return ajson.StringNode("", string(document)), nil
+	})
+
 	ajson.AddFunction("localtime", func(node *ajson.Node) (result *ajson.Node, err error) {
 		if node.IsNumeric() {
 			unixTime := int64(node.MustNumeric())
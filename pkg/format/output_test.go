@@ -109,6 +109,18 @@ var _ = Describe("format table unit tests", func() {
 			})
 		})
 
+		When("an object column is printed with to_json", func() {
+			BeforeEach(func() {
+				jsonInput = `{"test":{"nested":[1,2]}}`
+				output.TableColumns = []format.Column{{Name: "Column1", Expr: "to_json(@.test)"}}
+			})
+
+			It("prints the object as JSON", func() {
+				Expect(outputBuffer).To(ContainSubstring(`{"nested":[1,2]}`))
+				Expect(LineCount(outputBuffer)).To(Equal(1))
+			})
+		})
+
 		When("a list is evaluated", func() {
 			BeforeEach(func() {
 				jsonInput = `[{"test":666,"test2":"string"},{"test":667,"test3":52.6},{"test":668}]`
@@ -16,19 +16,27 @@ limitations under the License.
 package main
 
 import (
+	"flag"
+
 	"github.com/yugabyte/yb-tools/protoc-gen-ybrpc/plugin/json"
 	"github.com/yugabyte/yb-tools/protoc-gen-ybrpc/plugin/ybrpc"
 	"google.golang.org/protobuf/compiler/protogen"
 )
 
 func main() {
-	protogen.Options{}.Run(func(gen *protogen.Plugin) error {
+	var flags flag.FlagSet
+	mocks := flags.Bool("mocks", false, "also generate a mock of each service, as --ybrpc_opt=mocks=true")
+
+	protogen.Options{ParamFunc: flags.Set}.Run(func(gen *protogen.Plugin) error {
 		for _, file := range gen.Files {
 			if !file.Generate {
 				continue
 			}
 			ybrpc.Generate(gen, file)
 			json.Generate(gen, file)
+			if *mocks {
+				ybrpc.GenerateMocks(gen, file)
+			}
 		}
 		return nil
 	})
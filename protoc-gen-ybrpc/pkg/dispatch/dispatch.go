@@ -0,0 +1,176 @@
+// Package dispatch routes decoded RPC calls to server implementations of the
+// services generated by protoc-gen-ybrpc.
+//
+// For each service, the generated code provides a <Service>Server interface, an
+// Unimplemented<Service>Server to embed for partial implementations, a
+// <Service>_ServiceDesc describing the methods and their request and response
+// types, and a Register<Service> helper that adds an implementation to a
+// Registrar such as a Dispatcher. The descriptions of all services are also kept
+// in a registry, to find methods by name.
+package dispatch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	// ErrNoSuchService is returned for calls to a service that is not registered
+	ErrNoSuchService = errors.New("no such service")
+
+	// ErrNoSuchMethod is returned for calls to a method the service does not
+	// have, or that the registered implementation does not implement
+	ErrNoSuchMethod = errors.New("no such method")
+
+	// ErrInvalidRequest is returned when the request body cannot be decoded
+	ErrInvalidRequest = errors.New("invalid request")
+)
+
+// Handler calls the method on srv, which implements the service's server
+// interface, with a request created by the method's NewRequest.
+type Handler func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error)
+
+type MethodDesc struct {
+	MethodName  string
+	NewRequest  func() proto.Message
+	NewResponse func() proto.Message
+	Handler     Handler
+}
+
+type ServiceDesc struct {
+	// ServiceName is the full name of the service, such as "yb.master.MasterService"
+	ServiceName string
+
+	// HandlerType is a nil pointer to the service's server interface, used to
+	// check implementations on registration
+	HandlerType interface{}
+
+	Methods []MethodDesc
+}
+
+// Method returns the description of the named method.
+func (d *ServiceDesc) Method(name string) (*MethodDesc, bool) {
+	for i := range d.Methods {
+		if d.Methods[i].MethodName == name {
+			return &d.Methods[i], true
+		}
+	}
+	return nil, false
+}
+
+type Registrar interface {
+	RegisterService(desc *ServiceDesc, impl interface{})
+}
+
+type service struct {
+	desc *ServiceDesc
+	impl interface{}
+}
+
+// Dispatcher is a Registrar that routes calls to the registered implementations
+// by service full name and method name.
+type Dispatcher struct {
+	m        sync.RWMutex
+	services map[string]service
+}
+
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{services: make(map[string]service)}
+}
+
+// RegisterService serves the service with impl, replacing any implementation
+// registered before. It panics if impl does not implement the service's server
+// interface.
+func (d *Dispatcher) RegisterService(desc *ServiceDesc, impl interface{}) {
+	if desc.HandlerType != nil {
+		handlerType := reflect.TypeOf(desc.HandlerType).Elem()
+		if !reflect.TypeOf(impl).Implements(handlerType) {
+			panic(fmt.Sprintf("dispatch: %T does not implement %v", impl, handlerType))
+		}
+	}
+
+	d.m.Lock()
+	defer d.m.Unlock()
+
+	d.services[desc.ServiceName] = service{desc: desc, impl: impl}
+}
+
+// Services returns the full names of the registered services, sorted.
+func (d *Dispatcher) Services() []string {
+	d.m.RLock()
+	defer d.m.RUnlock()
+
+	var names []string
+	for name := range d.services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Lookup returns the description of a registered method.
+func (d *Dispatcher) Lookup(serviceName, methodName string) (*MethodDesc, error) {
+	_, method, err := d.lookup(serviceName, methodName)
+	return method, err
+}
+
+func (d *Dispatcher) lookup(serviceName, methodName string) (service, *MethodDesc, error) {
+	d.m.RLock()
+	s, ok := d.services[serviceName]
+	d.m.RUnlock()
+	if !ok {
+		return service{}, nil, fmt.Errorf("%w: %s", ErrNoSuchService, serviceName)
+	}
+
+	method, ok := s.desc.Method(methodName)
+	if !ok {
+		return service{}, nil, fmt.Errorf("%w: %s.%s", ErrNoSuchMethod, serviceName, methodName)
+	}
+	return s, method, nil
+}
+
+// Call passes the request to the registered implementation of the method.
+func (d *Dispatcher) Call(ctx context.Context, serviceName, methodName string, request proto.Message) (proto.Message, error) {
+	s, method, err := d.lookup(serviceName, methodName)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := method.Handler(s.impl, ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	// A typed nil response is not a usable message
+	if response == nil || !response.ProtoReflect().IsValid() {
+		return nil, fmt.Errorf("%s.%s returned no response", serviceName, methodName)
+	}
+	return response, nil
+}
+
+// Dispatch decodes the request body and passes it to the registered
+// implementation of the method.
+func (d *Dispatcher) Dispatch(ctx context.Context, serviceName, methodName string, body []byte) (proto.Message, error) {
+	method, err := d.Lookup(serviceName, methodName)
+	if err != nil {
+		return nil, err
+	}
+
+	request := method.NewRequest()
+	err = proto.Unmarshal(body, request)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidRequest, err)
+	}
+
+	return d.Call(ctx, serviceName, methodName, request)
+}
+
+// Unimplemented returns the error of methods a server does not implement.
+func Unimplemented(serviceName, methodName string) error {
+	return fmt.Errorf("%w: %s.%s is not implemented", ErrNoSuchMethod, serviceName, methodName)
+}
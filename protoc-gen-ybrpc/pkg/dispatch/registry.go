@@ -0,0 +1,115 @@
+package dispatch
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// The generated code registers the description of every service when its
+// package is initialized, so the registry holds the services of all imported
+// packages.
+var registry = struct {
+	sync.RWMutex
+	services map[string]*ServiceDesc
+}{services: make(map[string]*ServiceDesc)}
+
+// RegisterServiceDesc adds the service to the registry. It is called by the
+// generated code.
+func RegisterServiceDesc(desc *ServiceDesc) {
+	registry.Lock()
+	defer registry.Unlock()
+
+	registry.services[desc.ServiceName] = desc
+}
+
+// ServiceDescs returns the registered services, sorted by full name.
+func ServiceDescs() []*ServiceDesc {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	var descs []*ServiceDesc
+	for _, desc := range registry.services {
+		descs = append(descs, desc)
+	}
+	sort.Slice(descs, func(i, j int) bool {
+		return descs[i].ServiceName < descs[j].ServiceName
+	})
+	return descs
+}
+
+// FindService returns the registered service with the full name, such as
+// "yb.master.MasterService", or the unique service with the short name, such as
+// "MasterService".
+func FindService(name string) (*ServiceDesc, error) {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	if desc, ok := registry.services[name]; ok {
+		return desc, nil
+	}
+
+	var matches []*ServiceDesc
+	for _, desc := range registry.services {
+		if desc.Name() == name {
+			matches = append(matches, desc)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("%w: %s", ErrNoSuchService, name)
+	case 1:
+		return matches[0], nil
+	}
+	return nil, fmt.Errorf("service name %s is ambiguous, use the full name", name)
+}
+
+// FindMethod returns the registered method named <Service>.<Method>, where the
+// service is given as accepted by FindService.
+func FindMethod(name string) (*ServiceDesc, *MethodDesc, error) {
+	i := strings.LastIndex(name, ".")
+	if i < 1 || i == len(name)-1 {
+		return nil, nil, fmt.Errorf("method %q is not of the form <Service>.<Method>", name)
+	}
+
+	desc, err := FindService(name[:i])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	method, ok := desc.Method(name[i+1:])
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %s.%s", ErrNoSuchMethod, desc.ServiceName, name[i+1:])
+	}
+	return desc, method, nil
+}
+
+// Name returns the service name without its package.
+func (d *ServiceDesc) Name() string {
+	return d.ServiceName[strings.LastIndex(d.ServiceName, ".")+1:]
+}
+
+// Descriptor returns the protobuf descriptor of the service.
+func (d *ServiceDesc) Descriptor() (protoreflect.ServiceDescriptor, error) {
+	descriptor, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(d.ServiceName))
+	if err != nil {
+		return nil, err
+	}
+	service, ok := descriptor.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a service", d.ServiceName)
+	}
+	return service, nil
+}
+
+func (m *MethodDesc) RequestDescriptor() protoreflect.MessageDescriptor {
+	return m.NewRequest().ProtoReflect().Descriptor()
+}
+
+func (m *MethodDesc) ResponseDescriptor() protoreflect.MessageDescriptor {
+	return m.NewResponse().ProtoReflect().Descriptor()
+}
@@ -1,7 +1,13 @@
 package message
 
-import "google.golang.org/protobuf/proto"
+import (
+	"context"
+
+	"google.golang.org/protobuf/proto"
+)
 
 type Messenger interface {
-	SendMessage(service, method string, request, response proto.Message) error
+	// SendMessage sends the request and waits for the response. The deadline of
+	// ctx, if any, bounds the whole call, and cancelling ctx abandons it.
+	SendMessage(ctx context.Context, service, method string, request, response proto.Message) error
 }
@@ -0,0 +1,59 @@
+package mock
+
+import (
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Call is a call made to a mock service.
+type Call struct {
+	Method  string
+	Request proto.Message
+}
+
+// Recorder records the calls made to a mock service. It is embedded in the
+// generated mocks, and is safe for concurrent use.
+type Recorder struct {
+	m     sync.Mutex
+	calls []Call
+}
+
+// Record adds a call to the method. It is called by the generated mocks.
+func (r *Recorder) Record(method string, request proto.Message) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	r.calls = append(r.calls, Call{Method: method, Request: request})
+}
+
+// Calls returns every call made, in the order they were made.
+func (r *Recorder) Calls() []Call {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	return append([]Call{}, r.calls...)
+}
+
+// CallsTo returns the calls made to the method, given by its name in the proto
+// service.
+func (r *Recorder) CallsTo(method string) []Call {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	var calls []Call
+	for _, call := range r.calls {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// ResetCalls forgets the calls made so far.
+func (r *Recorder) ResetCalls() {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	r.calls = nil
+}
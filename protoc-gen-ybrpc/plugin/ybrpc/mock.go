@@ -0,0 +1,79 @@
+package ybrpc
+
+import (
+	"github.com/yugabyte/yb-tools/protoc-gen-ybrpc/pkg/util"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// GenerateMocks generates a _mock file with a mock of each service in the file,
+// for tests of code that calls the services.
+func GenerateMocks(gen *protogen.Plugin, file *protogen.File) {
+	for _, service := range file.Services {
+		if len(service.Methods) > 0 {
+			_ = generateMockFile(gen, file)
+			return
+		}
+	}
+}
+
+func generateMockFile(gen *protogen.Plugin, file *protogen.File) *protogen.GeneratedFile {
+	filename := file.GeneratedFilenamePrefix + ".pb.ybrpc.mock.go"
+	g := gen.NewGeneratedFile(filename, file.GoImportPath)
+
+	util.GenerateHeader(g, file)
+
+	g.P()
+	g.P(`import (`)
+	g.P(`    "context"`)
+	g.P()
+	g.P(`    "github.com/yugabyte/yb-tools/protoc-gen-ybrpc/pkg/dispatch"`)
+	g.P(`    "github.com/yugabyte/yb-tools/protoc-gen-ybrpc/pkg/mock"`)
+	g.P(`)`)
+	g.P()
+
+	for _, service := range file.Services {
+		if len(service.Methods) == 0 {
+			continue
+		}
+		generateMock(g, service)
+	}
+
+	return g
+}
+
+func mockName(service *protogen.Service) string {
+	return "Mock" + service.GoName
+}
+
+// generateMock generates a type implementing the service with a stub function
+// for each method, that records every call made to it.
+func generateMock(g *protogen.GeneratedFile, service *protogen.Service) {
+	g.P("// ", mockName(service), " is a ", service.GoName, " for tests. Each method calls its stub function,")
+	g.P("// or fails with dispatch.ErrNoSuchMethod when the stub is not set, and every")
+	g.P("// call is recorded.")
+	g.P("type ", mockName(service), " struct {")
+	g.P("    mock.Recorder")
+	g.P()
+	for _, method := range service.Methods {
+		g.P("    ", method.GoName, "Func func(ctx context.Context, request *", method.Input.GoIdent.GoName, ") (*", method.Output.GoIdent.GoName, ", error)")
+	}
+	g.P("}")
+	g.P()
+	g.P("var _ ", service.GoName, " = &", mockName(service), "{}")
+	g.P()
+
+	for _, method := range service.Methods {
+		g.P("func (s *", mockName(service), ") ", method.GoName, "(request *", method.Input.GoIdent.GoName, ") (*", method.Output.GoIdent.GoName, ", error) {")
+		g.P("    return s.", method.GoName, "WithContext(context.Background(), request)")
+		g.P("}")
+		g.P()
+		g.P("func (s *", mockName(service), ") ", method.GoName, "WithContext(ctx context.Context, request *", method.Input.GoIdent.GoName, ") (*", method.Output.GoIdent.GoName, ", error) {")
+		g.P(`    s.Record("`, string(method.Desc.Name()), `", request)`)
+		g.P("    if s.", method.GoName, "Func == nil {")
+		g.P(`        return nil, dispatch.Unimplemented("`, string(service.Desc.FullName()), `", "`, string(method.Desc.Name()), `")`)
+		g.P("    }")
+		g.P("    return s.", method.GoName, "Func(ctx, request)")
+		g.P("}")
+		g.P()
+	}
+}
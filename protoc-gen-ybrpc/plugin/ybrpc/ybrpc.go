@@ -37,6 +37,14 @@ func generateFile(gen *protogen.Plugin, file *protogen.File) *protogen.Generated
 		for _, method := range service.Methods {
 			generateServiceMethod(g, service, method)
 		}
+
+		generateServer(g, service)
+
+		generateUnimplementedServer(g, service)
+
+		generateServiceDesc(g, service)
+
+		generateRegister(g, service)
 	}
 	g.P()
 
@@ -47,8 +55,12 @@ func generateFile(gen *protogen.Plugin, file *protogen.File) *protogen.Generated
 func generateImports(g *protogen.GeneratedFile) {
 	g.P()
 	g.P(`import (`)
+	g.P(`    "context"`)
+	g.P()
 	g.P(`    "github.com/go-logr/logr"`)
+	g.P(`    "github.com/yugabyte/yb-tools/protoc-gen-ybrpc/pkg/dispatch"`)
 	g.P(`    "github.com/yugabyte/yb-tools/protoc-gen-ybrpc/pkg/message"`)
+	g.P(`    "google.golang.org/protobuf/proto"`)
 	g.P(`)`)
 	g.P()
 }
@@ -68,6 +80,7 @@ func generateService(g *protogen.GeneratedFile, service *protogen.Service) {
 
 func generateMethodSigniture(g *protogen.GeneratedFile, method *protogen.Method) {
 	g.P(method.GoName, "(request *", method.Input.GoIdent.GoName+")"+"(*"+method.Output.GoIdent.GoName+", error)")
+	g.P(method.GoName, "WithContext(ctx context.Context, request *", method.Input.GoIdent.GoName+")"+"(*"+method.Output.GoIdent.GoName+", error)")
 }
 
 func generateServiceImpl(g *protogen.GeneratedFile, service *protogen.Service) {
@@ -81,10 +94,15 @@ func generateServiceImpl(g *protogen.GeneratedFile, service *protogen.Service) {
 func generateServiceMethod(g *protogen.GeneratedFile, service *protogen.Service, method *protogen.Method) {
 	util.GenerateComments(g, method.Comments, method.Desc.Options().(*descriptorpb.MethodOptions).GetDeprecated())
 	g.P("func (s *" + service.GoName + "Impl)" + method.GoName + "(request *" + method.Input.GoIdent.GoName + ")" + "(*" + method.Output.GoIdent.GoName + ", error) {")
+	g.P("    return s.", method.GoName, "WithContext(context.Background(), request)")
+	g.P("}")
+	g.P()
+	util.GenerateComments(g, method.Comments, method.Desc.Options().(*descriptorpb.MethodOptions).GetDeprecated())
+	g.P("func (s *" + service.GoName + "Impl)" + method.GoName + "WithContext(ctx context.Context, request *" + method.Input.GoIdent.GoName + ")" + "(*" + method.Output.GoIdent.GoName + ", error) {")
 	g.P(`    s.Log.V(1).Info("sending RPC request", "service", "`, string(service.Desc.FullName()), `", "method", "`, string(method.Desc.Name()), `", "request", request)`)
 	g.P("    response := &" + method.Output.GoIdent.GoName + "{}")
 	g.P()
-	g.P(`    err := s.Messenger.SendMessage("`, string(service.Desc.FullName()), `", "`, string(method.Desc.Name()), `", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())`)
+	g.P(`    err := s.Messenger.SendMessage(ctx, "`, string(service.Desc.FullName()), `", "`, string(method.Desc.Name()), `", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())`)
 	g.P("    if err != nil {")
 	g.P("        return nil, err")
 	g.P("    }")
@@ -95,3 +113,73 @@ func generateServiceMethod(g *protogen.GeneratedFile, service *protogen.Service,
 	g.P("}")
 	g.P("")
 }
+
+func serverName(service *protogen.Service) string {
+	return service.GoName + "Server"
+}
+
+func serviceDescName(service *protogen.Service) string {
+	return service.GoName + "_ServiceDesc"
+}
+
+// generateServer generates the interface implemented by servers of the service.
+func generateServer(g *protogen.GeneratedFile, service *protogen.Service) {
+	g.P("// ", serverName(service), " is the server API for ", service.Desc.FullName(), ".")
+	g.P("type ", serverName(service), " interface {")
+	for _, method := range service.Methods {
+		g.P(method.GoName, "(ctx context.Context, request *", method.Input.GoIdent.GoName+")"+"(*"+method.Output.GoIdent.GoName+", error)")
+	}
+	g.P("}")
+	g.P()
+}
+
+// generateUnimplementedServer generates a type that servers can embed to
+// implement only some of the methods.
+func generateUnimplementedServer(g *protogen.GeneratedFile, service *protogen.Service) {
+	g.P("// Unimplemented", serverName(service), " can be embedded in implementations of ", serverName(service), " that only serve")
+	g.P("// some of the methods. The others fail with dispatch.ErrNoSuchMethod.")
+	g.P("type Unimplemented", serverName(service), " struct{}")
+	g.P()
+	for _, method := range service.Methods {
+		g.P("func (Unimplemented", serverName(service), ")", method.GoName, "(ctx context.Context, request *", method.Input.GoIdent.GoName+")"+"(*"+method.Output.GoIdent.GoName+", error) {")
+		g.P(`    return nil, dispatch.Unimplemented("`, string(service.Desc.FullName()), `", "`, string(method.Desc.Name()), `")`)
+		g.P("}")
+		g.P()
+	}
+}
+
+// generateServiceDesc generates the description used to dispatch calls by
+// method name to the server, with the request and response types of each method.
+func generateServiceDesc(g *protogen.GeneratedFile, service *protogen.Service) {
+	g.P("var ", serviceDescName(service), " = dispatch.ServiceDesc{")
+	g.P(`    ServiceName: "`, string(service.Desc.FullName()), `",`)
+	g.P("    HandlerType: (*", serverName(service), ")(nil),")
+	g.P("    Methods: []dispatch.MethodDesc{")
+	for _, method := range service.Methods {
+		g.P("        {")
+		g.P(`            MethodName: "`, string(method.Desc.Name()), `",`)
+		g.P("            NewRequest: func() proto.Message { return &", method.Input.GoIdent.GoName, "{} },")
+		g.P("            NewResponse: func() proto.Message { return &", method.Output.GoIdent.GoName, "{} },")
+		g.P("            Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {")
+		g.P("                return srv.(", serverName(service), ").", method.GoName, "(ctx, request.(*", method.Input.GoIdent.GoName, "))")
+		g.P("            },")
+		g.P("        },")
+	}
+	g.P("    },")
+	g.P("}")
+	g.P()
+}
+
+// generateRegister generates the helper that serves the service, and adds the
+// service description to the registry of all services.
+func generateRegister(g *protogen.GeneratedFile, service *protogen.Service) {
+	g.P("// Register", service.GoName, " serves ", service.Desc.FullName(), " with srv.")
+	g.P("func Register", service.GoName, "(r dispatch.Registrar, srv ", serverName(service), ") {")
+	g.P("    r.RegisterService(&", serviceDescName(service), ", srv)")
+	g.P("}")
+	g.P()
+	g.P("func init() {")
+	g.P("    dispatch.RegisterServiceDesc(&", serviceDescName(service), ")")
+	g.P("}")
+	g.P()
+}
@@ -34,21 +34,33 @@
 package cdc
 
 import (
+	"context"
+
 	"github.com/go-logr/logr"
+	"github.com/yugabyte/yb-tools/protoc-gen-ybrpc/pkg/dispatch"
 	"github.com/yugabyte/yb-tools/protoc-gen-ybrpc/pkg/message"
+	"google.golang.org/protobuf/proto"
 )
 
 // service: yb.cdc.CDCService
 // service: CDCService
 type CDCService interface {
 	CreateCDCStream(request *CreateCDCStreamRequestPB) (*CreateCDCStreamResponsePB, error)
+	CreateCDCStreamWithContext(ctx context.Context, request *CreateCDCStreamRequestPB) (*CreateCDCStreamResponsePB, error)
 	DeleteCDCStream(request *DeleteCDCStreamRequestPB) (*DeleteCDCStreamResponsePB, error)
+	DeleteCDCStreamWithContext(ctx context.Context, request *DeleteCDCStreamRequestPB) (*DeleteCDCStreamResponsePB, error)
 	ListTablets(request *ListTabletsRequestPB) (*ListTabletsResponsePB, error)
+	ListTabletsWithContext(ctx context.Context, request *ListTabletsRequestPB) (*ListTabletsResponsePB, error)
 	GetChanges(request *GetChangesRequestPB) (*GetChangesResponsePB, error)
+	GetChangesWithContext(ctx context.Context, request *GetChangesRequestPB) (*GetChangesResponsePB, error)
 	GetCheckpoint(request *GetCheckpointRequestPB) (*GetCheckpointResponsePB, error)
+	GetCheckpointWithContext(ctx context.Context, request *GetCheckpointRequestPB) (*GetCheckpointResponsePB, error)
 	UpdateCdcReplicatedIndex(request *UpdateCdcReplicatedIndexRequestPB) (*UpdateCdcReplicatedIndexResponsePB, error)
+	UpdateCdcReplicatedIndexWithContext(ctx context.Context, request *UpdateCdcReplicatedIndexRequestPB) (*UpdateCdcReplicatedIndexResponsePB, error)
 	BootstrapProducer(request *BootstrapProducerRequestPB) (*BootstrapProducerResponsePB, error)
+	BootstrapProducerWithContext(ctx context.Context, request *BootstrapProducerRequestPB) (*BootstrapProducerResponsePB, error)
 	GetLatestEntryOpId(request *GetLatestEntryOpIdRequestPB) (*GetLatestEntryOpIdResponsePB, error)
+	GetLatestEntryOpIdWithContext(ctx context.Context, request *GetLatestEntryOpIdRequestPB) (*GetLatestEntryOpIdResponsePB, error)
 }
 
 type CDCServiceImpl struct {
@@ -57,10 +69,14 @@ type CDCServiceImpl struct {
 }
 
 func (s *CDCServiceImpl) CreateCDCStream(request *CreateCDCStreamRequestPB) (*CreateCDCStreamResponsePB, error) {
+	return s.CreateCDCStreamWithContext(context.Background(), request)
+}
+
+func (s *CDCServiceImpl) CreateCDCStreamWithContext(ctx context.Context, request *CreateCDCStreamRequestPB) (*CreateCDCStreamResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.cdc.CDCService", "method", "CreateCDCStream", "request", request)
 	response := &CreateCDCStreamResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.cdc.CDCService", "CreateCDCStream", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.cdc.CDCService", "CreateCDCStream", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -71,10 +87,14 @@ func (s *CDCServiceImpl) CreateCDCStream(request *CreateCDCStreamRequestPB) (*Cr
 }
 
 func (s *CDCServiceImpl) DeleteCDCStream(request *DeleteCDCStreamRequestPB) (*DeleteCDCStreamResponsePB, error) {
+	return s.DeleteCDCStreamWithContext(context.Background(), request)
+}
+
+func (s *CDCServiceImpl) DeleteCDCStreamWithContext(ctx context.Context, request *DeleteCDCStreamRequestPB) (*DeleteCDCStreamResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.cdc.CDCService", "method", "DeleteCDCStream", "request", request)
 	response := &DeleteCDCStreamResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.cdc.CDCService", "DeleteCDCStream", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.cdc.CDCService", "DeleteCDCStream", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -85,10 +105,14 @@ func (s *CDCServiceImpl) DeleteCDCStream(request *DeleteCDCStreamRequestPB) (*De
 }
 
 func (s *CDCServiceImpl) ListTablets(request *ListTabletsRequestPB) (*ListTabletsResponsePB, error) {
+	return s.ListTabletsWithContext(context.Background(), request)
+}
+
+func (s *CDCServiceImpl) ListTabletsWithContext(ctx context.Context, request *ListTabletsRequestPB) (*ListTabletsResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.cdc.CDCService", "method", "ListTablets", "request", request)
 	response := &ListTabletsResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.cdc.CDCService", "ListTablets", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.cdc.CDCService", "ListTablets", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -99,10 +123,14 @@ func (s *CDCServiceImpl) ListTablets(request *ListTabletsRequestPB) (*ListTablet
 }
 
 func (s *CDCServiceImpl) GetChanges(request *GetChangesRequestPB) (*GetChangesResponsePB, error) {
+	return s.GetChangesWithContext(context.Background(), request)
+}
+
+func (s *CDCServiceImpl) GetChangesWithContext(ctx context.Context, request *GetChangesRequestPB) (*GetChangesResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.cdc.CDCService", "method", "GetChanges", "request", request)
 	response := &GetChangesResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.cdc.CDCService", "GetChanges", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.cdc.CDCService", "GetChanges", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -113,10 +141,14 @@ func (s *CDCServiceImpl) GetChanges(request *GetChangesRequestPB) (*GetChangesRe
 }
 
 func (s *CDCServiceImpl) GetCheckpoint(request *GetCheckpointRequestPB) (*GetCheckpointResponsePB, error) {
+	return s.GetCheckpointWithContext(context.Background(), request)
+}
+
+func (s *CDCServiceImpl) GetCheckpointWithContext(ctx context.Context, request *GetCheckpointRequestPB) (*GetCheckpointResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.cdc.CDCService", "method", "GetCheckpoint", "request", request)
 	response := &GetCheckpointResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.cdc.CDCService", "GetCheckpoint", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.cdc.CDCService", "GetCheckpoint", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -127,10 +159,14 @@ func (s *CDCServiceImpl) GetCheckpoint(request *GetCheckpointRequestPB) (*GetChe
 }
 
 func (s *CDCServiceImpl) UpdateCdcReplicatedIndex(request *UpdateCdcReplicatedIndexRequestPB) (*UpdateCdcReplicatedIndexResponsePB, error) {
+	return s.UpdateCdcReplicatedIndexWithContext(context.Background(), request)
+}
+
+func (s *CDCServiceImpl) UpdateCdcReplicatedIndexWithContext(ctx context.Context, request *UpdateCdcReplicatedIndexRequestPB) (*UpdateCdcReplicatedIndexResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.cdc.CDCService", "method", "UpdateCdcReplicatedIndex", "request", request)
 	response := &UpdateCdcReplicatedIndexResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.cdc.CDCService", "UpdateCdcReplicatedIndex", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.cdc.CDCService", "UpdateCdcReplicatedIndex", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -141,10 +177,14 @@ func (s *CDCServiceImpl) UpdateCdcReplicatedIndex(request *UpdateCdcReplicatedIn
 }
 
 func (s *CDCServiceImpl) BootstrapProducer(request *BootstrapProducerRequestPB) (*BootstrapProducerResponsePB, error) {
+	return s.BootstrapProducerWithContext(context.Background(), request)
+}
+
+func (s *CDCServiceImpl) BootstrapProducerWithContext(ctx context.Context, request *BootstrapProducerRequestPB) (*BootstrapProducerResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.cdc.CDCService", "method", "BootstrapProducer", "request", request)
 	response := &BootstrapProducerResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.cdc.CDCService", "BootstrapProducer", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.cdc.CDCService", "BootstrapProducer", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -155,10 +195,14 @@ func (s *CDCServiceImpl) BootstrapProducer(request *BootstrapProducerRequestPB)
 }
 
 func (s *CDCServiceImpl) GetLatestEntryOpId(request *GetLatestEntryOpIdRequestPB) (*GetLatestEntryOpIdResponsePB, error) {
+	return s.GetLatestEntryOpIdWithContext(context.Background(), request)
+}
+
+func (s *CDCServiceImpl) GetLatestEntryOpIdWithContext(ctx context.Context, request *GetLatestEntryOpIdRequestPB) (*GetLatestEntryOpIdResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.cdc.CDCService", "method", "GetLatestEntryOpId", "request", request)
 	response := &GetLatestEntryOpIdResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.cdc.CDCService", "GetLatestEntryOpId", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.cdc.CDCService", "GetLatestEntryOpId", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -167,3 +211,131 @@ func (s *CDCServiceImpl) GetLatestEntryOpId(request *GetLatestEntryOpIdRequestPB
 
 	return response, nil
 }
+
+// CDCServiceServer is the server API for yb.cdc.CDCService.
+type CDCServiceServer interface {
+	CreateCDCStream(ctx context.Context, request *CreateCDCStreamRequestPB) (*CreateCDCStreamResponsePB, error)
+	DeleteCDCStream(ctx context.Context, request *DeleteCDCStreamRequestPB) (*DeleteCDCStreamResponsePB, error)
+	ListTablets(ctx context.Context, request *ListTabletsRequestPB) (*ListTabletsResponsePB, error)
+	GetChanges(ctx context.Context, request *GetChangesRequestPB) (*GetChangesResponsePB, error)
+	GetCheckpoint(ctx context.Context, request *GetCheckpointRequestPB) (*GetCheckpointResponsePB, error)
+	UpdateCdcReplicatedIndex(ctx context.Context, request *UpdateCdcReplicatedIndexRequestPB) (*UpdateCdcReplicatedIndexResponsePB, error)
+	BootstrapProducer(ctx context.Context, request *BootstrapProducerRequestPB) (*BootstrapProducerResponsePB, error)
+	GetLatestEntryOpId(ctx context.Context, request *GetLatestEntryOpIdRequestPB) (*GetLatestEntryOpIdResponsePB, error)
+}
+
+// UnimplementedCDCServiceServer can be embedded in implementations of CDCServiceServer that only serve
+// some of the methods. The others fail with dispatch.ErrNoSuchMethod.
+type UnimplementedCDCServiceServer struct{}
+
+func (UnimplementedCDCServiceServer) CreateCDCStream(ctx context.Context, request *CreateCDCStreamRequestPB) (*CreateCDCStreamResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.cdc.CDCService", "CreateCDCStream")
+}
+
+func (UnimplementedCDCServiceServer) DeleteCDCStream(ctx context.Context, request *DeleteCDCStreamRequestPB) (*DeleteCDCStreamResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.cdc.CDCService", "DeleteCDCStream")
+}
+
+func (UnimplementedCDCServiceServer) ListTablets(ctx context.Context, request *ListTabletsRequestPB) (*ListTabletsResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.cdc.CDCService", "ListTablets")
+}
+
+func (UnimplementedCDCServiceServer) GetChanges(ctx context.Context, request *GetChangesRequestPB) (*GetChangesResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.cdc.CDCService", "GetChanges")
+}
+
+func (UnimplementedCDCServiceServer) GetCheckpoint(ctx context.Context, request *GetCheckpointRequestPB) (*GetCheckpointResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.cdc.CDCService", "GetCheckpoint")
+}
+
+func (UnimplementedCDCServiceServer) UpdateCdcReplicatedIndex(ctx context.Context, request *UpdateCdcReplicatedIndexRequestPB) (*UpdateCdcReplicatedIndexResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.cdc.CDCService", "UpdateCdcReplicatedIndex")
+}
+
+func (UnimplementedCDCServiceServer) BootstrapProducer(ctx context.Context, request *BootstrapProducerRequestPB) (*BootstrapProducerResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.cdc.CDCService", "BootstrapProducer")
+}
+
+func (UnimplementedCDCServiceServer) GetLatestEntryOpId(ctx context.Context, request *GetLatestEntryOpIdRequestPB) (*GetLatestEntryOpIdResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.cdc.CDCService", "GetLatestEntryOpId")
+}
+
+var CDCService_ServiceDesc = dispatch.ServiceDesc{
+	ServiceName: "yb.cdc.CDCService",
+	HandlerType: (*CDCServiceServer)(nil),
+	Methods: []dispatch.MethodDesc{
+		{
+			MethodName:  "CreateCDCStream",
+			NewRequest:  func() proto.Message { return &CreateCDCStreamRequestPB{} },
+			NewResponse: func() proto.Message { return &CreateCDCStreamResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(CDCServiceServer).CreateCDCStream(ctx, request.(*CreateCDCStreamRequestPB))
+			},
+		},
+		{
+			MethodName:  "DeleteCDCStream",
+			NewRequest:  func() proto.Message { return &DeleteCDCStreamRequestPB{} },
+			NewResponse: func() proto.Message { return &DeleteCDCStreamResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(CDCServiceServer).DeleteCDCStream(ctx, request.(*DeleteCDCStreamRequestPB))
+			},
+		},
+		{
+			MethodName:  "ListTablets",
+			NewRequest:  func() proto.Message { return &ListTabletsRequestPB{} },
+			NewResponse: func() proto.Message { return &ListTabletsResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(CDCServiceServer).ListTablets(ctx, request.(*ListTabletsRequestPB))
+			},
+		},
+		{
+			MethodName:  "GetChanges",
+			NewRequest:  func() proto.Message { return &GetChangesRequestPB{} },
+			NewResponse: func() proto.Message { return &GetChangesResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(CDCServiceServer).GetChanges(ctx, request.(*GetChangesRequestPB))
+			},
+		},
+		{
+			MethodName:  "GetCheckpoint",
+			NewRequest:  func() proto.Message { return &GetCheckpointRequestPB{} },
+			NewResponse: func() proto.Message { return &GetCheckpointResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(CDCServiceServer).GetCheckpoint(ctx, request.(*GetCheckpointRequestPB))
+			},
+		},
+		{
+			MethodName:  "UpdateCdcReplicatedIndex",
+			NewRequest:  func() proto.Message { return &UpdateCdcReplicatedIndexRequestPB{} },
+			NewResponse: func() proto.Message { return &UpdateCdcReplicatedIndexResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(CDCServiceServer).UpdateCdcReplicatedIndex(ctx, request.(*UpdateCdcReplicatedIndexRequestPB))
+			},
+		},
+		{
+			MethodName:  "BootstrapProducer",
+			NewRequest:  func() proto.Message { return &BootstrapProducerRequestPB{} },
+			NewResponse: func() proto.Message { return &BootstrapProducerResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(CDCServiceServer).BootstrapProducer(ctx, request.(*BootstrapProducerRequestPB))
+			},
+		},
+		{
+			MethodName:  "GetLatestEntryOpId",
+			NewRequest:  func() proto.Message { return &GetLatestEntryOpIdRequestPB{} },
+			NewResponse: func() proto.Message { return &GetLatestEntryOpIdResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(CDCServiceServer).GetLatestEntryOpId(ctx, request.(*GetLatestEntryOpIdRequestPB))
+			},
+		},
+	},
+}
+
+// RegisterCDCService serves yb.cdc.CDCService with srv.
+func RegisterCDCService(r dispatch.Registrar, srv CDCServiceServer) {
+	r.RegisterService(&CDCService_ServiceDesc, srv)
+}
+
+func init() {
+	dispatch.RegisterServiceDesc(&CDCService_ServiceDesc)
+}
@@ -0,0 +1,155 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// The following only applies to changes made to this file as part of YugaByte development.
+//
+// Portions Copyright (c) YugaByte, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied.  See the License for the specific language governing permissions and limitations
+// under the License.
+
+// Code generated by protoc-gen-ybrpc. DO NOT EDIT.
+
+package cdc
+
+import (
+	"context"
+
+	"github.com/yugabyte/yb-tools/protoc-gen-ybrpc/pkg/dispatch"
+	"github.com/yugabyte/yb-tools/protoc-gen-ybrpc/pkg/mock"
+)
+
+// MockCDCService is a CDCService for tests. Each method calls its stub function,
+// or fails with dispatch.ErrNoSuchMethod when the stub is not set, and every
+// call is recorded.
+type MockCDCService struct {
+	mock.Recorder
+
+	CreateCDCStreamFunc          func(ctx context.Context, request *CreateCDCStreamRequestPB) (*CreateCDCStreamResponsePB, error)
+	DeleteCDCStreamFunc          func(ctx context.Context, request *DeleteCDCStreamRequestPB) (*DeleteCDCStreamResponsePB, error)
+	ListTabletsFunc              func(ctx context.Context, request *ListTabletsRequestPB) (*ListTabletsResponsePB, error)
+	GetChangesFunc               func(ctx context.Context, request *GetChangesRequestPB) (*GetChangesResponsePB, error)
+	GetCheckpointFunc            func(ctx context.Context, request *GetCheckpointRequestPB) (*GetCheckpointResponsePB, error)
+	UpdateCdcReplicatedIndexFunc func(ctx context.Context, request *UpdateCdcReplicatedIndexRequestPB) (*UpdateCdcReplicatedIndexResponsePB, error)
+	BootstrapProducerFunc        func(ctx context.Context, request *BootstrapProducerRequestPB) (*BootstrapProducerResponsePB, error)
+	GetLatestEntryOpIdFunc       func(ctx context.Context, request *GetLatestEntryOpIdRequestPB) (*GetLatestEntryOpIdResponsePB, error)
+}
+
+var _ CDCService = &MockCDCService{}
+
+func (s *MockCDCService) CreateCDCStream(request *CreateCDCStreamRequestPB) (*CreateCDCStreamResponsePB, error) {
+	return s.CreateCDCStreamWithContext(context.Background(), request)
+}
+
+func (s *MockCDCService) CreateCDCStreamWithContext(ctx context.Context, request *CreateCDCStreamRequestPB) (*CreateCDCStreamResponsePB, error) {
+	s.Record("CreateCDCStream", request)
+	if s.CreateCDCStreamFunc == nil {
+		return nil, dispatch.Unimplemented("yb.cdc.CDCService", "CreateCDCStream")
+	}
+	return s.CreateCDCStreamFunc(ctx, request)
+}
+
+func (s *MockCDCService) DeleteCDCStream(request *DeleteCDCStreamRequestPB) (*DeleteCDCStreamResponsePB, error) {
+	return s.DeleteCDCStreamWithContext(context.Background(), request)
+}
+
+func (s *MockCDCService) DeleteCDCStreamWithContext(ctx context.Context, request *DeleteCDCStreamRequestPB) (*DeleteCDCStreamResponsePB, error) {
+	s.Record("DeleteCDCStream", request)
+	if s.DeleteCDCStreamFunc == nil {
+		return nil, dispatch.Unimplemented("yb.cdc.CDCService", "DeleteCDCStream")
+	}
+	return s.DeleteCDCStreamFunc(ctx, request)
+}
+
+func (s *MockCDCService) ListTablets(request *ListTabletsRequestPB) (*ListTabletsResponsePB, error) {
+	return s.ListTabletsWithContext(context.Background(), request)
+}
+
+func (s *MockCDCService) ListTabletsWithContext(ctx context.Context, request *ListTabletsRequestPB) (*ListTabletsResponsePB, error) {
+	s.Record("ListTablets", request)
+	if s.ListTabletsFunc == nil {
+		return nil, dispatch.Unimplemented("yb.cdc.CDCService", "ListTablets")
+	}
+	return s.ListTabletsFunc(ctx, request)
+}
+
+func (s *MockCDCService) GetChanges(request *GetChangesRequestPB) (*GetChangesResponsePB, error) {
+	return s.GetChangesWithContext(context.Background(), request)
+}
+
+func (s *MockCDCService) GetChangesWithContext(ctx context.Context, request *GetChangesRequestPB) (*GetChangesResponsePB, error) {
+	s.Record("GetChanges", request)
+	if s.GetChangesFunc == nil {
+		return nil, dispatch.Unimplemented("yb.cdc.CDCService", "GetChanges")
+	}
+	return s.GetChangesFunc(ctx, request)
+}
+
+func (s *MockCDCService) GetCheckpoint(request *GetCheckpointRequestPB) (*GetCheckpointResponsePB, error) {
+	return s.GetCheckpointWithContext(context.Background(), request)
+}
+
+func (s *MockCDCService) GetCheckpointWithContext(ctx context.Context, request *GetCheckpointRequestPB) (*GetCheckpointResponsePB, error) {
+	s.Record("GetCheckpoint", request)
+	if s.GetCheckpointFunc == nil {
+		return nil, dispatch.Unimplemented("yb.cdc.CDCService", "GetCheckpoint")
+	}
+	return s.GetCheckpointFunc(ctx, request)
+}
+
+func (s *MockCDCService) UpdateCdcReplicatedIndex(request *UpdateCdcReplicatedIndexRequestPB) (*UpdateCdcReplicatedIndexResponsePB, error) {
+	return s.UpdateCdcReplicatedIndexWithContext(context.Background(), request)
+}
+
+func (s *MockCDCService) UpdateCdcReplicatedIndexWithContext(ctx context.Context, request *UpdateCdcReplicatedIndexRequestPB) (*UpdateCdcReplicatedIndexResponsePB, error) {
+	s.Record("UpdateCdcReplicatedIndex", request)
+	if s.UpdateCdcReplicatedIndexFunc == nil {
+		return nil, dispatch.Unimplemented("yb.cdc.CDCService", "UpdateCdcReplicatedIndex")
+	}
+	return s.UpdateCdcReplicatedIndexFunc(ctx, request)
+}
+
+func (s *MockCDCService) BootstrapProducer(request *BootstrapProducerRequestPB) (*BootstrapProducerResponsePB, error) {
+	return s.BootstrapProducerWithContext(context.Background(), request)
+}
+
+func (s *MockCDCService) BootstrapProducerWithContext(ctx context.Context, request *BootstrapProducerRequestPB) (*BootstrapProducerResponsePB, error) {
+	s.Record("BootstrapProducer", request)
+	if s.BootstrapProducerFunc == nil {
+		return nil, dispatch.Unimplemented("yb.cdc.CDCService", "BootstrapProducer")
+	}
+	return s.BootstrapProducerFunc(ctx, request)
+}
+
+func (s *MockCDCService) GetLatestEntryOpId(request *GetLatestEntryOpIdRequestPB) (*GetLatestEntryOpIdResponsePB, error) {
+	return s.GetLatestEntryOpIdWithContext(context.Background(), request)
+}
+
+func (s *MockCDCService) GetLatestEntryOpIdWithContext(ctx context.Context, request *GetLatestEntryOpIdRequestPB) (*GetLatestEntryOpIdResponsePB, error) {
+	s.Record("GetLatestEntryOpId", request)
+	if s.GetLatestEntryOpIdFunc == nil {
+		return nil, dispatch.Unimplemented("yb.cdc.CDCService", "GetLatestEntryOpId")
+	}
+	return s.GetLatestEntryOpIdFunc(ctx, request)
+}
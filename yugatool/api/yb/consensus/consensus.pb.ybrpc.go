@@ -36,8 +36,12 @@
 package consensus
 
 import (
+	"context"
+
 	"github.com/go-logr/logr"
+	"github.com/yugabyte/yb-tools/protoc-gen-ybrpc/pkg/dispatch"
 	"github.com/yugabyte/yb-tools/protoc-gen-ybrpc/pkg/message"
+	"google.golang.org/protobuf/proto"
 )
 
 // service: yb.consensus.ConsensusService
@@ -46,15 +50,25 @@ import (
 
 type ConsensusService interface {
 	UpdateConsensus(request *ConsensusRequestPB) (*ConsensusResponsePB, error)
+	UpdateConsensusWithContext(ctx context.Context, request *ConsensusRequestPB) (*ConsensusResponsePB, error)
 	RequestConsensusVote(request *VoteRequestPB) (*VoteResponsePB, error)
+	RequestConsensusVoteWithContext(ctx context.Context, request *VoteRequestPB) (*VoteResponsePB, error)
 	ChangeConfig(request *ChangeConfigRequestPB) (*ChangeConfigResponsePB, error)
+	ChangeConfigWithContext(ctx context.Context, request *ChangeConfigRequestPB) (*ChangeConfigResponsePB, error)
 	GetNodeInstance(request *GetNodeInstanceRequestPB) (*GetNodeInstanceResponsePB, error)
+	GetNodeInstanceWithContext(ctx context.Context, request *GetNodeInstanceRequestPB) (*GetNodeInstanceResponsePB, error)
 	RunLeaderElection(request *RunLeaderElectionRequestPB) (*RunLeaderElectionResponsePB, error)
+	RunLeaderElectionWithContext(ctx context.Context, request *RunLeaderElectionRequestPB) (*RunLeaderElectionResponsePB, error)
 	LeaderElectionLost(request *LeaderElectionLostRequestPB) (*LeaderElectionLostResponsePB, error)
+	LeaderElectionLostWithContext(ctx context.Context, request *LeaderElectionLostRequestPB) (*LeaderElectionLostResponsePB, error)
 	LeaderStepDown(request *LeaderStepDownRequestPB) (*LeaderStepDownResponsePB, error)
+	LeaderStepDownWithContext(ctx context.Context, request *LeaderStepDownRequestPB) (*LeaderStepDownResponsePB, error)
 	GetLastOpId(request *GetLastOpIdRequestPB) (*GetLastOpIdResponsePB, error)
+	GetLastOpIdWithContext(ctx context.Context, request *GetLastOpIdRequestPB) (*GetLastOpIdResponsePB, error)
 	GetConsensusState(request *GetConsensusStateRequestPB) (*GetConsensusStateResponsePB, error)
+	GetConsensusStateWithContext(ctx context.Context, request *GetConsensusStateRequestPB) (*GetConsensusStateResponsePB, error)
 	StartRemoteBootstrap(request *StartRemoteBootstrapRequestPB) (*StartRemoteBootstrapResponsePB, error)
+	StartRemoteBootstrapWithContext(ctx context.Context, request *StartRemoteBootstrapRequestPB) (*StartRemoteBootstrapResponsePB, error)
 }
 
 type ConsensusServiceImpl struct {
@@ -65,10 +79,16 @@ type ConsensusServiceImpl struct {
 // Analogous to AppendEntries in Raft, but only used for followers.
 
 func (s *ConsensusServiceImpl) UpdateConsensus(request *ConsensusRequestPB) (*ConsensusResponsePB, error) {
+	return s.UpdateConsensusWithContext(context.Background(), request)
+}
+
+// Analogous to AppendEntries in Raft, but only used for followers.
+
+func (s *ConsensusServiceImpl) UpdateConsensusWithContext(ctx context.Context, request *ConsensusRequestPB) (*ConsensusResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.consensus.ConsensusService", "method", "UpdateConsensus", "request", request)
 	response := &ConsensusResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.consensus.ConsensusService", "UpdateConsensus", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.consensus.ConsensusService", "UpdateConsensus", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -81,10 +101,16 @@ func (s *ConsensusServiceImpl) UpdateConsensus(request *ConsensusRequestPB) (*Co
 // RequestVote() from Raft.
 
 func (s *ConsensusServiceImpl) RequestConsensusVote(request *VoteRequestPB) (*VoteResponsePB, error) {
+	return s.RequestConsensusVoteWithContext(context.Background(), request)
+}
+
+// RequestVote() from Raft.
+
+func (s *ConsensusServiceImpl) RequestConsensusVoteWithContext(ctx context.Context, request *VoteRequestPB) (*VoteResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.consensus.ConsensusService", "method", "RequestConsensusVote", "request", request)
 	response := &VoteResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.consensus.ConsensusService", "RequestConsensusVote", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.consensus.ConsensusService", "RequestConsensusVote", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -100,10 +126,19 @@ func (s *ConsensusServiceImpl) RequestConsensusVote(request *VoteRequestPB) (*Vo
 // An OK response means the operation was successful.
 
 func (s *ConsensusServiceImpl) ChangeConfig(request *ChangeConfigRequestPB) (*ChangeConfigResponsePB, error) {
+	return s.ChangeConfigWithContext(context.Background(), request)
+}
+
+// Implements all of the one-by-one config change operations, including
+// AddServer() and RemoveServer() from the Raft specification, as well as
+// an operation to change the role of a server between VOTER and PRE_VOTER.
+// An OK response means the operation was successful.
+
+func (s *ConsensusServiceImpl) ChangeConfigWithContext(ctx context.Context, request *ChangeConfigRequestPB) (*ChangeConfigResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.consensus.ConsensusService", "method", "ChangeConfig", "request", request)
 	response := &ChangeConfigResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.consensus.ConsensusService", "ChangeConfig", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.consensus.ConsensusService", "ChangeConfig", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -114,10 +149,14 @@ func (s *ConsensusServiceImpl) ChangeConfig(request *ChangeConfigRequestPB) (*Ch
 }
 
 func (s *ConsensusServiceImpl) GetNodeInstance(request *GetNodeInstanceRequestPB) (*GetNodeInstanceResponsePB, error) {
+	return s.GetNodeInstanceWithContext(context.Background(), request)
+}
+
+func (s *ConsensusServiceImpl) GetNodeInstanceWithContext(ctx context.Context, request *GetNodeInstanceRequestPB) (*GetNodeInstanceResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.consensus.ConsensusService", "method", "GetNodeInstance", "request", request)
 	response := &GetNodeInstanceResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.consensus.ConsensusService", "GetNodeInstance", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.consensus.ConsensusService", "GetNodeInstance", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -130,10 +169,16 @@ func (s *ConsensusServiceImpl) GetNodeInstance(request *GetNodeInstanceRequestPB
 // Force this node to run a leader election.
 
 func (s *ConsensusServiceImpl) RunLeaderElection(request *RunLeaderElectionRequestPB) (*RunLeaderElectionResponsePB, error) {
+	return s.RunLeaderElectionWithContext(context.Background(), request)
+}
+
+// Force this node to run a leader election.
+
+func (s *ConsensusServiceImpl) RunLeaderElectionWithContext(ctx context.Context, request *RunLeaderElectionRequestPB) (*RunLeaderElectionResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.consensus.ConsensusService", "method", "RunLeaderElection", "request", request)
 	response := &RunLeaderElectionResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.consensus.ConsensusService", "RunLeaderElection", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.consensus.ConsensusService", "RunLeaderElection", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -146,10 +191,16 @@ func (s *ConsensusServiceImpl) RunLeaderElection(request *RunLeaderElectionReque
 // Notify originator about lost election, so it could reset its timeout.
 
 func (s *ConsensusServiceImpl) LeaderElectionLost(request *LeaderElectionLostRequestPB) (*LeaderElectionLostResponsePB, error) {
+	return s.LeaderElectionLostWithContext(context.Background(), request)
+}
+
+// Notify originator about lost election, so it could reset its timeout.
+
+func (s *ConsensusServiceImpl) LeaderElectionLostWithContext(ctx context.Context, request *LeaderElectionLostRequestPB) (*LeaderElectionLostResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.consensus.ConsensusService", "method", "LeaderElectionLost", "request", request)
 	response := &LeaderElectionLostResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.consensus.ConsensusService", "LeaderElectionLost", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.consensus.ConsensusService", "LeaderElectionLost", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -162,10 +213,16 @@ func (s *ConsensusServiceImpl) LeaderElectionLost(request *LeaderElectionLostReq
 // Force this node to step down as leader.
 
 func (s *ConsensusServiceImpl) LeaderStepDown(request *LeaderStepDownRequestPB) (*LeaderStepDownResponsePB, error) {
+	return s.LeaderStepDownWithContext(context.Background(), request)
+}
+
+// Force this node to step down as leader.
+
+func (s *ConsensusServiceImpl) LeaderStepDownWithContext(ctx context.Context, request *LeaderStepDownRequestPB) (*LeaderStepDownResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.consensus.ConsensusService", "method", "LeaderStepDown", "request", request)
 	response := &LeaderStepDownResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.consensus.ConsensusService", "LeaderStepDown", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.consensus.ConsensusService", "LeaderStepDown", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -178,10 +235,16 @@ func (s *ConsensusServiceImpl) LeaderStepDown(request *LeaderStepDownRequestPB)
 // Get the latest committed or received opid on the server.
 
 func (s *ConsensusServiceImpl) GetLastOpId(request *GetLastOpIdRequestPB) (*GetLastOpIdResponsePB, error) {
+	return s.GetLastOpIdWithContext(context.Background(), request)
+}
+
+// Get the latest committed or received opid on the server.
+
+func (s *ConsensusServiceImpl) GetLastOpIdWithContext(ctx context.Context, request *GetLastOpIdRequestPB) (*GetLastOpIdResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.consensus.ConsensusService", "method", "GetLastOpId", "request", request)
 	response := &GetLastOpIdResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.consensus.ConsensusService", "GetLastOpId", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.consensus.ConsensusService", "GetLastOpId", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -194,10 +257,16 @@ func (s *ConsensusServiceImpl) GetLastOpId(request *GetLastOpIdRequestPB) (*GetL
 // Returns the committed Consensus state.
 
 func (s *ConsensusServiceImpl) GetConsensusState(request *GetConsensusStateRequestPB) (*GetConsensusStateResponsePB, error) {
+	return s.GetConsensusStateWithContext(context.Background(), request)
+}
+
+// Returns the committed Consensus state.
+
+func (s *ConsensusServiceImpl) GetConsensusStateWithContext(ctx context.Context, request *GetConsensusStateRequestPB) (*GetConsensusStateResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.consensus.ConsensusService", "method", "GetConsensusState", "request", request)
 	response := &GetConsensusStateResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.consensus.ConsensusService", "GetConsensusState", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.consensus.ConsensusService", "GetConsensusState", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -210,10 +279,16 @@ func (s *ConsensusServiceImpl) GetConsensusState(request *GetConsensusStateReque
 // Instruct this server to remotely bootstrap a tablet from another host.
 
 func (s *ConsensusServiceImpl) StartRemoteBootstrap(request *StartRemoteBootstrapRequestPB) (*StartRemoteBootstrapResponsePB, error) {
+	return s.StartRemoteBootstrapWithContext(context.Background(), request)
+}
+
+// Instruct this server to remotely bootstrap a tablet from another host.
+
+func (s *ConsensusServiceImpl) StartRemoteBootstrapWithContext(ctx context.Context, request *StartRemoteBootstrapRequestPB) (*StartRemoteBootstrapResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.consensus.ConsensusService", "method", "StartRemoteBootstrap", "request", request)
 	response := &StartRemoteBootstrapResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.consensus.ConsensusService", "StartRemoteBootstrap", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.consensus.ConsensusService", "StartRemoteBootstrap", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -222,3 +297,157 @@ func (s *ConsensusServiceImpl) StartRemoteBootstrap(request *StartRemoteBootstra
 
 	return response, nil
 }
+
+// ConsensusServiceServer is the server API for yb.consensus.ConsensusService.
+type ConsensusServiceServer interface {
+	UpdateConsensus(ctx context.Context, request *ConsensusRequestPB) (*ConsensusResponsePB, error)
+	RequestConsensusVote(ctx context.Context, request *VoteRequestPB) (*VoteResponsePB, error)
+	ChangeConfig(ctx context.Context, request *ChangeConfigRequestPB) (*ChangeConfigResponsePB, error)
+	GetNodeInstance(ctx context.Context, request *GetNodeInstanceRequestPB) (*GetNodeInstanceResponsePB, error)
+	RunLeaderElection(ctx context.Context, request *RunLeaderElectionRequestPB) (*RunLeaderElectionResponsePB, error)
+	LeaderElectionLost(ctx context.Context, request *LeaderElectionLostRequestPB) (*LeaderElectionLostResponsePB, error)
+	LeaderStepDown(ctx context.Context, request *LeaderStepDownRequestPB) (*LeaderStepDownResponsePB, error)
+	GetLastOpId(ctx context.Context, request *GetLastOpIdRequestPB) (*GetLastOpIdResponsePB, error)
+	GetConsensusState(ctx context.Context, request *GetConsensusStateRequestPB) (*GetConsensusStateResponsePB, error)
+	StartRemoteBootstrap(ctx context.Context, request *StartRemoteBootstrapRequestPB) (*StartRemoteBootstrapResponsePB, error)
+}
+
+// UnimplementedConsensusServiceServer can be embedded in implementations of ConsensusServiceServer that only serve
+// some of the methods. The others fail with dispatch.ErrNoSuchMethod.
+type UnimplementedConsensusServiceServer struct{}
+
+func (UnimplementedConsensusServiceServer) UpdateConsensus(ctx context.Context, request *ConsensusRequestPB) (*ConsensusResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.consensus.ConsensusService", "UpdateConsensus")
+}
+
+func (UnimplementedConsensusServiceServer) RequestConsensusVote(ctx context.Context, request *VoteRequestPB) (*VoteResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.consensus.ConsensusService", "RequestConsensusVote")
+}
+
+func (UnimplementedConsensusServiceServer) ChangeConfig(ctx context.Context, request *ChangeConfigRequestPB) (*ChangeConfigResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.consensus.ConsensusService", "ChangeConfig")
+}
+
+func (UnimplementedConsensusServiceServer) GetNodeInstance(ctx context.Context, request *GetNodeInstanceRequestPB) (*GetNodeInstanceResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.consensus.ConsensusService", "GetNodeInstance")
+}
+
+func (UnimplementedConsensusServiceServer) RunLeaderElection(ctx context.Context, request *RunLeaderElectionRequestPB) (*RunLeaderElectionResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.consensus.ConsensusService", "RunLeaderElection")
+}
+
+func (UnimplementedConsensusServiceServer) LeaderElectionLost(ctx context.Context, request *LeaderElectionLostRequestPB) (*LeaderElectionLostResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.consensus.ConsensusService", "LeaderElectionLost")
+}
+
+func (UnimplementedConsensusServiceServer) LeaderStepDown(ctx context.Context, request *LeaderStepDownRequestPB) (*LeaderStepDownResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.consensus.ConsensusService", "LeaderStepDown")
+}
+
+func (UnimplementedConsensusServiceServer) GetLastOpId(ctx context.Context, request *GetLastOpIdRequestPB) (*GetLastOpIdResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.consensus.ConsensusService", "GetLastOpId")
+}
+
+func (UnimplementedConsensusServiceServer) GetConsensusState(ctx context.Context, request *GetConsensusStateRequestPB) (*GetConsensusStateResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.consensus.ConsensusService", "GetConsensusState")
+}
+
+func (UnimplementedConsensusServiceServer) StartRemoteBootstrap(ctx context.Context, request *StartRemoteBootstrapRequestPB) (*StartRemoteBootstrapResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.consensus.ConsensusService", "StartRemoteBootstrap")
+}
+
+var ConsensusService_ServiceDesc = dispatch.ServiceDesc{
+	ServiceName: "yb.consensus.ConsensusService",
+	HandlerType: (*ConsensusServiceServer)(nil),
+	Methods: []dispatch.MethodDesc{
+		{
+			MethodName:  "UpdateConsensus",
+			NewRequest:  func() proto.Message { return &ConsensusRequestPB{} },
+			NewResponse: func() proto.Message { return &ConsensusResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(ConsensusServiceServer).UpdateConsensus(ctx, request.(*ConsensusRequestPB))
+			},
+		},
+		{
+			MethodName:  "RequestConsensusVote",
+			NewRequest:  func() proto.Message { return &VoteRequestPB{} },
+			NewResponse: func() proto.Message { return &VoteResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(ConsensusServiceServer).RequestConsensusVote(ctx, request.(*VoteRequestPB))
+			},
+		},
+		{
+			MethodName:  "ChangeConfig",
+			NewRequest:  func() proto.Message { return &ChangeConfigRequestPB{} },
+			NewResponse: func() proto.Message { return &ChangeConfigResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(ConsensusServiceServer).ChangeConfig(ctx, request.(*ChangeConfigRequestPB))
+			},
+		},
+		{
+			MethodName:  "GetNodeInstance",
+			NewRequest:  func() proto.Message { return &GetNodeInstanceRequestPB{} },
+			NewResponse: func() proto.Message { return &GetNodeInstanceResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(ConsensusServiceServer).GetNodeInstance(ctx, request.(*GetNodeInstanceRequestPB))
+			},
+		},
+		{
+			MethodName:  "RunLeaderElection",
+			NewRequest:  func() proto.Message { return &RunLeaderElectionRequestPB{} },
+			NewResponse: func() proto.Message { return &RunLeaderElectionResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(ConsensusServiceServer).RunLeaderElection(ctx, request.(*RunLeaderElectionRequestPB))
+			},
+		},
+		{
+			MethodName:  "LeaderElectionLost",
+			NewRequest:  func() proto.Message { return &LeaderElectionLostRequestPB{} },
+			NewResponse: func() proto.Message { return &LeaderElectionLostResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(ConsensusServiceServer).LeaderElectionLost(ctx, request.(*LeaderElectionLostRequestPB))
+			},
+		},
+		{
+			MethodName:  "LeaderStepDown",
+			NewRequest:  func() proto.Message { return &LeaderStepDownRequestPB{} },
+			NewResponse: func() proto.Message { return &LeaderStepDownResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(ConsensusServiceServer).LeaderStepDown(ctx, request.(*LeaderStepDownRequestPB))
+			},
+		},
+		{
+			MethodName:  "GetLastOpId",
+			NewRequest:  func() proto.Message { return &GetLastOpIdRequestPB{} },
+			NewResponse: func() proto.Message { return &GetLastOpIdResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(ConsensusServiceServer).GetLastOpId(ctx, request.(*GetLastOpIdRequestPB))
+			},
+		},
+		{
+			MethodName:  "GetConsensusState",
+			NewRequest:  func() proto.Message { return &GetConsensusStateRequestPB{} },
+			NewResponse: func() proto.Message { return &GetConsensusStateResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(ConsensusServiceServer).GetConsensusState(ctx, request.(*GetConsensusStateRequestPB))
+			},
+		},
+		{
+			MethodName:  "StartRemoteBootstrap",
+			NewRequest:  func() proto.Message { return &StartRemoteBootstrapRequestPB{} },
+			NewResponse: func() proto.Message { return &StartRemoteBootstrapResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(ConsensusServiceServer).StartRemoteBootstrap(ctx, request.(*StartRemoteBootstrapRequestPB))
+			},
+		},
+	},
+}
+
+// RegisterConsensusService serves yb.consensus.ConsensusService with srv.
+func RegisterConsensusService(r dispatch.Registrar, srv ConsensusServiceServer) {
+	r.RegisterService(&ConsensusService_ServiceDesc, srv)
+}
+
+func init() {
+	dispatch.RegisterServiceDesc(&ConsensusService_ServiceDesc)
+}
@@ -0,0 +1,183 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+//
+// The following only applies to changes made to this file as part of YugaByte development.
+//
+// Portions Copyright (c) YugaByte, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied.  See the License for the specific language governing permissions and limitations
+// under the License.
+//
+
+// Code generated by protoc-gen-ybrpc. DO NOT EDIT.
+
+package consensus
+
+import (
+	"context"
+
+	"github.com/yugabyte/yb-tools/protoc-gen-ybrpc/pkg/dispatch"
+	"github.com/yugabyte/yb-tools/protoc-gen-ybrpc/pkg/mock"
+)
+
+// MockConsensusService is a ConsensusService for tests. Each method calls its stub function,
+// or fails with dispatch.ErrNoSuchMethod when the stub is not set, and every
+// call is recorded.
+type MockConsensusService struct {
+	mock.Recorder
+
+	UpdateConsensusFunc      func(ctx context.Context, request *ConsensusRequestPB) (*ConsensusResponsePB, error)
+	RequestConsensusVoteFunc func(ctx context.Context, request *VoteRequestPB) (*VoteResponsePB, error)
+	ChangeConfigFunc         func(ctx context.Context, request *ChangeConfigRequestPB) (*ChangeConfigResponsePB, error)
+	GetNodeInstanceFunc      func(ctx context.Context, request *GetNodeInstanceRequestPB) (*GetNodeInstanceResponsePB, error)
+	RunLeaderElectionFunc    func(ctx context.Context, request *RunLeaderElectionRequestPB) (*RunLeaderElectionResponsePB, error)
+	LeaderElectionLostFunc   func(ctx context.Context, request *LeaderElectionLostRequestPB) (*LeaderElectionLostResponsePB, error)
+	LeaderStepDownFunc       func(ctx context.Context, request *LeaderStepDownRequestPB) (*LeaderStepDownResponsePB, error)
+	GetLastOpIdFunc          func(ctx context.Context, request *GetLastOpIdRequestPB) (*GetLastOpIdResponsePB, error)
+	GetConsensusStateFunc    func(ctx context.Context, request *GetConsensusStateRequestPB) (*GetConsensusStateResponsePB, error)
+	StartRemoteBootstrapFunc func(ctx context.Context, request *StartRemoteBootstrapRequestPB) (*StartRemoteBootstrapResponsePB, error)
+}
+
+var _ ConsensusService = &MockConsensusService{}
+
+func (s *MockConsensusService) UpdateConsensus(request *ConsensusRequestPB) (*ConsensusResponsePB, error) {
+	return s.UpdateConsensusWithContext(context.Background(), request)
+}
+
+func (s *MockConsensusService) UpdateConsensusWithContext(ctx context.Context, request *ConsensusRequestPB) (*ConsensusResponsePB, error) {
+	s.Record("UpdateConsensus", request)
+	if s.UpdateConsensusFunc == nil {
+		return nil, dispatch.Unimplemented("yb.consensus.ConsensusService", "UpdateConsensus")
+	}
+	return s.UpdateConsensusFunc(ctx, request)
+}
+
+func (s *MockConsensusService) RequestConsensusVote(request *VoteRequestPB) (*VoteResponsePB, error) {
+	return s.RequestConsensusVoteWithContext(context.Background(), request)
+}
+
+func (s *MockConsensusService) RequestConsensusVoteWithContext(ctx context.Context, request *VoteRequestPB) (*VoteResponsePB, error) {
+	s.Record("RequestConsensusVote", request)
+	if s.RequestConsensusVoteFunc == nil {
+		return nil, dispatch.Unimplemented("yb.consensus.ConsensusService", "RequestConsensusVote")
+	}
+	return s.RequestConsensusVoteFunc(ctx, request)
+}
+
+func (s *MockConsensusService) ChangeConfig(request *ChangeConfigRequestPB) (*ChangeConfigResponsePB, error) {
+	return s.ChangeConfigWithContext(context.Background(), request)
+}
+
+func (s *MockConsensusService) ChangeConfigWithContext(ctx context.Context, request *ChangeConfigRequestPB) (*ChangeConfigResponsePB, error) {
+	s.Record("ChangeConfig", request)
+	if s.ChangeConfigFunc == nil {
+		return nil, dispatch.Unimplemented("yb.consensus.ConsensusService", "ChangeConfig")
+	}
+	return s.ChangeConfigFunc(ctx, request)
+}
+
+func (s *MockConsensusService) GetNodeInstance(request *GetNodeInstanceRequestPB) (*GetNodeInstanceResponsePB, error) {
+	return s.GetNodeInstanceWithContext(context.Background(), request)
+}
+
+func (s *MockConsensusService) GetNodeInstanceWithContext(ctx context.Context, request *GetNodeInstanceRequestPB) (*GetNodeInstanceResponsePB, error) {
+	s.Record("GetNodeInstance", request)
+	if s.GetNodeInstanceFunc == nil {
+		return nil, dispatch.Unimplemented("yb.consensus.ConsensusService", "GetNodeInstance")
+	}
+	return s.GetNodeInstanceFunc(ctx, request)
+}
+
+func (s *MockConsensusService) RunLeaderElection(request *RunLeaderElectionRequestPB) (*RunLeaderElectionResponsePB, error) {
+	return s.RunLeaderElectionWithContext(context.Background(), request)
+}
+
+func (s *MockConsensusService) RunLeaderElectionWithContext(ctx context.Context, request *RunLeaderElectionRequestPB) (*RunLeaderElectionResponsePB, error) {
+	s.Record("RunLeaderElection", request)
+	if s.RunLeaderElectionFunc == nil {
+		return nil, dispatch.Unimplemented("yb.consensus.ConsensusService", "RunLeaderElection")
+	}
+	return s.RunLeaderElectionFunc(ctx, request)
+}
+
+func (s *MockConsensusService) LeaderElectionLost(request *LeaderElectionLostRequestPB) (*LeaderElectionLostResponsePB, error) {
+	return s.LeaderElectionLostWithContext(context.Background(), request)
+}
+
+func (s *MockConsensusService) LeaderElectionLostWithContext(ctx context.Context, request *LeaderElectionLostRequestPB) (*LeaderElectionLostResponsePB, error) {
+	s.Record("LeaderElectionLost", request)
+	if s.LeaderElectionLostFunc == nil {
+		return nil, dispatch.Unimplemented("yb.consensus.ConsensusService", "LeaderElectionLost")
+	}
+	return s.LeaderElectionLostFunc(ctx, request)
+}
+
+func (s *MockConsensusService) LeaderStepDown(request *LeaderStepDownRequestPB) (*LeaderStepDownResponsePB, error) {
+	return s.LeaderStepDownWithContext(context.Background(), request)
+}
+
+func (s *MockConsensusService) LeaderStepDownWithContext(ctx context.Context, request *LeaderStepDownRequestPB) (*LeaderStepDownResponsePB, error) {
+	s.Record("LeaderStepDown", request)
+	if s.LeaderStepDownFunc == nil {
+		return nil, dispatch.Unimplemented("yb.consensus.ConsensusService", "LeaderStepDown")
+	}
+	return s.LeaderStepDownFunc(ctx, request)
+}
+
+func (s *MockConsensusService) GetLastOpId(request *GetLastOpIdRequestPB) (*GetLastOpIdResponsePB, error) {
+	return s.GetLastOpIdWithContext(context.Background(), request)
+}
+
+func (s *MockConsensusService) GetLastOpIdWithContext(ctx context.Context, request *GetLastOpIdRequestPB) (*GetLastOpIdResponsePB, error) {
+	s.Record("GetLastOpId", request)
+	if s.GetLastOpIdFunc == nil {
+		return nil, dispatch.Unimplemented("yb.consensus.ConsensusService", "GetLastOpId")
+	}
+	return s.GetLastOpIdFunc(ctx, request)
+}
+
+func (s *MockConsensusService) GetConsensusState(request *GetConsensusStateRequestPB) (*GetConsensusStateResponsePB, error) {
+	return s.GetConsensusStateWithContext(context.Background(), request)
+}
+
+func (s *MockConsensusService) GetConsensusStateWithContext(ctx context.Context, request *GetConsensusStateRequestPB) (*GetConsensusStateResponsePB, error) {
+	s.Record("GetConsensusState", request)
+	if s.GetConsensusStateFunc == nil {
+		return nil, dispatch.Unimplemented("yb.consensus.ConsensusService", "GetConsensusState")
+	}
+	return s.GetConsensusStateFunc(ctx, request)
+}
+
+func (s *MockConsensusService) StartRemoteBootstrap(request *StartRemoteBootstrapRequestPB) (*StartRemoteBootstrapResponsePB, error) {
+	return s.StartRemoteBootstrapWithContext(context.Background(), request)
+}
+
+func (s *MockConsensusService) StartRemoteBootstrapWithContext(ctx context.Context, request *StartRemoteBootstrapRequestPB) (*StartRemoteBootstrapResponsePB, error) {
+	s.Record("StartRemoteBootstrap", request)
+	if s.StartRemoteBootstrapFunc == nil {
+		return nil, dispatch.Unimplemented("yb.consensus.ConsensusService", "StartRemoteBootstrap")
+	}
+	return s.StartRemoteBootstrapFunc(ctx, request)
+}
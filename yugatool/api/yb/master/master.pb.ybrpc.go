@@ -35,89 +35,169 @@
 package master
 
 import (
+	"context"
+
 	"github.com/go-logr/logr"
+	"github.com/yugabyte/yb-tools/protoc-gen-ybrpc/pkg/dispatch"
 	"github.com/yugabyte/yb-tools/protoc-gen-ybrpc/pkg/message"
+	"google.golang.org/protobuf/proto"
 )
 
 // service: yb.master.MasterService
 // service: MasterService
 type MasterService interface {
 	TSHeartbeat(request *TSHeartbeatRequestPB) (*TSHeartbeatResponsePB, error)
+	TSHeartbeatWithContext(ctx context.Context, request *TSHeartbeatRequestPB) (*TSHeartbeatResponsePB, error)
 	GetTabletLocations(request *GetTabletLocationsRequestPB) (*GetTabletLocationsResponsePB, error)
+	GetTabletLocationsWithContext(ctx context.Context, request *GetTabletLocationsRequestPB) (*GetTabletLocationsResponsePB, error)
 	CreateTable(request *CreateTableRequestPB) (*CreateTableResponsePB, error)
+	CreateTableWithContext(ctx context.Context, request *CreateTableRequestPB) (*CreateTableResponsePB, error)
 	IsCreateTableDone(request *IsCreateTableDoneRequestPB) (*IsCreateTableDoneResponsePB, error)
+	IsCreateTableDoneWithContext(ctx context.Context, request *IsCreateTableDoneRequestPB) (*IsCreateTableDoneResponsePB, error)
 	TruncateTable(request *TruncateTableRequestPB) (*TruncateTableResponsePB, error)
+	TruncateTableWithContext(ctx context.Context, request *TruncateTableRequestPB) (*TruncateTableResponsePB, error)
 	IsTruncateTableDone(request *IsTruncateTableDoneRequestPB) (*IsTruncateTableDoneResponsePB, error)
+	IsTruncateTableDoneWithContext(ctx context.Context, request *IsTruncateTableDoneRequestPB) (*IsTruncateTableDoneResponsePB, error)
 	BackfillIndex(request *BackfillIndexRequestPB) (*BackfillIndexResponsePB, error)
+	BackfillIndexWithContext(ctx context.Context, request *BackfillIndexRequestPB) (*BackfillIndexResponsePB, error)
 	LaunchBackfillIndexForTable(request *LaunchBackfillIndexForTableRequestPB) (*LaunchBackfillIndexForTableResponsePB, error)
+	LaunchBackfillIndexForTableWithContext(ctx context.Context, request *LaunchBackfillIndexForTableRequestPB) (*LaunchBackfillIndexForTableResponsePB, error)
 	DeleteTable(request *DeleteTableRequestPB) (*DeleteTableResponsePB, error)
+	DeleteTableWithContext(ctx context.Context, request *DeleteTableRequestPB) (*DeleteTableResponsePB, error)
 	IsDeleteTableDone(request *IsDeleteTableDoneRequestPB) (*IsDeleteTableDoneResponsePB, error)
+	IsDeleteTableDoneWithContext(ctx context.Context, request *IsDeleteTableDoneRequestPB) (*IsDeleteTableDoneResponsePB, error)
 	AlterTable(request *AlterTableRequestPB) (*AlterTableResponsePB, error)
+	AlterTableWithContext(ctx context.Context, request *AlterTableRequestPB) (*AlterTableResponsePB, error)
 	IsAlterTableDone(request *IsAlterTableDoneRequestPB) (*IsAlterTableDoneResponsePB, error)
+	IsAlterTableDoneWithContext(ctx context.Context, request *IsAlterTableDoneRequestPB) (*IsAlterTableDoneResponsePB, error)
 	ListTables(request *ListTablesRequestPB) (*ListTablesResponsePB, error)
+	ListTablesWithContext(ctx context.Context, request *ListTablesRequestPB) (*ListTablesResponsePB, error)
 	GetTableLocations(request *GetTableLocationsRequestPB) (*GetTableLocationsResponsePB, error)
+	GetTableLocationsWithContext(ctx context.Context, request *GetTableLocationsRequestPB) (*GetTableLocationsResponsePB, error)
 	GetTableSchema(request *GetTableSchemaRequestPB) (*GetTableSchemaResponsePB, error)
+	GetTableSchemaWithContext(ctx context.Context, request *GetTableSchemaRequestPB) (*GetTableSchemaResponsePB, error)
 	GetColocatedTabletSchema(request *GetColocatedTabletSchemaRequestPB) (*GetColocatedTabletSchemaResponsePB, error)
+	GetColocatedTabletSchemaWithContext(ctx context.Context, request *GetColocatedTabletSchemaRequestPB) (*GetColocatedTabletSchemaResponsePB, error)
 	CreateNamespace(request *CreateNamespaceRequestPB) (*CreateNamespaceResponsePB, error)
+	CreateNamespaceWithContext(ctx context.Context, request *CreateNamespaceRequestPB) (*CreateNamespaceResponsePB, error)
 	IsCreateNamespaceDone(request *IsCreateNamespaceDoneRequestPB) (*IsCreateNamespaceDoneResponsePB, error)
+	IsCreateNamespaceDoneWithContext(ctx context.Context, request *IsCreateNamespaceDoneRequestPB) (*IsCreateNamespaceDoneResponsePB, error)
 	DeleteNamespace(request *DeleteNamespaceRequestPB) (*DeleteNamespaceResponsePB, error)
+	DeleteNamespaceWithContext(ctx context.Context, request *DeleteNamespaceRequestPB) (*DeleteNamespaceResponsePB, error)
 	IsDeleteNamespaceDone(request *IsDeleteNamespaceDoneRequestPB) (*IsDeleteNamespaceDoneResponsePB, error)
+	IsDeleteNamespaceDoneWithContext(ctx context.Context, request *IsDeleteNamespaceDoneRequestPB) (*IsDeleteNamespaceDoneResponsePB, error)
 	AlterNamespace(request *AlterNamespaceRequestPB) (*AlterNamespaceResponsePB, error)
+	AlterNamespaceWithContext(ctx context.Context, request *AlterNamespaceRequestPB) (*AlterNamespaceResponsePB, error)
 	ListNamespaces(request *ListNamespacesRequestPB) (*ListNamespacesResponsePB, error)
+	ListNamespacesWithContext(ctx context.Context, request *ListNamespacesRequestPB) (*ListNamespacesResponsePB, error)
 	GetNamespaceInfo(request *GetNamespaceInfoRequestPB) (*GetNamespaceInfoResponsePB, error)
+	GetNamespaceInfoWithContext(ctx context.Context, request *GetNamespaceInfoRequestPB) (*GetNamespaceInfoResponsePB, error)
 	CreateTablegroup(request *CreateTablegroupRequestPB) (*CreateTablegroupResponsePB, error)
+	CreateTablegroupWithContext(ctx context.Context, request *CreateTablegroupRequestPB) (*CreateTablegroupResponsePB, error)
 	DeleteTablegroup(request *DeleteTablegroupRequestPB) (*DeleteTablegroupResponsePB, error)
+	DeleteTablegroupWithContext(ctx context.Context, request *DeleteTablegroupRequestPB) (*DeleteTablegroupResponsePB, error)
 	ListTablegroups(request *ListTablegroupsRequestPB) (*ListTablegroupsResponsePB, error)
+	ListTablegroupsWithContext(ctx context.Context, request *ListTablegroupsRequestPB) (*ListTablegroupsResponsePB, error)
 	ReservePgsqlOids(request *ReservePgsqlOidsRequestPB) (*ReservePgsqlOidsResponsePB, error)
+	ReservePgsqlOidsWithContext(ctx context.Context, request *ReservePgsqlOidsRequestPB) (*ReservePgsqlOidsResponsePB, error)
 	GetYsqlCatalogConfig(request *GetYsqlCatalogConfigRequestPB) (*GetYsqlCatalogConfigResponsePB, error)
+	GetYsqlCatalogConfigWithContext(ctx context.Context, request *GetYsqlCatalogConfigRequestPB) (*GetYsqlCatalogConfigResponsePB, error)
 	CreateRole(request *CreateRoleRequestPB) (*CreateRoleResponsePB, error)
+	CreateRoleWithContext(ctx context.Context, request *CreateRoleRequestPB) (*CreateRoleResponsePB, error)
 	AlterRole(request *AlterRoleRequestPB) (*AlterRoleResponsePB, error)
+	AlterRoleWithContext(ctx context.Context, request *AlterRoleRequestPB) (*AlterRoleResponsePB, error)
 	DeleteRole(request *DeleteRoleRequestPB) (*DeleteRoleResponsePB, error)
+	DeleteRoleWithContext(ctx context.Context, request *DeleteRoleRequestPB) (*DeleteRoleResponsePB, error)
 	GrantRevokeRole(request *GrantRevokeRoleRequestPB) (*GrantRevokeRoleResponsePB, error)
+	GrantRevokeRoleWithContext(ctx context.Context, request *GrantRevokeRoleRequestPB) (*GrantRevokeRoleResponsePB, error)
 	GrantRevokePermission(request *GrantRevokePermissionRequestPB) (*GrantRevokePermissionResponsePB, error)
+	GrantRevokePermissionWithContext(ctx context.Context, request *GrantRevokePermissionRequestPB) (*GrantRevokePermissionResponsePB, error)
 	GetPermissions(request *GetPermissionsRequestPB) (*GetPermissionsResponsePB, error)
+	GetPermissionsWithContext(ctx context.Context, request *GetPermissionsRequestPB) (*GetPermissionsResponsePB, error)
 	CreateUDType(request *CreateUDTypeRequestPB) (*CreateUDTypeResponsePB, error)
+	CreateUDTypeWithContext(ctx context.Context, request *CreateUDTypeRequestPB) (*CreateUDTypeResponsePB, error)
 	DeleteUDType(request *DeleteUDTypeRequestPB) (*DeleteUDTypeResponsePB, error)
+	DeleteUDTypeWithContext(ctx context.Context, request *DeleteUDTypeRequestPB) (*DeleteUDTypeResponsePB, error)
 	ListUDTypes(request *ListUDTypesRequestPB) (*ListUDTypesResponsePB, error)
+	ListUDTypesWithContext(ctx context.Context, request *ListUDTypesRequestPB) (*ListUDTypesResponsePB, error)
 	GetUDTypeInfo(request *GetUDTypeInfoRequestPB) (*GetUDTypeInfoResponsePB, error)
+	GetUDTypeInfoWithContext(ctx context.Context, request *GetUDTypeInfoRequestPB) (*GetUDTypeInfoResponsePB, error)
 	CreateCDCStream(request *CreateCDCStreamRequestPB) (*CreateCDCStreamResponsePB, error)
+	CreateCDCStreamWithContext(ctx context.Context, request *CreateCDCStreamRequestPB) (*CreateCDCStreamResponsePB, error)
 	DeleteCDCStream(request *DeleteCDCStreamRequestPB) (*DeleteCDCStreamResponsePB, error)
+	DeleteCDCStreamWithContext(ctx context.Context, request *DeleteCDCStreamRequestPB) (*DeleteCDCStreamResponsePB, error)
 	ListCDCStreams(request *ListCDCStreamsRequestPB) (*ListCDCStreamsResponsePB, error)
+	ListCDCStreamsWithContext(ctx context.Context, request *ListCDCStreamsRequestPB) (*ListCDCStreamsResponsePB, error)
 	GetCDCStream(request *GetCDCStreamRequestPB) (*GetCDCStreamResponsePB, error)
+	GetCDCStreamWithContext(ctx context.Context, request *GetCDCStreamRequestPB) (*GetCDCStreamResponsePB, error)
 	RedisConfigSet(request *RedisConfigSetRequestPB) (*RedisConfigSetResponsePB, error)
+	RedisConfigSetWithContext(ctx context.Context, request *RedisConfigSetRequestPB) (*RedisConfigSetResponsePB, error)
 	RedisConfigGet(request *RedisConfigGetRequestPB) (*RedisConfigGetResponsePB, error)
+	RedisConfigGetWithContext(ctx context.Context, request *RedisConfigGetRequestPB) (*RedisConfigGetResponsePB, error)
 	ListTabletServers(request *ListTabletServersRequestPB) (*ListTabletServersResponsePB, error)
+	ListTabletServersWithContext(ctx context.Context, request *ListTabletServersRequestPB) (*ListTabletServersResponsePB, error)
 	ListMasters(request *ListMastersRequestPB) (*ListMastersResponsePB, error)
+	ListMastersWithContext(ctx context.Context, request *ListMastersRequestPB) (*ListMastersResponsePB, error)
 	ListMasterRaftPeers(request *ListMasterRaftPeersRequestPB) (*ListMasterRaftPeersResponsePB, error)
+	ListMasterRaftPeersWithContext(ctx context.Context, request *ListMasterRaftPeersRequestPB) (*ListMasterRaftPeersResponsePB, error)
 	GetMasterRegistration(request *GetMasterRegistrationRequestPB) (*GetMasterRegistrationResponsePB, error)
+	GetMasterRegistrationWithContext(ctx context.Context, request *GetMasterRegistrationRequestPB) (*GetMasterRegistrationResponsePB, error)
 	IsMasterLeaderServiceReady(request *IsMasterLeaderReadyRequestPB) (*IsMasterLeaderReadyResponsePB, error)
+	IsMasterLeaderServiceReadyWithContext(ctx context.Context, request *IsMasterLeaderReadyRequestPB) (*IsMasterLeaderReadyResponsePB, error)
 	DumpState(request *DumpMasterStateRequestPB) (*DumpMasterStateResponsePB, error)
+	DumpStateWithContext(ctx context.Context, request *DumpMasterStateRequestPB) (*DumpMasterStateResponsePB, error)
 	ChangeLoadBalancerState(request *ChangeLoadBalancerStateRequestPB) (*ChangeLoadBalancerStateResponsePB, error)
+	ChangeLoadBalancerStateWithContext(ctx context.Context, request *ChangeLoadBalancerStateRequestPB) (*ChangeLoadBalancerStateResponsePB, error)
 	GetLoadBalancerState(request *GetLoadBalancerStateRequestPB) (*GetLoadBalancerStateResponsePB, error)
+	GetLoadBalancerStateWithContext(ctx context.Context, request *GetLoadBalancerStateRequestPB) (*GetLoadBalancerStateResponsePB, error)
 	RemovedMasterUpdate(request *RemovedMasterUpdateRequestPB) (*RemovedMasterUpdateResponsePB, error)
+	RemovedMasterUpdateWithContext(ctx context.Context, request *RemovedMasterUpdateRequestPB) (*RemovedMasterUpdateResponsePB, error)
 	SetPreferredZones(request *SetPreferredZonesRequestPB) (*SetPreferredZonesResponsePB, error)
+	SetPreferredZonesWithContext(ctx context.Context, request *SetPreferredZonesRequestPB) (*SetPreferredZonesResponsePB, error)
 	GetMasterClusterConfig(request *GetMasterClusterConfigRequestPB) (*GetMasterClusterConfigResponsePB, error)
+	GetMasterClusterConfigWithContext(ctx context.Context, request *GetMasterClusterConfigRequestPB) (*GetMasterClusterConfigResponsePB, error)
 	ChangeMasterClusterConfig(request *ChangeMasterClusterConfigRequestPB) (*ChangeMasterClusterConfigResponsePB, error)
+	ChangeMasterClusterConfigWithContext(ctx context.Context, request *ChangeMasterClusterConfigRequestPB) (*ChangeMasterClusterConfigResponsePB, error)
 	GetLoadMoveCompletion(request *GetLoadMovePercentRequestPB) (*GetLoadMovePercentResponsePB, error)
+	GetLoadMoveCompletionWithContext(ctx context.Context, request *GetLoadMovePercentRequestPB) (*GetLoadMovePercentResponsePB, error)
 	GetLeaderBlacklistCompletion(request *GetLeaderBlacklistPercentRequestPB) (*GetLoadMovePercentResponsePB, error)
+	GetLeaderBlacklistCompletionWithContext(ctx context.Context, request *GetLeaderBlacklistPercentRequestPB) (*GetLoadMovePercentResponsePB, error)
 	IsLoadBalanced(request *IsLoadBalancedRequestPB) (*IsLoadBalancedResponsePB, error)
+	IsLoadBalancedWithContext(ctx context.Context, request *IsLoadBalancedRequestPB) (*IsLoadBalancedResponsePB, error)
 	IsLoadBalancerIdle(request *IsLoadBalancerIdleRequestPB) (*IsLoadBalancerIdleResponsePB, error)
+	IsLoadBalancerIdleWithContext(ctx context.Context, request *IsLoadBalancerIdleRequestPB) (*IsLoadBalancerIdleResponsePB, error)
 	AreLeadersOnPreferredOnly(request *AreLeadersOnPreferredOnlyRequestPB) (*AreLeadersOnPreferredOnlyResponsePB, error)
+	AreLeadersOnPreferredOnlyWithContext(ctx context.Context, request *AreLeadersOnPreferredOnlyRequestPB) (*AreLeadersOnPreferredOnlyResponsePB, error)
 	FlushTables(request *FlushTablesRequestPB) (*FlushTablesResponsePB, error)
+	FlushTablesWithContext(ctx context.Context, request *FlushTablesRequestPB) (*FlushTablesResponsePB, error)
 	IsFlushTablesDone(request *IsFlushTablesDoneRequestPB) (*IsFlushTablesDoneResponsePB, error)
+	IsFlushTablesDoneWithContext(ctx context.Context, request *IsFlushTablesDoneRequestPB) (*IsFlushTablesDoneResponsePB, error)
 	IsInitDbDone(request *IsInitDbDoneRequestPB) (*IsInitDbDoneResponsePB, error)
+	IsInitDbDoneWithContext(ctx context.Context, request *IsInitDbDoneRequestPB) (*IsInitDbDoneResponsePB, error)
 	ChangeEncryptionInfo(request *ChangeEncryptionInfoRequestPB) (*ChangeEncryptionInfoResponsePB, error)
+	ChangeEncryptionInfoWithContext(ctx context.Context, request *ChangeEncryptionInfoRequestPB) (*ChangeEncryptionInfoResponsePB, error)
 	IsEncryptionEnabled(request *IsEncryptionEnabledRequestPB) (*IsEncryptionEnabledResponsePB, error)
+	IsEncryptionEnabledWithContext(ctx context.Context, request *IsEncryptionEnabledRequestPB) (*IsEncryptionEnabledResponsePB, error)
 	SetupUniverseReplication(request *SetupUniverseReplicationRequestPB) (*SetupUniverseReplicationResponsePB, error)
+	SetupUniverseReplicationWithContext(ctx context.Context, request *SetupUniverseReplicationRequestPB) (*SetupUniverseReplicationResponsePB, error)
 	DeleteUniverseReplication(request *DeleteUniverseReplicationRequestPB) (*DeleteUniverseReplicationResponsePB, error)
+	DeleteUniverseReplicationWithContext(ctx context.Context, request *DeleteUniverseReplicationRequestPB) (*DeleteUniverseReplicationResponsePB, error)
 	AlterUniverseReplication(request *AlterUniverseReplicationRequestPB) (*AlterUniverseReplicationResponsePB, error)
+	AlterUniverseReplicationWithContext(ctx context.Context, request *AlterUniverseReplicationRequestPB) (*AlterUniverseReplicationResponsePB, error)
 	SetUniverseReplicationEnabled(request *SetUniverseReplicationEnabledRequestPB) (*SetUniverseReplicationEnabledResponsePB, error)
+	SetUniverseReplicationEnabledWithContext(ctx context.Context, request *SetUniverseReplicationEnabledRequestPB) (*SetUniverseReplicationEnabledResponsePB, error)
 	GetUniverseReplication(request *GetUniverseReplicationRequestPB) (*GetUniverseReplicationResponsePB, error)
+	GetUniverseReplicationWithContext(ctx context.Context, request *GetUniverseReplicationRequestPB) (*GetUniverseReplicationResponsePB, error)
 	AddUniverseKeys(request *AddUniverseKeysRequestPB) (*AddUniverseKeysResponsePB, error)
+	AddUniverseKeysWithContext(ctx context.Context, request *AddUniverseKeysRequestPB) (*AddUniverseKeysResponsePB, error)
 	GetUniverseKeyRegistry(request *GetUniverseKeyRegistryRequestPB) (*GetUniverseKeyRegistryResponsePB, error)
+	GetUniverseKeyRegistryWithContext(ctx context.Context, request *GetUniverseKeyRegistryRequestPB) (*GetUniverseKeyRegistryResponsePB, error)
 	HasUniverseKeyInMemory(request *HasUniverseKeyInMemoryRequestPB) (*HasUniverseKeyInMemoryResponsePB, error)
+	HasUniverseKeyInMemoryWithContext(ctx context.Context, request *HasUniverseKeyInMemoryRequestPB) (*HasUniverseKeyInMemoryResponsePB, error)
 	SplitTablet(request *SplitTabletRequestPB) (*SplitTabletResponsePB, error)
+	SplitTabletWithContext(ctx context.Context, request *SplitTabletRequestPB) (*SplitTabletResponsePB, error)
 	DeleteTablet(request *DeleteTabletRequestPB) (*DeleteTabletResponsePB, error)
+	DeleteTabletWithContext(ctx context.Context, request *DeleteTabletRequestPB) (*DeleteTabletResponsePB, error)
 }
 
 type MasterServiceImpl struct {
@@ -128,10 +208,16 @@ type MasterServiceImpl struct {
 // TS->Master RPCs
 
 func (s *MasterServiceImpl) TSHeartbeat(request *TSHeartbeatRequestPB) (*TSHeartbeatResponsePB, error) {
+	return s.TSHeartbeatWithContext(context.Background(), request)
+}
+
+// TS->Master RPCs
+
+func (s *MasterServiceImpl) TSHeartbeatWithContext(ctx context.Context, request *TSHeartbeatRequestPB) (*TSHeartbeatResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "TSHeartbeat", "request", request)
 	response := &TSHeartbeatResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "TSHeartbeat", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "TSHeartbeat", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -144,10 +230,16 @@ func (s *MasterServiceImpl) TSHeartbeat(request *TSHeartbeatRequestPB) (*TSHeart
 // Client->Master RPCs
 
 func (s *MasterServiceImpl) GetTabletLocations(request *GetTabletLocationsRequestPB) (*GetTabletLocationsResponsePB, error) {
+	return s.GetTabletLocationsWithContext(context.Background(), request)
+}
+
+// Client->Master RPCs
+
+func (s *MasterServiceImpl) GetTabletLocationsWithContext(ctx context.Context, request *GetTabletLocationsRequestPB) (*GetTabletLocationsResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "GetTabletLocations", "request", request)
 	response := &GetTabletLocationsResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "GetTabletLocations", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "GetTabletLocations", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -158,10 +250,14 @@ func (s *MasterServiceImpl) GetTabletLocations(request *GetTabletLocationsReques
 }
 
 func (s *MasterServiceImpl) CreateTable(request *CreateTableRequestPB) (*CreateTableResponsePB, error) {
+	return s.CreateTableWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) CreateTableWithContext(ctx context.Context, request *CreateTableRequestPB) (*CreateTableResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "CreateTable", "request", request)
 	response := &CreateTableResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "CreateTable", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "CreateTable", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -172,10 +268,14 @@ func (s *MasterServiceImpl) CreateTable(request *CreateTableRequestPB) (*CreateT
 }
 
 func (s *MasterServiceImpl) IsCreateTableDone(request *IsCreateTableDoneRequestPB) (*IsCreateTableDoneResponsePB, error) {
+	return s.IsCreateTableDoneWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) IsCreateTableDoneWithContext(ctx context.Context, request *IsCreateTableDoneRequestPB) (*IsCreateTableDoneResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "IsCreateTableDone", "request", request)
 	response := &IsCreateTableDoneResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "IsCreateTableDone", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "IsCreateTableDone", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -186,10 +286,14 @@ func (s *MasterServiceImpl) IsCreateTableDone(request *IsCreateTableDoneRequestP
 }
 
 func (s *MasterServiceImpl) TruncateTable(request *TruncateTableRequestPB) (*TruncateTableResponsePB, error) {
+	return s.TruncateTableWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) TruncateTableWithContext(ctx context.Context, request *TruncateTableRequestPB) (*TruncateTableResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "TruncateTable", "request", request)
 	response := &TruncateTableResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "TruncateTable", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "TruncateTable", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -200,10 +304,14 @@ func (s *MasterServiceImpl) TruncateTable(request *TruncateTableRequestPB) (*Tru
 }
 
 func (s *MasterServiceImpl) IsTruncateTableDone(request *IsTruncateTableDoneRequestPB) (*IsTruncateTableDoneResponsePB, error) {
+	return s.IsTruncateTableDoneWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) IsTruncateTableDoneWithContext(ctx context.Context, request *IsTruncateTableDoneRequestPB) (*IsTruncateTableDoneResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "IsTruncateTableDone", "request", request)
 	response := &IsTruncateTableDoneResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "IsTruncateTableDone", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "IsTruncateTableDone", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -214,10 +322,14 @@ func (s *MasterServiceImpl) IsTruncateTableDone(request *IsTruncateTableDoneRequ
 }
 
 func (s *MasterServiceImpl) BackfillIndex(request *BackfillIndexRequestPB) (*BackfillIndexResponsePB, error) {
+	return s.BackfillIndexWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) BackfillIndexWithContext(ctx context.Context, request *BackfillIndexRequestPB) (*BackfillIndexResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "BackfillIndex", "request", request)
 	response := &BackfillIndexResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "BackfillIndex", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "BackfillIndex", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -228,10 +340,14 @@ func (s *MasterServiceImpl) BackfillIndex(request *BackfillIndexRequestPB) (*Bac
 }
 
 func (s *MasterServiceImpl) LaunchBackfillIndexForTable(request *LaunchBackfillIndexForTableRequestPB) (*LaunchBackfillIndexForTableResponsePB, error) {
+	return s.LaunchBackfillIndexForTableWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) LaunchBackfillIndexForTableWithContext(ctx context.Context, request *LaunchBackfillIndexForTableRequestPB) (*LaunchBackfillIndexForTableResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "LaunchBackfillIndexForTable", "request", request)
 	response := &LaunchBackfillIndexForTableResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "LaunchBackfillIndexForTable", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "LaunchBackfillIndexForTable", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -242,10 +358,14 @@ func (s *MasterServiceImpl) LaunchBackfillIndexForTable(request *LaunchBackfillI
 }
 
 func (s *MasterServiceImpl) DeleteTable(request *DeleteTableRequestPB) (*DeleteTableResponsePB, error) {
+	return s.DeleteTableWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) DeleteTableWithContext(ctx context.Context, request *DeleteTableRequestPB) (*DeleteTableResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "DeleteTable", "request", request)
 	response := &DeleteTableResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "DeleteTable", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "DeleteTable", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -256,10 +376,14 @@ func (s *MasterServiceImpl) DeleteTable(request *DeleteTableRequestPB) (*DeleteT
 }
 
 func (s *MasterServiceImpl) IsDeleteTableDone(request *IsDeleteTableDoneRequestPB) (*IsDeleteTableDoneResponsePB, error) {
+	return s.IsDeleteTableDoneWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) IsDeleteTableDoneWithContext(ctx context.Context, request *IsDeleteTableDoneRequestPB) (*IsDeleteTableDoneResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "IsDeleteTableDone", "request", request)
 	response := &IsDeleteTableDoneResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "IsDeleteTableDone", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "IsDeleteTableDone", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -270,10 +394,14 @@ func (s *MasterServiceImpl) IsDeleteTableDone(request *IsDeleteTableDoneRequestP
 }
 
 func (s *MasterServiceImpl) AlterTable(request *AlterTableRequestPB) (*AlterTableResponsePB, error) {
+	return s.AlterTableWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) AlterTableWithContext(ctx context.Context, request *AlterTableRequestPB) (*AlterTableResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "AlterTable", "request", request)
 	response := &AlterTableResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "AlterTable", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "AlterTable", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -284,10 +412,14 @@ func (s *MasterServiceImpl) AlterTable(request *AlterTableRequestPB) (*AlterTabl
 }
 
 func (s *MasterServiceImpl) IsAlterTableDone(request *IsAlterTableDoneRequestPB) (*IsAlterTableDoneResponsePB, error) {
+	return s.IsAlterTableDoneWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) IsAlterTableDoneWithContext(ctx context.Context, request *IsAlterTableDoneRequestPB) (*IsAlterTableDoneResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "IsAlterTableDone", "request", request)
 	response := &IsAlterTableDoneResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "IsAlterTableDone", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "IsAlterTableDone", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -298,10 +430,14 @@ func (s *MasterServiceImpl) IsAlterTableDone(request *IsAlterTableDoneRequestPB)
 }
 
 func (s *MasterServiceImpl) ListTables(request *ListTablesRequestPB) (*ListTablesResponsePB, error) {
+	return s.ListTablesWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) ListTablesWithContext(ctx context.Context, request *ListTablesRequestPB) (*ListTablesResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "ListTables", "request", request)
 	response := &ListTablesResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "ListTables", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "ListTables", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -312,10 +448,14 @@ func (s *MasterServiceImpl) ListTables(request *ListTablesRequestPB) (*ListTable
 }
 
 func (s *MasterServiceImpl) GetTableLocations(request *GetTableLocationsRequestPB) (*GetTableLocationsResponsePB, error) {
+	return s.GetTableLocationsWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) GetTableLocationsWithContext(ctx context.Context, request *GetTableLocationsRequestPB) (*GetTableLocationsResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "GetTableLocations", "request", request)
 	response := &GetTableLocationsResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "GetTableLocations", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "GetTableLocations", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -326,10 +466,14 @@ func (s *MasterServiceImpl) GetTableLocations(request *GetTableLocationsRequestP
 }
 
 func (s *MasterServiceImpl) GetTableSchema(request *GetTableSchemaRequestPB) (*GetTableSchemaResponsePB, error) {
+	return s.GetTableSchemaWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) GetTableSchemaWithContext(ctx context.Context, request *GetTableSchemaRequestPB) (*GetTableSchemaResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "GetTableSchema", "request", request)
 	response := &GetTableSchemaResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "GetTableSchema", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "GetTableSchema", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -340,10 +484,14 @@ func (s *MasterServiceImpl) GetTableSchema(request *GetTableSchemaRequestPB) (*G
 }
 
 func (s *MasterServiceImpl) GetColocatedTabletSchema(request *GetColocatedTabletSchemaRequestPB) (*GetColocatedTabletSchemaResponsePB, error) {
+	return s.GetColocatedTabletSchemaWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) GetColocatedTabletSchemaWithContext(ctx context.Context, request *GetColocatedTabletSchemaRequestPB) (*GetColocatedTabletSchemaResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "GetColocatedTabletSchema", "request", request)
 	response := &GetColocatedTabletSchemaResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "GetColocatedTabletSchema", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "GetColocatedTabletSchema", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -354,10 +502,14 @@ func (s *MasterServiceImpl) GetColocatedTabletSchema(request *GetColocatedTablet
 }
 
 func (s *MasterServiceImpl) CreateNamespace(request *CreateNamespaceRequestPB) (*CreateNamespaceResponsePB, error) {
+	return s.CreateNamespaceWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) CreateNamespaceWithContext(ctx context.Context, request *CreateNamespaceRequestPB) (*CreateNamespaceResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "CreateNamespace", "request", request)
 	response := &CreateNamespaceResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "CreateNamespace", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "CreateNamespace", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -368,10 +520,14 @@ func (s *MasterServiceImpl) CreateNamespace(request *CreateNamespaceRequestPB) (
 }
 
 func (s *MasterServiceImpl) IsCreateNamespaceDone(request *IsCreateNamespaceDoneRequestPB) (*IsCreateNamespaceDoneResponsePB, error) {
+	return s.IsCreateNamespaceDoneWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) IsCreateNamespaceDoneWithContext(ctx context.Context, request *IsCreateNamespaceDoneRequestPB) (*IsCreateNamespaceDoneResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "IsCreateNamespaceDone", "request", request)
 	response := &IsCreateNamespaceDoneResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "IsCreateNamespaceDone", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "IsCreateNamespaceDone", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -382,10 +538,14 @@ func (s *MasterServiceImpl) IsCreateNamespaceDone(request *IsCreateNamespaceDone
 }
 
 func (s *MasterServiceImpl) DeleteNamespace(request *DeleteNamespaceRequestPB) (*DeleteNamespaceResponsePB, error) {
+	return s.DeleteNamespaceWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) DeleteNamespaceWithContext(ctx context.Context, request *DeleteNamespaceRequestPB) (*DeleteNamespaceResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "DeleteNamespace", "request", request)
 	response := &DeleteNamespaceResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "DeleteNamespace", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "DeleteNamespace", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -396,10 +556,14 @@ func (s *MasterServiceImpl) DeleteNamespace(request *DeleteNamespaceRequestPB) (
 }
 
 func (s *MasterServiceImpl) IsDeleteNamespaceDone(request *IsDeleteNamespaceDoneRequestPB) (*IsDeleteNamespaceDoneResponsePB, error) {
+	return s.IsDeleteNamespaceDoneWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) IsDeleteNamespaceDoneWithContext(ctx context.Context, request *IsDeleteNamespaceDoneRequestPB) (*IsDeleteNamespaceDoneResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "IsDeleteNamespaceDone", "request", request)
 	response := &IsDeleteNamespaceDoneResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "IsDeleteNamespaceDone", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "IsDeleteNamespaceDone", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -410,10 +574,14 @@ func (s *MasterServiceImpl) IsDeleteNamespaceDone(request *IsDeleteNamespaceDone
 }
 
 func (s *MasterServiceImpl) AlterNamespace(request *AlterNamespaceRequestPB) (*AlterNamespaceResponsePB, error) {
+	return s.AlterNamespaceWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) AlterNamespaceWithContext(ctx context.Context, request *AlterNamespaceRequestPB) (*AlterNamespaceResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "AlterNamespace", "request", request)
 	response := &AlterNamespaceResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "AlterNamespace", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "AlterNamespace", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -424,10 +592,14 @@ func (s *MasterServiceImpl) AlterNamespace(request *AlterNamespaceRequestPB) (*A
 }
 
 func (s *MasterServiceImpl) ListNamespaces(request *ListNamespacesRequestPB) (*ListNamespacesResponsePB, error) {
+	return s.ListNamespacesWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) ListNamespacesWithContext(ctx context.Context, request *ListNamespacesRequestPB) (*ListNamespacesResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "ListNamespaces", "request", request)
 	response := &ListNamespacesResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "ListNamespaces", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "ListNamespaces", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -438,10 +610,14 @@ func (s *MasterServiceImpl) ListNamespaces(request *ListNamespacesRequestPB) (*L
 }
 
 func (s *MasterServiceImpl) GetNamespaceInfo(request *GetNamespaceInfoRequestPB) (*GetNamespaceInfoResponsePB, error) {
+	return s.GetNamespaceInfoWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) GetNamespaceInfoWithContext(ctx context.Context, request *GetNamespaceInfoRequestPB) (*GetNamespaceInfoResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "GetNamespaceInfo", "request", request)
 	response := &GetNamespaceInfoResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "GetNamespaceInfo", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "GetNamespaceInfo", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -454,10 +630,16 @@ func (s *MasterServiceImpl) GetNamespaceInfo(request *GetNamespaceInfoRequestPB)
 // For Tablegroup:
 
 func (s *MasterServiceImpl) CreateTablegroup(request *CreateTablegroupRequestPB) (*CreateTablegroupResponsePB, error) {
+	return s.CreateTablegroupWithContext(context.Background(), request)
+}
+
+// For Tablegroup:
+
+func (s *MasterServiceImpl) CreateTablegroupWithContext(ctx context.Context, request *CreateTablegroupRequestPB) (*CreateTablegroupResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "CreateTablegroup", "request", request)
 	response := &CreateTablegroupResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "CreateTablegroup", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "CreateTablegroup", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -468,10 +650,14 @@ func (s *MasterServiceImpl) CreateTablegroup(request *CreateTablegroupRequestPB)
 }
 
 func (s *MasterServiceImpl) DeleteTablegroup(request *DeleteTablegroupRequestPB) (*DeleteTablegroupResponsePB, error) {
+	return s.DeleteTablegroupWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) DeleteTablegroupWithContext(ctx context.Context, request *DeleteTablegroupRequestPB) (*DeleteTablegroupResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "DeleteTablegroup", "request", request)
 	response := &DeleteTablegroupResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "DeleteTablegroup", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "DeleteTablegroup", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -482,10 +668,14 @@ func (s *MasterServiceImpl) DeleteTablegroup(request *DeleteTablegroupRequestPB)
 }
 
 func (s *MasterServiceImpl) ListTablegroups(request *ListTablegroupsRequestPB) (*ListTablegroupsResponsePB, error) {
+	return s.ListTablegroupsWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) ListTablegroupsWithContext(ctx context.Context, request *ListTablegroupsRequestPB) (*ListTablegroupsResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "ListTablegroups", "request", request)
 	response := &ListTablegroupsResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "ListTablegroups", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "ListTablegroups", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -498,10 +688,16 @@ func (s *MasterServiceImpl) ListTablegroups(request *ListTablegroupsRequestPB) (
 // For Postgres:
 
 func (s *MasterServiceImpl) ReservePgsqlOids(request *ReservePgsqlOidsRequestPB) (*ReservePgsqlOidsResponsePB, error) {
+	return s.ReservePgsqlOidsWithContext(context.Background(), request)
+}
+
+// For Postgres:
+
+func (s *MasterServiceImpl) ReservePgsqlOidsWithContext(ctx context.Context, request *ReservePgsqlOidsRequestPB) (*ReservePgsqlOidsResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "ReservePgsqlOids", "request", request)
 	response := &ReservePgsqlOidsResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "ReservePgsqlOids", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "ReservePgsqlOids", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -512,10 +708,14 @@ func (s *MasterServiceImpl) ReservePgsqlOids(request *ReservePgsqlOidsRequestPB)
 }
 
 func (s *MasterServiceImpl) GetYsqlCatalogConfig(request *GetYsqlCatalogConfigRequestPB) (*GetYsqlCatalogConfigResponsePB, error) {
+	return s.GetYsqlCatalogConfigWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) GetYsqlCatalogConfigWithContext(ctx context.Context, request *GetYsqlCatalogConfigRequestPB) (*GetYsqlCatalogConfigResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "GetYsqlCatalogConfig", "request", request)
 	response := &GetYsqlCatalogConfigResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "GetYsqlCatalogConfig", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "GetYsqlCatalogConfig", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -528,10 +728,16 @@ func (s *MasterServiceImpl) GetYsqlCatalogConfig(request *GetYsqlCatalogConfigRe
 //  Authentication and Authorization.
 
 func (s *MasterServiceImpl) CreateRole(request *CreateRoleRequestPB) (*CreateRoleResponsePB, error) {
+	return s.CreateRoleWithContext(context.Background(), request)
+}
+
+//  Authentication and Authorization.
+
+func (s *MasterServiceImpl) CreateRoleWithContext(ctx context.Context, request *CreateRoleRequestPB) (*CreateRoleResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "CreateRole", "request", request)
 	response := &CreateRoleResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "CreateRole", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "CreateRole", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -542,10 +748,14 @@ func (s *MasterServiceImpl) CreateRole(request *CreateRoleRequestPB) (*CreateRol
 }
 
 func (s *MasterServiceImpl) AlterRole(request *AlterRoleRequestPB) (*AlterRoleResponsePB, error) {
+	return s.AlterRoleWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) AlterRoleWithContext(ctx context.Context, request *AlterRoleRequestPB) (*AlterRoleResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "AlterRole", "request", request)
 	response := &AlterRoleResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "AlterRole", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "AlterRole", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -556,10 +766,14 @@ func (s *MasterServiceImpl) AlterRole(request *AlterRoleRequestPB) (*AlterRoleRe
 }
 
 func (s *MasterServiceImpl) DeleteRole(request *DeleteRoleRequestPB) (*DeleteRoleResponsePB, error) {
+	return s.DeleteRoleWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) DeleteRoleWithContext(ctx context.Context, request *DeleteRoleRequestPB) (*DeleteRoleResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "DeleteRole", "request", request)
 	response := &DeleteRoleResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "DeleteRole", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "DeleteRole", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -570,10 +784,14 @@ func (s *MasterServiceImpl) DeleteRole(request *DeleteRoleRequestPB) (*DeleteRol
 }
 
 func (s *MasterServiceImpl) GrantRevokeRole(request *GrantRevokeRoleRequestPB) (*GrantRevokeRoleResponsePB, error) {
+	return s.GrantRevokeRoleWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) GrantRevokeRoleWithContext(ctx context.Context, request *GrantRevokeRoleRequestPB) (*GrantRevokeRoleResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "GrantRevokeRole", "request", request)
 	response := &GrantRevokeRoleResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "GrantRevokeRole", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "GrantRevokeRole", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -584,10 +802,14 @@ func (s *MasterServiceImpl) GrantRevokeRole(request *GrantRevokeRoleRequestPB) (
 }
 
 func (s *MasterServiceImpl) GrantRevokePermission(request *GrantRevokePermissionRequestPB) (*GrantRevokePermissionResponsePB, error) {
+	return s.GrantRevokePermissionWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) GrantRevokePermissionWithContext(ctx context.Context, request *GrantRevokePermissionRequestPB) (*GrantRevokePermissionResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "GrantRevokePermission", "request", request)
 	response := &GrantRevokePermissionResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "GrantRevokePermission", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "GrantRevokePermission", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -598,10 +820,14 @@ func (s *MasterServiceImpl) GrantRevokePermission(request *GrantRevokePermission
 }
 
 func (s *MasterServiceImpl) GetPermissions(request *GetPermissionsRequestPB) (*GetPermissionsResponsePB, error) {
+	return s.GetPermissionsWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) GetPermissionsWithContext(ctx context.Context, request *GetPermissionsRequestPB) (*GetPermissionsResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "GetPermissions", "request", request)
 	response := &GetPermissionsResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "GetPermissions", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "GetPermissions", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -612,10 +838,14 @@ func (s *MasterServiceImpl) GetPermissions(request *GetPermissionsRequestPB) (*G
 }
 
 func (s *MasterServiceImpl) CreateUDType(request *CreateUDTypeRequestPB) (*CreateUDTypeResponsePB, error) {
+	return s.CreateUDTypeWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) CreateUDTypeWithContext(ctx context.Context, request *CreateUDTypeRequestPB) (*CreateUDTypeResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "CreateUDType", "request", request)
 	response := &CreateUDTypeResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "CreateUDType", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "CreateUDType", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -626,10 +856,14 @@ func (s *MasterServiceImpl) CreateUDType(request *CreateUDTypeRequestPB) (*Creat
 }
 
 func (s *MasterServiceImpl) DeleteUDType(request *DeleteUDTypeRequestPB) (*DeleteUDTypeResponsePB, error) {
+	return s.DeleteUDTypeWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) DeleteUDTypeWithContext(ctx context.Context, request *DeleteUDTypeRequestPB) (*DeleteUDTypeResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "DeleteUDType", "request", request)
 	response := &DeleteUDTypeResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "DeleteUDType", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "DeleteUDType", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -640,10 +874,14 @@ func (s *MasterServiceImpl) DeleteUDType(request *DeleteUDTypeRequestPB) (*Delet
 }
 
 func (s *MasterServiceImpl) ListUDTypes(request *ListUDTypesRequestPB) (*ListUDTypesResponsePB, error) {
+	return s.ListUDTypesWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) ListUDTypesWithContext(ctx context.Context, request *ListUDTypesRequestPB) (*ListUDTypesResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "ListUDTypes", "request", request)
 	response := &ListUDTypesResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "ListUDTypes", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "ListUDTypes", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -654,10 +892,14 @@ func (s *MasterServiceImpl) ListUDTypes(request *ListUDTypesRequestPB) (*ListUDT
 }
 
 func (s *MasterServiceImpl) GetUDTypeInfo(request *GetUDTypeInfoRequestPB) (*GetUDTypeInfoResponsePB, error) {
+	return s.GetUDTypeInfoWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) GetUDTypeInfoWithContext(ctx context.Context, request *GetUDTypeInfoRequestPB) (*GetUDTypeInfoResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "GetUDTypeInfo", "request", request)
 	response := &GetUDTypeInfoResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "GetUDTypeInfo", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "GetUDTypeInfo", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -670,10 +912,16 @@ func (s *MasterServiceImpl) GetUDTypeInfo(request *GetUDTypeInfoRequestPB) (*Get
 // CDC stream RPCs.
 
 func (s *MasterServiceImpl) CreateCDCStream(request *CreateCDCStreamRequestPB) (*CreateCDCStreamResponsePB, error) {
+	return s.CreateCDCStreamWithContext(context.Background(), request)
+}
+
+// CDC stream RPCs.
+
+func (s *MasterServiceImpl) CreateCDCStreamWithContext(ctx context.Context, request *CreateCDCStreamRequestPB) (*CreateCDCStreamResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "CreateCDCStream", "request", request)
 	response := &CreateCDCStreamResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "CreateCDCStream", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "CreateCDCStream", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -684,10 +932,14 @@ func (s *MasterServiceImpl) CreateCDCStream(request *CreateCDCStreamRequestPB) (
 }
 
 func (s *MasterServiceImpl) DeleteCDCStream(request *DeleteCDCStreamRequestPB) (*DeleteCDCStreamResponsePB, error) {
+	return s.DeleteCDCStreamWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) DeleteCDCStreamWithContext(ctx context.Context, request *DeleteCDCStreamRequestPB) (*DeleteCDCStreamResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "DeleteCDCStream", "request", request)
 	response := &DeleteCDCStreamResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "DeleteCDCStream", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "DeleteCDCStream", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -698,10 +950,14 @@ func (s *MasterServiceImpl) DeleteCDCStream(request *DeleteCDCStreamRequestPB) (
 }
 
 func (s *MasterServiceImpl) ListCDCStreams(request *ListCDCStreamsRequestPB) (*ListCDCStreamsResponsePB, error) {
+	return s.ListCDCStreamsWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) ListCDCStreamsWithContext(ctx context.Context, request *ListCDCStreamsRequestPB) (*ListCDCStreamsResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "ListCDCStreams", "request", request)
 	response := &ListCDCStreamsResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "ListCDCStreams", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "ListCDCStreams", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -712,10 +968,14 @@ func (s *MasterServiceImpl) ListCDCStreams(request *ListCDCStreamsRequestPB) (*L
 }
 
 func (s *MasterServiceImpl) GetCDCStream(request *GetCDCStreamRequestPB) (*GetCDCStreamResponsePB, error) {
+	return s.GetCDCStreamWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) GetCDCStreamWithContext(ctx context.Context, request *GetCDCStreamRequestPB) (*GetCDCStreamResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "GetCDCStream", "request", request)
 	response := &GetCDCStreamResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "GetCDCStream", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "GetCDCStream", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -728,10 +988,16 @@ func (s *MasterServiceImpl) GetCDCStream(request *GetCDCStreamRequestPB) (*GetCD
 // Redis Config
 
 func (s *MasterServiceImpl) RedisConfigSet(request *RedisConfigSetRequestPB) (*RedisConfigSetResponsePB, error) {
+	return s.RedisConfigSetWithContext(context.Background(), request)
+}
+
+// Redis Config
+
+func (s *MasterServiceImpl) RedisConfigSetWithContext(ctx context.Context, request *RedisConfigSetRequestPB) (*RedisConfigSetResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "RedisConfigSet", "request", request)
 	response := &RedisConfigSetResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "RedisConfigSet", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "RedisConfigSet", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -742,10 +1008,14 @@ func (s *MasterServiceImpl) RedisConfigSet(request *RedisConfigSetRequestPB) (*R
 }
 
 func (s *MasterServiceImpl) RedisConfigGet(request *RedisConfigGetRequestPB) (*RedisConfigGetResponsePB, error) {
+	return s.RedisConfigGetWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) RedisConfigGetWithContext(ctx context.Context, request *RedisConfigGetRequestPB) (*RedisConfigGetResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "RedisConfigGet", "request", request)
 	response := &RedisConfigGetResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "RedisConfigGet", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "RedisConfigGet", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -758,10 +1028,16 @@ func (s *MasterServiceImpl) RedisConfigGet(request *RedisConfigGetRequestPB) (*R
 // Administrative/monitoring RPCs
 
 func (s *MasterServiceImpl) ListTabletServers(request *ListTabletServersRequestPB) (*ListTabletServersResponsePB, error) {
+	return s.ListTabletServersWithContext(context.Background(), request)
+}
+
+// Administrative/monitoring RPCs
+
+func (s *MasterServiceImpl) ListTabletServersWithContext(ctx context.Context, request *ListTabletServersRequestPB) (*ListTabletServersResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "ListTabletServers", "request", request)
 	response := &ListTabletServersResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "ListTabletServers", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "ListTabletServers", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -772,10 +1048,14 @@ func (s *MasterServiceImpl) ListTabletServers(request *ListTabletServersRequestP
 }
 
 func (s *MasterServiceImpl) ListMasters(request *ListMastersRequestPB) (*ListMastersResponsePB, error) {
+	return s.ListMastersWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) ListMastersWithContext(ctx context.Context, request *ListMastersRequestPB) (*ListMastersResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "ListMasters", "request", request)
 	response := &ListMastersResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "ListMasters", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "ListMasters", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -786,10 +1066,14 @@ func (s *MasterServiceImpl) ListMasters(request *ListMastersRequestPB) (*ListMas
 }
 
 func (s *MasterServiceImpl) ListMasterRaftPeers(request *ListMasterRaftPeersRequestPB) (*ListMasterRaftPeersResponsePB, error) {
+	return s.ListMasterRaftPeersWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) ListMasterRaftPeersWithContext(ctx context.Context, request *ListMasterRaftPeersRequestPB) (*ListMasterRaftPeersResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "ListMasterRaftPeers", "request", request)
 	response := &ListMasterRaftPeersResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "ListMasterRaftPeers", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "ListMasterRaftPeers", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -800,10 +1084,14 @@ func (s *MasterServiceImpl) ListMasterRaftPeers(request *ListMasterRaftPeersRequ
 }
 
 func (s *MasterServiceImpl) GetMasterRegistration(request *GetMasterRegistrationRequestPB) (*GetMasterRegistrationResponsePB, error) {
+	return s.GetMasterRegistrationWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) GetMasterRegistrationWithContext(ctx context.Context, request *GetMasterRegistrationRequestPB) (*GetMasterRegistrationResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "GetMasterRegistration", "request", request)
 	response := &GetMasterRegistrationResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "GetMasterRegistration", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "GetMasterRegistration", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -817,10 +1105,17 @@ func (s *MasterServiceImpl) GetMasterRegistration(request *GetMasterRegistration
 // NOTE: Should be used only for unit testing purposes.
 
 func (s *MasterServiceImpl) IsMasterLeaderServiceReady(request *IsMasterLeaderReadyRequestPB) (*IsMasterLeaderReadyResponsePB, error) {
+	return s.IsMasterLeaderServiceReadyWithContext(context.Background(), request)
+}
+
+// Get the ready status from the catalog manager for this master.
+// NOTE: Should be used only for unit testing purposes.
+
+func (s *MasterServiceImpl) IsMasterLeaderServiceReadyWithContext(ctx context.Context, request *IsMasterLeaderReadyRequestPB) (*IsMasterLeaderReadyResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "IsMasterLeaderServiceReady", "request", request)
 	response := &IsMasterLeaderReadyResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "IsMasterLeaderServiceReady", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "IsMasterLeaderServiceReady", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -833,10 +1128,16 @@ func (s *MasterServiceImpl) IsMasterLeaderServiceReady(request *IsMasterLeaderRe
 // Dump master state from all the peers in the current master's quorum
 
 func (s *MasterServiceImpl) DumpState(request *DumpMasterStateRequestPB) (*DumpMasterStateResponsePB, error) {
+	return s.DumpStateWithContext(context.Background(), request)
+}
+
+// Dump master state from all the peers in the current master's quorum
+
+func (s *MasterServiceImpl) DumpStateWithContext(ctx context.Context, request *DumpMasterStateRequestPB) (*DumpMasterStateResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "DumpState", "request", request)
 	response := &DumpMasterStateResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "DumpState", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "DumpState", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -847,10 +1148,14 @@ func (s *MasterServiceImpl) DumpState(request *DumpMasterStateRequestPB) (*DumpM
 }
 
 func (s *MasterServiceImpl) ChangeLoadBalancerState(request *ChangeLoadBalancerStateRequestPB) (*ChangeLoadBalancerStateResponsePB, error) {
+	return s.ChangeLoadBalancerStateWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) ChangeLoadBalancerStateWithContext(ctx context.Context, request *ChangeLoadBalancerStateRequestPB) (*ChangeLoadBalancerStateResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "ChangeLoadBalancerState", "request", request)
 	response := &ChangeLoadBalancerStateResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "ChangeLoadBalancerState", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "ChangeLoadBalancerState", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -861,10 +1166,14 @@ func (s *MasterServiceImpl) ChangeLoadBalancerState(request *ChangeLoadBalancerS
 }
 
 func (s *MasterServiceImpl) GetLoadBalancerState(request *GetLoadBalancerStateRequestPB) (*GetLoadBalancerStateResponsePB, error) {
+	return s.GetLoadBalancerStateWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) GetLoadBalancerStateWithContext(ctx context.Context, request *GetLoadBalancerStateRequestPB) (*GetLoadBalancerStateResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "GetLoadBalancerState", "request", request)
 	response := &GetLoadBalancerStateResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "GetLoadBalancerState", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "GetLoadBalancerState", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -875,10 +1184,14 @@ func (s *MasterServiceImpl) GetLoadBalancerState(request *GetLoadBalancerStateRe
 }
 
 func (s *MasterServiceImpl) RemovedMasterUpdate(request *RemovedMasterUpdateRequestPB) (*RemovedMasterUpdateResponsePB, error) {
+	return s.RemovedMasterUpdateWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) RemovedMasterUpdateWithContext(ctx context.Context, request *RemovedMasterUpdateRequestPB) (*RemovedMasterUpdateResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "RemovedMasterUpdate", "request", request)
 	response := &RemovedMasterUpdateResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "RemovedMasterUpdate", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "RemovedMasterUpdate", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -889,10 +1202,14 @@ func (s *MasterServiceImpl) RemovedMasterUpdate(request *RemovedMasterUpdateRequ
 }
 
 func (s *MasterServiceImpl) SetPreferredZones(request *SetPreferredZonesRequestPB) (*SetPreferredZonesResponsePB, error) {
+	return s.SetPreferredZonesWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) SetPreferredZonesWithContext(ctx context.Context, request *SetPreferredZonesRequestPB) (*SetPreferredZonesResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "SetPreferredZones", "request", request)
 	response := &SetPreferredZonesResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "SetPreferredZones", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "SetPreferredZones", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -903,10 +1220,14 @@ func (s *MasterServiceImpl) SetPreferredZones(request *SetPreferredZonesRequestP
 }
 
 func (s *MasterServiceImpl) GetMasterClusterConfig(request *GetMasterClusterConfigRequestPB) (*GetMasterClusterConfigResponsePB, error) {
+	return s.GetMasterClusterConfigWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) GetMasterClusterConfigWithContext(ctx context.Context, request *GetMasterClusterConfigRequestPB) (*GetMasterClusterConfigResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "GetMasterClusterConfig", "request", request)
 	response := &GetMasterClusterConfigResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "GetMasterClusterConfig", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "GetMasterClusterConfig", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -917,10 +1238,14 @@ func (s *MasterServiceImpl) GetMasterClusterConfig(request *GetMasterClusterConf
 }
 
 func (s *MasterServiceImpl) ChangeMasterClusterConfig(request *ChangeMasterClusterConfigRequestPB) (*ChangeMasterClusterConfigResponsePB, error) {
+	return s.ChangeMasterClusterConfigWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) ChangeMasterClusterConfigWithContext(ctx context.Context, request *ChangeMasterClusterConfigRequestPB) (*ChangeMasterClusterConfigResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "ChangeMasterClusterConfig", "request", request)
 	response := &ChangeMasterClusterConfigResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "ChangeMasterClusterConfig", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "ChangeMasterClusterConfig", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -931,10 +1256,14 @@ func (s *MasterServiceImpl) ChangeMasterClusterConfig(request *ChangeMasterClust
 }
 
 func (s *MasterServiceImpl) GetLoadMoveCompletion(request *GetLoadMovePercentRequestPB) (*GetLoadMovePercentResponsePB, error) {
+	return s.GetLoadMoveCompletionWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) GetLoadMoveCompletionWithContext(ctx context.Context, request *GetLoadMovePercentRequestPB) (*GetLoadMovePercentResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "GetLoadMoveCompletion", "request", request)
 	response := &GetLoadMovePercentResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "GetLoadMoveCompletion", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "GetLoadMoveCompletion", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -945,10 +1274,14 @@ func (s *MasterServiceImpl) GetLoadMoveCompletion(request *GetLoadMovePercentReq
 }
 
 func (s *MasterServiceImpl) GetLeaderBlacklistCompletion(request *GetLeaderBlacklistPercentRequestPB) (*GetLoadMovePercentResponsePB, error) {
+	return s.GetLeaderBlacklistCompletionWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) GetLeaderBlacklistCompletionWithContext(ctx context.Context, request *GetLeaderBlacklistPercentRequestPB) (*GetLoadMovePercentResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "GetLeaderBlacklistCompletion", "request", request)
 	response := &GetLoadMovePercentResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "GetLeaderBlacklistCompletion", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "GetLeaderBlacklistCompletion", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -959,10 +1292,14 @@ func (s *MasterServiceImpl) GetLeaderBlacklistCompletion(request *GetLeaderBlack
 }
 
 func (s *MasterServiceImpl) IsLoadBalanced(request *IsLoadBalancedRequestPB) (*IsLoadBalancedResponsePB, error) {
+	return s.IsLoadBalancedWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) IsLoadBalancedWithContext(ctx context.Context, request *IsLoadBalancedRequestPB) (*IsLoadBalancedResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "IsLoadBalanced", "request", request)
 	response := &IsLoadBalancedResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "IsLoadBalanced", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "IsLoadBalanced", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -973,10 +1310,14 @@ func (s *MasterServiceImpl) IsLoadBalanced(request *IsLoadBalancedRequestPB) (*I
 }
 
 func (s *MasterServiceImpl) IsLoadBalancerIdle(request *IsLoadBalancerIdleRequestPB) (*IsLoadBalancerIdleResponsePB, error) {
+	return s.IsLoadBalancerIdleWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) IsLoadBalancerIdleWithContext(ctx context.Context, request *IsLoadBalancerIdleRequestPB) (*IsLoadBalancerIdleResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "IsLoadBalancerIdle", "request", request)
 	response := &IsLoadBalancerIdleResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "IsLoadBalancerIdle", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "IsLoadBalancerIdle", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -987,10 +1328,14 @@ func (s *MasterServiceImpl) IsLoadBalancerIdle(request *IsLoadBalancerIdleReques
 }
 
 func (s *MasterServiceImpl) AreLeadersOnPreferredOnly(request *AreLeadersOnPreferredOnlyRequestPB) (*AreLeadersOnPreferredOnlyResponsePB, error) {
+	return s.AreLeadersOnPreferredOnlyWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) AreLeadersOnPreferredOnlyWithContext(ctx context.Context, request *AreLeadersOnPreferredOnlyRequestPB) (*AreLeadersOnPreferredOnlyResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "AreLeadersOnPreferredOnly", "request", request)
 	response := &AreLeadersOnPreferredOnlyResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "AreLeadersOnPreferredOnly", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "AreLeadersOnPreferredOnly", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -1001,10 +1346,14 @@ func (s *MasterServiceImpl) AreLeadersOnPreferredOnly(request *AreLeadersOnPrefe
 }
 
 func (s *MasterServiceImpl) FlushTables(request *FlushTablesRequestPB) (*FlushTablesResponsePB, error) {
+	return s.FlushTablesWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) FlushTablesWithContext(ctx context.Context, request *FlushTablesRequestPB) (*FlushTablesResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "FlushTables", "request", request)
 	response := &FlushTablesResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "FlushTables", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "FlushTables", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -1015,10 +1364,14 @@ func (s *MasterServiceImpl) FlushTables(request *FlushTablesRequestPB) (*FlushTa
 }
 
 func (s *MasterServiceImpl) IsFlushTablesDone(request *IsFlushTablesDoneRequestPB) (*IsFlushTablesDoneResponsePB, error) {
+	return s.IsFlushTablesDoneWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) IsFlushTablesDoneWithContext(ctx context.Context, request *IsFlushTablesDoneRequestPB) (*IsFlushTablesDoneResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "IsFlushTablesDone", "request", request)
 	response := &IsFlushTablesDoneResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "IsFlushTablesDone", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "IsFlushTablesDone", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -1029,10 +1382,14 @@ func (s *MasterServiceImpl) IsFlushTablesDone(request *IsFlushTablesDoneRequestP
 }
 
 func (s *MasterServiceImpl) IsInitDbDone(request *IsInitDbDoneRequestPB) (*IsInitDbDoneResponsePB, error) {
+	return s.IsInitDbDoneWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) IsInitDbDoneWithContext(ctx context.Context, request *IsInitDbDoneRequestPB) (*IsInitDbDoneResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "IsInitDbDone", "request", request)
 	response := &IsInitDbDoneResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "IsInitDbDone", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "IsInitDbDone", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -1043,10 +1400,14 @@ func (s *MasterServiceImpl) IsInitDbDone(request *IsInitDbDoneRequestPB) (*IsIni
 }
 
 func (s *MasterServiceImpl) ChangeEncryptionInfo(request *ChangeEncryptionInfoRequestPB) (*ChangeEncryptionInfoResponsePB, error) {
+	return s.ChangeEncryptionInfoWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) ChangeEncryptionInfoWithContext(ctx context.Context, request *ChangeEncryptionInfoRequestPB) (*ChangeEncryptionInfoResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "ChangeEncryptionInfo", "request", request)
 	response := &ChangeEncryptionInfoResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "ChangeEncryptionInfo", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "ChangeEncryptionInfo", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -1057,10 +1418,14 @@ func (s *MasterServiceImpl) ChangeEncryptionInfo(request *ChangeEncryptionInfoRe
 }
 
 func (s *MasterServiceImpl) IsEncryptionEnabled(request *IsEncryptionEnabledRequestPB) (*IsEncryptionEnabledResponsePB, error) {
+	return s.IsEncryptionEnabledWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) IsEncryptionEnabledWithContext(ctx context.Context, request *IsEncryptionEnabledRequestPB) (*IsEncryptionEnabledResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "IsEncryptionEnabled", "request", request)
 	response := &IsEncryptionEnabledResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "IsEncryptionEnabled", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "IsEncryptionEnabled", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -1071,10 +1436,14 @@ func (s *MasterServiceImpl) IsEncryptionEnabled(request *IsEncryptionEnabledRequ
 }
 
 func (s *MasterServiceImpl) SetupUniverseReplication(request *SetupUniverseReplicationRequestPB) (*SetupUniverseReplicationResponsePB, error) {
+	return s.SetupUniverseReplicationWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) SetupUniverseReplicationWithContext(ctx context.Context, request *SetupUniverseReplicationRequestPB) (*SetupUniverseReplicationResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "SetupUniverseReplication", "request", request)
 	response := &SetupUniverseReplicationResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "SetupUniverseReplication", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "SetupUniverseReplication", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -1085,10 +1454,14 @@ func (s *MasterServiceImpl) SetupUniverseReplication(request *SetupUniverseRepli
 }
 
 func (s *MasterServiceImpl) DeleteUniverseReplication(request *DeleteUniverseReplicationRequestPB) (*DeleteUniverseReplicationResponsePB, error) {
+	return s.DeleteUniverseReplicationWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) DeleteUniverseReplicationWithContext(ctx context.Context, request *DeleteUniverseReplicationRequestPB) (*DeleteUniverseReplicationResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "DeleteUniverseReplication", "request", request)
 	response := &DeleteUniverseReplicationResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "DeleteUniverseReplication", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "DeleteUniverseReplication", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -1099,10 +1472,14 @@ func (s *MasterServiceImpl) DeleteUniverseReplication(request *DeleteUniverseRep
 }
 
 func (s *MasterServiceImpl) AlterUniverseReplication(request *AlterUniverseReplicationRequestPB) (*AlterUniverseReplicationResponsePB, error) {
+	return s.AlterUniverseReplicationWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) AlterUniverseReplicationWithContext(ctx context.Context, request *AlterUniverseReplicationRequestPB) (*AlterUniverseReplicationResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "AlterUniverseReplication", "request", request)
 	response := &AlterUniverseReplicationResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "AlterUniverseReplication", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "AlterUniverseReplication", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -1113,10 +1490,14 @@ func (s *MasterServiceImpl) AlterUniverseReplication(request *AlterUniverseRepli
 }
 
 func (s *MasterServiceImpl) SetUniverseReplicationEnabled(request *SetUniverseReplicationEnabledRequestPB) (*SetUniverseReplicationEnabledResponsePB, error) {
+	return s.SetUniverseReplicationEnabledWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) SetUniverseReplicationEnabledWithContext(ctx context.Context, request *SetUniverseReplicationEnabledRequestPB) (*SetUniverseReplicationEnabledResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "SetUniverseReplicationEnabled", "request", request)
 	response := &SetUniverseReplicationEnabledResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "SetUniverseReplicationEnabled", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "SetUniverseReplicationEnabled", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -1127,10 +1508,14 @@ func (s *MasterServiceImpl) SetUniverseReplicationEnabled(request *SetUniverseRe
 }
 
 func (s *MasterServiceImpl) GetUniverseReplication(request *GetUniverseReplicationRequestPB) (*GetUniverseReplicationResponsePB, error) {
+	return s.GetUniverseReplicationWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) GetUniverseReplicationWithContext(ctx context.Context, request *GetUniverseReplicationRequestPB) (*GetUniverseReplicationResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "GetUniverseReplication", "request", request)
 	response := &GetUniverseReplicationResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "GetUniverseReplication", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "GetUniverseReplication", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -1141,10 +1526,14 @@ func (s *MasterServiceImpl) GetUniverseReplication(request *GetUniverseReplicati
 }
 
 func (s *MasterServiceImpl) AddUniverseKeys(request *AddUniverseKeysRequestPB) (*AddUniverseKeysResponsePB, error) {
+	return s.AddUniverseKeysWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) AddUniverseKeysWithContext(ctx context.Context, request *AddUniverseKeysRequestPB) (*AddUniverseKeysResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "AddUniverseKeys", "request", request)
 	response := &AddUniverseKeysResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "AddUniverseKeys", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "AddUniverseKeys", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -1155,10 +1544,14 @@ func (s *MasterServiceImpl) AddUniverseKeys(request *AddUniverseKeysRequestPB) (
 }
 
 func (s *MasterServiceImpl) GetUniverseKeyRegistry(request *GetUniverseKeyRegistryRequestPB) (*GetUniverseKeyRegistryResponsePB, error) {
+	return s.GetUniverseKeyRegistryWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) GetUniverseKeyRegistryWithContext(ctx context.Context, request *GetUniverseKeyRegistryRequestPB) (*GetUniverseKeyRegistryResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "GetUniverseKeyRegistry", "request", request)
 	response := &GetUniverseKeyRegistryResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "GetUniverseKeyRegistry", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "GetUniverseKeyRegistry", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -1169,10 +1562,14 @@ func (s *MasterServiceImpl) GetUniverseKeyRegistry(request *GetUniverseKeyRegist
 }
 
 func (s *MasterServiceImpl) HasUniverseKeyInMemory(request *HasUniverseKeyInMemoryRequestPB) (*HasUniverseKeyInMemoryResponsePB, error) {
+	return s.HasUniverseKeyInMemoryWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) HasUniverseKeyInMemoryWithContext(ctx context.Context, request *HasUniverseKeyInMemoryRequestPB) (*HasUniverseKeyInMemoryResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "HasUniverseKeyInMemory", "request", request)
 	response := &HasUniverseKeyInMemoryResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "HasUniverseKeyInMemory", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "HasUniverseKeyInMemory", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -1183,10 +1580,14 @@ func (s *MasterServiceImpl) HasUniverseKeyInMemory(request *HasUniverseKeyInMemo
 }
 
 func (s *MasterServiceImpl) SplitTablet(request *SplitTabletRequestPB) (*SplitTabletResponsePB, error) {
+	return s.SplitTabletWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) SplitTabletWithContext(ctx context.Context, request *SplitTabletRequestPB) (*SplitTabletResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "SplitTablet", "request", request)
 	response := &SplitTabletResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "SplitTablet", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "SplitTablet", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -1197,10 +1598,14 @@ func (s *MasterServiceImpl) SplitTablet(request *SplitTabletRequestPB) (*SplitTa
 }
 
 func (s *MasterServiceImpl) DeleteTablet(request *DeleteTabletRequestPB) (*DeleteTabletResponsePB, error) {
+	return s.DeleteTabletWithContext(context.Background(), request)
+}
+
+func (s *MasterServiceImpl) DeleteTabletWithContext(ctx context.Context, request *DeleteTabletRequestPB) (*DeleteTabletResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.master.MasterService", "method", "DeleteTablet", "request", request)
 	response := &DeleteTabletResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.master.MasterService", "DeleteTablet", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.master.MasterService", "DeleteTablet", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -1209,3 +1614,1015 @@ func (s *MasterServiceImpl) DeleteTablet(request *DeleteTabletRequestPB) (*Delet
 
 	return response, nil
 }
+
+// MasterServiceServer is the server API for yb.master.MasterService.
+type MasterServiceServer interface {
+	TSHeartbeat(ctx context.Context, request *TSHeartbeatRequestPB) (*TSHeartbeatResponsePB, error)
+	GetTabletLocations(ctx context.Context, request *GetTabletLocationsRequestPB) (*GetTabletLocationsResponsePB, error)
+	CreateTable(ctx context.Context, request *CreateTableRequestPB) (*CreateTableResponsePB, error)
+	IsCreateTableDone(ctx context.Context, request *IsCreateTableDoneRequestPB) (*IsCreateTableDoneResponsePB, error)
+	TruncateTable(ctx context.Context, request *TruncateTableRequestPB) (*TruncateTableResponsePB, error)
+	IsTruncateTableDone(ctx context.Context, request *IsTruncateTableDoneRequestPB) (*IsTruncateTableDoneResponsePB, error)
+	BackfillIndex(ctx context.Context, request *BackfillIndexRequestPB) (*BackfillIndexResponsePB, error)
+	LaunchBackfillIndexForTable(ctx context.Context, request *LaunchBackfillIndexForTableRequestPB) (*LaunchBackfillIndexForTableResponsePB, error)
+	DeleteTable(ctx context.Context, request *DeleteTableRequestPB) (*DeleteTableResponsePB, error)
+	IsDeleteTableDone(ctx context.Context, request *IsDeleteTableDoneRequestPB) (*IsDeleteTableDoneResponsePB, error)
+	AlterTable(ctx context.Context, request *AlterTableRequestPB) (*AlterTableResponsePB, error)
+	IsAlterTableDone(ctx context.Context, request *IsAlterTableDoneRequestPB) (*IsAlterTableDoneResponsePB, error)
+	ListTables(ctx context.Context, request *ListTablesRequestPB) (*ListTablesResponsePB, error)
+	GetTableLocations(ctx context.Context, request *GetTableLocationsRequestPB) (*GetTableLocationsResponsePB, error)
+	GetTableSchema(ctx context.Context, request *GetTableSchemaRequestPB) (*GetTableSchemaResponsePB, error)
+	GetColocatedTabletSchema(ctx context.Context, request *GetColocatedTabletSchemaRequestPB) (*GetColocatedTabletSchemaResponsePB, error)
+	CreateNamespace(ctx context.Context, request *CreateNamespaceRequestPB) (*CreateNamespaceResponsePB, error)
+	IsCreateNamespaceDone(ctx context.Context, request *IsCreateNamespaceDoneRequestPB) (*IsCreateNamespaceDoneResponsePB, error)
+	DeleteNamespace(ctx context.Context, request *DeleteNamespaceRequestPB) (*DeleteNamespaceResponsePB, error)
+	IsDeleteNamespaceDone(ctx context.Context, request *IsDeleteNamespaceDoneRequestPB) (*IsDeleteNamespaceDoneResponsePB, error)
+	AlterNamespace(ctx context.Context, request *AlterNamespaceRequestPB) (*AlterNamespaceResponsePB, error)
+	ListNamespaces(ctx context.Context, request *ListNamespacesRequestPB) (*ListNamespacesResponsePB, error)
+	GetNamespaceInfo(ctx context.Context, request *GetNamespaceInfoRequestPB) (*GetNamespaceInfoResponsePB, error)
+	CreateTablegroup(ctx context.Context, request *CreateTablegroupRequestPB) (*CreateTablegroupResponsePB, error)
+	DeleteTablegroup(ctx context.Context, request *DeleteTablegroupRequestPB) (*DeleteTablegroupResponsePB, error)
+	ListTablegroups(ctx context.Context, request *ListTablegroupsRequestPB) (*ListTablegroupsResponsePB, error)
+	ReservePgsqlOids(ctx context.Context, request *ReservePgsqlOidsRequestPB) (*ReservePgsqlOidsResponsePB, error)
+	GetYsqlCatalogConfig(ctx context.Context, request *GetYsqlCatalogConfigRequestPB) (*GetYsqlCatalogConfigResponsePB, error)
+	CreateRole(ctx context.Context, request *CreateRoleRequestPB) (*CreateRoleResponsePB, error)
+	AlterRole(ctx context.Context, request *AlterRoleRequestPB) (*AlterRoleResponsePB, error)
+	DeleteRole(ctx context.Context, request *DeleteRoleRequestPB) (*DeleteRoleResponsePB, error)
+	GrantRevokeRole(ctx context.Context, request *GrantRevokeRoleRequestPB) (*GrantRevokeRoleResponsePB, error)
+	GrantRevokePermission(ctx context.Context, request *GrantRevokePermissionRequestPB) (*GrantRevokePermissionResponsePB, error)
+	GetPermissions(ctx context.Context, request *GetPermissionsRequestPB) (*GetPermissionsResponsePB, error)
+	CreateUDType(ctx context.Context, request *CreateUDTypeRequestPB) (*CreateUDTypeResponsePB, error)
+	DeleteUDType(ctx context.Context, request *DeleteUDTypeRequestPB) (*DeleteUDTypeResponsePB, error)
+	ListUDTypes(ctx context.Context, request *ListUDTypesRequestPB) (*ListUDTypesResponsePB, error)
+	GetUDTypeInfo(ctx context.Context, request *GetUDTypeInfoRequestPB) (*GetUDTypeInfoResponsePB, error)
+	CreateCDCStream(ctx context.Context, request *CreateCDCStreamRequestPB) (*CreateCDCStreamResponsePB, error)
+	DeleteCDCStream(ctx context.Context, request *DeleteCDCStreamRequestPB) (*DeleteCDCStreamResponsePB, error)
+	ListCDCStreams(ctx context.Context, request *ListCDCStreamsRequestPB) (*ListCDCStreamsResponsePB, error)
+	GetCDCStream(ctx context.Context, request *GetCDCStreamRequestPB) (*GetCDCStreamResponsePB, error)
+	RedisConfigSet(ctx context.Context, request *RedisConfigSetRequestPB) (*RedisConfigSetResponsePB, error)
+	RedisConfigGet(ctx context.Context, request *RedisConfigGetRequestPB) (*RedisConfigGetResponsePB, error)
+	ListTabletServers(ctx context.Context, request *ListTabletServersRequestPB) (*ListTabletServersResponsePB, error)
+	ListMasters(ctx context.Context, request *ListMastersRequestPB) (*ListMastersResponsePB, error)
+	ListMasterRaftPeers(ctx context.Context, request *ListMasterRaftPeersRequestPB) (*ListMasterRaftPeersResponsePB, error)
+	GetMasterRegistration(ctx context.Context, request *GetMasterRegistrationRequestPB) (*GetMasterRegistrationResponsePB, error)
+	IsMasterLeaderServiceReady(ctx context.Context, request *IsMasterLeaderReadyRequestPB) (*IsMasterLeaderReadyResponsePB, error)
+	DumpState(ctx context.Context, request *DumpMasterStateRequestPB) (*DumpMasterStateResponsePB, error)
+	ChangeLoadBalancerState(ctx context.Context, request *ChangeLoadBalancerStateRequestPB) (*ChangeLoadBalancerStateResponsePB, error)
+	GetLoadBalancerState(ctx context.Context, request *GetLoadBalancerStateRequestPB) (*GetLoadBalancerStateResponsePB, error)
+	RemovedMasterUpdate(ctx context.Context, request *RemovedMasterUpdateRequestPB) (*RemovedMasterUpdateResponsePB, error)
+	SetPreferredZones(ctx context.Context, request *SetPreferredZonesRequestPB) (*SetPreferredZonesResponsePB, error)
+	GetMasterClusterConfig(ctx context.Context, request *GetMasterClusterConfigRequestPB) (*GetMasterClusterConfigResponsePB, error)
+	ChangeMasterClusterConfig(ctx context.Context, request *ChangeMasterClusterConfigRequestPB) (*ChangeMasterClusterConfigResponsePB, error)
+	GetLoadMoveCompletion(ctx context.Context, request *GetLoadMovePercentRequestPB) (*GetLoadMovePercentResponsePB, error)
+	GetLeaderBlacklistCompletion(ctx context.Context, request *GetLeaderBlacklistPercentRequestPB) (*GetLoadMovePercentResponsePB, error)
+	IsLoadBalanced(ctx context.Context, request *IsLoadBalancedRequestPB) (*IsLoadBalancedResponsePB, error)
+	IsLoadBalancerIdle(ctx context.Context, request *IsLoadBalancerIdleRequestPB) (*IsLoadBalancerIdleResponsePB, error)
+	AreLeadersOnPreferredOnly(ctx context.Context, request *AreLeadersOnPreferredOnlyRequestPB) (*AreLeadersOnPreferredOnlyResponsePB, error)
+	FlushTables(ctx context.Context, request *FlushTablesRequestPB) (*FlushTablesResponsePB, error)
+	IsFlushTablesDone(ctx context.Context, request *IsFlushTablesDoneRequestPB) (*IsFlushTablesDoneResponsePB, error)
+	IsInitDbDone(ctx context.Context, request *IsInitDbDoneRequestPB) (*IsInitDbDoneResponsePB, error)
+	ChangeEncryptionInfo(ctx context.Context, request *ChangeEncryptionInfoRequestPB) (*ChangeEncryptionInfoResponsePB, error)
+	IsEncryptionEnabled(ctx context.Context, request *IsEncryptionEnabledRequestPB) (*IsEncryptionEnabledResponsePB, error)
+	SetupUniverseReplication(ctx context.Context, request *SetupUniverseReplicationRequestPB) (*SetupUniverseReplicationResponsePB, error)
+	DeleteUniverseReplication(ctx context.Context, request *DeleteUniverseReplicationRequestPB) (*DeleteUniverseReplicationResponsePB, error)
+	AlterUniverseReplication(ctx context.Context, request *AlterUniverseReplicationRequestPB) (*AlterUniverseReplicationResponsePB, error)
+	SetUniverseReplicationEnabled(ctx context.Context, request *SetUniverseReplicationEnabledRequestPB) (*SetUniverseReplicationEnabledResponsePB, error)
+	GetUniverseReplication(ctx context.Context, request *GetUniverseReplicationRequestPB) (*GetUniverseReplicationResponsePB, error)
+	AddUniverseKeys(ctx context.Context, request *AddUniverseKeysRequestPB) (*AddUniverseKeysResponsePB, error)
+	GetUniverseKeyRegistry(ctx context.Context, request *GetUniverseKeyRegistryRequestPB) (*GetUniverseKeyRegistryResponsePB, error)
+	HasUniverseKeyInMemory(ctx context.Context, request *HasUniverseKeyInMemoryRequestPB) (*HasUniverseKeyInMemoryResponsePB, error)
+	SplitTablet(ctx context.Context, request *SplitTabletRequestPB) (*SplitTabletResponsePB, error)
+	DeleteTablet(ctx context.Context, request *DeleteTabletRequestPB) (*DeleteTabletResponsePB, error)
+}
+
+// UnimplementedMasterServiceServer can be embedded in implementations of MasterServiceServer that only serve
+// some of the methods. The others fail with dispatch.ErrNoSuchMethod.
+type UnimplementedMasterServiceServer struct{}
+
+func (UnimplementedMasterServiceServer) TSHeartbeat(ctx context.Context, request *TSHeartbeatRequestPB) (*TSHeartbeatResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "TSHeartbeat")
+}
+
+func (UnimplementedMasterServiceServer) GetTabletLocations(ctx context.Context, request *GetTabletLocationsRequestPB) (*GetTabletLocationsResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "GetTabletLocations")
+}
+
+func (UnimplementedMasterServiceServer) CreateTable(ctx context.Context, request *CreateTableRequestPB) (*CreateTableResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "CreateTable")
+}
+
+func (UnimplementedMasterServiceServer) IsCreateTableDone(ctx context.Context, request *IsCreateTableDoneRequestPB) (*IsCreateTableDoneResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "IsCreateTableDone")
+}
+
+func (UnimplementedMasterServiceServer) TruncateTable(ctx context.Context, request *TruncateTableRequestPB) (*TruncateTableResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "TruncateTable")
+}
+
+func (UnimplementedMasterServiceServer) IsTruncateTableDone(ctx context.Context, request *IsTruncateTableDoneRequestPB) (*IsTruncateTableDoneResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "IsTruncateTableDone")
+}
+
+func (UnimplementedMasterServiceServer) BackfillIndex(ctx context.Context, request *BackfillIndexRequestPB) (*BackfillIndexResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "BackfillIndex")
+}
+
+func (UnimplementedMasterServiceServer) LaunchBackfillIndexForTable(ctx context.Context, request *LaunchBackfillIndexForTableRequestPB) (*LaunchBackfillIndexForTableResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "LaunchBackfillIndexForTable")
+}
+
+func (UnimplementedMasterServiceServer) DeleteTable(ctx context.Context, request *DeleteTableRequestPB) (*DeleteTableResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "DeleteTable")
+}
+
+func (UnimplementedMasterServiceServer) IsDeleteTableDone(ctx context.Context, request *IsDeleteTableDoneRequestPB) (*IsDeleteTableDoneResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "IsDeleteTableDone")
+}
+
+func (UnimplementedMasterServiceServer) AlterTable(ctx context.Context, request *AlterTableRequestPB) (*AlterTableResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "AlterTable")
+}
+
+func (UnimplementedMasterServiceServer) IsAlterTableDone(ctx context.Context, request *IsAlterTableDoneRequestPB) (*IsAlterTableDoneResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "IsAlterTableDone")
+}
+
+func (UnimplementedMasterServiceServer) ListTables(ctx context.Context, request *ListTablesRequestPB) (*ListTablesResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "ListTables")
+}
+
+func (UnimplementedMasterServiceServer) GetTableLocations(ctx context.Context, request *GetTableLocationsRequestPB) (*GetTableLocationsResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "GetTableLocations")
+}
+
+func (UnimplementedMasterServiceServer) GetTableSchema(ctx context.Context, request *GetTableSchemaRequestPB) (*GetTableSchemaResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "GetTableSchema")
+}
+
+func (UnimplementedMasterServiceServer) GetColocatedTabletSchema(ctx context.Context, request *GetColocatedTabletSchemaRequestPB) (*GetColocatedTabletSchemaResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "GetColocatedTabletSchema")
+}
+
+func (UnimplementedMasterServiceServer) CreateNamespace(ctx context.Context, request *CreateNamespaceRequestPB) (*CreateNamespaceResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "CreateNamespace")
+}
+
+func (UnimplementedMasterServiceServer) IsCreateNamespaceDone(ctx context.Context, request *IsCreateNamespaceDoneRequestPB) (*IsCreateNamespaceDoneResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "IsCreateNamespaceDone")
+}
+
+func (UnimplementedMasterServiceServer) DeleteNamespace(ctx context.Context, request *DeleteNamespaceRequestPB) (*DeleteNamespaceResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "DeleteNamespace")
+}
+
+func (UnimplementedMasterServiceServer) IsDeleteNamespaceDone(ctx context.Context, request *IsDeleteNamespaceDoneRequestPB) (*IsDeleteNamespaceDoneResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "IsDeleteNamespaceDone")
+}
+
+func (UnimplementedMasterServiceServer) AlterNamespace(ctx context.Context, request *AlterNamespaceRequestPB) (*AlterNamespaceResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "AlterNamespace")
+}
+
+func (UnimplementedMasterServiceServer) ListNamespaces(ctx context.Context, request *ListNamespacesRequestPB) (*ListNamespacesResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "ListNamespaces")
+}
+
+func (UnimplementedMasterServiceServer) GetNamespaceInfo(ctx context.Context, request *GetNamespaceInfoRequestPB) (*GetNamespaceInfoResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "GetNamespaceInfo")
+}
+
+func (UnimplementedMasterServiceServer) CreateTablegroup(ctx context.Context, request *CreateTablegroupRequestPB) (*CreateTablegroupResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "CreateTablegroup")
+}
+
+func (UnimplementedMasterServiceServer) DeleteTablegroup(ctx context.Context, request *DeleteTablegroupRequestPB) (*DeleteTablegroupResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "DeleteTablegroup")
+}
+
+func (UnimplementedMasterServiceServer) ListTablegroups(ctx context.Context, request *ListTablegroupsRequestPB) (*ListTablegroupsResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "ListTablegroups")
+}
+
+func (UnimplementedMasterServiceServer) ReservePgsqlOids(ctx context.Context, request *ReservePgsqlOidsRequestPB) (*ReservePgsqlOidsResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "ReservePgsqlOids")
+}
+
+func (UnimplementedMasterServiceServer) GetYsqlCatalogConfig(ctx context.Context, request *GetYsqlCatalogConfigRequestPB) (*GetYsqlCatalogConfigResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "GetYsqlCatalogConfig")
+}
+
+func (UnimplementedMasterServiceServer) CreateRole(ctx context.Context, request *CreateRoleRequestPB) (*CreateRoleResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "CreateRole")
+}
+
+func (UnimplementedMasterServiceServer) AlterRole(ctx context.Context, request *AlterRoleRequestPB) (*AlterRoleResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "AlterRole")
+}
+
+func (UnimplementedMasterServiceServer) DeleteRole(ctx context.Context, request *DeleteRoleRequestPB) (*DeleteRoleResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "DeleteRole")
+}
+
+func (UnimplementedMasterServiceServer) GrantRevokeRole(ctx context.Context, request *GrantRevokeRoleRequestPB) (*GrantRevokeRoleResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "GrantRevokeRole")
+}
+
+func (UnimplementedMasterServiceServer) GrantRevokePermission(ctx context.Context, request *GrantRevokePermissionRequestPB) (*GrantRevokePermissionResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "GrantRevokePermission")
+}
+
+func (UnimplementedMasterServiceServer) GetPermissions(ctx context.Context, request *GetPermissionsRequestPB) (*GetPermissionsResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "GetPermissions")
+}
+
+func (UnimplementedMasterServiceServer) CreateUDType(ctx context.Context, request *CreateUDTypeRequestPB) (*CreateUDTypeResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "CreateUDType")
+}
+
+func (UnimplementedMasterServiceServer) DeleteUDType(ctx context.Context, request *DeleteUDTypeRequestPB) (*DeleteUDTypeResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "DeleteUDType")
+}
+
+func (UnimplementedMasterServiceServer) ListUDTypes(ctx context.Context, request *ListUDTypesRequestPB) (*ListUDTypesResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "ListUDTypes")
+}
+
+func (UnimplementedMasterServiceServer) GetUDTypeInfo(ctx context.Context, request *GetUDTypeInfoRequestPB) (*GetUDTypeInfoResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "GetUDTypeInfo")
+}
+
+func (UnimplementedMasterServiceServer) CreateCDCStream(ctx context.Context, request *CreateCDCStreamRequestPB) (*CreateCDCStreamResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "CreateCDCStream")
+}
+
+func (UnimplementedMasterServiceServer) DeleteCDCStream(ctx context.Context, request *DeleteCDCStreamRequestPB) (*DeleteCDCStreamResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "DeleteCDCStream")
+}
+
+func (UnimplementedMasterServiceServer) ListCDCStreams(ctx context.Context, request *ListCDCStreamsRequestPB) (*ListCDCStreamsResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "ListCDCStreams")
+}
+
+func (UnimplementedMasterServiceServer) GetCDCStream(ctx context.Context, request *GetCDCStreamRequestPB) (*GetCDCStreamResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "GetCDCStream")
+}
+
+func (UnimplementedMasterServiceServer) RedisConfigSet(ctx context.Context, request *RedisConfigSetRequestPB) (*RedisConfigSetResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "RedisConfigSet")
+}
+
+func (UnimplementedMasterServiceServer) RedisConfigGet(ctx context.Context, request *RedisConfigGetRequestPB) (*RedisConfigGetResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "RedisConfigGet")
+}
+
+func (UnimplementedMasterServiceServer) ListTabletServers(ctx context.Context, request *ListTabletServersRequestPB) (*ListTabletServersResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "ListTabletServers")
+}
+
+func (UnimplementedMasterServiceServer) ListMasters(ctx context.Context, request *ListMastersRequestPB) (*ListMastersResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "ListMasters")
+}
+
+func (UnimplementedMasterServiceServer) ListMasterRaftPeers(ctx context.Context, request *ListMasterRaftPeersRequestPB) (*ListMasterRaftPeersResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "ListMasterRaftPeers")
+}
+
+func (UnimplementedMasterServiceServer) GetMasterRegistration(ctx context.Context, request *GetMasterRegistrationRequestPB) (*GetMasterRegistrationResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "GetMasterRegistration")
+}
+
+func (UnimplementedMasterServiceServer) IsMasterLeaderServiceReady(ctx context.Context, request *IsMasterLeaderReadyRequestPB) (*IsMasterLeaderReadyResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "IsMasterLeaderServiceReady")
+}
+
+func (UnimplementedMasterServiceServer) DumpState(ctx context.Context, request *DumpMasterStateRequestPB) (*DumpMasterStateResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "DumpState")
+}
+
+func (UnimplementedMasterServiceServer) ChangeLoadBalancerState(ctx context.Context, request *ChangeLoadBalancerStateRequestPB) (*ChangeLoadBalancerStateResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "ChangeLoadBalancerState")
+}
+
+func (UnimplementedMasterServiceServer) GetLoadBalancerState(ctx context.Context, request *GetLoadBalancerStateRequestPB) (*GetLoadBalancerStateResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "GetLoadBalancerState")
+}
+
+func (UnimplementedMasterServiceServer) RemovedMasterUpdate(ctx context.Context, request *RemovedMasterUpdateRequestPB) (*RemovedMasterUpdateResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "RemovedMasterUpdate")
+}
+
+func (UnimplementedMasterServiceServer) SetPreferredZones(ctx context.Context, request *SetPreferredZonesRequestPB) (*SetPreferredZonesResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "SetPreferredZones")
+}
+
+func (UnimplementedMasterServiceServer) GetMasterClusterConfig(ctx context.Context, request *GetMasterClusterConfigRequestPB) (*GetMasterClusterConfigResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "GetMasterClusterConfig")
+}
+
+func (UnimplementedMasterServiceServer) ChangeMasterClusterConfig(ctx context.Context, request *ChangeMasterClusterConfigRequestPB) (*ChangeMasterClusterConfigResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "ChangeMasterClusterConfig")
+}
+
+func (UnimplementedMasterServiceServer) GetLoadMoveCompletion(ctx context.Context, request *GetLoadMovePercentRequestPB) (*GetLoadMovePercentResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "GetLoadMoveCompletion")
+}
+
+func (UnimplementedMasterServiceServer) GetLeaderBlacklistCompletion(ctx context.Context, request *GetLeaderBlacklistPercentRequestPB) (*GetLoadMovePercentResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "GetLeaderBlacklistCompletion")
+}
+
+func (UnimplementedMasterServiceServer) IsLoadBalanced(ctx context.Context, request *IsLoadBalancedRequestPB) (*IsLoadBalancedResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "IsLoadBalanced")
+}
+
+func (UnimplementedMasterServiceServer) IsLoadBalancerIdle(ctx context.Context, request *IsLoadBalancerIdleRequestPB) (*IsLoadBalancerIdleResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "IsLoadBalancerIdle")
+}
+
+func (UnimplementedMasterServiceServer) AreLeadersOnPreferredOnly(ctx context.Context, request *AreLeadersOnPreferredOnlyRequestPB) (*AreLeadersOnPreferredOnlyResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "AreLeadersOnPreferredOnly")
+}
+
+func (UnimplementedMasterServiceServer) FlushTables(ctx context.Context, request *FlushTablesRequestPB) (*FlushTablesResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "FlushTables")
+}
+
+func (UnimplementedMasterServiceServer) IsFlushTablesDone(ctx context.Context, request *IsFlushTablesDoneRequestPB) (*IsFlushTablesDoneResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "IsFlushTablesDone")
+}
+
+func (UnimplementedMasterServiceServer) IsInitDbDone(ctx context.Context, request *IsInitDbDoneRequestPB) (*IsInitDbDoneResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "IsInitDbDone")
+}
+
+func (UnimplementedMasterServiceServer) ChangeEncryptionInfo(ctx context.Context, request *ChangeEncryptionInfoRequestPB) (*ChangeEncryptionInfoResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "ChangeEncryptionInfo")
+}
+
+func (UnimplementedMasterServiceServer) IsEncryptionEnabled(ctx context.Context, request *IsEncryptionEnabledRequestPB) (*IsEncryptionEnabledResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "IsEncryptionEnabled")
+}
+
+func (UnimplementedMasterServiceServer) SetupUniverseReplication(ctx context.Context, request *SetupUniverseReplicationRequestPB) (*SetupUniverseReplicationResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "SetupUniverseReplication")
+}
+
+func (UnimplementedMasterServiceServer) DeleteUniverseReplication(ctx context.Context, request *DeleteUniverseReplicationRequestPB) (*DeleteUniverseReplicationResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "DeleteUniverseReplication")
+}
+
+func (UnimplementedMasterServiceServer) AlterUniverseReplication(ctx context.Context, request *AlterUniverseReplicationRequestPB) (*AlterUniverseReplicationResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "AlterUniverseReplication")
+}
+
+func (UnimplementedMasterServiceServer) SetUniverseReplicationEnabled(ctx context.Context, request *SetUniverseReplicationEnabledRequestPB) (*SetUniverseReplicationEnabledResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "SetUniverseReplicationEnabled")
+}
+
+func (UnimplementedMasterServiceServer) GetUniverseReplication(ctx context.Context, request *GetUniverseReplicationRequestPB) (*GetUniverseReplicationResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "GetUniverseReplication")
+}
+
+func (UnimplementedMasterServiceServer) AddUniverseKeys(ctx context.Context, request *AddUniverseKeysRequestPB) (*AddUniverseKeysResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "AddUniverseKeys")
+}
+
+func (UnimplementedMasterServiceServer) GetUniverseKeyRegistry(ctx context.Context, request *GetUniverseKeyRegistryRequestPB) (*GetUniverseKeyRegistryResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "GetUniverseKeyRegistry")
+}
+
+func (UnimplementedMasterServiceServer) HasUniverseKeyInMemory(ctx context.Context, request *HasUniverseKeyInMemoryRequestPB) (*HasUniverseKeyInMemoryResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "HasUniverseKeyInMemory")
+}
+
+func (UnimplementedMasterServiceServer) SplitTablet(ctx context.Context, request *SplitTabletRequestPB) (*SplitTabletResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "SplitTablet")
+}
+
+func (UnimplementedMasterServiceServer) DeleteTablet(ctx context.Context, request *DeleteTabletRequestPB) (*DeleteTabletResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.master.MasterService", "DeleteTablet")
+}
+
+var MasterService_ServiceDesc = dispatch.ServiceDesc{
+	ServiceName: "yb.master.MasterService",
+	HandlerType: (*MasterServiceServer)(nil),
+	Methods: []dispatch.MethodDesc{
+		{
+			MethodName:  "TSHeartbeat",
+			NewRequest:  func() proto.Message { return &TSHeartbeatRequestPB{} },
+			NewResponse: func() proto.Message { return &TSHeartbeatResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).TSHeartbeat(ctx, request.(*TSHeartbeatRequestPB))
+			},
+		},
+		{
+			MethodName:  "GetTabletLocations",
+			NewRequest:  func() proto.Message { return &GetTabletLocationsRequestPB{} },
+			NewResponse: func() proto.Message { return &GetTabletLocationsResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).GetTabletLocations(ctx, request.(*GetTabletLocationsRequestPB))
+			},
+		},
+		{
+			MethodName:  "CreateTable",
+			NewRequest:  func() proto.Message { return &CreateTableRequestPB{} },
+			NewResponse: func() proto.Message { return &CreateTableResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).CreateTable(ctx, request.(*CreateTableRequestPB))
+			},
+		},
+		{
+			MethodName:  "IsCreateTableDone",
+			NewRequest:  func() proto.Message { return &IsCreateTableDoneRequestPB{} },
+			NewResponse: func() proto.Message { return &IsCreateTableDoneResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).IsCreateTableDone(ctx, request.(*IsCreateTableDoneRequestPB))
+			},
+		},
+		{
+			MethodName:  "TruncateTable",
+			NewRequest:  func() proto.Message { return &TruncateTableRequestPB{} },
+			NewResponse: func() proto.Message { return &TruncateTableResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).TruncateTable(ctx, request.(*TruncateTableRequestPB))
+			},
+		},
+		{
+			MethodName:  "IsTruncateTableDone",
+			NewRequest:  func() proto.Message { return &IsTruncateTableDoneRequestPB{} },
+			NewResponse: func() proto.Message { return &IsTruncateTableDoneResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).IsTruncateTableDone(ctx, request.(*IsTruncateTableDoneRequestPB))
+			},
+		},
+		{
+			MethodName:  "BackfillIndex",
+			NewRequest:  func() proto.Message { return &BackfillIndexRequestPB{} },
+			NewResponse: func() proto.Message { return &BackfillIndexResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).BackfillIndex(ctx, request.(*BackfillIndexRequestPB))
+			},
+		},
+		{
+			MethodName:  "LaunchBackfillIndexForTable",
+			NewRequest:  func() proto.Message { return &LaunchBackfillIndexForTableRequestPB{} },
+			NewResponse: func() proto.Message { return &LaunchBackfillIndexForTableResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).LaunchBackfillIndexForTable(ctx, request.(*LaunchBackfillIndexForTableRequestPB))
+			},
+		},
+		{
+			MethodName:  "DeleteTable",
+			NewRequest:  func() proto.Message { return &DeleteTableRequestPB{} },
+			NewResponse: func() proto.Message { return &DeleteTableResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).DeleteTable(ctx, request.(*DeleteTableRequestPB))
+			},
+		},
+		{
+			MethodName:  "IsDeleteTableDone",
+			NewRequest:  func() proto.Message { return &IsDeleteTableDoneRequestPB{} },
+			NewResponse: func() proto.Message { return &IsDeleteTableDoneResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).IsDeleteTableDone(ctx, request.(*IsDeleteTableDoneRequestPB))
+			},
+		},
+		{
+			MethodName:  "AlterTable",
+			NewRequest:  func() proto.Message { return &AlterTableRequestPB{} },
+			NewResponse: func() proto.Message { return &AlterTableResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).AlterTable(ctx, request.(*AlterTableRequestPB))
+			},
+		},
+		{
+			MethodName:  "IsAlterTableDone",
+			NewRequest:  func() proto.Message { return &IsAlterTableDoneRequestPB{} },
+			NewResponse: func() proto.Message { return &IsAlterTableDoneResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).IsAlterTableDone(ctx, request.(*IsAlterTableDoneRequestPB))
+			},
+		},
+		{
+			MethodName:  "ListTables",
+			NewRequest:  func() proto.Message { return &ListTablesRequestPB{} },
+			NewResponse: func() proto.Message { return &ListTablesResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).ListTables(ctx, request.(*ListTablesRequestPB))
+			},
+		},
+		{
+			MethodName:  "GetTableLocations",
+			NewRequest:  func() proto.Message { return &GetTableLocationsRequestPB{} },
+			NewResponse: func() proto.Message { return &GetTableLocationsResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).GetTableLocations(ctx, request.(*GetTableLocationsRequestPB))
+			},
+		},
+		{
+			MethodName:  "GetTableSchema",
+			NewRequest:  func() proto.Message { return &GetTableSchemaRequestPB{} },
+			NewResponse: func() proto.Message { return &GetTableSchemaResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).GetTableSchema(ctx, request.(*GetTableSchemaRequestPB))
+			},
+		},
+		{
+			MethodName:  "GetColocatedTabletSchema",
+			NewRequest:  func() proto.Message { return &GetColocatedTabletSchemaRequestPB{} },
+			NewResponse: func() proto.Message { return &GetColocatedTabletSchemaResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).GetColocatedTabletSchema(ctx, request.(*GetColocatedTabletSchemaRequestPB))
+			},
+		},
+		{
+			MethodName:  "CreateNamespace",
+			NewRequest:  func() proto.Message { return &CreateNamespaceRequestPB{} },
+			NewResponse: func() proto.Message { return &CreateNamespaceResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).CreateNamespace(ctx, request.(*CreateNamespaceRequestPB))
+			},
+		},
+		{
+			MethodName:  "IsCreateNamespaceDone",
+			NewRequest:  func() proto.Message { return &IsCreateNamespaceDoneRequestPB{} },
+			NewResponse: func() proto.Message { return &IsCreateNamespaceDoneResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).IsCreateNamespaceDone(ctx, request.(*IsCreateNamespaceDoneRequestPB))
+			},
+		},
+		{
+			MethodName:  "DeleteNamespace",
+			NewRequest:  func() proto.Message { return &DeleteNamespaceRequestPB{} },
+			NewResponse: func() proto.Message { return &DeleteNamespaceResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).DeleteNamespace(ctx, request.(*DeleteNamespaceRequestPB))
+			},
+		},
+		{
+			MethodName:  "IsDeleteNamespaceDone",
+			NewRequest:  func() proto.Message { return &IsDeleteNamespaceDoneRequestPB{} },
+			NewResponse: func() proto.Message { return &IsDeleteNamespaceDoneResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).IsDeleteNamespaceDone(ctx, request.(*IsDeleteNamespaceDoneRequestPB))
+			},
+		},
+		{
+			MethodName:  "AlterNamespace",
+			NewRequest:  func() proto.Message { return &AlterNamespaceRequestPB{} },
+			NewResponse: func() proto.Message { return &AlterNamespaceResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).AlterNamespace(ctx, request.(*AlterNamespaceRequestPB))
+			},
+		},
+		{
+			MethodName:  "ListNamespaces",
+			NewRequest:  func() proto.Message { return &ListNamespacesRequestPB{} },
+			NewResponse: func() proto.Message { return &ListNamespacesResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).ListNamespaces(ctx, request.(*ListNamespacesRequestPB))
+			},
+		},
+		{
+			MethodName:  "GetNamespaceInfo",
+			NewRequest:  func() proto.Message { return &GetNamespaceInfoRequestPB{} },
+			NewResponse: func() proto.Message { return &GetNamespaceInfoResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).GetNamespaceInfo(ctx, request.(*GetNamespaceInfoRequestPB))
+			},
+		},
+		{
+			MethodName:  "CreateTablegroup",
+			NewRequest:  func() proto.Message { return &CreateTablegroupRequestPB{} },
+			NewResponse: func() proto.Message { return &CreateTablegroupResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).CreateTablegroup(ctx, request.(*CreateTablegroupRequestPB))
+			},
+		},
+		{
+			MethodName:  "DeleteTablegroup",
+			NewRequest:  func() proto.Message { return &DeleteTablegroupRequestPB{} },
+			NewResponse: func() proto.Message { return &DeleteTablegroupResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).DeleteTablegroup(ctx, request.(*DeleteTablegroupRequestPB))
+			},
+		},
+		{
+			MethodName:  "ListTablegroups",
+			NewRequest:  func() proto.Message { return &ListTablegroupsRequestPB{} },
+			NewResponse: func() proto.Message { return &ListTablegroupsResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).ListTablegroups(ctx, request.(*ListTablegroupsRequestPB))
+			},
+		},
+		{
+			MethodName:  "ReservePgsqlOids",
+			NewRequest:  func() proto.Message { return &ReservePgsqlOidsRequestPB{} },
+			NewResponse: func() proto.Message { return &ReservePgsqlOidsResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).ReservePgsqlOids(ctx, request.(*ReservePgsqlOidsRequestPB))
+			},
+		},
+		{
+			MethodName:  "GetYsqlCatalogConfig",
+			NewRequest:  func() proto.Message { return &GetYsqlCatalogConfigRequestPB{} },
+			NewResponse: func() proto.Message { return &GetYsqlCatalogConfigResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).GetYsqlCatalogConfig(ctx, request.(*GetYsqlCatalogConfigRequestPB))
+			},
+		},
+		{
+			MethodName:  "CreateRole",
+			NewRequest:  func() proto.Message { return &CreateRoleRequestPB{} },
+			NewResponse: func() proto.Message { return &CreateRoleResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).CreateRole(ctx, request.(*CreateRoleRequestPB))
+			},
+		},
+		{
+			MethodName:  "AlterRole",
+			NewRequest:  func() proto.Message { return &AlterRoleRequestPB{} },
+			NewResponse: func() proto.Message { return &AlterRoleResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).AlterRole(ctx, request.(*AlterRoleRequestPB))
+			},
+		},
+		{
+			MethodName:  "DeleteRole",
+			NewRequest:  func() proto.Message { return &DeleteRoleRequestPB{} },
+			NewResponse: func() proto.Message { return &DeleteRoleResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).DeleteRole(ctx, request.(*DeleteRoleRequestPB))
+			},
+		},
+		{
+			MethodName:  "GrantRevokeRole",
+			NewRequest:  func() proto.Message { return &GrantRevokeRoleRequestPB{} },
+			NewResponse: func() proto.Message { return &GrantRevokeRoleResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).GrantRevokeRole(ctx, request.(*GrantRevokeRoleRequestPB))
+			},
+		},
+		{
+			MethodName:  "GrantRevokePermission",
+			NewRequest:  func() proto.Message { return &GrantRevokePermissionRequestPB{} },
+			NewResponse: func() proto.Message { return &GrantRevokePermissionResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).GrantRevokePermission(ctx, request.(*GrantRevokePermissionRequestPB))
+			},
+		},
+		{
+			MethodName:  "GetPermissions",
+			NewRequest:  func() proto.Message { return &GetPermissionsRequestPB{} },
+			NewResponse: func() proto.Message { return &GetPermissionsResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).GetPermissions(ctx, request.(*GetPermissionsRequestPB))
+			},
+		},
+		{
+			MethodName:  "CreateUDType",
+			NewRequest:  func() proto.Message { return &CreateUDTypeRequestPB{} },
+			NewResponse: func() proto.Message { return &CreateUDTypeResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).CreateUDType(ctx, request.(*CreateUDTypeRequestPB))
+			},
+		},
+		{
+			MethodName:  "DeleteUDType",
+			NewRequest:  func() proto.Message { return &DeleteUDTypeRequestPB{} },
+			NewResponse: func() proto.Message { return &DeleteUDTypeResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).DeleteUDType(ctx, request.(*DeleteUDTypeRequestPB))
+			},
+		},
+		{
+			MethodName:  "ListUDTypes",
+			NewRequest:  func() proto.Message { return &ListUDTypesRequestPB{} },
+			NewResponse: func() proto.Message { return &ListUDTypesResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).ListUDTypes(ctx, request.(*ListUDTypesRequestPB))
+			},
+		},
+		{
+			MethodName:  "GetUDTypeInfo",
+			NewRequest:  func() proto.Message { return &GetUDTypeInfoRequestPB{} },
+			NewResponse: func() proto.Message { return &GetUDTypeInfoResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).GetUDTypeInfo(ctx, request.(*GetUDTypeInfoRequestPB))
+			},
+		},
+		{
+			MethodName:  "CreateCDCStream",
+			NewRequest:  func() proto.Message { return &CreateCDCStreamRequestPB{} },
+			NewResponse: func() proto.Message { return &CreateCDCStreamResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).CreateCDCStream(ctx, request.(*CreateCDCStreamRequestPB))
+			},
+		},
+		{
+			MethodName:  "DeleteCDCStream",
+			NewRequest:  func() proto.Message { return &DeleteCDCStreamRequestPB{} },
+			NewResponse: func() proto.Message { return &DeleteCDCStreamResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).DeleteCDCStream(ctx, request.(*DeleteCDCStreamRequestPB))
+			},
+		},
+		{
+			MethodName:  "ListCDCStreams",
+			NewRequest:  func() proto.Message { return &ListCDCStreamsRequestPB{} },
+			NewResponse: func() proto.Message { return &ListCDCStreamsResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).ListCDCStreams(ctx, request.(*ListCDCStreamsRequestPB))
+			},
+		},
+		{
+			MethodName:  "GetCDCStream",
+			NewRequest:  func() proto.Message { return &GetCDCStreamRequestPB{} },
+			NewResponse: func() proto.Message { return &GetCDCStreamResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).GetCDCStream(ctx, request.(*GetCDCStreamRequestPB))
+			},
+		},
+		{
+			MethodName:  "RedisConfigSet",
+			NewRequest:  func() proto.Message { return &RedisConfigSetRequestPB{} },
+			NewResponse: func() proto.Message { return &RedisConfigSetResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).RedisConfigSet(ctx, request.(*RedisConfigSetRequestPB))
+			},
+		},
+		{
+			MethodName:  "RedisConfigGet",
+			NewRequest:  func() proto.Message { return &RedisConfigGetRequestPB{} },
+			NewResponse: func() proto.Message { return &RedisConfigGetResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).RedisConfigGet(ctx, request.(*RedisConfigGetRequestPB))
+			},
+		},
+		{
+			MethodName:  "ListTabletServers",
+			NewRequest:  func() proto.Message { return &ListTabletServersRequestPB{} },
+			NewResponse: func() proto.Message { return &ListTabletServersResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).ListTabletServers(ctx, request.(*ListTabletServersRequestPB))
+			},
+		},
+		{
+			MethodName:  "ListMasters",
+			NewRequest:  func() proto.Message { return &ListMastersRequestPB{} },
+			NewResponse: func() proto.Message { return &ListMastersResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).ListMasters(ctx, request.(*ListMastersRequestPB))
+			},
+		},
+		{
+			MethodName:  "ListMasterRaftPeers",
+			NewRequest:  func() proto.Message { return &ListMasterRaftPeersRequestPB{} },
+			NewResponse: func() proto.Message { return &ListMasterRaftPeersResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).ListMasterRaftPeers(ctx, request.(*ListMasterRaftPeersRequestPB))
+			},
+		},
+		{
+			MethodName:  "GetMasterRegistration",
+			NewRequest:  func() proto.Message { return &GetMasterRegistrationRequestPB{} },
+			NewResponse: func() proto.Message { return &GetMasterRegistrationResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).GetMasterRegistration(ctx, request.(*GetMasterRegistrationRequestPB))
+			},
+		},
+		{
+			MethodName:  "IsMasterLeaderServiceReady",
+			NewRequest:  func() proto.Message { return &IsMasterLeaderReadyRequestPB{} },
+			NewResponse: func() proto.Message { return &IsMasterLeaderReadyResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).IsMasterLeaderServiceReady(ctx, request.(*IsMasterLeaderReadyRequestPB))
+			},
+		},
+		{
+			MethodName:  "DumpState",
+			NewRequest:  func() proto.Message { return &DumpMasterStateRequestPB{} },
+			NewResponse: func() proto.Message { return &DumpMasterStateResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).DumpState(ctx, request.(*DumpMasterStateRequestPB))
+			},
+		},
+		{
+			MethodName:  "ChangeLoadBalancerState",
+			NewRequest:  func() proto.Message { return &ChangeLoadBalancerStateRequestPB{} },
+			NewResponse: func() proto.Message { return &ChangeLoadBalancerStateResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).ChangeLoadBalancerState(ctx, request.(*ChangeLoadBalancerStateRequestPB))
+			},
+		},
+		{
+			MethodName:  "GetLoadBalancerState",
+			NewRequest:  func() proto.Message { return &GetLoadBalancerStateRequestPB{} },
+			NewResponse: func() proto.Message { return &GetLoadBalancerStateResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).GetLoadBalancerState(ctx, request.(*GetLoadBalancerStateRequestPB))
+			},
+		},
+		{
+			MethodName:  "RemovedMasterUpdate",
+			NewRequest:  func() proto.Message { return &RemovedMasterUpdateRequestPB{} },
+			NewResponse: func() proto.Message { return &RemovedMasterUpdateResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).RemovedMasterUpdate(ctx, request.(*RemovedMasterUpdateRequestPB))
+			},
+		},
+		{
+			MethodName:  "SetPreferredZones",
+			NewRequest:  func() proto.Message { return &SetPreferredZonesRequestPB{} },
+			NewResponse: func() proto.Message { return &SetPreferredZonesResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).SetPreferredZones(ctx, request.(*SetPreferredZonesRequestPB))
+			},
+		},
+		{
+			MethodName:  "GetMasterClusterConfig",
+			NewRequest:  func() proto.Message { return &GetMasterClusterConfigRequestPB{} },
+			NewResponse: func() proto.Message { return &GetMasterClusterConfigResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).GetMasterClusterConfig(ctx, request.(*GetMasterClusterConfigRequestPB))
+			},
+		},
+		{
+			MethodName:  "ChangeMasterClusterConfig",
+			NewRequest:  func() proto.Message { return &ChangeMasterClusterConfigRequestPB{} },
+			NewResponse: func() proto.Message { return &ChangeMasterClusterConfigResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).ChangeMasterClusterConfig(ctx, request.(*ChangeMasterClusterConfigRequestPB))
+			},
+		},
+		{
+			MethodName:  "GetLoadMoveCompletion",
+			NewRequest:  func() proto.Message { return &GetLoadMovePercentRequestPB{} },
+			NewResponse: func() proto.Message { return &GetLoadMovePercentResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).GetLoadMoveCompletion(ctx, request.(*GetLoadMovePercentRequestPB))
+			},
+		},
+		{
+			MethodName:  "GetLeaderBlacklistCompletion",
+			NewRequest:  func() proto.Message { return &GetLeaderBlacklistPercentRequestPB{} },
+			NewResponse: func() proto.Message { return &GetLoadMovePercentResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).GetLeaderBlacklistCompletion(ctx, request.(*GetLeaderBlacklistPercentRequestPB))
+			},
+		},
+		{
+			MethodName:  "IsLoadBalanced",
+			NewRequest:  func() proto.Message { return &IsLoadBalancedRequestPB{} },
+			NewResponse: func() proto.Message { return &IsLoadBalancedResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).IsLoadBalanced(ctx, request.(*IsLoadBalancedRequestPB))
+			},
+		},
+		{
+			MethodName:  "IsLoadBalancerIdle",
+			NewRequest:  func() proto.Message { return &IsLoadBalancerIdleRequestPB{} },
+			NewResponse: func() proto.Message { return &IsLoadBalancerIdleResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).IsLoadBalancerIdle(ctx, request.(*IsLoadBalancerIdleRequestPB))
+			},
+		},
+		{
+			MethodName:  "AreLeadersOnPreferredOnly",
+			NewRequest:  func() proto.Message { return &AreLeadersOnPreferredOnlyRequestPB{} },
+			NewResponse: func() proto.Message { return &AreLeadersOnPreferredOnlyResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).AreLeadersOnPreferredOnly(ctx, request.(*AreLeadersOnPreferredOnlyRequestPB))
+			},
+		},
+		{
+			MethodName:  "FlushTables",
+			NewRequest:  func() proto.Message { return &FlushTablesRequestPB{} },
+			NewResponse: func() proto.Message { return &FlushTablesResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).FlushTables(ctx, request.(*FlushTablesRequestPB))
+			},
+		},
+		{
+			MethodName:  "IsFlushTablesDone",
+			NewRequest:  func() proto.Message { return &IsFlushTablesDoneRequestPB{} },
+			NewResponse: func() proto.Message { return &IsFlushTablesDoneResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).IsFlushTablesDone(ctx, request.(*IsFlushTablesDoneRequestPB))
+			},
+		},
+		{
+			MethodName:  "IsInitDbDone",
+			NewRequest:  func() proto.Message { return &IsInitDbDoneRequestPB{} },
+			NewResponse: func() proto.Message { return &IsInitDbDoneResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).IsInitDbDone(ctx, request.(*IsInitDbDoneRequestPB))
+			},
+		},
+		{
+			MethodName:  "ChangeEncryptionInfo",
+			NewRequest:  func() proto.Message { return &ChangeEncryptionInfoRequestPB{} },
+			NewResponse: func() proto.Message { return &ChangeEncryptionInfoResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).ChangeEncryptionInfo(ctx, request.(*ChangeEncryptionInfoRequestPB))
+			},
+		},
+		{
+			MethodName:  "IsEncryptionEnabled",
+			NewRequest:  func() proto.Message { return &IsEncryptionEnabledRequestPB{} },
+			NewResponse: func() proto.Message { return &IsEncryptionEnabledResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).IsEncryptionEnabled(ctx, request.(*IsEncryptionEnabledRequestPB))
+			},
+		},
+		{
+			MethodName:  "SetupUniverseReplication",
+			NewRequest:  func() proto.Message { return &SetupUniverseReplicationRequestPB{} },
+			NewResponse: func() proto.Message { return &SetupUniverseReplicationResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).SetupUniverseReplication(ctx, request.(*SetupUniverseReplicationRequestPB))
+			},
+		},
+		{
+			MethodName:  "DeleteUniverseReplication",
+			NewRequest:  func() proto.Message { return &DeleteUniverseReplicationRequestPB{} },
+			NewResponse: func() proto.Message { return &DeleteUniverseReplicationResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).DeleteUniverseReplication(ctx, request.(*DeleteUniverseReplicationRequestPB))
+			},
+		},
+		{
+			MethodName:  "AlterUniverseReplication",
+			NewRequest:  func() proto.Message { return &AlterUniverseReplicationRequestPB{} },
+			NewResponse: func() proto.Message { return &AlterUniverseReplicationResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).AlterUniverseReplication(ctx, request.(*AlterUniverseReplicationRequestPB))
+			},
+		},
+		{
+			MethodName:  "SetUniverseReplicationEnabled",
+			NewRequest:  func() proto.Message { return &SetUniverseReplicationEnabledRequestPB{} },
+			NewResponse: func() proto.Message { return &SetUniverseReplicationEnabledResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).SetUniverseReplicationEnabled(ctx, request.(*SetUniverseReplicationEnabledRequestPB))
+			},
+		},
+		{
+			MethodName:  "GetUniverseReplication",
+			NewRequest:  func() proto.Message { return &GetUniverseReplicationRequestPB{} },
+			NewResponse: func() proto.Message { return &GetUniverseReplicationResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).GetUniverseReplication(ctx, request.(*GetUniverseReplicationRequestPB))
+			},
+		},
+		{
+			MethodName:  "AddUniverseKeys",
+			NewRequest:  func() proto.Message { return &AddUniverseKeysRequestPB{} },
+			NewResponse: func() proto.Message { return &AddUniverseKeysResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).AddUniverseKeys(ctx, request.(*AddUniverseKeysRequestPB))
+			},
+		},
+		{
+			MethodName:  "GetUniverseKeyRegistry",
+			NewRequest:  func() proto.Message { return &GetUniverseKeyRegistryRequestPB{} },
+			NewResponse: func() proto.Message { return &GetUniverseKeyRegistryResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).GetUniverseKeyRegistry(ctx, request.(*GetUniverseKeyRegistryRequestPB))
+			},
+		},
+		{
+			MethodName:  "HasUniverseKeyInMemory",
+			NewRequest:  func() proto.Message { return &HasUniverseKeyInMemoryRequestPB{} },
+			NewResponse: func() proto.Message { return &HasUniverseKeyInMemoryResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).HasUniverseKeyInMemory(ctx, request.(*HasUniverseKeyInMemoryRequestPB))
+			},
+		},
+		{
+			MethodName:  "SplitTablet",
+			NewRequest:  func() proto.Message { return &SplitTabletRequestPB{} },
+			NewResponse: func() proto.Message { return &SplitTabletResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).SplitTablet(ctx, request.(*SplitTabletRequestPB))
+			},
+		},
+		{
+			MethodName:  "DeleteTablet",
+			NewRequest:  func() proto.Message { return &DeleteTabletRequestPB{} },
+			NewResponse: func() proto.Message { return &DeleteTabletResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(MasterServiceServer).DeleteTablet(ctx, request.(*DeleteTabletRequestPB))
+			},
+		},
+	},
+}
+
+// RegisterMasterService serves yb.master.MasterService with srv.
+func RegisterMasterService(r dispatch.Registrar, srv MasterServiceServer) {
+	r.RegisterService(&MasterService_ServiceDesc, srv)
+}
+
+func init() {
+	dispatch.RegisterServiceDesc(&MasterService_ServiceDesc)
+}
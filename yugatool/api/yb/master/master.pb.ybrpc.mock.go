@@ -0,0 +1,1040 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// The following only applies to changes made to this file as part of YugaByte development.
+//
+// Portions Copyright (c) YugaByte, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied.  See the License for the specific language governing permissions and limitations
+// under the License.
+//
+
+// Code generated by protoc-gen-ybrpc. DO NOT EDIT.
+
+package master
+
+import (
+	"context"
+
+	"github.com/yugabyte/yb-tools/protoc-gen-ybrpc/pkg/dispatch"
+	"github.com/yugabyte/yb-tools/protoc-gen-ybrpc/pkg/mock"
+)
+
+// MockMasterService is a MasterService for tests. Each method calls its stub function,
+// or fails with dispatch.ErrNoSuchMethod when the stub is not set, and every
+// call is recorded.
+type MockMasterService struct {
+	mock.Recorder
+
+	TSHeartbeatFunc                   func(ctx context.Context, request *TSHeartbeatRequestPB) (*TSHeartbeatResponsePB, error)
+	GetTabletLocationsFunc            func(ctx context.Context, request *GetTabletLocationsRequestPB) (*GetTabletLocationsResponsePB, error)
+	CreateTableFunc                   func(ctx context.Context, request *CreateTableRequestPB) (*CreateTableResponsePB, error)
+	IsCreateTableDoneFunc             func(ctx context.Context, request *IsCreateTableDoneRequestPB) (*IsCreateTableDoneResponsePB, error)
+	TruncateTableFunc                 func(ctx context.Context, request *TruncateTableRequestPB) (*TruncateTableResponsePB, error)
+	IsTruncateTableDoneFunc           func(ctx context.Context, request *IsTruncateTableDoneRequestPB) (*IsTruncateTableDoneResponsePB, error)
+	BackfillIndexFunc                 func(ctx context.Context, request *BackfillIndexRequestPB) (*BackfillIndexResponsePB, error)
+	LaunchBackfillIndexForTableFunc   func(ctx context.Context, request *LaunchBackfillIndexForTableRequestPB) (*LaunchBackfillIndexForTableResponsePB, error)
+	DeleteTableFunc                   func(ctx context.Context, request *DeleteTableRequestPB) (*DeleteTableResponsePB, error)
+	IsDeleteTableDoneFunc             func(ctx context.Context, request *IsDeleteTableDoneRequestPB) (*IsDeleteTableDoneResponsePB, error)
+	AlterTableFunc                    func(ctx context.Context, request *AlterTableRequestPB) (*AlterTableResponsePB, error)
+	IsAlterTableDoneFunc              func(ctx context.Context, request *IsAlterTableDoneRequestPB) (*IsAlterTableDoneResponsePB, error)
+	ListTablesFunc                    func(ctx context.Context, request *ListTablesRequestPB) (*ListTablesResponsePB, error)
+	GetTableLocationsFunc             func(ctx context.Context, request *GetTableLocationsRequestPB) (*GetTableLocationsResponsePB, error)
+	GetTableSchemaFunc                func(ctx context.Context, request *GetTableSchemaRequestPB) (*GetTableSchemaResponsePB, error)
+	GetColocatedTabletSchemaFunc      func(ctx context.Context, request *GetColocatedTabletSchemaRequestPB) (*GetColocatedTabletSchemaResponsePB, error)
+	CreateNamespaceFunc               func(ctx context.Context, request *CreateNamespaceRequestPB) (*CreateNamespaceResponsePB, error)
+	IsCreateNamespaceDoneFunc         func(ctx context.Context, request *IsCreateNamespaceDoneRequestPB) (*IsCreateNamespaceDoneResponsePB, error)
+	DeleteNamespaceFunc               func(ctx context.Context, request *DeleteNamespaceRequestPB) (*DeleteNamespaceResponsePB, error)
+	IsDeleteNamespaceDoneFunc         func(ctx context.Context, request *IsDeleteNamespaceDoneRequestPB) (*IsDeleteNamespaceDoneResponsePB, error)
+	AlterNamespaceFunc                func(ctx context.Context, request *AlterNamespaceRequestPB) (*AlterNamespaceResponsePB, error)
+	ListNamespacesFunc                func(ctx context.Context, request *ListNamespacesRequestPB) (*ListNamespacesResponsePB, error)
+	GetNamespaceInfoFunc              func(ctx context.Context, request *GetNamespaceInfoRequestPB) (*GetNamespaceInfoResponsePB, error)
+	CreateTablegroupFunc              func(ctx context.Context, request *CreateTablegroupRequestPB) (*CreateTablegroupResponsePB, error)
+	DeleteTablegroupFunc              func(ctx context.Context, request *DeleteTablegroupRequestPB) (*DeleteTablegroupResponsePB, error)
+	ListTablegroupsFunc               func(ctx context.Context, request *ListTablegroupsRequestPB) (*ListTablegroupsResponsePB, error)
+	ReservePgsqlOidsFunc              func(ctx context.Context, request *ReservePgsqlOidsRequestPB) (*ReservePgsqlOidsResponsePB, error)
+	GetYsqlCatalogConfigFunc          func(ctx context.Context, request *GetYsqlCatalogConfigRequestPB) (*GetYsqlCatalogConfigResponsePB, error)
+	CreateRoleFunc                    func(ctx context.Context, request *CreateRoleRequestPB) (*CreateRoleResponsePB, error)
+	AlterRoleFunc                     func(ctx context.Context, request *AlterRoleRequestPB) (*AlterRoleResponsePB, error)
+	DeleteRoleFunc                    func(ctx context.Context, request *DeleteRoleRequestPB) (*DeleteRoleResponsePB, error)
+	GrantRevokeRoleFunc               func(ctx context.Context, request *GrantRevokeRoleRequestPB) (*GrantRevokeRoleResponsePB, error)
+	GrantRevokePermissionFunc         func(ctx context.Context, request *GrantRevokePermissionRequestPB) (*GrantRevokePermissionResponsePB, error)
+	GetPermissionsFunc                func(ctx context.Context, request *GetPermissionsRequestPB) (*GetPermissionsResponsePB, error)
+	CreateUDTypeFunc                  func(ctx context.Context, request *CreateUDTypeRequestPB) (*CreateUDTypeResponsePB, error)
+	DeleteUDTypeFunc                  func(ctx context.Context, request *DeleteUDTypeRequestPB) (*DeleteUDTypeResponsePB, error)
+	ListUDTypesFunc                   func(ctx context.Context, request *ListUDTypesRequestPB) (*ListUDTypesResponsePB, error)
+	GetUDTypeInfoFunc                 func(ctx context.Context, request *GetUDTypeInfoRequestPB) (*GetUDTypeInfoResponsePB, error)
+	CreateCDCStreamFunc               func(ctx context.Context, request *CreateCDCStreamRequestPB) (*CreateCDCStreamResponsePB, error)
+	DeleteCDCStreamFunc               func(ctx context.Context, request *DeleteCDCStreamRequestPB) (*DeleteCDCStreamResponsePB, error)
+	ListCDCStreamsFunc                func(ctx context.Context, request *ListCDCStreamsRequestPB) (*ListCDCStreamsResponsePB, error)
+	GetCDCStreamFunc                  func(ctx context.Context, request *GetCDCStreamRequestPB) (*GetCDCStreamResponsePB, error)
+	RedisConfigSetFunc                func(ctx context.Context, request *RedisConfigSetRequestPB) (*RedisConfigSetResponsePB, error)
+	RedisConfigGetFunc                func(ctx context.Context, request *RedisConfigGetRequestPB) (*RedisConfigGetResponsePB, error)
+	ListTabletServersFunc             func(ctx context.Context, request *ListTabletServersRequestPB) (*ListTabletServersResponsePB, error)
+	ListMastersFunc                   func(ctx context.Context, request *ListMastersRequestPB) (*ListMastersResponsePB, error)
+	ListMasterRaftPeersFunc           func(ctx context.Context, request *ListMasterRaftPeersRequestPB) (*ListMasterRaftPeersResponsePB, error)
+	GetMasterRegistrationFunc         func(ctx context.Context, request *GetMasterRegistrationRequestPB) (*GetMasterRegistrationResponsePB, error)
+	IsMasterLeaderServiceReadyFunc    func(ctx context.Context, request *IsMasterLeaderReadyRequestPB) (*IsMasterLeaderReadyResponsePB, error)
+	DumpStateFunc                     func(ctx context.Context, request *DumpMasterStateRequestPB) (*DumpMasterStateResponsePB, error)
+	ChangeLoadBalancerStateFunc       func(ctx context.Context, request *ChangeLoadBalancerStateRequestPB) (*ChangeLoadBalancerStateResponsePB, error)
+	GetLoadBalancerStateFunc          func(ctx context.Context, request *GetLoadBalancerStateRequestPB) (*GetLoadBalancerStateResponsePB, error)
+	RemovedMasterUpdateFunc           func(ctx context.Context, request *RemovedMasterUpdateRequestPB) (*RemovedMasterUpdateResponsePB, error)
+	SetPreferredZonesFunc             func(ctx context.Context, request *SetPreferredZonesRequestPB) (*SetPreferredZonesResponsePB, error)
+	GetMasterClusterConfigFunc        func(ctx context.Context, request *GetMasterClusterConfigRequestPB) (*GetMasterClusterConfigResponsePB, error)
+	ChangeMasterClusterConfigFunc     func(ctx context.Context, request *ChangeMasterClusterConfigRequestPB) (*ChangeMasterClusterConfigResponsePB, error)
+	GetLoadMoveCompletionFunc         func(ctx context.Context, request *GetLoadMovePercentRequestPB) (*GetLoadMovePercentResponsePB, error)
+	GetLeaderBlacklistCompletionFunc  func(ctx context.Context, request *GetLeaderBlacklistPercentRequestPB) (*GetLoadMovePercentResponsePB, error)
+	IsLoadBalancedFunc                func(ctx context.Context, request *IsLoadBalancedRequestPB) (*IsLoadBalancedResponsePB, error)
+	IsLoadBalancerIdleFunc            func(ctx context.Context, request *IsLoadBalancerIdleRequestPB) (*IsLoadBalancerIdleResponsePB, error)
+	AreLeadersOnPreferredOnlyFunc     func(ctx context.Context, request *AreLeadersOnPreferredOnlyRequestPB) (*AreLeadersOnPreferredOnlyResponsePB, error)
+	FlushTablesFunc                   func(ctx context.Context, request *FlushTablesRequestPB) (*FlushTablesResponsePB, error)
+	IsFlushTablesDoneFunc             func(ctx context.Context, request *IsFlushTablesDoneRequestPB) (*IsFlushTablesDoneResponsePB, error)
+	IsInitDbDoneFunc                  func(ctx context.Context, request *IsInitDbDoneRequestPB) (*IsInitDbDoneResponsePB, error)
+	ChangeEncryptionInfoFunc          func(ctx context.Context, request *ChangeEncryptionInfoRequestPB) (*ChangeEncryptionInfoResponsePB, error)
+	IsEncryptionEnabledFunc           func(ctx context.Context, request *IsEncryptionEnabledRequestPB) (*IsEncryptionEnabledResponsePB, error)
+	SetupUniverseReplicationFunc      func(ctx context.Context, request *SetupUniverseReplicationRequestPB) (*SetupUniverseReplicationResponsePB, error)
+	DeleteUniverseReplicationFunc     func(ctx context.Context, request *DeleteUniverseReplicationRequestPB) (*DeleteUniverseReplicationResponsePB, error)
+	AlterUniverseReplicationFunc      func(ctx context.Context, request *AlterUniverseReplicationRequestPB) (*AlterUniverseReplicationResponsePB, error)
+	SetUniverseReplicationEnabledFunc func(ctx context.Context, request *SetUniverseReplicationEnabledRequestPB) (*SetUniverseReplicationEnabledResponsePB, error)
+	GetUniverseReplicationFunc        func(ctx context.Context, request *GetUniverseReplicationRequestPB) (*GetUniverseReplicationResponsePB, error)
+	AddUniverseKeysFunc               func(ctx context.Context, request *AddUniverseKeysRequestPB) (*AddUniverseKeysResponsePB, error)
+	GetUniverseKeyRegistryFunc        func(ctx context.Context, request *GetUniverseKeyRegistryRequestPB) (*GetUniverseKeyRegistryResponsePB, error)
+	HasUniverseKeyInMemoryFunc        func(ctx context.Context, request *HasUniverseKeyInMemoryRequestPB) (*HasUniverseKeyInMemoryResponsePB, error)
+	SplitTabletFunc                   func(ctx context.Context, request *SplitTabletRequestPB) (*SplitTabletResponsePB, error)
+	DeleteTabletFunc                  func(ctx context.Context, request *DeleteTabletRequestPB) (*DeleteTabletResponsePB, error)
+}
+
+var _ MasterService = &MockMasterService{}
+
+func (s *MockMasterService) TSHeartbeat(request *TSHeartbeatRequestPB) (*TSHeartbeatResponsePB, error) {
+	return s.TSHeartbeatWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) TSHeartbeatWithContext(ctx context.Context, request *TSHeartbeatRequestPB) (*TSHeartbeatResponsePB, error) {
+	s.Record("TSHeartbeat", request)
+	if s.TSHeartbeatFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "TSHeartbeat")
+	}
+	return s.TSHeartbeatFunc(ctx, request)
+}
+
+func (s *MockMasterService) GetTabletLocations(request *GetTabletLocationsRequestPB) (*GetTabletLocationsResponsePB, error) {
+	return s.GetTabletLocationsWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) GetTabletLocationsWithContext(ctx context.Context, request *GetTabletLocationsRequestPB) (*GetTabletLocationsResponsePB, error) {
+	s.Record("GetTabletLocations", request)
+	if s.GetTabletLocationsFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "GetTabletLocations")
+	}
+	return s.GetTabletLocationsFunc(ctx, request)
+}
+
+func (s *MockMasterService) CreateTable(request *CreateTableRequestPB) (*CreateTableResponsePB, error) {
+	return s.CreateTableWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) CreateTableWithContext(ctx context.Context, request *CreateTableRequestPB) (*CreateTableResponsePB, error) {
+	s.Record("CreateTable", request)
+	if s.CreateTableFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "CreateTable")
+	}
+	return s.CreateTableFunc(ctx, request)
+}
+
+func (s *MockMasterService) IsCreateTableDone(request *IsCreateTableDoneRequestPB) (*IsCreateTableDoneResponsePB, error) {
+	return s.IsCreateTableDoneWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) IsCreateTableDoneWithContext(ctx context.Context, request *IsCreateTableDoneRequestPB) (*IsCreateTableDoneResponsePB, error) {
+	s.Record("IsCreateTableDone", request)
+	if s.IsCreateTableDoneFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "IsCreateTableDone")
+	}
+	return s.IsCreateTableDoneFunc(ctx, request)
+}
+
+func (s *MockMasterService) TruncateTable(request *TruncateTableRequestPB) (*TruncateTableResponsePB, error) {
+	return s.TruncateTableWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) TruncateTableWithContext(ctx context.Context, request *TruncateTableRequestPB) (*TruncateTableResponsePB, error) {
+	s.Record("TruncateTable", request)
+	if s.TruncateTableFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "TruncateTable")
+	}
+	return s.TruncateTableFunc(ctx, request)
+}
+
+func (s *MockMasterService) IsTruncateTableDone(request *IsTruncateTableDoneRequestPB) (*IsTruncateTableDoneResponsePB, error) {
+	return s.IsTruncateTableDoneWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) IsTruncateTableDoneWithContext(ctx context.Context, request *IsTruncateTableDoneRequestPB) (*IsTruncateTableDoneResponsePB, error) {
+	s.Record("IsTruncateTableDone", request)
+	if s.IsTruncateTableDoneFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "IsTruncateTableDone")
+	}
+	return s.IsTruncateTableDoneFunc(ctx, request)
+}
+
+func (s *MockMasterService) BackfillIndex(request *BackfillIndexRequestPB) (*BackfillIndexResponsePB, error) {
+	return s.BackfillIndexWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) BackfillIndexWithContext(ctx context.Context, request *BackfillIndexRequestPB) (*BackfillIndexResponsePB, error) {
+	s.Record("BackfillIndex", request)
+	if s.BackfillIndexFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "BackfillIndex")
+	}
+	return s.BackfillIndexFunc(ctx, request)
+}
+
+func (s *MockMasterService) LaunchBackfillIndexForTable(request *LaunchBackfillIndexForTableRequestPB) (*LaunchBackfillIndexForTableResponsePB, error) {
+	return s.LaunchBackfillIndexForTableWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) LaunchBackfillIndexForTableWithContext(ctx context.Context, request *LaunchBackfillIndexForTableRequestPB) (*LaunchBackfillIndexForTableResponsePB, error) {
+	s.Record("LaunchBackfillIndexForTable", request)
+	if s.LaunchBackfillIndexForTableFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "LaunchBackfillIndexForTable")
+	}
+	return s.LaunchBackfillIndexForTableFunc(ctx, request)
+}
+
+func (s *MockMasterService) DeleteTable(request *DeleteTableRequestPB) (*DeleteTableResponsePB, error) {
+	return s.DeleteTableWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) DeleteTableWithContext(ctx context.Context, request *DeleteTableRequestPB) (*DeleteTableResponsePB, error) {
+	s.Record("DeleteTable", request)
+	if s.DeleteTableFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "DeleteTable")
+	}
+	return s.DeleteTableFunc(ctx, request)
+}
+
+func (s *MockMasterService) IsDeleteTableDone(request *IsDeleteTableDoneRequestPB) (*IsDeleteTableDoneResponsePB, error) {
+	return s.IsDeleteTableDoneWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) IsDeleteTableDoneWithContext(ctx context.Context, request *IsDeleteTableDoneRequestPB) (*IsDeleteTableDoneResponsePB, error) {
+	s.Record("IsDeleteTableDone", request)
+	if s.IsDeleteTableDoneFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "IsDeleteTableDone")
+	}
+	return s.IsDeleteTableDoneFunc(ctx, request)
+}
+
+func (s *MockMasterService) AlterTable(request *AlterTableRequestPB) (*AlterTableResponsePB, error) {
+	return s.AlterTableWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) AlterTableWithContext(ctx context.Context, request *AlterTableRequestPB) (*AlterTableResponsePB, error) {
+	s.Record("AlterTable", request)
+	if s.AlterTableFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "AlterTable")
+	}
+	return s.AlterTableFunc(ctx, request)
+}
+
+func (s *MockMasterService) IsAlterTableDone(request *IsAlterTableDoneRequestPB) (*IsAlterTableDoneResponsePB, error) {
+	return s.IsAlterTableDoneWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) IsAlterTableDoneWithContext(ctx context.Context, request *IsAlterTableDoneRequestPB) (*IsAlterTableDoneResponsePB, error) {
+	s.Record("IsAlterTableDone", request)
+	if s.IsAlterTableDoneFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "IsAlterTableDone")
+	}
+	return s.IsAlterTableDoneFunc(ctx, request)
+}
+
+func (s *MockMasterService) ListTables(request *ListTablesRequestPB) (*ListTablesResponsePB, error) {
+	return s.ListTablesWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) ListTablesWithContext(ctx context.Context, request *ListTablesRequestPB) (*ListTablesResponsePB, error) {
+	s.Record("ListTables", request)
+	if s.ListTablesFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "ListTables")
+	}
+	return s.ListTablesFunc(ctx, request)
+}
+
+func (s *MockMasterService) GetTableLocations(request *GetTableLocationsRequestPB) (*GetTableLocationsResponsePB, error) {
+	return s.GetTableLocationsWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) GetTableLocationsWithContext(ctx context.Context, request *GetTableLocationsRequestPB) (*GetTableLocationsResponsePB, error) {
+	s.Record("GetTableLocations", request)
+	if s.GetTableLocationsFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "GetTableLocations")
+	}
+	return s.GetTableLocationsFunc(ctx, request)
+}
+
+func (s *MockMasterService) GetTableSchema(request *GetTableSchemaRequestPB) (*GetTableSchemaResponsePB, error) {
+	return s.GetTableSchemaWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) GetTableSchemaWithContext(ctx context.Context, request *GetTableSchemaRequestPB) (*GetTableSchemaResponsePB, error) {
+	s.Record("GetTableSchema", request)
+	if s.GetTableSchemaFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "GetTableSchema")
+	}
+	return s.GetTableSchemaFunc(ctx, request)
+}
+
+func (s *MockMasterService) GetColocatedTabletSchema(request *GetColocatedTabletSchemaRequestPB) (*GetColocatedTabletSchemaResponsePB, error) {
+	return s.GetColocatedTabletSchemaWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) GetColocatedTabletSchemaWithContext(ctx context.Context, request *GetColocatedTabletSchemaRequestPB) (*GetColocatedTabletSchemaResponsePB, error) {
+	s.Record("GetColocatedTabletSchema", request)
+	if s.GetColocatedTabletSchemaFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "GetColocatedTabletSchema")
+	}
+	return s.GetColocatedTabletSchemaFunc(ctx, request)
+}
+
+func (s *MockMasterService) CreateNamespace(request *CreateNamespaceRequestPB) (*CreateNamespaceResponsePB, error) {
+	return s.CreateNamespaceWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) CreateNamespaceWithContext(ctx context.Context, request *CreateNamespaceRequestPB) (*CreateNamespaceResponsePB, error) {
+	s.Record("CreateNamespace", request)
+	if s.CreateNamespaceFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "CreateNamespace")
+	}
+	return s.CreateNamespaceFunc(ctx, request)
+}
+
+func (s *MockMasterService) IsCreateNamespaceDone(request *IsCreateNamespaceDoneRequestPB) (*IsCreateNamespaceDoneResponsePB, error) {
+	return s.IsCreateNamespaceDoneWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) IsCreateNamespaceDoneWithContext(ctx context.Context, request *IsCreateNamespaceDoneRequestPB) (*IsCreateNamespaceDoneResponsePB, error) {
+	s.Record("IsCreateNamespaceDone", request)
+	if s.IsCreateNamespaceDoneFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "IsCreateNamespaceDone")
+	}
+	return s.IsCreateNamespaceDoneFunc(ctx, request)
+}
+
+func (s *MockMasterService) DeleteNamespace(request *DeleteNamespaceRequestPB) (*DeleteNamespaceResponsePB, error) {
+	return s.DeleteNamespaceWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) DeleteNamespaceWithContext(ctx context.Context, request *DeleteNamespaceRequestPB) (*DeleteNamespaceResponsePB, error) {
+	s.Record("DeleteNamespace", request)
+	if s.DeleteNamespaceFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "DeleteNamespace")
+	}
+	return s.DeleteNamespaceFunc(ctx, request)
+}
+
+func (s *MockMasterService) IsDeleteNamespaceDone(request *IsDeleteNamespaceDoneRequestPB) (*IsDeleteNamespaceDoneResponsePB, error) {
+	return s.IsDeleteNamespaceDoneWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) IsDeleteNamespaceDoneWithContext(ctx context.Context, request *IsDeleteNamespaceDoneRequestPB) (*IsDeleteNamespaceDoneResponsePB, error) {
+	s.Record("IsDeleteNamespaceDone", request)
+	if s.IsDeleteNamespaceDoneFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "IsDeleteNamespaceDone")
+	}
+	return s.IsDeleteNamespaceDoneFunc(ctx, request)
+}
+
+func (s *MockMasterService) AlterNamespace(request *AlterNamespaceRequestPB) (*AlterNamespaceResponsePB, error) {
+	return s.AlterNamespaceWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) AlterNamespaceWithContext(ctx context.Context, request *AlterNamespaceRequestPB) (*AlterNamespaceResponsePB, error) {
+	s.Record("AlterNamespace", request)
+	if s.AlterNamespaceFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "AlterNamespace")
+	}
+	return s.AlterNamespaceFunc(ctx, request)
+}
+
+func (s *MockMasterService) ListNamespaces(request *ListNamespacesRequestPB) (*ListNamespacesResponsePB, error) {
+	return s.ListNamespacesWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) ListNamespacesWithContext(ctx context.Context, request *ListNamespacesRequestPB) (*ListNamespacesResponsePB, error) {
+	s.Record("ListNamespaces", request)
+	if s.ListNamespacesFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "ListNamespaces")
+	}
+	return s.ListNamespacesFunc(ctx, request)
+}
+
+func (s *MockMasterService) GetNamespaceInfo(request *GetNamespaceInfoRequestPB) (*GetNamespaceInfoResponsePB, error) {
+	return s.GetNamespaceInfoWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) GetNamespaceInfoWithContext(ctx context.Context, request *GetNamespaceInfoRequestPB) (*GetNamespaceInfoResponsePB, error) {
+	s.Record("GetNamespaceInfo", request)
+	if s.GetNamespaceInfoFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "GetNamespaceInfo")
+	}
+	return s.GetNamespaceInfoFunc(ctx, request)
+}
+
+func (s *MockMasterService) CreateTablegroup(request *CreateTablegroupRequestPB) (*CreateTablegroupResponsePB, error) {
+	return s.CreateTablegroupWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) CreateTablegroupWithContext(ctx context.Context, request *CreateTablegroupRequestPB) (*CreateTablegroupResponsePB, error) {
+	s.Record("CreateTablegroup", request)
+	if s.CreateTablegroupFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "CreateTablegroup")
+	}
+	return s.CreateTablegroupFunc(ctx, request)
+}
+
+func (s *MockMasterService) DeleteTablegroup(request *DeleteTablegroupRequestPB) (*DeleteTablegroupResponsePB, error) {
+	return s.DeleteTablegroupWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) DeleteTablegroupWithContext(ctx context.Context, request *DeleteTablegroupRequestPB) (*DeleteTablegroupResponsePB, error) {
+	s.Record("DeleteTablegroup", request)
+	if s.DeleteTablegroupFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "DeleteTablegroup")
+	}
+	return s.DeleteTablegroupFunc(ctx, request)
+}
+
+func (s *MockMasterService) ListTablegroups(request *ListTablegroupsRequestPB) (*ListTablegroupsResponsePB, error) {
+	return s.ListTablegroupsWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) ListTablegroupsWithContext(ctx context.Context, request *ListTablegroupsRequestPB) (*ListTablegroupsResponsePB, error) {
+	s.Record("ListTablegroups", request)
+	if s.ListTablegroupsFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "ListTablegroups")
+	}
+	return s.ListTablegroupsFunc(ctx, request)
+}
+
+func (s *MockMasterService) ReservePgsqlOids(request *ReservePgsqlOidsRequestPB) (*ReservePgsqlOidsResponsePB, error) {
+	return s.ReservePgsqlOidsWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) ReservePgsqlOidsWithContext(ctx context.Context, request *ReservePgsqlOidsRequestPB) (*ReservePgsqlOidsResponsePB, error) {
+	s.Record("ReservePgsqlOids", request)
+	if s.ReservePgsqlOidsFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "ReservePgsqlOids")
+	}
+	return s.ReservePgsqlOidsFunc(ctx, request)
+}
+
+func (s *MockMasterService) GetYsqlCatalogConfig(request *GetYsqlCatalogConfigRequestPB) (*GetYsqlCatalogConfigResponsePB, error) {
+	return s.GetYsqlCatalogConfigWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) GetYsqlCatalogConfigWithContext(ctx context.Context, request *GetYsqlCatalogConfigRequestPB) (*GetYsqlCatalogConfigResponsePB, error) {
+	s.Record("GetYsqlCatalogConfig", request)
+	if s.GetYsqlCatalogConfigFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "GetYsqlCatalogConfig")
+	}
+	return s.GetYsqlCatalogConfigFunc(ctx, request)
+}
+
+func (s *MockMasterService) CreateRole(request *CreateRoleRequestPB) (*CreateRoleResponsePB, error) {
+	return s.CreateRoleWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) CreateRoleWithContext(ctx context.Context, request *CreateRoleRequestPB) (*CreateRoleResponsePB, error) {
+	s.Record("CreateRole", request)
+	if s.CreateRoleFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "CreateRole")
+	}
+	return s.CreateRoleFunc(ctx, request)
+}
+
+func (s *MockMasterService) AlterRole(request *AlterRoleRequestPB) (*AlterRoleResponsePB, error) {
+	return s.AlterRoleWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) AlterRoleWithContext(ctx context.Context, request *AlterRoleRequestPB) (*AlterRoleResponsePB, error) {
+	s.Record("AlterRole", request)
+	if s.AlterRoleFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "AlterRole")
+	}
+	return s.AlterRoleFunc(ctx, request)
+}
+
+func (s *MockMasterService) DeleteRole(request *DeleteRoleRequestPB) (*DeleteRoleResponsePB, error) {
+	return s.DeleteRoleWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) DeleteRoleWithContext(ctx context.Context, request *DeleteRoleRequestPB) (*DeleteRoleResponsePB, error) {
+	s.Record("DeleteRole", request)
+	if s.DeleteRoleFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "DeleteRole")
+	}
+	return s.DeleteRoleFunc(ctx, request)
+}
+
+func (s *MockMasterService) GrantRevokeRole(request *GrantRevokeRoleRequestPB) (*GrantRevokeRoleResponsePB, error) {
+	return s.GrantRevokeRoleWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) GrantRevokeRoleWithContext(ctx context.Context, request *GrantRevokeRoleRequestPB) (*GrantRevokeRoleResponsePB, error) {
+	s.Record("GrantRevokeRole", request)
+	if s.GrantRevokeRoleFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "GrantRevokeRole")
+	}
+	return s.GrantRevokeRoleFunc(ctx, request)
+}
+
+func (s *MockMasterService) GrantRevokePermission(request *GrantRevokePermissionRequestPB) (*GrantRevokePermissionResponsePB, error) {
+	return s.GrantRevokePermissionWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) GrantRevokePermissionWithContext(ctx context.Context, request *GrantRevokePermissionRequestPB) (*GrantRevokePermissionResponsePB, error) {
+	s.Record("GrantRevokePermission", request)
+	if s.GrantRevokePermissionFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "GrantRevokePermission")
+	}
+	return s.GrantRevokePermissionFunc(ctx, request)
+}
+
+func (s *MockMasterService) GetPermissions(request *GetPermissionsRequestPB) (*GetPermissionsResponsePB, error) {
+	return s.GetPermissionsWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) GetPermissionsWithContext(ctx context.Context, request *GetPermissionsRequestPB) (*GetPermissionsResponsePB, error) {
+	s.Record("GetPermissions", request)
+	if s.GetPermissionsFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "GetPermissions")
+	}
+	return s.GetPermissionsFunc(ctx, request)
+}
+
+func (s *MockMasterService) CreateUDType(request *CreateUDTypeRequestPB) (*CreateUDTypeResponsePB, error) {
+	return s.CreateUDTypeWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) CreateUDTypeWithContext(ctx context.Context, request *CreateUDTypeRequestPB) (*CreateUDTypeResponsePB, error) {
+	s.Record("CreateUDType", request)
+	if s.CreateUDTypeFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "CreateUDType")
+	}
+	return s.CreateUDTypeFunc(ctx, request)
+}
+
+func (s *MockMasterService) DeleteUDType(request *DeleteUDTypeRequestPB) (*DeleteUDTypeResponsePB, error) {
+	return s.DeleteUDTypeWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) DeleteUDTypeWithContext(ctx context.Context, request *DeleteUDTypeRequestPB) (*DeleteUDTypeResponsePB, error) {
+	s.Record("DeleteUDType", request)
+	if s.DeleteUDTypeFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "DeleteUDType")
+	}
+	return s.DeleteUDTypeFunc(ctx, request)
+}
+
+func (s *MockMasterService) ListUDTypes(request *ListUDTypesRequestPB) (*ListUDTypesResponsePB, error) {
+	return s.ListUDTypesWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) ListUDTypesWithContext(ctx context.Context, request *ListUDTypesRequestPB) (*ListUDTypesResponsePB, error) {
+	s.Record("ListUDTypes", request)
+	if s.ListUDTypesFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "ListUDTypes")
+	}
+	return s.ListUDTypesFunc(ctx, request)
+}
+
+func (s *MockMasterService) GetUDTypeInfo(request *GetUDTypeInfoRequestPB) (*GetUDTypeInfoResponsePB, error) {
+	return s.GetUDTypeInfoWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) GetUDTypeInfoWithContext(ctx context.Context, request *GetUDTypeInfoRequestPB) (*GetUDTypeInfoResponsePB, error) {
+	s.Record("GetUDTypeInfo", request)
+	if s.GetUDTypeInfoFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "GetUDTypeInfo")
+	}
+	return s.GetUDTypeInfoFunc(ctx, request)
+}
+
+func (s *MockMasterService) CreateCDCStream(request *CreateCDCStreamRequestPB) (*CreateCDCStreamResponsePB, error) {
+	return s.CreateCDCStreamWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) CreateCDCStreamWithContext(ctx context.Context, request *CreateCDCStreamRequestPB) (*CreateCDCStreamResponsePB, error) {
+	s.Record("CreateCDCStream", request)
+	if s.CreateCDCStreamFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "CreateCDCStream")
+	}
+	return s.CreateCDCStreamFunc(ctx, request)
+}
+
+func (s *MockMasterService) DeleteCDCStream(request *DeleteCDCStreamRequestPB) (*DeleteCDCStreamResponsePB, error) {
+	return s.DeleteCDCStreamWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) DeleteCDCStreamWithContext(ctx context.Context, request *DeleteCDCStreamRequestPB) (*DeleteCDCStreamResponsePB, error) {
+	s.Record("DeleteCDCStream", request)
+	if s.DeleteCDCStreamFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "DeleteCDCStream")
+	}
+	return s.DeleteCDCStreamFunc(ctx, request)
+}
+
+func (s *MockMasterService) ListCDCStreams(request *ListCDCStreamsRequestPB) (*ListCDCStreamsResponsePB, error) {
+	return s.ListCDCStreamsWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) ListCDCStreamsWithContext(ctx context.Context, request *ListCDCStreamsRequestPB) (*ListCDCStreamsResponsePB, error) {
+	s.Record("ListCDCStreams", request)
+	if s.ListCDCStreamsFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "ListCDCStreams")
+	}
+	return s.ListCDCStreamsFunc(ctx, request)
+}
+
+func (s *MockMasterService) GetCDCStream(request *GetCDCStreamRequestPB) (*GetCDCStreamResponsePB, error) {
+	return s.GetCDCStreamWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) GetCDCStreamWithContext(ctx context.Context, request *GetCDCStreamRequestPB) (*GetCDCStreamResponsePB, error) {
+	s.Record("GetCDCStream", request)
+	if s.GetCDCStreamFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "GetCDCStream")
+	}
+	return s.GetCDCStreamFunc(ctx, request)
+}
+
+func (s *MockMasterService) RedisConfigSet(request *RedisConfigSetRequestPB) (*RedisConfigSetResponsePB, error) {
+	return s.RedisConfigSetWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) RedisConfigSetWithContext(ctx context.Context, request *RedisConfigSetRequestPB) (*RedisConfigSetResponsePB, error) {
+	s.Record("RedisConfigSet", request)
+	if s.RedisConfigSetFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "RedisConfigSet")
+	}
+	return s.RedisConfigSetFunc(ctx, request)
+}
+
+func (s *MockMasterService) RedisConfigGet(request *RedisConfigGetRequestPB) (*RedisConfigGetResponsePB, error) {
+	return s.RedisConfigGetWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) RedisConfigGetWithContext(ctx context.Context, request *RedisConfigGetRequestPB) (*RedisConfigGetResponsePB, error) {
+	s.Record("RedisConfigGet", request)
+	if s.RedisConfigGetFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "RedisConfigGet")
+	}
+	return s.RedisConfigGetFunc(ctx, request)
+}
+
+func (s *MockMasterService) ListTabletServers(request *ListTabletServersRequestPB) (*ListTabletServersResponsePB, error) {
+	return s.ListTabletServersWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) ListTabletServersWithContext(ctx context.Context, request *ListTabletServersRequestPB) (*ListTabletServersResponsePB, error) {
+	s.Record("ListTabletServers", request)
+	if s.ListTabletServersFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "ListTabletServers")
+	}
+	return s.ListTabletServersFunc(ctx, request)
+}
+
+func (s *MockMasterService) ListMasters(request *ListMastersRequestPB) (*ListMastersResponsePB, error) {
+	return s.ListMastersWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) ListMastersWithContext(ctx context.Context, request *ListMastersRequestPB) (*ListMastersResponsePB, error) {
+	s.Record("ListMasters", request)
+	if s.ListMastersFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "ListMasters")
+	}
+	return s.ListMastersFunc(ctx, request)
+}
+
+func (s *MockMasterService) ListMasterRaftPeers(request *ListMasterRaftPeersRequestPB) (*ListMasterRaftPeersResponsePB, error) {
+	return s.ListMasterRaftPeersWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) ListMasterRaftPeersWithContext(ctx context.Context, request *ListMasterRaftPeersRequestPB) (*ListMasterRaftPeersResponsePB, error) {
+	s.Record("ListMasterRaftPeers", request)
+	if s.ListMasterRaftPeersFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "ListMasterRaftPeers")
+	}
+	return s.ListMasterRaftPeersFunc(ctx, request)
+}
+
+func (s *MockMasterService) GetMasterRegistration(request *GetMasterRegistrationRequestPB) (*GetMasterRegistrationResponsePB, error) {
+	return s.GetMasterRegistrationWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) GetMasterRegistrationWithContext(ctx context.Context, request *GetMasterRegistrationRequestPB) (*GetMasterRegistrationResponsePB, error) {
+	s.Record("GetMasterRegistration", request)
+	if s.GetMasterRegistrationFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "GetMasterRegistration")
+	}
+	return s.GetMasterRegistrationFunc(ctx, request)
+}
+
+func (s *MockMasterService) IsMasterLeaderServiceReady(request *IsMasterLeaderReadyRequestPB) (*IsMasterLeaderReadyResponsePB, error) {
+	return s.IsMasterLeaderServiceReadyWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) IsMasterLeaderServiceReadyWithContext(ctx context.Context, request *IsMasterLeaderReadyRequestPB) (*IsMasterLeaderReadyResponsePB, error) {
+	s.Record("IsMasterLeaderServiceReady", request)
+	if s.IsMasterLeaderServiceReadyFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "IsMasterLeaderServiceReady")
+	}
+	return s.IsMasterLeaderServiceReadyFunc(ctx, request)
+}
+
+func (s *MockMasterService) DumpState(request *DumpMasterStateRequestPB) (*DumpMasterStateResponsePB, error) {
+	return s.DumpStateWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) DumpStateWithContext(ctx context.Context, request *DumpMasterStateRequestPB) (*DumpMasterStateResponsePB, error) {
+	s.Record("DumpState", request)
+	if s.DumpStateFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "DumpState")
+	}
+	return s.DumpStateFunc(ctx, request)
+}
+
+func (s *MockMasterService) ChangeLoadBalancerState(request *ChangeLoadBalancerStateRequestPB) (*ChangeLoadBalancerStateResponsePB, error) {
+	return s.ChangeLoadBalancerStateWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) ChangeLoadBalancerStateWithContext(ctx context.Context, request *ChangeLoadBalancerStateRequestPB) (*ChangeLoadBalancerStateResponsePB, error) {
+	s.Record("ChangeLoadBalancerState", request)
+	if s.ChangeLoadBalancerStateFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "ChangeLoadBalancerState")
+	}
+	return s.ChangeLoadBalancerStateFunc(ctx, request)
+}
+
+func (s *MockMasterService) GetLoadBalancerState(request *GetLoadBalancerStateRequestPB) (*GetLoadBalancerStateResponsePB, error) {
+	return s.GetLoadBalancerStateWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) GetLoadBalancerStateWithContext(ctx context.Context, request *GetLoadBalancerStateRequestPB) (*GetLoadBalancerStateResponsePB, error) {
+	s.Record("GetLoadBalancerState", request)
+	if s.GetLoadBalancerStateFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "GetLoadBalancerState")
+	}
+	return s.GetLoadBalancerStateFunc(ctx, request)
+}
+
+func (s *MockMasterService) RemovedMasterUpdate(request *RemovedMasterUpdateRequestPB) (*RemovedMasterUpdateResponsePB, error) {
+	return s.RemovedMasterUpdateWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) RemovedMasterUpdateWithContext(ctx context.Context, request *RemovedMasterUpdateRequestPB) (*RemovedMasterUpdateResponsePB, error) {
+	s.Record("RemovedMasterUpdate", request)
+	if s.RemovedMasterUpdateFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "RemovedMasterUpdate")
+	}
+	return s.RemovedMasterUpdateFunc(ctx, request)
+}
+
+func (s *MockMasterService) SetPreferredZones(request *SetPreferredZonesRequestPB) (*SetPreferredZonesResponsePB, error) {
+	return s.SetPreferredZonesWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) SetPreferredZonesWithContext(ctx context.Context, request *SetPreferredZonesRequestPB) (*SetPreferredZonesResponsePB, error) {
+	s.Record("SetPreferredZones", request)
+	if s.SetPreferredZonesFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "SetPreferredZones")
+	}
+	return s.SetPreferredZonesFunc(ctx, request)
+}
+
+func (s *MockMasterService) GetMasterClusterConfig(request *GetMasterClusterConfigRequestPB) (*GetMasterClusterConfigResponsePB, error) {
+	return s.GetMasterClusterConfigWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) GetMasterClusterConfigWithContext(ctx context.Context, request *GetMasterClusterConfigRequestPB) (*GetMasterClusterConfigResponsePB, error) {
+	s.Record("GetMasterClusterConfig", request)
+	if s.GetMasterClusterConfigFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "GetMasterClusterConfig")
+	}
+	return s.GetMasterClusterConfigFunc(ctx, request)
+}
+
+func (s *MockMasterService) ChangeMasterClusterConfig(request *ChangeMasterClusterConfigRequestPB) (*ChangeMasterClusterConfigResponsePB, error) {
+	return s.ChangeMasterClusterConfigWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) ChangeMasterClusterConfigWithContext(ctx context.Context, request *ChangeMasterClusterConfigRequestPB) (*ChangeMasterClusterConfigResponsePB, error) {
+	s.Record("ChangeMasterClusterConfig", request)
+	if s.ChangeMasterClusterConfigFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "ChangeMasterClusterConfig")
+	}
+	return s.ChangeMasterClusterConfigFunc(ctx, request)
+}
+
+func (s *MockMasterService) GetLoadMoveCompletion(request *GetLoadMovePercentRequestPB) (*GetLoadMovePercentResponsePB, error) {
+	return s.GetLoadMoveCompletionWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) GetLoadMoveCompletionWithContext(ctx context.Context, request *GetLoadMovePercentRequestPB) (*GetLoadMovePercentResponsePB, error) {
+	s.Record("GetLoadMoveCompletion", request)
+	if s.GetLoadMoveCompletionFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "GetLoadMoveCompletion")
+	}
+	return s.GetLoadMoveCompletionFunc(ctx, request)
+}
+
+func (s *MockMasterService) GetLeaderBlacklistCompletion(request *GetLeaderBlacklistPercentRequestPB) (*GetLoadMovePercentResponsePB, error) {
+	return s.GetLeaderBlacklistCompletionWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) GetLeaderBlacklistCompletionWithContext(ctx context.Context, request *GetLeaderBlacklistPercentRequestPB) (*GetLoadMovePercentResponsePB, error) {
+	s.Record("GetLeaderBlacklistCompletion", request)
+	if s.GetLeaderBlacklistCompletionFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "GetLeaderBlacklistCompletion")
+	}
+	return s.GetLeaderBlacklistCompletionFunc(ctx, request)
+}
+
+func (s *MockMasterService) IsLoadBalanced(request *IsLoadBalancedRequestPB) (*IsLoadBalancedResponsePB, error) {
+	return s.IsLoadBalancedWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) IsLoadBalancedWithContext(ctx context.Context, request *IsLoadBalancedRequestPB) (*IsLoadBalancedResponsePB, error) {
+	s.Record("IsLoadBalanced", request)
+	if s.IsLoadBalancedFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "IsLoadBalanced")
+	}
+	return s.IsLoadBalancedFunc(ctx, request)
+}
+
+func (s *MockMasterService) IsLoadBalancerIdle(request *IsLoadBalancerIdleRequestPB) (*IsLoadBalancerIdleResponsePB, error) {
+	return s.IsLoadBalancerIdleWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) IsLoadBalancerIdleWithContext(ctx context.Context, request *IsLoadBalancerIdleRequestPB) (*IsLoadBalancerIdleResponsePB, error) {
+	s.Record("IsLoadBalancerIdle", request)
+	if s.IsLoadBalancerIdleFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "IsLoadBalancerIdle")
+	}
+	return s.IsLoadBalancerIdleFunc(ctx, request)
+}
+
+func (s *MockMasterService) AreLeadersOnPreferredOnly(request *AreLeadersOnPreferredOnlyRequestPB) (*AreLeadersOnPreferredOnlyResponsePB, error) {
+	return s.AreLeadersOnPreferredOnlyWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) AreLeadersOnPreferredOnlyWithContext(ctx context.Context, request *AreLeadersOnPreferredOnlyRequestPB) (*AreLeadersOnPreferredOnlyResponsePB, error) {
+	s.Record("AreLeadersOnPreferredOnly", request)
+	if s.AreLeadersOnPreferredOnlyFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "AreLeadersOnPreferredOnly")
+	}
+	return s.AreLeadersOnPreferredOnlyFunc(ctx, request)
+}
+
+func (s *MockMasterService) FlushTables(request *FlushTablesRequestPB) (*FlushTablesResponsePB, error) {
+	return s.FlushTablesWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) FlushTablesWithContext(ctx context.Context, request *FlushTablesRequestPB) (*FlushTablesResponsePB, error) {
+	s.Record("FlushTables", request)
+	if s.FlushTablesFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "FlushTables")
+	}
+	return s.FlushTablesFunc(ctx, request)
+}
+
+func (s *MockMasterService) IsFlushTablesDone(request *IsFlushTablesDoneRequestPB) (*IsFlushTablesDoneResponsePB, error) {
+	return s.IsFlushTablesDoneWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) IsFlushTablesDoneWithContext(ctx context.Context, request *IsFlushTablesDoneRequestPB) (*IsFlushTablesDoneResponsePB, error) {
+	s.Record("IsFlushTablesDone", request)
+	if s.IsFlushTablesDoneFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "IsFlushTablesDone")
+	}
+	return s.IsFlushTablesDoneFunc(ctx, request)
+}
+
+func (s *MockMasterService) IsInitDbDone(request *IsInitDbDoneRequestPB) (*IsInitDbDoneResponsePB, error) {
+	return s.IsInitDbDoneWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) IsInitDbDoneWithContext(ctx context.Context, request *IsInitDbDoneRequestPB) (*IsInitDbDoneResponsePB, error) {
+	s.Record("IsInitDbDone", request)
+	if s.IsInitDbDoneFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "IsInitDbDone")
+	}
+	return s.IsInitDbDoneFunc(ctx, request)
+}
+
+func (s *MockMasterService) ChangeEncryptionInfo(request *ChangeEncryptionInfoRequestPB) (*ChangeEncryptionInfoResponsePB, error) {
+	return s.ChangeEncryptionInfoWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) ChangeEncryptionInfoWithContext(ctx context.Context, request *ChangeEncryptionInfoRequestPB) (*ChangeEncryptionInfoResponsePB, error) {
+	s.Record("ChangeEncryptionInfo", request)
+	if s.ChangeEncryptionInfoFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "ChangeEncryptionInfo")
+	}
+	return s.ChangeEncryptionInfoFunc(ctx, request)
+}
+
+func (s *MockMasterService) IsEncryptionEnabled(request *IsEncryptionEnabledRequestPB) (*IsEncryptionEnabledResponsePB, error) {
+	return s.IsEncryptionEnabledWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) IsEncryptionEnabledWithContext(ctx context.Context, request *IsEncryptionEnabledRequestPB) (*IsEncryptionEnabledResponsePB, error) {
+	s.Record("IsEncryptionEnabled", request)
+	if s.IsEncryptionEnabledFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "IsEncryptionEnabled")
+	}
+	return s.IsEncryptionEnabledFunc(ctx, request)
+}
+
+func (s *MockMasterService) SetupUniverseReplication(request *SetupUniverseReplicationRequestPB) (*SetupUniverseReplicationResponsePB, error) {
+	return s.SetupUniverseReplicationWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) SetupUniverseReplicationWithContext(ctx context.Context, request *SetupUniverseReplicationRequestPB) (*SetupUniverseReplicationResponsePB, error) {
+	s.Record("SetupUniverseReplication", request)
+	if s.SetupUniverseReplicationFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "SetupUniverseReplication")
+	}
+	return s.SetupUniverseReplicationFunc(ctx, request)
+}
+
+func (s *MockMasterService) DeleteUniverseReplication(request *DeleteUniverseReplicationRequestPB) (*DeleteUniverseReplicationResponsePB, error) {
+	return s.DeleteUniverseReplicationWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) DeleteUniverseReplicationWithContext(ctx context.Context, request *DeleteUniverseReplicationRequestPB) (*DeleteUniverseReplicationResponsePB, error) {
+	s.Record("DeleteUniverseReplication", request)
+	if s.DeleteUniverseReplicationFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "DeleteUniverseReplication")
+	}
+	return s.DeleteUniverseReplicationFunc(ctx, request)
+}
+
+func (s *MockMasterService) AlterUniverseReplication(request *AlterUniverseReplicationRequestPB) (*AlterUniverseReplicationResponsePB, error) {
+	return s.AlterUniverseReplicationWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) AlterUniverseReplicationWithContext(ctx context.Context, request *AlterUniverseReplicationRequestPB) (*AlterUniverseReplicationResponsePB, error) {
+	s.Record("AlterUniverseReplication", request)
+	if s.AlterUniverseReplicationFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "AlterUniverseReplication")
+	}
+	return s.AlterUniverseReplicationFunc(ctx, request)
+}
+
+func (s *MockMasterService) SetUniverseReplicationEnabled(request *SetUniverseReplicationEnabledRequestPB) (*SetUniverseReplicationEnabledResponsePB, error) {
+	return s.SetUniverseReplicationEnabledWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) SetUniverseReplicationEnabledWithContext(ctx context.Context, request *SetUniverseReplicationEnabledRequestPB) (*SetUniverseReplicationEnabledResponsePB, error) {
+	s.Record("SetUniverseReplicationEnabled", request)
+	if s.SetUniverseReplicationEnabledFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "SetUniverseReplicationEnabled")
+	}
+	return s.SetUniverseReplicationEnabledFunc(ctx, request)
+}
+
+func (s *MockMasterService) GetUniverseReplication(request *GetUniverseReplicationRequestPB) (*GetUniverseReplicationResponsePB, error) {
+	return s.GetUniverseReplicationWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) GetUniverseReplicationWithContext(ctx context.Context, request *GetUniverseReplicationRequestPB) (*GetUniverseReplicationResponsePB, error) {
+	s.Record("GetUniverseReplication", request)
+	if s.GetUniverseReplicationFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "GetUniverseReplication")
+	}
+	return s.GetUniverseReplicationFunc(ctx, request)
+}
+
+func (s *MockMasterService) AddUniverseKeys(request *AddUniverseKeysRequestPB) (*AddUniverseKeysResponsePB, error) {
+	return s.AddUniverseKeysWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) AddUniverseKeysWithContext(ctx context.Context, request *AddUniverseKeysRequestPB) (*AddUniverseKeysResponsePB, error) {
+	s.Record("AddUniverseKeys", request)
+	if s.AddUniverseKeysFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "AddUniverseKeys")
+	}
+	return s.AddUniverseKeysFunc(ctx, request)
+}
+
+func (s *MockMasterService) GetUniverseKeyRegistry(request *GetUniverseKeyRegistryRequestPB) (*GetUniverseKeyRegistryResponsePB, error) {
+	return s.GetUniverseKeyRegistryWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) GetUniverseKeyRegistryWithContext(ctx context.Context, request *GetUniverseKeyRegistryRequestPB) (*GetUniverseKeyRegistryResponsePB, error) {
+	s.Record("GetUniverseKeyRegistry", request)
+	if s.GetUniverseKeyRegistryFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "GetUniverseKeyRegistry")
+	}
+	return s.GetUniverseKeyRegistryFunc(ctx, request)
+}
+
+func (s *MockMasterService) HasUniverseKeyInMemory(request *HasUniverseKeyInMemoryRequestPB) (*HasUniverseKeyInMemoryResponsePB, error) {
+	return s.HasUniverseKeyInMemoryWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) HasUniverseKeyInMemoryWithContext(ctx context.Context, request *HasUniverseKeyInMemoryRequestPB) (*HasUniverseKeyInMemoryResponsePB, error) {
+	s.Record("HasUniverseKeyInMemory", request)
+	if s.HasUniverseKeyInMemoryFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "HasUniverseKeyInMemory")
+	}
+	return s.HasUniverseKeyInMemoryFunc(ctx, request)
+}
+
+func (s *MockMasterService) SplitTablet(request *SplitTabletRequestPB) (*SplitTabletResponsePB, error) {
+	return s.SplitTabletWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) SplitTabletWithContext(ctx context.Context, request *SplitTabletRequestPB) (*SplitTabletResponsePB, error) {
+	s.Record("SplitTablet", request)
+	if s.SplitTabletFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "SplitTablet")
+	}
+	return s.SplitTabletFunc(ctx, request)
+}
+
+func (s *MockMasterService) DeleteTablet(request *DeleteTabletRequestPB) (*DeleteTabletResponsePB, error) {
+	return s.DeleteTabletWithContext(context.Background(), request)
+}
+
+func (s *MockMasterService) DeleteTabletWithContext(ctx context.Context, request *DeleteTabletRequestPB) (*DeleteTabletResponsePB, error) {
+	s.Record("DeleteTablet", request)
+	if s.DeleteTabletFunc == nil {
+		return nil, dispatch.Unimplemented("yb.master.MasterService", "DeleteTablet")
+	}
+	return s.DeleteTabletFunc(ctx, request)
+}
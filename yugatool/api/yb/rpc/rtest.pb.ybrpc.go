@@ -36,22 +36,35 @@
 package rpc
 
 import (
+	"context"
+
 	"github.com/go-logr/logr"
+	"github.com/yugabyte/yb-tools/protoc-gen-ybrpc/pkg/dispatch"
 	"github.com/yugabyte/yb-tools/protoc-gen-ybrpc/pkg/message"
+	"google.golang.org/protobuf/proto"
 )
 
 // service: yb.rpc_test.CalculatorService
 // service: CalculatorService
 type CalculatorService interface {
 	Add(request *AddRequestPB) (*AddResponsePB, error)
+	AddWithContext(ctx context.Context, request *AddRequestPB) (*AddResponsePB, error)
 	Sleep(request *SleepRequestPB) (*SleepResponsePB, error)
+	SleepWithContext(ctx context.Context, request *SleepRequestPB) (*SleepResponsePB, error)
 	Echo(request *EchoRequestPB) (*EchoResponsePB, error)
+	EchoWithContext(ctx context.Context, request *EchoRequestPB) (*EchoResponsePB, error)
 	WhoAmI(request *WhoAmIRequestPB) (*WhoAmIResponsePB, error)
+	WhoAmIWithContext(ctx context.Context, request *WhoAmIRequestPB) (*WhoAmIResponsePB, error)
 	TestArgumentsInDiffPackage(request *ReqDiffPackagePB) (*RespDiffPackagePB, error)
+	TestArgumentsInDiffPackageWithContext(ctx context.Context, request *ReqDiffPackagePB) (*RespDiffPackagePB, error)
 	Panic(request *PanicRequestPB) (*PanicResponsePB, error)
+	PanicWithContext(ctx context.Context, request *PanicRequestPB) (*PanicResponsePB, error)
 	Ping(request *PingRequestPB) (*PingResponsePB, error)
+	PingWithContext(ctx context.Context, request *PingRequestPB) (*PingResponsePB, error)
 	Disconnect(request *DisconnectRequestPB) (*DisconnectResponsePB, error)
+	DisconnectWithContext(ctx context.Context, request *DisconnectRequestPB) (*DisconnectResponsePB, error)
 	Forward(request *ForwardRequestPB) (*ForwardResponsePB, error)
+	ForwardWithContext(ctx context.Context, request *ForwardRequestPB) (*ForwardResponsePB, error)
 }
 
 type CalculatorServiceImpl struct {
@@ -60,10 +73,14 @@ type CalculatorServiceImpl struct {
 }
 
 func (s *CalculatorServiceImpl) Add(request *AddRequestPB) (*AddResponsePB, error) {
+	return s.AddWithContext(context.Background(), request)
+}
+
+func (s *CalculatorServiceImpl) AddWithContext(ctx context.Context, request *AddRequestPB) (*AddResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.rpc_test.CalculatorService", "method", "Add", "request", request)
 	response := &AddResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.rpc_test.CalculatorService", "Add", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.rpc_test.CalculatorService", "Add", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -74,10 +91,14 @@ func (s *CalculatorServiceImpl) Add(request *AddRequestPB) (*AddResponsePB, erro
 }
 
 func (s *CalculatorServiceImpl) Sleep(request *SleepRequestPB) (*SleepResponsePB, error) {
+	return s.SleepWithContext(context.Background(), request)
+}
+
+func (s *CalculatorServiceImpl) SleepWithContext(ctx context.Context, request *SleepRequestPB) (*SleepResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.rpc_test.CalculatorService", "method", "Sleep", "request", request)
 	response := &SleepResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.rpc_test.CalculatorService", "Sleep", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.rpc_test.CalculatorService", "Sleep", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -88,10 +109,14 @@ func (s *CalculatorServiceImpl) Sleep(request *SleepRequestPB) (*SleepResponsePB
 }
 
 func (s *CalculatorServiceImpl) Echo(request *EchoRequestPB) (*EchoResponsePB, error) {
+	return s.EchoWithContext(context.Background(), request)
+}
+
+func (s *CalculatorServiceImpl) EchoWithContext(ctx context.Context, request *EchoRequestPB) (*EchoResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.rpc_test.CalculatorService", "method", "Echo", "request", request)
 	response := &EchoResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.rpc_test.CalculatorService", "Echo", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.rpc_test.CalculatorService", "Echo", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -102,10 +127,14 @@ func (s *CalculatorServiceImpl) Echo(request *EchoRequestPB) (*EchoResponsePB, e
 }
 
 func (s *CalculatorServiceImpl) WhoAmI(request *WhoAmIRequestPB) (*WhoAmIResponsePB, error) {
+	return s.WhoAmIWithContext(context.Background(), request)
+}
+
+func (s *CalculatorServiceImpl) WhoAmIWithContext(ctx context.Context, request *WhoAmIRequestPB) (*WhoAmIResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.rpc_test.CalculatorService", "method", "WhoAmI", "request", request)
 	response := &WhoAmIResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.rpc_test.CalculatorService", "WhoAmI", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.rpc_test.CalculatorService", "WhoAmI", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -116,10 +145,14 @@ func (s *CalculatorServiceImpl) WhoAmI(request *WhoAmIRequestPB) (*WhoAmIRespons
 }
 
 func (s *CalculatorServiceImpl) TestArgumentsInDiffPackage(request *ReqDiffPackagePB) (*RespDiffPackagePB, error) {
+	return s.TestArgumentsInDiffPackageWithContext(context.Background(), request)
+}
+
+func (s *CalculatorServiceImpl) TestArgumentsInDiffPackageWithContext(ctx context.Context, request *ReqDiffPackagePB) (*RespDiffPackagePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.rpc_test.CalculatorService", "method", "TestArgumentsInDiffPackage", "request", request)
 	response := &RespDiffPackagePB{}
 
-	err := s.Messenger.SendMessage("yb.rpc_test.CalculatorService", "TestArgumentsInDiffPackage", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.rpc_test.CalculatorService", "TestArgumentsInDiffPackage", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -130,10 +163,14 @@ func (s *CalculatorServiceImpl) TestArgumentsInDiffPackage(request *ReqDiffPacka
 }
 
 func (s *CalculatorServiceImpl) Panic(request *PanicRequestPB) (*PanicResponsePB, error) {
+	return s.PanicWithContext(context.Background(), request)
+}
+
+func (s *CalculatorServiceImpl) PanicWithContext(ctx context.Context, request *PanicRequestPB) (*PanicResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.rpc_test.CalculatorService", "method", "Panic", "request", request)
 	response := &PanicResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.rpc_test.CalculatorService", "Panic", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.rpc_test.CalculatorService", "Panic", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -144,10 +181,14 @@ func (s *CalculatorServiceImpl) Panic(request *PanicRequestPB) (*PanicResponsePB
 }
 
 func (s *CalculatorServiceImpl) Ping(request *PingRequestPB) (*PingResponsePB, error) {
+	return s.PingWithContext(context.Background(), request)
+}
+
+func (s *CalculatorServiceImpl) PingWithContext(ctx context.Context, request *PingRequestPB) (*PingResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.rpc_test.CalculatorService", "method", "Ping", "request", request)
 	response := &PingResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.rpc_test.CalculatorService", "Ping", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.rpc_test.CalculatorService", "Ping", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -158,10 +199,14 @@ func (s *CalculatorServiceImpl) Ping(request *PingRequestPB) (*PingResponsePB, e
 }
 
 func (s *CalculatorServiceImpl) Disconnect(request *DisconnectRequestPB) (*DisconnectResponsePB, error) {
+	return s.DisconnectWithContext(context.Background(), request)
+}
+
+func (s *CalculatorServiceImpl) DisconnectWithContext(ctx context.Context, request *DisconnectRequestPB) (*DisconnectResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.rpc_test.CalculatorService", "method", "Disconnect", "request", request)
 	response := &DisconnectResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.rpc_test.CalculatorService", "Disconnect", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.rpc_test.CalculatorService", "Disconnect", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -172,10 +217,14 @@ func (s *CalculatorServiceImpl) Disconnect(request *DisconnectRequestPB) (*Disco
 }
 
 func (s *CalculatorServiceImpl) Forward(request *ForwardRequestPB) (*ForwardResponsePB, error) {
+	return s.ForwardWithContext(context.Background(), request)
+}
+
+func (s *CalculatorServiceImpl) ForwardWithContext(ctx context.Context, request *ForwardRequestPB) (*ForwardResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.rpc_test.CalculatorService", "method", "Forward", "request", request)
 	response := &ForwardResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.rpc_test.CalculatorService", "Forward", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.rpc_test.CalculatorService", "Forward", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -184,3 +233,144 @@ func (s *CalculatorServiceImpl) Forward(request *ForwardRequestPB) (*ForwardResp
 
 	return response, nil
 }
+
+// CalculatorServiceServer is the server API for yb.rpc_test.CalculatorService.
+type CalculatorServiceServer interface {
+	Add(ctx context.Context, request *AddRequestPB) (*AddResponsePB, error)
+	Sleep(ctx context.Context, request *SleepRequestPB) (*SleepResponsePB, error)
+	Echo(ctx context.Context, request *EchoRequestPB) (*EchoResponsePB, error)
+	WhoAmI(ctx context.Context, request *WhoAmIRequestPB) (*WhoAmIResponsePB, error)
+	TestArgumentsInDiffPackage(ctx context.Context, request *ReqDiffPackagePB) (*RespDiffPackagePB, error)
+	Panic(ctx context.Context, request *PanicRequestPB) (*PanicResponsePB, error)
+	Ping(ctx context.Context, request *PingRequestPB) (*PingResponsePB, error)
+	Disconnect(ctx context.Context, request *DisconnectRequestPB) (*DisconnectResponsePB, error)
+	Forward(ctx context.Context, request *ForwardRequestPB) (*ForwardResponsePB, error)
+}
+
+// UnimplementedCalculatorServiceServer can be embedded in implementations of CalculatorServiceServer that only serve
+// some of the methods. The others fail with dispatch.ErrNoSuchMethod.
+type UnimplementedCalculatorServiceServer struct{}
+
+func (UnimplementedCalculatorServiceServer) Add(ctx context.Context, request *AddRequestPB) (*AddResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.rpc_test.CalculatorService", "Add")
+}
+
+func (UnimplementedCalculatorServiceServer) Sleep(ctx context.Context, request *SleepRequestPB) (*SleepResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.rpc_test.CalculatorService", "Sleep")
+}
+
+func (UnimplementedCalculatorServiceServer) Echo(ctx context.Context, request *EchoRequestPB) (*EchoResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.rpc_test.CalculatorService", "Echo")
+}
+
+func (UnimplementedCalculatorServiceServer) WhoAmI(ctx context.Context, request *WhoAmIRequestPB) (*WhoAmIResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.rpc_test.CalculatorService", "WhoAmI")
+}
+
+func (UnimplementedCalculatorServiceServer) TestArgumentsInDiffPackage(ctx context.Context, request *ReqDiffPackagePB) (*RespDiffPackagePB, error) {
+	return nil, dispatch.Unimplemented("yb.rpc_test.CalculatorService", "TestArgumentsInDiffPackage")
+}
+
+func (UnimplementedCalculatorServiceServer) Panic(ctx context.Context, request *PanicRequestPB) (*PanicResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.rpc_test.CalculatorService", "Panic")
+}
+
+func (UnimplementedCalculatorServiceServer) Ping(ctx context.Context, request *PingRequestPB) (*PingResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.rpc_test.CalculatorService", "Ping")
+}
+
+func (UnimplementedCalculatorServiceServer) Disconnect(ctx context.Context, request *DisconnectRequestPB) (*DisconnectResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.rpc_test.CalculatorService", "Disconnect")
+}
+
+func (UnimplementedCalculatorServiceServer) Forward(ctx context.Context, request *ForwardRequestPB) (*ForwardResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.rpc_test.CalculatorService", "Forward")
+}
+
+var CalculatorService_ServiceDesc = dispatch.ServiceDesc{
+	ServiceName: "yb.rpc_test.CalculatorService",
+	HandlerType: (*CalculatorServiceServer)(nil),
+	Methods: []dispatch.MethodDesc{
+		{
+			MethodName:  "Add",
+			NewRequest:  func() proto.Message { return &AddRequestPB{} },
+			NewResponse: func() proto.Message { return &AddResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(CalculatorServiceServer).Add(ctx, request.(*AddRequestPB))
+			},
+		},
+		{
+			MethodName:  "Sleep",
+			NewRequest:  func() proto.Message { return &SleepRequestPB{} },
+			NewResponse: func() proto.Message { return &SleepResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(CalculatorServiceServer).Sleep(ctx, request.(*SleepRequestPB))
+			},
+		},
+		{
+			MethodName:  "Echo",
+			NewRequest:  func() proto.Message { return &EchoRequestPB{} },
+			NewResponse: func() proto.Message { return &EchoResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(CalculatorServiceServer).Echo(ctx, request.(*EchoRequestPB))
+			},
+		},
+		{
+			MethodName:  "WhoAmI",
+			NewRequest:  func() proto.Message { return &WhoAmIRequestPB{} },
+			NewResponse: func() proto.Message { return &WhoAmIResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(CalculatorServiceServer).WhoAmI(ctx, request.(*WhoAmIRequestPB))
+			},
+		},
+		{
+			MethodName:  "TestArgumentsInDiffPackage",
+			NewRequest:  func() proto.Message { return &ReqDiffPackagePB{} },
+			NewResponse: func() proto.Message { return &RespDiffPackagePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(CalculatorServiceServer).TestArgumentsInDiffPackage(ctx, request.(*ReqDiffPackagePB))
+			},
+		},
+		{
+			MethodName:  "Panic",
+			NewRequest:  func() proto.Message { return &PanicRequestPB{} },
+			NewResponse: func() proto.Message { return &PanicResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(CalculatorServiceServer).Panic(ctx, request.(*PanicRequestPB))
+			},
+		},
+		{
+			MethodName:  "Ping",
+			NewRequest:  func() proto.Message { return &PingRequestPB{} },
+			NewResponse: func() proto.Message { return &PingResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(CalculatorServiceServer).Ping(ctx, request.(*PingRequestPB))
+			},
+		},
+		{
+			MethodName:  "Disconnect",
+			NewRequest:  func() proto.Message { return &DisconnectRequestPB{} },
+			NewResponse: func() proto.Message { return &DisconnectResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(CalculatorServiceServer).Disconnect(ctx, request.(*DisconnectRequestPB))
+			},
+		},
+		{
+			MethodName:  "Forward",
+			NewRequest:  func() proto.Message { return &ForwardRequestPB{} },
+			NewResponse: func() proto.Message { return &ForwardResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(CalculatorServiceServer).Forward(ctx, request.(*ForwardRequestPB))
+			},
+		},
+	},
+}
+
+// RegisterCalculatorService serves yb.rpc_test.CalculatorService with srv.
+func RegisterCalculatorService(r dispatch.Registrar, srv CalculatorServiceServer) {
+	r.RegisterService(&CalculatorService_ServiceDesc, srv)
+}
+
+func init() {
+	dispatch.RegisterServiceDesc(&CalculatorService_ServiceDesc)
+}
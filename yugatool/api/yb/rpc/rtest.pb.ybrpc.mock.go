@@ -0,0 +1,170 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// The following only applies to changes made to this file as part of YugaByte development.
+//
+// Portions Copyright (c) YugaByte, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied.  See the License for the specific language governing permissions and limitations
+// under the License.
+//
+// Test protocol for yb RPC.
+
+// Code generated by protoc-gen-ybrpc. DO NOT EDIT.
+
+package rpc
+
+import (
+	"context"
+
+	"github.com/yugabyte/yb-tools/protoc-gen-ybrpc/pkg/dispatch"
+	"github.com/yugabyte/yb-tools/protoc-gen-ybrpc/pkg/mock"
+)
+
+// MockCalculatorService is a CalculatorService for tests. Each method calls its stub function,
+// or fails with dispatch.ErrNoSuchMethod when the stub is not set, and every
+// call is recorded.
+type MockCalculatorService struct {
+	mock.Recorder
+
+	AddFunc                        func(ctx context.Context, request *AddRequestPB) (*AddResponsePB, error)
+	SleepFunc                      func(ctx context.Context, request *SleepRequestPB) (*SleepResponsePB, error)
+	EchoFunc                       func(ctx context.Context, request *EchoRequestPB) (*EchoResponsePB, error)
+	WhoAmIFunc                     func(ctx context.Context, request *WhoAmIRequestPB) (*WhoAmIResponsePB, error)
+	TestArgumentsInDiffPackageFunc func(ctx context.Context, request *ReqDiffPackagePB) (*RespDiffPackagePB, error)
+	PanicFunc                      func(ctx context.Context, request *PanicRequestPB) (*PanicResponsePB, error)
+	PingFunc                       func(ctx context.Context, request *PingRequestPB) (*PingResponsePB, error)
+	DisconnectFunc                 func(ctx context.Context, request *DisconnectRequestPB) (*DisconnectResponsePB, error)
+	ForwardFunc                    func(ctx context.Context, request *ForwardRequestPB) (*ForwardResponsePB, error)
+}
+
+var _ CalculatorService = &MockCalculatorService{}
+
+func (s *MockCalculatorService) Add(request *AddRequestPB) (*AddResponsePB, error) {
+	return s.AddWithContext(context.Background(), request)
+}
+
+func (s *MockCalculatorService) AddWithContext(ctx context.Context, request *AddRequestPB) (*AddResponsePB, error) {
+	s.Record("Add", request)
+	if s.AddFunc == nil {
+		return nil, dispatch.Unimplemented("yb.rpc_test.CalculatorService", "Add")
+	}
+	return s.AddFunc(ctx, request)
+}
+
+func (s *MockCalculatorService) Sleep(request *SleepRequestPB) (*SleepResponsePB, error) {
+	return s.SleepWithContext(context.Background(), request)
+}
+
+func (s *MockCalculatorService) SleepWithContext(ctx context.Context, request *SleepRequestPB) (*SleepResponsePB, error) {
+	s.Record("Sleep", request)
+	if s.SleepFunc == nil {
+		return nil, dispatch.Unimplemented("yb.rpc_test.CalculatorService", "Sleep")
+	}
+	return s.SleepFunc(ctx, request)
+}
+
+func (s *MockCalculatorService) Echo(request *EchoRequestPB) (*EchoResponsePB, error) {
+	return s.EchoWithContext(context.Background(), request)
+}
+
+func (s *MockCalculatorService) EchoWithContext(ctx context.Context, request *EchoRequestPB) (*EchoResponsePB, error) {
+	s.Record("Echo", request)
+	if s.EchoFunc == nil {
+		return nil, dispatch.Unimplemented("yb.rpc_test.CalculatorService", "Echo")
+	}
+	return s.EchoFunc(ctx, request)
+}
+
+func (s *MockCalculatorService) WhoAmI(request *WhoAmIRequestPB) (*WhoAmIResponsePB, error) {
+	return s.WhoAmIWithContext(context.Background(), request)
+}
+
+func (s *MockCalculatorService) WhoAmIWithContext(ctx context.Context, request *WhoAmIRequestPB) (*WhoAmIResponsePB, error) {
+	s.Record("WhoAmI", request)
+	if s.WhoAmIFunc == nil {
+		return nil, dispatch.Unimplemented("yb.rpc_test.CalculatorService", "WhoAmI")
+	}
+	return s.WhoAmIFunc(ctx, request)
+}
+
+func (s *MockCalculatorService) TestArgumentsInDiffPackage(request *ReqDiffPackagePB) (*RespDiffPackagePB, error) {
+	return s.TestArgumentsInDiffPackageWithContext(context.Background(), request)
+}
+
+func (s *MockCalculatorService) TestArgumentsInDiffPackageWithContext(ctx context.Context, request *ReqDiffPackagePB) (*RespDiffPackagePB, error) {
+	s.Record("TestArgumentsInDiffPackage", request)
+	if s.TestArgumentsInDiffPackageFunc == nil {
+		return nil, dispatch.Unimplemented("yb.rpc_test.CalculatorService", "TestArgumentsInDiffPackage")
+	}
+	return s.TestArgumentsInDiffPackageFunc(ctx, request)
+}
+
+func (s *MockCalculatorService) Panic(request *PanicRequestPB) (*PanicResponsePB, error) {
+	return s.PanicWithContext(context.Background(), request)
+}
+
+func (s *MockCalculatorService) PanicWithContext(ctx context.Context, request *PanicRequestPB) (*PanicResponsePB, error) {
+	s.Record("Panic", request)
+	if s.PanicFunc == nil {
+		return nil, dispatch.Unimplemented("yb.rpc_test.CalculatorService", "Panic")
+	}
+	return s.PanicFunc(ctx, request)
+}
+
+func (s *MockCalculatorService) Ping(request *PingRequestPB) (*PingResponsePB, error) {
+	return s.PingWithContext(context.Background(), request)
+}
+
+func (s *MockCalculatorService) PingWithContext(ctx context.Context, request *PingRequestPB) (*PingResponsePB, error) {
+	s.Record("Ping", request)
+	if s.PingFunc == nil {
+		return nil, dispatch.Unimplemented("yb.rpc_test.CalculatorService", "Ping")
+	}
+	return s.PingFunc(ctx, request)
+}
+
+func (s *MockCalculatorService) Disconnect(request *DisconnectRequestPB) (*DisconnectResponsePB, error) {
+	return s.DisconnectWithContext(context.Background(), request)
+}
+
+func (s *MockCalculatorService) DisconnectWithContext(ctx context.Context, request *DisconnectRequestPB) (*DisconnectResponsePB, error) {
+	s.Record("Disconnect", request)
+	if s.DisconnectFunc == nil {
+		return nil, dispatch.Unimplemented("yb.rpc_test.CalculatorService", "Disconnect")
+	}
+	return s.DisconnectFunc(ctx, request)
+}
+
+func (s *MockCalculatorService) Forward(request *ForwardRequestPB) (*ForwardResponsePB, error) {
+	return s.ForwardWithContext(context.Background(), request)
+}
+
+func (s *MockCalculatorService) ForwardWithContext(ctx context.Context, request *ForwardRequestPB) (*ForwardResponsePB, error) {
+	s.Record("Forward", request)
+	if s.ForwardFunc == nil {
+		return nil, dispatch.Unimplemented("yb.rpc_test.CalculatorService", "Forward")
+	}
+	return s.ForwardFunc(ctx, request)
+}
@@ -35,20 +35,31 @@
 package server
 
 import (
+	"context"
+
 	"github.com/go-logr/logr"
+	"github.com/yugabyte/yb-tools/protoc-gen-ybrpc/pkg/dispatch"
 	"github.com/yugabyte/yb-tools/protoc-gen-ybrpc/pkg/message"
+	"google.golang.org/protobuf/proto"
 )
 
 // service: yb.server.GenericService
 // service: GenericService
 type GenericService interface {
 	SetFlag(request *SetFlagRequestPB) (*SetFlagResponsePB, error)
+	SetFlagWithContext(ctx context.Context, request *SetFlagRequestPB) (*SetFlagResponsePB, error)
 	GetFlag(request *GetFlagRequestPB) (*GetFlagResponsePB, error)
+	GetFlagWithContext(ctx context.Context, request *GetFlagRequestPB) (*GetFlagResponsePB, error)
 	RefreshFlags(request *RefreshFlagsRequestPB) (*RefreshFlagsResponsePB, error)
+	RefreshFlagsWithContext(ctx context.Context, request *RefreshFlagsRequestPB) (*RefreshFlagsResponsePB, error)
 	FlushCoverage(request *FlushCoverageRequestPB) (*FlushCoverageResponsePB, error)
+	FlushCoverageWithContext(ctx context.Context, request *FlushCoverageRequestPB) (*FlushCoverageResponsePB, error)
 	ServerClock(request *ServerClockRequestPB) (*ServerClockResponsePB, error)
+	ServerClockWithContext(ctx context.Context, request *ServerClockRequestPB) (*ServerClockResponsePB, error)
 	GetStatus(request *GetStatusRequestPB) (*GetStatusResponsePB, error)
+	GetStatusWithContext(ctx context.Context, request *GetStatusRequestPB) (*GetStatusResponsePB, error)
 	Ping(request *PingRequestPB) (*PingResponsePB, error)
+	PingWithContext(ctx context.Context, request *PingRequestPB) (*PingResponsePB, error)
 }
 
 type GenericServiceImpl struct {
@@ -57,10 +68,14 @@ type GenericServiceImpl struct {
 }
 
 func (s *GenericServiceImpl) SetFlag(request *SetFlagRequestPB) (*SetFlagResponsePB, error) {
+	return s.SetFlagWithContext(context.Background(), request)
+}
+
+func (s *GenericServiceImpl) SetFlagWithContext(ctx context.Context, request *SetFlagRequestPB) (*SetFlagResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.server.GenericService", "method", "SetFlag", "request", request)
 	response := &SetFlagResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.server.GenericService", "SetFlag", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.server.GenericService", "SetFlag", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -71,10 +86,14 @@ func (s *GenericServiceImpl) SetFlag(request *SetFlagRequestPB) (*SetFlagRespons
 }
 
 func (s *GenericServiceImpl) GetFlag(request *GetFlagRequestPB) (*GetFlagResponsePB, error) {
+	return s.GetFlagWithContext(context.Background(), request)
+}
+
+func (s *GenericServiceImpl) GetFlagWithContext(ctx context.Context, request *GetFlagRequestPB) (*GetFlagResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.server.GenericService", "method", "GetFlag", "request", request)
 	response := &GetFlagResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.server.GenericService", "GetFlag", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.server.GenericService", "GetFlag", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -85,10 +104,14 @@ func (s *GenericServiceImpl) GetFlag(request *GetFlagRequestPB) (*GetFlagRespons
 }
 
 func (s *GenericServiceImpl) RefreshFlags(request *RefreshFlagsRequestPB) (*RefreshFlagsResponsePB, error) {
+	return s.RefreshFlagsWithContext(context.Background(), request)
+}
+
+func (s *GenericServiceImpl) RefreshFlagsWithContext(ctx context.Context, request *RefreshFlagsRequestPB) (*RefreshFlagsResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.server.GenericService", "method", "RefreshFlags", "request", request)
 	response := &RefreshFlagsResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.server.GenericService", "RefreshFlags", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.server.GenericService", "RefreshFlags", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -99,10 +122,14 @@ func (s *GenericServiceImpl) RefreshFlags(request *RefreshFlagsRequestPB) (*Refr
 }
 
 func (s *GenericServiceImpl) FlushCoverage(request *FlushCoverageRequestPB) (*FlushCoverageResponsePB, error) {
+	return s.FlushCoverageWithContext(context.Background(), request)
+}
+
+func (s *GenericServiceImpl) FlushCoverageWithContext(ctx context.Context, request *FlushCoverageRequestPB) (*FlushCoverageResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.server.GenericService", "method", "FlushCoverage", "request", request)
 	response := &FlushCoverageResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.server.GenericService", "FlushCoverage", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.server.GenericService", "FlushCoverage", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -113,10 +140,14 @@ func (s *GenericServiceImpl) FlushCoverage(request *FlushCoverageRequestPB) (*Fl
 }
 
 func (s *GenericServiceImpl) ServerClock(request *ServerClockRequestPB) (*ServerClockResponsePB, error) {
+	return s.ServerClockWithContext(context.Background(), request)
+}
+
+func (s *GenericServiceImpl) ServerClockWithContext(ctx context.Context, request *ServerClockRequestPB) (*ServerClockResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.server.GenericService", "method", "ServerClock", "request", request)
 	response := &ServerClockResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.server.GenericService", "ServerClock", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.server.GenericService", "ServerClock", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -127,10 +158,14 @@ func (s *GenericServiceImpl) ServerClock(request *ServerClockRequestPB) (*Server
 }
 
 func (s *GenericServiceImpl) GetStatus(request *GetStatusRequestPB) (*GetStatusResponsePB, error) {
+	return s.GetStatusWithContext(context.Background(), request)
+}
+
+func (s *GenericServiceImpl) GetStatusWithContext(ctx context.Context, request *GetStatusRequestPB) (*GetStatusResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.server.GenericService", "method", "GetStatus", "request", request)
 	response := &GetStatusResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.server.GenericService", "GetStatus", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.server.GenericService", "GetStatus", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -141,10 +176,14 @@ func (s *GenericServiceImpl) GetStatus(request *GetStatusRequestPB) (*GetStatusR
 }
 
 func (s *GenericServiceImpl) Ping(request *PingRequestPB) (*PingResponsePB, error) {
+	return s.PingWithContext(context.Background(), request)
+}
+
+func (s *GenericServiceImpl) PingWithContext(ctx context.Context, request *PingRequestPB) (*PingResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.server.GenericService", "method", "Ping", "request", request)
 	response := &PingResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.server.GenericService", "Ping", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.server.GenericService", "Ping", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -153,3 +192,118 @@ func (s *GenericServiceImpl) Ping(request *PingRequestPB) (*PingResponsePB, erro
 
 	return response, nil
 }
+
+// GenericServiceServer is the server API for yb.server.GenericService.
+type GenericServiceServer interface {
+	SetFlag(ctx context.Context, request *SetFlagRequestPB) (*SetFlagResponsePB, error)
+	GetFlag(ctx context.Context, request *GetFlagRequestPB) (*GetFlagResponsePB, error)
+	RefreshFlags(ctx context.Context, request *RefreshFlagsRequestPB) (*RefreshFlagsResponsePB, error)
+	FlushCoverage(ctx context.Context, request *FlushCoverageRequestPB) (*FlushCoverageResponsePB, error)
+	ServerClock(ctx context.Context, request *ServerClockRequestPB) (*ServerClockResponsePB, error)
+	GetStatus(ctx context.Context, request *GetStatusRequestPB) (*GetStatusResponsePB, error)
+	Ping(ctx context.Context, request *PingRequestPB) (*PingResponsePB, error)
+}
+
+// UnimplementedGenericServiceServer can be embedded in implementations of GenericServiceServer that only serve
+// some of the methods. The others fail with dispatch.ErrNoSuchMethod.
+type UnimplementedGenericServiceServer struct{}
+
+func (UnimplementedGenericServiceServer) SetFlag(ctx context.Context, request *SetFlagRequestPB) (*SetFlagResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.server.GenericService", "SetFlag")
+}
+
+func (UnimplementedGenericServiceServer) GetFlag(ctx context.Context, request *GetFlagRequestPB) (*GetFlagResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.server.GenericService", "GetFlag")
+}
+
+func (UnimplementedGenericServiceServer) RefreshFlags(ctx context.Context, request *RefreshFlagsRequestPB) (*RefreshFlagsResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.server.GenericService", "RefreshFlags")
+}
+
+func (UnimplementedGenericServiceServer) FlushCoverage(ctx context.Context, request *FlushCoverageRequestPB) (*FlushCoverageResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.server.GenericService", "FlushCoverage")
+}
+
+func (UnimplementedGenericServiceServer) ServerClock(ctx context.Context, request *ServerClockRequestPB) (*ServerClockResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.server.GenericService", "ServerClock")
+}
+
+func (UnimplementedGenericServiceServer) GetStatus(ctx context.Context, request *GetStatusRequestPB) (*GetStatusResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.server.GenericService", "GetStatus")
+}
+
+func (UnimplementedGenericServiceServer) Ping(ctx context.Context, request *PingRequestPB) (*PingResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.server.GenericService", "Ping")
+}
+
+var GenericService_ServiceDesc = dispatch.ServiceDesc{
+	ServiceName: "yb.server.GenericService",
+	HandlerType: (*GenericServiceServer)(nil),
+	Methods: []dispatch.MethodDesc{
+		{
+			MethodName:  "SetFlag",
+			NewRequest:  func() proto.Message { return &SetFlagRequestPB{} },
+			NewResponse: func() proto.Message { return &SetFlagResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(GenericServiceServer).SetFlag(ctx, request.(*SetFlagRequestPB))
+			},
+		},
+		{
+			MethodName:  "GetFlag",
+			NewRequest:  func() proto.Message { return &GetFlagRequestPB{} },
+			NewResponse: func() proto.Message { return &GetFlagResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(GenericServiceServer).GetFlag(ctx, request.(*GetFlagRequestPB))
+			},
+		},
+		{
+			MethodName:  "RefreshFlags",
+			NewRequest:  func() proto.Message { return &RefreshFlagsRequestPB{} },
+			NewResponse: func() proto.Message { return &RefreshFlagsResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(GenericServiceServer).RefreshFlags(ctx, request.(*RefreshFlagsRequestPB))
+			},
+		},
+		{
+			MethodName:  "FlushCoverage",
+			NewRequest:  func() proto.Message { return &FlushCoverageRequestPB{} },
+			NewResponse: func() proto.Message { return &FlushCoverageResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(GenericServiceServer).FlushCoverage(ctx, request.(*FlushCoverageRequestPB))
+			},
+		},
+		{
+			MethodName:  "ServerClock",
+			NewRequest:  func() proto.Message { return &ServerClockRequestPB{} },
+			NewResponse: func() proto.Message { return &ServerClockResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(GenericServiceServer).ServerClock(ctx, request.(*ServerClockRequestPB))
+			},
+		},
+		{
+			MethodName:  "GetStatus",
+			NewRequest:  func() proto.Message { return &GetStatusRequestPB{} },
+			NewResponse: func() proto.Message { return &GetStatusResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(GenericServiceServer).GetStatus(ctx, request.(*GetStatusRequestPB))
+			},
+		},
+		{
+			MethodName:  "Ping",
+			NewRequest:  func() proto.Message { return &PingRequestPB{} },
+			NewResponse: func() proto.Message { return &PingResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(GenericServiceServer).Ping(ctx, request.(*PingRequestPB))
+			},
+		},
+	},
+}
+
+// RegisterGenericService serves yb.server.GenericService with srv.
+func RegisterGenericService(r dispatch.Registrar, srv GenericServiceServer) {
+	r.RegisterService(&GenericService_ServiceDesc, srv)
+}
+
+func init() {
+	dispatch.RegisterServiceDesc(&GenericService_ServiceDesc)
+}
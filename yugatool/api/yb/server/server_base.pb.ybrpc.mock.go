@@ -0,0 +1,143 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// The following only applies to changes made to this file as part of YugaByte development.
+//
+// Portions Copyright (c) YugaByte, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied.  See the License for the specific language governing permissions and limitations
+// under the License.
+//
+
+// Code generated by protoc-gen-ybrpc. DO NOT EDIT.
+
+package server
+
+import (
+	"context"
+
+	"github.com/yugabyte/yb-tools/protoc-gen-ybrpc/pkg/dispatch"
+	"github.com/yugabyte/yb-tools/protoc-gen-ybrpc/pkg/mock"
+)
+
+// MockGenericService is a GenericService for tests. Each method calls its stub function,
+// or fails with dispatch.ErrNoSuchMethod when the stub is not set, and every
+// call is recorded.
+type MockGenericService struct {
+	mock.Recorder
+
+	SetFlagFunc       func(ctx context.Context, request *SetFlagRequestPB) (*SetFlagResponsePB, error)
+	GetFlagFunc       func(ctx context.Context, request *GetFlagRequestPB) (*GetFlagResponsePB, error)
+	RefreshFlagsFunc  func(ctx context.Context, request *RefreshFlagsRequestPB) (*RefreshFlagsResponsePB, error)
+	FlushCoverageFunc func(ctx context.Context, request *FlushCoverageRequestPB) (*FlushCoverageResponsePB, error)
+	ServerClockFunc   func(ctx context.Context, request *ServerClockRequestPB) (*ServerClockResponsePB, error)
+	GetStatusFunc     func(ctx context.Context, request *GetStatusRequestPB) (*GetStatusResponsePB, error)
+	PingFunc          func(ctx context.Context, request *PingRequestPB) (*PingResponsePB, error)
+}
+
+var _ GenericService = &MockGenericService{}
+
+func (s *MockGenericService) SetFlag(request *SetFlagRequestPB) (*SetFlagResponsePB, error) {
+	return s.SetFlagWithContext(context.Background(), request)
+}
+
+func (s *MockGenericService) SetFlagWithContext(ctx context.Context, request *SetFlagRequestPB) (*SetFlagResponsePB, error) {
+	s.Record("SetFlag", request)
+	if s.SetFlagFunc == nil {
+		return nil, dispatch.Unimplemented("yb.server.GenericService", "SetFlag")
+	}
+	return s.SetFlagFunc(ctx, request)
+}
+
+func (s *MockGenericService) GetFlag(request *GetFlagRequestPB) (*GetFlagResponsePB, error) {
+	return s.GetFlagWithContext(context.Background(), request)
+}
+
+func (s *MockGenericService) GetFlagWithContext(ctx context.Context, request *GetFlagRequestPB) (*GetFlagResponsePB, error) {
+	s.Record("GetFlag", request)
+	if s.GetFlagFunc == nil {
+		return nil, dispatch.Unimplemented("yb.server.GenericService", "GetFlag")
+	}
+	return s.GetFlagFunc(ctx, request)
+}
+
+func (s *MockGenericService) RefreshFlags(request *RefreshFlagsRequestPB) (*RefreshFlagsResponsePB, error) {
+	return s.RefreshFlagsWithContext(context.Background(), request)
+}
+
+func (s *MockGenericService) RefreshFlagsWithContext(ctx context.Context, request *RefreshFlagsRequestPB) (*RefreshFlagsResponsePB, error) {
+	s.Record("RefreshFlags", request)
+	if s.RefreshFlagsFunc == nil {
+		return nil, dispatch.Unimplemented("yb.server.GenericService", "RefreshFlags")
+	}
+	return s.RefreshFlagsFunc(ctx, request)
+}
+
+func (s *MockGenericService) FlushCoverage(request *FlushCoverageRequestPB) (*FlushCoverageResponsePB, error) {
+	return s.FlushCoverageWithContext(context.Background(), request)
+}
+
+func (s *MockGenericService) FlushCoverageWithContext(ctx context.Context, request *FlushCoverageRequestPB) (*FlushCoverageResponsePB, error) {
+	s.Record("FlushCoverage", request)
+	if s.FlushCoverageFunc == nil {
+		return nil, dispatch.Unimplemented("yb.server.GenericService", "FlushCoverage")
+	}
+	return s.FlushCoverageFunc(ctx, request)
+}
+
+func (s *MockGenericService) ServerClock(request *ServerClockRequestPB) (*ServerClockResponsePB, error) {
+	return s.ServerClockWithContext(context.Background(), request)
+}
+
+func (s *MockGenericService) ServerClockWithContext(ctx context.Context, request *ServerClockRequestPB) (*ServerClockResponsePB, error) {
+	s.Record("ServerClock", request)
+	if s.ServerClockFunc == nil {
+		return nil, dispatch.Unimplemented("yb.server.GenericService", "ServerClock")
+	}
+	return s.ServerClockFunc(ctx, request)
+}
+
+func (s *MockGenericService) GetStatus(request *GetStatusRequestPB) (*GetStatusResponsePB, error) {
+	return s.GetStatusWithContext(context.Background(), request)
+}
+
+func (s *MockGenericService) GetStatusWithContext(ctx context.Context, request *GetStatusRequestPB) (*GetStatusResponsePB, error) {
+	s.Record("GetStatus", request)
+	if s.GetStatusFunc == nil {
+		return nil, dispatch.Unimplemented("yb.server.GenericService", "GetStatus")
+	}
+	return s.GetStatusFunc(ctx, request)
+}
+
+func (s *MockGenericService) Ping(request *PingRequestPB) (*PingResponsePB, error) {
+	return s.PingWithContext(context.Background(), request)
+}
+
+func (s *MockGenericService) PingWithContext(ctx context.Context, request *PingRequestPB) (*PingResponsePB, error) {
+	s.Record("Ping", request)
+	if s.PingFunc == nil {
+		return nil, dispatch.Unimplemented("yb.server.GenericService", "Ping")
+	}
+	return s.PingFunc(ctx, request)
+}
@@ -16,14 +16,19 @@
 package tserver
 
 import (
+	"context"
+
 	"github.com/go-logr/logr"
+	"github.com/yugabyte/yb-tools/protoc-gen-ybrpc/pkg/dispatch"
 	"github.com/yugabyte/yb-tools/protoc-gen-ybrpc/pkg/message"
+	"google.golang.org/protobuf/proto"
 )
 
 // service: yb.tserver.TabletServerBackupService
 // service: TabletServerBackupService
 type TabletServerBackupService interface {
 	TabletSnapshotOp(request *TabletSnapshotOpRequestPB) (*TabletSnapshotOpResponsePB, error)
+	TabletSnapshotOpWithContext(ctx context.Context, request *TabletSnapshotOpRequestPB) (*TabletSnapshotOpResponsePB, error)
 }
 
 type TabletServerBackupServiceImpl struct {
@@ -32,10 +37,14 @@ type TabletServerBackupServiceImpl struct {
 }
 
 func (s *TabletServerBackupServiceImpl) TabletSnapshotOp(request *TabletSnapshotOpRequestPB) (*TabletSnapshotOpResponsePB, error) {
+	return s.TabletSnapshotOpWithContext(context.Background(), request)
+}
+
+func (s *TabletServerBackupServiceImpl) TabletSnapshotOpWithContext(ctx context.Context, request *TabletSnapshotOpRequestPB) (*TabletSnapshotOpResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.tserver.TabletServerBackupService", "method", "TabletSnapshotOp", "request", request)
 	response := &TabletSnapshotOpResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.tserver.TabletServerBackupService", "TabletSnapshotOp", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.tserver.TabletServerBackupService", "TabletSnapshotOp", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -44,3 +53,40 @@ func (s *TabletServerBackupServiceImpl) TabletSnapshotOp(request *TabletSnapshot
 
 	return response, nil
 }
+
+// TabletServerBackupServiceServer is the server API for yb.tserver.TabletServerBackupService.
+type TabletServerBackupServiceServer interface {
+	TabletSnapshotOp(ctx context.Context, request *TabletSnapshotOpRequestPB) (*TabletSnapshotOpResponsePB, error)
+}
+
+// UnimplementedTabletServerBackupServiceServer can be embedded in implementations of TabletServerBackupServiceServer that only serve
+// some of the methods. The others fail with dispatch.ErrNoSuchMethod.
+type UnimplementedTabletServerBackupServiceServer struct{}
+
+func (UnimplementedTabletServerBackupServiceServer) TabletSnapshotOp(ctx context.Context, request *TabletSnapshotOpRequestPB) (*TabletSnapshotOpResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.tserver.TabletServerBackupService", "TabletSnapshotOp")
+}
+
+var TabletServerBackupService_ServiceDesc = dispatch.ServiceDesc{
+	ServiceName: "yb.tserver.TabletServerBackupService",
+	HandlerType: (*TabletServerBackupServiceServer)(nil),
+	Methods: []dispatch.MethodDesc{
+		{
+			MethodName:  "TabletSnapshotOp",
+			NewRequest:  func() proto.Message { return &TabletSnapshotOpRequestPB{} },
+			NewResponse: func() proto.Message { return &TabletSnapshotOpResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(TabletServerBackupServiceServer).TabletSnapshotOp(ctx, request.(*TabletSnapshotOpRequestPB))
+			},
+		},
+	},
+}
+
+// RegisterTabletServerBackupService serves yb.tserver.TabletServerBackupService with srv.
+func RegisterTabletServerBackupService(r dispatch.Registrar, srv TabletServerBackupServiceServer) {
+	r.RegisterService(&TabletServerBackupService_ServiceDesc, srv)
+}
+
+func init() {
+	dispatch.RegisterServiceDesc(&TabletServerBackupService_ServiceDesc)
+}
@@ -0,0 +1,46 @@
+// Copyright (c) YugaByte, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied.  See the License for the specific language governing permissions and limitations
+// under the License.
+//
+
+// Code generated by protoc-gen-ybrpc. DO NOT EDIT.
+
+package tserver
+
+import (
+	"context"
+
+	"github.com/yugabyte/yb-tools/protoc-gen-ybrpc/pkg/dispatch"
+	"github.com/yugabyte/yb-tools/protoc-gen-ybrpc/pkg/mock"
+)
+
+// MockTabletServerBackupService is a TabletServerBackupService for tests. Each method calls its stub function,
+// or fails with dispatch.ErrNoSuchMethod when the stub is not set, and every
+// call is recorded.
+type MockTabletServerBackupService struct {
+	mock.Recorder
+
+	TabletSnapshotOpFunc func(ctx context.Context, request *TabletSnapshotOpRequestPB) (*TabletSnapshotOpResponsePB, error)
+}
+
+var _ TabletServerBackupService = &MockTabletServerBackupService{}
+
+func (s *MockTabletServerBackupService) TabletSnapshotOp(request *TabletSnapshotOpRequestPB) (*TabletSnapshotOpResponsePB, error) {
+	return s.TabletSnapshotOpWithContext(context.Background(), request)
+}
+
+func (s *MockTabletServerBackupService) TabletSnapshotOpWithContext(ctx context.Context, request *TabletSnapshotOpRequestPB) (*TabletSnapshotOpResponsePB, error) {
+	s.Record("TabletSnapshotOp", request)
+	if s.TabletSnapshotOpFunc == nil {
+		return nil, dispatch.Unimplemented("yb.tserver.TabletServerBackupService", "TabletSnapshotOp")
+	}
+	return s.TabletSnapshotOpFunc(ctx, request)
+}
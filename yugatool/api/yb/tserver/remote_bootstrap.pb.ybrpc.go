@@ -35,8 +35,12 @@
 package tserver
 
 import (
+	"context"
+
 	"github.com/go-logr/logr"
+	"github.com/yugabyte/yb-tools/protoc-gen-ybrpc/pkg/dispatch"
 	"github.com/yugabyte/yb-tools/protoc-gen-ybrpc/pkg/message"
+	"google.golang.org/protobuf/proto"
 )
 
 // service: yb.tserver.RemoteBootstrapService
@@ -45,10 +49,15 @@ import (
 
 type RemoteBootstrapService interface {
 	BeginRemoteBootstrapSession(request *BeginRemoteBootstrapSessionRequestPB) (*BeginRemoteBootstrapSessionResponsePB, error)
+	BeginRemoteBootstrapSessionWithContext(ctx context.Context, request *BeginRemoteBootstrapSessionRequestPB) (*BeginRemoteBootstrapSessionResponsePB, error)
 	CheckSessionActive(request *CheckRemoteBootstrapSessionActiveRequestPB) (*CheckRemoteBootstrapSessionActiveResponsePB, error)
+	CheckSessionActiveWithContext(ctx context.Context, request *CheckRemoteBootstrapSessionActiveRequestPB) (*CheckRemoteBootstrapSessionActiveResponsePB, error)
 	FetchData(request *FetchDataRequestPB) (*FetchDataResponsePB, error)
+	FetchDataWithContext(ctx context.Context, request *FetchDataRequestPB) (*FetchDataResponsePB, error)
 	EndRemoteBootstrapSession(request *EndRemoteBootstrapSessionRequestPB) (*EndRemoteBootstrapSessionResponsePB, error)
+	EndRemoteBootstrapSessionWithContext(ctx context.Context, request *EndRemoteBootstrapSessionRequestPB) (*EndRemoteBootstrapSessionResponsePB, error)
 	RemoveSession(request *RemoveSessionRequestPB) (*RemoveSessionResponsePB, error)
+	RemoveSessionWithContext(ctx context.Context, request *RemoveSessionRequestPB) (*RemoveSessionResponsePB, error)
 }
 
 type RemoteBootstrapServiceImpl struct {
@@ -59,10 +68,16 @@ type RemoteBootstrapServiceImpl struct {
 // Establish a remote bootstrap session.
 
 func (s *RemoteBootstrapServiceImpl) BeginRemoteBootstrapSession(request *BeginRemoteBootstrapSessionRequestPB) (*BeginRemoteBootstrapSessionResponsePB, error) {
+	return s.BeginRemoteBootstrapSessionWithContext(context.Background(), request)
+}
+
+// Establish a remote bootstrap session.
+
+func (s *RemoteBootstrapServiceImpl) BeginRemoteBootstrapSessionWithContext(ctx context.Context, request *BeginRemoteBootstrapSessionRequestPB) (*BeginRemoteBootstrapSessionResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.tserver.RemoteBootstrapService", "method", "BeginRemoteBootstrapSession", "request", request)
 	response := &BeginRemoteBootstrapSessionResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.tserver.RemoteBootstrapService", "BeginRemoteBootstrapSession", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.tserver.RemoteBootstrapService", "BeginRemoteBootstrapSession", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -75,10 +90,16 @@ func (s *RemoteBootstrapServiceImpl) BeginRemoteBootstrapSession(request *BeginR
 // Check whether the specified session is active.
 
 func (s *RemoteBootstrapServiceImpl) CheckSessionActive(request *CheckRemoteBootstrapSessionActiveRequestPB) (*CheckRemoteBootstrapSessionActiveResponsePB, error) {
+	return s.CheckSessionActiveWithContext(context.Background(), request)
+}
+
+// Check whether the specified session is active.
+
+func (s *RemoteBootstrapServiceImpl) CheckSessionActiveWithContext(ctx context.Context, request *CheckRemoteBootstrapSessionActiveRequestPB) (*CheckRemoteBootstrapSessionActiveResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.tserver.RemoteBootstrapService", "method", "CheckSessionActive", "request", request)
 	response := &CheckRemoteBootstrapSessionActiveResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.tserver.RemoteBootstrapService", "CheckSessionActive", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.tserver.RemoteBootstrapService", "CheckSessionActive", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -91,10 +112,16 @@ func (s *RemoteBootstrapServiceImpl) CheckSessionActive(request *CheckRemoteBoot
 // Fetch data (blocks, logs) from the server.
 
 func (s *RemoteBootstrapServiceImpl) FetchData(request *FetchDataRequestPB) (*FetchDataResponsePB, error) {
+	return s.FetchDataWithContext(context.Background(), request)
+}
+
+// Fetch data (blocks, logs) from the server.
+
+func (s *RemoteBootstrapServiceImpl) FetchDataWithContext(ctx context.Context, request *FetchDataRequestPB) (*FetchDataResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.tserver.RemoteBootstrapService", "method", "FetchData", "request", request)
 	response := &FetchDataResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.tserver.RemoteBootstrapService", "FetchData", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.tserver.RemoteBootstrapService", "FetchData", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -107,10 +134,16 @@ func (s *RemoteBootstrapServiceImpl) FetchData(request *FetchDataRequestPB) (*Fe
 // End a remote bootstrap session, allow server to release resources.
 
 func (s *RemoteBootstrapServiceImpl) EndRemoteBootstrapSession(request *EndRemoteBootstrapSessionRequestPB) (*EndRemoteBootstrapSessionResponsePB, error) {
+	return s.EndRemoteBootstrapSessionWithContext(context.Background(), request)
+}
+
+// End a remote bootstrap session, allow server to release resources.
+
+func (s *RemoteBootstrapServiceImpl) EndRemoteBootstrapSessionWithContext(ctx context.Context, request *EndRemoteBootstrapSessionRequestPB) (*EndRemoteBootstrapSessionResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.tserver.RemoteBootstrapService", "method", "EndRemoteBootstrapSession", "request", request)
 	response := &EndRemoteBootstrapSessionResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.tserver.RemoteBootstrapService", "EndRemoteBootstrapSession", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.tserver.RemoteBootstrapService", "EndRemoteBootstrapSession", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -121,10 +154,14 @@ func (s *RemoteBootstrapServiceImpl) EndRemoteBootstrapSession(request *EndRemot
 }
 
 func (s *RemoteBootstrapServiceImpl) RemoveSession(request *RemoveSessionRequestPB) (*RemoveSessionResponsePB, error) {
+	return s.RemoveSessionWithContext(context.Background(), request)
+}
+
+func (s *RemoteBootstrapServiceImpl) RemoveSessionWithContext(ctx context.Context, request *RemoveSessionRequestPB) (*RemoveSessionResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.tserver.RemoteBootstrapService", "method", "RemoveSession", "request", request)
 	response := &RemoveSessionResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.tserver.RemoteBootstrapService", "RemoveSession", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.tserver.RemoteBootstrapService", "RemoveSession", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -133,3 +170,92 @@ func (s *RemoteBootstrapServiceImpl) RemoveSession(request *RemoveSessionRequest
 
 	return response, nil
 }
+
+// RemoteBootstrapServiceServer is the server API for yb.tserver.RemoteBootstrapService.
+type RemoteBootstrapServiceServer interface {
+	BeginRemoteBootstrapSession(ctx context.Context, request *BeginRemoteBootstrapSessionRequestPB) (*BeginRemoteBootstrapSessionResponsePB, error)
+	CheckSessionActive(ctx context.Context, request *CheckRemoteBootstrapSessionActiveRequestPB) (*CheckRemoteBootstrapSessionActiveResponsePB, error)
+	FetchData(ctx context.Context, request *FetchDataRequestPB) (*FetchDataResponsePB, error)
+	EndRemoteBootstrapSession(ctx context.Context, request *EndRemoteBootstrapSessionRequestPB) (*EndRemoteBootstrapSessionResponsePB, error)
+	RemoveSession(ctx context.Context, request *RemoveSessionRequestPB) (*RemoveSessionResponsePB, error)
+}
+
+// UnimplementedRemoteBootstrapServiceServer can be embedded in implementations of RemoteBootstrapServiceServer that only serve
+// some of the methods. The others fail with dispatch.ErrNoSuchMethod.
+type UnimplementedRemoteBootstrapServiceServer struct{}
+
+func (UnimplementedRemoteBootstrapServiceServer) BeginRemoteBootstrapSession(ctx context.Context, request *BeginRemoteBootstrapSessionRequestPB) (*BeginRemoteBootstrapSessionResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.tserver.RemoteBootstrapService", "BeginRemoteBootstrapSession")
+}
+
+func (UnimplementedRemoteBootstrapServiceServer) CheckSessionActive(ctx context.Context, request *CheckRemoteBootstrapSessionActiveRequestPB) (*CheckRemoteBootstrapSessionActiveResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.tserver.RemoteBootstrapService", "CheckSessionActive")
+}
+
+func (UnimplementedRemoteBootstrapServiceServer) FetchData(ctx context.Context, request *FetchDataRequestPB) (*FetchDataResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.tserver.RemoteBootstrapService", "FetchData")
+}
+
+func (UnimplementedRemoteBootstrapServiceServer) EndRemoteBootstrapSession(ctx context.Context, request *EndRemoteBootstrapSessionRequestPB) (*EndRemoteBootstrapSessionResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.tserver.RemoteBootstrapService", "EndRemoteBootstrapSession")
+}
+
+func (UnimplementedRemoteBootstrapServiceServer) RemoveSession(ctx context.Context, request *RemoveSessionRequestPB) (*RemoveSessionResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.tserver.RemoteBootstrapService", "RemoveSession")
+}
+
+var RemoteBootstrapService_ServiceDesc = dispatch.ServiceDesc{
+	ServiceName: "yb.tserver.RemoteBootstrapService",
+	HandlerType: (*RemoteBootstrapServiceServer)(nil),
+	Methods: []dispatch.MethodDesc{
+		{
+			MethodName:  "BeginRemoteBootstrapSession",
+			NewRequest:  func() proto.Message { return &BeginRemoteBootstrapSessionRequestPB{} },
+			NewResponse: func() proto.Message { return &BeginRemoteBootstrapSessionResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(RemoteBootstrapServiceServer).BeginRemoteBootstrapSession(ctx, request.(*BeginRemoteBootstrapSessionRequestPB))
+			},
+		},
+		{
+			MethodName:  "CheckSessionActive",
+			NewRequest:  func() proto.Message { return &CheckRemoteBootstrapSessionActiveRequestPB{} },
+			NewResponse: func() proto.Message { return &CheckRemoteBootstrapSessionActiveResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(RemoteBootstrapServiceServer).CheckSessionActive(ctx, request.(*CheckRemoteBootstrapSessionActiveRequestPB))
+			},
+		},
+		{
+			MethodName:  "FetchData",
+			NewRequest:  func() proto.Message { return &FetchDataRequestPB{} },
+			NewResponse: func() proto.Message { return &FetchDataResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(RemoteBootstrapServiceServer).FetchData(ctx, request.(*FetchDataRequestPB))
+			},
+		},
+		{
+			MethodName:  "EndRemoteBootstrapSession",
+			NewRequest:  func() proto.Message { return &EndRemoteBootstrapSessionRequestPB{} },
+			NewResponse: func() proto.Message { return &EndRemoteBootstrapSessionResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(RemoteBootstrapServiceServer).EndRemoteBootstrapSession(ctx, request.(*EndRemoteBootstrapSessionRequestPB))
+			},
+		},
+		{
+			MethodName:  "RemoveSession",
+			NewRequest:  func() proto.Message { return &RemoveSessionRequestPB{} },
+			NewResponse: func() proto.Message { return &RemoveSessionResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(RemoteBootstrapServiceServer).RemoveSession(ctx, request.(*RemoveSessionRequestPB))
+			},
+		},
+	},
+}
+
+// RegisterRemoteBootstrapService serves yb.tserver.RemoteBootstrapService with srv.
+func RegisterRemoteBootstrapService(r dispatch.Registrar, srv RemoteBootstrapServiceServer) {
+	r.RegisterService(&RemoteBootstrapService_ServiceDesc, srv)
+}
+
+func init() {
+	dispatch.RegisterServiceDesc(&RemoteBootstrapService_ServiceDesc)
+}
@@ -0,0 +1,117 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// The following only applies to changes made to this file as part of YugaByte development.
+//
+// Portions Copyright (c) YugaByte, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied.  See the License for the specific language governing permissions and limitations
+// under the License.
+//
+
+// Code generated by protoc-gen-ybrpc. DO NOT EDIT.
+
+package tserver
+
+import (
+	"context"
+
+	"github.com/yugabyte/yb-tools/protoc-gen-ybrpc/pkg/dispatch"
+	"github.com/yugabyte/yb-tools/protoc-gen-ybrpc/pkg/mock"
+)
+
+// MockRemoteBootstrapService is a RemoteBootstrapService for tests. Each method calls its stub function,
+// or fails with dispatch.ErrNoSuchMethod when the stub is not set, and every
+// call is recorded.
+type MockRemoteBootstrapService struct {
+	mock.Recorder
+
+	BeginRemoteBootstrapSessionFunc func(ctx context.Context, request *BeginRemoteBootstrapSessionRequestPB) (*BeginRemoteBootstrapSessionResponsePB, error)
+	CheckSessionActiveFunc          func(ctx context.Context, request *CheckRemoteBootstrapSessionActiveRequestPB) (*CheckRemoteBootstrapSessionActiveResponsePB, error)
+	FetchDataFunc                   func(ctx context.Context, request *FetchDataRequestPB) (*FetchDataResponsePB, error)
+	EndRemoteBootstrapSessionFunc   func(ctx context.Context, request *EndRemoteBootstrapSessionRequestPB) (*EndRemoteBootstrapSessionResponsePB, error)
+	RemoveSessionFunc               func(ctx context.Context, request *RemoveSessionRequestPB) (*RemoveSessionResponsePB, error)
+}
+
+var _ RemoteBootstrapService = &MockRemoteBootstrapService{}
+
+func (s *MockRemoteBootstrapService) BeginRemoteBootstrapSession(request *BeginRemoteBootstrapSessionRequestPB) (*BeginRemoteBootstrapSessionResponsePB, error) {
+	return s.BeginRemoteBootstrapSessionWithContext(context.Background(), request)
+}
+
+func (s *MockRemoteBootstrapService) BeginRemoteBootstrapSessionWithContext(ctx context.Context, request *BeginRemoteBootstrapSessionRequestPB) (*BeginRemoteBootstrapSessionResponsePB, error) {
+	s.Record("BeginRemoteBootstrapSession", request)
+	if s.BeginRemoteBootstrapSessionFunc == nil {
+		return nil, dispatch.Unimplemented("yb.tserver.RemoteBootstrapService", "BeginRemoteBootstrapSession")
+	}
+	return s.BeginRemoteBootstrapSessionFunc(ctx, request)
+}
+
+func (s *MockRemoteBootstrapService) CheckSessionActive(request *CheckRemoteBootstrapSessionActiveRequestPB) (*CheckRemoteBootstrapSessionActiveResponsePB, error) {
+	return s.CheckSessionActiveWithContext(context.Background(), request)
+}
+
+func (s *MockRemoteBootstrapService) CheckSessionActiveWithContext(ctx context.Context, request *CheckRemoteBootstrapSessionActiveRequestPB) (*CheckRemoteBootstrapSessionActiveResponsePB, error) {
+	s.Record("CheckSessionActive", request)
+	if s.CheckSessionActiveFunc == nil {
+		return nil, dispatch.Unimplemented("yb.tserver.RemoteBootstrapService", "CheckSessionActive")
+	}
+	return s.CheckSessionActiveFunc(ctx, request)
+}
+
+func (s *MockRemoteBootstrapService) FetchData(request *FetchDataRequestPB) (*FetchDataResponsePB, error) {
+	return s.FetchDataWithContext(context.Background(), request)
+}
+
+func (s *MockRemoteBootstrapService) FetchDataWithContext(ctx context.Context, request *FetchDataRequestPB) (*FetchDataResponsePB, error) {
+	s.Record("FetchData", request)
+	if s.FetchDataFunc == nil {
+		return nil, dispatch.Unimplemented("yb.tserver.RemoteBootstrapService", "FetchData")
+	}
+	return s.FetchDataFunc(ctx, request)
+}
+
+func (s *MockRemoteBootstrapService) EndRemoteBootstrapSession(request *EndRemoteBootstrapSessionRequestPB) (*EndRemoteBootstrapSessionResponsePB, error) {
+	return s.EndRemoteBootstrapSessionWithContext(context.Background(), request)
+}
+
+func (s *MockRemoteBootstrapService) EndRemoteBootstrapSessionWithContext(ctx context.Context, request *EndRemoteBootstrapSessionRequestPB) (*EndRemoteBootstrapSessionResponsePB, error) {
+	s.Record("EndRemoteBootstrapSession", request)
+	if s.EndRemoteBootstrapSessionFunc == nil {
+		return nil, dispatch.Unimplemented("yb.tserver.RemoteBootstrapService", "EndRemoteBootstrapSession")
+	}
+	return s.EndRemoteBootstrapSessionFunc(ctx, request)
+}
+
+func (s *MockRemoteBootstrapService) RemoveSession(request *RemoveSessionRequestPB) (*RemoveSessionResponsePB, error) {
+	return s.RemoveSessionWithContext(context.Background(), request)
+}
+
+func (s *MockRemoteBootstrapService) RemoveSessionWithContext(ctx context.Context, request *RemoveSessionRequestPB) (*RemoveSessionResponsePB, error) {
+	s.Record("RemoveSession", request)
+	if s.RemoveSessionFunc == nil {
+		return nil, dispatch.Unimplemented("yb.tserver.RemoteBootstrapService", "RemoveSession")
+	}
+	return s.RemoveSessionFunc(ctx, request)
+}
@@ -35,25 +35,41 @@
 package tserver
 
 import (
+	"context"
+
 	"github.com/go-logr/logr"
+	"github.com/yugabyte/yb-tools/protoc-gen-ybrpc/pkg/dispatch"
 	"github.com/yugabyte/yb-tools/protoc-gen-ybrpc/pkg/message"
+	"google.golang.org/protobuf/proto"
 )
 
 // service: yb.tserver.TabletServerAdminService
 // service: TabletServerAdminService
 type TabletServerAdminService interface {
 	CreateTablet(request *CreateTabletRequestPB) (*CreateTabletResponsePB, error)
+	CreateTabletWithContext(ctx context.Context, request *CreateTabletRequestPB) (*CreateTabletResponsePB, error)
 	DeleteTablet(request *DeleteTabletRequestPB) (*DeleteTabletResponsePB, error)
+	DeleteTabletWithContext(ctx context.Context, request *DeleteTabletRequestPB) (*DeleteTabletResponsePB, error)
 	AlterSchema(request *ChangeMetadataRequestPB) (*ChangeMetadataResponsePB, error)
+	AlterSchemaWithContext(ctx context.Context, request *ChangeMetadataRequestPB) (*ChangeMetadataResponsePB, error)
 	GetSafeTime(request *GetSafeTimeRequestPB) (*GetSafeTimeResponsePB, error)
+	GetSafeTimeWithContext(ctx context.Context, request *GetSafeTimeRequestPB) (*GetSafeTimeResponsePB, error)
 	BackfillIndex(request *BackfillIndexRequestPB) (*BackfillIndexResponsePB, error)
+	BackfillIndexWithContext(ctx context.Context, request *BackfillIndexRequestPB) (*BackfillIndexResponsePB, error)
 	BackfillDone(request *ChangeMetadataRequestPB) (*ChangeMetadataResponsePB, error)
+	BackfillDoneWithContext(ctx context.Context, request *ChangeMetadataRequestPB) (*ChangeMetadataResponsePB, error)
 	CopartitionTable(request *CopartitionTableRequestPB) (*CopartitionTableResponsePB, error)
+	CopartitionTableWithContext(ctx context.Context, request *CopartitionTableRequestPB) (*CopartitionTableResponsePB, error)
 	FlushTablets(request *FlushTabletsRequestPB) (*FlushTabletsResponsePB, error)
+	FlushTabletsWithContext(ctx context.Context, request *FlushTabletsRequestPB) (*FlushTabletsResponsePB, error)
 	CountIntents(request *CountIntentsRequestPB) (*CountIntentsResponsePB, error)
+	CountIntentsWithContext(ctx context.Context, request *CountIntentsRequestPB) (*CountIntentsResponsePB, error)
 	AddTableToTablet(request *AddTableToTabletRequestPB) (*AddTableToTabletResponsePB, error)
+	AddTableToTabletWithContext(ctx context.Context, request *AddTableToTabletRequestPB) (*AddTableToTabletResponsePB, error)
 	RemoveTableFromTablet(request *RemoveTableFromTabletRequestPB) (*RemoveTableFromTabletResponsePB, error)
+	RemoveTableFromTabletWithContext(ctx context.Context, request *RemoveTableFromTabletRequestPB) (*RemoveTableFromTabletResponsePB, error)
 	SplitTablet(request *SplitTabletRequestPB) (*SplitTabletResponsePB, error)
+	SplitTabletWithContext(ctx context.Context, request *SplitTabletRequestPB) (*SplitTabletResponsePB, error)
 }
 
 type TabletServerAdminServiceImpl struct {
@@ -65,10 +81,17 @@ type TabletServerAdminServiceImpl struct {
 // brand-new tablets, not for "moves".
 
 func (s *TabletServerAdminServiceImpl) CreateTablet(request *CreateTabletRequestPB) (*CreateTabletResponsePB, error) {
+	return s.CreateTabletWithContext(context.Background(), request)
+}
+
+// Create a new, empty tablet with the specified parameters. Only used for
+// brand-new tablets, not for "moves".
+
+func (s *TabletServerAdminServiceImpl) CreateTabletWithContext(ctx context.Context, request *CreateTabletRequestPB) (*CreateTabletResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.tserver.TabletServerAdminService", "method", "CreateTablet", "request", request)
 	response := &CreateTabletResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.tserver.TabletServerAdminService", "CreateTablet", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.tserver.TabletServerAdminService", "CreateTablet", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -81,10 +104,16 @@ func (s *TabletServerAdminServiceImpl) CreateTablet(request *CreateTabletRequest
 // Delete a tablet replica.
 
 func (s *TabletServerAdminServiceImpl) DeleteTablet(request *DeleteTabletRequestPB) (*DeleteTabletResponsePB, error) {
+	return s.DeleteTabletWithContext(context.Background(), request)
+}
+
+// Delete a tablet replica.
+
+func (s *TabletServerAdminServiceImpl) DeleteTabletWithContext(ctx context.Context, request *DeleteTabletRequestPB) (*DeleteTabletResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.tserver.TabletServerAdminService", "method", "DeleteTablet", "request", request)
 	response := &DeleteTabletResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.tserver.TabletServerAdminService", "DeleteTablet", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.tserver.TabletServerAdminService", "DeleteTablet", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -97,10 +126,16 @@ func (s *TabletServerAdminServiceImpl) DeleteTablet(request *DeleteTabletRequest
 // Alter a tablet's schema.
 
 func (s *TabletServerAdminServiceImpl) AlterSchema(request *ChangeMetadataRequestPB) (*ChangeMetadataResponsePB, error) {
+	return s.AlterSchemaWithContext(context.Background(), request)
+}
+
+// Alter a tablet's schema.
+
+func (s *TabletServerAdminServiceImpl) AlterSchemaWithContext(ctx context.Context, request *ChangeMetadataRequestPB) (*ChangeMetadataResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.tserver.TabletServerAdminService", "method", "AlterSchema", "request", request)
 	response := &ChangeMetadataResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.tserver.TabletServerAdminService", "AlterSchema", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.tserver.TabletServerAdminService", "AlterSchema", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -113,10 +148,16 @@ func (s *TabletServerAdminServiceImpl) AlterSchema(request *ChangeMetadataReques
 // GetSafeTime API to get the current safe time.
 
 func (s *TabletServerAdminServiceImpl) GetSafeTime(request *GetSafeTimeRequestPB) (*GetSafeTimeResponsePB, error) {
+	return s.GetSafeTimeWithContext(context.Background(), request)
+}
+
+// GetSafeTime API to get the current safe time.
+
+func (s *TabletServerAdminServiceImpl) GetSafeTimeWithContext(ctx context.Context, request *GetSafeTimeRequestPB) (*GetSafeTimeResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.tserver.TabletServerAdminService", "method", "GetSafeTime", "request", request)
 	response := &GetSafeTimeResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.tserver.TabletServerAdminService", "GetSafeTime", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.tserver.TabletServerAdminService", "GetSafeTime", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -130,10 +171,17 @@ func (s *TabletServerAdminServiceImpl) GetSafeTime(request *GetSafeTimeRequestPB
 // table.
 
 func (s *TabletServerAdminServiceImpl) BackfillIndex(request *BackfillIndexRequestPB) (*BackfillIndexResponsePB, error) {
+	return s.BackfillIndexWithContext(context.Background(), request)
+}
+
+// Backfill the index for the specified index tables. Addressed to the indexed
+// table.
+
+func (s *TabletServerAdminServiceImpl) BackfillIndexWithContext(ctx context.Context, request *BackfillIndexRequestPB) (*BackfillIndexResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.tserver.TabletServerAdminService", "method", "BackfillIndex", "request", request)
 	response := &BackfillIndexResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.tserver.TabletServerAdminService", "BackfillIndex", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.tserver.TabletServerAdminService", "BackfillIndex", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -146,10 +194,16 @@ func (s *TabletServerAdminServiceImpl) BackfillIndex(request *BackfillIndexReque
 // Marks an index table as having completed backfilling.
 
 func (s *TabletServerAdminServiceImpl) BackfillDone(request *ChangeMetadataRequestPB) (*ChangeMetadataResponsePB, error) {
+	return s.BackfillDoneWithContext(context.Background(), request)
+}
+
+// Marks an index table as having completed backfilling.
+
+func (s *TabletServerAdminServiceImpl) BackfillDoneWithContext(ctx context.Context, request *ChangeMetadataRequestPB) (*ChangeMetadataResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.tserver.TabletServerAdminService", "method", "BackfillDone", "request", request)
 	response := &ChangeMetadataResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.tserver.TabletServerAdminService", "BackfillDone", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.tserver.TabletServerAdminService", "BackfillDone", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -162,10 +216,16 @@ func (s *TabletServerAdminServiceImpl) BackfillDone(request *ChangeMetadataReque
 // Create a co-partitioned table in an existing tablet
 
 func (s *TabletServerAdminServiceImpl) CopartitionTable(request *CopartitionTableRequestPB) (*CopartitionTableResponsePB, error) {
+	return s.CopartitionTableWithContext(context.Background(), request)
+}
+
+// Create a co-partitioned table in an existing tablet
+
+func (s *TabletServerAdminServiceImpl) CopartitionTableWithContext(ctx context.Context, request *CopartitionTableRequestPB) (*CopartitionTableResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.tserver.TabletServerAdminService", "method", "CopartitionTable", "request", request)
 	response := &CopartitionTableResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.tserver.TabletServerAdminService", "CopartitionTable", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.tserver.TabletServerAdminService", "CopartitionTable", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -176,10 +236,14 @@ func (s *TabletServerAdminServiceImpl) CopartitionTable(request *CopartitionTabl
 }
 
 func (s *TabletServerAdminServiceImpl) FlushTablets(request *FlushTabletsRequestPB) (*FlushTabletsResponsePB, error) {
+	return s.FlushTabletsWithContext(context.Background(), request)
+}
+
+func (s *TabletServerAdminServiceImpl) FlushTabletsWithContext(ctx context.Context, request *FlushTabletsRequestPB) (*FlushTabletsResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.tserver.TabletServerAdminService", "method", "FlushTablets", "request", request)
 	response := &FlushTabletsResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.tserver.TabletServerAdminService", "FlushTablets", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.tserver.TabletServerAdminService", "FlushTablets", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -190,10 +254,14 @@ func (s *TabletServerAdminServiceImpl) FlushTablets(request *FlushTabletsRequest
 }
 
 func (s *TabletServerAdminServiceImpl) CountIntents(request *CountIntentsRequestPB) (*CountIntentsResponsePB, error) {
+	return s.CountIntentsWithContext(context.Background(), request)
+}
+
+func (s *TabletServerAdminServiceImpl) CountIntentsWithContext(ctx context.Context, request *CountIntentsRequestPB) (*CountIntentsResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.tserver.TabletServerAdminService", "method", "CountIntents", "request", request)
 	response := &CountIntentsResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.tserver.TabletServerAdminService", "CountIntents", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.tserver.TabletServerAdminService", "CountIntents", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -204,10 +272,14 @@ func (s *TabletServerAdminServiceImpl) CountIntents(request *CountIntentsRequest
 }
 
 func (s *TabletServerAdminServiceImpl) AddTableToTablet(request *AddTableToTabletRequestPB) (*AddTableToTabletResponsePB, error) {
+	return s.AddTableToTabletWithContext(context.Background(), request)
+}
+
+func (s *TabletServerAdminServiceImpl) AddTableToTabletWithContext(ctx context.Context, request *AddTableToTabletRequestPB) (*AddTableToTabletResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.tserver.TabletServerAdminService", "method", "AddTableToTablet", "request", request)
 	response := &AddTableToTabletResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.tserver.TabletServerAdminService", "AddTableToTablet", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.tserver.TabletServerAdminService", "AddTableToTablet", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -218,10 +290,14 @@ func (s *TabletServerAdminServiceImpl) AddTableToTablet(request *AddTableToTable
 }
 
 func (s *TabletServerAdminServiceImpl) RemoveTableFromTablet(request *RemoveTableFromTabletRequestPB) (*RemoveTableFromTabletResponsePB, error) {
+	return s.RemoveTableFromTabletWithContext(context.Background(), request)
+}
+
+func (s *TabletServerAdminServiceImpl) RemoveTableFromTabletWithContext(ctx context.Context, request *RemoveTableFromTabletRequestPB) (*RemoveTableFromTabletResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.tserver.TabletServerAdminService", "method", "RemoveTableFromTablet", "request", request)
 	response := &RemoveTableFromTabletResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.tserver.TabletServerAdminService", "RemoveTableFromTablet", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.tserver.TabletServerAdminService", "RemoveTableFromTablet", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -232,10 +308,14 @@ func (s *TabletServerAdminServiceImpl) RemoveTableFromTablet(request *RemoveTabl
 }
 
 func (s *TabletServerAdminServiceImpl) SplitTablet(request *SplitTabletRequestPB) (*SplitTabletResponsePB, error) {
+	return s.SplitTabletWithContext(context.Background(), request)
+}
+
+func (s *TabletServerAdminServiceImpl) SplitTabletWithContext(ctx context.Context, request *SplitTabletRequestPB) (*SplitTabletResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.tserver.TabletServerAdminService", "method", "SplitTablet", "request", request)
 	response := &SplitTabletResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.tserver.TabletServerAdminService", "SplitTablet", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.tserver.TabletServerAdminService", "SplitTablet", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -244,3 +324,183 @@ func (s *TabletServerAdminServiceImpl) SplitTablet(request *SplitTabletRequestPB
 
 	return response, nil
 }
+
+// TabletServerAdminServiceServer is the server API for yb.tserver.TabletServerAdminService.
+type TabletServerAdminServiceServer interface {
+	CreateTablet(ctx context.Context, request *CreateTabletRequestPB) (*CreateTabletResponsePB, error)
+	DeleteTablet(ctx context.Context, request *DeleteTabletRequestPB) (*DeleteTabletResponsePB, error)
+	AlterSchema(ctx context.Context, request *ChangeMetadataRequestPB) (*ChangeMetadataResponsePB, error)
+	GetSafeTime(ctx context.Context, request *GetSafeTimeRequestPB) (*GetSafeTimeResponsePB, error)
+	BackfillIndex(ctx context.Context, request *BackfillIndexRequestPB) (*BackfillIndexResponsePB, error)
+	BackfillDone(ctx context.Context, request *ChangeMetadataRequestPB) (*ChangeMetadataResponsePB, error)
+	CopartitionTable(ctx context.Context, request *CopartitionTableRequestPB) (*CopartitionTableResponsePB, error)
+	FlushTablets(ctx context.Context, request *FlushTabletsRequestPB) (*FlushTabletsResponsePB, error)
+	CountIntents(ctx context.Context, request *CountIntentsRequestPB) (*CountIntentsResponsePB, error)
+	AddTableToTablet(ctx context.Context, request *AddTableToTabletRequestPB) (*AddTableToTabletResponsePB, error)
+	RemoveTableFromTablet(ctx context.Context, request *RemoveTableFromTabletRequestPB) (*RemoveTableFromTabletResponsePB, error)
+	SplitTablet(ctx context.Context, request *SplitTabletRequestPB) (*SplitTabletResponsePB, error)
+}
+
+// UnimplementedTabletServerAdminServiceServer can be embedded in implementations of TabletServerAdminServiceServer that only serve
+// some of the methods. The others fail with dispatch.ErrNoSuchMethod.
+type UnimplementedTabletServerAdminServiceServer struct{}
+
+func (UnimplementedTabletServerAdminServiceServer) CreateTablet(ctx context.Context, request *CreateTabletRequestPB) (*CreateTabletResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.tserver.TabletServerAdminService", "CreateTablet")
+}
+
+func (UnimplementedTabletServerAdminServiceServer) DeleteTablet(ctx context.Context, request *DeleteTabletRequestPB) (*DeleteTabletResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.tserver.TabletServerAdminService", "DeleteTablet")
+}
+
+func (UnimplementedTabletServerAdminServiceServer) AlterSchema(ctx context.Context, request *ChangeMetadataRequestPB) (*ChangeMetadataResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.tserver.TabletServerAdminService", "AlterSchema")
+}
+
+func (UnimplementedTabletServerAdminServiceServer) GetSafeTime(ctx context.Context, request *GetSafeTimeRequestPB) (*GetSafeTimeResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.tserver.TabletServerAdminService", "GetSafeTime")
+}
+
+func (UnimplementedTabletServerAdminServiceServer) BackfillIndex(ctx context.Context, request *BackfillIndexRequestPB) (*BackfillIndexResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.tserver.TabletServerAdminService", "BackfillIndex")
+}
+
+func (UnimplementedTabletServerAdminServiceServer) BackfillDone(ctx context.Context, request *ChangeMetadataRequestPB) (*ChangeMetadataResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.tserver.TabletServerAdminService", "BackfillDone")
+}
+
+func (UnimplementedTabletServerAdminServiceServer) CopartitionTable(ctx context.Context, request *CopartitionTableRequestPB) (*CopartitionTableResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.tserver.TabletServerAdminService", "CopartitionTable")
+}
+
+func (UnimplementedTabletServerAdminServiceServer) FlushTablets(ctx context.Context, request *FlushTabletsRequestPB) (*FlushTabletsResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.tserver.TabletServerAdminService", "FlushTablets")
+}
+
+func (UnimplementedTabletServerAdminServiceServer) CountIntents(ctx context.Context, request *CountIntentsRequestPB) (*CountIntentsResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.tserver.TabletServerAdminService", "CountIntents")
+}
+
+func (UnimplementedTabletServerAdminServiceServer) AddTableToTablet(ctx context.Context, request *AddTableToTabletRequestPB) (*AddTableToTabletResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.tserver.TabletServerAdminService", "AddTableToTablet")
+}
+
+func (UnimplementedTabletServerAdminServiceServer) RemoveTableFromTablet(ctx context.Context, request *RemoveTableFromTabletRequestPB) (*RemoveTableFromTabletResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.tserver.TabletServerAdminService", "RemoveTableFromTablet")
+}
+
+func (UnimplementedTabletServerAdminServiceServer) SplitTablet(ctx context.Context, request *SplitTabletRequestPB) (*SplitTabletResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.tserver.TabletServerAdminService", "SplitTablet")
+}
+
+var TabletServerAdminService_ServiceDesc = dispatch.ServiceDesc{
+	ServiceName: "yb.tserver.TabletServerAdminService",
+	HandlerType: (*TabletServerAdminServiceServer)(nil),
+	Methods: []dispatch.MethodDesc{
+		{
+			MethodName:  "CreateTablet",
+			NewRequest:  func() proto.Message { return &CreateTabletRequestPB{} },
+			NewResponse: func() proto.Message { return &CreateTabletResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(TabletServerAdminServiceServer).CreateTablet(ctx, request.(*CreateTabletRequestPB))
+			},
+		},
+		{
+			MethodName:  "DeleteTablet",
+			NewRequest:  func() proto.Message { return &DeleteTabletRequestPB{} },
+			NewResponse: func() proto.Message { return &DeleteTabletResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(TabletServerAdminServiceServer).DeleteTablet(ctx, request.(*DeleteTabletRequestPB))
+			},
+		},
+		{
+			MethodName:  "AlterSchema",
+			NewRequest:  func() proto.Message { return &ChangeMetadataRequestPB{} },
+			NewResponse: func() proto.Message { return &ChangeMetadataResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(TabletServerAdminServiceServer).AlterSchema(ctx, request.(*ChangeMetadataRequestPB))
+			},
+		},
+		{
+			MethodName:  "GetSafeTime",
+			NewRequest:  func() proto.Message { return &GetSafeTimeRequestPB{} },
+			NewResponse: func() proto.Message { return &GetSafeTimeResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(TabletServerAdminServiceServer).GetSafeTime(ctx, request.(*GetSafeTimeRequestPB))
+			},
+		},
+		{
+			MethodName:  "BackfillIndex",
+			NewRequest:  func() proto.Message { return &BackfillIndexRequestPB{} },
+			NewResponse: func() proto.Message { return &BackfillIndexResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(TabletServerAdminServiceServer).BackfillIndex(ctx, request.(*BackfillIndexRequestPB))
+			},
+		},
+		{
+			MethodName:  "BackfillDone",
+			NewRequest:  func() proto.Message { return &ChangeMetadataRequestPB{} },
+			NewResponse: func() proto.Message { return &ChangeMetadataResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(TabletServerAdminServiceServer).BackfillDone(ctx, request.(*ChangeMetadataRequestPB))
+			},
+		},
+		{
+			MethodName:  "CopartitionTable",
+			NewRequest:  func() proto.Message { return &CopartitionTableRequestPB{} },
+			NewResponse: func() proto.Message { return &CopartitionTableResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(TabletServerAdminServiceServer).CopartitionTable(ctx, request.(*CopartitionTableRequestPB))
+			},
+		},
+		{
+			MethodName:  "FlushTablets",
+			NewRequest:  func() proto.Message { return &FlushTabletsRequestPB{} },
+			NewResponse: func() proto.Message { return &FlushTabletsResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(TabletServerAdminServiceServer).FlushTablets(ctx, request.(*FlushTabletsRequestPB))
+			},
+		},
+		{
+			MethodName:  "CountIntents",
+			NewRequest:  func() proto.Message { return &CountIntentsRequestPB{} },
+			NewResponse: func() proto.Message { return &CountIntentsResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(TabletServerAdminServiceServer).CountIntents(ctx, request.(*CountIntentsRequestPB))
+			},
+		},
+		{
+			MethodName:  "AddTableToTablet",
+			NewRequest:  func() proto.Message { return &AddTableToTabletRequestPB{} },
+			NewResponse: func() proto.Message { return &AddTableToTabletResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(TabletServerAdminServiceServer).AddTableToTablet(ctx, request.(*AddTableToTabletRequestPB))
+			},
+		},
+		{
+			MethodName:  "RemoveTableFromTablet",
+			NewRequest:  func() proto.Message { return &RemoveTableFromTabletRequestPB{} },
+			NewResponse: func() proto.Message { return &RemoveTableFromTabletResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(TabletServerAdminServiceServer).RemoveTableFromTablet(ctx, request.(*RemoveTableFromTabletRequestPB))
+			},
+		},
+		{
+			MethodName:  "SplitTablet",
+			NewRequest:  func() proto.Message { return &SplitTabletRequestPB{} },
+			NewResponse: func() proto.Message { return &SplitTabletResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(TabletServerAdminServiceServer).SplitTablet(ctx, request.(*SplitTabletRequestPB))
+			},
+		},
+	},
+}
+
+// RegisterTabletServerAdminService serves yb.tserver.TabletServerAdminService with srv.
+func RegisterTabletServerAdminService(r dispatch.Registrar, srv TabletServerAdminServiceServer) {
+	r.RegisterService(&TabletServerAdminService_ServiceDesc, srv)
+}
+
+func init() {
+	dispatch.RegisterServiceDesc(&TabletServerAdminService_ServiceDesc)
+}
@@ -0,0 +1,208 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// The following only applies to changes made to this file as part of YugaByte development.
+//
+// Portions Copyright (c) YugaByte, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied.  See the License for the specific language governing permissions and limitations
+// under the License.
+//
+
+// Code generated by protoc-gen-ybrpc. DO NOT EDIT.
+
+package tserver
+
+import (
+	"context"
+
+	"github.com/yugabyte/yb-tools/protoc-gen-ybrpc/pkg/dispatch"
+	"github.com/yugabyte/yb-tools/protoc-gen-ybrpc/pkg/mock"
+)
+
+// MockTabletServerAdminService is a TabletServerAdminService for tests. Each method calls its stub function,
+// or fails with dispatch.ErrNoSuchMethod when the stub is not set, and every
+// call is recorded.
+type MockTabletServerAdminService struct {
+	mock.Recorder
+
+	CreateTabletFunc          func(ctx context.Context, request *CreateTabletRequestPB) (*CreateTabletResponsePB, error)
+	DeleteTabletFunc          func(ctx context.Context, request *DeleteTabletRequestPB) (*DeleteTabletResponsePB, error)
+	AlterSchemaFunc           func(ctx context.Context, request *ChangeMetadataRequestPB) (*ChangeMetadataResponsePB, error)
+	GetSafeTimeFunc           func(ctx context.Context, request *GetSafeTimeRequestPB) (*GetSafeTimeResponsePB, error)
+	BackfillIndexFunc         func(ctx context.Context, request *BackfillIndexRequestPB) (*BackfillIndexResponsePB, error)
+	BackfillDoneFunc          func(ctx context.Context, request *ChangeMetadataRequestPB) (*ChangeMetadataResponsePB, error)
+	CopartitionTableFunc      func(ctx context.Context, request *CopartitionTableRequestPB) (*CopartitionTableResponsePB, error)
+	FlushTabletsFunc          func(ctx context.Context, request *FlushTabletsRequestPB) (*FlushTabletsResponsePB, error)
+	CountIntentsFunc          func(ctx context.Context, request *CountIntentsRequestPB) (*CountIntentsResponsePB, error)
+	AddTableToTabletFunc      func(ctx context.Context, request *AddTableToTabletRequestPB) (*AddTableToTabletResponsePB, error)
+	RemoveTableFromTabletFunc func(ctx context.Context, request *RemoveTableFromTabletRequestPB) (*RemoveTableFromTabletResponsePB, error)
+	SplitTabletFunc           func(ctx context.Context, request *SplitTabletRequestPB) (*SplitTabletResponsePB, error)
+}
+
+var _ TabletServerAdminService = &MockTabletServerAdminService{}
+
+func (s *MockTabletServerAdminService) CreateTablet(request *CreateTabletRequestPB) (*CreateTabletResponsePB, error) {
+	return s.CreateTabletWithContext(context.Background(), request)
+}
+
+func (s *MockTabletServerAdminService) CreateTabletWithContext(ctx context.Context, request *CreateTabletRequestPB) (*CreateTabletResponsePB, error) {
+	s.Record("CreateTablet", request)
+	if s.CreateTabletFunc == nil {
+		return nil, dispatch.Unimplemented("yb.tserver.TabletServerAdminService", "CreateTablet")
+	}
+	return s.CreateTabletFunc(ctx, request)
+}
+
+func (s *MockTabletServerAdminService) DeleteTablet(request *DeleteTabletRequestPB) (*DeleteTabletResponsePB, error) {
+	return s.DeleteTabletWithContext(context.Background(), request)
+}
+
+func (s *MockTabletServerAdminService) DeleteTabletWithContext(ctx context.Context, request *DeleteTabletRequestPB) (*DeleteTabletResponsePB, error) {
+	s.Record("DeleteTablet", request)
+	if s.DeleteTabletFunc == nil {
+		return nil, dispatch.Unimplemented("yb.tserver.TabletServerAdminService", "DeleteTablet")
+	}
+	return s.DeleteTabletFunc(ctx, request)
+}
+
+func (s *MockTabletServerAdminService) AlterSchema(request *ChangeMetadataRequestPB) (*ChangeMetadataResponsePB, error) {
+	return s.AlterSchemaWithContext(context.Background(), request)
+}
+
+func (s *MockTabletServerAdminService) AlterSchemaWithContext(ctx context.Context, request *ChangeMetadataRequestPB) (*ChangeMetadataResponsePB, error) {
+	s.Record("AlterSchema", request)
+	if s.AlterSchemaFunc == nil {
+		return nil, dispatch.Unimplemented("yb.tserver.TabletServerAdminService", "AlterSchema")
+	}
+	return s.AlterSchemaFunc(ctx, request)
+}
+
+func (s *MockTabletServerAdminService) GetSafeTime(request *GetSafeTimeRequestPB) (*GetSafeTimeResponsePB, error) {
+	return s.GetSafeTimeWithContext(context.Background(), request)
+}
+
+func (s *MockTabletServerAdminService) GetSafeTimeWithContext(ctx context.Context, request *GetSafeTimeRequestPB) (*GetSafeTimeResponsePB, error) {
+	s.Record("GetSafeTime", request)
+	if s.GetSafeTimeFunc == nil {
+		return nil, dispatch.Unimplemented("yb.tserver.TabletServerAdminService", "GetSafeTime")
+	}
+	return s.GetSafeTimeFunc(ctx, request)
+}
+
+func (s *MockTabletServerAdminService) BackfillIndex(request *BackfillIndexRequestPB) (*BackfillIndexResponsePB, error) {
+	return s.BackfillIndexWithContext(context.Background(), request)
+}
+
+func (s *MockTabletServerAdminService) BackfillIndexWithContext(ctx context.Context, request *BackfillIndexRequestPB) (*BackfillIndexResponsePB, error) {
+	s.Record("BackfillIndex", request)
+	if s.BackfillIndexFunc == nil {
+		return nil, dispatch.Unimplemented("yb.tserver.TabletServerAdminService", "BackfillIndex")
+	}
+	return s.BackfillIndexFunc(ctx, request)
+}
+
+func (s *MockTabletServerAdminService) BackfillDone(request *ChangeMetadataRequestPB) (*ChangeMetadataResponsePB, error) {
+	return s.BackfillDoneWithContext(context.Background(), request)
+}
+
+func (s *MockTabletServerAdminService) BackfillDoneWithContext(ctx context.Context, request *ChangeMetadataRequestPB) (*ChangeMetadataResponsePB, error) {
+	s.Record("BackfillDone", request)
+	if s.BackfillDoneFunc == nil {
+		return nil, dispatch.Unimplemented("yb.tserver.TabletServerAdminService", "BackfillDone")
+	}
+	return s.BackfillDoneFunc(ctx, request)
+}
+
+func (s *MockTabletServerAdminService) CopartitionTable(request *CopartitionTableRequestPB) (*CopartitionTableResponsePB, error) {
+	return s.CopartitionTableWithContext(context.Background(), request)
+}
+
+func (s *MockTabletServerAdminService) CopartitionTableWithContext(ctx context.Context, request *CopartitionTableRequestPB) (*CopartitionTableResponsePB, error) {
+	s.Record("CopartitionTable", request)
+	if s.CopartitionTableFunc == nil {
+		return nil, dispatch.Unimplemented("yb.tserver.TabletServerAdminService", "CopartitionTable")
+	}
+	return s.CopartitionTableFunc(ctx, request)
+}
+
+func (s *MockTabletServerAdminService) FlushTablets(request *FlushTabletsRequestPB) (*FlushTabletsResponsePB, error) {
+	return s.FlushTabletsWithContext(context.Background(), request)
+}
+
+func (s *MockTabletServerAdminService) FlushTabletsWithContext(ctx context.Context, request *FlushTabletsRequestPB) (*FlushTabletsResponsePB, error) {
+	s.Record("FlushTablets", request)
+	if s.FlushTabletsFunc == nil {
+		return nil, dispatch.Unimplemented("yb.tserver.TabletServerAdminService", "FlushTablets")
+	}
+	return s.FlushTabletsFunc(ctx, request)
+}
+
+func (s *MockTabletServerAdminService) CountIntents(request *CountIntentsRequestPB) (*CountIntentsResponsePB, error) {
+	return s.CountIntentsWithContext(context.Background(), request)
+}
+
+func (s *MockTabletServerAdminService) CountIntentsWithContext(ctx context.Context, request *CountIntentsRequestPB) (*CountIntentsResponsePB, error) {
+	s.Record("CountIntents", request)
+	if s.CountIntentsFunc == nil {
+		return nil, dispatch.Unimplemented("yb.tserver.TabletServerAdminService", "CountIntents")
+	}
+	return s.CountIntentsFunc(ctx, request)
+}
+
+func (s *MockTabletServerAdminService) AddTableToTablet(request *AddTableToTabletRequestPB) (*AddTableToTabletResponsePB, error) {
+	return s.AddTableToTabletWithContext(context.Background(), request)
+}
+
+func (s *MockTabletServerAdminService) AddTableToTabletWithContext(ctx context.Context, request *AddTableToTabletRequestPB) (*AddTableToTabletResponsePB, error) {
+	s.Record("AddTableToTablet", request)
+	if s.AddTableToTabletFunc == nil {
+		return nil, dispatch.Unimplemented("yb.tserver.TabletServerAdminService", "AddTableToTablet")
+	}
+	return s.AddTableToTabletFunc(ctx, request)
+}
+
+func (s *MockTabletServerAdminService) RemoveTableFromTablet(request *RemoveTableFromTabletRequestPB) (*RemoveTableFromTabletResponsePB, error) {
+	return s.RemoveTableFromTabletWithContext(context.Background(), request)
+}
+
+func (s *MockTabletServerAdminService) RemoveTableFromTabletWithContext(ctx context.Context, request *RemoveTableFromTabletRequestPB) (*RemoveTableFromTabletResponsePB, error) {
+	s.Record("RemoveTableFromTablet", request)
+	if s.RemoveTableFromTabletFunc == nil {
+		return nil, dispatch.Unimplemented("yb.tserver.TabletServerAdminService", "RemoveTableFromTablet")
+	}
+	return s.RemoveTableFromTabletFunc(ctx, request)
+}
+
+func (s *MockTabletServerAdminService) SplitTablet(request *SplitTabletRequestPB) (*SplitTabletResponsePB, error) {
+	return s.SplitTabletWithContext(context.Background(), request)
+}
+
+func (s *MockTabletServerAdminService) SplitTabletWithContext(ctx context.Context, request *SplitTabletRequestPB) (*SplitTabletResponsePB, error) {
+	s.Record("SplitTablet", request)
+	if s.SplitTabletFunc == nil {
+		return nil, dispatch.Unimplemented("yb.tserver.TabletServerAdminService", "SplitTablet")
+	}
+	return s.SplitTabletFunc(ctx, request)
+}
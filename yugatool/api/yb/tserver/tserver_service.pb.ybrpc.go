@@ -35,31 +35,53 @@
 package tserver
 
 import (
+	"context"
+
 	"github.com/go-logr/logr"
+	"github.com/yugabyte/yb-tools/protoc-gen-ybrpc/pkg/dispatch"
 	"github.com/yugabyte/yb-tools/protoc-gen-ybrpc/pkg/message"
+	"google.golang.org/protobuf/proto"
 )
 
 // service: yb.tserver.TabletServerService
 // service: TabletServerService
 type TabletServerService interface {
 	Write(request *WriteRequestPB) (*WriteResponsePB, error)
+	WriteWithContext(ctx context.Context, request *WriteRequestPB) (*WriteResponsePB, error)
 	Read(request *ReadRequestPB) (*ReadResponsePB, error)
+	ReadWithContext(ctx context.Context, request *ReadRequestPB) (*ReadResponsePB, error)
 	NoOp(request *NoOpRequestPB) (*NoOpResponsePB, error)
+	NoOpWithContext(ctx context.Context, request *NoOpRequestPB) (*NoOpResponsePB, error)
 	ListTablets(request *ListTabletsRequestPB) (*ListTabletsResponsePB, error)
+	ListTabletsWithContext(ctx context.Context, request *ListTabletsRequestPB) (*ListTabletsResponsePB, error)
 	GetLogLocation(request *GetLogLocationRequestPB) (*GetLogLocationResponsePB, error)
+	GetLogLocationWithContext(ctx context.Context, request *GetLogLocationRequestPB) (*GetLogLocationResponsePB, error)
 	Checksum(request *ChecksumRequestPB) (*ChecksumResponsePB, error)
+	ChecksumWithContext(ctx context.Context, request *ChecksumRequestPB) (*ChecksumResponsePB, error)
 	ListTabletsForTabletServer(request *ListTabletsForTabletServerRequestPB) (*ListTabletsForTabletServerResponsePB, error)
+	ListTabletsForTabletServerWithContext(ctx context.Context, request *ListTabletsForTabletServerRequestPB) (*ListTabletsForTabletServerResponsePB, error)
 	ImportData(request *ImportDataRequestPB) (*ImportDataResponsePB, error)
+	ImportDataWithContext(ctx context.Context, request *ImportDataRequestPB) (*ImportDataResponsePB, error)
 	UpdateTransaction(request *UpdateTransactionRequestPB) (*UpdateTransactionResponsePB, error)
+	UpdateTransactionWithContext(ctx context.Context, request *UpdateTransactionRequestPB) (*UpdateTransactionResponsePB, error)
 	GetTransactionStatus(request *GetTransactionStatusRequestPB) (*GetTransactionStatusResponsePB, error)
+	GetTransactionStatusWithContext(ctx context.Context, request *GetTransactionStatusRequestPB) (*GetTransactionStatusResponsePB, error)
 	GetTransactionStatusAtParticipant(request *GetTransactionStatusAtParticipantRequestPB) (*GetTransactionStatusAtParticipantResponsePB, error)
+	GetTransactionStatusAtParticipantWithContext(ctx context.Context, request *GetTransactionStatusAtParticipantRequestPB) (*GetTransactionStatusAtParticipantResponsePB, error)
 	AbortTransaction(request *AbortTransactionRequestPB) (*AbortTransactionResponsePB, error)
+	AbortTransactionWithContext(ctx context.Context, request *AbortTransactionRequestPB) (*AbortTransactionResponsePB, error)
 	Truncate(request *TruncateRequestPB) (*TruncateResponsePB, error)
+	TruncateWithContext(ctx context.Context, request *TruncateRequestPB) (*TruncateResponsePB, error)
 	GetTabletStatus(request *GetTabletStatusRequestPB) (*GetTabletStatusResponsePB, error)
+	GetTabletStatusWithContext(ctx context.Context, request *GetTabletStatusRequestPB) (*GetTabletStatusResponsePB, error)
 	GetMasterAddresses(request *GetMasterAddressesRequestPB) (*GetMasterAddressesResponsePB, error)
+	GetMasterAddressesWithContext(ctx context.Context, request *GetMasterAddressesRequestPB) (*GetMasterAddressesResponsePB, error)
 	Publish(request *PublishRequestPB) (*PublishResponsePB, error)
+	PublishWithContext(ctx context.Context, request *PublishRequestPB) (*PublishResponsePB, error)
 	IsTabletServerReady(request *IsTabletServerReadyRequestPB) (*IsTabletServerReadyResponsePB, error)
+	IsTabletServerReadyWithContext(ctx context.Context, request *IsTabletServerReadyRequestPB) (*IsTabletServerReadyResponsePB, error)
 	TakeTransaction(request *TakeTransactionRequestPB) (*TakeTransactionResponsePB, error)
+	TakeTransactionWithContext(ctx context.Context, request *TakeTransactionRequestPB) (*TakeTransactionResponsePB, error)
 }
 
 type TabletServerServiceImpl struct {
@@ -68,10 +90,14 @@ type TabletServerServiceImpl struct {
 }
 
 func (s *TabletServerServiceImpl) Write(request *WriteRequestPB) (*WriteResponsePB, error) {
+	return s.WriteWithContext(context.Background(), request)
+}
+
+func (s *TabletServerServiceImpl) WriteWithContext(ctx context.Context, request *WriteRequestPB) (*WriteResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.tserver.TabletServerService", "method", "Write", "request", request)
 	response := &WriteResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.tserver.TabletServerService", "Write", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.tserver.TabletServerService", "Write", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -82,10 +108,14 @@ func (s *TabletServerServiceImpl) Write(request *WriteRequestPB) (*WriteResponse
 }
 
 func (s *TabletServerServiceImpl) Read(request *ReadRequestPB) (*ReadResponsePB, error) {
+	return s.ReadWithContext(context.Background(), request)
+}
+
+func (s *TabletServerServiceImpl) ReadWithContext(ctx context.Context, request *ReadRequestPB) (*ReadResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.tserver.TabletServerService", "method", "Read", "request", request)
 	response := &ReadResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.tserver.TabletServerService", "Read", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.tserver.TabletServerService", "Read", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -96,10 +126,14 @@ func (s *TabletServerServiceImpl) Read(request *ReadRequestPB) (*ReadResponsePB,
 }
 
 func (s *TabletServerServiceImpl) NoOp(request *NoOpRequestPB) (*NoOpResponsePB, error) {
+	return s.NoOpWithContext(context.Background(), request)
+}
+
+func (s *TabletServerServiceImpl) NoOpWithContext(ctx context.Context, request *NoOpRequestPB) (*NoOpResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.tserver.TabletServerService", "method", "NoOp", "request", request)
 	response := &NoOpResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.tserver.TabletServerService", "NoOp", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.tserver.TabletServerService", "NoOp", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -110,10 +144,14 @@ func (s *TabletServerServiceImpl) NoOp(request *NoOpRequestPB) (*NoOpResponsePB,
 }
 
 func (s *TabletServerServiceImpl) ListTablets(request *ListTabletsRequestPB) (*ListTabletsResponsePB, error) {
+	return s.ListTabletsWithContext(context.Background(), request)
+}
+
+func (s *TabletServerServiceImpl) ListTabletsWithContext(ctx context.Context, request *ListTabletsRequestPB) (*ListTabletsResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.tserver.TabletServerService", "method", "ListTablets", "request", request)
 	response := &ListTabletsResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.tserver.TabletServerService", "ListTablets", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.tserver.TabletServerService", "ListTablets", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -124,10 +162,14 @@ func (s *TabletServerServiceImpl) ListTablets(request *ListTabletsRequestPB) (*L
 }
 
 func (s *TabletServerServiceImpl) GetLogLocation(request *GetLogLocationRequestPB) (*GetLogLocationResponsePB, error) {
+	return s.GetLogLocationWithContext(context.Background(), request)
+}
+
+func (s *TabletServerServiceImpl) GetLogLocationWithContext(ctx context.Context, request *GetLogLocationRequestPB) (*GetLogLocationResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.tserver.TabletServerService", "method", "GetLogLocation", "request", request)
 	response := &GetLogLocationResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.tserver.TabletServerService", "GetLogLocation", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.tserver.TabletServerService", "GetLogLocation", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -143,10 +185,19 @@ func (s *TabletServerServiceImpl) GetLogLocation(request *GetLogLocationRequestP
 // function.
 
 func (s *TabletServerServiceImpl) Checksum(request *ChecksumRequestPB) (*ChecksumResponsePB, error) {
+	return s.ChecksumWithContext(context.Background(), request)
+}
+
+// Run full-scan data checksum on a tablet to verify data integrity.
+//
+// TODO: Consider refactoring this as a scan that runs a checksum aggregation
+// function.
+
+func (s *TabletServerServiceImpl) ChecksumWithContext(ctx context.Context, request *ChecksumRequestPB) (*ChecksumResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.tserver.TabletServerService", "method", "Checksum", "request", request)
 	response := &ChecksumResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.tserver.TabletServerService", "Checksum", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.tserver.TabletServerService", "Checksum", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -157,10 +208,14 @@ func (s *TabletServerServiceImpl) Checksum(request *ChecksumRequestPB) (*Checksu
 }
 
 func (s *TabletServerServiceImpl) ListTabletsForTabletServer(request *ListTabletsForTabletServerRequestPB) (*ListTabletsForTabletServerResponsePB, error) {
+	return s.ListTabletsForTabletServerWithContext(context.Background(), request)
+}
+
+func (s *TabletServerServiceImpl) ListTabletsForTabletServerWithContext(ctx context.Context, request *ListTabletsForTabletServerRequestPB) (*ListTabletsForTabletServerResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.tserver.TabletServerService", "method", "ListTabletsForTabletServer", "request", request)
 	response := &ListTabletsForTabletServerResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.tserver.TabletServerService", "ListTabletsForTabletServer", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.tserver.TabletServerService", "ListTabletsForTabletServer", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -171,10 +226,14 @@ func (s *TabletServerServiceImpl) ListTabletsForTabletServer(request *ListTablet
 }
 
 func (s *TabletServerServiceImpl) ImportData(request *ImportDataRequestPB) (*ImportDataResponsePB, error) {
+	return s.ImportDataWithContext(context.Background(), request)
+}
+
+func (s *TabletServerServiceImpl) ImportDataWithContext(ctx context.Context, request *ImportDataRequestPB) (*ImportDataResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.tserver.TabletServerService", "method", "ImportData", "request", request)
 	response := &ImportDataResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.tserver.TabletServerService", "ImportData", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.tserver.TabletServerService", "ImportData", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -185,10 +244,14 @@ func (s *TabletServerServiceImpl) ImportData(request *ImportDataRequestPB) (*Imp
 }
 
 func (s *TabletServerServiceImpl) UpdateTransaction(request *UpdateTransactionRequestPB) (*UpdateTransactionResponsePB, error) {
+	return s.UpdateTransactionWithContext(context.Background(), request)
+}
+
+func (s *TabletServerServiceImpl) UpdateTransactionWithContext(ctx context.Context, request *UpdateTransactionRequestPB) (*UpdateTransactionResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.tserver.TabletServerService", "method", "UpdateTransaction", "request", request)
 	response := &UpdateTransactionResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.tserver.TabletServerService", "UpdateTransaction", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.tserver.TabletServerService", "UpdateTransaction", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -201,10 +264,16 @@ func (s *TabletServerServiceImpl) UpdateTransaction(request *UpdateTransactionRe
 // Returns transaction status at coordinator, i.e. PENDING, ABORTED, COMMITTED etc.
 
 func (s *TabletServerServiceImpl) GetTransactionStatus(request *GetTransactionStatusRequestPB) (*GetTransactionStatusResponsePB, error) {
+	return s.GetTransactionStatusWithContext(context.Background(), request)
+}
+
+// Returns transaction status at coordinator, i.e. PENDING, ABORTED, COMMITTED etc.
+
+func (s *TabletServerServiceImpl) GetTransactionStatusWithContext(ctx context.Context, request *GetTransactionStatusRequestPB) (*GetTransactionStatusResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.tserver.TabletServerService", "method", "GetTransactionStatus", "request", request)
 	response := &GetTransactionStatusResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.tserver.TabletServerService", "GetTransactionStatus", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.tserver.TabletServerService", "GetTransactionStatus", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -218,10 +287,17 @@ func (s *TabletServerServiceImpl) GetTransactionStatus(request *GetTransactionSt
 // aborted.
 
 func (s *TabletServerServiceImpl) GetTransactionStatusAtParticipant(request *GetTransactionStatusAtParticipantRequestPB) (*GetTransactionStatusAtParticipantResponsePB, error) {
+	return s.GetTransactionStatusAtParticipantWithContext(context.Background(), request)
+}
+
+// Returns transaction status at participant, i.e. number of replicated batches or whether it was
+// aborted.
+
+func (s *TabletServerServiceImpl) GetTransactionStatusAtParticipantWithContext(ctx context.Context, request *GetTransactionStatusAtParticipantRequestPB) (*GetTransactionStatusAtParticipantResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.tserver.TabletServerService", "method", "GetTransactionStatusAtParticipant", "request", request)
 	response := &GetTransactionStatusAtParticipantResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.tserver.TabletServerService", "GetTransactionStatusAtParticipant", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.tserver.TabletServerService", "GetTransactionStatusAtParticipant", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -232,10 +308,14 @@ func (s *TabletServerServiceImpl) GetTransactionStatusAtParticipant(request *Get
 }
 
 func (s *TabletServerServiceImpl) AbortTransaction(request *AbortTransactionRequestPB) (*AbortTransactionResponsePB, error) {
+	return s.AbortTransactionWithContext(context.Background(), request)
+}
+
+func (s *TabletServerServiceImpl) AbortTransactionWithContext(ctx context.Context, request *AbortTransactionRequestPB) (*AbortTransactionResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.tserver.TabletServerService", "method", "AbortTransaction", "request", request)
 	response := &AbortTransactionResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.tserver.TabletServerService", "AbortTransaction", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.tserver.TabletServerService", "AbortTransaction", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -246,10 +326,14 @@ func (s *TabletServerServiceImpl) AbortTransaction(request *AbortTransactionRequ
 }
 
 func (s *TabletServerServiceImpl) Truncate(request *TruncateRequestPB) (*TruncateResponsePB, error) {
+	return s.TruncateWithContext(context.Background(), request)
+}
+
+func (s *TabletServerServiceImpl) TruncateWithContext(ctx context.Context, request *TruncateRequestPB) (*TruncateResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.tserver.TabletServerService", "method", "Truncate", "request", request)
 	response := &TruncateResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.tserver.TabletServerService", "Truncate", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.tserver.TabletServerService", "Truncate", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -260,10 +344,14 @@ func (s *TabletServerServiceImpl) Truncate(request *TruncateRequestPB) (*Truncat
 }
 
 func (s *TabletServerServiceImpl) GetTabletStatus(request *GetTabletStatusRequestPB) (*GetTabletStatusResponsePB, error) {
+	return s.GetTabletStatusWithContext(context.Background(), request)
+}
+
+func (s *TabletServerServiceImpl) GetTabletStatusWithContext(ctx context.Context, request *GetTabletStatusRequestPB) (*GetTabletStatusResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.tserver.TabletServerService", "method", "GetTabletStatus", "request", request)
 	response := &GetTabletStatusResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.tserver.TabletServerService", "GetTabletStatus", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.tserver.TabletServerService", "GetTabletStatus", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -274,10 +362,14 @@ func (s *TabletServerServiceImpl) GetTabletStatus(request *GetTabletStatusReques
 }
 
 func (s *TabletServerServiceImpl) GetMasterAddresses(request *GetMasterAddressesRequestPB) (*GetMasterAddressesResponsePB, error) {
+	return s.GetMasterAddressesWithContext(context.Background(), request)
+}
+
+func (s *TabletServerServiceImpl) GetMasterAddressesWithContext(ctx context.Context, request *GetMasterAddressesRequestPB) (*GetMasterAddressesResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.tserver.TabletServerService", "method", "GetMasterAddresses", "request", request)
 	response := &GetMasterAddressesResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.tserver.TabletServerService", "GetMasterAddresses", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.tserver.TabletServerService", "GetMasterAddresses", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -288,10 +380,14 @@ func (s *TabletServerServiceImpl) GetMasterAddresses(request *GetMasterAddresses
 }
 
 func (s *TabletServerServiceImpl) Publish(request *PublishRequestPB) (*PublishResponsePB, error) {
+	return s.PublishWithContext(context.Background(), request)
+}
+
+func (s *TabletServerServiceImpl) PublishWithContext(ctx context.Context, request *PublishRequestPB) (*PublishResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.tserver.TabletServerService", "method", "Publish", "request", request)
 	response := &PublishResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.tserver.TabletServerService", "Publish", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.tserver.TabletServerService", "Publish", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -302,10 +398,14 @@ func (s *TabletServerServiceImpl) Publish(request *PublishRequestPB) (*PublishRe
 }
 
 func (s *TabletServerServiceImpl) IsTabletServerReady(request *IsTabletServerReadyRequestPB) (*IsTabletServerReadyResponsePB, error) {
+	return s.IsTabletServerReadyWithContext(context.Background(), request)
+}
+
+func (s *TabletServerServiceImpl) IsTabletServerReadyWithContext(ctx context.Context, request *IsTabletServerReadyRequestPB) (*IsTabletServerReadyResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.tserver.TabletServerService", "method", "IsTabletServerReady", "request", request)
 	response := &IsTabletServerReadyResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.tserver.TabletServerService", "IsTabletServerReady", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.tserver.TabletServerService", "IsTabletServerReady", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -318,10 +418,16 @@ func (s *TabletServerServiceImpl) IsTabletServerReady(request *IsTabletServerRea
 // Takes precreated transaction from this tserver.
 
 func (s *TabletServerServiceImpl) TakeTransaction(request *TakeTransactionRequestPB) (*TakeTransactionResponsePB, error) {
+	return s.TakeTransactionWithContext(context.Background(), request)
+}
+
+// Takes precreated transaction from this tserver.
+
+func (s *TabletServerServiceImpl) TakeTransactionWithContext(ctx context.Context, request *TakeTransactionRequestPB) (*TakeTransactionResponsePB, error) {
 	s.Log.V(1).Info("sending RPC request", "service", "yb.tserver.TabletServerService", "method", "TakeTransaction", "request", request)
 	response := &TakeTransactionResponsePB{}
 
-	err := s.Messenger.SendMessage("yb.tserver.TabletServerService", "TakeTransaction", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
+	err := s.Messenger.SendMessage(ctx, "yb.tserver.TabletServerService", "TakeTransaction", request.ProtoReflect().Interface(), response.ProtoReflect().Interface())
 	if err != nil {
 		return nil, err
 	}
@@ -330,3 +436,261 @@ func (s *TabletServerServiceImpl) TakeTransaction(request *TakeTransactionReques
 
 	return response, nil
 }
+
+// TabletServerServiceServer is the server API for yb.tserver.TabletServerService.
+type TabletServerServiceServer interface {
+	Write(ctx context.Context, request *WriteRequestPB) (*WriteResponsePB, error)
+	Read(ctx context.Context, request *ReadRequestPB) (*ReadResponsePB, error)
+	NoOp(ctx context.Context, request *NoOpRequestPB) (*NoOpResponsePB, error)
+	ListTablets(ctx context.Context, request *ListTabletsRequestPB) (*ListTabletsResponsePB, error)
+	GetLogLocation(ctx context.Context, request *GetLogLocationRequestPB) (*GetLogLocationResponsePB, error)
+	Checksum(ctx context.Context, request *ChecksumRequestPB) (*ChecksumResponsePB, error)
+	ListTabletsForTabletServer(ctx context.Context, request *ListTabletsForTabletServerRequestPB) (*ListTabletsForTabletServerResponsePB, error)
+	ImportData(ctx context.Context, request *ImportDataRequestPB) (*ImportDataResponsePB, error)
+	UpdateTransaction(ctx context.Context, request *UpdateTransactionRequestPB) (*UpdateTransactionResponsePB, error)
+	GetTransactionStatus(ctx context.Context, request *GetTransactionStatusRequestPB) (*GetTransactionStatusResponsePB, error)
+	GetTransactionStatusAtParticipant(ctx context.Context, request *GetTransactionStatusAtParticipantRequestPB) (*GetTransactionStatusAtParticipantResponsePB, error)
+	AbortTransaction(ctx context.Context, request *AbortTransactionRequestPB) (*AbortTransactionResponsePB, error)
+	Truncate(ctx context.Context, request *TruncateRequestPB) (*TruncateResponsePB, error)
+	GetTabletStatus(ctx context.Context, request *GetTabletStatusRequestPB) (*GetTabletStatusResponsePB, error)
+	GetMasterAddresses(ctx context.Context, request *GetMasterAddressesRequestPB) (*GetMasterAddressesResponsePB, error)
+	Publish(ctx context.Context, request *PublishRequestPB) (*PublishResponsePB, error)
+	IsTabletServerReady(ctx context.Context, request *IsTabletServerReadyRequestPB) (*IsTabletServerReadyResponsePB, error)
+	TakeTransaction(ctx context.Context, request *TakeTransactionRequestPB) (*TakeTransactionResponsePB, error)
+}
+
+// UnimplementedTabletServerServiceServer can be embedded in implementations of TabletServerServiceServer that only serve
+// some of the methods. The others fail with dispatch.ErrNoSuchMethod.
+type UnimplementedTabletServerServiceServer struct{}
+
+func (UnimplementedTabletServerServiceServer) Write(ctx context.Context, request *WriteRequestPB) (*WriteResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.tserver.TabletServerService", "Write")
+}
+
+func (UnimplementedTabletServerServiceServer) Read(ctx context.Context, request *ReadRequestPB) (*ReadResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.tserver.TabletServerService", "Read")
+}
+
+func (UnimplementedTabletServerServiceServer) NoOp(ctx context.Context, request *NoOpRequestPB) (*NoOpResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.tserver.TabletServerService", "NoOp")
+}
+
+func (UnimplementedTabletServerServiceServer) ListTablets(ctx context.Context, request *ListTabletsRequestPB) (*ListTabletsResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.tserver.TabletServerService", "ListTablets")
+}
+
+func (UnimplementedTabletServerServiceServer) GetLogLocation(ctx context.Context, request *GetLogLocationRequestPB) (*GetLogLocationResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.tserver.TabletServerService", "GetLogLocation")
+}
+
+func (UnimplementedTabletServerServiceServer) Checksum(ctx context.Context, request *ChecksumRequestPB) (*ChecksumResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.tserver.TabletServerService", "Checksum")
+}
+
+func (UnimplementedTabletServerServiceServer) ListTabletsForTabletServer(ctx context.Context, request *ListTabletsForTabletServerRequestPB) (*ListTabletsForTabletServerResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.tserver.TabletServerService", "ListTabletsForTabletServer")
+}
+
+func (UnimplementedTabletServerServiceServer) ImportData(ctx context.Context, request *ImportDataRequestPB) (*ImportDataResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.tserver.TabletServerService", "ImportData")
+}
+
+func (UnimplementedTabletServerServiceServer) UpdateTransaction(ctx context.Context, request *UpdateTransactionRequestPB) (*UpdateTransactionResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.tserver.TabletServerService", "UpdateTransaction")
+}
+
+func (UnimplementedTabletServerServiceServer) GetTransactionStatus(ctx context.Context, request *GetTransactionStatusRequestPB) (*GetTransactionStatusResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.tserver.TabletServerService", "GetTransactionStatus")
+}
+
+func (UnimplementedTabletServerServiceServer) GetTransactionStatusAtParticipant(ctx context.Context, request *GetTransactionStatusAtParticipantRequestPB) (*GetTransactionStatusAtParticipantResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.tserver.TabletServerService", "GetTransactionStatusAtParticipant")
+}
+
+func (UnimplementedTabletServerServiceServer) AbortTransaction(ctx context.Context, request *AbortTransactionRequestPB) (*AbortTransactionResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.tserver.TabletServerService", "AbortTransaction")
+}
+
+func (UnimplementedTabletServerServiceServer) Truncate(ctx context.Context, request *TruncateRequestPB) (*TruncateResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.tserver.TabletServerService", "Truncate")
+}
+
+func (UnimplementedTabletServerServiceServer) GetTabletStatus(ctx context.Context, request *GetTabletStatusRequestPB) (*GetTabletStatusResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.tserver.TabletServerService", "GetTabletStatus")
+}
+
+func (UnimplementedTabletServerServiceServer) GetMasterAddresses(ctx context.Context, request *GetMasterAddressesRequestPB) (*GetMasterAddressesResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.tserver.TabletServerService", "GetMasterAddresses")
+}
+
+func (UnimplementedTabletServerServiceServer) Publish(ctx context.Context, request *PublishRequestPB) (*PublishResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.tserver.TabletServerService", "Publish")
+}
+
+func (UnimplementedTabletServerServiceServer) IsTabletServerReady(ctx context.Context, request *IsTabletServerReadyRequestPB) (*IsTabletServerReadyResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.tserver.TabletServerService", "IsTabletServerReady")
+}
+
+func (UnimplementedTabletServerServiceServer) TakeTransaction(ctx context.Context, request *TakeTransactionRequestPB) (*TakeTransactionResponsePB, error) {
+	return nil, dispatch.Unimplemented("yb.tserver.TabletServerService", "TakeTransaction")
+}
+
+var TabletServerService_ServiceDesc = dispatch.ServiceDesc{
+	ServiceName: "yb.tserver.TabletServerService",
+	HandlerType: (*TabletServerServiceServer)(nil),
+	Methods: []dispatch.MethodDesc{
+		{
+			MethodName:  "Write",
+			NewRequest:  func() proto.Message { return &WriteRequestPB{} },
+			NewResponse: func() proto.Message { return &WriteResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(TabletServerServiceServer).Write(ctx, request.(*WriteRequestPB))
+			},
+		},
+		{
+			MethodName:  "Read",
+			NewRequest:  func() proto.Message { return &ReadRequestPB{} },
+			NewResponse: func() proto.Message { return &ReadResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(TabletServerServiceServer).Read(ctx, request.(*ReadRequestPB))
+			},
+		},
+		{
+			MethodName:  "NoOp",
+			NewRequest:  func() proto.Message { return &NoOpRequestPB{} },
+			NewResponse: func() proto.Message { return &NoOpResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(TabletServerServiceServer).NoOp(ctx, request.(*NoOpRequestPB))
+			},
+		},
+		{
+			MethodName:  "ListTablets",
+			NewRequest:  func() proto.Message { return &ListTabletsRequestPB{} },
+			NewResponse: func() proto.Message { return &ListTabletsResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(TabletServerServiceServer).ListTablets(ctx, request.(*ListTabletsRequestPB))
+			},
+		},
+		{
+			MethodName:  "GetLogLocation",
+			NewRequest:  func() proto.Message { return &GetLogLocationRequestPB{} },
+			NewResponse: func() proto.Message { return &GetLogLocationResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(TabletServerServiceServer).GetLogLocation(ctx, request.(*GetLogLocationRequestPB))
+			},
+		},
+		{
+			MethodName:  "Checksum",
+			NewRequest:  func() proto.Message { return &ChecksumRequestPB{} },
+			NewResponse: func() proto.Message { return &ChecksumResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(TabletServerServiceServer).Checksum(ctx, request.(*ChecksumRequestPB))
+			},
+		},
+		{
+			MethodName:  "ListTabletsForTabletServer",
+			NewRequest:  func() proto.Message { return &ListTabletsForTabletServerRequestPB{} },
+			NewResponse: func() proto.Message { return &ListTabletsForTabletServerResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(TabletServerServiceServer).ListTabletsForTabletServer(ctx, request.(*ListTabletsForTabletServerRequestPB))
+			},
+		},
+		{
+			MethodName:  "ImportData",
+			NewRequest:  func() proto.Message { return &ImportDataRequestPB{} },
+			NewResponse: func() proto.Message { return &ImportDataResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(TabletServerServiceServer).ImportData(ctx, request.(*ImportDataRequestPB))
+			},
+		},
+		{
+			MethodName:  "UpdateTransaction",
+			NewRequest:  func() proto.Message { return &UpdateTransactionRequestPB{} },
+			NewResponse: func() proto.Message { return &UpdateTransactionResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(TabletServerServiceServer).UpdateTransaction(ctx, request.(*UpdateTransactionRequestPB))
+			},
+		},
+		{
+			MethodName:  "GetTransactionStatus",
+			NewRequest:  func() proto.Message { return &GetTransactionStatusRequestPB{} },
+			NewResponse: func() proto.Message { return &GetTransactionStatusResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(TabletServerServiceServer).GetTransactionStatus(ctx, request.(*GetTransactionStatusRequestPB))
+			},
+		},
+		{
+			MethodName:  "GetTransactionStatusAtParticipant",
+			NewRequest:  func() proto.Message { return &GetTransactionStatusAtParticipantRequestPB{} },
+			NewResponse: func() proto.Message { return &GetTransactionStatusAtParticipantResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(TabletServerServiceServer).GetTransactionStatusAtParticipant(ctx, request.(*GetTransactionStatusAtParticipantRequestPB))
+			},
+		},
+		{
+			MethodName:  "AbortTransaction",
+			NewRequest:  func() proto.Message { return &AbortTransactionRequestPB{} },
+			NewResponse: func() proto.Message { return &AbortTransactionResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(TabletServerServiceServer).AbortTransaction(ctx, request.(*AbortTransactionRequestPB))
+			},
+		},
+		{
+			MethodName:  "Truncate",
+			NewRequest:  func() proto.Message { return &TruncateRequestPB{} },
+			NewResponse: func() proto.Message { return &TruncateResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(TabletServerServiceServer).Truncate(ctx, request.(*TruncateRequestPB))
+			},
+		},
+		{
+			MethodName:  "GetTabletStatus",
+			NewRequest:  func() proto.Message { return &GetTabletStatusRequestPB{} },
+			NewResponse: func() proto.Message { return &GetTabletStatusResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(TabletServerServiceServer).GetTabletStatus(ctx, request.(*GetTabletStatusRequestPB))
+			},
+		},
+		{
+			MethodName:  "GetMasterAddresses",
+			NewRequest:  func() proto.Message { return &GetMasterAddressesRequestPB{} },
+			NewResponse: func() proto.Message { return &GetMasterAddressesResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(TabletServerServiceServer).GetMasterAddresses(ctx, request.(*GetMasterAddressesRequestPB))
+			},
+		},
+		{
+			MethodName:  "Publish",
+			NewRequest:  func() proto.Message { return &PublishRequestPB{} },
+			NewResponse: func() proto.Message { return &PublishResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(TabletServerServiceServer).Publish(ctx, request.(*PublishRequestPB))
+			},
+		},
+		{
+			MethodName:  "IsTabletServerReady",
+			NewRequest:  func() proto.Message { return &IsTabletServerReadyRequestPB{} },
+			NewResponse: func() proto.Message { return &IsTabletServerReadyResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(TabletServerServiceServer).IsTabletServerReady(ctx, request.(*IsTabletServerReadyRequestPB))
+			},
+		},
+		{
+			MethodName:  "TakeTransaction",
+			NewRequest:  func() proto.Message { return &TakeTransactionRequestPB{} },
+			NewResponse: func() proto.Message { return &TakeTransactionResponsePB{} },
+			Handler: func(srv interface{}, ctx context.Context, request proto.Message) (proto.Message, error) {
+				return srv.(TabletServerServiceServer).TakeTransaction(ctx, request.(*TakeTransactionRequestPB))
+			},
+		},
+	},
+}
+
+// RegisterTabletServerService serves yb.tserver.TabletServerService with srv.
+func RegisterTabletServerService(r dispatch.Registrar, srv TabletServerServiceServer) {
+	r.RegisterService(&TabletServerService_ServiceDesc, srv)
+}
+
+func init() {
+	dispatch.RegisterServiceDesc(&TabletServerService_ServiceDesc)
+}
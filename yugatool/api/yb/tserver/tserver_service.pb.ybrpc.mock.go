@@ -0,0 +1,286 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// The following only applies to changes made to this file as part of YugaByte development.
+//
+// Portions Copyright (c) YugaByte, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied.  See the License for the specific language governing permissions and limitations
+// under the License.
+//
+
+// Code generated by protoc-gen-ybrpc. DO NOT EDIT.
+
+package tserver
+
+import (
+	"context"
+
+	"github.com/yugabyte/yb-tools/protoc-gen-ybrpc/pkg/dispatch"
+	"github.com/yugabyte/yb-tools/protoc-gen-ybrpc/pkg/mock"
+)
+
+// MockTabletServerService is a TabletServerService for tests. Each method calls its stub function,
+// or fails with dispatch.ErrNoSuchMethod when the stub is not set, and every
+// call is recorded.
+type MockTabletServerService struct {
+	mock.Recorder
+
+	WriteFunc                             func(ctx context.Context, request *WriteRequestPB) (*WriteResponsePB, error)
+	ReadFunc                              func(ctx context.Context, request *ReadRequestPB) (*ReadResponsePB, error)
+	NoOpFunc                              func(ctx context.Context, request *NoOpRequestPB) (*NoOpResponsePB, error)
+	ListTabletsFunc                       func(ctx context.Context, request *ListTabletsRequestPB) (*ListTabletsResponsePB, error)
+	GetLogLocationFunc                    func(ctx context.Context, request *GetLogLocationRequestPB) (*GetLogLocationResponsePB, error)
+	ChecksumFunc                          func(ctx context.Context, request *ChecksumRequestPB) (*ChecksumResponsePB, error)
+	ListTabletsForTabletServerFunc        func(ctx context.Context, request *ListTabletsForTabletServerRequestPB) (*ListTabletsForTabletServerResponsePB, error)
+	ImportDataFunc                        func(ctx context.Context, request *ImportDataRequestPB) (*ImportDataResponsePB, error)
+	UpdateTransactionFunc                 func(ctx context.Context, request *UpdateTransactionRequestPB) (*UpdateTransactionResponsePB, error)
+	GetTransactionStatusFunc              func(ctx context.Context, request *GetTransactionStatusRequestPB) (*GetTransactionStatusResponsePB, error)
+	GetTransactionStatusAtParticipantFunc func(ctx context.Context, request *GetTransactionStatusAtParticipantRequestPB) (*GetTransactionStatusAtParticipantResponsePB, error)
+	AbortTransactionFunc                  func(ctx context.Context, request *AbortTransactionRequestPB) (*AbortTransactionResponsePB, error)
+	TruncateFunc                          func(ctx context.Context, request *TruncateRequestPB) (*TruncateResponsePB, error)
+	GetTabletStatusFunc                   func(ctx context.Context, request *GetTabletStatusRequestPB) (*GetTabletStatusResponsePB, error)
+	GetMasterAddressesFunc                func(ctx context.Context, request *GetMasterAddressesRequestPB) (*GetMasterAddressesResponsePB, error)
+	PublishFunc                           func(ctx context.Context, request *PublishRequestPB) (*PublishResponsePB, error)
+	IsTabletServerReadyFunc               func(ctx context.Context, request *IsTabletServerReadyRequestPB) (*IsTabletServerReadyResponsePB, error)
+	TakeTransactionFunc                   func(ctx context.Context, request *TakeTransactionRequestPB) (*TakeTransactionResponsePB, error)
+}
+
+var _ TabletServerService = &MockTabletServerService{}
+
+func (s *MockTabletServerService) Write(request *WriteRequestPB) (*WriteResponsePB, error) {
+	return s.WriteWithContext(context.Background(), request)
+}
+
+func (s *MockTabletServerService) WriteWithContext(ctx context.Context, request *WriteRequestPB) (*WriteResponsePB, error) {
+	s.Record("Write", request)
+	if s.WriteFunc == nil {
+		return nil, dispatch.Unimplemented("yb.tserver.TabletServerService", "Write")
+	}
+	return s.WriteFunc(ctx, request)
+}
+
+func (s *MockTabletServerService) Read(request *ReadRequestPB) (*ReadResponsePB, error) {
+	return s.ReadWithContext(context.Background(), request)
+}
+
+func (s *MockTabletServerService) ReadWithContext(ctx context.Context, request *ReadRequestPB) (*ReadResponsePB, error) {
+	s.Record("Read", request)
+	if s.ReadFunc == nil {
+		return nil, dispatch.Unimplemented("yb.tserver.TabletServerService", "Read")
+	}
+	return s.ReadFunc(ctx, request)
+}
+
+func (s *MockTabletServerService) NoOp(request *NoOpRequestPB) (*NoOpResponsePB, error) {
+	return s.NoOpWithContext(context.Background(), request)
+}
+
+func (s *MockTabletServerService) NoOpWithContext(ctx context.Context, request *NoOpRequestPB) (*NoOpResponsePB, error) {
+	s.Record("NoOp", request)
+	if s.NoOpFunc == nil {
+		return nil, dispatch.Unimplemented("yb.tserver.TabletServerService", "NoOp")
+	}
+	return s.NoOpFunc(ctx, request)
+}
+
+func (s *MockTabletServerService) ListTablets(request *ListTabletsRequestPB) (*ListTabletsResponsePB, error) {
+	return s.ListTabletsWithContext(context.Background(), request)
+}
+
+func (s *MockTabletServerService) ListTabletsWithContext(ctx context.Context, request *ListTabletsRequestPB) (*ListTabletsResponsePB, error) {
+	s.Record("ListTablets", request)
+	if s.ListTabletsFunc == nil {
+		return nil, dispatch.Unimplemented("yb.tserver.TabletServerService", "ListTablets")
+	}
+	return s.ListTabletsFunc(ctx, request)
+}
+
+func (s *MockTabletServerService) GetLogLocation(request *GetLogLocationRequestPB) (*GetLogLocationResponsePB, error) {
+	return s.GetLogLocationWithContext(context.Background(), request)
+}
+
+func (s *MockTabletServerService) GetLogLocationWithContext(ctx context.Context, request *GetLogLocationRequestPB) (*GetLogLocationResponsePB, error) {
+	s.Record("GetLogLocation", request)
+	if s.GetLogLocationFunc == nil {
+		return nil, dispatch.Unimplemented("yb.tserver.TabletServerService", "GetLogLocation")
+	}
+	return s.GetLogLocationFunc(ctx, request)
+}
+
+func (s *MockTabletServerService) Checksum(request *ChecksumRequestPB) (*ChecksumResponsePB, error) {
+	return s.ChecksumWithContext(context.Background(), request)
+}
+
+func (s *MockTabletServerService) ChecksumWithContext(ctx context.Context, request *ChecksumRequestPB) (*ChecksumResponsePB, error) {
+	s.Record("Checksum", request)
+	if s.ChecksumFunc == nil {
+		return nil, dispatch.Unimplemented("yb.tserver.TabletServerService", "Checksum")
+	}
+	return s.ChecksumFunc(ctx, request)
+}
+
+func (s *MockTabletServerService) ListTabletsForTabletServer(request *ListTabletsForTabletServerRequestPB) (*ListTabletsForTabletServerResponsePB, error) {
+	return s.ListTabletsForTabletServerWithContext(context.Background(), request)
+}
+
+func (s *MockTabletServerService) ListTabletsForTabletServerWithContext(ctx context.Context, request *ListTabletsForTabletServerRequestPB) (*ListTabletsForTabletServerResponsePB, error) {
+	s.Record("ListTabletsForTabletServer", request)
+	if s.ListTabletsForTabletServerFunc == nil {
+		return nil, dispatch.Unimplemented("yb.tserver.TabletServerService", "ListTabletsForTabletServer")
+	}
+	return s.ListTabletsForTabletServerFunc(ctx, request)
+}
+
+func (s *MockTabletServerService) ImportData(request *ImportDataRequestPB) (*ImportDataResponsePB, error) {
+	return s.ImportDataWithContext(context.Background(), request)
+}
+
+func (s *MockTabletServerService) ImportDataWithContext(ctx context.Context, request *ImportDataRequestPB) (*ImportDataResponsePB, error) {
+	s.Record("ImportData", request)
+	if s.ImportDataFunc == nil {
+		return nil, dispatch.Unimplemented("yb.tserver.TabletServerService", "ImportData")
+	}
+	return s.ImportDataFunc(ctx, request)
+}
+
+func (s *MockTabletServerService) UpdateTransaction(request *UpdateTransactionRequestPB) (*UpdateTransactionResponsePB, error) {
+	return s.UpdateTransactionWithContext(context.Background(), request)
+}
+
+func (s *MockTabletServerService) UpdateTransactionWithContext(ctx context.Context, request *UpdateTransactionRequestPB) (*UpdateTransactionResponsePB, error) {
+	s.Record("UpdateTransaction", request)
+	if s.UpdateTransactionFunc == nil {
+		return nil, dispatch.Unimplemented("yb.tserver.TabletServerService", "UpdateTransaction")
+	}
+	return s.UpdateTransactionFunc(ctx, request)
+}
+
+func (s *MockTabletServerService) GetTransactionStatus(request *GetTransactionStatusRequestPB) (*GetTransactionStatusResponsePB, error) {
+	return s.GetTransactionStatusWithContext(context.Background(), request)
+}
+
+func (s *MockTabletServerService) GetTransactionStatusWithContext(ctx context.Context, request *GetTransactionStatusRequestPB) (*GetTransactionStatusResponsePB, error) {
+	s.Record("GetTransactionStatus", request)
+	if s.GetTransactionStatusFunc == nil {
+		return nil, dispatch.Unimplemented("yb.tserver.TabletServerService", "GetTransactionStatus")
+	}
+	return s.GetTransactionStatusFunc(ctx, request)
+}
+
+func (s *MockTabletServerService) GetTransactionStatusAtParticipant(request *GetTransactionStatusAtParticipantRequestPB) (*GetTransactionStatusAtParticipantResponsePB, error) {
+	return s.GetTransactionStatusAtParticipantWithContext(context.Background(), request)
+}
+
+func (s *MockTabletServerService) GetTransactionStatusAtParticipantWithContext(ctx context.Context, request *GetTransactionStatusAtParticipantRequestPB) (*GetTransactionStatusAtParticipantResponsePB, error) {
+	s.Record("GetTransactionStatusAtParticipant", request)
+	if s.GetTransactionStatusAtParticipantFunc == nil {
+		return nil, dispatch.Unimplemented("yb.tserver.TabletServerService", "GetTransactionStatusAtParticipant")
+	}
+	return s.GetTransactionStatusAtParticipantFunc(ctx, request)
+}
+
+func (s *MockTabletServerService) AbortTransaction(request *AbortTransactionRequestPB) (*AbortTransactionResponsePB, error) {
+	return s.AbortTransactionWithContext(context.Background(), request)
+}
+
+func (s *MockTabletServerService) AbortTransactionWithContext(ctx context.Context, request *AbortTransactionRequestPB) (*AbortTransactionResponsePB, error) {
+	s.Record("AbortTransaction", request)
+	if s.AbortTransactionFunc == nil {
+		return nil, dispatch.Unimplemented("yb.tserver.TabletServerService", "AbortTransaction")
+	}
+	return s.AbortTransactionFunc(ctx, request)
+}
+
+func (s *MockTabletServerService) Truncate(request *TruncateRequestPB) (*TruncateResponsePB, error) {
+	return s.TruncateWithContext(context.Background(), request)
+}
+
+func (s *MockTabletServerService) TruncateWithContext(ctx context.Context, request *TruncateRequestPB) (*TruncateResponsePB, error) {
+	s.Record("Truncate", request)
+	if s.TruncateFunc == nil {
+		return nil, dispatch.Unimplemented("yb.tserver.TabletServerService", "Truncate")
+	}
+	return s.TruncateFunc(ctx, request)
+}
+
+func (s *MockTabletServerService) GetTabletStatus(request *GetTabletStatusRequestPB) (*GetTabletStatusResponsePB, error) {
+	return s.GetTabletStatusWithContext(context.Background(), request)
+}
+
+func (s *MockTabletServerService) GetTabletStatusWithContext(ctx context.Context, request *GetTabletStatusRequestPB) (*GetTabletStatusResponsePB, error) {
+	s.Record("GetTabletStatus", request)
+	if s.GetTabletStatusFunc == nil {
+		return nil, dispatch.Unimplemented("yb.tserver.TabletServerService", "GetTabletStatus")
+	}
+	return s.GetTabletStatusFunc(ctx, request)
+}
+
+func (s *MockTabletServerService) GetMasterAddresses(request *GetMasterAddressesRequestPB) (*GetMasterAddressesResponsePB, error) {
+	return s.GetMasterAddressesWithContext(context.Background(), request)
+}
+
+func (s *MockTabletServerService) GetMasterAddressesWithContext(ctx context.Context, request *GetMasterAddressesRequestPB) (*GetMasterAddressesResponsePB, error) {
+	s.Record("GetMasterAddresses", request)
+	if s.GetMasterAddressesFunc == nil {
+		return nil, dispatch.Unimplemented("yb.tserver.TabletServerService", "GetMasterAddresses")
+	}
+	return s.GetMasterAddressesFunc(ctx, request)
+}
+
+func (s *MockTabletServerService) Publish(request *PublishRequestPB) (*PublishResponsePB, error) {
+	return s.PublishWithContext(context.Background(), request)
+}
+
+func (s *MockTabletServerService) PublishWithContext(ctx context.Context, request *PublishRequestPB) (*PublishResponsePB, error) {
+	s.Record("Publish", request)
+	if s.PublishFunc == nil {
+		return nil, dispatch.Unimplemented("yb.tserver.TabletServerService", "Publish")
+	}
+	return s.PublishFunc(ctx, request)
+}
+
+func (s *MockTabletServerService) IsTabletServerReady(request *IsTabletServerReadyRequestPB) (*IsTabletServerReadyResponsePB, error) {
+	return s.IsTabletServerReadyWithContext(context.Background(), request)
+}
+
+func (s *MockTabletServerService) IsTabletServerReadyWithContext(ctx context.Context, request *IsTabletServerReadyRequestPB) (*IsTabletServerReadyResponsePB, error) {
+	s.Record("IsTabletServerReady", request)
+	if s.IsTabletServerReadyFunc == nil {
+		return nil, dispatch.Unimplemented("yb.tserver.TabletServerService", "IsTabletServerReady")
+	}
+	return s.IsTabletServerReadyFunc(ctx, request)
+}
+
+func (s *MockTabletServerService) TakeTransaction(request *TakeTransactionRequestPB) (*TakeTransactionResponsePB, error) {
+	return s.TakeTransactionWithContext(context.Background(), request)
+}
+
+func (s *MockTabletServerService) TakeTransactionWithContext(ctx context.Context, request *TakeTransactionRequestPB) (*TakeTransactionResponsePB, error) {
+	s.Record("TakeTransaction", request)
+	if s.TakeTransactionFunc == nil {
+		return nil, dispatch.Unimplemented("yb.tserver.TabletServerService", "TakeTransaction")
+	}
+	return s.TakeTransactionFunc(ctx, request)
+}
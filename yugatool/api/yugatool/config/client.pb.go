@@ -36,6 +36,132 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type TlsPolicyPB int32
+
+const (
+	// Connect over TLS, and fail when that is not possible
+	TlsPolicyPB_REQUIRED TlsPolicyPB = 1
+	// Connect over TLS, falling back to plaintext when no master can be reached
+	TlsPolicyPB_PREFERRED TlsPolicyPB = 2
+	// Connect in plaintext
+	TlsPolicyPB_DISABLED TlsPolicyPB = 3
+)
+
+// Enum value maps for TlsPolicyPB.
+var (
+	TlsPolicyPB_name = map[int32]string{
+		1: "REQUIRED",
+		2: "PREFERRED",
+		3: "DISABLED",
+	}
+	TlsPolicyPB_value = map[string]int32{
+		"REQUIRED":  1,
+		"PREFERRED": 2,
+		"DISABLED":  3,
+	}
+)
+
+func (x TlsPolicyPB) Enum() *TlsPolicyPB {
+	p := new(TlsPolicyPB)
+	*p = x
+	return p
+}
+
+func (x TlsPolicyPB) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TlsPolicyPB) Descriptor() protoreflect.EnumDescriptor {
+	return file_yugatool_config_client_proto_enumTypes[0].Descriptor()
+}
+
+func (TlsPolicyPB) Type() protoreflect.EnumType {
+	return &file_yugatool_config_client_proto_enumTypes[0]
+}
+
+func (x TlsPolicyPB) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Do not use.
+func (x *TlsPolicyPB) UnmarshalJSON(b []byte) error {
+	num, err := protoimpl.X.UnmarshalJSONEnum(x.Descriptor(), b)
+	if err != nil {
+		return err
+	}
+	*x = TlsPolicyPB(num)
+	return nil
+}
+
+// Deprecated: Use TlsPolicyPB.Descriptor instead.
+func (TlsPolicyPB) EnumDescriptor() ([]byte, []int) {
+	return file_yugatool_config_client_proto_rawDescGZIP(), []int{0}
+}
+
+// The kinds of address a server registers with the masters
+type AddressTypePB int32
+
+const (
+	// The private_rpc_addresses of the server
+	AddressTypePB_PRIVATE AddressTypePB = 0
+	// The broadcast_addresses of the server
+	AddressTypePB_BROADCAST AddressTypePB = 1
+	// Any registered address that is an IP outside of the private, loopback and
+	// link-local ranges
+	AddressTypePB_PUBLIC AddressTypePB = 2
+)
+
+// Enum value maps for AddressTypePB.
+var (
+	AddressTypePB_name = map[int32]string{
+		0: "PRIVATE",
+		1: "BROADCAST",
+		2: "PUBLIC",
+	}
+	AddressTypePB_value = map[string]int32{
+		"PRIVATE":   0,
+		"BROADCAST": 1,
+		"PUBLIC":    2,
+	}
+)
+
+func (x AddressTypePB) Enum() *AddressTypePB {
+	p := new(AddressTypePB)
+	*p = x
+	return p
+}
+
+func (x AddressTypePB) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AddressTypePB) Descriptor() protoreflect.EnumDescriptor {
+	return file_yugatool_config_client_proto_enumTypes[1].Descriptor()
+}
+
+func (AddressTypePB) Type() protoreflect.EnumType {
+	return &file_yugatool_config_client_proto_enumTypes[1]
+}
+
+func (x AddressTypePB) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Do not use.
+func (x *AddressTypePB) UnmarshalJSON(b []byte) error {
+	num, err := protoimpl.X.UnmarshalJSONEnum(x.Descriptor(), b)
+	if err != nil {
+		return err
+	}
+	*x = AddressTypePB(num)
+	return nil
+}
+
+// Deprecated: Use AddressTypePB.Descriptor instead.
+func (AddressTypePB) EnumDescriptor() ([]byte, []int) {
+	return file_yugatool_config_client_proto_rawDescGZIP(), []int{1}
+}
+
 type TlsOptionsPB struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -45,6 +171,9 @@ type TlsOptionsPB struct {
 	CaCertPath           *string `protobuf:"bytes,2,req,name=CaCertPath" json:"CaCertPath,omitempty"`
 	CertPath             *string `protobuf:"bytes,3,req,name=CertPath" json:"CertPath,omitempty"`
 	KeyPath              *string `protobuf:"bytes,4,req,name=KeyPath" json:"KeyPath,omitempty"`
+	// Whether connections must use TLS. When unset, TLS is required if any of the
+	// options above are set, and disabled otherwise.
+	Policy *TlsPolicyPB `protobuf:"varint,5,opt,name=Policy,enum=yugatool.config.TlsPolicyPB" json:"Policy,omitempty"`
 }
 
 func (x *TlsOptionsPB) Reset() {
@@ -107,6 +236,13 @@ func (x *TlsOptionsPB) GetKeyPath() string {
 	return ""
 }
 
+func (x *TlsOptionsPB) GetPolicy() TlsPolicyPB {
+	if x != nil && x.Policy != nil {
+		return *x.Policy
+	}
+	return TlsPolicyPB_REQUIRED
+}
+
 type UniverseConfigPB struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -115,6 +251,13 @@ type UniverseConfigPB struct {
 	Masters        []*common.HostPortPB `protobuf:"bytes,1,rep,name=masters" json:"masters,omitempty"`
 	TimeoutSeconds *int64               `protobuf:"varint,2,req,name=timeout_seconds,json=timeoutSeconds" json:"timeout_seconds,omitempty"`
 	TlsOpts        *TlsOptionsPB        `protobuf:"bytes,3,opt,name=tls_opts,json=tlsOpts" json:"tls_opts,omitempty"`
+	// Timeout for RPCs made without a deadline of their own
+	RpcTimeoutSeconds *int64 `protobuf:"varint,4,opt,name=rpc_timeout_seconds,json=rpcTimeoutSeconds" json:"rpc_timeout_seconds,omitempty"`
+	// Chooses which of the addresses registered by each server to connect to
+	AddressPolicy *AddressPolicyPB `protobuf:"bytes,5,opt,name=address_policy,json=addressPolicy" json:"address_policy,omitempty"`
+	// Interval of the pings that keep idle connections open and reconnect failed
+	// ones, or zero for no pings
+	KeepaliveSeconds *int64 `protobuf:"varint,6,opt,name=keepalive_seconds,json=keepaliveSeconds" json:"keepalive_seconds,omitempty"`
 }
 
 func (x *UniverseConfigPB) Reset() {
@@ -170,6 +313,229 @@ func (x *UniverseConfigPB) GetTlsOpts() *TlsOptionsPB {
 	return nil
 }
 
+func (x *UniverseConfigPB) GetRpcTimeoutSeconds() int64 {
+	if x != nil && x.RpcTimeoutSeconds != nil {
+		return *x.RpcTimeoutSeconds
+	}
+	return 0
+}
+
+func (x *UniverseConfigPB) GetAddressPolicy() *AddressPolicyPB {
+	if x != nil {
+		return x.AddressPolicy
+	}
+	return nil
+}
+
+func (x *UniverseConfigPB) GetKeepaliveSeconds() int64 {
+	if x != nil && x.KeepaliveSeconds != nil {
+		return *x.KeepaliveSeconds
+	}
+	return 0
+}
+
+type RegionAddressPolicyPB struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Region     *string         `protobuf:"bytes,1,req,name=region" json:"region,omitempty"`
+	Preference []AddressTypePB `protobuf:"varint,2,rep,name=preference,enum=yugatool.config.AddressTypePB" json:"preference,omitempty"`
+}
+
+func (x *RegionAddressPolicyPB) Reset() {
+	*x = RegionAddressPolicyPB{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_yugatool_config_client_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RegionAddressPolicyPB) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegionAddressPolicyPB) ProtoMessage() {}
+
+func (x *RegionAddressPolicyPB) ProtoReflect() protoreflect.Message {
+	mi := &file_yugatool_config_client_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegionAddressPolicyPB.ProtoReflect.Descriptor instead.
+func (*RegionAddressPolicyPB) Descriptor() ([]byte, []int) {
+	return file_yugatool_config_client_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RegionAddressPolicyPB) GetRegion() string {
+	if x != nil && x.Region != nil {
+		return *x.Region
+	}
+	return ""
+}
+
+func (x *RegionAddressPolicyPB) GetPreference() []AddressTypePB {
+	if x != nil {
+		return x.Preference
+	}
+	return nil
+}
+
+// Rewrites addresses within one network to the same host in another network of
+// the same size, such as for a NAT between the client and the universe
+type AddressRewritePB struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FromCidr *string `protobuf:"bytes,1,req,name=from_cidr,json=fromCidr" json:"from_cidr,omitempty"`
+	ToCidr   *string `protobuf:"bytes,2,req,name=to_cidr,json=toCidr" json:"to_cidr,omitempty"`
+}
+
+func (x *AddressRewritePB) Reset() {
+	*x = AddressRewritePB{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_yugatool_config_client_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddressRewritePB) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddressRewritePB) ProtoMessage() {}
+
+func (x *AddressRewritePB) ProtoReflect() protoreflect.Message {
+	mi := &file_yugatool_config_client_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddressRewritePB.ProtoReflect.Descriptor instead.
+func (*AddressRewritePB) Descriptor() ([]byte, []int) {
+	return file_yugatool_config_client_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *AddressRewritePB) GetFromCidr() string {
+	if x != nil && x.FromCidr != nil {
+		return *x.FromCidr
+	}
+	return ""
+}
+
+func (x *AddressRewritePB) GetToCidr() string {
+	if x != nil && x.ToCidr != nil {
+		return *x.ToCidr
+	}
+	return ""
+}
+
+type AddressPolicyPB struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The order in which the kinds of registered address are tried. Defaults to
+	// PRIVATE, BROADCAST, PUBLIC.
+	Preference []AddressTypePB `protobuf:"varint,1,rep,name=preference,enum=yugatool.config.AddressTypePB" json:"preference,omitempty"`
+	// Preference orders for servers placed in particular regions
+	Regions  []*RegionAddressPolicyPB `protobuf:"bytes,2,rep,name=regions" json:"regions,omitempty"`
+	Rewrites []*AddressRewritePB      `protobuf:"bytes,3,rep,name=rewrites" json:"rewrites,omitempty"`
+	// Replaces addresses, given as host or host:port, with another host or
+	// host:port. The host map is applied before the rewrites.
+	HostMap map[string]string `protobuf:"bytes,4,rep,name=host_map,json=hostMap" json:"host_map,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Try the remaining addresses when the preferred address cannot be reached
+	Fallback *bool `protobuf:"varint,5,opt,name=fallback,def=1" json:"fallback,omitempty"`
+}
+
+// Default values for AddressPolicyPB fields.
+const (
+	Default_AddressPolicyPB_Fallback = bool(true)
+)
+
+func (x *AddressPolicyPB) Reset() {
+	*x = AddressPolicyPB{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_yugatool_config_client_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddressPolicyPB) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddressPolicyPB) ProtoMessage() {}
+
+func (x *AddressPolicyPB) ProtoReflect() protoreflect.Message {
+	mi := &file_yugatool_config_client_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddressPolicyPB.ProtoReflect.Descriptor instead.
+func (*AddressPolicyPB) Descriptor() ([]byte, []int) {
+	return file_yugatool_config_client_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *AddressPolicyPB) GetPreference() []AddressTypePB {
+	if x != nil {
+		return x.Preference
+	}
+	return nil
+}
+
+func (x *AddressPolicyPB) GetRegions() []*RegionAddressPolicyPB {
+	if x != nil {
+		return x.Regions
+	}
+	return nil
+}
+
+func (x *AddressPolicyPB) GetRewrites() []*AddressRewritePB {
+	if x != nil {
+		return x.Rewrites
+	}
+	return nil
+}
+
+func (x *AddressPolicyPB) GetHostMap() map[string]string {
+	if x != nil {
+		return x.HostMap
+	}
+	return nil
+}
+
+func (x *AddressPolicyPB) GetFallback() bool {
+	if x != nil && x.Fallback != nil {
+		return *x.Fallback
+	}
+	return Default_AddressPolicyPB_Fallback
+}
+
 var File_yugatool_config_client_proto protoreflect.FileDescriptor
 
 var file_yugatool_config_client_proto_rawDesc = []byte{
@@ -177,7 +543,7 @@ var file_yugatool_config_client_proto_rawDesc = []byte{
 	0x67, 0x2f, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0f,
 	0x79, 0x75, 0x67, 0x61, 0x74, 0x6f, 0x6f, 0x6c, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x1a,
 	0x16, 0x79, 0x62, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f,
-	0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x98, 0x01, 0x0a, 0x0c, 0x54, 0x6c, 0x73, 0x4f,
+	0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xce, 0x01, 0x0a, 0x0c, 0x54, 0x6c, 0x73, 0x4f,
 	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x50, 0x42, 0x12, 0x32, 0x0a, 0x14, 0x53, 0x6b, 0x69, 0x70,
 	0x48, 0x6f, 0x73, 0x74, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
 	0x18, 0x01, 0x20, 0x02, 0x28, 0x08, 0x52, 0x14, 0x53, 0x6b, 0x69, 0x70, 0x48, 0x6f, 0x73, 0x74,
@@ -187,18 +553,75 @@ var file_yugatool_config_client_proto_rawDesc = []byte{
 	0x43, 0x65, 0x72, 0x74, 0x50, 0x61, 0x74, 0x68, 0x18, 0x03, 0x20, 0x02, 0x28, 0x09, 0x52, 0x08,
 	0x43, 0x65, 0x72, 0x74, 0x50, 0x61, 0x74, 0x68, 0x12, 0x18, 0x0a, 0x07, 0x4b, 0x65, 0x79, 0x50,
 	0x61, 0x74, 0x68, 0x18, 0x04, 0x20, 0x02, 0x28, 0x09, 0x52, 0x07, 0x4b, 0x65, 0x79, 0x50, 0x61,
-	0x74, 0x68, 0x22, 0x9f, 0x01, 0x0a, 0x10, 0x55, 0x6e, 0x69, 0x76, 0x65, 0x72, 0x73, 0x65, 0x43,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x50, 0x42, 0x12, 0x28, 0x0a, 0x07, 0x6d, 0x61, 0x73, 0x74, 0x65,
-	0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x79, 0x62, 0x2e, 0x48, 0x6f,
-	0x73, 0x74, 0x50, 0x6f, 0x72, 0x74, 0x50, 0x42, 0x52, 0x07, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72,
-	0x73, 0x12, 0x27, 0x0a, 0x0f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x5f, 0x73, 0x65, 0x63,
-	0x6f, 0x6e, 0x64, 0x73, 0x18, 0x02, 0x20, 0x02, 0x28, 0x03, 0x52, 0x0e, 0x74, 0x69, 0x6d, 0x65,
-	0x6f, 0x75, 0x74, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x38, 0x0a, 0x08, 0x74, 0x6c,
-	0x73, 0x5f, 0x6f, 0x70, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x79,
-	0x75, 0x67, 0x61, 0x74, 0x6f, 0x6f, 0x6c, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x54,
-	0x6c, 0x73, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x50, 0x42, 0x52, 0x07, 0x74, 0x6c, 0x73,
-	0x4f, 0x70, 0x74, 0x73, 0x42, 0x15, 0x0a, 0x13, 0x6f, 0x72, 0x67, 0x2e, 0x79, 0x75, 0x67, 0x61,
-	0x74, 0x6f, 0x6f, 0x6c, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x74, 0x68, 0x12, 0x34, 0x0a, 0x06, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x79, 0x75, 0x67, 0x61, 0x74, 0x6f, 0x6f, 0x6c, 0x2e, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x2e, 0x54, 0x6c, 0x73, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x50, 0x42,
+	0x52, 0x06, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x22, 0xc5, 0x02, 0x0a, 0x10, 0x55, 0x6e, 0x69,
+	0x76, 0x65, 0x72, 0x73, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x50, 0x42, 0x12, 0x28, 0x0a,
+	0x07, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e,
+	0x2e, 0x79, 0x62, 0x2e, 0x48, 0x6f, 0x73, 0x74, 0x50, 0x6f, 0x72, 0x74, 0x50, 0x42, 0x52, 0x07,
+	0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x74, 0x69, 0x6d, 0x65, 0x6f,
+	0x75, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x02, 0x20, 0x02, 0x28, 0x03,
+	0x52, 0x0e, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73,
+	0x12, 0x38, 0x0a, 0x08, 0x74, 0x6c, 0x73, 0x5f, 0x6f, 0x70, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x79, 0x75, 0x67, 0x61, 0x74, 0x6f, 0x6f, 0x6c, 0x2e, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x2e, 0x54, 0x6c, 0x73, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x50,
+	0x42, 0x52, 0x07, 0x74, 0x6c, 0x73, 0x4f, 0x70, 0x74, 0x73, 0x12, 0x2e, 0x0a, 0x13, 0x72, 0x70,
+	0x63, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x72, 0x70, 0x63, 0x54, 0x69, 0x6d, 0x65,
+	0x6f, 0x75, 0x74, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x47, 0x0a, 0x0e, 0x61, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x20, 0x2e, 0x79, 0x75, 0x67, 0x61, 0x74, 0x6f, 0x6f, 0x6c, 0x2e, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x50, 0x6f, 0x6c, 0x69,
+	0x63, 0x79, 0x50, 0x42, 0x52, 0x0d, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x50, 0x6f, 0x6c,
+	0x69, 0x63, 0x79, 0x12, 0x2b, 0x0a, 0x11, 0x6b, 0x65, 0x65, 0x70, 0x61, 0x6c, 0x69, 0x76, 0x65,
+	0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10,
+	0x6b, 0x65, 0x65, 0x70, 0x61, 0x6c, 0x69, 0x76, 0x65, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73,
+	0x22, 0x6f, 0x0a, 0x15, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x50, 0x42, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x67,
+	0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x02, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f,
+	0x6e, 0x12, 0x3e, 0x0a, 0x0a, 0x70, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x79, 0x75, 0x67, 0x61, 0x74, 0x6f, 0x6f, 0x6c,
+	0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x54,
+	0x79, 0x70, 0x65, 0x50, 0x42, 0x52, 0x0a, 0x70, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63,
+	0x65, 0x22, 0x48, 0x0a, 0x10, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x77, 0x72,
+	0x69, 0x74, 0x65, 0x50, 0x42, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x63, 0x69,
+	0x64, 0x72, 0x18, 0x01, 0x20, 0x02, 0x28, 0x09, 0x52, 0x08, 0x66, 0x72, 0x6f, 0x6d, 0x43, 0x69,
+	0x64, 0x72, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x6f, 0x5f, 0x63, 0x69, 0x64, 0x72, 0x18, 0x02, 0x20,
+	0x02, 0x28, 0x09, 0x52, 0x06, 0x74, 0x6f, 0x43, 0x69, 0x64, 0x72, 0x22, 0xfa, 0x02, 0x0a, 0x0f,
+	0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x50, 0x42, 0x12,
+	0x3e, 0x0a, 0x0a, 0x70, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x79, 0x75, 0x67, 0x61, 0x74, 0x6f, 0x6f, 0x6c, 0x2e, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x54, 0x79, 0x70,
+	0x65, 0x50, 0x42, 0x52, 0x0a, 0x70, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12,
+	0x40, 0x0a, 0x07, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x26, 0x2e, 0x79, 0x75, 0x67, 0x61, 0x74, 0x6f, 0x6f, 0x6c, 0x2e, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x50, 0x42, 0x52, 0x07, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x3d, 0x0a, 0x08, 0x72, 0x65, 0x77, 0x72, 0x69, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x79, 0x75, 0x67, 0x61, 0x74, 0x6f, 0x6f, 0x6c, 0x2e, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x77,
+	0x72, 0x69, 0x74, 0x65, 0x50, 0x42, 0x52, 0x08, 0x72, 0x65, 0x77, 0x72, 0x69, 0x74, 0x65, 0x73,
+	0x12, 0x48, 0x0a, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x5f, 0x6d, 0x61, 0x70, 0x18, 0x04, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x79, 0x75, 0x67, 0x61, 0x74, 0x6f, 0x6f, 0x6c, 0x2e, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x50, 0x6f, 0x6c, 0x69,
+	0x63, 0x79, 0x50, 0x42, 0x2e, 0x48, 0x6f, 0x73, 0x74, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x07, 0x68, 0x6f, 0x73, 0x74, 0x4d, 0x61, 0x70, 0x12, 0x20, 0x0a, 0x08, 0x66, 0x61,
+	0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x3a, 0x04, 0x74, 0x72,
+	0x75, 0x65, 0x52, 0x08, 0x66, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x1a, 0x3a, 0x0a, 0x0c,
+	0x48, 0x6f, 0x73, 0x74, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x2a, 0x38, 0x0a, 0x0b, 0x54, 0x6c, 0x73, 0x50,
+	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x50, 0x42, 0x12, 0x0c, 0x0a, 0x08, 0x52, 0x45, 0x51, 0x55, 0x49,
+	0x52, 0x45, 0x44, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x50, 0x52, 0x45, 0x46, 0x45, 0x52, 0x52,
+	0x45, 0x44, 0x10, 0x02, 0x12, 0x0c, 0x0a, 0x08, 0x44, 0x49, 0x53, 0x41, 0x42, 0x4c, 0x45, 0x44,
+	0x10, 0x03, 0x2a, 0x37, 0x0a, 0x0d, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x54, 0x79, 0x70,
+	0x65, 0x50, 0x42, 0x12, 0x0b, 0x0a, 0x07, 0x50, 0x52, 0x49, 0x56, 0x41, 0x54, 0x45, 0x10, 0x00,
+	0x12, 0x0d, 0x0a, 0x09, 0x42, 0x52, 0x4f, 0x41, 0x44, 0x43, 0x41, 0x53, 0x54, 0x10, 0x01, 0x12,
+	0x0a, 0x0a, 0x06, 0x50, 0x55, 0x42, 0x4c, 0x49, 0x43, 0x10, 0x02, 0x42, 0x15, 0x0a, 0x13, 0x6f,
+	0x72, 0x67, 0x2e, 0x79, 0x75, 0x67, 0x61, 0x74, 0x6f, 0x6f, 0x6c, 0x2e, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x67,
 }
 
 var (
@@ -213,20 +636,34 @@ func file_yugatool_config_client_proto_rawDescGZIP() []byte {
 	return file_yugatool_config_client_proto_rawDescData
 }
 
-var file_yugatool_config_client_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_yugatool_config_client_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_yugatool_config_client_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
 var file_yugatool_config_client_proto_goTypes = []interface{}{
-	(*TlsOptionsPB)(nil),      // 0: yugatool.config.TlsOptionsPB
-	(*UniverseConfigPB)(nil),  // 1: yugatool.config.UniverseConfigPB
-	(*common.HostPortPB)(nil), // 2: yb.HostPortPB
+	(TlsPolicyPB)(0),              // 0: yugatool.config.TlsPolicyPB
+	(AddressTypePB)(0),            // 1: yugatool.config.AddressTypePB
+	(*TlsOptionsPB)(nil),          // 2: yugatool.config.TlsOptionsPB
+	(*UniverseConfigPB)(nil),      // 3: yugatool.config.UniverseConfigPB
+	(*RegionAddressPolicyPB)(nil), // 4: yugatool.config.RegionAddressPolicyPB
+	(*AddressRewritePB)(nil),      // 5: yugatool.config.AddressRewritePB
+	(*AddressPolicyPB)(nil),       // 6: yugatool.config.AddressPolicyPB
+	nil,                           // 7: yugatool.config.AddressPolicyPB.HostMapEntry
+	(*common.HostPortPB)(nil),     // 8: yb.HostPortPB
 }
 var file_yugatool_config_client_proto_depIdxs = []int32{
-	2, // 0: yugatool.config.UniverseConfigPB.masters:type_name -> yb.HostPortPB
-	0, // 1: yugatool.config.UniverseConfigPB.tls_opts:type_name -> yugatool.config.TlsOptionsPB
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	0, // 0: yugatool.config.TlsOptionsPB.Policy:type_name -> yugatool.config.TlsPolicyPB
+	8, // 1: yugatool.config.UniverseConfigPB.masters:type_name -> yb.HostPortPB
+	2, // 2: yugatool.config.UniverseConfigPB.tls_opts:type_name -> yugatool.config.TlsOptionsPB
+	6, // 3: yugatool.config.UniverseConfigPB.address_policy:type_name -> yugatool.config.AddressPolicyPB
+	1, // 4: yugatool.config.RegionAddressPolicyPB.preference:type_name -> yugatool.config.AddressTypePB
+	1, // 5: yugatool.config.AddressPolicyPB.preference:type_name -> yugatool.config.AddressTypePB
+	4, // 6: yugatool.config.AddressPolicyPB.regions:type_name -> yugatool.config.RegionAddressPolicyPB
+	5, // 7: yugatool.config.AddressPolicyPB.rewrites:type_name -> yugatool.config.AddressRewritePB
+	7, // 8: yugatool.config.AddressPolicyPB.host_map:type_name -> yugatool.config.AddressPolicyPB.HostMapEntry
+	9, // [9:9] is the sub-list for method output_type
+	9, // [9:9] is the sub-list for method input_type
+	9, // [9:9] is the sub-list for extension type_name
+	9, // [9:9] is the sub-list for extension extendee
+	0, // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_yugatool_config_client_proto_init() }
@@ -259,19 +696,56 @@ func file_yugatool_config_client_proto_init() {
 				return nil
 			}
 		}
+		file_yugatool_config_client_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RegionAddressPolicyPB); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_yugatool_config_client_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddressRewritePB); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_yugatool_config_client_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddressPolicyPB); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_yugatool_config_client_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   2,
+			NumEnums:      2,
+			NumMessages:   6,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_yugatool_config_client_proto_goTypes,
 		DependencyIndexes: file_yugatool_config_client_proto_depIdxs,
+		EnumInfos:         file_yugatool_config_client_proto_enumTypes,
 		MessageInfos:      file_yugatool_config_client_proto_msgTypes,
 	}.Build()
 	File_yugatool_config_client_proto = out.File
@@ -0,0 +1,141 @@
+/*
+Copyright © 2021 Yugabyte Support
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/yugabyte/yb-tools/pkg/format"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/balance"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/cmdutil"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/snapshot"
+)
+
+func BalanceCmd(ctx *cmdutil.YugatoolContext) *cobra.Command {
+	options := &BalanceOptions{}
+	cmd := &cobra.Command{
+		Use:   "balance",
+		Short: "Report the balance of tablet leaders and replicas",
+		Long: `Report the tablet leaders and followers of each table by tablet server, zone and region, with the
+skew between the most and least loaded of each. Tablets placed against the placement policy of the
+universe or their table are listed, along with the state of the load balancer.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := ctx.WithCmd(cmd).WithOptions(options).Setup()
+			if err != nil {
+				return err
+			}
+			defer ctx.Client.Close()
+
+			return balanceReport(ctx, options)
+		},
+	}
+	options.AddFlags(cmd)
+
+	return cmd
+}
+
+type BalanceOptions struct {
+	Concurrency int `mapstructure:"concurrency"`
+}
+
+var _ cmdutil.CommandOptions = &BalanceOptions{}
+
+func (o *BalanceOptions) AddFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.IntVar(&o.Concurrency, "concurrency", 64, "maximum number of concurrent requests to each tablet server")
+}
+
+func (o *BalanceOptions) Validate() error {
+	if o.Concurrency < 1 {
+		return errors.New("concurrency must be at least 1")
+	}
+	return nil
+}
+
+func balanceReport(ctx *cmdutil.YugatoolContext, options *BalanceOptions) error {
+	state, err := snapshot.Collect(ctx, ctx.Log, ctx.Client, snapshot.CollectOptions{Concurrency: options.Concurrency})
+	if err != nil {
+		return err
+	}
+	report := balance.Analyze(state)
+
+	checks, err := balance.CheckLoadBalancer(ctx, ctx.Client)
+	if err != nil {
+		return err
+	}
+
+	loadReport := format.Output{
+		OutputMessage: "Table Load",
+		JSONObject:    report.Load,
+		OutputType:    ctx.GlobalOptions.Output,
+		TableColumns: []format.Column{
+			{Name: "TABLE", JSONPath: "$.table"},
+			{Name: "LEVEL", JSONPath: "$.level"},
+			{Name: "PLACEMENT", JSONPath: "$.placement"},
+			{Name: "LEADERS", JSONPath: "$.leaders"},
+			{Name: "FOLLOWERS", JSONPath: "$.followers"},
+		},
+	}
+	err = loadReport.Println()
+	if err != nil {
+		return err
+	}
+
+	skewReport := format.Output{
+		OutputMessage: "Table Skew",
+		JSONObject:    report.Skew,
+		OutputType:    ctx.GlobalOptions.Output,
+		TableColumns: []format.Column{
+			{Name: "TABLE", JSONPath: "$.table"},
+			{Name: "LEVEL", JSONPath: "$.level"},
+			{Name: "LEADER_SKEW", JSONPath: "$.leader_skew"},
+			{Name: "REPLICA_SKEW", JSONPath: "$.replica_skew"},
+		},
+	}
+	err = skewReport.Println()
+	if err != nil {
+		return err
+	}
+
+	violationReport := format.Output{
+		OutputMessage: "Placement Violations",
+		JSONObject:    report.Violations,
+		OutputType:    ctx.GlobalOptions.Output,
+		TableColumns: []format.Column{
+			{Name: "TABLE", JSONPath: "$.table"},
+			{Name: "TABLET", JSONPath: "$.tablet"},
+			{Name: "TSERVER", JSONPath: "$.tserver"},
+			{Name: "VIOLATION", JSONPath: "$.violation"},
+		},
+	}
+	err = violationReport.Println()
+	if err != nil {
+		return err
+	}
+
+	checkReport := format.Output{
+		OutputMessage: "Load Balancer",
+		JSONObject:    checks,
+		OutputType:    ctx.GlobalOptions.Output,
+		TableColumns: []format.Column{
+			{Name: "CHECK", JSONPath: "$.check"},
+			{Name: "PASSED", JSONPath: "$.passed"},
+			{Name: "DETAIL", JSONPath: "$.detail"},
+		},
+	}
+	return checkReport.Println()
+}
@@ -0,0 +1,51 @@
+package cmd_test
+
+import (
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/balance"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/rpcserver"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/test/fakecluster"
+)
+
+var _ = Describe("balance", func() {
+	var cluster *fakecluster.Cluster
+
+	BeforeEach(func() {
+		cluster = fakecluster.New(logr.Discard(), rpcserver.NewNetwork(), "balance", 1, 3)
+		cluster.AddTable("yugabyte", "test_table", 3)
+	})
+
+	It("reports the load, skew, placement violations and load balancer state", func() {
+		cluster.LoadBalancer.Active = true
+
+		out, err := runYugatool(cluster, "balance", "-o", "json")
+		Expect(err).NotTo(HaveOccurred(), out.String())
+
+		var load []balance.Load
+		decodeReport(out, "Table Load", &load)
+		// Three tablet servers, three zones and one region, for the table and for all tables
+		Expect(load).To(HaveLen(2 * (3 + 3 + 1)))
+
+		var skew []balance.Skew
+		decodeReport(out, "Table Skew", &skew)
+		Expect(skew).To(HaveLen(2 * 3))
+
+		var violations []balance.Violation
+		decodeReport(out, "Placement Violations", &violations)
+		Expect(violations).To(BeEmpty())
+
+		var checks []balance.LoadBalancerCheck
+		decodeReport(out, "Load Balancer", &checks)
+		Expect(checks).To(HaveLen(4))
+		Expect(checks[2]).To(Equal(balance.LoadBalancerCheck{Check: balance.CheckLoadBalancerIdle, Detail: "load balancer is active"}))
+	})
+
+	It("prints tables", func() {
+		out, err := runYugatool(cluster, "balance")
+		Expect(err).NotTo(HaveOccurred(), out.String())
+		Expect(out.String()).To(ContainSubstring("[ Table Skew ]"))
+		Expect(out.String()).To(ContainSubstring("balance-tserver-3:9100"))
+	})
+})
@@ -23,13 +23,16 @@ import (
 	"sync"
 	"time"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/yugabyte/yb-tools/pkg/format"
 	"github.com/yugabyte/yb-tools/yugatool/api/yb/common"
 	"github.com/yugabyte/yb-tools/yugatool/api/yb/consensus"
 	"github.com/yugabyte/yb-tools/yugatool/api/yb/master"
 	"github.com/yugabyte/yb-tools/yugatool/api/yb/tserver"
+	yberrors "github.com/yugabyte/yb-tools/yugatool/pkg/client/errors"
 	"github.com/yugabyte/yb-tools/yugatool/pkg/cmdutil"
+	"golang.org/x/sync/errgroup"
 )
 
 func ClusterInfoCmd(ctx *cmdutil.YugatoolContext) *cobra.Command {
@@ -60,6 +63,7 @@ type ClusterInfoOptions struct {
 	LeadersOnlyFilter    bool
 	ShowTombstonedFilter bool
 	ShowTableID          bool
+	Concurrency          int
 }
 
 func (o *ClusterInfoOptions) AddFlags(cmd *cobra.Command) {
@@ -71,9 +75,13 @@ func (o *ClusterInfoOptions) AddFlags(cmd *cobra.Command) {
 	flags.BoolVar(&o.LeadersOnlyFilter, "leaders-only", false, "in tablet report mode, display only tablet leaders")
 	flags.BoolVar(&o.ShowTombstonedFilter, "show-tombstoned", false, "in tablet report mode, display tombstoned tablets")
 	flags.BoolVar(&o.ShowTableID, "show-tableid", false, "in tablet report mode, include the table id of each table")
+	flags.IntVar(&o.Concurrency, "concurrency", 64, "in tablet report mode, maximum number of concurrent requests to each tablet server")
 }
 
 func (o *ClusterInfoOptions) Validate() error {
+	if o.Concurrency < 1 {
+		return errors.New("concurrency must be at least 1")
+	}
 	return nil
 }
 
@@ -119,13 +127,13 @@ func clusterInfo(ctx *cmdutil.YugatoolContext, options *ClusterInfoOptions) erro
 		return err
 	}
 
-	masterClusterConfig, err := c.Master.MasterService.GetMasterClusterConfig(&master.GetMasterClusterConfigRequestPB{})
+	masterClusterConfig, err := c.Master.MasterService.GetMasterClusterConfigWithContext(ctx, &master.GetMasterClusterConfigRequestPB{})
 	if err != nil {
 		return err
 	}
 
-	if masterClusterConfig.GetError() != nil {
-		return fmt.Errorf("could not get cluster config: %v", masterClusterConfig.GetError())
+	if err := yberrors.FromResponse(masterClusterConfig); err != nil {
+		return fmt.Errorf("could not get cluster config: %w", err)
 	}
 
 	clusterConfigReport := format.Output{
@@ -145,13 +153,13 @@ func clusterInfo(ctx *cmdutil.YugatoolContext, options *ClusterInfoOptions) erro
 		return err
 	}
 
-	listMasters, err := c.Master.MasterService.ListMasters(&master.ListMastersRequestPB{})
+	listMasters, err := c.Master.MasterService.ListMastersWithContext(ctx, &master.ListMastersRequestPB{})
 	if err != nil {
 		return err
 	}
 
-	if listMasters.Error != nil {
-		return fmt.Errorf("could not list masters: %v", listMasters.GetError())
+	if err := yberrors.FromResponse(listMasters); err != nil {
+		return fmt.Errorf("could not list masters: %w", err)
 	}
 
 	masterReport := format.Output{
@@ -172,13 +180,42 @@ func clusterInfo(ctx *cmdutil.YugatoolContext, options *ClusterInfoOptions) erro
 		return err
 	}
 
-	tabletServers, err := c.Master.MasterService.ListTabletServers(&master.ListTabletServersRequestPB{})
+	quorumStatus, err := c.MasterQuorumStatus(ctx)
+	if err != nil {
+		return err
+	}
+
+	masterQuorumReport := format.Output{
+		OutputMessage: "Master Quorum",
+		JSONObject:    quorumStatus.Masters,
+		OutputType:    ctx.GlobalOptions.Output,
+		TableColumns: []format.Column{
+			{Name: "ADDRESS", JSONPath: "$.address"},
+			{Name: "CONFIGURED", JSONPath: "$.configured"},
+			{Name: "REACHABLE", JSONPath: "$.reachable"},
+			{Name: "IN_RAFT_CONFIG", JSONPath: "$.in_raft_config"},
+			{Name: "UUID", JSONPath: "$.uuid"},
+			{Name: "ROLE", JSONPath: "$.role"},
+			{Name: "STATUS", JSONPath: "$.status"},
+			{Name: "ERROR", JSONPath: "$.error"},
+		},
+	}
+	err = masterQuorumReport.Println()
 	if err != nil {
 		return err
 	}
 
-	if tabletServers.Error != nil {
-		return fmt.Errorf("could not list tablet servers: %v", tabletServers.GetError())
+	if quorumStatus.Mismatched() {
+		ctx.Log.Error(nil, "the configured master addresses do not match the master raft config, update the master address list")
+	}
+
+	tabletServers, err := c.Master.MasterService.ListTabletServersWithContext(ctx, &master.ListTabletServersRequestPB{})
+	if err != nil {
+		return err
+	}
+
+	if err := yberrors.FromResponse(tabletServers); err != nil {
+		return fmt.Errorf("could not list tablet servers: %w", err)
 	}
 
 	tabletServerReport := format.Output{
@@ -230,29 +267,39 @@ func clusterInfo(ctx *cmdutil.YugatoolContext, options *ClusterInfoOptions) erro
 					return
 				}
 
-				tablets, err := host.TabletServerService.ListTablets(&tserver.ListTabletsRequestPB{})
+				tablets, err := host.TabletServerService.ListTabletsWithContext(ctx, &tserver.ListTabletsRequestPB{})
 				if err != nil {
 					ch <- Report{nil, err}
 					return
 				}
 
-				var tabletinfos []*TabletInfo
-				for _, tablet := range tablets.GetStatusAndSchema() {
-					pb := consensus.GetConsensusStateRequestPB{
-						DestUuid: host.Status.GetNodeInstance().GetPermanentUuid(),
-						TabletId: []byte(tablet.GetTabletStatus().GetTabletId()),
-						Type:     common.ConsensusConfigType_CONSENSUS_CONFIG_COMMITTED.Enum(),
-					}
-					consensusState, err := host.ConsensusService.GetConsensusState(&pb)
-					if err != nil {
-						ch <- Report{nil, err}
-						return
-					}
-					tabletinfos = append(tabletinfos, &TabletInfo{
-						Tablet:         tablet,
-						ConsensusState: consensusState,
+				// The consensus state requests are pipelined over the tserver connection
+				tabletinfos := make([]*TabletInfo, len(tablets.GetStatusAndSchema()))
+				g := &errgroup.Group{}
+				g.SetLimit(options.Concurrency)
+				for i, tablet := range tablets.GetStatusAndSchema() {
+					i, tablet := i, tablet
+					g.Go(func() error {
+						pb := consensus.GetConsensusStateRequestPB{
+							DestUuid: host.Status.GetNodeInstance().GetPermanentUuid(),
+							TabletId: []byte(tablet.GetTabletStatus().GetTabletId()),
+							Type:     common.ConsensusConfigType_CONSENSUS_CONFIG_COMMITTED.Enum(),
+						}
+						consensusState, err := host.ConsensusService.GetConsensusStateWithContext(ctx, &pb)
+						if err != nil {
+							return err
+						}
+						tabletinfos[i] = &TabletInfo{
+							Tablet:         tablet,
+							ConsensusState: consensusState,
+						}
+						return nil
 					})
 				}
+				if err := g.Wait(); err != nil {
+					ch <- Report{nil, err}
+					return
+				}
 
 				filter := &strings.Builder{}
 				if !options.ShowTombstonedFilter {
@@ -313,7 +360,7 @@ func clusterInfo(ctx *cmdutil.YugatoolContext, options *ClusterInfoOptions) erro
 
 		for tabletReport := range ch {
 			if tabletReport.err != nil {
-				ctx.Log.Error(err, "tablet report failed")
+				ctx.Log.Error(tabletReport.err, "tablet report failed")
 			} else if tabletReport.output != nil {
 				err = tabletReport.output.Println()
 				if err != nil {
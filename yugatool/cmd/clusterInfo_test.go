@@ -0,0 +1,160 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/blang/vfs/memfs"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/common"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/master"
+	"github.com/yugabyte/yb-tools/yugatool/cmd"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/rpcserver"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/test/fakecluster"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/util"
+)
+
+var _ = Describe("cluster_info", func() {
+	var (
+		cluster         *fakecluster.Cluster
+		table           *fakecluster.Table
+		args            []string
+		masterAddresses string
+
+		clusterConfig *master.SysClusterConfigEntryPB
+		masters       []*common.ServerEntryPB
+		masterQuorum  []*client.MasterStatus
+		tabletServers []*master.ListTabletServersResponsePB_Entry
+		tabletReports map[string][]*cmd.TabletInfo
+	)
+
+	BeforeEach(func() {
+		cluster = fakecluster.New(logr.Discard(), rpcserver.NewNetwork(), "cluster-info", 3, 3)
+		table = cluster.AddTable("yugabyte", "test_table", 3)
+		args = []string{"cluster_info", "-o", "json"}
+		masterAddresses = cluster.MasterAddresses()
+	})
+
+	JustBeforeEach(func() {
+		out, err := runYugatoolWithMasters(memfs.Create(), cluster, masterAddresses, args...)
+		Expect(err).NotTo(HaveOccurred(), out.String())
+
+		tabletReports = make(map[string][]*cmd.TabletInfo)
+		for _, report := range decodeReports(out) {
+			switch {
+			case report.Msg == "Cluster":
+				Expect(json.Unmarshal(report.Content, &clusterConfig)).To(Succeed())
+			case report.Msg == "Masters":
+				Expect(json.Unmarshal(report.Content, &masters)).To(Succeed())
+			case report.Msg == "Master Quorum":
+				Expect(json.Unmarshal(report.Content, &masterQuorum)).To(Succeed())
+			case report.Msg == "Tablet Servers":
+				Expect(json.Unmarshal(report.Content, &tabletServers)).To(Succeed())
+			case strings.HasPrefix(report.Msg, "Tablet Report: "):
+				var tablets []*cmd.TabletInfo
+				Expect(json.Unmarshal(report.Content, &tablets)).To(Succeed())
+				tabletReports[report.Msg] = tablets
+			}
+		}
+	})
+
+	It("reports the cluster, masters and tablet servers", func() {
+		Expect(clusterConfig.GetClusterUuid()).To(Equal(cluster.ClusterConfig.GetClusterUuid()))
+
+		Expect(masters).To(HaveLen(3))
+		var leaders []string
+		for _, m := range masters {
+			if m.GetRole() == common.RaftPeerPB_LEADER {
+				leaders = append(leaders, string(m.GetInstanceId().GetPermanentUuid()))
+			}
+		}
+		Expect(leaders).To(ConsistOf(cluster.Leader().UUID))
+
+		Expect(masterQuorum).To(HaveLen(3))
+		for _, m := range masterQuorum {
+			Expect(m.Status).To(Equal(client.MasterOK))
+		}
+
+		Expect(tabletServers).To(HaveLen(3))
+		Expect(tabletReports).To(BeEmpty())
+	})
+
+	When("the tablet report is requested", func() {
+		BeforeEach(func() {
+			args = append(args, "--tablet-report")
+		})
+
+		It("reports every replica on every tablet server", func() {
+			Expect(tabletReports).To(HaveLen(3))
+			for _, tablets := range tabletReports {
+				Expect(tablets).To(HaveLen(3))
+				for _, tablet := range tablets {
+					Expect(tablet.Tablet.GetTabletStatus().GetTableName()).To(Equal(table.Name))
+					Expect(tablet.ConsensusState.GetCstate().GetConfig().GetPeers()).To(HaveLen(3))
+				}
+			}
+		})
+
+		When("only leaders are requested", func() {
+			BeforeEach(func() {
+				args = append(args, "--leaders-only")
+			})
+
+			It("reports each tablet once", func() {
+				var leaders []string
+				for _, tablets := range tabletReports {
+					for _, tablet := range tablets {
+						leaders = append(leaders, tablet.Tablet.GetTabletStatus().GetTabletId())
+					}
+				}
+				Expect(leaders).To(ConsistOf(table.Tablets[0].ID, table.Tablets[1].ID, table.Tablets[2].ID))
+			})
+		})
+
+		When("a tablet server is down", func() {
+			BeforeEach(func() {
+				cluster.Stop(cluster.TabletServers[2])
+			})
+
+			It("reports the remaining tablet servers", func() {
+				Expect(tabletReports).To(HaveLen(2))
+			})
+		})
+	})
+
+	When("the master address list is stale", func() {
+		BeforeEach(func() {
+			masterAddresses = strings.Join([]string{
+				util.HostPortString(cluster.Masters[0].Address),
+				util.HostPortString(cluster.Masters[1].Address),
+				"removed-master:7100",
+			}, ",")
+		})
+
+		It("flags the addresses that do not match the raft config", func() {
+			statuses := make(map[string]string)
+			for _, m := range masterQuorum {
+				statuses[m.Address] = m.Status
+			}
+			Expect(statuses).To(Equal(map[string]string{
+				util.HostPortString(cluster.Masters[0].Address): client.MasterOK,
+				util.HostPortString(cluster.Masters[1].Address): client.MasterOK,
+				util.HostPortString(cluster.Masters[2].Address): client.MasterNotConfigured,
+				"removed-master:7100":                           client.MasterNotInRaftConfig,
+			}))
+		})
+	})
+
+	When("the master leader changes", func() {
+		BeforeEach(func() {
+			cluster.SetLeader(cluster.Masters[2])
+		})
+
+		It("connects to the new leader", func() {
+			Expect(tabletServers).To(HaveLen(3))
+		})
+	})
+})
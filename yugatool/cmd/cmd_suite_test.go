@@ -0,0 +1,70 @@
+package cmd_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/blang/vfs"
+	"github.com/blang/vfs/memfs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/yugabyte/yb-tools/yugatool/cmd"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/test/fakecluster"
+)
+
+func TestCmd(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Cmd Suite")
+}
+
+// runYugatool runs a yugatool command against the fake cluster
+func runYugatool(cluster *fakecluster.Cluster, args ...string) (*bytes.Buffer, error) {
+	return runYugatoolWithFs(memfs.Create(), cluster, args...)
+}
+
+func runYugatoolWithFs(fs vfs.Filesystem, cluster *fakecluster.Cluster, args ...string) (*bytes.Buffer, error) {
+	return runYugatoolWithMasters(fs, cluster, cluster.MasterAddresses(), args...)
+}
+
+// runYugatoolWithMasters connects to the cluster through the given master
+// addresses, which may differ from the masters of the cluster
+func runYugatoolWithMasters(fs vfs.Filesystem, cluster *fakecluster.Cluster, masters string, args ...string) (*bytes.Buffer, error) {
+	return runYugatoolWithContext(context.Background(), fs, cluster, masters, args...)
+}
+
+// runYugatoolWithContext runs a yugatool command that is interrupted when ctx
+// is cancelled
+func runYugatoolWithContext(ctx context.Context, fs vfs.Filesystem, cluster *fakecluster.Cluster, masters string, args ...string) (*bytes.Buffer, error) {
+	ytCommand := cmd.RootInitWithDialer(fs, cluster.Network)
+
+	args = append(args, "-m", masters, "--dial-timeout", "1")
+
+	buf := new(bytes.Buffer)
+	ytCommand.SetOut(buf)
+	ytCommand.SetErr(buf)
+	ytCommand.SetArgs(args)
+
+	err := ytCommand.ExecuteContext(ctx)
+
+	return buf, err
+}
+
+type Report struct {
+	Msg     string          `json:"msg"`
+	Content json.RawMessage `json:"content"`
+}
+
+// decodeReports splits json output into its reports
+func decodeReports(buf *bytes.Buffer) []Report {
+	var reports []Report
+
+	dec := json.NewDecoder(buf)
+	for dec.More() {
+		report := Report{}
+		Expect(dec.Decode(&report)).To(Succeed())
+		reports = append(reports, report)
+	}
+	return reports
+}
@@ -0,0 +1,257 @@
+/*
+Copyright © 2021 Yugabyte Support
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flags
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/blang/vfs"
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/yugabyte/yb-tools/pkg/format"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/cmdutil"
+)
+
+const (
+	ReasonBaseline   = "differs from baseline"
+	ReasonMajority   = "differs from majority"
+	ReasonNoMajority = "no majority value"
+	ReasonError      = "could not get flag"
+)
+
+func DiffCmd(ctx *cmdutil.YugatoolContext) *cobra.Command {
+	options := &DiffOptions{}
+	cmd := &cobra.Command{
+		Use:   "diff [FLAG...]",
+		Short: "Find servers whose gflags differ from the other servers or a baseline",
+		Long: `Compare gflags across the masters and tablet servers. Masters and tablet servers are compared
+separately. Each server whose value differs from the value of the majority of its role is reported, or
+from the value in the baseline file when the flag is in it.
+
+The baseline is a YAML file of flag names and values, whose flags are compared along with those given.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := ctx.WithCmd(cmd).WithOptions(options).Setup()
+			if err != nil {
+				return err
+			}
+			defer ctx.Client.Close()
+
+			return diff(ctx, options, args)
+		},
+	}
+	options.AddFlags(cmd)
+
+	return cmd
+}
+
+type DiffOptions struct {
+	Role     string `mapstructure:"role"`
+	Baseline string `mapstructure:"baseline"`
+}
+
+var _ cmdutil.CommandOptions = &DiffOptions{}
+
+func (o *DiffOptions) AddFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.StringVar(&o.Role, "role", RoleAll, "the servers to compare the flags of, as one of: [all, master, tserver]")
+	flags.StringVar(&o.Baseline, "baseline", "", "a YAML file of the expected flag values")
+}
+
+func (o *DiffOptions) Validate() error {
+	return validateRole(o.Role)
+}
+
+type FlagDifference struct {
+	Role     string `json:"role"`
+	UUID     string `json:"uuid"`
+	Address  string `json:"address"`
+	Flag     string `json:"flag"`
+	Value    string `json:"value"`
+	Expected string `json:"expected"`
+	Reason   string `json:"reason"`
+	Error    string `json:"error"`
+}
+
+func diff(ctx *cmdutil.YugatoolContext, options *DiffOptions, flags []string) error {
+	baseline := map[string]string{}
+	if options.Baseline != "" {
+		var err error
+		baseline, err = loadBaseline(ctx.Fs, options.Baseline)
+		if err != nil {
+			return err
+		}
+	}
+
+	flags = append(flags, baselineFlags(baseline, flags)...)
+	if len(flags) == 0 {
+		return errors.New("no flags to compare, give the flags or a baseline")
+	}
+
+	servers, err := listServers(ctx, options.Role)
+	if err != nil {
+		return err
+	}
+
+	var differences []FlagDifference
+	values := readFlags(ctx, servers, flags)
+	for _, flag := range flags {
+		for _, role := range []string{"MASTER", "TSERVER"} {
+			var group []FlagValue
+			for _, value := range values {
+				if value.Flag == flag && value.Role == role {
+					group = append(group, value)
+				}
+			}
+
+			expected, inBaseline := baseline[flag]
+			differences = append(differences, compareFlag(group, expected, inBaseline)...)
+		}
+	}
+
+	diffReport := format.Output{
+		OutputMessage: "Flag Differences",
+		JSONObject:    differences,
+		OutputType:    ctx.GlobalOptions.Output,
+		TableColumns: []format.Column{
+			{Name: "ROLE", JSONPath: "$.role"},
+			{Name: "UUID", JSONPath: "$.uuid"},
+			{Name: "ADDRESS", JSONPath: "$.address"},
+			{Name: "FLAG", JSONPath: "$.flag"},
+			{Name: "VALUE", JSONPath: "$.value"},
+			{Name: "EXPECTED", JSONPath: "$.expected"},
+			{Name: "REASON", JSONPath: "$.reason"},
+			{Name: "ERROR", JSONPath: "$.error"},
+		},
+	}
+
+	err = diffReport.Print()
+	if err != nil {
+		return err
+	}
+
+	if len(differences) > 0 {
+		return errors.Errorf("%d flag values differ", len(differences))
+	}
+	return nil
+}
+
+// compareFlag returns the servers of a role whose value of a flag differs from
+// the baseline, or from the majority without one. A flag that none of the
+// servers have is not a difference, as many flags only exist on one role.
+func compareFlag(group []FlagValue, expected string, inBaseline bool) []FlagDifference {
+	counts := map[string]int{}
+	found := 0
+	for _, value := range group {
+		if value.err == nil {
+			counts[value.Value]++
+			found++
+		}
+	}
+
+	reason := ReasonBaseline
+	if !inBaseline {
+		reason = ReasonMajority
+
+		var majority string
+		for value, count := range counts {
+			if count > counts[majority] || (count == counts[majority] && value < majority) {
+				majority = value
+			}
+		}
+		if counts[majority]*2 > found {
+			expected = majority
+		} else {
+			reason = ReasonNoMajority
+		}
+	}
+
+	var differences []FlagDifference
+	for _, value := range group {
+		difference := FlagDifference{
+			Role:     value.Role,
+			UUID:     value.UUID,
+			Address:  value.Address,
+			Flag:     value.Flag,
+			Value:    value.Value,
+			Expected: expected,
+			Reason:   reason,
+		}
+		if value.err != nil {
+			if found == 0 && errors.Is(value.err, errNoSuchFlag) {
+				continue
+			}
+			difference.Reason = ReasonError
+			difference.Error = value.Error
+		} else if reason != ReasonNoMajority && value.Value == expected {
+			continue
+		}
+		differences = append(differences, difference)
+	}
+	return differences
+}
+
+// baselineFlags returns the flags of the baseline that are not already in flags,
+// in order
+func baselineFlags(baseline map[string]string, flags []string) []string {
+	given := map[string]bool{}
+	for _, flag := range flags {
+		given[flag] = true
+	}
+
+	var extra []string
+	for flag := range baseline {
+		if !given[flag] {
+			extra = append(extra, flag)
+		}
+	}
+	sort.Strings(extra)
+	return extra
+}
+
+// loadBaseline reads a YAML file of flag names and values. Values may be written
+// as numbers or booleans, and are compared as the server reports them.
+func loadBaseline(fs vfs.Filesystem, filename string) (map[string]string, error) {
+	contents, err := vfs.ReadFile(fs, filename)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read baseline")
+	}
+
+	var values map[string]interface{}
+	err = yaml.Unmarshal(contents, &values)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not parse baseline %s", filename)
+	}
+
+	baseline := make(map[string]string, len(values))
+	for flag, value := range values {
+		switch v := value.(type) {
+		case string:
+			baseline[flag] = v
+		case float64:
+			baseline[flag] = strconv.FormatFloat(v, 'f', -1, 64)
+		case bool:
+			baseline[flag] = strconv.FormatBool(v)
+		case nil:
+			baseline[flag] = ""
+		default:
+			return nil, errors.Errorf("invalid baseline value for %s: %v", flag, v)
+		}
+	}
+	return baseline, nil
+}
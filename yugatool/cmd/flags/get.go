@@ -0,0 +1,114 @@
+/*
+Copyright © 2021 Yugabyte Support
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flags
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/yugabyte/yb-tools/pkg/format"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/cmdutil"
+)
+
+func GetCmd(ctx *cmdutil.YugatoolContext) *cobra.Command {
+	options := &GetOptions{}
+	cmd := &cobra.Command{
+		Use:   "get FLAG...",
+		Short: "Get the value of gflags on every master and tablet server",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := ctx.WithCmd(cmd).WithOptions(options).Setup()
+			if err != nil {
+				return err
+			}
+			defer ctx.Client.Close()
+
+			return get(ctx, options, args)
+		},
+	}
+	options.AddFlags(cmd)
+
+	return cmd
+}
+
+type GetOptions struct {
+	Role string `mapstructure:"role"`
+}
+
+var _ cmdutil.CommandOptions = &GetOptions{}
+
+func (o *GetOptions) AddFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.StringVar(&o.Role, "role", RoleAll, "the servers to get the flags of, as one of: [all, master, tserver]")
+}
+
+func (o *GetOptions) Validate() error {
+	return validateRole(o.Role)
+}
+
+type FlagValue struct {
+	Role    string `json:"role"`
+	UUID    string `json:"uuid"`
+	Address string `json:"address"`
+	Flag    string `json:"flag"`
+	Value   string `json:"value"`
+	Error   string `json:"error"`
+
+	err error
+}
+
+func get(ctx *cmdutil.YugatoolContext, options *GetOptions, flags []string) error {
+	servers, err := listServers(ctx, options.Role)
+	if err != nil {
+		return err
+	}
+
+	flagReport := format.Output{
+		OutputMessage: "Flags",
+		JSONObject:    readFlags(ctx, servers, flags),
+		OutputType:    ctx.GlobalOptions.Output,
+		TableColumns: []format.Column{
+			{Name: "ROLE", JSONPath: "$.role"},
+			{Name: "UUID", JSONPath: "$.uuid"},
+			{Name: "ADDRESS", JSONPath: "$.address"},
+			{Name: "FLAG", JSONPath: "$.flag"},
+			{Name: "VALUE", JSONPath: "$.value"},
+			{Name: "ERROR", JSONPath: "$.error"},
+		},
+	}
+	return flagReport.Print()
+}
+
+// readFlags gets each flag from each server, ordered by flag
+func readFlags(ctx *cmdutil.YugatoolContext, servers []*flagServer, flags []string) []FlagValue {
+	var values []FlagValue
+	for _, flag := range flags {
+		for _, s := range servers {
+			value := FlagValue{
+				Role:    s.Role,
+				UUID:    s.UUID,
+				Address: s.Address,
+				Flag:    flag,
+			}
+
+			value.Value, value.err = s.getFlag(ctx, flag)
+			if value.err != nil {
+				value.Error = value.err.Error()
+			}
+			values = append(values, value)
+		}
+	}
+	return values
+}
@@ -0,0 +1,121 @@
+/*
+Copyright © 2021 Yugabyte Support
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flags
+
+import (
+	"github.com/blang/vfs"
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/cmdutil"
+)
+
+func RollbackCmd(ctx *cmdutil.YugatoolContext) *cobra.Command {
+	options := &RollbackOptions{}
+	cmd := &cobra.Command{
+		Use:   "rollback ROLLBACK_FILE",
+		Short: "Restore the gflags changed by flags set",
+		Long: `Restore the previous values of the gflags changed by "yugatool flags set", from the rollback file
+it saved. Each flag is set back on the server it was changed on.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := ctx.WithCmd(cmd).WithOptions(options).Setup()
+			if err != nil {
+				return err
+			}
+			defer ctx.Client.Close()
+
+			return rollback(ctx, options, args[0])
+		},
+	}
+	options.AddFlags(cmd)
+
+	return cmd
+}
+
+type RollbackOptions struct {
+	Force   bool `mapstructure:"force"`
+	DryRun  bool `mapstructure:"dry_run"`
+	Approve bool `mapstructure:"approve"`
+}
+
+var _ cmdutil.CommandOptions = &RollbackOptions{}
+
+func (o *RollbackOptions) AddFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.BoolVar(&o.Force, "force", false, "restore flags even if they are not marked as safe to change at runtime")
+	flags.BoolVar(&o.DryRun, "dry-run", false, "list the changes without making them")
+	flags.BoolVar(&o.Approve, "approve", false, "make the changes without prompting")
+}
+
+func (o *RollbackOptions) Validate() error {
+	return nil
+}
+
+func rollback(ctx *cmdutil.YugatoolContext, options *RollbackOptions, filename string) error {
+	contents, err := vfs.ReadFile(ctx.Fs, filename)
+	if err != nil {
+		return errors.Wrap(err, "could not read rollback file")
+	}
+
+	var records []FlagChange
+	err = yaml.Unmarshal(contents, &records)
+	if err != nil {
+		return errors.Wrapf(err, "could not parse rollback file %s", filename)
+	}
+
+	servers, err := listServers(ctx, RoleAll)
+	if err != nil {
+		return err
+	}
+	serversByUUID := make(map[string]*flagServer)
+	for _, s := range servers {
+		serversByUUID[s.UUID] = s
+	}
+
+	var changes []*FlagChange
+	for _, record := range records {
+		// The change is undone by setting the flag back to its old value
+		change := &FlagChange{
+			Role:     record.Role,
+			UUID:     record.UUID,
+			Address:  record.Address,
+			Flag:     record.Flag,
+			NewValue: record.OldValue,
+			Result:   ResultPending,
+		}
+		changes = append(changes, change)
+
+		s, ok := serversByUUID[record.UUID]
+		if !ok {
+			change.Result = ResultSkipped
+			change.Error = "server not found"
+			continue
+		}
+		change.server = s
+
+		change.OldValue, err = s.getFlag(ctx, record.Flag)
+		if err != nil {
+			change.Result = ResultSkipped
+			change.Error = err.Error()
+		} else if change.OldValue == change.NewValue {
+			change.Result = ResultUnchanged
+		}
+	}
+
+	return applyChanges(ctx, changes, options.Force, options.DryRun, options.Approve, "")
+}
@@ -0,0 +1,134 @@
+/*
+Copyright © 2021 Yugabyte Support
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flags
+
+import (
+	. "github.com/icza/gox/gox"
+	"github.com/pkg/errors"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/server"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/cmdutil"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/util"
+)
+
+const (
+	RoleAll     = "all"
+	RoleMaster  = "master"
+	RoleTserver = "tserver"
+)
+
+func validateRole(role string) error {
+	switch role {
+	case RoleAll, RoleMaster, RoleTserver:
+		return nil
+	}
+	return errors.Errorf("invalid role %q, must be one of: [%s, %s, %s]", role, RoleAll, RoleMaster, RoleTserver)
+}
+
+// errNoSuchFlag is returned for flags the server does not have, such as master
+// flags on a tablet server
+var errNoSuchFlag = errors.New("no such flag")
+
+// flagServer is a master or tablet server whose flags are read or changed
+type flagServer struct {
+	Role    string
+	UUID    string
+	Address string
+
+	host *client.HostState
+}
+
+// listServers connects to every master and tablet server of the role. Servers
+// that cannot be reached are logged and left out.
+func listServers(ctx *cmdutil.YugatoolContext, role string) ([]*flagServer, error) {
+	var servers []*flagServer
+	unreachable := 0
+
+	if role == RoleAll || role == RoleMaster {
+		masters, errs := ctx.Client.AllMasters(ctx)
+		for _, err := range errs {
+			ctx.Log.Error(err, "could not connect to master")
+		}
+		unreachable += len(errs)
+		for _, host := range masters {
+			servers = append(servers, newFlagServer("MASTER", host))
+		}
+	}
+
+	if role == RoleAll || role == RoleTserver {
+		tservers, errs := ctx.Client.AllTservers()
+		for _, err := range errs {
+			ctx.Log.Error(err, "could not connect to tablet server")
+		}
+		unreachable += len(errs)
+		for _, host := range tservers {
+			servers = append(servers, newFlagServer("TSERVER", host))
+		}
+	}
+
+	if len(servers) == 0 {
+		return nil, errors.Errorf("could not connect to any server, %d unreachable", unreachable)
+	}
+	return servers, nil
+}
+
+func newFlagServer(role string, host *client.HostState) *flagServer {
+	s := &flagServer{
+		Role: role,
+		UUID: string(host.Status.GetNodeInstance().GetPermanentUuid()),
+		host: host,
+	}
+	if addresses := host.Status.GetBoundRpcAddresses(); len(addresses) > 0 {
+		s.Address = util.HostPortString(addresses[0])
+	}
+	return s
+}
+
+// getFlag returns the value of the flag on the server
+func (s *flagServer) getFlag(ctx *cmdutil.YugatoolContext, flag string) (string, error) {
+	response, err := s.host.GenericService.GetFlagWithContext(ctx, &server.GetFlagRequestPB{
+		Flag: NewString(flag),
+	})
+	if err != nil {
+		return "", err
+	}
+	if !response.GetValid() {
+		return "", errors.Wrap(errNoSuchFlag, flag)
+	}
+	return response.GetValue(), nil
+}
+
+// setFlag changes the flag on the server, returning its previous value
+func (s *flagServer) setFlag(ctx *cmdutil.YugatoolContext, flag, value string, force bool) (string, error) {
+	response, err := s.host.GenericService.SetFlagWithContext(ctx, &server.SetFlagRequestPB{
+		Flag:  NewString(flag),
+		Value: NewString(value),
+		Force: NewBool(force),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	switch response.GetResult() {
+	case server.SetFlagResponsePB_SUCCESS:
+		return response.GetOldValue(), nil
+	case server.SetFlagResponsePB_NOT_SAFE:
+		return response.GetOldValue(), errors.Errorf("%s is not safe to change at runtime, use --force to change it anyway", flag)
+	default:
+		return response.GetOldValue(), errors.Errorf("could not set %s to %q: %s: %s", flag, value, response.GetResult(), response.GetMsg())
+	}
+}
@@ -0,0 +1,271 @@
+/*
+Copyright © 2021 Yugabyte Support
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flags
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/blang/vfs"
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/yugabyte/yb-tools/pkg/format"
+	"github.com/yugabyte/yb-tools/pkg/util"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/cmdutil"
+)
+
+const (
+	ResultPending   = "pending"
+	ResultChanged   = "changed"
+	ResultUnchanged = "unchanged"
+	ResultSkipped   = "skipped"
+	ResultFailed    = "failed"
+)
+
+func SetCmd(ctx *cmdutil.YugatoolContext) *cobra.Command {
+	options := &SetOptions{}
+	cmd := &cobra.Command{
+		Use:   "set FLAG=VALUE...",
+		Short: "Set gflags on every master and tablet server",
+		Long: `Set gflags at runtime on every master and tablet server, or on those of one role. The changes are
+listed and confirmed before they are made. The previous values are saved to a rollback file first,
+which "yugatool flags rollback" restores them from.
+
+Servers that do not have a flag, such as master flags on tablet servers, are skipped. Flags not marked
+as safe to change at runtime are only changed with --force.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			assignments, err := parseAssignments(args)
+			if err != nil {
+				return err
+			}
+
+			err = ctx.WithCmd(cmd).WithOptions(options).Setup()
+			if err != nil {
+				return err
+			}
+			defer ctx.Client.Close()
+
+			return set(ctx, options, assignments)
+		},
+	}
+	options.AddFlags(cmd)
+
+	return cmd
+}
+
+type SetOptions struct {
+	Role         string `mapstructure:"role"`
+	Force        bool   `mapstructure:"force"`
+	DryRun       bool   `mapstructure:"dry_run"`
+	Approve      bool   `mapstructure:"approve"`
+	RollbackFile string `mapstructure:"rollback_file"`
+}
+
+var _ cmdutil.CommandOptions = &SetOptions{}
+
+func (o *SetOptions) AddFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.StringVar(&o.Role, "role", RoleAll, "the servers to set the flags on, as one of: [all, master, tserver]")
+	flags.BoolVar(&o.Force, "force", false, "change flags even if they are not marked as safe to change at runtime")
+	flags.BoolVar(&o.DryRun, "dry-run", false, "list the changes without making them")
+	flags.BoolVar(&o.Approve, "approve", false, "make the changes without prompting")
+	flags.StringVar(&o.RollbackFile, "rollback-file", "", "the file to save the previous values to (default flags-rollback-<time>.yaml)")
+}
+
+func (o *SetOptions) Validate() error {
+	if o.RollbackFile == "" {
+		o.RollbackFile = fmt.Sprintf("flags-rollback-%s.yaml", time.Now().Format("20060102-150405"))
+	}
+	return validateRole(o.Role)
+}
+
+type flagAssignment struct {
+	flag  string
+	value string
+}
+
+func parseAssignments(args []string) ([]flagAssignment, error) {
+	var assignments []flagAssignment
+	for _, arg := range args {
+		flag, value, ok := strings.Cut(arg, "=")
+		flag = strings.TrimPrefix(flag, "--")
+		if !ok || flag == "" {
+			return nil, errors.Errorf("invalid flag assignment %q, expected <flag>=<value>", arg)
+		}
+		assignments = append(assignments, flagAssignment{flag: flag, value: value})
+	}
+	return assignments, nil
+}
+
+// FlagChange is a change of a flag on one server, which is also the record of
+// the change kept in the rollback file
+type FlagChange struct {
+	Role     string `json:"role"`
+	UUID     string `json:"uuid"`
+	Address  string `json:"address"`
+	Flag     string `json:"flag"`
+	OldValue string `json:"old_value"`
+	NewValue string `json:"new_value"`
+	Result   string `json:"result,omitempty"`
+	Error    string `json:"error,omitempty"`
+
+	server *flagServer
+}
+
+func set(ctx *cmdutil.YugatoolContext, options *SetOptions, assignments []flagAssignment) error {
+	servers, err := listServers(ctx, options.Role)
+	if err != nil {
+		return err
+	}
+
+	var changes []*FlagChange
+	for _, assignment := range assignments {
+		found := false
+		for _, s := range servers {
+			change := &FlagChange{
+				Role:     s.Role,
+				UUID:     s.UUID,
+				Address:  s.Address,
+				Flag:     assignment.flag,
+				NewValue: assignment.value,
+				Result:   ResultPending,
+				server:   s,
+			}
+
+			change.OldValue, err = s.getFlag(ctx, assignment.flag)
+			if errors.Is(err, errNoSuchFlag) {
+				change.Result = ResultSkipped
+				change.Error = err.Error()
+			} else if err != nil {
+				return errors.Wrapf(err, "could not get %s from %s", assignment.flag, s.Address)
+			} else {
+				found = true
+				if change.OldValue == change.NewValue {
+					change.Result = ResultUnchanged
+				}
+			}
+			changes = append(changes, change)
+		}
+
+		if !found {
+			return errors.Errorf("no server has the flag %s", assignment.flag)
+		}
+	}
+
+	return applyChanges(ctx, changes, options.Force, options.DryRun, options.Approve, options.RollbackFile)
+}
+
+// applyChanges lists the pending changes and, once confirmed, makes them. When
+// rollbackFile is set, the changes are saved to it before they are made.
+func applyChanges(ctx *cmdutil.YugatoolContext, changes []*FlagChange, force, dryRun, approve bool, rollbackFile string) error {
+	var pending []*FlagChange
+	for _, change := range changes {
+		if change.Result == ResultPending {
+			pending = append(pending, change)
+		}
+	}
+
+	if dryRun || len(pending) == 0 {
+		return printChanges(ctx, changes)
+	}
+
+	if !approve {
+		err := printChanges(ctx, changes)
+		if err != nil {
+			return err
+		}
+
+		err = util.ConfirmationDialog()
+		if err != nil {
+			return err
+		}
+	}
+
+	if rollbackFile != "" {
+		err := saveRollback(ctx.Fs, rollbackFile, pending)
+		if err != nil {
+			return err
+		}
+		ctx.Log.Info("saved the previous flag values", "rollback_file", rollbackFile)
+	}
+
+	failed := 0
+	for _, change := range pending {
+		_, err := change.server.setFlag(ctx, change.Flag, change.NewValue, force)
+		if err != nil {
+			failed++
+			change.Result = ResultFailed
+			change.Error = err.Error()
+			continue
+		}
+		change.Result = ResultChanged
+	}
+
+	err := printChanges(ctx, changes)
+	if err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return errors.Errorf("%d of %d flag changes failed", failed, len(pending))
+	}
+	return nil
+}
+
+func printChanges(ctx *cmdutil.YugatoolContext, changes []*FlagChange) error {
+	changeReport := format.Output{
+		OutputMessage: "Flag Changes",
+		JSONObject:    changes,
+		OutputType:    ctx.GlobalOptions.Output,
+		TableColumns: []format.Column{
+			{Name: "ROLE", JSONPath: "$.role"},
+			{Name: "UUID", JSONPath: "$.uuid"},
+			{Name: "ADDRESS", JSONPath: "$.address"},
+			{Name: "FLAG", JSONPath: "$.flag"},
+			{Name: "OLD_VALUE", JSONPath: "$.old_value"},
+			{Name: "NEW_VALUE", JSONPath: "$.new_value"},
+			{Name: "RESULT", JSONPath: "$.result"},
+			{Name: "ERROR", JSONPath: "$.error"},
+		},
+	}
+	return changeReport.Println()
+}
+
+// saveRollback writes the changes to a file, to be undone from by "flags rollback"
+func saveRollback(fs vfs.Filesystem, filename string, changes []*FlagChange) error {
+	var rollback []FlagChange
+	for _, change := range changes {
+		record := *change
+		record.Result = ""
+		record.Error = ""
+		rollback = append(rollback, record)
+	}
+
+	contents, err := yaml.Marshal(rollback)
+	if err != nil {
+		return err
+	}
+
+	err = vfs.WriteFile(fs, filename, contents, 0644)
+	if err != nil {
+		return errors.Wrap(err, "could not save rollback file")
+	}
+	return nil
+}
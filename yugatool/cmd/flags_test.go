@@ -0,0 +1,261 @@
+package cmd_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/blang/vfs"
+	"github.com/blang/vfs/memfs"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/yugabyte/yb-tools/yugatool/cmd/flags"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/rpcserver"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/test/fakecluster"
+)
+
+// decodeReport returns the content of the report with the message, ignoring the
+// error printed after the reports when the command fails
+func decodeReport(out *bytes.Buffer, msg string, content interface{}) {
+	output := out.String()
+	if i := strings.Index(output, "Error: "); i >= 0 {
+		output = output[:i]
+	}
+
+	for _, report := range decodeReports(bytes.NewBufferString(output)) {
+		if report.Msg == msg {
+			Expect(json.Unmarshal(report.Content, content)).To(Succeed())
+			return
+		}
+	}
+	Fail("no report " + msg + " in output: " + out.String())
+}
+
+var _ = Describe("flags", func() {
+	var (
+		cluster *fakecluster.Cluster
+		fs      vfs.Filesystem
+		nodes   []*fakecluster.Node
+	)
+
+	BeforeEach(func() {
+		cluster = fakecluster.New(logr.Discard(), rpcserver.NewNetwork(), "flags", 1, 3)
+		fs = memfs.Create()
+		nodes = append(append([]*fakecluster.Node{}, cluster.Masters...), cluster.TabletServers...)
+
+		for _, tserver := range cluster.TabletServers {
+			tserver.Flags["ysql_max_connections"] = "300"
+		}
+		cluster.Masters[0].Flags["enable_load_balancing"] = "true"
+	})
+
+	Context("get", func() {
+		It("gets the flags of every server", func() {
+			out, err := runYugatoolWithFs(fs, cluster, "flags", "get", "v", "ysql_max_connections", "-o", "json")
+			Expect(err).NotTo(HaveOccurred())
+
+			var values []flags.FlagValue
+			decodeReport(out, "Flags", &values)
+			Expect(values).To(HaveLen(2 * len(nodes)))
+
+			for _, value := range values[:len(nodes)] {
+				Expect(value.Flag).To(Equal("v"))
+				Expect(value.Value).To(Equal("0"))
+				Expect(value.Error).To(BeEmpty())
+			}
+			Expect(values[len(nodes)].Role).To(Equal("MASTER"))
+			Expect(values[len(nodes)].Error).To(ContainSubstring("no such flag"))
+			for _, value := range values[len(nodes)+1:] {
+				Expect(value.Role).To(Equal("TSERVER"))
+				Expect(value.Value).To(Equal("300"))
+			}
+		})
+
+		It("gets the flags of one role", func() {
+			out, err := runYugatoolWithFs(fs, cluster, "flags", "get", "v", "--role", "master", "-o", "json")
+			Expect(err).NotTo(HaveOccurred())
+
+			var values []flags.FlagValue
+			decodeReport(out, "Flags", &values)
+			Expect(values).To(HaveLen(1))
+			Expect(values[0].UUID).To(Equal(cluster.Masters[0].UUID))
+		})
+
+		It("prints a table", func() {
+			out, err := runYugatoolWithFs(fs, cluster, "flags", "get", "v")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out.String()).To(ContainSubstring("[ Flags ]"))
+			Expect(out.String()).To(ContainSubstring(cluster.TabletServers[0].UUID))
+		})
+	})
+
+	Context("diff", func() {
+		It("finds no differences between matching servers", func() {
+			out, err := runYugatoolWithFs(fs, cluster, "flags", "diff", "v", "ysql_max_connections", "enable_load_balancing", "-o", "json")
+			Expect(err).NotTo(HaveOccurred())
+
+			var differences []flags.FlagDifference
+			decodeReport(out, "Flag Differences", &differences)
+			Expect(differences).To(BeEmpty())
+		})
+
+		It("reports servers that differ from the majority of their role", func() {
+			cluster.TabletServers[1].Flags["ysql_max_connections"] = "500"
+
+			out, err := runYugatoolWithFs(fs, cluster, "flags", "diff", "ysql_max_connections", "-o", "json")
+			Expect(err).To(MatchError("1 flag values differ"))
+
+			var differences []flags.FlagDifference
+			decodeReport(out, "Flag Differences", &differences)
+			Expect(differences).To(ConsistOf(flags.FlagDifference{
+				Role:     "TSERVER",
+				UUID:     cluster.TabletServers[1].UUID,
+				Address:  "flags-tserver-2:9100",
+				Flag:     "ysql_max_connections",
+				Value:    "500",
+				Expected: "300",
+				Reason:   flags.ReasonMajority,
+			}))
+		})
+
+		It("reports every server when there is no majority", func() {
+			cluster.TabletServers[1].Flags["ysql_max_connections"] = "500"
+			cluster.TabletServers[2].Flags["ysql_max_connections"] = "400"
+
+			out, err := runYugatoolWithFs(fs, cluster, "flags", "diff", "ysql_max_connections", "-o", "json")
+			Expect(err).To(HaveOccurred())
+
+			var differences []flags.FlagDifference
+			decodeReport(out, "Flag Differences", &differences)
+			Expect(differences).To(HaveLen(3))
+			for _, difference := range differences {
+				Expect(difference.Reason).To(Equal(flags.ReasonNoMajority))
+			}
+		})
+
+		It("compares servers with a baseline", func() {
+			Expect(vfs.WriteFile(fs, "/baseline.yaml", []byte("v: 1\nysql_max_connections: 300\n"), 0644)).To(Succeed())
+			cluster.TabletServers[2].Flags["v"] = "1"
+
+			out, err := runYugatoolWithFs(fs, cluster, "flags", "diff", "--baseline", "/baseline.yaml", "-o", "json")
+			Expect(err).To(MatchError("3 flag values differ"))
+
+			var differences []flags.FlagDifference
+			decodeReport(out, "Flag Differences", &differences)
+			Expect(differences).To(HaveLen(3))
+			for _, difference := range differences {
+				Expect(difference.Flag).To(Equal("v"))
+				Expect(difference.Value).To(Equal("0"))
+				Expect(difference.Expected).To(Equal("1"))
+				Expect(difference.Reason).To(Equal(flags.ReasonBaseline))
+			}
+		})
+
+		It("needs flags to compare", func() {
+			_, err := runYugatoolWithFs(fs, cluster, "flags", "diff")
+			Expect(err).To(MatchError(ContainSubstring("no flags to compare")))
+		})
+	})
+
+	Context("set", func() {
+		It("sets the flag on every server that has it, saving the previous values", func() {
+			out, err := runYugatoolWithFs(fs, cluster, "flags", "set", "ysql_max_connections=500", "--approve", "--rollback-file", "/rollback.yaml", "-o", "json")
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, tserver := range cluster.TabletServers {
+				Expect(tserver.Flags["ysql_max_connections"]).To(Equal("500"))
+			}
+
+			var changes []flags.FlagChange
+			decodeReport(out, "Flag Changes", &changes)
+			Expect(changes).To(HaveLen(len(nodes)))
+			Expect(changes[0].Result).To(Equal(flags.ResultSkipped))
+			for _, change := range changes[1:] {
+				Expect(change.Result).To(Equal(flags.ResultChanged))
+				Expect(change.OldValue).To(Equal("300"))
+			}
+
+			rollback, err := vfs.ReadFile(fs, "/rollback.yaml")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(strings.Count(string(rollback), "old_value: \"300\"")).To(Equal(3))
+		})
+
+		It("changes nothing on a dry run", func() {
+			out, err := runYugatoolWithFs(fs, cluster, "flags", "set", "v=2", "--dry-run", "--rollback-file", "/rollback.yaml", "-o", "json")
+			Expect(err).NotTo(HaveOccurred())
+
+			var changes []flags.FlagChange
+			decodeReport(out, "Flag Changes", &changes)
+			for _, change := range changes {
+				Expect(change.Result).To(Equal(flags.ResultPending))
+				Expect(change.NewValue).To(Equal("2"))
+			}
+			for _, node := range nodes {
+				Expect(node.Flags["v"]).To(Equal("0"))
+			}
+
+			_, err = fs.Stat("/rollback.yaml")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("sets flags only on servers of the role", func() {
+			_, err := runYugatoolWithFs(fs, cluster, "flags", "set", "v=2", "--role", "tserver", "--approve", "--rollback-file", "/rollback.yaml")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(cluster.Masters[0].Flags["v"]).To(Equal("0"))
+			Expect(cluster.TabletServers[0].Flags["v"]).To(Equal("2"))
+		})
+
+		It("only changes unsafe flags when forced", func() {
+			cluster.UnsafeFlags["v"] = true
+
+			out, err := runYugatoolWithFs(fs, cluster, "flags", "set", "v=2", "--approve", "--rollback-file", "/rollback.yaml", "-o", "json")
+			Expect(err).To(MatchError("4 of 4 flag changes failed"))
+
+			var changes []flags.FlagChange
+			decodeReport(out, "Flag Changes", &changes)
+			Expect(changes[0].Error).To(ContainSubstring("use --force"))
+			Expect(cluster.Masters[0].Flags["v"]).To(Equal("0"))
+
+			_, err = runYugatoolWithFs(fs, cluster, "flags", "set", "v=2", "--force", "--approve", "--rollback-file", "/rollback.yaml")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cluster.Masters[0].Flags["v"]).To(Equal("2"))
+		})
+
+		It("fails for a flag no server has", func() {
+			_, err := runYugatoolWithFs(fs, cluster, "flags", "set", "no_such_flag=1", "--approve", "--rollback-file", "/rollback.yaml")
+			Expect(err).To(MatchError("no server has the flag no_such_flag"))
+		})
+
+		It("rejects invalid assignments", func() {
+			_, err := runYugatoolWithFs(fs, cluster, "flags", "set", "v")
+			Expect(err).To(MatchError(ContainSubstring("expected <flag>=<value>")))
+		})
+	})
+
+	Context("rollback", func() {
+		It("restores the previous values", func() {
+			_, err := runYugatoolWithFs(fs, cluster, "flags", "set", "v=2", "ysql_max_connections=500", "--approve", "--rollback-file", "/rollback.yaml")
+			Expect(err).NotTo(HaveOccurred())
+			cluster.TabletServers[0].Flags["v"] = "0"
+
+			out, err := runYugatoolWithFs(fs, cluster, "flags", "rollback", "/rollback.yaml", "--approve", "-o", "json")
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, node := range nodes {
+				Expect(node.Flags["v"]).To(Equal("0"))
+			}
+			for _, tserver := range cluster.TabletServers {
+				Expect(tserver.Flags["ysql_max_connections"]).To(Equal("300"))
+			}
+
+			var changes []flags.FlagChange
+			decodeReport(out, "Flag Changes", &changes)
+			Expect(changes).To(HaveLen(7))
+			Expect(changes[1].UUID).To(Equal(cluster.TabletServers[0].UUID))
+			Expect(changes[1].Flag).To(Equal("v"))
+			Expect(changes[1].Result).To(Equal(flags.ResultUnchanged))
+		})
+	})
+})
@@ -0,0 +1,111 @@
+/*
+Copyright © 2021 Yugabyte Support
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package healthcheck
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/yugabyte/yb-tools/pkg/format"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/cmdutil"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/healthcheck"
+)
+
+func TabletsCheck(ctx *cmdutil.YugatoolContext) *cobra.Command {
+	options := &TabletsCheckOptions{}
+	cmd := &cobra.Command{
+		Use:   "tablets",
+		Short: "Check for under-replicated and unhealthy tablets",
+		Long: `Check every tablet for too few or too many replicas for the replication factor of its table,
+no leader, replicas on dead tablet servers, replicas that are not running, and peers whose committed
+Raft configs disagree. The command fails when any problem is found, so it can be run from cron.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := ctx.WithCmd(cmd).WithOptions(options).Setup()
+			if err != nil {
+				return err
+			}
+			defer ctx.Client.Close()
+
+			return checkTablets(ctx, options)
+		},
+	}
+	options.AddFlags(cmd)
+
+	return cmd
+}
+
+type TabletsCheckOptions struct {
+	Namespace        string `mapstructure:"namespace"`
+	Table            string `mapstructure:"table"`
+	HeartbeatTimeout int64  `mapstructure:"heartbeat_timeout"`
+	Concurrency      int    `mapstructure:"concurrency"`
+}
+
+var _ cmdutil.CommandOptions = &TabletsCheckOptions{}
+
+func (o *TabletsCheckOptions) AddFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.StringVar(&o.Namespace, "namespace", "", "check only the tables of this namespace")
+	flags.StringVar(&o.Table, "table", "", "check only the tables whose name contains this")
+	flags.Int64Var(&o.HeartbeatTimeout, "heartbeat-timeout", 60, "number of seconds since its last heartbeat after which a tablet server is considered dead")
+	flags.IntVar(&o.Concurrency, "concurrency", 64, "maximum number of concurrent requests to each tablet server")
+}
+
+func (o *TabletsCheckOptions) Validate() error {
+	if o.HeartbeatTimeout < 1 {
+		return errors.New("heartbeat-timeout must be at least 1 second")
+	}
+	if o.Concurrency < 1 {
+		return errors.New("concurrency must be at least 1")
+	}
+	return nil
+}
+
+func checkTablets(ctx *cmdutil.YugatoolContext, options *TabletsCheckOptions) error {
+	problems, err := healthcheck.CheckTablets(ctx, ctx.Log, ctx.Client, healthcheck.TabletCheckOptions{
+		NamespaceFilter:  options.Namespace,
+		TableFilter:      options.Table,
+		HeartbeatTimeout: time.Duration(options.HeartbeatTimeout) * time.Second,
+		Concurrency:      options.Concurrency,
+	})
+	if err != nil {
+		return err
+	}
+
+	problemReport := format.Output{
+		OutputMessage: "Tablet Problems",
+		JSONObject:    problems,
+		OutputType:    ctx.GlobalOptions.Output,
+		TableColumns: []format.Column{
+			{Name: "TABLE", JSONPath: "$.table"},
+			{Name: "TABLET", JSONPath: "$.tablet"},
+			{Name: "TSERVER", JSONPath: "$.tserver"},
+			{Name: "PROBLEM", JSONPath: "$.problem"},
+			{Name: "DETAIL", JSONPath: "$.detail"},
+		},
+	}
+	err = problemReport.Println()
+	if err != nil {
+		return err
+	}
+
+	if len(problems) > 0 {
+		return errors.Errorf("%d tablet problems found", len(problems))
+	}
+	return nil
+}
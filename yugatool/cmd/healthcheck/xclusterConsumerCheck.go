@@ -17,6 +17,7 @@ limitations under the License.
 package healthcheck
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/go-logr/logr"
@@ -42,15 +43,15 @@ func XclusterConsumerCheck(ctx *cmdutil.YugatoolContext) *cobra.Command {
 			}
 			defer ctx.Client.Close()
 
-			return runXClusterConsumerCheck(cmd, ctx.Log, ctx.Client)
+			return runXClusterConsumerCheck(ctx, cmd, ctx.Log, ctx.Client)
 		},
 	}
 
 	return cmd
 }
 
-func runXClusterConsumerCheck(cmd *cobra.Command, log logr.Logger, consumerClient *client.YBClient) error {
-	clusterConfig, err := consumerClient.Master.MasterService.GetMasterClusterConfig(&master.GetMasterClusterConfigRequestPB{})
+func runXClusterConsumerCheck(ctx context.Context, cmd *cobra.Command, log logr.Logger, consumerClient *client.YBClient) error {
+	clusterConfig, err := consumerClient.Master.MasterService.GetMasterClusterConfigWithContext(ctx, &master.GetMasterClusterConfigRequestPB{})
 	if err != nil {
 		return err
 	}
@@ -61,14 +62,16 @@ func runXClusterConsumerCheck(cmd *cobra.Command, log logr.Logger, consumerClien
 	// Connect to each producer and generate a report
 	for producerID, producer := range clusterConfig.GetClusterConfig().GetConsumerRegistry().GetProducerMap() {
 		consumerUniverseConfig := &config.UniverseConfigPB{
-			Masters:        producer.GetMasterAddrs(),
-			TimeoutSeconds: consumerClient.Config.TimeoutSeconds,
-			TlsOpts:        consumerClient.Config.TlsOpts,
+			Masters:           producer.GetMasterAddrs(),
+			TimeoutSeconds:    consumerClient.Config.TimeoutSeconds,
+			RpcTimeoutSeconds: consumerClient.Config.RpcTimeoutSeconds,
+			TlsOpts:           consumerClient.Config.TlsOpts,
+			AddressPolicy:     consumerClient.Config.AddressPolicy,
 		}
 
 		producerReport := healthcheck.NewCDCProducerReport(log, consumerClient, consumerUniverseConfig, clusterConfig.GetClusterConfig().GetClusterUuid(), producerID, producer)
 
-		err := producerReport.RunCheck()
+		err := producerReport.RunCheck(ctx)
 		if err != nil {
 			return err
 		}
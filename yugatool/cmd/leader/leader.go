@@ -0,0 +1,55 @@
+/*
+Copyright © 2021 Yugabyte Support
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package leader
+
+import (
+	"github.com/yugabyte/yb-tools/pkg/format"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/balance"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/cmdutil"
+)
+
+const (
+	ResultPending = "pending"
+	ResultMoved   = "moved"
+	ResultFailed  = "failed"
+	ResultSkipped = "skipped"
+)
+
+// LeaderMoveResult is a leader move and how it went
+type LeaderMoveResult struct {
+	balance.LeaderMove
+	Result string `json:"result"`
+	Error  string `json:"error,omitempty"`
+}
+
+func printMoves(ctx *cmdutil.YugatoolContext, message string, moves []*LeaderMoveResult) error {
+	moveReport := format.Output{
+		OutputMessage: message,
+		JSONObject:    moves,
+		OutputType:    ctx.GlobalOptions.Output,
+		TableColumns: []format.Column{
+			{Name: "TABLE", JSONPath: "$.table"},
+			{Name: "TABLET", JSONPath: "$.tablet"},
+			{Name: "FROM", JSONPath: "$.from"},
+			{Name: "TO", JSONPath: "$.to"},
+			{Name: "REASON", JSONPath: "$.reason"},
+			{Name: "RESULT", JSONPath: "$.result"},
+			{Name: "ERROR", JSONPath: "$.error"},
+		},
+	}
+	return moveReport.Println()
+}
@@ -0,0 +1,169 @@
+/*
+Copyright © 2021 Yugabyte Support
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package leader
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/yugabyte/yb-tools/pkg/util"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/balance"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/cmdutil"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/snapshot"
+)
+
+func RebalanceCmd(ctx *cmdutil.YugatoolContext) *cobra.Command {
+	options := &RebalanceOptions{}
+	cmd := &cobra.Command{
+		Use:   "rebalance",
+		Short: "Spread the tablet leaders of each table evenly",
+		Long: `Plan the fewest leader moves that spread the tablet leaders of each table evenly across the tablet
+servers that may lead them: those in the preferred leader zones set by SetPreferredZones or the placement
+policy of the table, and not on the leader blacklist. Leaders outside the preferred zones are moved first.
+
+The moves are listed and confirmed before they are made, one at a time, waiting --interval seconds
+between them. Each move is verified by waiting for the new leader to take over.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := ctx.WithCmd(cmd).WithOptions(options).Setup()
+			if err != nil {
+				return err
+			}
+			defer ctx.Client.Close()
+
+			return rebalance(ctx, options)
+		},
+	}
+	options.AddFlags(cmd)
+
+	return cmd
+}
+
+type RebalanceOptions struct {
+	MaxMoves      int   `mapstructure:"max_moves"`
+	Interval      int64 `mapstructure:"interval"`
+	VerifyTimeout int64 `mapstructure:"verify_timeout"`
+	Concurrency   int   `mapstructure:"concurrency"`
+	DryRun        bool  `mapstructure:"dry_run"`
+	Approve       bool  `mapstructure:"approve"`
+}
+
+var _ cmdutil.CommandOptions = &RebalanceOptions{}
+
+func (o *RebalanceOptions) AddFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.IntVar(&o.MaxMoves, "max-moves", 0, "maximum number of leader moves to make, or 0 for all of them")
+	flags.Int64Var(&o.Interval, "interval", 1, "number of seconds to wait between leader moves")
+	flags.Int64Var(&o.VerifyTimeout, "verify-timeout", 10, "number of seconds to wait for each new leader to take over")
+	flags.IntVar(&o.Concurrency, "concurrency", 64, "maximum number of concurrent requests to each tablet server")
+	flags.BoolVar(&o.DryRun, "dry-run", false, "list the leader moves without making them")
+	flags.BoolVar(&o.Approve, "approve", false, "make the leader moves without prompting")
+}
+
+func (o *RebalanceOptions) Validate() error {
+	if o.MaxMoves < 0 {
+		return errors.New("max-moves must not be negative")
+	}
+	if o.Interval < 0 {
+		return errors.New("interval must not be negative")
+	}
+	if o.VerifyTimeout < 1 {
+		return errors.New("verify-timeout must be at least 1 second")
+	}
+	if o.Concurrency < 1 {
+		return errors.New("concurrency must be at least 1")
+	}
+	return nil
+}
+
+func rebalance(ctx *cmdutil.YugatoolContext, options *RebalanceOptions) error {
+	state, err := snapshot.Collect(ctx, ctx.Log, ctx.Client, snapshot.CollectOptions{Concurrency: options.Concurrency})
+	if err != nil {
+		return err
+	}
+
+	planned := balance.PlanLeaderMoves(state)
+	if options.MaxMoves > 0 && len(planned) > options.MaxMoves {
+		planned = planned[:options.MaxMoves]
+	}
+
+	moves := []*LeaderMoveResult{}
+	for _, move := range planned {
+		moves = append(moves, &LeaderMoveResult{LeaderMove: *move, Result: ResultPending})
+	}
+
+	if options.DryRun || len(moves) == 0 {
+		return printMoves(ctx, "Leader Moves", moves)
+	}
+
+	if !options.Approve {
+		err := printMoves(ctx, "Leader Moves", moves)
+		if err != nil {
+			return err
+		}
+
+		err = util.ConfirmationDialog()
+		if err != nil {
+			return err
+		}
+	}
+
+	failed := 0
+	for i, move := range moves {
+		if i > 0 && options.Interval > 0 {
+			select {
+			case <-ctx.Done():
+			case <-time.After(time.Duration(options.Interval) * time.Second):
+			}
+		}
+
+		// Once interrupted, the moves not yet made are reported as skipped
+		if ctx.Err() != nil {
+			for _, skipped := range moves[i:] {
+				skipped.Result = ResultSkipped
+			}
+			break
+		}
+
+		err := balance.StepDown(ctx, ctx.Client, move.Tablet, move.FromUUID, move.ToUUID)
+		if err == nil {
+			_, err = balance.WaitForLeader(ctx, ctx.Client, move.Tablet, move.ToUUID, move.FromUUID, move.ToUUID, time.Duration(options.VerifyTimeout)*time.Second)
+		}
+		if err != nil {
+			failed++
+			move.Result = ResultFailed
+			move.Error = err.Error()
+			ctx.Log.Info("leader move failed", "tablet", move.Tablet, "from", move.From, "to", move.To, "error", err.Error())
+			continue
+		}
+		move.Result = ResultMoved
+		ctx.Log.Info("moved leader", "tablet", move.Tablet, "from", move.From, "to", move.To, "move", i+1, "of", len(moves))
+	}
+
+	err = printMoves(ctx, "Leader Moves", moves)
+	if err != nil {
+		return err
+	}
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if failed > 0 {
+		return errors.Errorf("%d of %d leader moves failed", failed, len(moves))
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+/*
+Copyright © 2021 Yugabyte Support
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package leader
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/common"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/master"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/balance"
+	yberrors "github.com/yugabyte/yb-tools/yugatool/pkg/client/errors"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/cmdutil"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/util"
+)
+
+func StepdownCmd(ctx *cmdutil.YugatoolContext) *cobra.Command {
+	options := &StepdownOptions{}
+	cmd := &cobra.Command{
+		Use:   "stepdown TABLET_UUID",
+		Short: "Step down the leader of a tablet",
+		Long: `Ask the leader of a tablet to step down, in favour of the replica on the tablet server given by --to
+as a UUID, host:port or host, or of a replica of its own choosing. A tablet without a leader has the
+replica given by --to run an election instead. The command waits for the new leader to take over.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := ctx.WithCmd(cmd).WithOptions(options).Setup()
+			if err != nil {
+				return err
+			}
+			defer ctx.Client.Close()
+
+			return stepdown(ctx, options, args[0])
+		},
+	}
+	options.AddFlags(cmd)
+
+	return cmd
+}
+
+type StepdownOptions struct {
+	To            string `mapstructure:"to"`
+	VerifyTimeout int64  `mapstructure:"verify_timeout"`
+}
+
+var _ cmdutil.CommandOptions = &StepdownOptions{}
+
+func (o *StepdownOptions) AddFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.StringVar(&o.To, "to", "", "the tablet server whose replica should become the leader")
+	flags.Int64Var(&o.VerifyTimeout, "verify-timeout", 10, "number of seconds to wait for the new leader to take over")
+}
+
+func (o *StepdownOptions) Validate() error {
+	if o.VerifyTimeout < 1 {
+		return errors.New("verify-timeout must be at least 1 second")
+	}
+	return nil
+}
+
+func stepdown(ctx *cmdutil.YugatoolContext, options *StepdownOptions, tablet string) error {
+	locations, err := ctx.Client.Master.MasterService.GetTabletLocationsWithContext(ctx, &master.GetTabletLocationsRequestPB{
+		TabletIds: [][]byte{[]byte(tablet)},
+	})
+	if err != nil {
+		return err
+	}
+	if err := yberrors.FromResponse(locations); err != nil {
+		return errors.Wrap(err, "could not get tablet locations")
+	}
+	if tabletErrors := locations.GetErrors(); len(tabletErrors) > 0 {
+		return errors.Wrapf(yberrors.FromStatus(tabletErrors[0].GetStatus()), "could not get the locations of tablet %s", tablet)
+	}
+	if len(locations.GetTabletLocations()) == 0 {
+		return errors.Errorf("tablet %s not found", tablet)
+	}
+
+	var leader, to *master.TSInfoPB
+	for _, replica := range locations.GetTabletLocations()[0].GetReplicas() {
+		if replica.GetRole() == common.RaftPeerPB_LEADER {
+			leader = replica.GetTsInfo()
+		}
+		if options.To != "" && matchesReplica(replica.GetTsInfo(), options.To) {
+			to = replica.GetTsInfo()
+		}
+	}
+	if options.To != "" && to == nil {
+		return errors.Errorf("tablet %s has no replica on %s", tablet, options.To)
+	}
+
+	move := &LeaderMoveResult{
+		LeaderMove: balance.LeaderMove{
+			TableID: string(locations.GetTabletLocations()[0].GetTableId()),
+			Tablet:  tablet,
+		},
+		Result: ResultPending,
+	}
+	var oldLeader, newLeader string
+	if to != nil {
+		newLeader = string(to.GetPermanentUuid())
+	}
+
+	switch {
+	case leader == nil && to == nil:
+		return errors.Errorf("tablet %s has no leader to step down, give --to to elect one", tablet)
+	case leader == nil:
+		ctx.Log.Info("tablet has no leader, running an election", "tablet", tablet, "tserver", replicaAddress(to))
+		err = balance.Elect(ctx, ctx.Client, tablet, newLeader)
+	case to != nil && string(leader.GetPermanentUuid()) == newLeader:
+		return errors.Errorf("%s already leads tablet %s", options.To, tablet)
+	default:
+		oldLeader = string(leader.GetPermanentUuid())
+		move.From, move.FromUUID = replicaAddress(leader), oldLeader
+		err = balance.StepDown(ctx, ctx.Client, tablet, oldLeader, newLeader)
+	}
+
+	if err == nil {
+		// The new leader is read from the replica asked to lead, or from the
+		// old leader when it chose the replica itself
+		replica := newLeader
+		if replica == "" {
+			replica = oldLeader
+		}
+		newLeader, err = balance.WaitForLeader(ctx, ctx.Client, tablet, replica, oldLeader, newLeader, time.Duration(options.VerifyTimeout)*time.Second)
+	}
+
+	if err != nil {
+		move.Result = ResultFailed
+		move.Error = err.Error()
+	} else {
+		move.Result = ResultMoved
+		move.ToUUID = newLeader
+		move.To = newLeader
+		for _, replica := range locations.GetTabletLocations()[0].GetReplicas() {
+			if string(replica.GetTsInfo().GetPermanentUuid()) == newLeader {
+				move.To = replicaAddress(replica.GetTsInfo())
+			}
+		}
+	}
+
+	printErr := printMoves(ctx, "Leader Stepdown", []*LeaderMoveResult{move})
+	if printErr != nil {
+		return printErr
+	}
+	return err
+}
+
+func replicaAddress(ts *master.TSInfoPB) string {
+	if addresses := ts.GetPrivateRpcAddresses(); len(addresses) > 0 {
+		return util.HostPortString(addresses[0])
+	}
+	return string(ts.GetPermanentUuid())
+}
+
+// matchesReplica returns whether the tablet server is named by its UUID,
+// host:port or host
+func matchesReplica(ts *master.TSInfoPB, name string) bool {
+	if string(ts.GetPermanentUuid()) == name {
+		return true
+	}
+	for _, address := range append(append([]*common.HostPortPB{}, ts.GetPrivateRpcAddresses()...), ts.GetBroadcastAddresses()...) {
+		if util.HostPortString(address) == name || address.GetHost() == name {
+			return true
+		}
+	}
+	return false
+}
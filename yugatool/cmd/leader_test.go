@@ -0,0 +1,194 @@
+package cmd_test
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/blang/vfs/memfs"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/yugabyte/yb-tools/yugatool/cmd/leader"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/rpcserver"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/test/fakecluster"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/util"
+)
+
+var _ = Describe("leader", func() {
+	var (
+		cluster *fakecluster.Cluster
+		table   *fakecluster.Table
+	)
+
+	BeforeEach(func() {
+		cluster = fakecluster.New(logr.Discard(), rpcserver.NewNetwork(), "leader", 1, 3)
+		table = cluster.AddTable("yugabyte", "test_table", 6)
+	})
+
+	leaderOf := func(tablet *fakecluster.Tablet) *fakecluster.Node {
+		cluster.Lock()
+		defer cluster.Unlock()
+		return tablet.Leader
+	}
+
+	Context("stepdown", func() {
+		It("moves the leader to the given tablet server", func() {
+			tablet := table.Tablets[0]
+			to := cluster.TabletServers[2]
+
+			out, err := runYugatool(cluster, "leader", "stepdown", tablet.ID, "--to", util.HostPortString(to.Address), "-o", "json")
+			Expect(err).NotTo(HaveOccurred(), out.String())
+			Expect(leaderOf(tablet)).To(Equal(to))
+
+			var moves []leader.LeaderMoveResult
+			decodeReport(out, "Leader Stepdown", &moves)
+			Expect(moves).To(HaveLen(1))
+			Expect(moves[0].From).To(Equal(util.HostPortString(cluster.TabletServers[0].Address)))
+			Expect(moves[0].To).To(Equal(util.HostPortString(to.Address)))
+			Expect(moves[0].Result).To(Equal(leader.ResultMoved))
+		})
+
+		It("lets the leader choose its successor", func() {
+			tablet := table.Tablets[0]
+
+			out, err := runYugatool(cluster, "leader", "stepdown", tablet.ID)
+			Expect(err).NotTo(HaveOccurred(), out.String())
+			Expect(leaderOf(tablet)).NotTo(Equal(cluster.TabletServers[0]))
+		})
+
+		It("elects the given replica of a tablet without a leader", func() {
+			tablet := table.Tablets[0]
+			cluster.Lock()
+			tablet.Leader = nil
+			cluster.Unlock()
+
+			_, err := runYugatool(cluster, "leader", "stepdown", tablet.ID)
+			Expect(err).To(MatchError(ContainSubstring("has no leader to step down")))
+
+			out, err := runYugatool(cluster, "leader", "stepdown", tablet.ID, "--to", cluster.TabletServers[1].UUID)
+			Expect(err).NotTo(HaveOccurred(), out.String())
+			Expect(leaderOf(tablet)).To(Equal(cluster.TabletServers[1]))
+		})
+
+		It("fails for a tablet server without a replica", func() {
+			_, err := runYugatool(cluster, "leader", "stepdown", table.Tablets[0].ID, "--to", "nowhere")
+			Expect(err).To(MatchError(ContainSubstring("has no replica on nowhere")))
+		})
+
+		It("fails when the new leader does not take over", func() {
+			tablet := table.Tablets[0]
+			cluster.Lock()
+			tablet.LeaderPinned = true
+			cluster.Unlock()
+
+			out, err := runYugatool(cluster, "leader", "stepdown", tablet.ID, "--to", cluster.TabletServers[1].UUID, "--verify-timeout", "1", "-o", "json")
+			Expect(err).To(MatchError(ContainSubstring("is still led by")))
+
+			var moves []leader.LeaderMoveResult
+			decodeReport(out, "Leader Stepdown", &moves)
+			Expect(moves[0].Result).To(Equal(leader.ResultFailed))
+		})
+	})
+
+	Context("rebalance", func() {
+		BeforeEach(func() {
+			cluster.Lock()
+			for _, tablet := range table.Tablets {
+				tablet.Leader = cluster.TabletServers[0]
+			}
+			cluster.Unlock()
+		})
+
+		leaderCounts := func() map[string]int {
+			counts := make(map[string]int)
+			for _, tablet := range table.Tablets {
+				counts[leaderOf(tablet).UUID]++
+			}
+			return counts
+		}
+
+		It("lists the moves on a dry run", func() {
+			out, err := runYugatool(cluster, "leader", "rebalance", "--dry-run", "-o", "json")
+			Expect(err).NotTo(HaveOccurred(), out.String())
+
+			var moves []leader.LeaderMoveResult
+			decodeReport(out, "Leader Moves", &moves)
+			Expect(moves).To(HaveLen(4))
+			for _, move := range moves {
+				Expect(move.Result).To(Equal(leader.ResultPending))
+			}
+			Expect(leaderCounts()).To(Equal(map[string]int{cluster.TabletServers[0].UUID: 6}))
+		})
+
+		It("spreads the leaders evenly", func() {
+			out, err := runYugatool(cluster, "leader", "rebalance", "--approve", "--interval", "0", "-o", "json")
+			Expect(err).NotTo(HaveOccurred(), out.String())
+
+			Expect(leaderCounts()).To(Equal(map[string]int{
+				cluster.TabletServers[0].UUID: 2,
+				cluster.TabletServers[1].UUID: 2,
+				cluster.TabletServers[2].UUID: 2,
+			}))
+
+			var moves []leader.LeaderMoveResult
+			decodeReport(out, "Leader Moves", &moves)
+			Expect(moves).To(HaveLen(4))
+			for _, move := range moves {
+				Expect(move.Result).To(Equal(leader.ResultMoved))
+			}
+		})
+
+		It("makes at most the given number of moves", func() {
+			out, err := runYugatool(cluster, "leader", "rebalance", "--approve", "--interval", "0", "--max-moves", "1")
+			Expect(err).NotTo(HaveOccurred(), out.String())
+			Expect(leaderCounts()[cluster.TabletServers[0].UUID]).To(Equal(5))
+		})
+
+		It("reports the moves made and skips the rest when interrupted", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			done := make(chan struct{})
+			var out *bytes.Buffer
+			var err error
+			go func() {
+				defer GinkgoRecover()
+				defer close(done)
+				out, err = runYugatoolWithContext(ctx, memfs.Create(), cluster, cluster.MasterAddresses(), "leader", "rebalance", "--approve", "--interval", "60", "-o", "json")
+			}()
+
+			// Interrupt the wait after the first move
+			Eventually(func() int {
+				return leaderCounts()[cluster.TabletServers[0].UUID]
+			}, 5*time.Second).Should(Equal(5))
+			cancel()
+			Eventually(done, 5*time.Second).Should(BeClosed())
+			Expect(err).To(MatchError(context.Canceled))
+
+			var moves []leader.LeaderMoveResult
+			decodeReport(out, "Leader Moves", &moves)
+			Expect(moves).To(HaveLen(4))
+			Expect(moves[0].Result).To(Equal(leader.ResultMoved))
+			for _, move := range moves[1:] {
+				Expect(move.Result).To(Equal(leader.ResultSkipped))
+			}
+		})
+
+		It("reports the moves that fail", func() {
+			cluster.Lock()
+			for _, tablet := range table.Tablets {
+				tablet.LeaderPinned = true
+			}
+			cluster.Unlock()
+
+			out, err := runYugatool(cluster, "leader", "rebalance", "--approve", "--interval", "0", "--max-moves", "1", "--verify-timeout", "1", "-o", "json")
+			Expect(err).To(MatchError("1 of 1 leader moves failed"))
+
+			var moves []leader.LeaderMoveResult
+			decodeReport(out, "Leader Moves", &moves)
+			Expect(moves[0].Result).To(Equal(leader.ResultFailed))
+			Expect(moves[0].Error).To(ContainSubstring("is still led by"))
+		})
+	})
+})
@@ -0,0 +1,306 @@
+/*
+Copyright © 2021 Yugabyte Support
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/yugabyte/yb-tools/pkg/format"
+	"github.com/yugabyte/yb-tools/pkg/util"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/master"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/balance"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client"
+	yberrors "github.com/yugabyte/yb-tools/yugatool/pkg/client/errors"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/cmdutil"
+	"google.golang.org/protobuf/proto"
+)
+
+// Moves reported while draining
+const (
+	MovesData    = "data"
+	MovesLeaders = "leaders"
+)
+
+func DrainCmd(ctx *cmdutil.YugatoolContext) *cobra.Command {
+	options := &DrainOptions{}
+	cmd := &cobra.Command{
+		Use:   "drain TSERVER",
+		Short: "Move the tablets of a tablet server off it before it is decommissioned",
+		Long: `Add a tablet server, given by UUID, host:port or host, to the server blacklist of the cluster config,
+so the load balancer moves its tablet replicas to the other tablet servers. With --leader-blacklist it
+is added to the leader blacklist as well, so its tablet leaders are moved first.
+
+Before the blacklist is changed, the live tablet servers left are checked against the placement policy
+of the universe. The command then waits for the moves to complete, logging their progress.
+"yugatool node undrain" removes the tablet server from the blacklists again.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := ctx.WithCmd(cmd).WithOptions(options).Setup()
+			if err != nil {
+				return err
+			}
+			defer ctx.Client.Close()
+
+			return drain(ctx, options, args[0])
+		},
+	}
+	options.AddFlags(cmd)
+
+	return cmd
+}
+
+type DrainOptions struct {
+	LeaderBlacklist bool  `mapstructure:"leader_blacklist"`
+	Wait            bool  `mapstructure:"wait"`
+	PollInterval    int64 `mapstructure:"poll_interval"`
+	Timeout         int64 `mapstructure:"timeout"`
+	Force           bool  `mapstructure:"force"`
+	DryRun          bool  `mapstructure:"dry_run"`
+	Approve         bool  `mapstructure:"approve"`
+}
+
+var _ cmdutil.CommandOptions = &DrainOptions{}
+
+func (o *DrainOptions) AddFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.BoolVar(&o.LeaderBlacklist, "leader-blacklist", false, "also add the tablet server to the leader blacklist")
+	flags.BoolVar(&o.Wait, "wait", true, "wait for the data and leaders to move off the tablet server")
+	flags.Int64Var(&o.PollInterval, "poll-interval", 5, "number of seconds between checks of the move progress")
+	flags.Int64Var(&o.Timeout, "timeout", 0, "number of seconds to wait for the moves to complete, or 0 to wait until they do")
+	flags.BoolVar(&o.Force, "force", false, "drain even if the remaining tablet servers cannot satisfy the placement policy")
+	flags.BoolVar(&o.DryRun, "dry-run", false, "check the drain without changing the blacklists")
+	flags.BoolVar(&o.Approve, "approve", false, "change the blacklists without prompting")
+}
+
+func (o *DrainOptions) Validate() error {
+	if o.PollInterval < 1 {
+		return errors.New("poll-interval must be at least 1 second")
+	}
+	if o.Timeout < 0 {
+		return errors.New("timeout must not be negative")
+	}
+	return nil
+}
+
+// MoveProgress is how far the load balancer has moved the data or leaders off
+// the blacklisted tablet servers
+type MoveProgress struct {
+	Moves     string  `json:"moves"`
+	Percent   float64 `json:"percent"`
+	Remaining uint64  `json:"remaining"`
+	Total     uint64  `json:"total"`
+}
+
+func drain(ctx *cmdutil.YugatoolContext, options *DrainOptions, name string) error {
+	ts, servers, err := findTabletServer(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	uuid := string(ts.GetInstanceId().GetPermanentUuid())
+	config, err := ctx.Client.GetClusterConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	replicationFactor, err := ctx.Client.ReplicationFactor(ctx, config)
+	if err != nil {
+		return err
+	}
+
+	if problems := balance.CheckDrain(config, servers, uuid, replicationFactor); len(problems) > 0 {
+		if !options.Force {
+			return errors.Errorf("the remaining tablet servers cannot satisfy the placement policy, use --force to drain anyway: %s",
+				strings.Join(problems, "; "))
+		}
+		ctx.Log.Info("draining although the remaining tablet servers cannot satisfy the placement policy", "problems", problems)
+	}
+
+	blacklist := func(config *master.SysClusterConfigEntryPB) error {
+		// The config is checked again each time it is read, as another tablet
+		// server may have been blacklisted since
+		if problems := balance.CheckDrain(config, servers, uuid, replicationFactor); len(problems) > 0 && !options.Force {
+			return errors.Errorf("the remaining tablet servers cannot satisfy the placement policy, use --force to drain anyway: %s",
+				strings.Join(problems, "; "))
+		}
+
+		changed := addToBlacklist(&config.ServerBlacklist, ts)
+		if options.LeaderBlacklist {
+			changed = addToBlacklist(&config.LeaderBlacklist, ts) || changed
+		}
+		if !changed {
+			return client.ErrClusterConfigUnchanged
+		}
+		return nil
+	}
+
+	// The blacklists are shown as they will be, before they are changed
+	planned := proto.Clone(config).(*master.SysClusterConfigEntryPB)
+	err = blacklist(planned)
+	if err == client.ErrClusterConfigUnchanged {
+		ctx.Log.Info("the tablet server is already blacklisted", "tserver", client.TabletServerAddress(ts))
+	} else if options.DryRun {
+		return printBlacklistStatus(ctx, "Node Drain", blacklistStatus(ts, planned))
+	} else if !options.Approve {
+		err := printBlacklistStatus(ctx, "Node Drain", blacklistStatus(ts, planned))
+		if err != nil {
+			return err
+		}
+
+		err = util.ConfirmationDialog()
+		if err != nil {
+			return err
+		}
+	}
+
+	if !options.DryRun {
+		config, err = ctx.Client.UpdateClusterConfig(ctx, blacklist)
+		if err != nil {
+			return err
+		}
+	}
+
+	status := blacklistStatus(ts, config)
+	if options.DryRun || !options.Wait {
+		return printBlacklistStatus(ctx, "Node Drain", status)
+	}
+
+	progress, err := waitForMoves(ctx, options, status.LeaderBlacklisted)
+
+	// The blacklists are reported whether or not the moves completed
+	printErr := printBlacklistStatus(ctx, "Node Drain", status)
+	if printErr != nil {
+		return printErr
+	}
+	if progress != nil {
+		printErr = printProgress(ctx, progress)
+		if printErr != nil {
+			return printErr
+		}
+	}
+	return err
+}
+
+// addToBlacklist adds the tablet server to the blacklist, unless it is on it
+// already
+func addToBlacklist(blacklist **master.BlacklistPB, ts *master.ListTabletServersResponsePB_Entry) bool {
+	if balance.Blacklisted(*blacklist, ts) {
+		return false
+	}
+	if *blacklist == nil {
+		*blacklist = &master.BlacklistPB{}
+	}
+	(*blacklist).Hosts = append((*blacklist).Hosts, blacklistHost(ts))
+	return true
+}
+
+// waitForMoves polls the progress of the moves off the blacklisted tablet
+// servers until they complete or the timeout passes
+func waitForMoves(ctx *cmdutil.YugatoolContext, options *DrainOptions, leaders bool) ([]MoveProgress, error) {
+	var waitCtx context.Context = ctx
+	if options.Timeout > 0 {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(ctx, time.Duration(options.Timeout)*time.Second)
+		defer cancel()
+	}
+
+	var progress []MoveProgress
+	for {
+		current, err := moveProgress(waitCtx, ctx.Client, leaders)
+		if err != nil {
+			// The progress last seen is kept when the timeout passes mid-call
+			if waitCtx.Err() == context.DeadlineExceeded {
+				return progress, errors.Errorf("moves did not complete within %d seconds", options.Timeout)
+			}
+			return progress, err
+		}
+		progress = current
+
+		done := true
+		for _, p := range progress {
+			ctx.Log.Info("moving off the blacklisted tablet servers", "moves", p.Moves,
+				"complete", fmt.Sprintf("%.1f%%", p.Percent), "remaining", p.Remaining, "total", p.Total)
+			if p.Remaining > 0 {
+				done = false
+			}
+		}
+		if done {
+			return progress, nil
+		}
+
+		select {
+		case <-waitCtx.Done():
+			if waitCtx.Err() == context.DeadlineExceeded {
+				return progress, errors.Errorf("moves did not complete within %d seconds", options.Timeout)
+			}
+			return progress, waitCtx.Err()
+		case <-time.After(time.Duration(options.PollInterval) * time.Second):
+		}
+	}
+}
+
+func moveProgress(ctx context.Context, c *client.YBClient, leaders bool) ([]MoveProgress, error) {
+	progress := func(moves string, response *master.GetLoadMovePercentResponsePB) MoveProgress {
+		return MoveProgress{
+			Moves:     moves,
+			Percent:   response.GetPercent(),
+			Remaining: response.GetRemaining(),
+			Total:     response.GetTotal(),
+		}
+	}
+
+	data, err := c.Master.MasterService.GetLoadMoveCompletionWithContext(ctx, &master.GetLoadMovePercentRequestPB{})
+	if err != nil {
+		return nil, err
+	}
+	if err := yberrors.FromResponse(data); err != nil {
+		return nil, errors.Wrap(err, "could not get load move completion")
+	}
+	moves := []MoveProgress{progress(MovesData, data)}
+
+	if leaders {
+		leaderMoves, err := c.Master.MasterService.GetLeaderBlacklistCompletionWithContext(ctx, &master.GetLeaderBlacklistPercentRequestPB{})
+		if err != nil {
+			return nil, err
+		}
+		if err := yberrors.FromResponse(leaderMoves); err != nil {
+			return nil, errors.Wrap(err, "could not get leader blacklist completion")
+		}
+		moves = append(moves, progress(MovesLeaders, leaderMoves))
+	}
+	return moves, nil
+}
+
+func printProgress(ctx *cmdutil.YugatoolContext, progress []MoveProgress) error {
+	progressReport := format.Output{
+		OutputMessage: "Drain Progress",
+		JSONObject:    progress,
+		OutputType:    ctx.GlobalOptions.Output,
+		TableColumns: []format.Column{
+			{Name: "MOVES", JSONPath: "$.moves"},
+			{Name: "PERCENT", JSONPath: "$.percent"},
+			{Name: "REMAINING", JSONPath: "$.remaining"},
+			{Name: "TOTAL", JSONPath: "$.total"},
+		},
+	}
+	return progressReport.Println()
+}
@@ -0,0 +1,82 @@
+/*
+Copyright © 2021 Yugabyte Support
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"github.com/pkg/errors"
+	"github.com/yugabyte/yb-tools/pkg/format"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/common"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/master"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/balance"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/cmdutil"
+)
+
+// BlacklistStatus is whether a tablet server is on the server and leader
+// blacklists of the cluster config
+type BlacklistStatus struct {
+	TabletServer      string `json:"tserver"`
+	UUID              string `json:"uuid"`
+	ServerBlacklisted bool   `json:"server_blacklisted"`
+	LeaderBlacklisted bool   `json:"leader_blacklisted"`
+	ConfigVersion     int32  `json:"config_version"`
+}
+
+func blacklistStatus(ts *master.ListTabletServersResponsePB_Entry, config *master.SysClusterConfigEntryPB) *BlacklistStatus {
+	return &BlacklistStatus{
+		TabletServer:      client.TabletServerAddress(ts),
+		UUID:              string(ts.GetInstanceId().GetPermanentUuid()),
+		ServerBlacklisted: balance.Blacklisted(config.GetServerBlacklist(), ts),
+		LeaderBlacklisted: balance.Blacklisted(config.GetLeaderBlacklist(), ts),
+		ConfigVersion:     config.GetVersion(),
+	}
+}
+
+func printBlacklistStatus(ctx *cmdutil.YugatoolContext, message string, status *BlacklistStatus) error {
+	statusReport := format.Output{
+		OutputMessage: message,
+		JSONObject:    status,
+		OutputType:    ctx.GlobalOptions.Output,
+		TableColumns: []format.Column{
+			{Name: "TSERVER", JSONPath: "$.tserver"},
+			{Name: "UUID", JSONPath: "$.uuid"},
+			{Name: "SERVER_BLACKLISTED", JSONPath: "$.server_blacklisted"},
+			{Name: "LEADER_BLACKLISTED", JSONPath: "$.leader_blacklisted"},
+			{Name: "CONFIG_VERSION", JSONPath: "$.config_version"},
+		},
+	}
+	return statusReport.Println()
+}
+
+// blacklistHost is the address a tablet server is blacklisted by
+func blacklistHost(ts *master.ListTabletServersResponsePB_Entry) *common.HostPortPB {
+	return ts.GetRegistration().GetCommon().GetPrivateRpcAddresses()[0]
+}
+
+// findTabletServer returns the tablet server named by its UUID, host:port or
+// host, which must have an address to blacklist, and every tablet server
+// registered with the master leader
+func findTabletServer(ctx *cmdutil.YugatoolContext, name string) (*master.ListTabletServersResponsePB_Entry, []*master.ListTabletServersResponsePB_Entry, error) {
+	ts, servers, err := ctx.Client.FindTabletServer(ctx, name)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(ts.GetRegistration().GetCommon().GetPrivateRpcAddresses()) == 0 {
+		return nil, nil, errors.Errorf("tablet server %s has no registered address to blacklist", name)
+	}
+	return ts, servers, nil
+}
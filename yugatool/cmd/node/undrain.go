@@ -0,0 +1,95 @@
+/*
+Copyright © 2021 Yugabyte Support
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/common"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/master"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/balance"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/cmdutil"
+)
+
+func UndrainCmd(ctx *cmdutil.YugatoolContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "undrain TSERVER",
+		Short: "Remove a tablet server from the blacklists",
+		Long: `Remove a tablet server, given by UUID, host:port or host, from the server and leader blacklists of the
+cluster config, so the load balancer moves tablet replicas and leaders back onto it.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := ctx.WithCmd(cmd).Setup()
+			if err != nil {
+				return err
+			}
+			defer ctx.Client.Close()
+
+			return undrain(ctx, args[0])
+		},
+	}
+
+	return cmd
+}
+
+func undrain(ctx *cmdutil.YugatoolContext, name string) error {
+	ts, _, err := findTabletServer(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	config, err := ctx.Client.UpdateClusterConfig(ctx, func(config *master.SysClusterConfigEntryPB) error {
+		changed := removeFromBlacklist(config.ServerBlacklist, ts)
+		changed = removeFromBlacklist(config.LeaderBlacklist, ts) || changed
+		if !changed {
+			return client.ErrClusterConfigUnchanged
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return printBlacklistStatus(ctx, "Node Undrain", blacklistStatus(ts, config))
+}
+
+// removeFromBlacklist removes every host of the blacklist that names the tablet
+// server
+func removeFromBlacklist(blacklist *master.BlacklistPB, ts *master.ListTabletServersResponsePB_Entry) bool {
+	blacklisted := balance.BlacklistedHosts(blacklist, ts)
+	if len(blacklisted) == 0 {
+		return false
+	}
+
+	var hosts []*common.HostPortPB
+	for _, host := range blacklist.GetHosts() {
+		if !containsHost(blacklisted, host) {
+			hosts = append(hosts, host)
+		}
+	}
+	blacklist.Hosts = hosts
+	return true
+}
+
+func containsHost(hosts []*common.HostPortPB, host *common.HostPortPB) bool {
+	for _, h := range hosts {
+		if h == host {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,181 @@
+package cmd_test
+
+import (
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/common"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/master"
+	"github.com/yugabyte/yb-tools/yugatool/cmd/node"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/rpcserver"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/test/fakecluster"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/util"
+)
+
+var _ = Describe("node drain", func() {
+	var (
+		cluster *fakecluster.Cluster
+		drained *fakecluster.Node
+	)
+
+	BeforeEach(func() {
+		cluster = fakecluster.New(logr.Discard(), rpcserver.NewNetwork(), "drain", 1, 4)
+		cluster.AddTable("yugabyte", "test_table", 3)
+		drained = cluster.TabletServers[3]
+	})
+
+	blacklisted := func(blacklist func(*master.SysClusterConfigEntryPB) *master.BlacklistPB) []string {
+		cluster.Lock()
+		defer cluster.Unlock()
+
+		var hosts []string
+		for _, host := range blacklist(cluster.ClusterConfig).GetHosts() {
+			hosts = append(hosts, util.HostPortString(host))
+		}
+		return hosts
+	}
+	serverBlacklist := func() []string {
+		return blacklisted((*master.SysClusterConfigEntryPB).GetServerBlacklist)
+	}
+	leaderBlacklist := func() []string {
+		return blacklisted((*master.SysClusterConfigEntryPB).GetLeaderBlacklist)
+	}
+
+	It("blacklists the tablet server and waits for its data to move", func() {
+		cluster.LoadBalancer.MovesRemaining = 2
+		cluster.LoadBalancer.MovesTotal = 2
+		cluster.LoadBalancer.MovesPerCall = 1
+
+		out, err := runYugatool(cluster, "node", "drain", util.HostPortString(drained.Address), "--approve", "--poll-interval", "1", "-o", "json")
+		Expect(err).NotTo(HaveOccurred(), out.String())
+
+		Expect(serverBlacklist()).To(Equal([]string{util.HostPortString(drained.Address)}))
+		Expect(leaderBlacklist()).To(BeEmpty())
+
+		var status node.BlacklistStatus
+		decodeReport(out, "Node Drain", &status)
+		Expect(status.UUID).To(Equal(drained.UUID))
+		Expect(status.ServerBlacklisted).To(BeTrue())
+		Expect(status.LeaderBlacklisted).To(BeFalse())
+		Expect(status.ConfigVersion).To(Equal(int32(2)))
+
+		var progress []node.MoveProgress
+		decodeReport(out, "Drain Progress", &progress)
+		Expect(progress).To(Equal([]node.MoveProgress{{Moves: node.MovesData, Percent: 100, Remaining: 0, Total: 2}}))
+	})
+
+	It("blacklists the leaders of the tablet server", func() {
+		cluster.LoadBalancer.LeaderMovesRemaining = 1
+		cluster.LoadBalancer.LeaderMovesTotal = 1
+		cluster.LoadBalancer.MovesPerCall = 1
+
+		out, err := runYugatool(cluster, "node", "drain", drained.UUID, "--leader-blacklist", "--approve", "--poll-interval", "1", "-o", "json")
+		Expect(err).NotTo(HaveOccurred(), out.String())
+
+		Expect(serverBlacklist()).To(Equal([]string{util.HostPortString(drained.Address)}))
+		Expect(leaderBlacklist()).To(Equal([]string{util.HostPortString(drained.Address)}))
+
+		var progress []node.MoveProgress
+		decodeReport(out, "Drain Progress", &progress)
+		Expect(progress).To(HaveLen(2))
+		Expect(progress[1].Moves).To(Equal(node.MovesLeaders))
+		Expect(progress[1].Remaining).To(BeZero())
+	})
+
+	It("does not blacklist a tablet server twice", func() {
+		cluster.ClusterConfig.ServerBlacklist = &master.BlacklistPB{Hosts: []*common.HostPortPB{drained.Address}}
+
+		out, err := runYugatool(cluster, "node", "drain", drained.Address.GetHost(), "--wait=false", "-o", "json")
+		Expect(err).NotTo(HaveOccurred(), out.String())
+
+		Expect(serverBlacklist()).To(HaveLen(1))
+		Expect(cluster.ClusterConfig.GetVersion()).To(Equal(int32(1)))
+	})
+
+	It("does not change the blacklists on a dry run", func() {
+		out, err := runYugatool(cluster, "node", "drain", drained.UUID, "--dry-run", "-o", "json")
+		Expect(err).NotTo(HaveOccurred(), out.String())
+
+		var status node.BlacklistStatus
+		decodeReport(out, "Node Drain", &status)
+		Expect(status.ServerBlacklisted).To(BeTrue())
+
+		Expect(serverBlacklist()).To(BeEmpty())
+		Expect(cluster.ClusterConfig.GetVersion()).To(Equal(int32(1)))
+	})
+
+	It("refuses to drain when the placement policy could not be satisfied", func() {
+		cluster.ClusterConfig.ServerBlacklist = &master.BlacklistPB{Hosts: []*common.HostPortPB{cluster.TabletServers[0].Address}}
+
+		_, err := runYugatool(cluster, "node", "drain", drained.UUID, "--approve")
+		Expect(err).To(MatchError(ContainSubstring("2 tablet servers would remain, the placement policy requires 3 replicas")))
+		Expect(serverBlacklist()).To(HaveLen(1))
+
+		out, err := runYugatool(cluster, "node", "drain", drained.UUID, "--approve", "--force", "--wait=false")
+		Expect(err).NotTo(HaveOccurred(), out.String())
+		Expect(serverBlacklist()).To(HaveLen(2))
+	})
+
+	It("refuses to drain below the replication factor without a placement policy", func() {
+		cluster.ClusterConfig.ReplicationInfo = &master.ReplicationInfoPB{}
+		cluster.ClusterConfig.ServerBlacklist = &master.BlacklistPB{Hosts: []*common.HostPortPB{cluster.TabletServers[0].Address}}
+
+		_, err := runYugatool(cluster, "node", "drain", drained.UUID, "--approve")
+		Expect(err).To(MatchError(ContainSubstring("2 tablet servers would remain, the replication factor is 3")))
+		Expect(serverBlacklist()).To(HaveLen(1))
+	})
+
+	It("checks the placement policy again when the config changes while it is updated", func() {
+		cluster.ConcurrentConfigChange = func(config *master.SysClusterConfigEntryPB) {
+			config.ServerBlacklist = &master.BlacklistPB{Hosts: []*common.HostPortPB{cluster.TabletServers[0].Address}}
+		}
+
+		_, err := runYugatool(cluster, "node", "drain", drained.UUID, "--approve", "--wait=false")
+		Expect(err).To(MatchError(ContainSubstring("2 tablet servers would remain, the placement policy requires 3 replicas")))
+		Expect(serverBlacklist()).To(Equal([]string{util.HostPortString(cluster.TabletServers[0].Address)}))
+
+		cluster.ConcurrentConfigChange = func(config *master.SysClusterConfigEntryPB) {
+			config.LeaderBlacklist = &master.BlacklistPB{Hosts: []*common.HostPortPB{cluster.TabletServers[1].Address}}
+		}
+
+		out, err := runYugatool(cluster, "node", "drain", drained.UUID, "--approve", "--force", "--wait=false")
+		Expect(err).NotTo(HaveOccurred(), out.String())
+		Expect(serverBlacklist()).To(HaveLen(2))
+		Expect(leaderBlacklist()).To(HaveLen(1))
+	})
+
+	It("fails when the moves do not complete in time", func() {
+		cluster.LoadBalancer.MovesRemaining = 5
+		cluster.LoadBalancer.MovesTotal = 5
+
+		out, err := runYugatool(cluster, "node", "drain", drained.UUID, "--approve", "--poll-interval", "1", "--timeout", "1", "-o", "json")
+		Expect(err).To(MatchError("moves did not complete within 1 seconds"))
+
+		var progress []node.MoveProgress
+		decodeReport(out, "Drain Progress", &progress)
+		Expect(progress[0].Remaining).To(Equal(uint64(5)))
+	})
+
+	It("fails for an unknown tablet server", func() {
+		_, err := runYugatool(cluster, "node", "drain", "nowhere:9100", "--approve")
+		Expect(err).To(MatchError("no tablet server nowhere:9100 is registered with the master"))
+	})
+
+	It("removes the tablet server from the blacklists", func() {
+		other := cluster.TabletServers[0].Address
+		cluster.ClusterConfig.ServerBlacklist = &master.BlacklistPB{Hosts: []*common.HostPortPB{other, drained.Address}}
+		cluster.ClusterConfig.LeaderBlacklist = &master.BlacklistPB{Hosts: []*common.HostPortPB{drained.Address}}
+
+		out, err := runYugatool(cluster, "node", "undrain", drained.UUID, "-o", "json")
+		Expect(err).NotTo(HaveOccurred(), out.String())
+
+		Expect(serverBlacklist()).To(Equal([]string{util.HostPortString(other)}))
+		Expect(leaderBlacklist()).To(BeEmpty())
+
+		var status node.BlacklistStatus
+		decodeReport(out, "Node Undrain", &status)
+		Expect(status.ServerBlacklisted).To(BeFalse())
+		Expect(status.LeaderBlacklisted).To(BeFalse())
+		Expect(status.ConfigVersion).To(Equal(int32(2)))
+	})
+})
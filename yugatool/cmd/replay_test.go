@@ -0,0 +1,67 @@
+package cmd_test
+
+import (
+	"github.com/blang/vfs"
+	"github.com/blang/vfs/memfs"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/rpcserver"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/test/fakecluster"
+)
+
+var _ = Describe("--record-rpcs and --replay-rpcs", func() {
+	var (
+		cluster *fakecluster.Cluster
+		fs      vfs.Filesystem
+	)
+
+	BeforeEach(func() {
+		cluster = fakecluster.New(logr.Discard(), rpcserver.NewNetwork(), "replay", 3, 3)
+		cluster.AddTable("yugabyte", "test_table", 3)
+		fs = memfs.Create()
+	})
+
+	It("replays a recorded command without the universe", func() {
+		recorded, err := runYugatoolWithFs(fs, cluster, "cluster_info", "-o", "json", "--record-rpcs", "/rpcs.json")
+		Expect(err).NotTo(HaveOccurred(), recorded.String())
+
+		recording, err := vfs.ReadFile(fs, "/rpcs.json")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(recording)).To(ContainSubstring(`"method":"ListTabletServers"`))
+
+		for _, node := range append(cluster.Masters, cluster.TabletServers...) {
+			cluster.Stop(node)
+		}
+
+		replayed, err := runYugatoolWithFs(fs, cluster, "cluster_info", "-o", "json", "--replay-rpcs", "/rpcs.json")
+		Expect(err).NotTo(HaveOccurred(), replayed.String())
+		recordedReports := make(map[string]string)
+		for _, report := range decodeReports(recorded) {
+			recordedReports[report.Msg] = string(report.Content)
+		}
+		replayedReports := decodeReports(replayed)
+		Expect(replayedReports).To(HaveLen(len(recordedReports)))
+		for _, report := range replayedReports {
+			// The report info carries the time the command ran
+			if report.Msg == "ReportInfo" {
+				continue
+			}
+			Expect(report.Content).To(MatchJSON(recordedReports[report.Msg]), report.Msg)
+		}
+	})
+
+	It("fails calls that were not recorded", func() {
+		recorded, err := runYugatoolWithFs(fs, cluster, "cluster_info", "-o", "json", "--record-rpcs", "/rpcs.json")
+		Expect(err).NotTo(HaveOccurred(), recorded.String())
+
+		out, err := runYugatoolWithFs(fs, cluster, "rpc", "call", "MasterService.IsLoadBalanced", "-o", "json", "--replay-rpcs", "/rpcs.json")
+		Expect(err).To(HaveOccurred(), out.String())
+		Expect(out.String()).To(ContainSubstring("no recorded response for yb.master.MasterService.IsLoadBalanced"))
+	})
+
+	It("cannot record and replay at once", func() {
+		_, err := runYugatoolWithFs(fs, cluster, "cluster_info", "--record-rpcs", "/a.json", "--replay-rpcs", "/b.json")
+		Expect(err).To(MatchError("record-rpcs and replay-rpcs cannot be used together"))
+	})
+})
@@ -16,17 +16,27 @@ limitations under the License.
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 
 	"github.com/blang/vfs"
 	homedir "github.com/mitchellh/go-homedir"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/yugabyte/yb-tools/yugatool/cmd/flags"
 	"github.com/yugabyte/yb-tools/yugatool/cmd/healthcheck"
+	"github.com/yugabyte/yb-tools/yugatool/cmd/leader"
+	"github.com/yugabyte/yb-tools/yugatool/cmd/node"
+	"github.com/yugabyte/yb-tools/yugatool/cmd/rpc"
+	"github.com/yugabyte/yb-tools/yugatool/cmd/snapshot"
+	"github.com/yugabyte/yb-tools/yugatool/cmd/tablet"
 	"github.com/yugabyte/yb-tools/yugatool/cmd/util"
 	"github.com/yugabyte/yb-tools/yugatool/cmd/xcluster"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client/dial"
 	"github.com/yugabyte/yb-tools/yugatool/pkg/cmdutil"
 )
 
@@ -43,7 +53,11 @@ var rootCmd = RootInit(vfs.OS())
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	// Cancel any in-flight RPCs when interrupted
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
 		os.Exit(1)
 	}
 }
@@ -79,6 +93,12 @@ func initConfig() {
 }
 
 func RootInit(fs vfs.Filesystem) *cobra.Command {
+	return RootInitWithDialer(fs, nil)
+}
+
+// RootInitWithDialer builds the command tree with every connection to the
+// universe made through the dialer, such as an in-memory network in tests.
+func RootInitWithDialer(fs vfs.Filesystem, dialer dial.Dialer) *cobra.Command {
 	globalOptions := &cmdutil.GlobalOptions{}
 
 	cmd := &cobra.Command{
@@ -93,11 +113,14 @@ func RootInit(fs vfs.Filesystem) *cobra.Command {
 
 	ctx := cmdutil.NewCommandContext().
 		WithVFS(fs).
+		WithDialer(dialer).
 		WithGlobalOptions(globalOptions)
 
 	// Top level commands
 	cmd.AddCommand(ClusterInfoCmd(ctx))
+	cmd.AddCommand(BalanceCmd(ctx))
 	cmd.AddCommand(TabletInfoCmd(ctx))
+	cmd.AddCommand(TLSCheckCmd(ctx))
 
 	type CommandCategory struct {
 		Name        string
@@ -111,6 +134,7 @@ func RootInit(fs vfs.Filesystem) *cobra.Command {
 			Description: "Run yugabyte health checks",
 			Commands: []*cobra.Command{
 				healthcheck.XclusterConsumerCheck(ctx),
+				healthcheck.TabletsCheck(ctx),
 			},
 		},
 		{
@@ -122,6 +146,57 @@ func RootInit(fs vfs.Filesystem) *cobra.Command {
 				xcluster.StreamInfoCmd(ctx),
 			},
 		},
+		{
+			Name:        "flags",
+			Description: "Get, compare and set the gflags of the masters and tablet servers",
+			Commands: []*cobra.Command{
+				flags.GetCmd(ctx),
+				flags.DiffCmd(ctx),
+				flags.SetCmd(ctx),
+				flags.RollbackCmd(ctx),
+			},
+		},
+		{
+			Name:        "leader",
+			Description: "Step down tablet leaders and spread them evenly",
+			Commands: []*cobra.Command{
+				leader.StepdownCmd(ctx),
+				leader.RebalanceCmd(ctx),
+			},
+		},
+		{
+			Name:        "node",
+			Description: "Drain tablet servers before they are decommissioned",
+			Commands: []*cobra.Command{
+				node.DrainCmd(ctx),
+				node.UndrainCmd(ctx),
+			},
+		},
+		{
+			Name:        "tablet",
+			Description: "Move and split tablets, and delete tombstoned replicas",
+			Commands: []*cobra.Command{
+				tablet.MoveCmd(ctx),
+				tablet.SplitCmd(ctx),
+				tablet.GCCmd(ctx),
+			},
+		},
+		{
+			Name:        "snapshot",
+			Description: "Save the state of a universe and compare saved states",
+			Commands: []*cobra.Command{
+				snapshot.SaveCmd(ctx, Version),
+				snapshot.DiffCmd(ctx),
+			},
+		},
+		{
+			Name:        "rpc",
+			Description: "Call any RPC of the masters and tablet servers",
+			Commands: []*cobra.Command{
+				rpc.CallCmd(ctx),
+				rpc.ListCmd(ctx),
+			},
+		},
 		{
 			Name:        "util",
 			Description: "Miscellaneous utilities",
@@ -145,5 +220,25 @@ func RootInit(fs vfs.Filesystem) *cobra.Command {
 		cmd.AddCommand(categoryCmd)
 	}
 
+	printRPCStats(ctx, cmd)
+
 	return cmd
 }
+
+// printRPCStats prints the RPC stats after each command runs, whether or not it
+// succeeds, when requested with --rpc-stats
+func printRPCStats(ctx *cmdutil.YugatoolContext, cmd *cobra.Command) {
+	if runE := cmd.RunE; runE != nil {
+		cmd.RunE = func(cmd *cobra.Command, args []string) error {
+			err := runE(cmd, args)
+			if statsErr := ctx.PrintRPCStats(); err == nil {
+				err = statsErr
+			}
+			return err
+		}
+	}
+
+	for _, subcommand := range cmd.Commands() {
+		printRPCStats(ctx, subcommand)
+	}
+}
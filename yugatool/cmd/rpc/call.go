@@ -0,0 +1,237 @@
+/*
+Copyright © 2021 Yugabyte Support
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/yugabyte/yb-tools/pkg/format"
+	"github.com/yugabyte/yb-tools/protoc-gen-ybrpc/pkg/dispatch"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/cdc"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/consensus"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/master"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/server"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/tserver"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/cmdutil"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/util"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+func CallCmd(ctx *cmdutil.YugatoolContext) *cobra.Command {
+	options := &CallOptions{}
+	cmd := &cobra.Command{
+		Use:   "call SERVICE.METHOD",
+		Short: "Call an RPC by name",
+		Long: `Call any RPC of the masters or tablet servers by name, such as MasterService.GetLoadMoveCompletion.
+The request is given as JSON, and the response is printed for each target host.
+Run "yugatool rpc list" to see the available methods.`,
+		Example: `  yugatool rpc call MasterService.GetLoadMoveCompletion -m master-1:7100
+  yugatool rpc call TabletServerAdminService.CountIntents --target all -m master-1:7100
+  yugatool rpc call GenericService.GetFlag --target tserver:0123456789abcdef0123456789abcdef --request '{"flag": "log_dir"}' -m master-1:7100`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Positional argument
+			options.Method = args[0]
+
+			err := ctx.WithCmd(cmd).WithOptions(options).Setup()
+			if err != nil {
+				return err
+			}
+			defer ctx.Client.Close()
+
+			return call(ctx, options)
+		},
+	}
+	options.AddFlags(cmd)
+
+	return cmd
+}
+
+const (
+	TargetMaster  = "master"
+	TargetAll     = "all"
+	TargetTserver = "tserver:"
+)
+
+type CallOptions struct {
+	Method  string
+	Target  string `mapstructure:"target"`
+	Request string `mapstructure:"request"`
+
+	service   *dispatch.ServiceDesc
+	method    *dispatch.MethodDesc
+	request   proto.Message
+	tserverID uuid.UUID
+}
+
+var _ cmdutil.CommandOptions = &CallOptions{}
+
+func (o *CallOptions) AddFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.StringVar(&o.Target, "target", TargetMaster, `hosts to call, as one of: [master, tserver:<uuid>, all]. "master" is the master leader, and "all" is every server of the service`)
+	flags.StringVar(&o.Request, "request", "{}", "the request, as JSON")
+}
+
+func (o *CallOptions) Validate() error {
+	var err error
+	o.service, o.method, err = dispatch.FindMethod(o.Method)
+	if err != nil {
+		return err
+	}
+
+	o.request = o.method.NewRequest()
+	err = protojson.Unmarshal([]byte(o.Request), o.request)
+	if err != nil {
+		return errors.Wrapf(err, "invalid %s", o.method.RequestDescriptor().FullName())
+	}
+
+	switch {
+	case o.Target == TargetMaster, o.Target == TargetAll:
+	case strings.HasPrefix(o.Target, TargetTserver):
+		o.tserverID, err = uuid.Parse(strings.TrimPrefix(o.Target, TargetTserver))
+		if err != nil {
+			return errors.Wrapf(err, "invalid tablet server in target %s", o.Target)
+		}
+	default:
+		return errors.Errorf("unknown target %q: must be one of [master, tserver:<uuid>, all]", o.Target)
+	}
+	return nil
+}
+
+type CallResult struct {
+	Host     string        `json:"host"`
+	UUID     string        `json:"uuid"`
+	Error    string        `json:"error"`
+	Response proto.Message `json:"response"`
+}
+
+func call(ctx *cmdutil.YugatoolContext, options *CallOptions) error {
+	hosts, errs := targetHosts(ctx, ctx.Client, options)
+	for _, err := range errs {
+		ctx.Log.Error(err, "could not connect to target")
+	}
+	if len(hosts) == 0 {
+		return errors.Errorf("no hosts to call for target %s", options.Target)
+	}
+
+	failed := len(errs)
+	var results []CallResult
+	for _, host := range hosts {
+		result := CallResult{
+			UUID: string(host.Status.GetNodeInstance().GetPermanentUuid()),
+		}
+		if addresses := host.Status.GetBoundRpcAddresses(); len(addresses) > 0 {
+			result.Host = util.HostPortString(addresses[0])
+		}
+
+		response := options.method.NewResponse()
+		err := host.Messenger(options.service.ServiceName).SendMessage(ctx, options.service.ServiceName, options.method.MethodName, options.request, response)
+		if err != nil {
+			failed++
+			result.Error = err.Error()
+		} else {
+			result.Response = response
+		}
+		results = append(results, result)
+	}
+
+	callReport := format.Output{
+		OutputMessage: fmt.Sprintf("%s.%s", options.service.ServiceName, options.method.MethodName),
+		JSONObject:    results,
+		OutputType:    ctx.GlobalOptions.Output,
+		TableColumns: []format.Column{
+			{Name: "HOST", JSONPath: "$.host"},
+			{Name: "UUID", JSONPath: "$.uuid"},
+			{Name: "ERROR", JSONPath: "$.error"},
+			{Name: "RESPONSE", Expr: "to_json(@.response)"},
+		},
+	}
+
+	err := callReport.Print()
+	if err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return errors.Errorf("%d of %d calls failed", failed, len(hosts)+len(errs))
+	}
+	return nil
+}
+
+// targetHosts returns the hosts to call. "all" calls every master for master
+// services, every tablet server for tablet server services, and both for the
+// services they have in common.
+func targetHosts(ctx context.Context, c *client.YBClient, options *CallOptions) ([]*client.HostState, []error) {
+	switch {
+	case options.Target == TargetMaster:
+		return []*client.HostState{c.Master}, nil
+	case strings.HasPrefix(options.Target, TargetTserver):
+		host, err := c.GetHostByUUIDWithContext(ctx, []byte(options.tserverID.String()))
+		if err != nil {
+			return nil, []error{err}
+		}
+		return []*client.HostState{host}, nil
+	}
+
+	var hosts []*client.HostState
+	var errs []error
+	if servedByMasters(options.service) {
+		masters, masterErrors := c.AllMasters(ctx)
+		hosts = append(hosts, masters...)
+		errs = append(errs, masterErrors...)
+	}
+	if servedByTservers(options.service) {
+		tservers, tserverErrors := c.AllTservers()
+		hosts = append(hosts, tservers...)
+		errs = append(errs, tserverErrors...)
+	}
+	return hosts, errs
+}
+
+// masterServices and tserverServices are the services each kind of server
+// serves. Services in neither, such as the RPC test services, have no hosts
+// for the "all" target.
+var (
+	masterServices = map[*dispatch.ServiceDesc]bool{
+		&master.MasterService_ServiceDesc:  true,
+		&server.GenericService_ServiceDesc: true,
+	}
+	tserverServices = map[*dispatch.ServiceDesc]bool{
+		&tserver.TabletServerService_ServiceDesc:       true,
+		&tserver.TabletServerAdminService_ServiceDesc:  true,
+		&tserver.TabletServerBackupService_ServiceDesc: true,
+		&tserver.RemoteBootstrapService_ServiceDesc:    true,
+		&consensus.ConsensusService_ServiceDesc:        true,
+		&cdc.CDCService_ServiceDesc:                    true,
+		&server.GenericService_ServiceDesc:             true,
+	}
+)
+
+func servedByMasters(service *dispatch.ServiceDesc) bool {
+	return masterServices[service]
+}
+
+func servedByTservers(service *dispatch.ServiceDesc) bool {
+	return tserverServices[service]
+}
@@ -0,0 +1,88 @@
+/*
+Copyright © 2021 Yugabyte Support
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rpc
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/yugabyte/yb-tools/pkg/format"
+	"github.com/yugabyte/yb-tools/protoc-gen-ybrpc/pkg/dispatch"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/cmdutil"
+)
+
+func ListCmd(ctx *cmdutil.YugatoolContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list [SERVICE]",
+		Short: "List the RPCs that can be called",
+		Long:  `List the services and methods that can be called with "yugatool rpc call", with their request and response types.`,
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Listing methods does not need a connection to the universe
+			format.SetOut(cmd.OutOrStdout())
+			cmd.SilenceUsage = true
+
+			var services []*dispatch.ServiceDesc
+			if len(args) > 0 {
+				service, err := dispatch.FindService(args[0])
+				if err != nil {
+					return err
+				}
+				services = append(services, service)
+			} else {
+				services = dispatch.ServiceDescs()
+			}
+
+			return list(ctx, services)
+		},
+	}
+
+	return cmd
+}
+
+type MethodInfo struct {
+	Service  string `json:"service"`
+	Method   string `json:"method"`
+	Request  string `json:"request"`
+	Response string `json:"response"`
+}
+
+func list(ctx *cmdutil.YugatoolContext, services []*dispatch.ServiceDesc) error {
+	var methods []MethodInfo
+	for _, service := range services {
+		for i := range service.Methods {
+			method := &service.Methods[i]
+			methods = append(methods, MethodInfo{
+				Service:  service.ServiceName,
+				Method:   method.MethodName,
+				Request:  string(method.RequestDescriptor().FullName()),
+				Response: string(method.ResponseDescriptor().FullName()),
+			})
+		}
+	}
+
+	methodReport := format.Output{
+		JSONObject: methods,
+		OutputType: ctx.GlobalOptions.Output,
+		TableColumns: []format.Column{
+			{Name: "SERVICE", JSONPath: "$.service"},
+			{Name: "METHOD", JSONPath: "$.method"},
+			{Name: "REQUEST", JSONPath: "$.request"},
+			{Name: "RESPONSE", JSONPath: "$.response"},
+		},
+	}
+
+	return methodReport.Print()
+}
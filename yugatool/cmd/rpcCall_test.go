@@ -0,0 +1,111 @@
+package cmd_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/master"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/tserver"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/rpcserver"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/test/fakecluster"
+)
+
+var _ = Describe("rpc call", func() {
+	type callResult struct {
+		Host     string          `json:"host"`
+		UUID     string          `json:"uuid"`
+		Error    string          `json:"error"`
+		Response json.RawMessage `json:"response"`
+	}
+
+	var cluster *fakecluster.Cluster
+
+	BeforeEach(func() {
+		cluster = fakecluster.New(logr.Discard(), rpcserver.NewNetwork(), "rpc-call", 3, 3)
+		cluster.AddTable("yugabyte", "test_table", 3)
+	})
+
+	call := func(args ...string) ([]callResult, error) {
+		out, err := runYugatool(cluster, append([]string{"rpc", "call", "-o", "json"}, args...)...)
+		if err != nil {
+			return nil, err
+		}
+
+		reports := decodeReports(out)
+		Expect(reports).To(HaveLen(1))
+
+		var results []callResult
+		Expect(json.Unmarshal(reports[0].Content, &results)).To(Succeed())
+		return results, nil
+	}
+
+	It("calls the master leader", func() {
+		cluster.SetLeader(cluster.Masters[2])
+
+		results, err := call("MasterService.ListTabletServers")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(HaveLen(1))
+
+		response := &master.ListTabletServersResponsePB{}
+		Expect(json.Unmarshal(results[0].Response, response)).To(Succeed())
+		Expect(response.GetError()).To(BeNil())
+		Expect(response.GetServers()).To(HaveLen(3))
+	})
+
+	It("calls a tablet server with the request", func() {
+		ts := cluster.TabletServers[1]
+
+		results, err := call("yb.consensus.ConsensusService.GetConsensusState", "--target", "tserver:"+ts.UUID,
+			"--request", `{"tabletId": "`+base64.StdEncoding.EncodeToString([]byte(cluster.Tables[0].Tablets[0].ID))+`"}`)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(HaveLen(1))
+		Expect(results[0].UUID).To(Equal(ts.UUID))
+		Expect(string(results[0].Response)).To(ContainSubstring(cluster.Tables[0].Tablets[0].Leader.UUID))
+	})
+
+	It("calls every master and tablet server of the service", func() {
+		results, err := call("GenericService.Ping", "--target", "all")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(HaveLen(6))
+
+		results, err = call("TabletServerService.ListTablets", "--target", "all")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(HaveLen(3))
+		for _, result := range results {
+			response := &tserver.ListTabletsResponsePB{}
+			Expect(json.Unmarshal(result.Response, response)).To(Succeed())
+			Expect(response.GetStatusAndSchema()).To(HaveLen(3))
+		}
+	})
+
+	It("calls only the servers that serve the service", func() {
+		results, err := call("MasterService.ListTabletServers", "--target", "all")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(HaveLen(3))
+		for _, result := range results {
+			Expect(result.UUID).To(BeElementOf(cluster.Masters[0].UUID, cluster.Masters[1].UUID, cluster.Masters[2].UUID))
+		}
+
+		_, err = call("yb.rpc_test.CalculatorService.Add", "--target", "all", "--request", `{"x": 1, "y": 2}`)
+		Expect(err).To(MatchError("no hosts to call for target all"))
+	})
+
+	It("reports the calls that fail", func() {
+		_, err := call("GenericService.FlushCoverage", "--target", "all")
+		Expect(err).To(MatchError("6 of 6 calls failed"))
+	})
+
+	It("rejects unknown methods and invalid requests", func() {
+		_, err := call("MasterService.NoSuchMethod")
+		Expect(err).To(MatchError(ContainSubstring("no such method")))
+
+		_, err = call("MasterService.ListTables", "--request", `{"noSuchField": 1}`)
+		Expect(err).To(MatchError(ContainSubstring("invalid yb.master.ListTablesRequestPB")))
+
+		_, err = call("MasterService.ListTables", "--target", "tserver:not-a-uuid")
+		Expect(err).To(MatchError(ContainSubstring("invalid tablet server")))
+	})
+})
@@ -0,0 +1,56 @@
+package cmd_test
+
+import (
+	"encoding/json"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client/message"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/rpcserver"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/test/fakecluster"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/util"
+)
+
+var _ = Describe("--rpc-stats", func() {
+	var cluster *fakecluster.Cluster
+
+	BeforeEach(func() {
+		cluster = fakecluster.New(logr.Discard(), rpcserver.NewNetwork(), "rpc-stats", 3, 3)
+	})
+
+	rpcStats := func(args ...string) ([]message.MethodStats, bool) {
+		out, err := runYugatool(cluster, args...)
+		Expect(err).NotTo(HaveOccurred(), out.String())
+
+		for _, report := range decodeReports(out) {
+			if report.Msg == "RPC Stats" {
+				var stats []message.MethodStats
+				Expect(json.Unmarshal(report.Content, &stats)).To(Succeed())
+				return stats, true
+			}
+		}
+		return nil, false
+	}
+
+	It("prints the calls made by the command", func() {
+		stats, ok := rpcStats("cluster_info", "-o", "json", "--rpc-stats")
+		Expect(ok).To(BeTrue())
+
+		leader := util.HostPortString(cluster.Leader().Address)
+		calls := make(map[string]int64)
+		for _, method := range stats {
+			if method.Host == leader {
+				calls[method.Method] = method.Calls
+			}
+			Expect(method.Errors).To(BeZero())
+		}
+		Expect(calls).To(HaveKeyWithValue("GetMasterClusterConfig", BeEquivalentTo(1)))
+		Expect(calls).To(HaveKey("ListTabletServers"))
+	})
+
+	It("prints nothing unless requested", func() {
+		_, ok := rpcStats("cluster_info", "-o", "json")
+		Expect(ok).To(BeFalse())
+	})
+})
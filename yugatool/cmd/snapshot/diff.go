@@ -0,0 +1,126 @@
+/*
+Copyright © 2021 Yugabyte Support
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshot
+
+import (
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/yugabyte/yb-tools/pkg/format"
+	"github.com/yugabyte/yb-tools/yugatool/api/yugatool/config"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/cmdutil"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/snapshot"
+)
+
+func DiffCmd(ctx *cmdutil.YugatoolContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff BEFORE AFTER",
+		Short: "Compare two snapshots of a universe",
+		Long: `Compare two snapshot files written by "yugatool snapshot save", reporting the tables added and
+removed, tablets moved between tablet servers, changes in replica counts and tablet leaders, changed
+gflags, and the growth of the SST files of each tablet server and table. A tablet server that could not be
+reached for either snapshot is reported as such, and its tablets are left out of the comparison.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Comparing snapshots does not need a connection to the universe
+			format.SetOut(cmd.OutOrStdout())
+			cmd.SilenceUsage = true
+
+			var err error
+			ctx.Log, err = cmdutil.GetLogger(cmd.Name(), ctx.GlobalOptions.Debug)
+			if err != nil {
+				return err
+			}
+
+			return diff(ctx, args[0], args[1])
+		},
+	}
+
+	return cmd
+}
+
+func diff(ctx *cmdutil.YugatoolContext, beforeFile, afterFile string) error {
+	before, err := loadState(ctx, beforeFile)
+	if err != nil {
+		return err
+	}
+	after, err := loadState(ctx, afterFile)
+	if err != nil {
+		return err
+	}
+
+	changes := snapshot.Diff(before, after)
+
+	diffReport := format.Output{
+		OutputMessage: "Snapshot Diff",
+		JSONObject:    changes,
+		OutputType:    ctx.GlobalOptions.Output,
+		TableColumns: []format.Column{
+			{Name: "KIND", JSONPath: "$.kind"},
+			{Name: "OBJECT", JSONPath: "$.object"},
+			{Name: "BEFORE", JSONPath: "$.before"},
+			{Name: "AFTER", JSONPath: "$.after"},
+		},
+	}
+	return diffReport.Println()
+}
+
+// loadState reads the state saved in a snapshot file, by replaying its calls
+func loadState(ctx *cmdutil.YugatoolContext, filename string) (*snapshot.State, error) {
+	f, err := ctx.Fs.OpenFile(filename, os.O_RDONLY, 0)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to open snapshot")
+	}
+	defer f.Close()
+
+	s, err := snapshot.Load(f)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not load %s", filename)
+	}
+
+	masters, err := cmdutil.ValidateHostnameList(strings.Join(s.Manifest.Masters, ","), client.DefaultMasterPort)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid masters in %s", filename)
+	}
+
+	log := ctx.Log.WithValues("snapshot", filename)
+	c := &client.YBClient{
+		Log: log.WithName("client"),
+		Fs:  ctx.Fs,
+		Config: &config.UniverseConfigPB{
+			Masters:           masters,
+			TimeoutSeconds:    &ctx.GlobalOptions.DialTimeout,
+			RpcTimeoutSeconds: &ctx.GlobalOptions.RPCTimeout,
+		},
+	}
+	c.OverrideDialer(s.Network(log.WithName("replay")))
+
+	err = c.ConnectWithContext(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not replay %s", filename)
+	}
+	defer c.Close()
+
+	state, err := snapshot.Collect(ctx, log, c, snapshot.CollectOptions{Flags: s.Manifest.Flags, Concurrency: 1})
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not replay %s", filename)
+	}
+	return state, nil
+}
@@ -0,0 +1,169 @@
+/*
+Copyright © 2021 Yugabyte Support
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshot
+
+import (
+	"bytes"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/yugabyte/yb-tools/pkg/format"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/cmdutil"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/recording"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/snapshot"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/util"
+)
+
+func SaveCmd(ctx *cmdutil.YugatoolContext, version string) *cobra.Command {
+	options := &SaveOptions{}
+	cmd := &cobra.Command{
+		Use:   "save FILE",
+		Short: "Save the state of the universe to a snapshot file",
+		Long: `Save the cluster config, masters, tablet servers, tables and their schemas, the tablets of each
+tablet server with their consensus state, and a set of gflags of each server to a snapshot file.
+
+The snapshot can be compared with a later one by "yugatool snapshot diff", and commands such as
+cluster_info can be run against it with --snapshot instead of connecting to the universe.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := ctx.WithCmd(cmd).WithOptions(options).Prepare()
+			if err != nil {
+				return err
+			}
+
+			if ctx.GlobalOptions.Snapshot != "" || ctx.GlobalOptions.ReplayRPCs != "" || ctx.GlobalOptions.RecordRPCs != "" {
+				return errors.New("snapshot save cannot be used with snapshot, record-rpcs or replay-rpcs")
+			}
+
+			return save(ctx, options, version, args[0])
+		},
+	}
+	options.AddFlags(cmd)
+
+	return cmd
+}
+
+type SaveOptions struct {
+	Flags       []string `mapstructure:"flag"`
+	Concurrency int      `mapstructure:"concurrency"`
+}
+
+var _ cmdutil.CommandOptions = &SaveOptions{}
+
+func (o *SaveOptions) AddFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.StringSliceVar(&o.Flags, "flag", nil, "gflags to save in addition to the default set (may be repeated)")
+	flags.IntVar(&o.Concurrency, "concurrency", 8, "the maximum number of concurrent requests to each tablet server")
+}
+
+func (o *SaveOptions) Validate() error {
+	if o.Concurrency < 1 {
+		return errors.New("concurrency must be at least 1")
+	}
+	return nil
+}
+
+type SaveSummary struct {
+	File          string    `json:"file"`
+	Created       time.Time `json:"created"`
+	Masters       int       `json:"masters"`
+	TabletServers int       `json:"tablet_servers"`
+	Tables        int       `json:"tables"`
+	Replicas      int       `json:"tablet_replicas"`
+	Calls         int       `json:"calls"`
+}
+
+func save(ctx *cmdutil.YugatoolContext, options *SaveOptions, version, filename string) error {
+	// The snapshot holds the calls made while collecting the state
+	calls := &bytes.Buffer{}
+	recorder := recording.NewRecorder(ctx.Log.WithName("recorder"), calls)
+	ctx.WrapDialer = recorder.Dialer
+
+	var err error
+	ctx.Client, err = cmdutil.ConnectToYugabyte(ctx)
+	if err != nil {
+		return err
+	}
+
+	flags := append(append([]string{}, snapshot.DefaultFlags...), options.Flags...)
+	state, err := snapshot.Collect(ctx, ctx.Log, ctx.Client, snapshot.CollectOptions{
+		Flags:       flags,
+		Concurrency: options.Concurrency,
+	})
+	ctx.Client.Close()
+	if err != nil {
+		return errors.Wrap(err, "could not collect the state of the universe")
+	}
+
+	s := &snapshot.Snapshot{
+		Manifest: snapshot.Manifest{
+			Version:      snapshot.FormatVersion,
+			Created:      time.Now().UTC(),
+			ToolsVersion: version,
+			Flags:        flags,
+		},
+	}
+	for _, host := range ctx.GlobalOptions.Hosts() {
+		s.Manifest.Masters = append(s.Manifest.Masters, util.HostPortString(host))
+	}
+	s.Calls, err = recording.Load(calls)
+	if err != nil {
+		return err
+	}
+
+	f, err := ctx.Fs.OpenFile(filename, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return errors.Wrap(err, "could not create snapshot file")
+	}
+	err = snapshot.Save(f, s)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return errors.Wrap(err, "could not save snapshot")
+	}
+
+	summary := SaveSummary{
+		File:          filename,
+		Created:       s.Manifest.Created,
+		Masters:       len(state.Masters),
+		TabletServers: len(state.TabletServers),
+		Tables:        len(state.Tables),
+		Calls:         len(s.Calls),
+	}
+	for _, replicas := range state.Tablets {
+		summary.Replicas += len(replicas)
+	}
+
+	summaryReport := format.Output{
+		OutputMessage: "Snapshot",
+		JSONObject:    summary,
+		OutputType:    ctx.GlobalOptions.Output,
+		TableColumns: []format.Column{
+			{Name: "FILE", JSONPath: "$.file"},
+			{Name: "CREATED", JSONPath: "$.created"},
+			{Name: "MASTERS", JSONPath: "$.masters"},
+			{Name: "TSERVERS", JSONPath: "$.tablet_servers"},
+			{Name: "TABLES", JSONPath: "$.tables"},
+			{Name: "TABLET_REPLICAS", JSONPath: "$.tablet_replicas"},
+			{Name: "RPCS", JSONPath: "$.calls"},
+		},
+	}
+	return summaryReport.Println()
+}
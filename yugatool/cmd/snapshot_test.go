@@ -0,0 +1,99 @@
+package cmd_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/blang/vfs"
+	"github.com/blang/vfs/memfs"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/yugabyte/yb-tools/yugatool/cmd"
+	"github.com/yugabyte/yb-tools/yugatool/cmd/snapshot"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/rpcserver"
+	pkgsnapshot "github.com/yugabyte/yb-tools/yugatool/pkg/snapshot"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/test/fakecluster"
+)
+
+var _ = Describe("snapshot", func() {
+	var (
+		cluster *fakecluster.Cluster
+		table   *fakecluster.Table
+		fs      vfs.Filesystem
+	)
+
+	BeforeEach(func() {
+		cluster = fakecluster.New(logr.Discard(), rpcserver.NewNetwork(), "snapshot", 3, 3)
+		table = cluster.AddTable("yugabyte", "test_table", 3)
+		fs = memfs.Create()
+	})
+
+	save := func(filename string) *bytes.Buffer {
+		out, err := runYugatoolWithFs(fs, cluster, "snapshot", "save", filename, "-o", "json")
+		Expect(err).NotTo(HaveOccurred(), out.String())
+		return out
+	}
+
+	It("runs cluster_info against a snapshot without the universe", func() {
+		var summary snapshot.SaveSummary
+		decodeReport(save("/before.tgz"), "Snapshot", &summary)
+		Expect(summary.TabletServers).To(Equal(3))
+		Expect(summary.Tables).To(Equal(1))
+		Expect(summary.Replicas).To(Equal(9))
+
+		for _, node := range append(cluster.Masters, cluster.TabletServers...) {
+			cluster.Stop(node)
+		}
+
+		// The masters are read from the snapshot
+		out, err := runYugatoolWithMasters(fs, cluster, "", "cluster_info", "--tablet-report", "-o", "json", "--snapshot", "/before.tgz")
+		Expect(err).NotTo(HaveOccurred(), out.String())
+
+		var tabletReports int
+		for _, report := range decodeReports(out) {
+			if strings.HasPrefix(report.Msg, "Tablet Report: ") {
+				var tablets []*cmd.TabletInfo
+				Expect(json.Unmarshal(report.Content, &tablets)).To(Succeed())
+				Expect(tablets).To(HaveLen(3))
+				tabletReports++
+			}
+		}
+		Expect(tabletReports).To(Equal(3))
+	})
+
+	It("reports the changes between two snapshots", func() {
+		save("/before.tgz")
+
+		cluster.Lock()
+		table.Tablets[0].Leader = table.Tablets[0].Replicas[1]
+		cluster.TabletServers[2].Flags["v"] = "1"
+		cluster.Unlock()
+		cluster.AddTable("yugabyte", "new_table", 1)
+
+		save("/after.tgz")
+
+		out, err := runYugatoolWithFs(fs, cluster, "snapshot", "diff", "/before.tgz", "/after.tgz", "-o", "json")
+		Expect(err).NotTo(HaveOccurred(), out.String())
+
+		var changes []pkgsnapshot.Change
+		decodeReport(out, "Snapshot Diff", &changes)
+		Expect(changes).To(HaveLen(3))
+		Expect(changes[0].Kind).To(Equal(pkgsnapshot.TableAdded))
+		Expect(changes[1]).To(Equal(pkgsnapshot.Change{
+			Kind:   pkgsnapshot.LeaderChanged,
+			Object: "tablet " + table.Tablets[0].ID + " of yugabyte.test_table (" + table.ID + ")",
+			Before: "snapshot-tserver-1:9100",
+			After:  "snapshot-tserver-2:9100",
+		}))
+		Expect(changes[2].Object).To(Equal("v on snapshot-tserver-3:9100"))
+	})
+
+	It("cannot be used with recorded RPCs", func() {
+		save("/before.tgz")
+
+		_, err := runYugatoolWithFs(fs, cluster, "cluster_info", "--snapshot", "/before.tgz", "--replay-rpcs", "/rpcs.json")
+		Expect(err).To(MatchError("snapshot cannot be used with record-rpcs or replay-rpcs"))
+	})
+})
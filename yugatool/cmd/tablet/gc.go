@@ -0,0 +1,154 @@
+/*
+Copyright © 2021 Yugabyte Support
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tablet
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/yugabyte/yb-tools/pkg/format"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/cmdutil"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/tablets"
+)
+
+func GCCmd(ctx *cmdutil.YugatoolContext) *cobra.Command {
+	options := &GCOptions{}
+	cmd := &cobra.Command{
+		Use:   "gc",
+		Short: "Delete tombstoned tablet replicas",
+		Long: `Find the tombstoned replicas left on each live tablet server once their tablet server was removed from
+the Raft config of the tablet, and delete them with their WAL and SST files. The master is asked for the
+locations of each tablet first, and a replica on a tablet server that is still a peer of its tablet is skipped.
+
+The replicas are only listed, with the space deleting them would reclaim, unless --approve is given.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := ctx.WithCmd(cmd).WithOptions(options).Setup()
+			if err != nil {
+				return err
+			}
+			defer ctx.Client.Close()
+
+			return gc(ctx, options)
+		},
+	}
+	options.AddFlags(cmd)
+
+	return cmd
+}
+
+type GCOptions struct {
+	Approve bool `mapstructure:"approve"`
+}
+
+var _ cmdutil.CommandOptions = &GCOptions{}
+
+func (o *GCOptions) AddFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.BoolVar(&o.Approve, "approve", false, "delete the tombstoned replicas, rather than only listing them")
+}
+
+func (o *GCOptions) Validate() error {
+	return nil
+}
+
+// GCSummary is the space the tombstoned replicas take, and how much of it was
+// reclaimed
+type GCSummary struct {
+	Replicas         int   `json:"replicas"`
+	Deleted          int   `json:"deleted"`
+	Skipped          int   `json:"skipped"`
+	Failed           int   `json:"failed"`
+	WALSize          int64 `json:"wal_size"`
+	SSTSize          int64 `json:"sst_size"`
+	ReclaimedWALSize int64 `json:"reclaimed_wal_size"`
+	ReclaimedSSTSize int64 `json:"reclaimed_sst_size"`
+}
+
+func gc(ctx *cmdutil.YugatoolContext, options *GCOptions) error {
+	replicas, err := tablets.FindTombstoned(ctx, ctx.Log, ctx.Client)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	if options.Approve {
+		failed = tablets.DeleteTombstoned(ctx, ctx.Log, ctx.Client, replicas)
+	}
+
+	replicaReport := format.Output{
+		OutputMessage: "Tombstoned Replicas",
+		JSONObject:    replicas,
+		OutputType:    ctx.GlobalOptions.Output,
+		TableColumns: []format.Column{
+			{Name: "TSERVER", JSONPath: "$.tserver"},
+			{Name: "TABLET", JSONPath: "$.tablet"},
+			{Name: "NAMESPACE", JSONPath: "$.namespace"},
+			{Name: "TABLE", JSONPath: "$.table"},
+			{Name: "WAL_SIZE", Expr: "size_pretty(@.wal_size)"},
+			{Name: "SST_SIZE", Expr: "size_pretty(@.sst_size)"},
+			{Name: "RESULT", JSONPath: "$.result"},
+			{Name: "ERROR", JSONPath: "$.error"},
+		},
+	}
+	err = replicaReport.Println()
+	if err != nil {
+		return err
+	}
+
+	summary := &GCSummary{Replicas: len(replicas)}
+	for _, replica := range replicas {
+		summary.WALSize += replica.WALSize
+		summary.SSTSize += replica.SSTSize
+		switch replica.Result {
+		case tablets.ResultDeleted:
+			summary.Deleted++
+			summary.ReclaimedWALSize += replica.WALSize
+			summary.ReclaimedSSTSize += replica.SSTSize
+		case tablets.ResultSkipped:
+			summary.Skipped++
+		case tablets.ResultFailed:
+			summary.Failed++
+		}
+	}
+
+	summaryReport := format.Output{
+		OutputMessage: "Tablet GC",
+		JSONObject:    summary,
+		OutputType:    ctx.GlobalOptions.Output,
+		TableColumns: []format.Column{
+			{Name: "REPLICAS", JSONPath: "$.replicas"},
+			{Name: "DELETED", JSONPath: "$.deleted"},
+			{Name: "SKIPPED", JSONPath: "$.skipped"},
+			{Name: "FAILED", JSONPath: "$.failed"},
+			{Name: "WAL_SIZE", Expr: "size_pretty(@.wal_size)"},
+			{Name: "SST_SIZE", Expr: "size_pretty(@.sst_size)"},
+			{Name: "RECLAIMED_WAL_SIZE", Expr: "size_pretty(@.reclaimed_wal_size)"},
+			{Name: "RECLAIMED_SST_SIZE", Expr: "size_pretty(@.reclaimed_sst_size)"},
+		},
+	}
+	err = summaryReport.Println()
+	if err != nil {
+		return err
+	}
+
+	if !options.Approve && summary.Replicas > summary.Skipped {
+		ctx.Log.Info("no replicas were deleted, give --approve to delete them")
+	}
+	if failed > 0 {
+		return errors.Errorf("%d of %d tombstoned replicas could not be deleted", failed, summary.Replicas-summary.Skipped)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+/*
+Copyright © 2021 Yugabyte Support
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tablet
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/yugabyte/yb-tools/pkg/util"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/cmdutil"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/tablets"
+)
+
+func MoveCmd(ctx *cmdutil.YugatoolContext) *cobra.Command {
+	options := &MoveOptions{}
+	cmd := &cobra.Command{
+		Use:   "move TABLET_UUID",
+		Short: "Move a tablet replica to another tablet server",
+		Long: `Move the replica of a tablet on the tablet server given by --from to the one given by --to, each as a
+UUID, host:port or host. The new peer is added to the Raft config of the tablet as a PRE_VOTER and remote
+bootstrapped by the leader. Once it has caught up with the leader it is promoted to a VOTER, the leader is
+stepped down if it is the replica moved, and the old peer is removed from the Raft config. A new peer that
+does not catch up within --catch-up-timeout seconds is removed again. With --delete-replica, the tombstoned
+replica left on the old tablet server is deleted.
+
+The move is refused if it would leave the tablet with fewer voters than its replication factor. The steps
+are listed and confirmed before they are run, and each is logged as it runs.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := ctx.WithCmd(cmd).WithOptions(options).Setup()
+			if err != nil {
+				return err
+			}
+			defer ctx.Client.Close()
+
+			return move(ctx, options, args[0])
+		},
+	}
+	options.AddFlags(cmd)
+
+	return cmd
+}
+
+type MoveOptions struct {
+	From           string `mapstructure:"from"`
+	To             string `mapstructure:"to"`
+	CatchUpTimeout int64  `mapstructure:"catch_up_timeout"`
+	VerifyTimeout  int64  `mapstructure:"verify_timeout"`
+	DeleteReplica  bool   `mapstructure:"delete_replica"`
+	DryRun         bool   `mapstructure:"dry_run"`
+	Approve        bool   `mapstructure:"approve"`
+}
+
+var _ cmdutil.CommandOptions = &MoveOptions{}
+
+func (o *MoveOptions) AddFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.StringVar(&o.From, "from", "", "the tablet server to move the replica from")
+	flags.StringVar(&o.To, "to", "", "the tablet server to move the replica to")
+	flags.Int64Var(&o.CatchUpTimeout, "catch-up-timeout", 300, "number of seconds to wait for the new peer to catch up with the leader")
+	flags.Int64Var(&o.VerifyTimeout, "verify-timeout", 10, "number of seconds to wait for a new leader to take over when the leader is moved")
+	flags.BoolVar(&o.DeleteReplica, "delete-replica", false, "delete the tombstoned replica left on the tablet server moved from")
+	flags.BoolVar(&o.DryRun, "dry-run", false, "list the steps of the move without running them")
+	flags.BoolVar(&o.Approve, "approve", false, "move the replica without prompting")
+}
+
+func (o *MoveOptions) Validate() error {
+	if o.From == "" || o.To == "" {
+		return errors.New("both --from and --to must be given")
+	}
+	if o.CatchUpTimeout < 1 {
+		return errors.New("catch-up-timeout must be at least 1 second")
+	}
+	if o.VerifyTimeout < 1 {
+		return errors.New("verify-timeout must be at least 1 second")
+	}
+	return nil
+}
+
+func move(ctx *cmdutil.YugatoolContext, options *MoveOptions, tablet string) error {
+	m, err := tablets.PlanMove(ctx, ctx.Log, ctx.Client, tablets.MoveOptions{
+		Tablet:          tablet,
+		From:            options.From,
+		To:              options.To,
+		CatchUpTimeout:  time.Duration(options.CatchUpTimeout) * time.Second,
+		StepDownTimeout: time.Duration(options.VerifyTimeout) * time.Second,
+		DeleteReplica:   options.DeleteReplica,
+	})
+	if err != nil {
+		return err
+	}
+
+	if options.DryRun {
+		return printSteps(ctx, "Tablet Move", m.Plan())
+	}
+
+	if !options.Approve {
+		err := printSteps(ctx, "Tablet Move", m.Plan())
+		if err != nil {
+			return err
+		}
+
+		err = util.ConfirmationDialog()
+		if err != nil {
+			return err
+		}
+	}
+
+	err = m.Run(ctx)
+
+	printErr := printSteps(ctx, "Tablet Move", m.Steps)
+	if printErr != nil {
+		return printErr
+	}
+	return err
+}
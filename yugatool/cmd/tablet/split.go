@@ -0,0 +1,233 @@
+/*
+Copyright © 2021 Yugabyte Support
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tablet
+
+import (
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/yugabyte/yb-tools/pkg/format"
+	"github.com/yugabyte/yb-tools/pkg/util"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/cmdutil"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/tablets"
+)
+
+func SplitCmd(ctx *cmdutil.YugatoolContext) *cobra.Command {
+	options := &SplitOptions{}
+	cmd := &cobra.Command{
+		Use:   "split [TABLET_UUID...]",
+		Short: "Split large tablets",
+		Long: `Split the given tablets, or the tablets of the table given by --table whose SST files are larger than
+--target-size, each at the midpoint of its hash range. The size of each tablet is read from the tablet
+server that leads it.
+
+The universe must run a version that can split tablets. A tablet is skipped, with the reason why, when its
+table is range partitioned, when it is not running or is already splitting, or when its table has CDC streams
+and the universe runs a version that cannot split their tablets.
+
+The tablets are listed and confirmed before they are split, no more than --concurrency at once. Each split
+waits up to --timeout seconds for the new tablets to be running, and the hash range of each is reported.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Positional arguments
+			options.Tablets = args
+
+			err := ctx.WithCmd(cmd).WithOptions(options).Setup()
+			if err != nil {
+				return err
+			}
+			defer ctx.Client.Close()
+
+			return split(ctx, options)
+		},
+	}
+	options.AddFlags(cmd)
+
+	return cmd
+}
+
+type SplitOptions struct {
+	Tablets     []string
+	Namespace   string `mapstructure:"namespace"`
+	Table       string `mapstructure:"table"`
+	TargetSize  string `mapstructure:"target_size"`
+	Concurrency int    `mapstructure:"concurrency"`
+	Timeout     int64  `mapstructure:"timeout"`
+	DryRun      bool   `mapstructure:"dry_run"`
+	Approve     bool   `mapstructure:"approve"`
+
+	targetSize int64
+}
+
+var _ cmdutil.CommandOptions = &SplitOptions{}
+
+func (o *SplitOptions) AddFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.StringVar(&o.Namespace, "namespace", "", "the namespace of the table")
+	flags.StringVar(&o.Table, "table", "", "split the tablets of this table that are larger than --target-size")
+	flags.StringVar(&o.TargetSize, "target-size", "10GB", "split the tablets whose SST files are larger than this size")
+	flags.IntVar(&o.Concurrency, "concurrency", 4, "number of tablets to split at once")
+	flags.Int64Var(&o.Timeout, "timeout", 300, "number of seconds to wait for the new tablets of each split to be running")
+	flags.BoolVar(&o.DryRun, "dry-run", false, "list the tablets to split without splitting them")
+	flags.BoolVar(&o.Approve, "approve", false, "split the tablets without prompting")
+}
+
+func (o *SplitOptions) Validate() error {
+	if len(o.Tablets) == 0 && o.Table == "" {
+		return errors.New("either tablets or --table must be given")
+	}
+	if len(o.Tablets) > 0 && o.Table != "" {
+		return errors.New("tablets and --table cannot both be given")
+	}
+
+	var err error
+	o.targetSize, err = units.RAMInBytes(o.TargetSize)
+	if err != nil {
+		return errors.Wrap(err, "invalid target-size")
+	}
+	if o.targetSize < 1 {
+		return errors.New("target-size must be at least 1 byte")
+	}
+	if o.Concurrency < 1 {
+		return errors.New("concurrency must be at least 1")
+	}
+	if o.Timeout < 1 {
+		return errors.New("timeout must be at least 1 second")
+	}
+	return nil
+}
+
+// SplitTablet is a tablet a split tablet was split into, for the report
+type SplitTablet struct {
+	Parent    string             `json:"parent"`
+	Tablet    string             `json:"tablet"`
+	Partition *tablets.Partition `json:"partition"`
+	State     string             `json:"state"`
+}
+
+func split(ctx *cmdutil.YugatoolContext, options *SplitOptions) error {
+	splits, err := tablets.PlanSplits(ctx, ctx.Log, ctx.Client, tablets.SplitOptions{
+		Tablets:    options.Tablets,
+		Namespace:  options.Namespace,
+		Table:      options.Table,
+		TargetSize: options.targetSize,
+	})
+	if err != nil {
+		return err
+	}
+
+	pending := 0
+	for _, s := range splits {
+		if s.Result == tablets.ResultPending {
+			pending++
+		}
+	}
+
+	if options.DryRun || pending == 0 {
+		err := printSplits(ctx, splits)
+		if err != nil {
+			return err
+		}
+		if pending == 0 {
+			ctx.Log.Info("no tablets to split")
+		}
+		return skippedError(splits)
+	}
+
+	if !options.Approve {
+		err := printSplits(ctx, splits)
+		if err != nil {
+			return err
+		}
+
+		err = util.ConfirmationDialog()
+		if err != nil {
+			return err
+		}
+	}
+
+	tablets.Split(ctx, ctx.Log, ctx.Client, splits, options.Concurrency, time.Duration(options.Timeout)*time.Second)
+
+	err = printSplits(ctx, splits)
+	if err != nil {
+		return err
+	}
+
+	children := []*SplitTablet{}
+	for _, s := range splits {
+		for _, child := range s.Children {
+			children = append(children, &SplitTablet{
+				Parent:    s.Tablet,
+				Tablet:    child.Tablet,
+				Partition: child.Partition,
+				State:     child.State,
+			})
+		}
+	}
+	childReport := format.Output{
+		OutputMessage: "Split Tablets",
+		JSONObject:    children,
+		OutputType:    ctx.GlobalOptions.Output,
+		TableColumns: []format.Column{
+			{Name: "PARENT", JSONPath: "$.parent"},
+			{Name: "TABLET", JSONPath: "$.tablet"},
+			{Name: "START", Expr: "partition_key_to_hex(@.partition.partitionKeyStart)"},
+			{Name: "END", Expr: "partition_key_to_hex(@.partition.partitionKeyEnd)"},
+			{Name: "STATE", JSONPath: "$.state"},
+		},
+	}
+	err = childReport.Println()
+	if err != nil {
+		return err
+	}
+
+	return skippedError(splits)
+}
+
+func printSplits(ctx *cmdutil.YugatoolContext, splits []*tablets.TabletSplit) error {
+	report := format.Output{
+		OutputMessage: "Tablet Splits",
+		JSONObject:    splits,
+		OutputType:    ctx.GlobalOptions.Output,
+		TableColumns: []format.Column{
+			{Name: "TABLE", JSONPath: "$.table"},
+			{Name: "TABLET", JSONPath: "$.tablet"},
+			{Name: "SST_SIZE", Expr: "size_pretty(@.sst_size)"},
+			{Name: "START", Expr: "partition_key_to_hex(@.partition.partitionKeyStart)"},
+			{Name: "END", Expr: "partition_key_to_hex(@.partition.partitionKeyEnd)"},
+			{Name: "RESULT", JSONPath: "$.result"},
+			{Name: "ERROR", JSONPath: "$.error"},
+		},
+	}
+	return report.Println()
+}
+
+// skippedError returns an error when any tablet was skipped or could not be
+// split
+func skippedError(splits []*tablets.TabletSplit) error {
+	unsplit := 0
+	for _, s := range splits {
+		if s.Result == tablets.ResultSkipped || s.Result == tablets.ResultFailed {
+			unsplit++
+		}
+	}
+	if unsplit > 0 {
+		return errors.Errorf("%d of %d tablets could not be split", unsplit, len(splits))
+	}
+	return nil
+}
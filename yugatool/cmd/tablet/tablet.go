@@ -0,0 +1,38 @@
+/*
+Copyright © 2021 Yugabyte Support
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tablet
+
+import (
+	"github.com/yugabyte/yb-tools/pkg/format"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/cmdutil"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/tablets"
+)
+
+func printSteps(ctx *cmdutil.YugatoolContext, message string, steps []*tablets.MoveStep) error {
+	stepReport := format.Output{
+		OutputMessage: message,
+		JSONObject:    steps,
+		OutputType:    ctx.GlobalOptions.Output,
+		TableColumns: []format.Column{
+			{Name: "TIME", JSONPath: "$.time"},
+			{Name: "STEP", JSONPath: "$.step"},
+			{Name: "DETAIL", JSONPath: "$.detail"},
+			{Name: "ERROR", JSONPath: "$.error"},
+		},
+	}
+	return stepReport.Println()
+}
@@ -0,0 +1,73 @@
+package cmd_test
+
+import (
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/yugabyte/yb-tools/yugatool/cmd/tablet"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/rpcserver"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/tablets"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/test/fakecluster"
+)
+
+var _ = Describe("tablet gc", func() {
+	var (
+		cluster *fakecluster.Cluster
+		table   *fakecluster.Table
+	)
+
+	BeforeEach(func() {
+		cluster = fakecluster.New(logr.Discard(), rpcserver.NewNetwork(), "gc", 1, 6)
+		table = cluster.AddTable("yugabyte", "test_table", 3)
+		for _, t := range table.Tablets {
+			t.Tombstoned = []*fakecluster.Node{cluster.TabletServers[5]}
+			t.WALSize = 1 << 20
+			t.SSTSize = 4 << 20
+		}
+	})
+
+	tombstoned := func() int {
+		cluster.Lock()
+		defer cluster.Unlock()
+		count := 0
+		for _, t := range table.Tablets {
+			count += len(t.Tombstoned)
+		}
+		return count
+	}
+
+	It("only lists the tombstoned replicas without --approve", func() {
+		out, err := runYugatool(cluster, "tablet", "gc", "-o", "json")
+		Expect(err).NotTo(HaveOccurred(), out.String())
+
+		var replicas []tablets.TombstonedReplica
+		decodeReport(out, "Tombstoned Replicas", &replicas)
+		Expect(replicas).To(HaveLen(3))
+
+		var summary tablet.GCSummary
+		decodeReport(out, "Tablet GC", &summary)
+		Expect(summary).To(Equal(tablet.GCSummary{Replicas: 3, WALSize: 3 << 20, SSTSize: 12 << 20}))
+
+		Expect(tombstoned()).To(Equal(3))
+	})
+
+	It("deletes the tombstoned replicas and reports the space reclaimed", func() {
+		out, err := runYugatool(cluster, "tablet", "gc", "--approve", "-o", "json")
+		Expect(err).NotTo(HaveOccurred(), out.String())
+
+		var summary tablet.GCSummary
+		decodeReport(out, "Tablet GC", &summary)
+		Expect(summary.Deleted).To(Equal(3))
+		Expect(summary.ReclaimedWALSize).To(Equal(int64(3 << 20)))
+		Expect(summary.ReclaimedSSTSize).To(Equal(int64(12 << 20)))
+
+		Expect(tombstoned()).To(BeZero())
+	})
+
+	It("prints the sizes in a table", func() {
+		out, err := runYugatool(cluster, "tablet", "gc")
+		Expect(err).NotTo(HaveOccurred(), out.String())
+		Expect(out.String()).To(ContainSubstring("1024 kB"))
+		Expect(out.String()).To(ContainSubstring("12 MB"))
+	})
+})
@@ -17,6 +17,7 @@ limitations under the License.
 package cmd
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/go-logr/logr"
@@ -47,14 +48,14 @@ func TabletInfoCmd(ctx *cmdutil.YugatoolContext) *cobra.Command {
 			// Positional argument
 			tablet := args[0]
 
-			return tabletInfo(cmd, ctx.Log, ctx.Client, tablet)
+			return tabletInfo(ctx, cmd, ctx.Log, ctx.Client, tablet)
 		},
 	}
 
 	return cmd
 }
 
-func tabletInfo(cmd *cobra.Command, log logr.Logger, c *client.YBClient, tablet string) error {
+func tabletInfo(ctx context.Context, cmd *cobra.Command, log logr.Logger, c *client.YBClient, tablet string) error {
 	hosts, errors := c.AllTservers()
 	if len(errors) > 0 {
 		for _, err := range errors {
@@ -67,14 +68,14 @@ func tabletInfo(cmd *cobra.Command, log logr.Logger, c *client.YBClient, tablet
 	}
 
 	for _, host := range hosts {
-		tablets, err := host.TabletServerService.ListTablets(&tserver.ListTabletsRequestPB{})
+		tablets, err := host.TabletServerService.ListTabletsWithContext(ctx, &tserver.ListTabletsRequestPB{})
 		if err != nil {
 			return err
 		}
 
 		for _, t := range tablets.GetStatusAndSchema() {
 			if t.GetTabletStatus().GetTabletId() == tablet {
-				latestEntryOpID, err := host.CDCService.GetLatestEntryOpId(&cdc.GetLatestEntryOpIdRequestPB{
+				latestEntryOpID, err := host.CDCService.GetLatestEntryOpIdWithContext(ctx, &cdc.GetLatestEntryOpIdRequestPB{
 					TabletId: []byte(tablet),
 				})
 				if err != nil {
@@ -86,7 +87,7 @@ func tabletInfo(cmd *cobra.Command, log logr.Logger, c *client.YBClient, tablet
 					TabletId: []byte(t.GetTabletStatus().GetTabletId()),
 					Type:     common.ConsensusConfigType_CONSENSUS_CONFIG_COMMITTED.Enum(),
 				}
-				consensusState, err := host.ConsensusService.GetConsensusState(&pb)
+				consensusState, err := host.ConsensusService.GetConsensusStateWithContext(ctx, &pb)
 				if err != nil {
 					return err
 				}
@@ -0,0 +1,41 @@
+package cmd_test
+
+import (
+	"regexp"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/rpcserver"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/test/fakecluster"
+)
+
+var _ = Describe("tablet_info", func() {
+	var (
+		cluster *fakecluster.Cluster
+		tablet  *fakecluster.Tablet
+	)
+
+	BeforeEach(func() {
+		cluster = fakecluster.New(logr.Discard(), rpcserver.NewNetwork(), "tablet-info", 3, 4)
+		tablet = cluster.AddTable("yugabyte", "test_table", 2).Tablets[1]
+	})
+
+	It("reports the tablet from each of its replicas", func() {
+		out, err := runYugatool(cluster, "tablet_info", tablet.ID)
+		Expect(err).NotTo(HaveOccurred(), out.String())
+
+		for _, replica := range tablet.Replicas {
+			Expect(out.String()).To(ContainSubstring("(UUID %s)", replica.UUID))
+		}
+		// prototext varies the spacing after field names between runs
+		Expect(regexp.MustCompile(`tablet_id:\s+"`+tablet.ID+`"`).FindAllString(out.String(), -1)).To(HaveLen(3))
+		Expect(out.String()).To(MatchRegexp(`leader_uuid:\s+"%s"`, tablet.Leader.UUID))
+	})
+
+	It("reports nothing for an unknown tablet", func() {
+		out, err := runYugatool(cluster, "tablet_info", "00000000000000000000000000000000")
+		Expect(err).NotTo(HaveOccurred(), out.String())
+		Expect(out.String()).To(BeEmpty())
+	})
+})
@@ -0,0 +1,84 @@
+package cmd_test
+
+import (
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/rpcserver"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/tablets"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/test/fakecluster"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/util"
+)
+
+var _ = Describe("tablet move", func() {
+	var (
+		cluster  *fakecluster.Cluster
+		tablet   *fakecluster.Tablet
+		from, to *fakecluster.Node
+	)
+
+	BeforeEach(func() {
+		cluster = fakecluster.New(logr.Discard(), rpcserver.NewNetwork(), "move", 1, 4)
+		tablet = cluster.AddTable("yugabyte", "test_table", 1).Tablets[0]
+		from, to = cluster.TabletServers[0], cluster.TabletServers[3]
+	})
+
+	replicas := func() []*fakecluster.Node {
+		cluster.Lock()
+		defer cluster.Unlock()
+		return append([]*fakecluster.Node{}, tablet.Replicas...)
+	}
+
+	It("moves the replica and reports each step", func() {
+		cluster.BootstrapLag = 2
+
+		out, err := runYugatool(cluster, "tablet", "move", tablet.ID, "--from", util.HostPortString(from.Address), "--to", to.UUID, "--delete-replica", "--approve", "-o", "json")
+		Expect(err).NotTo(HaveOccurred(), out.String())
+
+		Expect(replicas()).To(ConsistOf(cluster.TabletServers[1], cluster.TabletServers[2], to))
+
+		var steps []tablets.MoveStep
+		decodeReport(out, "Tablet Move", &steps)
+		var names []string
+		for _, step := range steps {
+			names = append(names, step.Step)
+			Expect(step.Error).To(BeEmpty())
+		}
+		Expect(names).To(Equal([]string{
+			tablets.StepAddPeer, tablets.StepCatchUp, tablets.StepPromote,
+			tablets.StepStepDown, tablets.StepRemovePeer, tablets.StepDeleteReplica,
+		}))
+	})
+
+	It("lists the steps without running them on a dry run", func() {
+		out, err := runYugatool(cluster, "tablet", "move", tablet.ID, "--from", cluster.TabletServers[1].UUID, "--to", to.UUID, "--dry-run", "-o", "json")
+		Expect(err).NotTo(HaveOccurred(), out.String())
+
+		var steps []tablets.MoveStep
+		decodeReport(out, "Tablet Move", &steps)
+		Expect(steps).To(HaveLen(4))
+		Expect(steps[0].Time).To(BeEmpty())
+
+		Expect(replicas()).To(ConsistOf(cluster.TabletServers[0], cluster.TabletServers[1], cluster.TabletServers[2]))
+	})
+
+	It("reports the steps run when the move fails", func() {
+		cluster.BootstrapLag = 1000
+
+		out, err := runYugatool(cluster, "tablet", "move", tablet.ID, "--from", from.UUID, "--to", to.UUID, "--catch-up-timeout", "1", "--approve", "-o", "json")
+		Expect(err).To(MatchError(ContainSubstring("did not catch up with the leader within 1s")))
+
+		var steps []tablets.MoveStep
+		decodeReport(out, "Tablet Move", &steps)
+		Expect(steps).To(HaveLen(3))
+		Expect(steps[1].Error).To(ContainSubstring("operations behind"))
+		Expect(steps[2].Step).To(Equal(tablets.StepRollBack))
+
+		Expect(replicas()).To(ConsistOf(cluster.TabletServers[0], cluster.TabletServers[1], cluster.TabletServers[2]))
+	})
+
+	It("requires both tablet servers", func() {
+		_, err := runYugatool(cluster, "tablet", "move", tablet.ID, "--from", from.UUID)
+		Expect(err).To(MatchError("both --from and --to must be given"))
+	})
+})
@@ -0,0 +1,91 @@
+package cmd_test
+
+import (
+	"github.com/blang/vfs/memfs"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/common"
+	"github.com/yugabyte/yb-tools/yugatool/cmd/tablet"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/rpcserver"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/tablets"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/test/fakecluster"
+)
+
+var _ = Describe("tablet split", func() {
+	var (
+		cluster *fakecluster.Cluster
+		table   *fakecluster.Table
+	)
+
+	BeforeEach(func() {
+		cluster = fakecluster.New(logr.Discard(), rpcserver.NewNetwork(), "split", 1, 3)
+		table = cluster.AddTable("yugabyte", "test_table", 3)
+		table.Tablets[0].SSTSize = 12 << 30
+		table.Tablets[1].SSTSize = 1 << 30
+		table.Tablets[2].SSTSize = 16 << 30
+	})
+
+	It("lists the tablets larger than the target size on a dry run", func() {
+		out, err := runYugatool(cluster, "tablet", "split", "--table", "test_table", "--dry-run", "-o", "json")
+		Expect(err).NotTo(HaveOccurred(), out.String())
+
+		var splits []tablets.TabletSplit
+		decodeReport(out, "Tablet Splits", &splits)
+		Expect(splits).To(HaveLen(2))
+		for _, split := range splits {
+			Expect(split.Result).To(Equal(tablets.ResultPending))
+		}
+		Expect(table.Tablets).To(HaveLen(3))
+	})
+
+	It("splits the tablets and reports their children", func() {
+		out, err := runYugatool(cluster, "tablet", "split", "--table", "test_table", "--target-size", "10GB", "--approve", "-o", "json")
+		Expect(err).NotTo(HaveOccurred(), out.String())
+
+		var splits []tablets.TabletSplit
+		decodeReport(out, "Tablet Splits", &splits)
+		Expect(splits).To(HaveLen(2))
+		for _, split := range splits {
+			Expect(split.Result).To(Equal(tablets.ResultSplit))
+		}
+
+		var children []tablet.SplitTablet
+		decodeReport(out, "Split Tablets", &children)
+		Expect(children).To(HaveLen(4))
+		for _, child := range children {
+			Expect(child.State).To(Equal(common.RaftGroupStatePB_RUNNING.String()))
+		}
+	})
+
+	It("prints the hash range of the children", func() {
+		out, err := runYugatool(cluster, "tablet", "split", table.Tablets[0].ID, "--approve")
+		Expect(err).NotTo(HaveOccurred(), out.String())
+		Expect(out.String()).To(ContainSubstring("0x0000"))
+		Expect(out.String()).To(ContainSubstring("0x2aaa"))
+	})
+
+	It("fails for a tablet that is skipped", func() {
+		table.PartitionSchema = &common.PartitionSchemaPB{}
+
+		out, err := runYugatool(cluster, "tablet", "split", table.Tablets[0].ID, "--approve", "-o", "json")
+		Expect(err).To(MatchError("1 of 1 tablets could not be split"))
+
+		var splits []tablets.TabletSplit
+		decodeReport(out, "Tablet Splits", &splits)
+		Expect(splits[0].Error).To(Equal("table is range partitioned"))
+	})
+
+	It("requires tablets or a table without connecting to the universe", func() {
+		_, err := runYugatoolWithMasters(memfs.Create(), cluster, "nowhere:7100", "tablet", "split")
+		Expect(err).To(MatchError("either tablets or --table must be given"))
+
+		_, err = runYugatoolWithMasters(memfs.Create(), cluster, "nowhere:7100", "tablet", "split", table.Tablets[0].ID, "--table", "test_table")
+		Expect(err).To(MatchError("tablets and --table cannot both be given"))
+	})
+
+	It("rejects an invalid target size", func() {
+		_, err := runYugatool(cluster, "tablet", "split", "--table", "test_table", "--target-size", "large")
+		Expect(err).To(MatchError(ContainSubstring("invalid target-size")))
+	})
+})
@@ -0,0 +1,56 @@
+package cmd_test
+
+import (
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/healthcheck"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/rpcserver"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/test/fakecluster"
+)
+
+var _ = Describe("healthcheck tablets", func() {
+	var (
+		cluster *fakecluster.Cluster
+		table   *fakecluster.Table
+	)
+
+	BeforeEach(func() {
+		cluster = fakecluster.New(logr.Discard(), rpcserver.NewNetwork(), "tablets", 1, 3)
+		table = cluster.AddTable("yugabyte", "test_table", 3)
+		cluster.AddTable("other", "other_table", 1)
+	})
+
+	It("succeeds when every tablet is healthy", func() {
+		out, err := runYugatool(cluster, "healthcheck", "tablets", "-o", "json")
+		Expect(err).NotTo(HaveOccurred(), out.String())
+
+		var problems []healthcheck.TabletProblem
+		decodeReport(out, "Tablet Problems", &problems)
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("fails when a tablet has a problem", func() {
+		cluster.Lock()
+		table.Tablets[1].Leader = nil
+		cluster.Unlock()
+
+		out, err := runYugatool(cluster, "healthcheck", "tablets", "-o", "json")
+		Expect(err).To(MatchError("1 tablet problems found"))
+
+		var problems []healthcheck.TabletProblem
+		decodeReport(out, "Tablet Problems", &problems)
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].Tablet).To(Equal(table.Tablets[1].ID))
+		Expect(problems[0].Problem).To(Equal(healthcheck.ProblemNoLeader))
+	})
+
+	It("checks only the tables of the namespace", func() {
+		cluster.Lock()
+		table.Tablets[1].Leader = nil
+		cluster.Unlock()
+
+		_, err := runYugatool(cluster, "healthcheck", "tablets", "--namespace", "other")
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
@@ -0,0 +1,362 @@
+/*
+Copyright © 2021 Yugabyte Support
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"time"
+
+	. "github.com/icza/gox/gox"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/yugabyte/yb-tools/pkg/format"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/common"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/master"
+	"github.com/yugabyte/yb-tools/yugatool/api/yugatool/config"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client/dial"
+	yberrors "github.com/yugabyte/yb-tools/yugatool/pkg/client/errors"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/cmdutil"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/util"
+)
+
+func TLSCheckCmd(ctx *cmdutil.YugatoolContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tls_check",
+		Short: "Check the TLS certificates of the masters and tablet servers",
+		Long: `Check the TLS certificate of every master and tablet server that can be reached.
+The certificate chain, expiry and subject alternative names of each server are reported, with any
+problem verifying the certificate against the CA certificate and the name the server registered.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Servers with bad certificates must be listed to be checked, so the
+			// connection to the universe is made by the check itself
+			err := ctx.WithCmd(cmd).Prepare()
+			if err != nil {
+				return err
+			}
+
+			if ctx.GlobalOptions.ReplayRPCs != "" || ctx.GlobalOptions.Snapshot != "" {
+				return errors.New("tls_check cannot be run from recorded RPCs")
+			}
+
+			ctx.Client, err = cmdutil.NewClient(ctx)
+			if err != nil {
+				return err
+			}
+			defer ctx.Client.Close()
+
+			return tlsCheck(ctx)
+		},
+	}
+
+	return cmd
+}
+
+type CertificateCheck struct {
+	Role       string     `json:"role"`
+	UUID       string     `json:"uuid"`
+	Address    string     `json:"address"`
+	ServerName string     `json:"server_name"`
+	Subject    string     `json:"subject"`
+	Issuer     string     `json:"issuer"`
+	Chain      []string   `json:"chain"`
+	NotBefore  *time.Time `json:"not_before,omitempty"`
+	NotAfter   *time.Time `json:"not_after,omitempty"`
+	ExpiresIn  string     `json:"expires_in"`
+	SANs       []string   `json:"sans"`
+	// UncoveredNames are the names the server registered that its certificate is not valid for
+	UncoveredNames []string `json:"uncovered_names"`
+	Error          string   `json:"error"`
+}
+
+// tlsTarget is a server to check, with the addresses to reach it at and the
+// names it is known by.
+type tlsTarget struct {
+	role      string
+	uuid      string
+	addresses []client.Address
+	names     []string
+}
+
+func tlsCheck(ctx *cmdutil.YugatoolContext) error {
+	c := ctx.Client
+
+	if c.Config.TlsOpts == nil {
+		c.Config.TlsOpts = &config.TlsOptionsPB{}
+	}
+	tlsOpts := c.Config.TlsOpts
+	if tlsOpts.Policy != nil && tlsOpts.GetPolicy() == config.TlsPolicyPB_DISABLED {
+		return errors.New("tls_check cannot be run with tls-policy disabled")
+	}
+
+	// Certificates are verified with the CA certificate given, or the system's
+	// trusted CAs without one
+	tlsOpts.Policy = config.TlsPolicyPB_REQUIRED.Enum()
+	verifyConfig, err := c.TLSConfig()
+	if err != nil {
+		return err
+	}
+	skipHostVerification := tlsOpts.GetSkipHostVerification()
+
+	addressPolicy, err := client.NewAddressPolicy(c.Config.GetAddressPolicy())
+	if err != nil {
+		return err
+	}
+
+	// The servers are listed over a connection that does not verify them, so a
+	// bad certificate does not stop the others from being checked
+	tlsOpts.SkipHostVerification = NewBool(true)
+	tlsOpts.Policy = config.TlsPolicyPB_PREFERRED.Enum()
+
+	targets, err := listTLSTargets(ctx, c, addressPolicy)
+	if err != nil {
+		ctx.Log.Error(err, "could not list the servers of the universe, checking the configured masters only")
+
+		targets = nil
+		for _, m := range c.Config.GetMasters() {
+			targets = append(targets, tlsTarget{
+				role:      "MASTER",
+				addresses: []client.Address{addressPolicy.Address(m)},
+				names:     []string{m.GetHost()},
+			})
+		}
+	}
+
+	failed := 0
+	var checks []CertificateCheck
+	for _, target := range targets {
+		check := checkTarget(ctx, c, target, verifyConfig, skipHostVerification)
+		if check.Error != "" {
+			failed++
+		}
+		checks = append(checks, check)
+	}
+
+	checkReport := format.Output{
+		OutputMessage: "TLS Certificates",
+		JSONObject:    checks,
+		OutputType:    ctx.GlobalOptions.Output,
+		TableColumns: []format.Column{
+			{Name: "ROLE", JSONPath: "$.role"},
+			{Name: "UUID", JSONPath: "$.uuid"},
+			{Name: "ADDRESS", JSONPath: "$.address"},
+			{Name: "SUBJECT", JSONPath: "$.subject"},
+			{Name: "ISSUER", JSONPath: "$.issuer"},
+			{Name: "EXPIRES_IN", JSONPath: "$.expires_in"},
+			{Name: "SANS", JSONPath: "$.sans[*]"},
+			{Name: "UNCOVERED_NAMES", JSONPath: "$.uncovered_names[*]"},
+			{Name: "ERROR", JSONPath: "$.error"},
+		},
+	}
+
+	err = checkReport.Print()
+	if err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return errors.Errorf("%d of %d servers failed the TLS check", failed, len(checks))
+	}
+	return nil
+}
+
+// listTLSTargets connects to the universe and returns each master and tablet
+// server it lists.
+func listTLSTargets(ctx *cmdutil.YugatoolContext, c *client.YBClient, addressPolicy *client.AddressPolicy) ([]tlsTarget, error) {
+	err := c.ConnectWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	masters, err := c.Master.MasterService.ListMastersWithContext(ctx, &master.ListMastersRequestPB{})
+	if err != nil {
+		return nil, err
+	}
+	if err := yberrors.FromResponse(masters); err != nil {
+		return nil, fmt.Errorf("could not list masters: %w", err)
+	}
+
+	tabletServers, err := c.Master.MasterService.ListTabletServersWithContext(ctx, &master.ListTabletServersRequestPB{PrimaryOnly: NewBool(false)})
+	if err != nil {
+		return nil, err
+	}
+	if err := yberrors.FromResponse(tabletServers); err != nil {
+		return nil, fmt.Errorf("could not list tablet servers: %w", err)
+	}
+
+	var targets []tlsTarget
+	for _, m := range masters.GetMasters() {
+		targets = append(targets, tlsTarget{
+			role:      "MASTER",
+			uuid:      string(m.GetInstanceId().GetPermanentUuid()),
+			addresses: addressPolicy.Addresses(m.GetRegistration()),
+			names:     registeredNames(m.GetRegistration()),
+		})
+	}
+	for _, tserver := range tabletServers.GetServers() {
+		registration := tserver.GetRegistration().GetCommon()
+		targets = append(targets, tlsTarget{
+			role:      "TSERVER",
+			uuid:      string(tserver.GetInstanceId().GetPermanentUuid()),
+			addresses: addressPolicy.Addresses(registration),
+			names:     registeredNames(registration),
+		})
+	}
+	return targets, nil
+}
+
+// registeredNames returns the hosts a server registered, which clients may
+// verify its certificate against.
+func registeredNames(registration *common.ServerRegistrationPB) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, hostPort := range append(append([]*common.HostPortPB{}, registration.GetPrivateRpcAddresses()...), registration.GetBroadcastAddresses()...) {
+		if !seen[hostPort.GetHost()] {
+			seen[hostPort.GetHost()] = true
+			names = append(names, hostPort.GetHost())
+		}
+	}
+	return names
+}
+
+// checkTarget fetches the certificate of the server at the first of its
+// addresses that completes a TLS handshake, and verifies it.
+func checkTarget(ctx *cmdutil.YugatoolContext, c *client.YBClient, target tlsTarget, verifyConfig *tls.Config, skipHostVerification bool) CertificateCheck {
+	check := CertificateCheck{
+		Role: target.role,
+		UUID: target.uuid,
+	}
+	if len(target.addresses) == 0 {
+		check.Error = "server has no address matching the address policy"
+		return check
+	}
+
+	var state tls.ConnectionState
+	var err error
+	for _, address := range target.addresses {
+		check.Address = util.HostPortString(address.HostPort)
+		check.ServerName = address.ServerName
+
+		state, err = handshake(ctx, c, check.Address, address.ServerName, verifyConfig)
+		if err == nil {
+			break
+		}
+		ctx.Log.V(1).Info("could not complete TLS handshake", "host", check.Address, "error", err)
+	}
+	if err != nil {
+		check.Error = err.Error()
+		return check
+	}
+
+	if len(state.PeerCertificates) == 0 {
+		check.Error = "server sent no certificate"
+		return check
+	}
+
+	leaf := state.PeerCertificates[0]
+	check.Subject = leaf.Subject.String()
+	check.Issuer = leaf.Issuer.String()
+	check.NotBefore = &leaf.NotBefore
+	check.NotAfter = &leaf.NotAfter
+	check.SANs = dial.CertificateNames(leaf)
+
+	if remaining := time.Until(leaf.NotAfter); remaining > 0 {
+		check.ExpiresIn = fmt.Sprintf("%dd", int(remaining.Hours()/24))
+	} else {
+		check.ExpiresIn = "expired"
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range state.PeerCertificates {
+		check.Chain = append(check.Chain, cert.Subject.String())
+		if cert != leaf {
+			intermediates.AddCert(cert)
+		}
+	}
+
+	for _, name := range target.names {
+		if leaf.VerifyHostname(name) != nil {
+			check.UncoveredNames = append(check.UncoveredNames, name)
+		}
+	}
+
+	options := x509.VerifyOptions{
+		Roots:         verifyConfig.RootCAs,
+		Intermediates: intermediates,
+	}
+	if !skipHostVerification {
+		options.DNSName = check.ServerName
+	}
+	_, err = leaf.Verify(options)
+	if err != nil {
+		check.Error = dial.NewTLSError(check.Address, err).Error()
+	}
+
+	return check
+}
+
+// handshake runs a TLS handshake with the server without verifying it, and
+// returns the state of the connection.
+func handshake(ctx *cmdutil.YugatoolContext, c *client.YBClient, address, serverName string, verifyConfig *tls.Config) (tls.ConnectionState, error) {
+	dialContext, cancel := context.WithTimeout(ctx, time.Duration(c.Config.GetTimeoutSeconds())*time.Second)
+	defer cancel()
+
+	conn, err := dialTCP(dialContext, ctx, c, address)
+	if err != nil {
+		return tls.ConnectionState{}, err
+	}
+	defer conn.Close()
+
+	tlsConn := tls.Client(conn, &tls.Config{
+		// The certificate is verified after the handshake, to report on it even when it is not valid
+		InsecureSkipVerify: true,
+		ServerName:         serverName,
+		Certificates:       verifyConfig.Certificates,
+	})
+	err = tlsConn.HandshakeContext(dialContext)
+	if err != nil {
+		return tls.ConnectionState{}, errors.Wrapf(err, "TLS handshake with %s failed", address)
+	}
+	return tlsConn.ConnectionState(), nil
+}
+
+type contextDialer interface {
+	DialContext(ctx context.Context, network, address string) (net.Conn, error)
+}
+
+// dialTCP connects to the address the way the client does, through the proxy
+// or the dialer of the context when set.
+func dialTCP(dialContext context.Context, ctx *cmdutil.YugatoolContext, c *client.YBClient, address string) (net.Conn, error) {
+	if c.Proxy != nil {
+		return c.Proxy.DialContext(dialContext, "tcp", address)
+	}
+
+	if ctx.Dialer != nil {
+		dialer, ok := ctx.Dialer.(contextDialer)
+		if !ok {
+			return nil, errors.Errorf("%T cannot open connections for a TLS handshake", ctx.Dialer)
+		}
+		return dialer.DialContext(dialContext, "tcp", address)
+	}
+
+	netDialer := &net.Dialer{}
+	return netDialer.DialContext(dialContext, "tcp", address)
+}
@@ -0,0 +1,145 @@
+package cmd_test
+
+import (
+	"bytes"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"strings"
+	"time"
+
+	"github.com/blang/vfs"
+	"github.com/blang/vfs/memfs"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/yugabyte/yb-tools/yugatool/cmd"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/rpcserver"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/test/fakecluster"
+	testutil "github.com/yugabyte/yb-tools/yugatool/pkg/test/util"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/util"
+)
+
+var _ = Describe("tls_check", func() {
+	var (
+		cluster *fakecluster.Cluster
+		fs      vfs.Filesystem
+
+		caCert *x509.Certificate
+		caKey  *rsa.PrivateKey
+
+		out    *bytes.Buffer
+		err    error
+		checks map[string]cmd.CertificateCheck
+	)
+
+	certificate := func(notAfter time.Time, hosts ...string) tls.Certificate {
+		certPEM, keyPEM, err := testutil.GenerateServerCertificate(caCert, caKey, notAfter, hosts...)
+		Expect(err).NotTo(HaveOccurred())
+		certificate, err := tls.X509KeyPair(certPEM, keyPEM)
+		Expect(err).NotTo(HaveOccurred())
+		return certificate
+	}
+
+	BeforeEach(func() {
+		cluster = fakecluster.New(logr.Discard(), rpcserver.NewNetwork(), "tls-check", 1, 2)
+
+		var caPEM []byte
+		caPEM, caKey, err = testutil.GenerateCACertificate()
+		Expect(err).NotTo(HaveOccurred())
+		block, _ := pem.Decode(caPEM)
+		caCert, err = x509.ParseCertificate(block.Bytes)
+		Expect(err).NotTo(HaveOccurred())
+
+		fs = memfs.Create()
+		Expect(vfs.WriteFile(fs, "/ca.crt", caPEM, 0600)).To(Succeed())
+
+		for _, node := range append(append([]*fakecluster.Node{}, cluster.Masters...), cluster.TabletServers...) {
+			cluster.ServeTLS(node, certificate(time.Now().AddDate(1, 0, 0), node.Address.GetHost()))
+		}
+	})
+
+	JustBeforeEach(func() {
+		out, err = runYugatoolWithFs(fs, cluster, "tls_check", "-o", "json", "--cacert", "/ca.crt")
+
+		// A failed check prints its error after the report
+		output := out.String()
+		if i := strings.Index(output, "Error: "); i >= 0 {
+			output = output[:i]
+		}
+
+		checks = make(map[string]cmd.CertificateCheck)
+		for _, report := range decodeReports(bytes.NewBufferString(output)) {
+			if report.Msg == "TLS Certificates" {
+				var reported []cmd.CertificateCheck
+				Expect(json.Unmarshal(report.Content, &reported)).To(Succeed())
+				for _, check := range reported {
+					checks[check.UUID] = check
+				}
+			}
+		}
+	})
+
+	It("reports the certificate of every master and tablet server", func() {
+		Expect(err).NotTo(HaveOccurred(), out.String())
+		Expect(checks).To(HaveLen(3))
+
+		for _, node := range append(append([]*fakecluster.Node{}, cluster.Masters...), cluster.TabletServers...) {
+			check := checks[node.UUID]
+			Expect(check.Error).To(BeEmpty())
+			Expect(check.Subject).To(Equal("CN=" + node.Address.GetHost()))
+			Expect(check.Issuer).To(Equal("CN=Yugabyte DB"))
+			Expect(check.Chain).To(HaveLen(1))
+			Expect(check.SANs).To(Equal([]string{node.Address.GetHost()}))
+			Expect(check.UncoveredNames).To(BeEmpty())
+			Expect(check.ExpiresIn).To(MatchRegexp(`^36[45]d$`))
+		}
+	})
+
+	When("a tablet server's certificate has expired", func() {
+		BeforeEach(func() {
+			tserver := cluster.TabletServers[0]
+			cluster.ServeTLS(tserver, certificate(time.Now().Add(-time.Hour), tserver.Address.GetHost()))
+		})
+
+		It("reports the expired certificate", func() {
+			Expect(err).To(MatchError("1 of 3 servers failed the TLS check"))
+
+			check := checks[cluster.TabletServers[0].UUID]
+			Expect(check.ExpiresIn).To(Equal("expired"))
+			Expect(check.Error).To(ContainSubstring("rotate the server certificates"))
+
+			Expect(checks[cluster.TabletServers[1].UUID].Error).To(BeEmpty())
+		})
+	})
+
+	When("a tablet server's certificate does not cover its registered name", func() {
+		BeforeEach(func() {
+			tserver := cluster.TabletServers[1]
+			cluster.ServeTLS(tserver, certificate(time.Now().AddDate(1, 0, 0), "other-host"))
+		})
+
+		It("reports the names the certificate does not cover", func() {
+			Expect(err).To(MatchError("1 of 3 servers failed the TLS check"))
+
+			tserver := cluster.TabletServers[1]
+			check := checks[tserver.UUID]
+			Expect(check.SANs).To(Equal([]string{"other-host"}))
+			Expect(check.UncoveredNames).To(Equal([]string{tserver.Address.GetHost()}))
+			Expect(check.Error).To(ContainSubstring("only for [other-host]"))
+		})
+	})
+
+	When("a server does not serve TLS", func() {
+		BeforeEach(func() {
+			cluster.Network.ServeTLS(util.HostPortString(cluster.Masters[0].Address), nil)
+		})
+
+		It("reports the failed handshake", func() {
+			Expect(err).To(MatchError("1 of 3 servers failed the TLS check"))
+			Expect(checks[cluster.Masters[0].UUID].Error).To(ContainSubstring("TLS handshake with"))
+		})
+	})
+})
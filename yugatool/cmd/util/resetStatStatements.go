@@ -1,18 +1,22 @@
 package util
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"net"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	. "github.com/icza/gox/gox"
-	_ "github.com/lib/pq" // NOLINT
+	"github.com/lib/pq"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/yugabyte/yb-tools/yugatool/api/yb/common"
 	"github.com/yugabyte/yb-tools/yugatool/api/yb/server"
 	"github.com/yugabyte/yb-tools/yugatool/pkg/client"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client/dial"
 	"github.com/yugabyte/yb-tools/yugatool/pkg/client/session"
 	"github.com/yugabyte/yb-tools/yugatool/pkg/cmdutil"
 )
@@ -29,7 +33,7 @@ func ResetStatStatementsCmd(ctx *cmdutil.YugatoolContext) *cobra.Command {
 			}
 			defer ctx.Client.Close()
 
-			return resetAllStatStatements(ctx.Log, ctx.Client, options)
+			return resetAllStatStatements(ctx, ctx.Log, ctx.Client, options)
 		},
 	}
 	options.AddFlags(cmd)
@@ -60,7 +64,7 @@ func (o *ResetStatStatementsOptions) Validate() error {
 	return nil
 }
 
-func getPostgresHostPort(log logr.Logger, ybclient *client.YBClient, uuid []byte) (*common.HostPortPB, error) {
+func getPostgresHostPort(ctx context.Context, log logr.Logger, ybclient *client.YBClient, uuid []byte) (*common.HostPortPB, error) {
 	getHostError := func(err error) (*common.HostPortPB, error) {
 		return &common.HostPortPB{}, fmt.Errorf("could not get postgres bind address: %w", err)
 	}
@@ -70,7 +74,7 @@ func getPostgresHostPort(log logr.Logger, ybclient *client.YBClient, uuid []byte
 		return getHostError(err)
 	}
 
-	flag, err := host.GenericService.GetFlag(&server.GetFlagRequestPB{
+	flag, err := host.GenericService.GetFlagWithContext(ctx, &server.GetFlagRequestPB{
 		Flag: NewString("pgsql_proxy_bind_address"),
 	})
 	if err != nil {
@@ -97,7 +101,7 @@ func getPostgresHostPort(log logr.Logger, ybclient *client.YBClient, uuid []byte
 	}, nil
 }
 
-func resetAllStatStatements(log logr.Logger, ybclient *client.YBClient, options *ResetStatStatementsOptions) error {
+func resetAllStatStatements(ctx context.Context, log logr.Logger, ybclient *client.YBClient, options *ResetStatStatementsOptions) error {
 	log.Info("getting postgres hosts...")
 	hosts, errors := ybclient.AllTservers()
 	if len(errors) > 0 {
@@ -111,19 +115,19 @@ func resetAllStatStatements(log logr.Logger, ybclient *client.YBClient, options
 	}
 
 	for _, host := range hosts {
-		hostport, err := getPostgresHostPort(log, ybclient, host.Status.NodeInstance.PermanentUuid)
+		hostport, err := getPostgresHostPort(ctx, log, ybclient, host.Status.NodeInstance.PermanentUuid)
 		if err != nil {
 			log.Error(err, "could not find postgres hostport")
 			continue
 		}
 
-		encryptionEnabled, err := isPostgresEncryptionEnabled(ybclient, host.Status.NodeInstance.PermanentUuid)
+		encryptionEnabled, err := isPostgresEncryptionEnabled(ctx, ybclient, host.Status.NodeInstance.PermanentUuid)
 		if err != nil {
 			// not the end of the world, try to connect anyway
 			log.Error(err, "could not determine if encryption is enabled")
 		}
 
-		err = resetStatStatements(log, hostport, options, encryptionEnabled)
+		err = resetStatStatements(ctx, log, hostport, options, encryptionEnabled, ybclient.Proxy)
 		if err != nil {
 			log.Error(err, "could not reset pg_stat_statements")
 		}
@@ -132,7 +136,7 @@ func resetAllStatStatements(log logr.Logger, ybclient *client.YBClient, options
 	return nil
 }
 
-func resetStatStatements(log logr.Logger, host *common.HostPortPB, options *ResetStatStatementsOptions, encryptionEnabled bool) error {
+func resetStatStatements(ctx context.Context, log logr.Logger, host *common.HostPortPB, options *ResetStatStatementsOptions, encryptionEnabled bool, proxy dial.ProxyDialer) error {
 	sslMode := "disable"
 	if encryptionEnabled {
 		sslMode = "require"
@@ -147,25 +151,29 @@ func resetStatStatements(log logr.Logger, host *common.HostPortPB, options *Rese
 	if options.Password != "" {
 		psqlInfo = fmt.Sprintf("%s password=%s", psqlInfo, options.Password)
 	}
-	db, err := sql.Open("postgres", psqlInfo)
+	connector, err := pq.NewConnector(psqlInfo)
 	if err != nil {
 		return err
 	}
+	if proxy != nil {
+		connector.Dialer(&postgresDialer{proxy: proxy})
+	}
+	db := sql.OpenDB(connector)
 	defer db.Close()
 
 	log.Info("resetting pg_stat_statements")
-	_, err = db.Exec(`SELECT pg_stat_statements_reset();`)
+	_, err = db.ExecContext(ctx, `SELECT pg_stat_statements_reset();`)
 
 	return err
 }
 
-func isPostgresEncryptionEnabled(ybclient *client.YBClient, uuid []byte) (bool, error) {
+func isPostgresEncryptionEnabled(ctx context.Context, ybclient *client.YBClient, uuid []byte) (bool, error) {
 	host, err := ybclient.GetHostByUUID(uuid)
 	if err != nil {
 		return false, err
 	}
 
-	flag, err := host.GenericService.GetFlag(&server.GetFlagRequestPB{
+	flag, err := host.GenericService.GetFlagWithContext(ctx, &server.GetFlagRequestPB{
 		Flag: NewString("use_client_to_server_encryption"),
 	})
 	if err != nil {
@@ -180,3 +188,22 @@ func isPostgresEncryptionEnabled(ybclient *client.YBClient, uuid []byte) (bool,
 	}
 	return false, nil
 }
+
+// postgresDialer opens the connections of lib/pq through the proxy
+type postgresDialer struct {
+	proxy dial.ProxyDialer
+}
+
+func (d *postgresDialer) Dial(network, address string) (net.Conn, error) {
+	return d.DialContext(context.Background(), network, address)
+}
+
+func (d *postgresDialer) DialTimeout(network, address string, timeout time.Duration) (net.Conn, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return d.DialContext(ctx, network, address)
+}
+
+func (d *postgresDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	return d.proxy.DialContext(ctx, network, address)
+}
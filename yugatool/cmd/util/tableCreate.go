@@ -17,6 +17,7 @@ limitations under the License.
 package util
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"time"
@@ -46,7 +47,7 @@ func TableCreateCmd(ctx *cmdutil.YugatoolContext) *cobra.Command {
 			}
 			defer ctx.Client.Close()
 
-			return tableCreate(ctx.Log, ctx.Client, options, ctx.GlobalOptions)
+			return tableCreate(ctx, ctx.Log, ctx.Client, options, ctx.GlobalOptions)
 		},
 	}
 	options.AddFlags(cmd)
@@ -83,9 +84,9 @@ func (o *CreateOptions) Validate() error {
 	return nil
 }
 
-func tableCreate(log logr.Logger, c *client.YBClient, options *CreateOptions, globalOptions *cmdutil.GlobalOptions) error {
+func tableCreate(ctx context.Context, log logr.Logger, c *client.YBClient, options *CreateOptions, globalOptions *cmdutil.GlobalOptions) error {
 	log.Info("getting cql hosts...")
-	cqlHosts, err := getCqlHosts(log, c)
+	cqlHosts, err := getCqlHosts(ctx, log, c)
 	if err != nil {
 		return err
 	}
@@ -101,6 +102,10 @@ func tableCreate(log logr.Logger, c *client.YBClient, options *CreateOptions, gl
 
 	ycqlClient.Timeout = time.Duration(globalOptions.DialTimeout) * time.Second
 
+	if c.Proxy != nil {
+		ycqlClient.Dialer = c.Proxy
+	}
+
 	if globalOptions.ClientCert != "" || globalOptions.ClientKey != "" || globalOptions.CACert != "" || globalOptions.SkipHostVerification {
 		ycqlClient.SslOpts = &gocql.SslOptions{
 			CertPath:               globalOptions.ClientCert,
@@ -142,7 +147,7 @@ func tableCreate(log logr.Logger, c *client.YBClient, options *CreateOptions, gl
 	return err
 }
 
-func getCqlHosts(log logr.Logger, ybClient *client.YBClient) ([]string, error) {
+func getCqlHosts(ctx context.Context, log logr.Logger, ybClient *client.YBClient) ([]string, error) {
 	getHostsError := func(err error) ([]string, error) {
 		return []string{}, fmt.Errorf("could not get cql bind address: %w", err)
 	}
@@ -160,7 +165,7 @@ func getCqlHosts(log logr.Logger, ybClient *client.YBClient) ([]string, error) {
 	}
 
 	for _, host := range hosts {
-		flag, err := host.GenericService.GetFlag(&server.GetFlagRequestPB{
+		flag, err := host.GenericService.GetFlagWithContext(ctx, &server.GetFlagRequestPB{
 			Flag: NewString("cql_proxy_bind_address"),
 		})
 		if err != nil {
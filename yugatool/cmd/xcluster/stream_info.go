@@ -65,7 +65,7 @@ func streamInfo(ctx *cmdutil.YugatoolContext, options *ClusterInfoOptions) error
 	c := ctx.Client
 
 	// TODO: set tableId if requested
-	listCDCStreamsResponse, err := c.Master.MasterService.ListCDCStreams(&master.ListCDCStreamsRequestPB{
+	listCDCStreamsResponse, err := c.Master.MasterService.ListCDCStreamsWithContext(ctx, &master.ListCDCStreamsRequestPB{
 		TableId: nil,
 	})
 	if err != nil {
@@ -94,7 +94,7 @@ func streamInfo(ctx *cmdutil.YugatoolContext, options *ClusterInfoOptions) error
 			continue
 		}
 
-		tableSchemaResponse, err := c.Master.MasterService.GetTableSchema(&master.GetTableSchemaRequestPB{
+		tableSchemaResponse, err := c.Master.MasterService.GetTableSchemaWithContext(ctx, &master.GetTableSchemaRequestPB{
 			Table: &master.TableIdentifierPB{
 				TableId: stream.GetTableId(),
 			},
@@ -0,0 +1,95 @@
+package cmd_test
+
+import (
+	"github.com/go-logr/logr"
+	. "github.com/icza/gox/gox"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/cdc"
+	ybutil "github.com/yugabyte/yb-tools/yugatool/api/yb/util"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/rpcserver"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/test/fakecluster"
+)
+
+var _ = Describe("healthcheck xcluster_consumer_check", func() {
+	const streamID = "stream-1"
+
+	var (
+		producer, consumer           *fakecluster.Cluster
+		producerTable, consumerTable *fakecluster.Table
+		stream                       *cdc.StreamEntryPB
+	)
+
+	BeforeEach(func() {
+		network := rpcserver.NewNetwork()
+		producer = fakecluster.New(logr.Discard(), network, "producer", 1, 3)
+		consumer = fakecluster.New(logr.Discard(), network, "consumer", 1, 3)
+
+		producerTable = producer.AddTable("yugabyte", "replicated", 2)
+		consumerTable = consumer.AddTable("yugabyte", "replicated", 2)
+
+		stream = &cdc.StreamEntryPB{
+			ConsumerTableId:           consumerTable.ID,
+			ProducerTableId:           producerTable.ID,
+			ConsumerProducerTabletMap: map[string]*cdc.ProducerTabletListPB{},
+		}
+		for i, tablet := range consumerTable.Tablets {
+			stream.ConsumerProducerTabletMap[tablet.ID] = &cdc.ProducerTabletListPB{
+				Tablets: []string{producerTable.Tablets[i].ID},
+			}
+			producer.SetCheckpoint(streamID, producerTable.Tablets[i], producerTable.Tablets[i].LastOpID)
+		}
+
+		consumer.ClusterConfig.ConsumerRegistry = &cdc.ConsumerRegistryPB{
+			ProducerMap: map[string]*cdc.ProducerEntryPB{
+				producer.ClusterConfig.GetClusterUuid(): {
+					StreamMap:   map[string]*cdc.StreamEntryPB{streamID: stream},
+					MasterAddrs: producer.MasterHostPorts(),
+				},
+			},
+		}
+	})
+
+	runCheck := func() string {
+		out, err := runYugatool(consumer, "healthcheck", "xcluster_consumer_check")
+		Expect(err).NotTo(HaveOccurred(), out.String())
+		return out.String()
+	}
+
+	It("reports nothing when replication is healthy", func() {
+		Expect(runCheck()).To(BeEmpty())
+	})
+
+	When("a tablet is behind the producer", func() {
+		BeforeEach(func() {
+			producer.SetCheckpoint(streamID, producerTable.Tablets[0], &ybutil.OpIdPB{Term: NewInt64(1), Index: NewInt64(0)})
+		})
+
+		It("reports the replication lag", func() {
+			out := runCheck()
+			Expect(out).To(ContainSubstring("tablets_with_replication_lag"))
+			Expect(out).To(ContainSubstring(producerTable.Tablets[0].ID))
+			Expect(out).NotTo(ContainSubstring(producerTable.Tablets[1].ID))
+		})
+	})
+
+	When("a producer tablet no longer exists", func() {
+		BeforeEach(func() {
+			stream.ConsumerProducerTabletMap[consumerTable.Tablets[0].ID].Tablets = []string{"00000000000000000000000000000000"}
+		})
+
+		It("reports the missing tablet", func() {
+			Expect(runCheck()).To(MatchRegexp(`missing_tablets_producer:\s+"00000000000000000000000000000000"`))
+		})
+	})
+
+	When("the schemas differ", func() {
+		BeforeEach(func() {
+			consumerTable.Schema.Columns = consumerTable.Schema.Columns[:1]
+		})
+
+		It("reports the schema mismatch", func() {
+			Expect(runCheck()).To(ContainSubstring("schema_mismatch_error"))
+		})
+	})
+})
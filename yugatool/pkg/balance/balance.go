@@ -0,0 +1,351 @@
+// Package balance reports how the tablet leaders and replicas of a universe are
+// spread across its tablet servers, zones and regions, and where that placement
+// breaks the placement policy of the universe or its tables.
+package balance
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/common"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/master"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/snapshot"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/util"
+)
+
+// Levels of placement that load is counted at
+const (
+	LevelTabletServer = "tserver"
+	LevelZone         = "zone"
+	LevelRegion       = "region"
+)
+
+var levels = []string{LevelTabletServer, LevelZone, LevelRegion}
+
+// AllTables names the load of all tables together
+const AllTables = "(all)"
+
+// Load is the number of tablet leaders and followers of a table in one tablet
+// server, zone or region.
+type Load struct {
+	TableID   string `json:"table_id"`
+	Table     string `json:"table"`
+	Level     string `json:"level"`
+	Placement string `json:"placement"`
+	Leaders   int    `json:"leaders"`
+	Followers int    `json:"followers"`
+}
+
+// Skew is the difference between the most and least loaded placements of a level
+// for a table. The load balancer keeps a balanced table within one of even.
+type Skew struct {
+	TableID     string `json:"table_id"`
+	Table       string `json:"table"`
+	Level       string `json:"level"`
+	LeaderSkew  int    `json:"leader_skew"`
+	ReplicaSkew int    `json:"replica_skew"`
+}
+
+// Violation is a tablet placed against the placement policy.
+type Violation struct {
+	TableID      string `json:"table_id"`
+	Table        string `json:"table"`
+	Tablet       string `json:"tablet"`
+	TabletServer string `json:"tserver,omitempty"`
+	Violation    string `json:"violation"`
+}
+
+type Report struct {
+	Load       []Load
+	Skew       []Skew
+	Violations []Violation
+}
+
+// tabletServer is a live tablet server and where it is placed
+type tabletServer struct {
+	uuid    string
+	address string
+	cloud   *common.CloudInfoPB
+
+	// leaderBlacklisted tablet servers are not to lead any tablets
+	leaderBlacklisted bool
+}
+
+func (ts *tabletServer) placement(level string) string {
+	switch level {
+	case LevelZone:
+		return fmt.Sprintf("%s.%s.%s", ts.cloud.GetPlacementCloud(), ts.cloud.GetPlacementRegion(), ts.cloud.GetPlacementZone())
+	case LevelRegion:
+		return fmt.Sprintf("%s.%s", ts.cloud.GetPlacementCloud(), ts.cloud.GetPlacementRegion())
+	}
+	return ts.address
+}
+
+// tablet is a tablet as seen from all of its replicas
+type tablet struct {
+	id       string
+	tableID  string
+	replicas []*tabletServer
+	leader   *tabletServer
+}
+
+type table struct {
+	id      string
+	name    string
+	tablets []*tablet
+	policy  *master.ReplicationInfoPB
+}
+
+// Analyze counts the load of each table, and checks each tablet against the
+// placement policy of its table, or of the universe when the table has none.
+func Analyze(state *snapshot.State) *Report {
+	tabletServers, tabletServersByUUID := liveTabletServers(state)
+	tables := collectTables(state, tabletServersByUUID)
+
+	report := &Report{}
+	all := &table{id: "", name: AllTables}
+	for _, t := range tables {
+		report.Violations = append(report.Violations, t.violations()...)
+		all.tablets = append(all.tablets, t.tablets...)
+	}
+	for _, t := range append(tables, all) {
+		for _, level := range levels {
+			load := t.load(level, tabletServers)
+			report.Load = append(report.Load, load...)
+			report.Skew = append(report.Skew, skew(t, level, load))
+		}
+	}
+	return report
+}
+
+// liveTabletServers returns the live tablet servers in the order of their
+// addresses, and by UUID
+func liveTabletServers(state *snapshot.State) ([]*tabletServer, map[string]*tabletServer) {
+	var tabletServers []*tabletServer
+	tabletServersByUUID := make(map[string]*tabletServer)
+	for _, ts := range state.TabletServers {
+		if !ts.GetAlive() {
+			continue
+		}
+		server := &tabletServer{
+			uuid:              string(ts.GetInstanceId().GetPermanentUuid()),
+			cloud:             ts.GetRegistration().GetCommon().GetCloudInfo(),
+			leaderBlacklisted: Blacklisted(state.ClusterConfig.GetLeaderBlacklist(), ts),
+		}
+		server.address = server.uuid
+		if addresses := ts.GetRegistration().GetCommon().GetPrivateRpcAddresses(); len(addresses) > 0 {
+			server.address = util.HostPortString(addresses[0])
+		}
+		tabletServers = append(tabletServers, server)
+		tabletServersByUUID[server.uuid] = server
+	}
+	sort.Slice(tabletServers, func(i, j int) bool {
+		return tabletServers[i].address < tabletServers[j].address
+	})
+	return tabletServers, tabletServersByUUID
+}
+
+// collectTables gathers the tablets of each table from the tablet servers that
+// host them, in the order of the table names
+func collectTables(state *snapshot.State, tabletServers map[string]*tabletServer) []*table {
+	tables := make(map[string]*table)
+	for _, info := range state.Tables {
+		id := string(info.GetId())
+		t := &table{
+			id:     id,
+			name:   info.GetNamespace().GetName() + "." + info.GetName(),
+			policy: state.ClusterConfig.GetReplicationInfo(),
+		}
+		if policy := state.Schemas[id].GetReplicationInfo(); policy.GetLiveReplicas() != nil {
+			t.policy = policy
+		}
+		tables[id] = t
+	}
+
+	tablets := make(map[string]*tablet)
+	for uuid, replicas := range state.Tablets {
+		ts, ok := tabletServers[uuid]
+		if !ok {
+			continue
+		}
+		for _, replica := range replicas {
+			switch replica.Status.GetTabletDataState() {
+			case common.TabletDataState_TABLET_DATA_TOMBSTONED, common.TabletDataState_TABLET_DATA_DELETED:
+				continue
+			}
+
+			t, ok := tables[replica.Status.GetTableId()]
+			if !ok {
+				continue
+			}
+
+			id := replica.Status.GetTabletId()
+			tab, ok := tablets[id]
+			if !ok {
+				tab = &tablet{id: id, tableID: t.id}
+				tablets[id] = tab
+				t.tablets = append(t.tablets, tab)
+			}
+			tab.replicas = append(tab.replicas, ts)
+			if replica.ConsensusState.GetCstate().GetLeaderUuid() == uuid {
+				tab.leader = ts
+			}
+		}
+	}
+
+	var sorted []*table
+	for _, t := range tables {
+		// Tables hosted by the masters, such as the system tables, have no load
+		if len(t.tablets) == 0 {
+			continue
+		}
+		sort.Slice(t.tablets, func(i, j int) bool {
+			return t.tablets[i].id < t.tablets[j].id
+		})
+		sorted = append(sorted, t)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].name != sorted[j].name {
+			return sorted[i].name < sorted[j].name
+		}
+		return sorted[i].id < sorted[j].id
+	})
+	return sorted
+}
+
+// load counts the leaders and followers of the table in each placement of the
+// level, including the placements of live tablet servers without any
+func (t *table) load(level string, tabletServers []*tabletServer) []Load {
+	var load []Load
+	index := make(map[string]int)
+	for _, ts := range tabletServers {
+		placement := ts.placement(level)
+		if _, ok := index[placement]; !ok {
+			index[placement] = len(load)
+			load = append(load, Load{TableID: t.id, Table: t.name, Level: level, Placement: placement})
+		}
+	}
+
+	for _, tab := range t.tablets {
+		for _, replica := range tab.replicas {
+			l := &load[index[replica.placement(level)]]
+			if replica == tab.leader {
+				l.Leaders++
+			} else {
+				l.Followers++
+			}
+		}
+	}
+	return load
+}
+
+func skew(t *table, level string, load []Load) Skew {
+	s := Skew{TableID: t.id, Table: t.name, Level: level}
+	if len(load) == 0 {
+		return s
+	}
+
+	minLeaders, maxLeaders := load[0].Leaders, load[0].Leaders
+	minReplicas, maxReplicas := load[0].Leaders+load[0].Followers, load[0].Leaders+load[0].Followers
+	for _, l := range load[1:] {
+		replicas := l.Leaders + l.Followers
+		if l.Leaders < minLeaders {
+			minLeaders = l.Leaders
+		}
+		if l.Leaders > maxLeaders {
+			maxLeaders = l.Leaders
+		}
+		if replicas < minReplicas {
+			minReplicas = replicas
+		}
+		if replicas > maxReplicas {
+			maxReplicas = replicas
+		}
+	}
+	s.LeaderSkew = maxLeaders - minLeaders
+	s.ReplicaSkew = maxReplicas - minReplicas
+	return s
+}
+
+// violations checks the replica count, placement blocks and preferred leader
+// zones of each tablet of the table
+func (t *table) violations() []Violation {
+	var violations []Violation
+	violation := func(tab *tablet, ts *tabletServer, format string, args ...interface{}) {
+		v := Violation{TableID: t.id, Table: t.name, Tablet: tab.id, Violation: fmt.Sprintf(format, args...)}
+		if ts != nil {
+			v.TabletServer = ts.address
+		}
+		violations = append(violations, v)
+	}
+
+	live := t.policy.GetLiveReplicas()
+	for _, tab := range t.tablets {
+		if numReplicas := int(live.GetNumReplicas()); numReplicas > 0 && len(tab.replicas) != numReplicas {
+			violation(tab, nil, "has %d replicas, the placement policy requires %d", len(tab.replicas), numReplicas)
+		}
+
+		if blocks := live.GetPlacementBlocks(); len(blocks) > 0 {
+			for _, replica := range tab.replicas {
+				if !inAnyPlacement(replica.cloud, blocks) {
+					violation(tab, replica, "has a replica in %s, outside the placement blocks", replica.placement(LevelZone))
+				}
+			}
+
+			for _, block := range blocks {
+				var replicas int
+				for _, replica := range tab.replicas {
+					if inPlacement(replica.cloud, block.GetCloudInfo()) {
+						replicas++
+					}
+				}
+				if replicas < int(block.GetMinNumReplicas()) {
+					violation(tab, nil, "has %d replicas in %s, the placement policy requires at least %d",
+						replicas, placementName(block.GetCloudInfo()), block.GetMinNumReplicas())
+				}
+			}
+		}
+
+		if preferred := t.policy.GetAffinitizedLeaders(); len(preferred) > 0 && tab.leader != nil {
+			var onPreferred bool
+			for _, cloud := range preferred {
+				if inPlacement(tab.leader.cloud, cloud) {
+					onPreferred = true
+				}
+			}
+			if !onPreferred {
+				violation(tab, tab.leader, "has its leader in %s, outside the preferred leader zones", tab.leader.placement(LevelZone))
+			}
+		}
+	}
+	return violations
+}
+
+func inAnyPlacement(cloud *common.CloudInfoPB, blocks []*master.PlacementBlockPB) bool {
+	for _, block := range blocks {
+		if inPlacement(cloud, block.GetCloudInfo()) {
+			return true
+		}
+	}
+	return false
+}
+
+// inPlacement returns whether the cloud info is within the placement, which
+// matches any cloud, region or zone it does not give
+func inPlacement(cloud *common.CloudInfoPB, placement *common.CloudInfoPB) bool {
+	return (placement.GetPlacementCloud() == "" || placement.GetPlacementCloud() == cloud.GetPlacementCloud()) &&
+		(placement.GetPlacementRegion() == "" || placement.GetPlacementRegion() == cloud.GetPlacementRegion()) &&
+		(placement.GetPlacementZone() == "" || placement.GetPlacementZone() == cloud.GetPlacementZone())
+}
+
+func placementName(placement *common.CloudInfoPB) string {
+	var parts []string
+	for _, part := range []string{placement.GetPlacementCloud(), placement.GetPlacementRegion(), placement.GetPlacementZone()} {
+		if part == "" {
+			part = "*"
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, ".")
+}
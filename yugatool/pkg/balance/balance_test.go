@@ -0,0 +1,330 @@
+package balance_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/blang/vfs/memfs"
+	"github.com/go-logr/logr"
+	. "github.com/icza/gox/gox"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/common"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/master"
+	"github.com/yugabyte/yb-tools/yugatool/api/yugatool/config"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/balance"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client"
+	yberrors "github.com/yugabyte/yb-tools/yugatool/pkg/client/errors"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/rpcserver"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/snapshot"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/test/fakecluster"
+)
+
+func zone(name string) *common.CloudInfoPB {
+	return &common.CloudInfoPB{
+		PlacementCloud:  NewString(fakecluster.Cloud),
+		PlacementRegion: NewString(fakecluster.Region),
+		PlacementZone:   NewString(name),
+	}
+}
+
+var _ = Describe("Balance", func() {
+	var (
+		cluster *fakecluster.Cluster
+		table   *fakecluster.Table
+		c       *client.YBClient
+	)
+
+	BeforeEach(func() {
+		cluster = fakecluster.New(logr.Discard(), rpcserver.NewNetwork(), "balance", 1, 3)
+		table = cluster.AddTable("yugabyte", "test_table", 3)
+
+		c = &client.YBClient{
+			Log: logr.Discard(),
+			Fs:  memfs.Create(),
+			Config: &config.UniverseConfigPB{
+				Masters:        cluster.MasterHostPorts(),
+				TimeoutSeconds: NewInt64(1),
+			},
+		}
+		c.OverrideDialer(cluster.Network)
+		Expect(c.Connect()).To(Succeed())
+	})
+
+	AfterEach(func() {
+		c.Close()
+	})
+
+	analyze := func() *balance.Report {
+		state, err := snapshot.Collect(context.Background(), logr.Discard(), c, snapshot.CollectOptions{Concurrency: 1})
+		Expect(err).NotTo(HaveOccurred())
+		return balance.Analyze(state)
+	}
+
+	skews := func(report *balance.Report, table string) map[string]balance.Skew {
+		skews := make(map[string]balance.Skew)
+		for _, skew := range report.Skew {
+			if skew.Table == table {
+				skews[skew.Level] = skew
+			}
+		}
+		return skews
+	}
+
+	Context("Analyze", func() {
+		It("counts the leaders and followers of each table", func() {
+			report := analyze()
+
+			var load []balance.Load
+			for _, l := range report.Load {
+				if l.TableID == table.ID && l.Level == balance.LevelTabletServer {
+					load = append(load, l)
+				}
+			}
+			Expect(load).To(Equal([]balance.Load{
+				{TableID: table.ID, Table: "yugabyte.test_table", Level: balance.LevelTabletServer, Placement: "balance-tserver-1:9100", Leaders: 1, Followers: 2},
+				{TableID: table.ID, Table: "yugabyte.test_table", Level: balance.LevelTabletServer, Placement: "balance-tserver-2:9100", Leaders: 1, Followers: 2},
+				{TableID: table.ID, Table: "yugabyte.test_table", Level: balance.LevelTabletServer, Placement: "balance-tserver-3:9100", Leaders: 1, Followers: 2},
+			}))
+
+			for _, skew := range skews(report, "yugabyte.test_table") {
+				Expect(skew.LeaderSkew).To(BeZero())
+				Expect(skew.ReplicaSkew).To(BeZero())
+			}
+			Expect(report.Violations).To(BeEmpty())
+		})
+
+		It("measures the skew of each level", func() {
+			cluster.Lock()
+			for _, tablet := range table.Tablets {
+				tablet.Leader = cluster.TabletServers[0]
+			}
+			cluster.Unlock()
+			cluster.AddTable("yugabyte", "other_table", 2)
+
+			report := analyze()
+			tableSkew := skews(report, "yugabyte.test_table")
+			Expect(tableSkew[balance.LevelTabletServer].LeaderSkew).To(Equal(3))
+			Expect(tableSkew[balance.LevelZone].LeaderSkew).To(Equal(3))
+			Expect(tableSkew[balance.LevelRegion].LeaderSkew).To(BeZero())
+			Expect(tableSkew[balance.LevelTabletServer].ReplicaSkew).To(BeZero())
+
+			// other_table leads its tablets from the first two tablet servers
+			allSkew := skews(report, balance.AllTables)
+			Expect(allSkew[balance.LevelTabletServer].LeaderSkew).To(Equal(4))
+			Expect(allSkew[balance.LevelTabletServer].ReplicaSkew).To(BeZero())
+		})
+
+		It("reports tablets placed against the placement policy", func() {
+			cluster.Lock()
+			table.ReplicationInfo = &master.ReplicationInfoPB{
+				LiveReplicas: &master.PlacementInfoPB{
+					NumReplicas: NewInt32(2),
+					PlacementBlocks: []*master.PlacementBlockPB{
+						{CloudInfo: zone("zone-1"), MinNumReplicas: NewInt32(1)},
+						{CloudInfo: zone("zone-2"), MinNumReplicas: NewInt32(1)},
+					},
+				},
+				AffinitizedLeaders: []*common.CloudInfoPB{zone("zone-1")},
+			}
+			// The first tablet follows the policy
+			table.Tablets[0].Replicas = table.Tablets[0].Replicas[:2]
+			cluster.Unlock()
+
+			report := analyze()
+			Expect(report.Violations).To(ConsistOf(
+				balance.Violation{TableID: table.ID, Table: "yugabyte.test_table", Tablet: table.Tablets[1].ID,
+					Violation: "has 3 replicas, the placement policy requires 2"},
+				balance.Violation{TableID: table.ID, Table: "yugabyte.test_table", Tablet: table.Tablets[1].ID, TabletServer: "balance-tserver-3:9100",
+					Violation: "has a replica in cloud-1.region-1.zone-3, outside the placement blocks"},
+				balance.Violation{TableID: table.ID, Table: "yugabyte.test_table", Tablet: table.Tablets[1].ID, TabletServer: "balance-tserver-2:9100",
+					Violation: "has its leader in cloud-1.region-1.zone-2, outside the preferred leader zones"},
+				balance.Violation{TableID: table.ID, Table: "yugabyte.test_table", Tablet: table.Tablets[2].ID,
+					Violation: "has 3 replicas, the placement policy requires 2"},
+				balance.Violation{TableID: table.ID, Table: "yugabyte.test_table", Tablet: table.Tablets[2].ID, TabletServer: "balance-tserver-3:9100",
+					Violation: "has a replica in cloud-1.region-1.zone-3, outside the placement blocks"},
+				balance.Violation{TableID: table.ID, Table: "yugabyte.test_table", Tablet: table.Tablets[2].ID, TabletServer: "balance-tserver-3:9100",
+					Violation: "has its leader in cloud-1.region-1.zone-3, outside the preferred leader zones"},
+			))
+		})
+
+		It("uses the placement policy of the universe for tables without one", func() {
+			cluster.Lock()
+			cluster.ClusterConfig.ReplicationInfo.LiveReplicas.PlacementBlocks = []*master.PlacementBlockPB{
+				{CloudInfo: zone("zone-4"), MinNumReplicas: NewInt32(1)},
+			}
+			cluster.Unlock()
+
+			report := analyze()
+			Expect(report.Violations).To(HaveLen(3 * 4))
+			Expect(report.Violations).To(ContainElement(balance.Violation{
+				TableID:   table.ID,
+				Table:     "yugabyte.test_table",
+				Tablet:    table.Tablets[0].ID,
+				Violation: "has 0 replicas in cloud-1.region-1.zone-4, the placement policy requires at least 1",
+			}))
+		})
+	})
+
+	Context("CheckLoadBalancer", func() {
+		It("passes a balanced universe", func() {
+			checks, err := balance.CheckLoadBalancer(context.Background(), c)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(checks).To(Equal([]balance.LoadBalancerCheck{
+				{Check: balance.CheckLeadersOnPreferredOnly, Passed: true},
+				{Check: balance.CheckLoadBalanced, Passed: true},
+				{Check: balance.CheckLoadBalancerIdle, Passed: true},
+				{Check: balance.CheckLoadMoveCompletion, Passed: true, Detail: "100.0% complete, 0 of 0 moves remaining"},
+			}))
+		})
+
+		It("fails the checks of a universe being balanced", func() {
+			cluster.Lock()
+			cluster.LoadBalancer = fakecluster.LoadBalancerState{
+				Unbalanced:          true,
+				Active:              true,
+				LeadersNotPreferred: true,
+				MovesRemaining:      1,
+				MovesTotal:          4,
+			}
+			cluster.Unlock()
+
+			checks, err := balance.CheckLoadBalancer(context.Background(), c)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(checks).To(Equal([]balance.LoadBalancerCheck{
+				{Check: balance.CheckLeadersOnPreferredOnly, Detail: "leaders are not on the preferred zones only"},
+				{Check: balance.CheckLoadBalanced, Detail: "cluster is not balanced"},
+				{Check: balance.CheckLoadBalancerIdle, Detail: "load balancer is active"},
+				{Check: balance.CheckLoadMoveCompletion, Detail: "75.0% complete, 1 of 4 moves remaining"},
+			}))
+		})
+	})
+
+	Context("PlanLeaderMoves", func() {
+		plan := func() []*balance.LeaderMove {
+			state, err := snapshot.Collect(context.Background(), logr.Discard(), c, snapshot.CollectOptions{Concurrency: 1})
+			Expect(err).NotTo(HaveOccurred())
+			return balance.PlanLeaderMoves(state)
+		}
+
+		// leaders counts the leaders of the table on each tablet server once the
+		// moves are made
+		leaders := func(t *fakecluster.Table, moves []*balance.LeaderMove) map[string]int {
+			moved := make(map[string]string)
+			for _, move := range moves {
+				Expect(move.FromUUID).NotTo(Equal(move.ToUUID))
+				Expect(moved).NotTo(HaveKey(move.Tablet))
+				moved[move.Tablet] = move.ToUUID
+			}
+
+			counts := make(map[string]int)
+			for _, tablet := range t.Tablets {
+				leader := tablet.Leader.UUID
+				if to, ok := moved[tablet.ID]; ok {
+					leader = to
+				}
+				counts[leader]++
+			}
+			return counts
+		}
+
+		It("plans no moves for balanced leaders", func() {
+			Expect(plan()).To(BeEmpty())
+		})
+
+		It("plans the fewest moves to spread the leaders evenly", func() {
+			other := cluster.AddTable("yugabyte", "other_table", 6)
+			cluster.Lock()
+			for _, tablet := range other.Tablets {
+				tablet.Leader = cluster.TabletServers[0]
+			}
+			cluster.Unlock()
+
+			moves := plan()
+			Expect(moves).To(HaveLen(4))
+			for _, move := range moves {
+				Expect(move.Table).To(Equal("yugabyte.other_table"))
+				Expect(move.From).To(Equal("balance-tserver-1:9100"))
+				Expect(move.Reason).To(Equal(balance.ReasonUneven))
+			}
+			Expect(leaders(other, moves)).To(Equal(map[string]int{
+				cluster.TabletServers[0].UUID: 2,
+				cluster.TabletServers[1].UUID: 2,
+				cluster.TabletServers[2].UUID: 2,
+			}))
+		})
+
+		It("moves the leaders into the preferred zones", func() {
+			cluster.Lock()
+			cluster.ClusterConfig.ReplicationInfo.AffinitizedLeaders = []*common.CloudInfoPB{zone("zone-1")}
+			cluster.Unlock()
+
+			moves := plan()
+			Expect(moves).To(HaveLen(2))
+			for _, move := range moves {
+				Expect(move.To).To(Equal("balance-tserver-1:9100"))
+				Expect(move.Reason).To(Equal(balance.ReasonNotPreferred))
+			}
+		})
+
+		It("moves the leaders off the leader blacklist", func() {
+			cluster.Lock()
+			cluster.ClusterConfig.LeaderBlacklist = &master.BlacklistPB{Hosts: []*common.HostPortPB{cluster.TabletServers[0].Address}}
+			cluster.Unlock()
+
+			Expect(plan()).To(Equal([]*balance.LeaderMove{{
+				TableID:  table.ID,
+				Table:    "yugabyte.test_table",
+				Tablet:   table.Tablets[0].ID,
+				From:     "balance-tserver-1:9100",
+				FromUUID: cluster.TabletServers[0].UUID,
+				To:       "balance-tserver-2:9100",
+				ToUUID:   cluster.TabletServers[1].UUID,
+				Reason:   balance.ReasonBlacklisted,
+			}}))
+		})
+	})
+
+	Context("StepDown", func() {
+		It("moves the leader to the new leader", func() {
+			tablet := table.Tablets[0]
+			from, to := cluster.TabletServers[0].UUID, cluster.TabletServers[2].UUID
+
+			Expect(balance.StepDown(context.Background(), c, tablet.ID, from, to)).To(Succeed())
+			leader, err := balance.WaitForLeader(context.Background(), c, tablet.ID, to, from, to, time.Second)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(leader).To(Equal(to))
+		})
+
+		It("fails when asked of a follower", func() {
+			err := balance.StepDown(context.Background(), c, table.Tablets[0].ID, cluster.TabletServers[1].UUID, "")
+			Expect(err).To(MatchError(yberrors.ErrNotTheLeader))
+		})
+
+		It("elects a replica of a tablet without a leader", func() {
+			tablet := table.Tablets[0]
+			cluster.Lock()
+			tablet.Leader = nil
+			cluster.Unlock()
+
+			to := cluster.TabletServers[1].UUID
+			Expect(balance.Elect(context.Background(), c, tablet.ID, to)).To(Succeed())
+			leader, err := balance.WaitForLeader(context.Background(), c, tablet.ID, to, "", to, time.Second)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(leader).To(Equal(to))
+		})
+
+		It("times out when the leader does not change", func() {
+			tablet := table.Tablets[0]
+			cluster.Lock()
+			tablet.LeaderPinned = true
+			cluster.Unlock()
+
+			from := cluster.TabletServers[0].UUID
+			Expect(balance.StepDown(context.Background(), c, tablet.ID, from, "")).To(Succeed())
+			_, err := balance.WaitForLeader(context.Background(), c, tablet.ID, from, from, "", time.Second)
+			Expect(err).To(MatchError(fmt.Sprintf("tablet %s is still led by %s after 1s", tablet.ID, from)))
+		})
+	})
+})
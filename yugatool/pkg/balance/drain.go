@@ -0,0 +1,84 @@
+package balance
+
+import (
+	"fmt"
+
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/common"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/master"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/util"
+)
+
+// Blacklisted returns whether any address the tablet server registered with is
+// on the blacklist, which is how the master matches them.
+func Blacklisted(blacklist *master.BlacklistPB, ts *master.ListTabletServersResponsePB_Entry) bool {
+	return len(BlacklistedHosts(blacklist, ts)) > 0
+}
+
+// BlacklistedHosts returns the hosts of the blacklist that name the tablet server.
+func BlacklistedHosts(blacklist *master.BlacklistPB, ts *master.ListTabletServersResponsePB_Entry) []*common.HostPortPB {
+	addresses := make(map[string]bool)
+	for _, address := range RegisteredAddresses(ts) {
+		addresses[util.HostPortString(address)] = true
+	}
+
+	var hosts []*common.HostPortPB
+	for _, host := range blacklist.GetHosts() {
+		if addresses[util.HostPortString(host)] {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts
+}
+
+// RegisteredAddresses returns the private and then broadcast addresses of the
+// tablet server.
+func RegisteredAddresses(ts *master.ListTabletServersResponsePB_Entry) []*common.HostPortPB {
+	registration := ts.GetRegistration().GetCommon()
+	return append(append([]*common.HostPortPB{}, registration.GetPrivateRpcAddresses()...), registration.GetBroadcastAddresses()...)
+}
+
+// CheckDrain returns the ways that the live tablet servers left once the
+// drained one is blacklisted fall short of the placement policy of the cluster
+// config. Tablet servers already on the server blacklist are not counted. The
+// replication factor of the universe, as returned by
+// YBClient.ReplicationFactor, stands in for a placement policy without a number
+// of replicas, and a drain is refused when it is 0, as unknown.
+func CheckDrain(config *master.SysClusterConfigEntryPB, servers []*master.ListTabletServersResponsePB_Entry, drained string, replicationFactor int) []string {
+	var remaining []*common.CloudInfoPB
+	for _, ts := range servers {
+		if !ts.GetAlive() || string(ts.GetInstanceId().GetPermanentUuid()) == drained ||
+			Blacklisted(config.GetServerBlacklist(), ts) {
+			continue
+		}
+		remaining = append(remaining, ts.GetRegistration().GetCommon().GetCloudInfo())
+	}
+
+	var problems []string
+	live := config.GetReplicationInfo().GetLiveReplicas()
+	switch numReplicas := int(live.GetNumReplicas()); {
+	case numReplicas > 0:
+		if len(remaining) < numReplicas {
+			problems = append(problems, fmt.Sprintf("%d tablet servers would remain, the placement policy requires %d replicas", len(remaining), numReplicas))
+		}
+	case replicationFactor > 0:
+		if len(remaining) < replicationFactor {
+			problems = append(problems, fmt.Sprintf("%d tablet servers would remain, the replication factor is %d", len(remaining), replicationFactor))
+		}
+	default:
+		problems = append(problems, "the replication factor is unknown, as neither the placement policy nor the master sets it")
+	}
+
+	for _, block := range live.GetPlacementBlocks() {
+		var servers int
+		for _, cloud := range remaining {
+			if inPlacement(cloud, block.GetCloudInfo()) {
+				servers++
+			}
+		}
+		if servers < int(block.GetMinNumReplicas()) {
+			problems = append(problems, fmt.Sprintf("%d tablet servers would remain in %s, the placement policy requires at least %d replicas",
+				servers, placementName(block.GetCloudInfo()), block.GetMinNumReplicas()))
+		}
+	}
+	return problems
+}
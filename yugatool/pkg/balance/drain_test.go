@@ -0,0 +1,103 @@
+package balance_test
+
+import (
+	"fmt"
+
+	. "github.com/icza/gox/gox"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/common"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/master"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/balance"
+)
+
+func tabletServer(i int, zoneName string) *master.ListTabletServersResponsePB_Entry {
+	return &master.ListTabletServersResponsePB_Entry{
+		InstanceId: &common.NodeInstancePB{PermanentUuid: []byte(fmt.Sprintf("ts-%d", i))},
+		Registration: &master.TSRegistrationPB{
+			Common: &common.ServerRegistrationPB{
+				PrivateRpcAddresses: []*common.HostPortPB{{Host: NewString(fmt.Sprintf("host-%d", i)), Port: NewUint32(9100)}},
+				BroadcastAddresses:  []*common.HostPortPB{{Host: NewString(fmt.Sprintf("public-%d", i)), Port: NewUint32(9100)}},
+				CloudInfo:           zone(zoneName),
+			},
+		},
+		Alive: NewBool(true),
+	}
+}
+
+var _ = Describe("Drain", func() {
+	var (
+		config  *master.SysClusterConfigEntryPB
+		servers []*master.ListTabletServersResponsePB_Entry
+	)
+
+	BeforeEach(func() {
+		config = &master.SysClusterConfigEntryPB{
+			ReplicationInfo: &master.ReplicationInfoPB{
+				LiveReplicas: &master.PlacementInfoPB{NumReplicas: NewInt32(3)},
+			},
+		}
+		servers = []*master.ListTabletServersResponsePB_Entry{
+			tabletServer(1, "zone-1"),
+			tabletServer(2, "zone-2"),
+			tabletServer(3, "zone-3"),
+			tabletServer(4, "zone-1"),
+		}
+	})
+
+	It("matches the blacklist against the private and broadcast addresses", func() {
+		blacklist := &master.BlacklistPB{Hosts: []*common.HostPortPB{
+			{Host: NewString("public-1"), Port: NewUint32(9100)},
+			{Host: NewString("host-2"), Port: NewUint32(7100)},
+		}}
+		Expect(balance.Blacklisted(blacklist, servers[0])).To(BeTrue())
+		Expect(balance.Blacklisted(blacklist, servers[1])).To(BeFalse())
+		Expect(balance.BlacklistedHosts(blacklist, servers[0])).To(HaveLen(1))
+	})
+
+	It("passes when enough tablet servers remain", func() {
+		Expect(balance.CheckDrain(config, servers, "ts-4", 3)).To(BeEmpty())
+	})
+
+	It("fails when too few tablet servers remain for the replication factor", func() {
+		config.ServerBlacklist = &master.BlacklistPB{Hosts: []*common.HostPortPB{{Host: NewString("host-4"), Port: NewUint32(9100)}}}
+		Expect(balance.CheckDrain(config, servers, "ts-3", 3)).To(ConsistOf(
+			"2 tablet servers would remain, the placement policy requires 3 replicas",
+		))
+	})
+
+	It("does not count dead tablet servers", func() {
+		servers[3].Alive = NewBool(false)
+		Expect(balance.CheckDrain(config, servers, "ts-1", 3)).To(HaveLen(1))
+	})
+
+	DescribeTable("falls back to the replication factor without a number of replicas in the placement policy",
+		func(replicationInfo *master.ReplicationInfoPB) {
+			config.ReplicationInfo = replicationInfo
+			Expect(balance.CheckDrain(config, servers, "ts-4", 3)).To(BeEmpty())
+			Expect(balance.CheckDrain(config, servers[:3], "ts-3", 3)).To(ConsistOf(
+				"2 tablet servers would remain, the replication factor is 3",
+			))
+			Expect(balance.CheckDrain(config, servers, "ts-4", 0)).To(ConsistOf(
+				"the replication factor is unknown, as neither the placement policy nor the master sets it",
+			))
+		},
+		Entry("no replication info", nil),
+		Entry("empty replication info", &master.ReplicationInfoPB{}),
+	)
+
+	It("fails when a placement block would be left short", func() {
+		config.ReplicationInfo.LiveReplicas.PlacementBlocks = []*master.PlacementBlockPB{
+			{CloudInfo: zone("zone-1"), MinNumReplicas: NewInt32(1)},
+			{CloudInfo: zone("zone-2"), MinNumReplicas: NewInt32(1)},
+			{CloudInfo: zone("zone-3"), MinNumReplicas: NewInt32(1)},
+		}
+		Expect(balance.CheckDrain(config, servers, "ts-1", 3)).To(BeEmpty())
+
+		servers = append(servers, tabletServer(5, "zone-1"))
+		Expect(balance.CheckDrain(config, servers, "ts-2", 3)).To(ConsistOf(
+			"0 tablet servers would remain in cloud-1.region-1.zone-2, the placement policy requires at least 1 replicas",
+		))
+	})
+})
@@ -0,0 +1,161 @@
+package balance
+
+import (
+	"sort"
+
+	"github.com/yugabyte/yb-tools/yugatool/pkg/snapshot"
+)
+
+// Reasons for a leader move
+const (
+	ReasonNotPreferred = "leader outside the preferred leader zones"
+	ReasonBlacklisted  = "leader on the leader blacklist"
+	ReasonUneven       = "uneven leaders"
+)
+
+// LeaderMove is a tablet leader to move from one replica to another.
+type LeaderMove struct {
+	TableID  string `json:"table_id"`
+	Table    string `json:"table"`
+	Tablet   string `json:"tablet"`
+	From     string `json:"from"`
+	FromUUID string `json:"from_uuid"`
+	To       string `json:"to"`
+	ToUUID   string `json:"to_uuid"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// PlanLeaderMoves returns the moves that leave the tablet leaders of each table
+// spread evenly across the tablet servers hosting it that may lead: those in the
+// preferred leader zones of the table, or of the universe as set by
+// SetPreferredZones, and not on the leader blacklist. Leaders on tablet servers
+// that may not lead are moved first, then leaders are moved from the tablet
+// servers with the most to those with the fewest, until none is more than one
+// leader from even. Each tablet is moved at most once.
+func PlanLeaderMoves(state *snapshot.State) []*LeaderMove {
+	_, tabletServers := liveTabletServers(state)
+
+	var moves []*LeaderMove
+	for _, t := range collectTables(state, tabletServers) {
+		moves = append(moves, t.planLeaderMoves()...)
+	}
+	return moves
+}
+
+// mayLead returns whether the tablet server may lead tablets of the table
+func (t *table) mayLead(ts *tabletServer) bool {
+	if ts.leaderBlacklisted {
+		return false
+	}
+	preferred := t.policy.GetAffinitizedLeaders()
+	if len(preferred) == 0 {
+		return true
+	}
+	for _, cloud := range preferred {
+		if inPlacement(ts.cloud, cloud) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *table) planLeaderMoves() []*LeaderMove {
+	// The leaders of the table on each tablet server
+	leaders := make(map[*tabletServer]int)
+	for _, tab := range t.tablets {
+		if tab.leader != nil {
+			leaders[tab.leader]++
+		}
+	}
+
+	movesByTablet := make(map[*tablet]*LeaderMove)
+	var moves []*LeaderMove
+	move := func(tab *tablet, to *tabletServer, reason string) {
+		leaders[tab.leader]--
+		leaders[to]++
+
+		// A tablet moved again keeps its first move, to the new leader
+		if m, ok := movesByTablet[tab]; ok {
+			m.To, m.ToUUID = to.address, to.uuid
+		} else {
+			m = &LeaderMove{
+				TableID:  t.id,
+				Table:    t.name,
+				Tablet:   tab.id,
+				From:     tab.leader.address,
+				FromUUID: tab.leader.uuid,
+				To:       to.address,
+				ToUUID:   to.uuid,
+				Reason:   reason,
+			}
+			movesByTablet[tab] = m
+			moves = append(moves, m)
+		}
+		tab.leader = to
+	}
+
+	// fewestLeaders returns the replica of the tablet that may lead with the
+	// fewest leaders
+	fewestLeaders := func(tab *tablet) *tabletServer {
+		var fewest *tabletServer
+		for _, replica := range tab.replicas {
+			if !t.mayLead(replica) {
+				continue
+			}
+			if fewest == nil || leaders[replica] < leaders[fewest] ||
+				(leaders[replica] == leaders[fewest] && replica.address < fewest.address) {
+				fewest = replica
+			}
+		}
+		return fewest
+	}
+
+	for _, tab := range t.tablets {
+		if tab.leader == nil || t.mayLead(tab.leader) {
+			continue
+		}
+		to := fewestLeaders(tab)
+		if to == nil {
+			continue
+		}
+		reason := ReasonNotPreferred
+		if tab.leader.leaderBlacklisted {
+			reason = ReasonBlacklisted
+		}
+		move(tab, to, reason)
+	}
+
+	// Each move narrows the gap between two tablet servers by two, so this ends
+	for {
+		var (
+			bestTablet *tablet
+			bestTo     *tabletServer
+			bestGap    = 1
+		)
+		for _, tab := range t.tablets {
+			if tab.leader == nil || !t.mayLead(tab.leader) {
+				continue
+			}
+			to := fewestLeaders(tab)
+			if gap := leaders[tab.leader] - leaders[to]; gap > bestGap {
+				bestTablet, bestTo, bestGap = tab, to, gap
+			}
+		}
+		if bestTablet == nil {
+			break
+		}
+		move(bestTablet, bestTo, ReasonUneven)
+	}
+
+	// A tablet moved back to where it started needs no move
+	var planned []*LeaderMove
+	for _, m := range moves {
+		if m.FromUUID != m.ToUUID {
+			planned = append(planned, m)
+		}
+	}
+	sort.SliceStable(planned, func(i, j int) bool {
+		return planned[i].Tablet < planned[j].Tablet
+	})
+	return planned
+}
@@ -0,0 +1,88 @@
+package balance
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/master"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client"
+	yberrors "github.com/yugabyte/yb-tools/yugatool/pkg/client/errors"
+	"google.golang.org/protobuf/proto"
+)
+
+// Load balancer checks, as named by the master calls that make them
+const (
+	CheckLeadersOnPreferredOnly = "AreLeadersOnPreferredOnly"
+	CheckLoadBalanced           = "IsLoadBalanced"
+	CheckLoadBalancerIdle       = "IsLoadBalancerIdle"
+	CheckLoadMoveCompletion     = "GetLoadMoveCompletion"
+)
+
+// LoadBalancerCheck is the result of asking the master leader about the state of
+// its load balancer.
+type LoadBalancerCheck struct {
+	Check  string `json:"check"`
+	Passed bool   `json:"passed"`
+	Detail string `json:"detail,omitempty"`
+}
+
+type masterResponse interface {
+	proto.Message
+	GetError() *master.MasterErrorPB
+}
+
+// CheckLoadBalancer asks the master leader whether the leaders are on the
+// preferred zones only, whether the universe is balanced and the load balancer
+// idle, and how far the current load moves have completed.
+func CheckLoadBalancer(ctx context.Context, c *client.YBClient) ([]LoadBalancerCheck, error) {
+	var checks []LoadBalancerCheck
+
+	// Each check fails with its own code, while any other error is the call failing
+	check := func(name string, failedCode master.MasterErrorPB_Code, response masterResponse, err error) error {
+		if err != nil {
+			return err
+		}
+		if response.GetError().GetCode() == failedCode {
+			checks = append(checks, LoadBalancerCheck{Check: name, Detail: response.GetError().GetStatus().GetMessage()})
+			return nil
+		}
+		if err := yberrors.FromResponse(response); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		checks = append(checks, LoadBalancerCheck{Check: name, Passed: true})
+		return nil
+	}
+
+	leadersOnPreferred, err := c.Master.MasterService.AreLeadersOnPreferredOnlyWithContext(ctx, &master.AreLeadersOnPreferredOnlyRequestPB{})
+	err = check(CheckLeadersOnPreferredOnly, master.MasterErrorPB_CAN_RETRY_ARE_LEADERS_ON_PREFERRED_ONLY_CHECK, leadersOnPreferred, err)
+	if err != nil {
+		return nil, err
+	}
+
+	loadBalanced, err := c.Master.MasterService.IsLoadBalancedWithContext(ctx, &master.IsLoadBalancedRequestPB{})
+	err = check(CheckLoadBalanced, master.MasterErrorPB_CAN_RETRY_LOAD_BALANCE_CHECK, loadBalanced, err)
+	if err != nil {
+		return nil, err
+	}
+
+	idle, err := c.Master.MasterService.IsLoadBalancerIdleWithContext(ctx, &master.IsLoadBalancerIdleRequestPB{})
+	err = check(CheckLoadBalancerIdle, master.MasterErrorPB_LOAD_BALANCER_RECENTLY_ACTIVE, idle, err)
+	if err != nil {
+		return nil, err
+	}
+
+	completion, err := c.Master.MasterService.GetLoadMoveCompletionWithContext(ctx, &master.GetLoadMovePercentRequestPB{})
+	if err != nil {
+		return nil, err
+	}
+	if err := yberrors.FromResponse(completion); err != nil {
+		return nil, fmt.Errorf("%s: %w", CheckLoadMoveCompletion, err)
+	}
+	checks = append(checks, LoadBalancerCheck{
+		Check:  CheckLoadMoveCompletion,
+		Passed: completion.GetRemaining() == 0,
+		Detail: fmt.Sprintf("%.1f%% complete, %d of %d moves remaining", completion.GetPercent(), completion.GetRemaining(), completion.GetTotal()),
+	})
+
+	return checks, nil
+}
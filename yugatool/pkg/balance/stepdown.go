@@ -0,0 +1,107 @@
+package balance
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/common"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/consensus"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client"
+	yberrors "github.com/yugabyte/yb-tools/yugatool/pkg/client/errors"
+)
+
+// leaderPollInterval is how often the consensus state is read while waiting for
+// a new leader
+const leaderPollInterval = 200 * time.Millisecond
+
+// StepDown asks the leader of the tablet to hand its leadership to the new
+// leader, or to a peer of its own choosing when newLeader is empty.
+func StepDown(ctx context.Context, c *client.YBClient, tablet string, leader string, newLeader string) error {
+	host, err := c.GetHostByUUIDWithContext(ctx, []byte(leader))
+	if err != nil {
+		return err
+	}
+
+	request := &consensus.LeaderStepDownRequestPB{
+		DestUuid: []byte(leader),
+		TabletId: []byte(tablet),
+	}
+	if newLeader != "" {
+		request.NewLeaderUuid = []byte(newLeader)
+	}
+	response, err := host.ConsensusService.LeaderStepDownWithContext(ctx, request)
+	if err != nil {
+		return err
+	}
+	if err := yberrors.FromResponse(response); err != nil {
+		return errors.Wrapf(err, "could not step down the leader of tablet %s", tablet)
+	}
+	return nil
+}
+
+// Elect asks a replica of the tablet to run a leader election, as for a tablet
+// without a leader to step down.
+func Elect(ctx context.Context, c *client.YBClient, tablet string, replica string) error {
+	host, err := c.GetHostByUUIDWithContext(ctx, []byte(replica))
+	if err != nil {
+		return err
+	}
+
+	response, err := host.ConsensusService.RunLeaderElectionWithContext(ctx, &consensus.RunLeaderElectionRequestPB{
+		DestUuid: []byte(replica),
+		TabletId: []byte(tablet),
+	})
+	if err != nil {
+		return err
+	}
+	if err := yberrors.FromResponse(response); err != nil {
+		return errors.Wrapf(err, "could not run a leader election for tablet %s", tablet)
+	}
+	return nil
+}
+
+// WaitForLeader reads the consensus state of the tablet from a replica until
+// its leader is no longer oldLeader, and is newLeader when that is given. The
+// new leader is returned, or an error once the timeout passes.
+func WaitForLeader(ctx context.Context, c *client.YBClient, tablet string, replica string, oldLeader string, newLeader string, timeout time.Duration) (string, error) {
+	host, err := c.GetHostByUUIDWithContext(ctx, []byte(replica))
+	if err != nil {
+		return "", err
+	}
+
+	deadline := time.Now().Add(timeout)
+	var leader string
+	for {
+		state, err := host.ConsensusService.GetConsensusStateWithContext(ctx, &consensus.GetConsensusStateRequestPB{
+			DestUuid: []byte(replica),
+			TabletId: []byte(tablet),
+			Type:     common.ConsensusConfigType_CONSENSUS_CONFIG_ACTIVE.Enum(),
+		})
+		if err != nil {
+			return "", err
+		}
+		if err := yberrors.FromResponse(state); err != nil {
+			return "", errors.Wrapf(err, "could not get the consensus state of tablet %s", tablet)
+		}
+
+		leader = state.GetCstate().GetLeaderUuid()
+		if leader != "" && leader != oldLeader && (newLeader == "" || leader == newLeader) {
+			return leader, nil
+		}
+
+		if time.Now().After(deadline) {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(leaderPollInterval):
+		}
+	}
+
+	if leader == "" {
+		return "", errors.Errorf("tablet %s has no leader after %s", tablet, timeout)
+	}
+	return "", errors.Errorf("tablet %s is still led by %s after %s", tablet, leader, timeout)
+}
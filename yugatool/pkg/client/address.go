@@ -0,0 +1,191 @@
+package client
+
+import (
+	"net"
+	"strconv"
+
+	. "github.com/icza/gox/gox"
+	"github.com/pkg/errors"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/common"
+	"github.com/yugabyte/yb-tools/yugatool/api/yugatool/config"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/util"
+)
+
+// DefaultAddressPreference is the order in which registered addresses are tried
+// when the address policy does not give one.
+var DefaultAddressPreference = []config.AddressTypePB{
+	config.AddressTypePB_PRIVATE,
+	config.AddressTypePB_BROADCAST,
+	config.AddressTypePB_PUBLIC,
+}
+
+// AddressPolicy chooses the addresses to connect to a server at, from those the
+// server registered with the masters. Addresses are tried in the preference
+// order for the server's region, after being rewritten by the host map and the
+// CIDR rewrites.
+type AddressPolicy struct {
+	policy   *config.AddressPolicyPB
+	rewrites []addressRewrite
+}
+
+// Address is an address to connect to a server at. ServerName is the host the
+// server registered it as, which TLS certificates are verified against even
+// when the address was rewritten.
+type Address struct {
+	HostPort   *common.HostPortPB
+	ServerName string
+}
+
+type addressRewrite struct {
+	from *net.IPNet
+	to   *net.IPNet
+}
+
+// NewAddressPolicy validates the policy. A nil policy connects to the private
+// address first, and falls back to the others.
+func NewAddressPolicy(policy *config.AddressPolicyPB) (*AddressPolicy, error) {
+	p := &AddressPolicy{policy: policy}
+
+	for _, rewrite := range policy.GetRewrites() {
+		_, from, err := net.ParseCIDR(rewrite.GetFromCidr())
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid address rewrite")
+		}
+		_, to, err := net.ParseCIDR(rewrite.GetToCidr())
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid address rewrite")
+		}
+
+		fromOnes, fromBits := from.Mask.Size()
+		toOnes, toBits := to.Mask.Size()
+		if fromOnes != toOnes || fromBits != toBits {
+			return nil, errors.Errorf("address rewrite from %s to %s must be between networks of the same size", from, to)
+		}
+		p.rewrites = append(p.rewrites, addressRewrite{from: from, to: to})
+	}
+
+	for host, replacement := range policy.GetHostMap() {
+		if host == "" || replacement == "" {
+			return nil, errors.Errorf("invalid host map entry %q=%q", host, replacement)
+		}
+		if _, port, err := net.SplitHostPort(replacement); err == nil {
+			if _, err := strconv.ParseUint(port, 10, 32); err != nil {
+				return nil, errors.Errorf("invalid port in host map entry %q=%q", host, replacement)
+			}
+		}
+	}
+
+	return p, nil
+}
+
+// Addresses returns the addresses to try, in order, to reach the server with
+// the registration. Without fallback, only the preferred address is returned.
+func (p *AddressPolicy) Addresses(registration *common.ServerRegistrationPB) []Address {
+	var addresses []Address
+	seen := make(map[string]bool)
+	add := func(hostPorts ...*common.HostPortPB) {
+		for _, hostPort := range hostPorts {
+			address := p.Address(hostPort)
+			if s := util.HostPortString(address.HostPort); !seen[s] {
+				seen[s] = true
+				addresses = append(addresses, address)
+			}
+		}
+	}
+
+	registered := append(append([]*common.HostPortPB{}, registration.GetPrivateRpcAddresses()...), registration.GetBroadcastAddresses()...)
+	for _, addressType := range p.preference(registration.GetCloudInfo().GetPlacementRegion()) {
+		switch addressType {
+		case config.AddressTypePB_PRIVATE:
+			add(registration.GetPrivateRpcAddresses()...)
+		case config.AddressTypePB_BROADCAST:
+			add(registration.GetBroadcastAddresses()...)
+		case config.AddressTypePB_PUBLIC:
+			for _, hostPort := range registered {
+				if isPublicAddress(hostPort.GetHost()) {
+					add(hostPort)
+				}
+			}
+		}
+	}
+
+	if !p.policy.GetFallback() {
+		if len(addresses) > 1 {
+			addresses = addresses[:1]
+		}
+		return addresses
+	}
+
+	// Addresses of a kind left out of the preference are still worth a try
+	add(registered...)
+	return addresses
+}
+
+func (p *AddressPolicy) preference(region string) []config.AddressTypePB {
+	for _, override := range p.policy.GetRegions() {
+		if override.GetRegion() == region && len(override.GetPreference()) > 0 {
+			return override.GetPreference()
+		}
+	}
+	if len(p.policy.GetPreference()) > 0 {
+		return p.policy.GetPreference()
+	}
+	return DefaultAddressPreference
+}
+
+// Address returns the address to connect to a server known as hostPort at.
+func (p *AddressPolicy) Address(hostPort *common.HostPortPB) Address {
+	return Address{HostPort: p.Rewrite(hostPort), ServerName: hostPort.GetHost()}
+}
+
+// Rewrite returns the address the client should dial in place of the address.
+// The host map is consulted first, for host:port and then for host, and then
+// the first CIDR rewrite containing the address is applied.
+func (p *AddressPolicy) Rewrite(hostPort *common.HostPortPB) *common.HostPortPB {
+	hostMap := p.policy.GetHostMap()
+
+	replacement, ok := hostMap[util.HostPortString(hostPort)]
+	if !ok {
+		replacement, ok = hostMap[hostPort.GetHost()]
+	}
+	if ok {
+		host, port, err := net.SplitHostPort(replacement)
+		if err != nil {
+			// The replacement is a host alone, keep the port
+			return &common.HostPortPB{Host: NewString(replacement), Port: NewUint32(hostPort.GetPort())}
+		}
+		n, _ := strconv.ParseUint(port, 10, 32)
+		return &common.HostPortPB{Host: NewString(host), Port: NewUint32(uint32(n))}
+	}
+
+	ip := net.ParseIP(hostPort.GetHost())
+	if ip == nil {
+		return hostPort
+	}
+	for _, rewrite := range p.rewrites {
+		if !rewrite.from.Contains(ip) {
+			continue
+		}
+		if len(rewrite.from.IP) == net.IPv4len {
+			ip = ip.To4()
+		}
+
+		rewritten := make(net.IP, len(ip))
+		for i := range ip {
+			rewritten[i] = rewrite.to.IP[i] | (ip[i] &^ rewrite.from.Mask[i])
+		}
+		return &common.HostPortPB{Host: NewString(rewritten.String()), Port: NewUint32(hostPort.GetPort())}
+	}
+	return hostPort
+}
+
+// isPublicAddress reports whether host is an IP that may be reached from outside
+// the universe's network. Hostnames are never considered public, as telling
+// would take a DNS lookup.
+func isPublicAddress(host string) bool {
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	return !ip.IsPrivate() && !ip.IsLoopback() && !ip.IsLinkLocalUnicast() && !ip.IsUnspecified()
+}
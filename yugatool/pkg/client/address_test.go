@@ -0,0 +1,171 @@
+package client_test
+
+import (
+	"github.com/blang/vfs/memfs"
+	"github.com/go-logr/logr"
+	. "github.com/icza/gox/gox"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/common"
+	"github.com/yugabyte/yb-tools/yugatool/api/yugatool/config"
+	ybclient "github.com/yugabyte/yb-tools/yugatool/pkg/client"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/rpcserver"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/test/fakecluster"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/util"
+)
+
+func hostPort(host string, port uint32) *common.HostPortPB {
+	return &common.HostPortPB{Host: NewString(host), Port: NewUint32(port)}
+}
+
+func addressStrings(addresses []ybclient.Address) []string {
+	var s []string
+	for _, address := range addresses {
+		s = append(s, util.HostPortString(address.HostPort))
+	}
+	return s
+}
+
+var _ = Describe("AddressPolicy", func() {
+	registration := &common.ServerRegistrationPB{
+		PrivateRpcAddresses: []*common.HostPortPB{hostPort("10.0.1.5", 9100)},
+		BroadcastAddresses:  []*common.HostPortPB{hostPort("yb-tserver-0.yb-tservers", 9100), hostPort("203.0.113.5", 9100)},
+		CloudInfo:           &common.CloudInfoPB{PlacementRegion: NewString("us-west-2")},
+	}
+
+	DescribeTable("Addresses",
+		func(policy *config.AddressPolicyPB, expected []string) {
+			p, err := ybclient.NewAddressPolicy(policy)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(addressStrings(p.Addresses(registration))).To(Equal(expected))
+		},
+		Entry("prefers private, then broadcast addresses by default", nil,
+			[]string{"10.0.1.5:9100", "yb-tserver-0.yb-tservers:9100", "203.0.113.5:9100"}),
+		Entry("follows the preference order",
+			&config.AddressPolicyPB{Preference: []config.AddressTypePB{config.AddressTypePB_PUBLIC, config.AddressTypePB_PRIVATE}},
+			[]string{"203.0.113.5:9100", "10.0.1.5:9100", "yb-tserver-0.yb-tservers:9100"}),
+		Entry("returns only the preferred address without fallback",
+			&config.AddressPolicyPB{Preference: []config.AddressTypePB{config.AddressTypePB_BROADCAST}, Fallback: NewBool(false)},
+			[]string{"yb-tserver-0.yb-tservers:9100"}),
+		Entry("takes public addresses from any registered kind",
+			&config.AddressPolicyPB{
+				Preference: []config.AddressTypePB{config.AddressTypePB_PUBLIC},
+				Fallback:   NewBool(false),
+			}, []string{"203.0.113.5:9100"}),
+		Entry("uses the preference of the server's region",
+			&config.AddressPolicyPB{
+				Preference: []config.AddressTypePB{config.AddressTypePB_PRIVATE},
+				Regions: []*config.RegionAddressPolicyPB{
+					{Region: NewString("us-east-1"), Preference: []config.AddressTypePB{config.AddressTypePB_BROADCAST}},
+					{Region: NewString("us-west-2"), Preference: []config.AddressTypePB{config.AddressTypePB_PUBLIC}},
+				},
+				Fallback: NewBool(false),
+			}, []string{"203.0.113.5:9100"}),
+		Entry("rewrites addresses",
+			&config.AddressPolicyPB{
+				Rewrites: []*config.AddressRewritePB{{FromCidr: NewString("10.0.0.0/16"), ToCidr: NewString("192.168.0.0/16")}},
+				HostMap:  map[string]string{"yb-tserver-0.yb-tservers": "localhost:19100"},
+			}, []string{"192.168.1.5:9100", "localhost:19100", "203.0.113.5:9100"}),
+	)
+
+	It("keeps the registered host as the server name of rewritten addresses", func() {
+		p, err := ybclient.NewAddressPolicy(&config.AddressPolicyPB{
+			HostMap: map[string]string{"yb-tserver-0.yb-tservers": "localhost:19100"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		addresses := p.Addresses(registration)
+		Expect(addresses).To(HaveLen(3))
+		Expect(util.HostPortString(addresses[1].HostPort)).To(Equal("localhost:19100"))
+		Expect(addresses[1].ServerName).To(Equal("yb-tserver-0.yb-tservers"))
+	})
+
+	DescribeTable("Rewrite",
+		func(policy *config.AddressPolicyPB, address *common.HostPortPB, expected string) {
+			p, err := ybclient.NewAddressPolicy(policy)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(util.HostPortString(p.Rewrite(address))).To(Equal(expected))
+		},
+		Entry("keeps addresses outside the rewrites",
+			&config.AddressPolicyPB{Rewrites: []*config.AddressRewritePB{{FromCidr: NewString("10.1.0.0/16"), ToCidr: NewString("172.16.0.0/16")}}},
+			hostPort("10.2.3.4", 7100), "10.2.3.4:7100"),
+		Entry("keeps the host part of rewritten addresses",
+			&config.AddressPolicyPB{Rewrites: []*config.AddressRewritePB{{FromCidr: NewString("10.1.0.0/16"), ToCidr: NewString("172.16.0.0/16")}}},
+			hostPort("10.1.3.4", 7100), "172.16.3.4:7100"),
+		Entry("rewrites IPv6 addresses",
+			&config.AddressPolicyPB{Rewrites: []*config.AddressRewritePB{{FromCidr: NewString("fd00::/64"), ToCidr: NewString("2001:db8::/64")}}},
+			hostPort("fd00::12", 7100), "[2001:db8::12]:7100"),
+		Entry("maps hosts, keeping the port",
+			&config.AddressPolicyPB{HostMap: map[string]string{"master-1": "bastion"}},
+			hostPort("master-1", 7100), "bastion:7100"),
+		Entry("maps host and port before host",
+			&config.AddressPolicyPB{HostMap: map[string]string{"master-1": "bastion", "master-1:7100": "bastion:17100"}},
+			hostPort("master-1", 7100), "bastion:17100"),
+	)
+
+	DescribeTable("invalid policies",
+		func(policy *config.AddressPolicyPB) {
+			_, err := ybclient.NewAddressPolicy(policy)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("invalid CIDR", &config.AddressPolicyPB{Rewrites: []*config.AddressRewritePB{{FromCidr: NewString("10.1.0.0"), ToCidr: NewString("172.16.0.0/16")}}}),
+		Entry("networks of different sizes", &config.AddressPolicyPB{Rewrites: []*config.AddressRewritePB{{FromCidr: NewString("10.1.0.0/16"), ToCidr: NewString("172.16.0.0/12")}}}),
+		Entry("invalid port", &config.AddressPolicyPB{HostMap: map[string]string{"master-1": "bastion:ssh"}}),
+	)
+})
+
+var _ = Describe("Connecting with an address policy", func() {
+	var (
+		cluster        *fakecluster.Cluster
+		yugabyteClient *ybclient.YBClient
+		tserver        *fakecluster.Node
+	)
+
+	BeforeEach(func() {
+		cluster = fakecluster.New(logr.Discard(), rpcserver.NewNetwork(), "address", 1, 1)
+
+		// The tablet server is only reachable at its broadcast address
+		tserver = cluster.TabletServers[0]
+		tserver.BroadcastAddresses = []*common.HostPortPB{hostPort("203.0.113.5", 9100)}
+		cluster.Network.Stop(util.HostPortString(tserver.Address))
+		cluster.Network.Listen("203.0.113.5:9100", tserver.Server)
+
+		yugabyteClient = &ybclient.YBClient{
+			Log: logr.Discard(),
+			Fs:  memfs.Create(),
+			Config: &config.UniverseConfigPB{
+				Masters:        []*common.HostPortPB{hostPort("10.0.0.1", 7100)},
+				TimeoutSeconds: NewInt64(1),
+				AddressPolicy: &config.AddressPolicyPB{
+					HostMap: map[string]string{"10.0.0.1": util.HostPortString(cluster.Masters[0].Address)},
+				},
+			},
+		}
+		yugabyteClient.OverrideDialer(cluster.Network)
+	})
+
+	AfterEach(func() {
+		yugabyteClient.Close()
+	})
+
+	It("rewrites the master addresses", func() {
+		Expect(yugabyteClient.Connect()).To(Succeed())
+	})
+
+	It("falls back to the next address of a server", func() {
+		Expect(yugabyteClient.Connect()).To(Succeed())
+
+		host, err := yugabyteClient.GetHostByUUID([]byte(tserver.UUID))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(host.Status.GetNodeInstance().GetPermanentUuid()).To(BeEquivalentTo(tserver.UUID))
+	})
+
+	It("only tries the preferred address without fallback", func() {
+		yugabyteClient.Config.AddressPolicy.Fallback = NewBool(false)
+		Expect(yugabyteClient.Connect()).To(Succeed())
+
+		_, err := yugabyteClient.GetHostByUUID([]byte(tserver.UUID))
+		Expect(err).To(MatchError(ContainSubstring("connection refused")))
+	})
+})
@@ -1,10 +1,12 @@
 package client
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/blang/vfs"
 	"github.com/go-logr/logr"
@@ -14,6 +16,7 @@ import (
 	"github.com/yugabyte/yb-tools/yugatool/api/yb/master"
 	"github.com/yugabyte/yb-tools/yugatool/api/yugatool/config"
 	"github.com/yugabyte/yb-tools/yugatool/pkg/client/dial"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client/message"
 	"github.com/yugabyte/yb-tools/yugatool/pkg/util"
 )
 
@@ -32,61 +35,95 @@ type YBClient struct {
 
 	m               sync.Mutex
 	tServersUUIDMap map[uuid.UUID]*HostState
+	mastersUUIDMap  map[uuid.UUID]*HostState
 
-	dialer dial.Dialer
+	leaderLock sync.Mutex
+	leader     *HostState
+
+	dialer        dial.Dialer
+	addressPolicy *AddressPolicy
+
+	// usingTLS is set when the dialer wraps connections in TLS, and plaintext
+	// once a preferred TLS policy has fallen back to plaintext
+	usingTLS  bool
+	plaintext bool
+
+	// Proxy, when set, carries the connections to the universe, such as
+	// through a bastion host
+	Proxy dial.ProxyDialer
+
+	// Stats counts the calls made to each server. Connect sets it when it is
+	// not already set, so several clients may share one
+	Stats *message.Stats
+
+	// WrapDialer, when set, wraps the dialer the client connects with, such as
+	// to record the calls made
+	WrapDialer func(dial.Dialer) dial.Dialer
 
 	tabletServers *master.ListTabletServersResponsePB
+
+	// masterProbes are the configured masters as found by Connect, which
+	// finishes probing them in the background once the leader is found
+	masterProbes []*masterProbe
+	probes       *sync.WaitGroup
 }
 
 func (c *YBClient) Connect() error {
+	return c.ConnectWithContext(context.Background())
+}
+
+func (c *YBClient) ConnectWithContext(ctx context.Context) error {
 	c.tServersUUIDMap = make(map[uuid.UUID]*HostState)
+	c.mastersUUIDMap = make(map[uuid.UUID]*HostState)
+	if c.Stats == nil {
+		c.Stats = message.NewStats()
+	}
 
 	dialer, err := c.GetDialer()
 	if err != nil {
 		return err
 	}
 
-	var hostState *HostState
-
-	for _, m := range c.Config.Masters {
-		// Connect to a master address
-		hostState, err = NewHostState(c.Log, m, dialer)
-		if err != nil {
-			if hostState != nil {
-				_ = hostState.Close()
-			}
-			c.Log.V(1).Info("could not connect", "host", m, "error", err)
-			continue
-		}
-		tabletServers, err := hostState.MasterService.ListTabletServers(&master.ListTabletServersRequestPB{PrimaryOnly: NewBool(false)})
-		if err != nil {
-			return err
-		}
-		if tabletServers.Error != nil {
-			if tabletServers.Error.GetCode() == master.MasterErrorPB_NOT_THE_LEADER {
-				_ = hostState.Close()
-				continue
-			}
-			return errors.Errorf("ListTabletServers returned error: %s", tabletServers.Error)
-		}
-
-		c.Master = hostState
-		c.tabletServers = tabletServers
-
+	c.addressPolicy, err = NewAddressPolicy(c.Config.GetAddressPolicy())
+	if err != nil {
+		return err
 	}
-	if c.Master == nil {
-		if util.HasTLS(c.Config.GetTlsOpts()) {
-			c.Log.V(1).Info("could not connect to master leader, disabling TLS and trying again", "error", err)
 
-			c.Config.TlsOpts = nil
+	hostState, err := c.connectMasterLeader(ctx, dialer)
+	if err != nil {
+		if c.usingTLS && c.TLSPolicy() == config.TlsPolicyPB_PREFERRED {
+			c.Log.Error(err, "could not connect to master leader over TLS, falling back to plaintext as the TLS policy is preferred")
+
+			c.plaintext = true
 			// Deallocate the existing dialer so a new non-TLS dialer will be created during Connect()
 			c.dialer = nil
 
-			return c.Connect()
+			return c.ConnectWithContext(ctx)
 		}
-		return errors.Errorf("could not connect to master leader")
+		return err
 	}
-	return err
+
+	tabletServers, err := hostState.MasterService.ListTabletServersWithContext(ctx, &master.ListTabletServersRequestPB{PrimaryOnly: NewBool(false)})
+	if err != nil {
+		_ = hostState.Close()
+		return err
+	}
+	if tabletServers.Error != nil {
+		_ = hostState.Close()
+		return errors.Errorf("ListTabletServers returned error: %s", tabletServers.Error)
+	}
+
+	c.Master = hostState
+	c.tabletServers = tabletServers
+
+	// Calls through Master.MasterService follow the leader if it moves. The other
+	// services of Master stay bound to the master that was the leader at connect time.
+	c.leader = c.Master
+	c.Master.MasterService = &master.MasterServiceImpl{
+		Log:       c.Log.WithName("MasterLeader"),
+		Messenger: &masterLeaderMessenger{Log: c.Log.WithName("MasterLeader"), client: c},
+	}
+	return nil
 }
 
 func (c *YBClient) AllTservers() ([]*HostState, []error) {
@@ -103,11 +140,70 @@ func (c *YBClient) AllTservers() ([]*HostState, []error) {
 	return hostStates, errors
 }
 
+// AllMasters connects to each master listed by the leader. These connections are
+// separate from Master, so their calls are not redirected to the leader.
+func (c *YBClient) AllMasters(ctx context.Context) ([]*HostState, []error) {
+	masters, err := c.Master.MasterService.ListMastersWithContext(ctx, &master.ListMastersRequestPB{})
+	if err != nil {
+		return nil, []error{err}
+	}
+	if masters.GetError() != nil {
+		return nil, []error{errors.Errorf("ListMasters returned error: %s", masters.GetError())}
+	}
+
+	dialer, err := c.GetDialer()
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	var hostStates []*HostState
+	var errs []error
+	for _, m := range masters.GetMasters() {
+		if m.GetError() != nil {
+			errs = append(errs, errors.Errorf("master %s: %s", m.GetInstanceId().GetPermanentUuid(), m.GetError()))
+			continue
+		}
+
+		masterUUID, err := uuid.ParseBytes(m.GetInstanceId().GetPermanentUuid())
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		hostState, ok := c.mastersUUIDMap[masterUUID]
+		if ok && hostState.Dead() {
+			c.Log.V(1).Info("evicting dead master", "uuid", masterUUID.String())
+			_ = hostState.Close()
+			delete(c.mastersUUIDMap, masterUUID)
+			ok = false
+		}
+		if !ok {
+			hostState, err = c.dialAddresses(ctx, c.addressPolicy.Addresses(m.GetRegistration()), dialer)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			c.mastersUUIDMap[masterUUID] = hostState
+		}
+		hostStates = append(hostStates, hostState)
+	}
+	return hostStates, errs
+}
+
 func (c *YBClient) TserverCount() int {
 	return len(c.tabletServers.GetServers())
 }
 
 func (c *YBClient) GetHostByUUID(permanentUUID []byte) (*HostState, error) {
+	return c.GetHostByUUIDWithContext(context.Background(), permanentUUID)
+}
+
+// GetHostByUUIDWithContext returns the connection to the tablet server, dialing
+// it within the context when it is not yet connected.
+func (c *YBClient) GetHostByUUIDWithContext(ctx context.Context, permanentUUID []byte) (*HostState, error) {
 	tserverUUID, err := uuid.ParseBytes(permanentUUID)
 	if err != nil {
 		return nil, err
@@ -116,8 +212,14 @@ func (c *YBClient) GetHostByUUID(permanentUUID []byte) (*HostState, error) {
 	c.m.Lock()
 	defer c.m.Unlock()
 	hostState, ok := c.tServersUUIDMap[tserverUUID]
+	if ok && hostState.Dead() {
+		c.Log.V(1).Info("evicting dead tablet server", "uuid", tserverUUID.String())
+		_ = hostState.Close()
+		delete(c.tServersUUIDMap, tserverUUID)
+		ok = false
+	}
 	if !ok {
-		hostState, err = c.dialTserver(tserverUUID)
+		hostState, err = c.dialTserver(ctx, tserverUUID)
 		if err != nil {
 			return hostState, err
 		}
@@ -128,7 +230,7 @@ func (c *YBClient) GetHostByUUID(permanentUUID []byte) (*HostState, error) {
 	return hostState, nil
 }
 
-func (c *YBClient) dialTserver(tserverUUID uuid.UUID) (*HostState, error) {
+func (c *YBClient) dialTserver(ctx context.Context, tserverUUID uuid.UUID) (*HostState, error) {
 	dialer, err := c.GetDialer()
 	if err != nil {
 		return nil, err
@@ -141,43 +243,135 @@ func (c *YBClient) dialTserver(tserverUUID uuid.UUID) (*HostState, error) {
 		}
 
 		if tsuuid.String() == tserverUUID.String() {
-			rpcAddress := server.GetRegistration().Common.GetPrivateRpcAddresses()[0]
+			return c.dialAddresses(ctx, c.addressPolicy.Addresses(server.GetRegistration().GetCommon()), dialer)
+		}
+	}
 
-			hostState, err := NewHostState(c.Log, rpcAddress, dialer)
-			if err != nil {
-				return nil, err
-			}
+	return nil, fmt.Errorf("host %s not found in known tserver list", tserverUUID.String())
+}
+
+// dialAddresses connects to a server at the first of its addresses that answers.
+// Certificates are verified against the name the server registered.
+func (c *YBClient) dialAddresses(ctx context.Context, addresses []Address, dialer dial.Dialer) (*HostState, error) {
+	if len(addresses) == 0 {
+		return nil, errors.New("server has no address matching the address policy")
+	}
 
+	var lastErr error
+	for _, address := range addresses {
+		hostState, err := newHostState(ctx, c.Log, address.HostPort, dial.WithServerName(dialer, address.ServerName), c.RPCTimeout(), c.Stats, c.keepaliveInterval())
+		if err == nil {
 			return hostState, nil
 		}
+		c.Log.V(1).Info("could not connect", "host", util.HostPortString(address.HostPort), "error", err)
+		lastErr = err
 	}
 
-	return nil, fmt.Errorf("host %s not found in known tserver list", tserverUUID.String())
+	if len(addresses) > 1 {
+		return nil, errors.Wrapf(lastErr, "could not connect to any of %d addresses", len(addresses))
+	}
+	return nil, lastErr
+}
+
+// RPCStats returns the calls made to each method of each server since the
+// client connected, with their error counts and latency.
+func (c *YBClient) RPCStats() []message.MethodStats {
+	if c.Stats == nil {
+		return nil
+	}
+	return c.Stats.Methods()
+}
+
+// keepaliveInterval is the interval between pings of each server, or zero for none
+func (c *YBClient) keepaliveInterval() time.Duration {
+	return time.Duration(c.Config.GetKeepaliveSeconds()) * time.Second
+}
+
+// RPCTimeout is the timeout for calls made without a deadline of their own
+func (c *YBClient) RPCTimeout() time.Duration {
+	if c.Config.GetRpcTimeoutSeconds() > 0 {
+		return time.Duration(c.Config.GetRpcTimeoutSeconds()) * time.Second
+	}
+	return message.DefaultTimeout
 }
 
 // TODO: Log errors
 func (c *YBClient) Close() {
-	c.Master.Close()
+	c.leaderLock.Lock()
+	if c.leader != nil && c.leader != c.Master {
+		c.leader.Close()
+	}
+	c.leader = nil
+	c.leaderLock.Unlock()
+
+	if c.Master != nil {
+		c.Master.Close()
+	}
 	c.m.Lock()
 	defer c.m.Unlock()
 	for _, tserver := range c.tServersUUIDMap {
 		tserver.Close()
 	}
+	for _, master := range c.mastersUUIDMap {
+		master.Close()
+	}
+	if c.Proxy != nil {
+		_ = c.Proxy.Close()
+	}
 }
 func (c *YBClient) OverrideDialer(dialer dial.Dialer) {
-	c.dialer = dialer
+	c.dialer = c.wrapDialer(dialer)
+}
+
+func (c *YBClient) wrapDialer(dialer dial.Dialer) dial.Dialer {
+	if c.WrapDialer != nil {
+		return c.WrapDialer(dialer)
+	}
+	return dialer
 }
 
 func (c *YBClient) GetDialer() (dial.Dialer, error) {
 	if c.dialer != nil {
 		return c.dialer, nil
 	}
-	if !util.HasTLS(c.Config.GetTlsOpts()) {
-		netDialer := &dial.NetDialer{
-			TimeoutSeconds: c.Config.GetTimeoutSeconds(),
-		}
-		c.dialer = netDialer
-		return netDialer, nil
+
+	tlsConfig, err := c.TLSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	c.usingTLS = tlsConfig != nil
+	switch {
+	case c.Proxy != nil:
+		c.dialer = c.wrapDialer(c.Proxy.WithTLS(tlsConfig))
+	case tlsConfig != nil:
+		c.dialer = c.wrapDialer(&dial.TLSDialer{TimeoutSeconds: c.Config.GetTimeoutSeconds(), Config: tlsConfig})
+	default:
+		c.dialer = c.wrapDialer(&dial.NetDialer{TimeoutSeconds: c.Config.GetTimeoutSeconds()})
+	}
+	return c.dialer, nil
+}
+
+// TLSPolicy returns the policy for connections to the universe. When the
+// configuration does not give one, TLS is required if any TLS option is set,
+// and disabled otherwise.
+func (c *YBClient) TLSPolicy() config.TlsPolicyPB {
+	tlsOpts := c.Config.GetTlsOpts()
+	if tlsOpts != nil && tlsOpts.Policy != nil {
+		return tlsOpts.GetPolicy()
+	}
+	if util.HasTLS(tlsOpts) {
+		return config.TlsPolicyPB_REQUIRED
+	}
+	return config.TlsPolicyPB_DISABLED
+}
+
+// TLSConfig returns the TLS configuration for connections to the universe, or
+// nil when connections are made in plaintext. Without a CA certificate, servers
+// are verified against the system's trusted CAs.
+func (c *YBClient) TLSConfig() (*tls.Config, error) {
+	if c.plaintext || c.TLSPolicy() == config.TlsPolicyPB_DISABLED {
+		return nil, nil
 	}
 
 	tlsConfig := &tls.Config{
@@ -203,24 +397,21 @@ func (c *YBClient) GetDialer() (dial.Dialer, error) {
 		}
 		tlsCert, err := vfs.ReadFile(c.Fs, c.Config.GetTlsOpts().GetCertPath())
 		if err != nil {
-			return c.dialer, fmt.Errorf("unable to read x509 certificate: %w", err)
+			return nil, fmt.Errorf("unable to read x509 certificate: %w", err)
 		}
 
 		tlsKey, err := vfs.ReadFile(c.Fs, c.Config.GetTlsOpts().GetKeyPath())
 		if err != nil {
-			return c.dialer, fmt.Errorf("unable to read client key: %w", err)
+			return nil, fmt.Errorf("unable to read client key: %w", err)
 		}
 
 		tlsCertificate, err := tls.X509KeyPair(tlsCert, tlsKey)
 		if err != nil {
-			return c.dialer, fmt.Errorf("unable to read x509 key pair: %w", err)
+			return nil, fmt.Errorf("unable to read x509 key pair: %w", err)
 		}
 
 		tlsConfig.Certificates = append(tlsConfig.Certificates, tlsCertificate)
 	}
 
-	tlsDialer := &dial.TLSDialer{TimeoutSeconds: c.Config.GetTimeoutSeconds(), Config: tlsConfig}
-
-	c.dialer = tlsDialer
-	return tlsDialer, nil
+	return tlsConfig, nil
 }
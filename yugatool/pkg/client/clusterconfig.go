@@ -0,0 +1,97 @@
+package client
+
+import (
+	"context"
+	"strconv"
+
+	. "github.com/icza/gox/gox"
+	"github.com/pkg/errors"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/master"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/server"
+	yberrors "github.com/yugabyte/yb-tools/yugatool/pkg/client/errors"
+)
+
+// clusterConfigAttempts is the number of times a cluster config change is read
+// and written before giving up on concurrent changes
+const clusterConfigAttempts = 5
+
+// ErrClusterConfigUnchanged is returned by an update to leave the cluster config
+// as it is.
+var ErrClusterConfigUnchanged = errors.New("cluster config unchanged")
+
+// GetClusterConfig returns the cluster config of the master leader.
+func (c *YBClient) GetClusterConfig(ctx context.Context) (*master.SysClusterConfigEntryPB, error) {
+	response, err := c.Master.MasterService.GetMasterClusterConfigWithContext(ctx, &master.GetMasterClusterConfigRequestPB{})
+	if err != nil {
+		return nil, err
+	}
+	if err := yberrors.FromResponse(response); err != nil {
+		return nil, errors.Wrap(err, "could not get cluster config")
+	}
+	return response.GetClusterConfig(), nil
+}
+
+// ReplicationFactor returns the number of live replicas of the placement policy
+// of the cluster config or, for a universe that never set a placement policy,
+// the replication factor the master was started with. It is 0 when neither is
+// known.
+func (c *YBClient) ReplicationFactor(ctx context.Context, config *master.SysClusterConfigEntryPB) (int, error) {
+	if numReplicas := config.GetReplicationInfo().GetLiveReplicas().GetNumReplicas(); numReplicas > 0 {
+		return int(numReplicas), nil
+	}
+
+	response, err := c.Master.GenericService.GetFlagWithContext(ctx, &server.GetFlagRequestPB{Flag: NewString("replication_factor")})
+	if err != nil {
+		return 0, err
+	}
+	if !response.GetValid() {
+		return 0, nil
+	}
+	replicationFactor, err := strconv.Atoi(response.GetValue())
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid replication_factor %q", response.GetValue())
+	}
+	return replicationFactor, nil
+}
+
+// UpdateClusterConfig reads the cluster config, changes it with update and
+// writes it back. The master only accepts the change if the config has not
+// changed since it was read, so a change made concurrently, such as by yb-admin,
+// is never lost: the config is read and update applied again instead. The
+// config as written is returned, or the config as read when update returns
+// ErrClusterConfigUnchanged.
+func (c *YBClient) UpdateClusterConfig(ctx context.Context, update func(config *master.SysClusterConfigEntryPB) error) (*master.SysClusterConfigEntryPB, error) {
+	var lastErr error
+	for attempt := 0; attempt < clusterConfigAttempts; attempt++ {
+		config, err := c.GetClusterConfig(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		err = update(config)
+		if err == ErrClusterConfigUnchanged {
+			return config, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		change, err := c.Master.MasterService.ChangeMasterClusterConfigWithContext(ctx, &master.ChangeMasterClusterConfigRequestPB{ClusterConfig: config})
+		if err != nil {
+			return nil, err
+		}
+		if change.GetError().GetCode() == master.MasterErrorPB_CONFIG_VERSION_MISMATCH {
+			lastErr = yberrors.FromResponse(change)
+			c.Log.V(1).Info("cluster config changed while updating it, retrying", "version", config.GetVersion())
+			continue
+		}
+		if err := yberrors.FromResponse(change); err != nil {
+			return nil, errors.Wrap(err, "could not change cluster config")
+		}
+
+		// The master bumps the version of the config it stores
+		config.Version = NewInt32(config.GetVersion() + 1)
+		return config, nil
+	}
+	return nil, errors.Wrapf(lastErr, "cluster config kept changing after %d attempts", clusterConfigAttempts)
+}
@@ -0,0 +1,124 @@
+package client_test
+
+import (
+	"context"
+
+	"github.com/blang/vfs/memfs"
+	"github.com/go-logr/logr"
+	. "github.com/icza/gox/gox"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/common"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/master"
+	"github.com/yugabyte/yb-tools/yugatool/api/yugatool/config"
+	ybclient "github.com/yugabyte/yb-tools/yugatool/pkg/client"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/rpcserver"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/test/fakecluster"
+)
+
+var _ = Describe("Cluster config", func() {
+	var (
+		cluster        *fakecluster.Cluster
+		yugabyteClient *ybclient.YBClient
+	)
+
+	BeforeEach(func() {
+		cluster = fakecluster.New(logr.Discard(), rpcserver.NewNetwork(), "config", 1, 3)
+
+		yugabyteClient = &ybclient.YBClient{
+			Log: logr.Discard(),
+			Fs:  memfs.Create(),
+			Config: &config.UniverseConfigPB{
+				Masters:        cluster.MasterHostPorts(),
+				TimeoutSeconds: NewInt64(1),
+			},
+		}
+		yugabyteClient.OverrideDialer(cluster.Network)
+		Expect(yugabyteClient.Connect()).To(Succeed())
+	})
+
+	AfterEach(func() {
+		yugabyteClient.Close()
+	})
+
+	blacklistHost := func(host string) func(*master.SysClusterConfigEntryPB) error {
+		return func(config *master.SysClusterConfigEntryPB) error {
+			if config.ServerBlacklist == nil {
+				config.ServerBlacklist = &master.BlacklistPB{}
+			}
+			config.ServerBlacklist.Hosts = append(config.ServerBlacklist.Hosts, &common.HostPortPB{Host: NewString(host), Port: NewUint32(9100)})
+			return nil
+		}
+	}
+
+	blacklistedHosts := func() []string {
+		cluster.Lock()
+		defer cluster.Unlock()
+
+		var hosts []string
+		for _, hostPort := range cluster.ClusterConfig.GetServerBlacklist().GetHosts() {
+			hosts = append(hosts, hostPort.GetHost())
+		}
+		return hosts
+	}
+
+	It("writes the updated config and returns its new version", func() {
+		config, err := yugabyteClient.UpdateClusterConfig(context.Background(), blacklistHost("host-1"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(config.GetVersion()).To(Equal(int32(2)))
+		Expect(config.GetServerBlacklist().GetHosts()).To(HaveLen(1))
+
+		Expect(blacklistedHosts()).To(Equal([]string{"host-1"}))
+		Expect(cluster.ClusterConfig.GetVersion()).To(Equal(int32(2)))
+	})
+
+	It("leaves the config alone when the update makes no change", func() {
+		config, err := yugabyteClient.UpdateClusterConfig(context.Background(), func(*master.SysClusterConfigEntryPB) error {
+			return ybclient.ErrClusterConfigUnchanged
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(config.GetVersion()).To(Equal(int32(1)))
+		Expect(cluster.ClusterConfig.GetVersion()).To(Equal(int32(1)))
+	})
+
+	It("returns the error of the update without writing the config", func() {
+		_, err := yugabyteClient.UpdateClusterConfig(context.Background(), func(*master.SysClusterConfigEntryPB) error {
+			return errors.New("bad update")
+		})
+		Expect(err).To(MatchError("bad update"))
+		Expect(cluster.ClusterConfig.GetVersion()).To(Equal(int32(1)))
+	})
+
+	It("reapplies the update when the config changes while it is updated", func() {
+		attempts := 0
+		config, err := yugabyteClient.UpdateClusterConfig(context.Background(), func(config *master.SysClusterConfigEntryPB) error {
+			attempts++
+			if attempts == 1 {
+				// Another client changes the config after it was read
+				cluster.Lock()
+				concurrent := blacklistHost("host-2")
+				Expect(concurrent(cluster.ClusterConfig)).To(Succeed())
+				cluster.ClusterConfig.Version = NewInt32(cluster.ClusterConfig.GetVersion() + 1)
+				cluster.Unlock()
+			}
+			return blacklistHost("host-1")(config)
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(attempts).To(Equal(2))
+		Expect(config.GetVersion()).To(Equal(int32(3)))
+
+		// Neither change is lost
+		Expect(blacklistedHosts()).To(Equal([]string{"host-2", "host-1"}))
+	})
+
+	It("gives up when the config keeps changing", func() {
+		_, err := yugabyteClient.UpdateClusterConfig(context.Background(), func(config *master.SysClusterConfigEntryPB) error {
+			cluster.Lock()
+			cluster.ClusterConfig.Version = NewInt32(cluster.ClusterConfig.GetVersion() + 1)
+			cluster.Unlock()
+			return nil
+		})
+		Expect(err).To(MatchError(ContainSubstring("cluster config kept changing after 5 attempts")))
+	})
+})
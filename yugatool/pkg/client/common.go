@@ -1,7 +1,12 @@
 package client
 
 import (
+	"context"
+	"sync"
+	"time"
+
 	"github.com/go-logr/logr"
+	ybrpc "github.com/yugabyte/yb-tools/protoc-gen-ybrpc/pkg/message"
 	"github.com/yugabyte/yb-tools/yugatool/api/yb/cdc"
 	"github.com/yugabyte/yb-tools/yugatool/api/yb/common"
 	"github.com/yugabyte/yb-tools/yugatool/api/yb/consensus"
@@ -15,7 +20,11 @@ import (
 )
 
 type HostState struct {
-	session *session.Session
+	session   *session.Session
+	messenger *message.MessengerImpl
+
+	stopKeepalive chan struct{}
+	closeOnce     sync.Once
 
 	Status                   *server.ServerStatusPB
 	GenericService           server.GenericService
@@ -24,67 +33,140 @@ type HostState struct {
 	TabletServerAdminService tserver.TabletServerAdminService
 	ConsensusService         consensus.ConsensusService
 	CDCService               cdc.CDCService
+	RemoteBootstrapService   tserver.RemoteBootstrapService
+}
+
+func NewHostState(ctx context.Context, log logr.Logger, host *common.HostPortPB, dialer dial.Dialer, rpcTimeout time.Duration) (*HostState, error) {
+	return newHostState(ctx, log, host, dialer, rpcTimeout, nil, 0)
 }
 
-func NewHostState(log logr.Logger, host *common.HostPortPB, dialer dial.Dialer) (*HostState, error) {
+// newHostState connects to the server at host. When stats is not nil, the calls
+// made to the server are counted in it. When keepalive is not zero, the server
+// is pinged at that interval, which reconnects the session if it was lost.
+func newHostState(ctx context.Context, log logr.Logger, host *common.HostPortPB, dialer dial.Dialer, rpcTimeout time.Duration, stats *message.Stats, keepalive time.Duration) (*HostState, error) {
 	log = log.WithValues("host", util.HostPortString(host))
 
-	s, err := session.NewSession(log, host, dialer, ping)
+	s, err := session.NewSession(log, host, dialer, pinger(rpcTimeout))
 	if err != nil {
 		return nil, err
 	}
+	// All services share a single messenger so their calls can be multiplexed over the session
+	messenger := message.NewMessenger(s, rpcTimeout)
+	messenger.Stats = stats
+
 	hostState := &HostState{
-		session: s,
+		session:   s,
+		messenger: messenger,
 	}
+
 	hostState.GenericService = &server.GenericServiceImpl{
 		Log:       log,
-		Messenger: &message.MessengerImpl{Session: s},
+		Messenger: messenger,
 	}
 
 	hostState.MasterService = &master.MasterServiceImpl{
 		Log:       log,
-		Messenger: &message.MessengerImpl{Session: s},
+		Messenger: messenger,
 	}
 
 	hostState.TabletServerService = &tserver.TabletServerServiceImpl{
 		Log:       log,
-		Messenger: &message.MessengerImpl{Session: s},
+		Messenger: messenger,
 	}
 
 	hostState.TabletServerAdminService = &tserver.TabletServerAdminServiceImpl{
 		Log:       log,
-		Messenger: &message.MessengerImpl{Session: s},
+		Messenger: messenger,
 	}
 
 	hostState.ConsensusService = &consensus.ConsensusServiceImpl{
 		Log:       log,
-		Messenger: &message.MessengerImpl{Session: s},
+		Messenger: messenger,
 	}
 
 	hostState.CDCService = &cdc.CDCServiceImpl{
 		Log:       log,
-		Messenger: &message.MessengerImpl{Session: s},
+		Messenger: messenger,
 	}
 
-	status, err := hostState.GenericService.GetStatus(&server.GetStatusRequestPB{})
+	hostState.RemoteBootstrapService = &tserver.RemoteBootstrapServiceImpl{
+		Log:       log,
+		Messenger: messenger,
+	}
+
+	status, err := hostState.GenericService.GetStatusWithContext(ctx, &server.GetStatusRequestPB{})
 	if err != nil {
 		_ = s.Close()
 		return nil, err
 	}
 	hostState.Status = status.GetStatus()
 
+	if keepalive > 0 {
+		hostState.stopKeepalive = make(chan struct{})
+		go hostState.keepalive(log, keepalive, rpcTimeout)
+	}
+
 	return hostState, nil
 }
 
+// keepalive pings the server until the host is closed, so an idle connection
+// stays open, and a lost one is replaced before the next call needs it.
+func (h *HostState) keepalive(log logr.Logger, interval time.Duration, timeout time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-h.stopKeepalive:
+			return
+		case <-ticker.C:
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		_, err := h.GenericService.PingWithContext(ctx, &server.PingRequestPB{})
+		cancel()
+		if err != nil {
+			log.V(1).Info("keepalive ping failed", "error", err)
+		}
+	}
+}
+
+// Dead reports whether the host is closed, or its connection was lost and could
+// not be opened again.
+func (h *HostState) Dead() bool {
+	return h.session.Dead()
+}
+
+// Messenger returns the messenger behind the host's client of the service, given
+// by its full name, so calls made by name take the same path as calls through
+// the typed clients. On YBClient.Master, MasterService calls follow the leader.
+func (h *HostState) Messenger(service string) ybrpc.Messenger {
+	if service == master.MasterService_ServiceDesc.ServiceName {
+		if impl, ok := h.MasterService.(*master.MasterServiceImpl); ok {
+			return impl.Messenger
+		}
+	}
+	return h.messenger
+}
+
 func (h *HostState) Close() error {
+	h.closeOnce.Do(func() {
+		if h.stopKeepalive != nil {
+			close(h.stopKeepalive)
+		}
+	})
 	return h.session.Close()
 }
 
-func ping(s *session.Session) error {
-	service := server.GenericServiceImpl{
-		Log:       s.Log,
-		Messenger: &message.MessengerImpl{Session: s},
+// pinger returns the ping a session makes over each new connection, which times
+// out like any other call to the server
+func pinger(rpcTimeout time.Duration) func(s *session.Session) error {
+	return func(s *session.Session) error {
+		service := server.GenericServiceImpl{
+			Log:       s.Log,
+			Messenger: message.NewMessenger(s, rpcTimeout),
+		}
+		_, err := service.Ping(&server.PingRequestPB{})
+		return err
 	}
-	_, err := service.Ping(&server.PingRequestPB{})
-	return err
 }
@@ -11,6 +11,23 @@ type Dialer interface {
 	Dial(network, address string) (io.ReadWriteCloser, error)
 }
 
+// ServerNameDialer is a Dialer that can verify TLS servers against a name other
+// than the host dialed, such as when the address policy rewrote the address.
+type ServerNameDialer interface {
+	Dialer
+	WithServerName(name string) Dialer
+}
+
+// WithServerName returns a dialer that verifies servers against name. Dialers
+// that do not verify server names are returned unchanged, as is d when name is
+// empty.
+func WithServerName(d Dialer, name string) Dialer {
+	if serverNameDialer, ok := d.(ServerNameDialer); ok && name != "" {
+		return serverNameDialer.WithServerName(name)
+	}
+	return d
+}
+
 type NetDialer struct {
 	TimeoutSeconds int64
 }
@@ -30,5 +47,25 @@ func (d *TLSDialer) Dial(network, address string) (io.ReadWriteCloser, error) {
 		NetDialer: &net.Dialer{Timeout: time.Duration(d.TimeoutSeconds) * time.Second},
 		Config:    d.Config,
 	}
-	return tlsDialer.Dial(network, address)
+	conn, err := tlsDialer.Dial(network, address)
+	if err != nil {
+		return nil, NewTLSError(address, err)
+	}
+	return conn, nil
+}
+
+func (d *TLSDialer) WithServerName(name string) Dialer {
+	dialer := *d
+	dialer.Config = withServerName(d.Config, name)
+	return &dialer
+}
+
+// withServerName returns a copy of config that verifies servers against name.
+func withServerName(config *tls.Config, name string) *tls.Config {
+	if config == nil {
+		return nil
+	}
+	config = config.Clone()
+	config.ServerName = name
+	return config
 }
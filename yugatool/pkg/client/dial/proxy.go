@@ -0,0 +1,55 @@
+package dial
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"time"
+)
+
+// ProxyDialer is a Dialer that reaches servers through an intermediary, such as
+// a bastion host.
+type ProxyDialer interface {
+	Dialer
+
+	// DialContext opens a plain connection through the intermediary, for
+	// clients such as the YSQL and YCQL drivers that set up TLS themselves
+	DialContext(ctx context.Context, network, address string) (net.Conn, error)
+
+	// WithTLS returns a dialer over the same intermediary whose Dial wraps
+	// connections in TLS. A nil config leaves connections unencrypted.
+	WithTLS(config *tls.Config) ProxyDialer
+
+	// Close closes the connection to the intermediary, if any
+	Close() error
+}
+
+// timeoutContext bounds a dial and its TLS handshake by the dial timeout. A zero
+// timeout does not bound them.
+func timeoutContext(timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(context.Background())
+	}
+	return context.WithTimeout(context.Background(), timeout)
+}
+
+// clientTLS runs the TLS handshake over conn, verifying the server against the
+// host of address unless config names a server.
+func clientTLS(ctx context.Context, conn net.Conn, config *tls.Config, address string) (net.Conn, error) {
+	if config.ServerName == "" {
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			host = address
+		}
+		config = config.Clone()
+		config.ServerName = host
+	}
+
+	tlsConn := tls.Client(conn, config)
+	err := tlsConn.HandshakeContext(ctx)
+	if err != nil {
+		_ = conn.Close()
+		return nil, NewTLSError(address, err)
+	}
+	return tlsConn, nil
+}
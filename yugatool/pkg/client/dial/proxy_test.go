@@ -0,0 +1,319 @@
+package dial_test
+
+import (
+	"bufio"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"strconv"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client/dial"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/test/util"
+	"golang.org/x/crypto/ssh"
+)
+
+// serve accepts connections on a local port until the listener is closed
+func serve(listener net.Listener, handle func(conn net.Conn)) string {
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go handle(conn)
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func listen() net.Listener {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).NotTo(HaveOccurred())
+	return listener
+}
+
+func echo(conn net.Conn) {
+	defer conn.Close()
+	_, _ = io.Copy(conn, conn)
+}
+
+func pipe(a, b io.ReadWriteCloser) {
+	go func() {
+		_, _ = io.Copy(a, b)
+		_ = a.Close()
+	}()
+	_, _ = io.Copy(b, a)
+	_ = b.Close()
+}
+
+// sshJumpHost serves the "direct-tcpip" channels that ssh -J opens to the
+// clients with the authorized key
+func sshJumpHost(hostKey ssh.Signer, authorized ssh.PublicKey) func(conn net.Conn) {
+	config := &ssh.ServerConfig{
+		PublicKeyCallback: func(_ ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if string(key.Marshal()) != string(authorized.Marshal()) {
+				return nil, errors.New("unauthorized")
+			}
+			return nil, nil
+		},
+	}
+	config.AddHostKey(hostKey)
+
+	return func(conn net.Conn) {
+		_, channels, requests, err := ssh.NewServerConn(conn, config)
+		if err != nil {
+			return
+		}
+		go ssh.DiscardRequests(requests)
+
+		for newChannel := range channels {
+			var target struct {
+				Host       string
+				Port       uint32
+				OriginHost string
+				OriginPort uint32
+			}
+			if newChannel.ChannelType() != "direct-tcpip" || ssh.Unmarshal(newChannel.ExtraData(), &target) != nil {
+				_ = newChannel.Reject(ssh.UnknownChannelType, "unsupported")
+				continue
+			}
+
+			targetConn, err := net.Dial("tcp", net.JoinHostPort(target.Host, strconv.Itoa(int(target.Port))))
+			if err != nil {
+				_ = newChannel.Reject(ssh.ConnectionFailed, err.Error())
+				continue
+			}
+			channel, channelRequests, err := newChannel.Accept()
+			if err != nil {
+				_ = targetConn.Close()
+				continue
+			}
+			go ssh.DiscardRequests(channelRequests)
+			go pipe(channel, targetConn)
+		}
+	}
+}
+
+// socks5Proxy serves CONNECT requests without authentication
+func socks5Proxy(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+
+	// Greeting: version, methods
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return
+	}
+	if _, err := io.ReadFull(r, make([]byte, header[1])); err != nil {
+		return
+	}
+	_, _ = conn.Write([]byte{5, 0})
+
+	// Request: version, command, reserved, address type, address, port
+	request := make([]byte, 4)
+	if _, err := io.ReadFull(r, request); err != nil {
+		return
+	}
+	var host string
+	switch request[3] {
+	case 1:
+		ip := make([]byte, 4)
+		if _, err := io.ReadFull(r, ip); err != nil {
+			return
+		}
+		host = net.IP(ip).String()
+	case 3:
+		length, err := r.ReadByte()
+		if err != nil {
+			return
+		}
+		name := make([]byte, length)
+		if _, err := io.ReadFull(r, name); err != nil {
+			return
+		}
+		host = string(name)
+	default:
+		return
+	}
+	port := make([]byte, 2)
+	if _, err := io.ReadFull(r, port); err != nil {
+		return
+	}
+
+	target, err := net.Dial("tcp", net.JoinHostPort(host, strconv.Itoa(int(binary.BigEndian.Uint16(port)))))
+	if err != nil {
+		_, _ = conn.Write([]byte{5, 5, 0, 1, 0, 0, 0, 0, 0, 0})
+		return
+	}
+	_, _ = conn.Write([]byte{5, 0, 0, 1, 0, 0, 0, 0, 0, 0})
+	pipe(&bufferedConn{Reader: r, Conn: conn}, target)
+}
+
+type bufferedConn struct {
+	io.Reader
+	net.Conn
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.Reader.Read(b)
+}
+
+// tlsEchoServer echoes over TLS
+func tlsEchoServer() (string, net.Listener) {
+	caPEM, key, err := util.GenerateCACertificate()
+	Expect(err).NotTo(HaveOccurred())
+	certPEM, keyPEM, err := util.GeenerateClientCertFromCACertPEM(caPEM, key)
+	Expect(err).NotTo(HaveOccurred())
+	certificate, err := tls.X509KeyPair(certPEM, keyPEM)
+	Expect(err).NotTo(HaveOccurred())
+
+	listener := tls.NewListener(listen(), &tls.Config{Certificates: []tls.Certificate{certificate}})
+	return serve(listener, echo), listener
+}
+
+func expectEcho(conn io.ReadWriteCloser) {
+	defer conn.Close()
+
+	_, err := conn.Write([]byte("YB\x01"))
+	Expect(err).NotTo(HaveOccurred())
+
+	reply := make([]byte, 3)
+	_, err = io.ReadFull(conn, reply)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(reply).To(Equal([]byte("YB\x01")))
+}
+
+var _ = Describe("Proxy dialers", func() {
+	var (
+		listeners []net.Listener
+		target    string
+	)
+
+	start := func(handle func(conn net.Conn)) string {
+		listener := listen()
+		listeners = append(listeners, listener)
+		return serve(listener, handle)
+	}
+
+	BeforeEach(func() {
+		listeners = nil
+		target = start(echo)
+	})
+
+	AfterEach(func() {
+		for _, listener := range listeners {
+			_ = listener.Close()
+		}
+	})
+
+	Context("SSHDialer", func() {
+		var (
+			dialer  *dial.SSHDialer
+			jumpKey ssh.PublicKey
+		)
+
+		BeforeEach(func() {
+			_, hostPrivateKey, err := ed25519.GenerateKey(rand.Reader)
+			Expect(err).NotTo(HaveOccurred())
+			hostKey, err := ssh.NewSignerFromKey(hostPrivateKey)
+			Expect(err).NotTo(HaveOccurred())
+			jumpKey = hostKey.PublicKey()
+
+			_, clientPrivateKey, err := ed25519.GenerateKey(rand.Reader)
+			Expect(err).NotTo(HaveOccurred())
+			clientKey, err := ssh.NewSignerFromKey(clientPrivateKey)
+			Expect(err).NotTo(HaveOccurred())
+
+			jumpHost := start(sshJumpHost(hostKey, clientKey.PublicKey()))
+			dialer = dial.NewSSHDialer(jumpHost, &ssh.ClientConfig{
+				User:            "yugabyte",
+				Auth:            []ssh.AuthMethod{ssh.PublicKeys(clientKey)},
+				HostKeyCallback: ssh.FixedHostKey(jumpKey),
+			})
+		})
+
+		AfterEach(func() {
+			_ = dialer.Close()
+		})
+
+		It("connects through the jump host", func() {
+			conn, err := dialer.Dial("tcp", target)
+			Expect(err).NotTo(HaveOccurred())
+			expectEcho(conn)
+
+			// Later connections share the ssh connection
+			conn, err = dialer.Dial("tcp", target)
+			Expect(err).NotTo(HaveOccurred())
+			expectEcho(conn)
+		})
+
+		It("wraps connections in TLS", func() {
+			tlsTarget, listener := tlsEchoServer()
+			listeners = append(listeners, listener)
+
+			tlsDialer := dialer.WithTLS(&tls.Config{InsecureSkipVerify: true})
+			conn, err := tlsDialer.Dial("tcp", tlsTarget)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(conn).To(BeAssignableToTypeOf(&tls.Conn{}))
+			expectEcho(conn)
+		})
+
+		It("fails to connect to unreachable servers", func() {
+			unreachable := listen()
+			_ = unreachable.Close()
+
+			_, err := dialer.Dial("tcp", unreachable.Addr().String())
+			Expect(err).To(MatchError(ContainSubstring("through jump host")))
+		})
+
+		It("rejects a jump host with an unknown host key", func() {
+			_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+			Expect(err).NotTo(HaveOccurred())
+			otherSigner, err := ssh.NewSignerFromKey(otherKey)
+			Expect(err).NotTo(HaveOccurred())
+			dialer.ClientConfig.HostKeyCallback = ssh.FixedHostKey(otherSigner.PublicKey())
+
+			_, err = dialer.Dial("tcp", target)
+			Expect(err).To(MatchError(ContainSubstring("unable to log in to jump host")))
+		})
+	})
+
+	Context("SOCKS5Dialer", func() {
+		var dialer *dial.SOCKS5Dialer
+
+		BeforeEach(func() {
+			dialer = &dial.SOCKS5Dialer{ProxyAddress: start(socks5Proxy), TimeoutSeconds: 5}
+		})
+
+		It("connects through the proxy", func() {
+			conn, err := dialer.Dial("tcp", target)
+			Expect(err).NotTo(HaveOccurred())
+			expectEcho(conn)
+		})
+
+		It("wraps connections in TLS", func() {
+			tlsTarget, listener := tlsEchoServer()
+			listeners = append(listeners, listener)
+
+			tlsDialer := dialer.WithTLS(&tls.Config{InsecureSkipVerify: true})
+			conn, err := tlsDialer.Dial("tcp", tlsTarget)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(conn).To(BeAssignableToTypeOf(&tls.Conn{}))
+			expectEcho(conn)
+		})
+
+		It("reports connections the proxy could not make", func() {
+			unreachable := listen()
+			_ = unreachable.Close()
+
+			_, err := dialer.Dial("tcp", unreachable.Addr().String())
+			Expect(err).To(MatchError(ContainSubstring("through proxy")))
+		})
+	})
+})
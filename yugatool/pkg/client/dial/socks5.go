@@ -0,0 +1,71 @@
+package dial
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"net"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/net/proxy"
+)
+
+var (
+	_ ProxyDialer      = &SOCKS5Dialer{}
+	_ ServerNameDialer = &SOCKS5Dialer{}
+)
+
+// SOCKS5Dialer connects to servers through a SOCKS5 proxy, such as one opened
+// with "ssh -D". Server addresses are resolved by the proxy.
+type SOCKS5Dialer struct {
+	// ProxyAddress is the address of the proxy, as host:port
+	ProxyAddress   string
+	Auth           *proxy.Auth
+	TimeoutSeconds int64
+
+	// Config, when set, wraps the connections made by Dial in TLS
+	Config *tls.Config
+}
+
+func (d *SOCKS5Dialer) Dial(network, address string) (io.ReadWriteCloser, error) {
+	ctx, cancel := timeoutContext(time.Duration(d.TimeoutSeconds) * time.Second)
+	defer cancel()
+
+	conn, err := d.DialContext(ctx, network, address)
+	if err != nil || d.Config == nil {
+		return conn, err
+	}
+	return clientTLS(ctx, conn, d.Config, address)
+}
+
+func (d *SOCKS5Dialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	forward := &net.Dialer{Timeout: time.Duration(d.TimeoutSeconds) * time.Second}
+
+	dialer, err := proxy.SOCKS5("tcp", d.ProxyAddress, d.Auth, forward)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := dialer.(proxy.ContextDialer).DialContext(ctx, network, address)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to connect to %s through proxy %s", address, d.ProxyAddress)
+	}
+	return conn, nil
+}
+
+func (d *SOCKS5Dialer) WithTLS(config *tls.Config) ProxyDialer {
+	dialer := *d
+	dialer.Config = config
+	return &dialer
+}
+
+func (d *SOCKS5Dialer) WithServerName(name string) Dialer {
+	dialer := *d
+	dialer.Config = withServerName(d.Config, name)
+	return &dialer
+}
+
+func (d *SOCKS5Dialer) Close() error {
+	return nil
+}
@@ -0,0 +1,140 @@
+package dial
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"net"
+	"sync"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ssh"
+)
+
+var (
+	_ ProxyDialer      = &SSHDialer{}
+	_ ServerNameDialer = &SSHDialer{}
+)
+
+// SSHDialer connects to servers through an SSH jump host, the way "ssh -J"
+// does. The SSH connection is made on the first dial and shared by all later
+// connections, including those of dialers returned by WithTLS.
+type SSHDialer struct {
+	// JumpHost is the address of the SSH server, as host:port
+	JumpHost     string
+	ClientConfig *ssh.ClientConfig
+
+	// Config, when set, wraps the connections made by Dial in TLS
+	Config *tls.Config
+
+	// Agent, when set, is the connection to ssh-agent that ClientConfig
+	// authenticates with. It is closed with the dialer.
+	Agent io.Closer
+
+	client *sshClient
+}
+
+type sshClient struct {
+	m      sync.Mutex
+	client *ssh.Client
+}
+
+func NewSSHDialer(jumpHost string, clientConfig *ssh.ClientConfig) *SSHDialer {
+	return &SSHDialer{
+		JumpHost:     jumpHost,
+		ClientConfig: clientConfig,
+		client:       &sshClient{},
+	}
+}
+
+func (d *SSHDialer) Dial(network, address string) (io.ReadWriteCloser, error) {
+	ctx, cancel := timeoutContext(d.ClientConfig.Timeout)
+	defer cancel()
+
+	conn, err := d.DialContext(ctx, network, address)
+	if err != nil || d.Config == nil {
+		return conn, err
+	}
+	return clientTLS(ctx, conn, d.Config, address)
+}
+
+func (d *SSHDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	client, err := d.sshClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := client.Dial(network, address)
+	if err != nil {
+		// A jump host that went away is reconnected to on the next dial
+		if _, ok := err.(*ssh.OpenChannelError); !ok {
+			d.reset(client)
+		}
+		return nil, errors.Wrapf(err, "unable to connect to %s through jump host %s", address, d.JumpHost)
+	}
+	return conn, nil
+}
+
+func (d *SSHDialer) sshClient(ctx context.Context) (*ssh.Client, error) {
+	d.client.m.Lock()
+	defer d.client.m.Unlock()
+
+	if d.client.client != nil {
+		return d.client.client, nil
+	}
+
+	netDialer := &net.Dialer{Timeout: d.ClientConfig.Timeout}
+	conn, err := netDialer.DialContext(ctx, "tcp", d.JumpHost)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to connect to jump host %s", d.JumpHost)
+	}
+
+	sshConn, channels, requests, err := ssh.NewClientConn(conn, d.JumpHost, d.ClientConfig)
+	if err != nil {
+		_ = conn.Close()
+		return nil, errors.Wrapf(err, "unable to log in to jump host %s", d.JumpHost)
+	}
+
+	d.client.client = ssh.NewClient(sshConn, channels, requests)
+	return d.client.client, nil
+}
+
+func (d *SSHDialer) reset(client *ssh.Client) {
+	d.client.m.Lock()
+	defer d.client.m.Unlock()
+
+	if d.client.client == client {
+		_ = client.Close()
+		d.client.client = nil
+	}
+}
+
+func (d *SSHDialer) WithTLS(config *tls.Config) ProxyDialer {
+	dialer := *d
+	dialer.Config = config
+	return &dialer
+}
+
+func (d *SSHDialer) WithServerName(name string) Dialer {
+	dialer := *d
+	dialer.Config = withServerName(d.Config, name)
+	return &dialer
+}
+
+func (d *SSHDialer) Close() error {
+	d.client.m.Lock()
+	defer d.client.m.Unlock()
+
+	var err error
+	if d.client.client != nil {
+		err = d.client.client.Close()
+		d.client.client = nil
+	}
+	if d.Agent != nil {
+		if agentErr := d.Agent.Close(); err == nil {
+			err = agentErr
+		}
+		d.Agent = nil
+	}
+	return err
+}
@@ -0,0 +1,104 @@
+package dial
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrCertificateExpired is matched by TLS errors for certificates that have
+	// expired or are not yet valid
+	ErrCertificateExpired = errors.New("certificate has expired or is not yet valid")
+
+	// ErrUnknownAuthority is matched by TLS errors for certificates that are not
+	// signed by a trusted CA
+	ErrUnknownAuthority = errors.New("certificate signed by unknown authority")
+
+	// ErrHostnameMismatch is matched by TLS errors for certificates that are not
+	// valid for the name of the server
+	ErrHostnameMismatch = errors.New("certificate is not valid for the server name")
+)
+
+// TLSError is a failure to verify the certificate of the server at Address.
+// It matches ErrCertificateExpired, ErrUnknownAuthority or ErrHostnameMismatch
+// with errors.Is, and explains how the problem may be fixed.
+type TLSError struct {
+	Address string
+	Err     error
+}
+
+// NewTLSError returns err as a TLSError when it is a certificate verification
+// failure, and err unchanged otherwise.
+func NewTLSError(address string, err error) error {
+	if err == nil || kind(err) == nil {
+		return err
+	}
+	return &TLSError{Address: address, Err: err}
+}
+
+func (e *TLSError) Error() string {
+	var invalid x509.CertificateInvalidError
+	var unknownAuthority x509.UnknownAuthorityError
+	var hostname x509.HostnameError
+
+	switch {
+	case errors.As(e.Err, &invalid) && invalid.Reason == x509.Expired:
+		cert := invalid.Cert
+		return fmt.Sprintf("the certificate of %s for %q is only valid from %s until %s: rotate the server certificates, or check the clock of this host",
+			e.Address, cert.Subject.CommonName, cert.NotBefore.Format(time.RFC3339), cert.NotAfter.Format(time.RFC3339))
+	case errors.As(e.Err, &unknownAuthority):
+		issuer := "an unknown issuer"
+		if unknownAuthority.Cert != nil {
+			issuer = fmt.Sprintf("%q", unknownAuthority.Cert.Issuer.String())
+		}
+		return fmt.Sprintf("the certificate of %s is signed by %s, which is not a trusted CA: pass the universe's root certificate with --cacert",
+			e.Address, issuer)
+	case errors.As(e.Err, &hostname):
+		return fmt.Sprintf("the certificate of %s is not valid for %q, only for [%s]: connect using one of those names, or pass --skiphostverification",
+			e.Address, hostname.Host, strings.Join(CertificateNames(hostname.Certificate), ", "))
+	}
+	return fmt.Sprintf("TLS handshake with %s failed: %s", e.Address, e.Err)
+}
+
+func (e *TLSError) Unwrap() error {
+	return e.Err
+}
+
+func (e *TLSError) Is(target error) bool {
+	return target == kind(e.Err)
+}
+
+// kind returns the sentinel error matching a certificate verification failure,
+// or nil for other errors.
+func kind(err error) error {
+	var invalid x509.CertificateInvalidError
+	var unknownAuthority x509.UnknownAuthorityError
+	var hostname x509.HostnameError
+
+	switch {
+	case errors.As(err, &invalid) && invalid.Reason == x509.Expired:
+		return ErrCertificateExpired
+	case errors.As(err, &unknownAuthority):
+		return ErrUnknownAuthority
+	case errors.As(err, &hostname):
+		return ErrHostnameMismatch
+	}
+	return nil
+}
+
+// CertificateNames returns the DNS names and IP addresses a certificate is valid
+// for.
+func CertificateNames(cert *x509.Certificate) []string {
+	if cert == nil {
+		return nil
+	}
+
+	names := append([]string{}, cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		names = append(names, ip.String())
+	}
+	return names
+}
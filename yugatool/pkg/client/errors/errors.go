@@ -0,0 +1,241 @@
+// Package errors turns the failures reported by YugabyteDB RPCs into Go errors.
+//
+// Failures arrive in one of two places. The RPC layer rejects a call with an
+// ErrorStatusPB in place of the response, which is decoded into an *RPCError.
+// Services accept the call but report an application error in the Error field
+// of the response, which FromResponse decodes into a *MasterError,
+// *TabletServerError or *CDCError.
+//
+// Each of these may be matched against the common failures below with
+// errors.Is, or inspected for the exact code with errors.As.
+package errors
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/cdc"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/common"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/master"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/rpc"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/tserver"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	// ErrNotFound matches errors for an object, such as a table, tablet or
+	// namespace, that does not exist
+	ErrNotFound = errors.New("not found")
+
+	// ErrNotTheLeader matches errors from a server that is not the leader of
+	// the master quorum or tablet the call was meant for
+	ErrNotTheLeader = errors.New("not the leader")
+
+	// ErrTabletNotRunning matches errors for a tablet replica that exists but is
+	// not serving yet, or any more
+	ErrTabletNotRunning = errors.New("tablet not running")
+
+	// ErrServiceUnavailable matches errors from a server that is too busy, is
+	// shutting down or is not ready to serve. The call may succeed if retried.
+	ErrServiceUnavailable = errors.New("service unavailable")
+)
+
+// RPCError is a call rejected by the RPC layer, such as a call to an unknown
+// method or a call refused by a server that is too busy.
+type RPCError struct {
+	Service string
+	Method  string
+	Status  *rpc.ErrorStatusPB
+}
+
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("%s.%s returned %s: %s", e.Service, e.Method, e.Status.GetCode(), e.Status.GetMessage())
+}
+
+func (e *RPCError) Is(target error) bool {
+	switch e.Status.GetCode() {
+	case rpc.ErrorStatusPB_ERROR_SERVER_TOO_BUSY,
+		rpc.ErrorStatusPB_FATAL_SERVER_SHUTTING_DOWN:
+		return target == ErrServiceUnavailable
+	}
+	return false
+}
+
+// DecodeErrorStatus decodes the ErrorStatusPB sent in place of a response when
+// the response header is flagged as an error.
+func DecodeErrorStatus(service string, method string, body []byte) error {
+	status := &rpc.ErrorStatusPB{}
+	err := proto.Unmarshal(body, status)
+	if err != nil {
+		return fmt.Errorf("%s.%s returned an error that could not be decoded: %w", service, method, err)
+	}
+	return &RPCError{Service: service, Method: method, Status: status}
+}
+
+// StatusError is a failed AppStatusPB, the status carried by application
+// errors and by per-item errors such as those from GetTabletLocations.
+type StatusError struct {
+	Status *common.AppStatusPB
+}
+
+// FromStatus returns the status as an error, or nil if it is not set or OK.
+func FromStatus(status *common.AppStatusPB) error {
+	if status == nil || status.GetCode() == common.AppStatusPB_OK {
+		return nil
+	}
+	return &StatusError{Status: status}
+}
+
+func (e *StatusError) Error() string {
+	if e.Status.GetMessage() == "" {
+		return e.Status.GetCode().String()
+	}
+	return fmt.Sprintf("%s: %s", e.Status.GetCode(), e.Status.GetMessage())
+}
+
+func (e *StatusError) Is(target error) bool {
+	switch e.Status.GetCode() {
+	case common.AppStatusPB_NOT_FOUND:
+		return target == ErrNotFound
+	case common.AppStatusPB_SERVICE_UNAVAILABLE,
+		common.AppStatusPB_LEADER_NOT_READY_TO_SERVE,
+		common.AppStatusPB_LEADER_HAS_NO_LEASE,
+		common.AppStatusPB_SHUTDOWN_IN_PROGRESS:
+		return target == ErrServiceUnavailable
+	}
+	return false
+}
+
+// MasterError is an application error returned by the MasterService.
+type MasterError struct {
+	Err *master.MasterErrorPB
+}
+
+func (e *MasterError) Error() string {
+	return describe("master", e.Err.GetCode().String(), e.Err.GetStatus())
+}
+
+func (e *MasterError) Is(target error) bool {
+	switch e.Err.GetCode() {
+	case master.MasterErrorPB_NOT_THE_LEADER:
+		return target == ErrNotTheLeader
+	case master.MasterErrorPB_OBJECT_NOT_FOUND,
+		master.MasterErrorPB_NAMESPACE_NOT_FOUND,
+		master.MasterErrorPB_TYPE_NOT_FOUND,
+		master.MasterErrorPB_SNAPSHOT_NOT_FOUND,
+		master.MasterErrorPB_ROLE_NOT_FOUND,
+		master.MasterErrorPB_REDIS_CONFIG_NOT_FOUND:
+		return target == ErrNotFound
+	case master.MasterErrorPB_CATALOG_MANAGER_NOT_INITIALIZED:
+		return target == ErrServiceUnavailable
+	}
+	return false
+}
+
+// Unwrap exposes the status, so errors.Is also matches on the status code when
+// the master error code is not specific.
+func (e *MasterError) Unwrap() error {
+	return FromStatus(e.Err.GetStatus())
+}
+
+// TabletServerError is an application error returned by the TabletServerService,
+// TabletServerAdminService or ConsensusService.
+type TabletServerError struct {
+	Err *tserver.TabletServerErrorPB
+}
+
+func (e *TabletServerError) Error() string {
+	return describe("tablet server", e.Err.GetCode().String(), e.Err.GetStatus())
+}
+
+func (e *TabletServerError) Is(target error) bool {
+	switch e.Err.GetCode() {
+	case tserver.TabletServerErrorPB_TABLET_NOT_FOUND:
+		return target == ErrNotFound
+	case tserver.TabletServerErrorPB_TABLET_NOT_RUNNING:
+		return target == ErrTabletNotRunning
+	case tserver.TabletServerErrorPB_NOT_THE_LEADER:
+		return target == ErrNotTheLeader
+	case tserver.TabletServerErrorPB_LEADER_NOT_READY_TO_SERVE:
+		return target == ErrServiceUnavailable
+	}
+	return false
+}
+
+func (e *TabletServerError) Unwrap() error {
+	return FromStatus(e.Err.GetStatus())
+}
+
+// CDCError is an application error returned by the CDCService.
+type CDCError struct {
+	Err *cdc.CDCErrorPB
+}
+
+func (e *CDCError) Error() string {
+	return describe("cdc", e.Err.GetCode().String(), e.Err.GetStatus())
+}
+
+func (e *CDCError) Is(target error) bool {
+	switch e.Err.GetCode() {
+	case cdc.CDCErrorPB_TABLET_NOT_FOUND,
+		cdc.CDCErrorPB_TABLE_NOT_FOUND,
+		cdc.CDCErrorPB_SUBSCRIBER_NOT_FOUND:
+		return target == ErrNotFound
+	case cdc.CDCErrorPB_TABLET_NOT_RUNNING:
+		return target == ErrTabletNotRunning
+	case cdc.CDCErrorPB_NOT_LEADER:
+		return target == ErrNotTheLeader
+	case cdc.CDCErrorPB_LEADER_NOT_READY,
+		cdc.CDCErrorPB_NOT_RUNNING:
+		return target == ErrServiceUnavailable
+	}
+	return false
+}
+
+func (e *CDCError) Unwrap() error {
+	return FromStatus(e.Err.GetStatus())
+}
+
+func describe(source string, code string, status *common.AppStatusPB) string {
+	if status.GetMessage() == "" {
+		return fmt.Sprintf("%s error %s", source, code)
+	}
+	return fmt.Sprintf("%s error %s: %s", source, code, status.GetMessage())
+}
+
+type masterResponse interface {
+	GetError() *master.MasterErrorPB
+}
+
+type tabletServerResponse interface {
+	GetError() *tserver.TabletServerErrorPB
+}
+
+type cdcResponse interface {
+	GetError() *cdc.CDCErrorPB
+}
+
+// FromResponse returns the application error embedded in a response, or nil if
+// the response reports no error.
+func FromResponse(response proto.Message) error {
+	// Unwrap responses wrapped to collect sidecars
+	if wrapper, ok := response.(interface{ Unwrap() proto.Message }); ok {
+		response = wrapper.Unwrap()
+	}
+
+	switch r := response.(type) {
+	case masterResponse:
+		if r.GetError() != nil {
+			return &MasterError{Err: r.GetError()}
+		}
+	case tabletServerResponse:
+		if r.GetError() != nil {
+			return &TabletServerError{Err: r.GetError()}
+		}
+	case cdcResponse:
+		if r.GetError() != nil {
+			return &CDCError{Err: r.GetError()}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package errors_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/icza/gox/gox"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/cdc"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/common"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/master"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/rpc"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/tserver"
+	yberrors "github.com/yugabyte/yb-tools/yugatool/pkg/client/errors"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client/message"
+	"google.golang.org/protobuf/proto"
+)
+
+var _ = Describe("Errors", func() {
+	Context("DecodeErrorStatus()", func() {
+		It("decodes the error status", func() {
+			body, err := proto.Marshal(&rpc.ErrorStatusPB{Message: NewString("too busy"), Code: rpc.ErrorStatusPB_ERROR_SERVER_TOO_BUSY.Enum()})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = yberrors.DecodeErrorStatus("yb.master.MasterService", "ListMasters", body)
+			Expect(err).To(MatchError("yb.master.MasterService.ListMasters returned ERROR_SERVER_TOO_BUSY: too busy"))
+			Expect(errors.Is(err, yberrors.ErrServiceUnavailable)).To(BeTrue())
+
+			var rpcError *yberrors.RPCError
+			Expect(errors.As(err, &rpcError)).To(BeTrue())
+			Expect(rpcError.Service).To(Equal("yb.master.MasterService"))
+			Expect(rpcError.Method).To(Equal("ListMasters"))
+		})
+		It("returns an error for a corrupt body", func() {
+			err := yberrors.DecodeErrorStatus("yb.master.MasterService", "ListMasters", []byte{0xff})
+			Expect(err).To(HaveOccurred())
+
+			var rpcError *yberrors.RPCError
+			Expect(errors.As(err, &rpcError)).To(BeFalse())
+		})
+	})
+
+	Context("FromResponse()", func() {
+		It("returns nil when the response has no error", func() {
+			Expect(yberrors.FromResponse(&master.ListMastersResponsePB{})).To(BeNil())
+			Expect(yberrors.FromResponse(&tserver.ListTabletsResponsePB{})).To(BeNil())
+			Expect(yberrors.FromResponse(&cdc.ListTabletsResponsePB{})).To(BeNil())
+		})
+		It("returns the master error", func() {
+			err := yberrors.FromResponse(&master.ListMastersResponsePB{
+				Error: &master.MasterErrorPB{
+					Code:   master.MasterErrorPB_NOT_THE_LEADER.Enum(),
+					Status: &common.AppStatusPB{Code: common.AppStatusPB_ILLEGAL_STATE.Enum(), Message: NewString("not the leader")},
+				},
+			})
+			Expect(err).To(MatchError("master error NOT_THE_LEADER: not the leader"))
+
+			var masterError *yberrors.MasterError
+			Expect(errors.As(err, &masterError)).To(BeTrue())
+			Expect(masterError.Err.GetCode()).To(Equal(master.MasterErrorPB_NOT_THE_LEADER))
+		})
+		It("returns the tablet server error", func() {
+			err := yberrors.FromResponse(&tserver.ListTabletsResponsePB{
+				Error: &tserver.TabletServerErrorPB{Code: tserver.TabletServerErrorPB_TABLET_NOT_RUNNING.Enum()},
+			})
+			Expect(err).To(MatchError("tablet server error TABLET_NOT_RUNNING"))
+			Expect(errors.Is(err, yberrors.ErrTabletNotRunning)).To(BeTrue())
+		})
+		It("returns the cdc error", func() {
+			err := yberrors.FromResponse(&cdc.ListTabletsResponsePB{
+				Error: &cdc.CDCErrorPB{Code: cdc.CDCErrorPB_TABLE_NOT_FOUND.Enum()},
+			})
+			Expect(err).To(MatchError("cdc error TABLE_NOT_FOUND"))
+			Expect(errors.Is(err, yberrors.ErrNotFound)).To(BeTrue())
+		})
+		It("looks inside responses wrapped to collect sidecars", func() {
+			err := yberrors.FromResponse(&message.ResponseWithSidecars{
+				Message: &tserver.ReadResponsePB{
+					Error: &tserver.TabletServerErrorPB{Code: tserver.TabletServerErrorPB_NOT_THE_LEADER.Enum()},
+				},
+			})
+			Expect(errors.Is(err, yberrors.ErrNotTheLeader)).To(BeTrue())
+		})
+	})
+
+	DescribeTable("errors.Is()",
+		func(err error, target error, matches bool) {
+			Expect(errors.Is(err, target)).To(Equal(matches))
+		},
+		Entry("master NOT_THE_LEADER is ErrNotTheLeader",
+			&yberrors.MasterError{Err: &master.MasterErrorPB{Code: master.MasterErrorPB_NOT_THE_LEADER.Enum()}}, yberrors.ErrNotTheLeader, true),
+		Entry("master OBJECT_NOT_FOUND is ErrNotFound",
+			&yberrors.MasterError{Err: &master.MasterErrorPB{Code: master.MasterErrorPB_OBJECT_NOT_FOUND.Enum()}}, yberrors.ErrNotFound, true),
+		Entry("master NAMESPACE_NOT_FOUND is ErrNotFound",
+			&yberrors.MasterError{Err: &master.MasterErrorPB{Code: master.MasterErrorPB_NAMESPACE_NOT_FOUND.Enum()}}, yberrors.ErrNotFound, true),
+		Entry("master OBJECT_NOT_FOUND is not ErrNotTheLeader",
+			&yberrors.MasterError{Err: &master.MasterErrorPB{Code: master.MasterErrorPB_OBJECT_NOT_FOUND.Enum()}}, yberrors.ErrNotTheLeader, false),
+		Entry("master UNKNOWN_ERROR with a NOT_FOUND status is ErrNotFound",
+			&yberrors.MasterError{Err: &master.MasterErrorPB{
+				Code:   master.MasterErrorPB_UNKNOWN_ERROR.Enum(),
+				Status: &common.AppStatusPB{Code: common.AppStatusPB_NOT_FOUND.Enum()},
+			}}, yberrors.ErrNotFound, true),
+		Entry("master CATALOG_MANAGER_NOT_INITIALIZED is ErrServiceUnavailable",
+			&yberrors.MasterError{Err: &master.MasterErrorPB{Code: master.MasterErrorPB_CATALOG_MANAGER_NOT_INITIALIZED.Enum()}}, yberrors.ErrServiceUnavailable, true),
+		Entry("tablet server TABLET_NOT_FOUND is ErrNotFound",
+			&yberrors.TabletServerError{Err: &tserver.TabletServerErrorPB{Code: tserver.TabletServerErrorPB_TABLET_NOT_FOUND.Enum()}}, yberrors.ErrNotFound, true),
+		Entry("tablet server TABLET_NOT_RUNNING is ErrTabletNotRunning",
+			&yberrors.TabletServerError{Err: &tserver.TabletServerErrorPB{Code: tserver.TabletServerErrorPB_TABLET_NOT_RUNNING.Enum()}}, yberrors.ErrTabletNotRunning, true),
+		Entry("tablet server NOT_THE_LEADER is ErrNotTheLeader",
+			&yberrors.TabletServerError{Err: &tserver.TabletServerErrorPB{Code: tserver.TabletServerErrorPB_NOT_THE_LEADER.Enum()}}, yberrors.ErrNotTheLeader, true),
+		Entry("cdc NOT_LEADER is ErrNotTheLeader",
+			&yberrors.CDCError{Err: &cdc.CDCErrorPB{Code: cdc.CDCErrorPB_NOT_LEADER.Enum()}}, yberrors.ErrNotTheLeader, true),
+		Entry("cdc TABLET_NOT_RUNNING is ErrTabletNotRunning",
+			&yberrors.CDCError{Err: &cdc.CDCErrorPB{Code: cdc.CDCErrorPB_TABLET_NOT_RUNNING.Enum()}}, yberrors.ErrTabletNotRunning, true),
+		Entry("status SERVICE_UNAVAILABLE is ErrServiceUnavailable",
+			&yberrors.StatusError{Status: &common.AppStatusPB{Code: common.AppStatusPB_SERVICE_UNAVAILABLE.Enum()}}, yberrors.ErrServiceUnavailable, true),
+		Entry("status LEADER_HAS_NO_LEASE is ErrServiceUnavailable",
+			&yberrors.StatusError{Status: &common.AppStatusPB{Code: common.AppStatusPB_LEADER_HAS_NO_LEASE.Enum()}}, yberrors.ErrServiceUnavailable, true),
+		Entry("status INVALID_ARGUMENT is not ErrNotFound",
+			&yberrors.StatusError{Status: &common.AppStatusPB{Code: common.AppStatusPB_INVALID_ARGUMENT.Enum()}}, yberrors.ErrNotFound, false),
+		Entry("rpc FATAL_SERVER_SHUTTING_DOWN is ErrServiceUnavailable",
+			&yberrors.RPCError{Status: &rpc.ErrorStatusPB{Code: rpc.ErrorStatusPB_FATAL_SERVER_SHUTTING_DOWN.Enum()}}, yberrors.ErrServiceUnavailable, true),
+		Entry("rpc ERROR_NO_SUCH_METHOD is not ErrServiceUnavailable",
+			&yberrors.RPCError{Status: &rpc.ErrorStatusPB{Code: rpc.ErrorStatusPB_ERROR_NO_SUCH_METHOD.Enum()}}, yberrors.ErrServiceUnavailable, false),
+	)
+
+	Context("FromStatus()", func() {
+		It("returns nil for a missing or OK status", func() {
+			Expect(yberrors.FromStatus(nil)).To(BeNil())
+			Expect(yberrors.FromStatus(&common.AppStatusPB{Code: common.AppStatusPB_OK.Enum()})).To(BeNil())
+		})
+	})
+
+	Context("PromoteResponseErrors()", func() {
+		var response *master.ListMastersResponsePB
+
+		It("returns the error embedded in the response", func() {
+			response = &master.ListMastersResponsePB{}
+			messenger := yberrors.PromoteResponseErrors(&fakeMessenger{
+				response: &master.ListMastersResponsePB{Error: &master.MasterErrorPB{Code: master.MasterErrorPB_NOT_THE_LEADER.Enum()}},
+			})
+
+			err := messenger.SendMessage(context.Background(), "yb.master.MasterService", "ListMasters", &master.ListMastersRequestPB{}, response)
+			Expect(err).To(MatchError("yb.master.MasterService.ListMasters: master error NOT_THE_LEADER"))
+			Expect(errors.Is(err, yberrors.ErrNotTheLeader)).To(BeTrue())
+		})
+		It("returns nil when the response has no error", func() {
+			response = &master.ListMastersResponsePB{}
+			messenger := yberrors.PromoteResponseErrors(&fakeMessenger{response: &master.ListMastersResponsePB{}})
+
+			err := messenger.SendMessage(context.Background(), "yb.master.MasterService", "ListMasters", &master.ListMastersRequestPB{}, response)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
+
+type fakeMessenger struct {
+	response proto.Message
+}
+
+func (m *fakeMessenger) SendMessage(_ context.Context, _ string, _ string, _ proto.Message, response proto.Message) error {
+	proto.Merge(response, m.response)
+	return nil
+}
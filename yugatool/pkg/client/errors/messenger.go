@@ -0,0 +1,35 @@
+package errors
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yugabyte/yb-tools/protoc-gen-ybrpc/pkg/message"
+	"google.golang.org/protobuf/proto"
+)
+
+// promotingMessenger returns the application error embedded in each response as
+// the error of the call.
+type promotingMessenger struct {
+	message.Messenger
+}
+
+// PromoteResponseErrors wraps a messenger so the generated service methods using
+// it return the Error field of a response as their error, instead of leaving it
+// to every caller to check.
+func PromoteResponseErrors(m message.Messenger) message.Messenger {
+	return &promotingMessenger{Messenger: m}
+}
+
+func (m *promotingMessenger) SendMessage(ctx context.Context, service string, method string, request proto.Message, response proto.Message) error {
+	err := m.Messenger.SendMessage(ctx, service, method, request, response)
+	if err != nil {
+		return err
+	}
+
+	err = FromResponse(response)
+	if err != nil {
+		return fmt.Errorf("%s.%s: %w", service, method, err)
+	}
+	return nil
+}
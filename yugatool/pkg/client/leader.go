@@ -0,0 +1,198 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/common"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/master"
+	yberrors "github.com/yugabyte/yb-tools/yugatool/pkg/client/errors"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client/message"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/util"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// MasterLeaderRetries is the number of times a MasterService call is retried
+	// against a newly discovered leader before giving up
+	MasterLeaderRetries = 5
+
+	masterLeaderBackoff    = 100 * time.Millisecond
+	masterLeaderMaxBackoff = 2 * time.Second
+)
+
+// masterLeaderMessenger sends MasterService calls to the current master leader.
+// When the master replies NOT_THE_LEADER or is unavailable, the leader is
+// rediscovered and the call is retried with bounded backoff. When the
+// connection to it fails, only calls to idempotent methods are retried, as the
+// call may already have run.
+type masterLeaderMessenger struct {
+	Log    logr.Logger
+	client *YBClient
+}
+
+func (m *masterLeaderMessenger) SendMessage(ctx context.Context, service string, method string, request proto.Message, response proto.Message) error {
+	backoff := masterLeaderBackoff
+	for attempt := 0; ; attempt++ {
+		leader, err := m.client.masterLeader(ctx)
+		if err == nil {
+			err = leader.messenger.SendMessage(ctx, service, method, request, response)
+			if err == nil {
+				if !isNotTheLeader(response) {
+					return nil
+				}
+				err = errors.Errorf("%s is not the master leader", util.HostPortString(leader.session.Host))
+			} else if !isRetryable(service, method, err) {
+				// A lost leader is still looked for again by the next call
+				if !isRPCError(err) {
+					m.client.invalidateMasterLeader(leader)
+				}
+				return err
+			}
+			m.client.invalidateMasterLeader(leader)
+		}
+
+		if ctx.Err() != nil || attempt >= MasterLeaderRetries {
+			// Once out of retries a NOT_THE_LEADER response is returned as-is, the same as without leader tracking
+			if isNotTheLeader(response) {
+				return nil
+			}
+			return err
+		}
+
+		m.Log.V(1).Info("retrying call on master leader", "service", service, "method", method, "attempt", attempt+1, "backoff", backoff, "error", err)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%s.%s: %w", service, method, ctx.Err())
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > masterLeaderMaxBackoff {
+			backoff = masterLeaderMaxBackoff
+		}
+		proto.Reset(response)
+	}
+}
+
+func isNotTheLeader(response proto.Message) bool {
+	return errors.Is(yberrors.FromResponse(response), yberrors.ErrNotTheLeader)
+}
+
+// isRetryable reports whether a call that failed may succeed on the next leader.
+// A call the master turned away as unavailable never ran, and may be sent
+// again. Any other call rejected by the RPC layer, such as one to an unknown
+// method, fails the same way everywhere. A call whose connection was lost, or
+// that timed out, may have run on the old leader, so only idempotent methods
+// are sent again.
+func isRetryable(service string, method string, err error) bool {
+	if isRPCError(err) {
+		return errors.Is(err, yberrors.ErrServiceUnavailable)
+	}
+	return message.IsIdempotent(service, method)
+}
+
+func isRPCError(err error) bool {
+	var rpcError *yberrors.RPCError
+	return errors.As(err, &rpcError)
+}
+
+// masterLeader returns the connection to the master leader, finding the leader
+// again if the last one was lost.
+func (c *YBClient) masterLeader(ctx context.Context) (*HostState, error) {
+	c.leaderLock.Lock()
+	defer c.leaderLock.Unlock()
+
+	if c.leader != nil {
+		return c.leader, nil
+	}
+
+	leader, err := c.findMasterLeader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.Log.V(1).Info("found master leader", "host", util.HostPortString(leader.session.Host))
+	c.leader = leader
+	return leader, nil
+}
+
+// invalidateMasterLeader forgets the leader so the next call looks for a new
+// one. Calls that already moved on to a newer leader are left alone.
+func (c *YBClient) invalidateMasterLeader(leader *HostState) {
+	c.leaderLock.Lock()
+	defer c.leaderLock.Unlock()
+
+	if c.leader != leader {
+		return
+	}
+	c.leader = nil
+
+	// The master the client connected through remains open for its other services
+	if leader != c.Master {
+		_ = leader.Close()
+	}
+}
+
+// findMasterLeader asks each configured master, and any other master they list,
+// for its role until the leader is found.
+func (c *YBClient) findMasterLeader(ctx context.Context) (*HostState, error) {
+	dialer, err := c.GetDialer()
+	if err != nil {
+		return nil, err
+	}
+
+	// Each candidate is the list of addresses of one master, in the order the
+	// address policy prefers them
+	var candidates [][]Address
+	for _, m := range c.Config.Masters {
+		candidates = append(candidates, []Address{c.addressPolicy.Address(m)})
+	}
+	tried := make(map[string]bool)
+	lastErr := errors.New("no master addresses")
+
+	for i := 0; i < len(candidates); i++ {
+		var addresses []Address
+		for _, address := range candidates[i] {
+			if !tried[util.HostPortString(address.HostPort)] {
+				addresses = append(addresses, address)
+			}
+		}
+		if len(addresses) == 0 {
+			continue
+		}
+		for _, address := range addresses {
+			tried[util.HostPortString(address.HostPort)] = true
+		}
+
+		hostState, err := c.dialAddresses(ctx, addresses, dialer)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		address := util.HostPortString(hostState.session.Host)
+
+		registration, err := hostState.MasterService.GetMasterRegistrationWithContext(ctx, &master.GetMasterRegistrationRequestPB{})
+		if err == nil && registration.GetError() == nil && registration.GetRole() == common.RaftPeerPB_LEADER {
+			return hostState, nil
+		}
+		if err != nil {
+			lastErr = err
+		} else {
+			lastErr = errors.Errorf("%s has role %s", address, registration.GetRole())
+		}
+
+		// The configured master list may be stale, so learn about any masters it is missing
+		masters, err := hostState.MasterService.ListMastersWithContext(ctx, &master.ListMastersRequestPB{})
+		if err == nil {
+			for _, m := range masters.GetMasters() {
+				candidates = append(candidates, c.addressPolicy.Addresses(m.GetRegistration()))
+			}
+		}
+		_ = hostState.Close()
+	}
+
+	return nil, fmt.Errorf("could not find master leader: %w", lastErr)
+}
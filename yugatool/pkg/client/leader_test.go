@@ -0,0 +1,150 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/blang/vfs/memfs"
+	"github.com/go-logr/logr"
+	. "github.com/icza/gox/gox"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/master"
+	"github.com/yugabyte/yb-tools/yugatool/api/yugatool/config"
+	ybclient "github.com/yugabyte/yb-tools/yugatool/pkg/client"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client/message"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client/session"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/rpcserver"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/test/fakecluster"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/util"
+)
+
+var _ = Describe("Master leader", func() {
+	var (
+		cluster        *fakecluster.Cluster
+		yugabyteClient *ybclient.YBClient
+	)
+
+	BeforeEach(func() {
+		cluster = fakecluster.New(logr.Discard(), rpcserver.NewNetwork(), "leader", 3, 3)
+
+		yugabyteClient = &ybclient.YBClient{
+			Log: logr.Discard(),
+			Fs:  memfs.Create(),
+			Config: &config.UniverseConfigPB{
+				Masters:        cluster.MasterHostPorts(),
+				TimeoutSeconds: NewInt64(1),
+			},
+		}
+		yugabyteClient.OverrideDialer(cluster.Network)
+
+		Expect(yugabyteClient.Connect()).To(Succeed())
+	})
+
+	AfterEach(func() {
+		yugabyteClient.Close()
+	})
+
+	listTabletServers := func(ctx context.Context) (*master.ListTabletServersResponsePB, error) {
+		return yugabyteClient.Master.MasterService.ListTabletServersWithContext(ctx, &master.ListTabletServersRequestPB{})
+	}
+
+	// splitTablet is a call that changes state, so is not idempotent
+	splitTablet := func(tablet *fakecluster.Tablet) (*master.SplitTabletResponsePB, error) {
+		return yugabyteClient.Master.MasterService.SplitTablet(&master.SplitTabletRequestPB{TabletId: []byte(tablet.ID)})
+	}
+	splitTablets := func(tablet *fakecluster.Tablet) int {
+		cluster.Lock()
+		defer cluster.Unlock()
+		return len(tablet.SplitTablets)
+	}
+
+	It("sends calls to the leader", func() {
+		response, err := listTabletServers(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response.GetError()).To(BeNil())
+		Expect(response.GetServers()).To(HaveLen(3))
+	})
+
+	When("another master is elected leader", func() {
+		BeforeEach(func() {
+			cluster.SetLeader(cluster.Masters[1])
+		})
+
+		It("follows the new leader", func() {
+			response, err := listTabletServers(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.GetError()).To(BeNil())
+		})
+
+		It("counts the call refused by the old leader as failed", func() {
+			_, err := listTabletServers(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+
+			calls := make(map[string]message.MethodStats)
+			for _, stats := range yugabyteClient.RPCStats() {
+				if stats.Method == "ListTabletServers" {
+					calls[stats.Host] = stats
+				}
+			}
+			oldLeader := calls[util.HostPortString(cluster.Masters[0].Address)]
+			Expect(oldLeader.Calls).To(BeEquivalentTo(2))
+			Expect(oldLeader.Errors).To(BeEquivalentTo(1))
+
+			newLeader := calls[util.HostPortString(cluster.Masters[1].Address)]
+			Expect(newLeader.Calls).To(BeEquivalentTo(1))
+			Expect(newLeader.Errors).To(BeZero())
+		})
+
+		It("retries calls that are not idempotent on the new leader", func() {
+			tablet := cluster.AddTable("yugabyte", "test_table", 1).Tablets[0]
+
+			response, err := splitTablet(tablet)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.GetError()).To(BeNil())
+			Expect(splitTablets(tablet)).To(Equal(2))
+		})
+	})
+
+	When("the leader goes down", func() {
+		BeforeEach(func() {
+			cluster.Stop(cluster.Masters[0])
+			cluster.SetLeader(cluster.Masters[2])
+		})
+
+		It("follows the new leader", func() {
+			response, err := listTabletServers(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.GetError()).To(BeNil())
+		})
+
+		It("does not resend calls that are not idempotent, but follows the new leader for the next", func() {
+			tablet := cluster.AddTable("yugabyte", "test_table", 1).Tablets[0]
+
+			_, err := splitTablet(tablet)
+			var connectionError *session.ConnectionError
+			Expect(errors.As(err, &connectionError)).To(BeTrue(), "%v", err)
+			Expect(splitTablets(tablet)).To(BeZero())
+
+			response, err := splitTablet(tablet)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.GetError()).To(BeNil())
+			Expect(splitTablets(tablet)).To(Equal(2))
+		})
+	})
+
+	When("there is no leader", func() {
+		BeforeEach(func() {
+			cluster.SetLeader(nil)
+		})
+
+		It("retries until the context is done", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+			defer cancel()
+
+			_, err := listTabletServers(ctx)
+			Expect(err).To(MatchError(context.DeadlineExceeded))
+		})
+	})
+})
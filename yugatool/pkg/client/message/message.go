@@ -2,141 +2,485 @@ package message
 
 import (
 	"bytes"
+	"context"
 	"encoding/binary"
 	"fmt"
+	"io"
+	"sync"
+	"time"
 
-	. "github.com/icza/gox/gox"
 	"github.com/pkg/errors"
 	"github.com/yugabyte/yb-tools/yugatool/api/yb/rpc"
+	yberrors "github.com/yugabyte/yb-tools/yugatool/pkg/client/errors"
 	"github.com/yugabyte/yb-tools/yugatool/pkg/client/session"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/util"
 	"google.golang.org/protobuf/proto"
 )
 
+// MessengerImpl multiplexes RPC calls over a single session. Requests are
+// written as soon as they are sent, and a reader goroutine hands each response
+// to the caller waiting on its call ID, so any number of calls may be in flight
+// and responses may arrive in any order.
+//
+// When the connection is lost, the session reconnects for the next call, and
+// calls to idempotent methods are retried on the new connection.
+//
+// A session must only be read by one MessengerImpl at a time, so all services
+// on a host should share the same messenger.
 type MessengerImpl struct {
 	Session *session.Session
+
+	// Timeout applies to calls whose context carries no deadline
+	Timeout time.Duration
+
+	// Stats, when set, counts the calls made and their latency
+	Stats *Stats
+
+	m          sync.Mutex
+	calls      map[int32]*pendingCall
+	reading    bool
+	readingGen uint64
 }
 
-// TODO: Needs to copy the buffer to the connection
-// TODO: Needs extensive tests
-// TODO: This should actually be implemented as a message queue
-func (m *MessengerImpl) SendMessage(service string, method string, request proto.Message, response proto.Message) error {
-	m.Session.Lock()
-	defer m.Session.Unlock()
+// pendingCall is a call waiting for its response on the connection of a
+// generation of the session
+type pendingCall struct {
+	result     chan *callResult
+	generation uint64
+}
+
+// ResponseWithSidecars wraps a response message to also collect the sidecars
+// that follow it, such as the row data returned by TabletServerService.Read.
+// Pass it to SendMessage in place of the response message.
+type ResponseWithSidecars struct {
+	proto.Message
+	Sidecars [][]byte
+}
+
+// Unwrap returns the wrapped response message.
+func (r *ResponseWithSidecars) Unwrap() proto.Message {
+	return r.Message
+}
+
+type callResult struct {
+	header *rpc.ResponseHeader
+	body   []byte
+	err    error
+}
+
+const DefaultTimeout = 3 * time.Second
+
+// CallRetries is the number of times a call to an idempotent method is retried
+// after losing its connection
+const CallRetries = 2
+
+// readOnlyMethods are the methods of each service, by its full name, that only
+// read, which may be sent again when the connection is lost before their
+// response arrives. Methods are listed one by one, as names do not tell: a call
+// to CDCService.GetChanges moves the checkpoint of its stream.
+var readOnlyMethods = map[string]map[string]bool{
+	"yb.server.GenericService": methods(
+		"GetFlag", "GetStatus", "Ping", "ServerClock",
+	),
+	"yb.master.MasterService": methods(
+		"AreLeadersOnPreferredOnly", "GetCDCStream", "GetColocatedTabletSchema", "GetLeaderBlacklistCompletion",
+		"GetLoadBalancerState", "GetLoadMoveCompletion", "GetMasterClusterConfig", "GetMasterRegistration",
+		"GetNamespaceInfo", "GetPermissions", "GetTableLocations", "GetTableSchema", "GetTabletLocations",
+		"GetUDTypeInfo", "GetUniverseKeyRegistry", "GetUniverseReplication", "GetYsqlCatalogConfig",
+		"HasUniverseKeyInMemory", "IsAlterTableDone", "IsCreateNamespaceDone", "IsCreateTableDone",
+		"IsDeleteNamespaceDone", "IsDeleteTableDone", "IsEncryptionEnabled", "IsFlushTablesDone", "IsInitDbDone",
+		"IsLoadBalanced", "IsLoadBalancerIdle", "IsMasterLeaderServiceReady", "IsTruncateTableDone",
+		"ListCDCStreams", "ListMasterRaftPeers", "ListMasters", "ListNamespaces", "ListTablegroups", "ListTables",
+		"ListTabletServers", "ListUDTypes", "RedisConfigGet",
+	),
+	"yb.tserver.TabletServerService": methods(
+		"Checksum", "GetLogLocation", "GetMasterAddresses", "GetTabletStatus", "GetTransactionStatus",
+		"IsTabletServerReady", "ListTablets", "ListTabletsForTabletServer", "Read",
+	),
+	"yb.tserver.TabletServerAdminService": methods(
+		"CountIntents", "GetSafeTime",
+	),
+	"yb.consensus.ConsensusService": methods(
+		"GetConsensusState", "GetLastOpId", "GetNodeInstance",
+	),
+	"yb.cdc.CDCService": methods(
+		"GetCheckpoint", "GetLatestEntryOpId", "ListTablets",
+	),
+}
+
+func methods(names ...string) map[string]bool {
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		set[name] = true
+	}
+	return set
+}
+
+// IsIdempotent reports whether calling the method of the service, given by its
+// full name, again has no further effect.
+func IsIdempotent(service string, method string) bool {
+	return readOnlyMethods[service][method]
+}
+
+func NewMessenger(s *session.Session, timeout time.Duration) *MessengerImpl {
+	return &MessengerImpl{Session: s, Timeout: timeout}
+}
+
+func (m *MessengerImpl) SendMessage(ctx context.Context, service string, method string, request proto.Message, response proto.Message) error {
+	if m.Stats == nil {
+		return m.sendMessage(ctx, service, method, request, response)
+	}
+
+	start := time.Now()
+	err := m.sendMessage(ctx, service, method, request, response)
+
+	// Responses that carry an application error count as failed calls
+	callErr := err
+	if callErr == nil {
+		callErr = yberrors.FromResponse(response)
+	}
+	m.Stats.Record(util.HostPortString(m.Session.Host), service, method, time.Since(start), callErr)
+	return err
+}
+
+func (m *MessengerImpl) sendMessage(ctx context.Context, service string, method string, request proto.Message, response proto.Message) error {
+	if _, ok := ctx.Deadline(); !ok {
+		timeout := m.Timeout
+		if timeout <= 0 {
+			timeout = DefaultTimeout
+		}
+
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	// Don't bother sending requests that have already been cancelled or timed out
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("%s.%s: %w", service, method, err)
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := m.call(ctx, service, method, request, response)
+
+		var connectionError *session.ConnectionError
+		if err == nil || !errors.As(err, &connectionError) || !IsIdempotent(service, method) || attempt >= CallRetries || ctx.Err() != nil {
+			return err
+		}
+		m.Session.Log.V(1).Info("retrying call on a new connection", "service", service, "method", method, "attempt", attempt+1, "error", err)
+	}
+}
+
+// call sends the request over the current connection of the session, and waits
+// for the response.
+func (m *MessengerImpl) call(ctx context.Context, service string, method string, request proto.Message, response proto.Message) error {
+	conn, generation, err := m.Session.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("%s.%s: %w", service, method, err)
+	}
+
+	// The deadline is sent to the server so it can drop the call once nobody is waiting for it
+	deadline, _ := ctx.Deadline()
+	timeoutMillis := uint32(time.Until(deadline).Milliseconds())
+	if timeoutMillis == 0 {
+		timeoutMillis = 1
+	}
 
 	callID := m.Session.GenerateCallID()
+
+	packet, err := encodeRequest(callID, service, method, timeoutMillis, request)
+	if err != nil {
+		return err
+	}
+
+	result := m.registerCall(callID, conn, generation)
+
+	err = m.writePacket(conn, deadline, packet)
+	if err != nil {
+		m.cancelCall(callID)
+		m.Session.Fail(generation, err)
+		return fmt.Errorf("%s.%s: %w", service, method, &session.ConnectionError{HostPortPB: m.Session.Host, Err: err})
+	}
+
+	select {
+	case r := <-result:
+		if r.err != nil {
+			return r.err
+		}
+
+		return decodeResponse(service, method, r, response)
+	case <-ctx.Done():
+		// Any response that arrives later is discarded by the reader
+		m.cancelCall(callID)
+		return fmt.Errorf("%s.%s: %w", service, method, ctx.Err())
+	}
+}
+
+func encodeRequest(callID int32, service string, method string, timeoutMillis uint32, request proto.Message) ([]byte, error) {
 	pb := &rpc.RequestHeader{
 		CallId: &callID,
 		RemoteMethod: &rpc.RemoteMethodPB{
 			ServiceName: &service,
 			MethodName:  &method,
 		},
-		// TODO: Timout should be a config value as part of client configuration
-		TimeoutMillis: NewUint32(3000),
+		TimeoutMillis: &timeoutMillis,
 	}
-	// TODO: what to do about sidecars?
 
 	messageHeader, err := proto.Marshal(pb)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	var buf [binary.MaxVarintLen32]byte
-	encodedLen := binary.PutUvarint(buf[:], uint64(len(messageHeader)))
-
-	var b bytes.Buffer
-	b.Write(buf[0:encodedLen])
-	b.Write(messageHeader)
-
 	messageBody, err := proto.Marshal(request)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	encodedLen = binary.PutUvarint(buf[:], uint64(len(messageBody)))
+	var buf [binary.MaxVarintLen32]byte
+	var b bytes.Buffer
+
+	// Reserve space for the packet length, which is filled in once the rest of the packet is known
+	b.Write(make([]byte, 4))
 
+	encodedLen := binary.PutUvarint(buf[:], uint64(len(messageHeader)))
 	b.Write(buf[0:encodedLen])
+	b.Write(messageHeader)
 
+	encodedLen = binary.PutUvarint(buf[:], uint64(len(messageBody)))
+	b.Write(buf[0:encodedLen])
 	b.Write(messageBody)
-	messageLen := uint32(b.Len())
 
-	var intBytes [4]byte
-	binary.BigEndian.PutUint32(intBytes[:], messageLen)
+	packet := b.Bytes()
+	binary.BigEndian.PutUint32(packet[0:4], uint32(len(packet)-4))
+
+	return packet, nil
+}
+
+// writePacket writes the whole request in a single call, so requests from
+// concurrent callers are never interleaved on the wire.
+func (m *MessengerImpl) writePacket(conn io.Writer, deadline time.Time, packet []byte) error {
+	m.Session.Lock()
+	defer m.Session.Unlock()
 
-	n, err := m.Session.Write(intBytes[:])
+	err := session.SetWriteDeadline(conn, deadline)
 	if err != nil {
 		return err
 	}
-	if n != 4 {
-		return errors.New("packet len not 4 bytes")
-	}
 
-	n, err = m.Session.Write(b.Bytes())
+	n, err := conn.Write(packet)
 	if err != nil {
 		return err
 	}
-	if n != int(messageLen) {
-		return errors.New("request over the wire not equal to messageLen")
+	if n != len(packet) {
+		return errors.New("request over the wire not equal to packet length")
 	}
-	return m.GetResponse(callID, response)
+	return nil
 }
 
-func (m *MessengerImpl) GetResponse(callID int32, response proto.Message) error {
-	responseLen, err := m.getMessageLen()
-	if err != nil {
-		return fmt.Errorf("invalid message len callID %d: %w", callID, err)
+// registerCall records a call waiting for a response, and starts the reader of
+// the connection if it is not already running.
+func (m *MessengerImpl) registerCall(callID int32, conn io.Reader, generation uint64) <-chan *callResult {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	if m.calls == nil {
+		m.calls = make(map[int32]*pendingCall)
 	}
 
-	var offset, n int
-	responseBuf := make([]byte, responseLen)
-	for offset = 0; offset < int(responseLen); offset = offset + n {
-		n, err = m.Session.Read(responseBuf[offset:])
+	// Buffered so the reader never blocks on a caller that has given up
+	result := make(chan *callResult, 1)
+	m.calls[callID] = &pendingCall{result: result, generation: generation}
+
+	// A reader left on a lost connection stops when that connection is closed
+	if !m.reading || m.readingGen != generation {
+		m.reading = true
+		m.readingGen = generation
+		go m.readResponses(conn, generation)
+	}
+
+	return result
+}
+
+func (m *MessengerImpl) cancelCall(callID int32) {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	delete(m.calls, callID)
+}
+
+// readResponses delivers responses to waiting callers until there are no calls
+// left in flight on the connection, or until the connection fails, in which
+// case every call waiting on it receives the error and the session reconnects
+// for the next call.
+func (m *MessengerImpl) readResponses(conn io.Reader, generation uint64) {
+	for {
+		header, body, err := ReadResponse(conn)
+
+		m.m.Lock()
 		if err != nil {
-			return err
+			for callID, call := range m.calls {
+				if call.generation != generation {
+					continue
+				}
+				call.result <- &callResult{err: &session.ConnectionError{
+					HostPortPB: m.Session.Host,
+					Err:        fmt.Errorf("could not read response for callID %d: %w", callID, err),
+				}}
+				delete(m.calls, callID)
+			}
+			m.stopReading(generation)
+			m.m.Unlock()
+
+			m.Session.Fail(generation, err)
+			return
+		}
+
+		if call, ok := m.calls[header.GetCallId()]; ok && call.generation == generation {
+			delete(m.calls, header.GetCallId())
+			call.result <- &callResult{header: header, body: body}
+		} else {
+			m.Session.Log.V(1).Info("discarding response with unknown call ID", "callID", header.GetCallId())
+		}
+
+		if !m.waiting(generation) {
+			m.stopReading(generation)
+			m.m.Unlock()
+			return
 		}
+		m.m.Unlock()
+	}
+}
+
+// waiting reports whether any call waits on the connection of the generation.
+// It must be called with m.m held.
+func (m *MessengerImpl) waiting(generation uint64) bool {
+	for _, call := range m.calls {
+		if call.generation == generation {
+			return true
+		}
+	}
+	return false
+}
+
+// stopReading records that the reader of the generation stopped. It must be
+// called with m.m held.
+func (m *MessengerImpl) stopReading(generation uint64) {
+	if m.readingGen == generation {
+		m.reading = false
+	}
+}
+
+// ReadResponse reads the next response packet, returning its header and the
+// body that follows, which holds the response message and any sidecars.
+func ReadResponse(r io.Reader) (*rpc.ResponseHeader, []byte, error) {
+	responseLen, err := getMessageLen(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid message len: %w", err)
+	}
+
+	responseBuf := make([]byte, responseLen)
+	_, err = io.ReadFull(r, responseBuf)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	offset = 0
 	value, nbytes := binary.Uvarint(responseBuf)
 	if nbytes <= 0 {
-		return errors.New("varint corruption")
+		return nil, nil, errors.New("varint corruption")
+	}
+	offset := nbytes
+
+	if offset+int(value) > len(responseBuf) {
+		return nil, nil, errors.New("response header exceeds message length")
 	}
-	offset = offset + nbytes
 
 	responseHeader := &rpc.ResponseHeader{}
 	err = proto.Unmarshal(responseBuf[offset:offset+int(value)], responseHeader)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 	offset = offset + int(value)
-	if responseHeader.GetCallId() != callID {
-		return errors.New("unknown call ID in response")
+
+	return responseHeader, responseBuf[offset:], nil
+}
+
+// SplitBody splits the body of a response packet into the response message, or
+// the error status if the header marks the call as failed, and the sidecars.
+func SplitBody(header *rpc.ResponseHeader, body []byte) ([]byte, [][]byte, error) {
+	if len(body) == 0 {
+		return nil, nil, nil
 	}
 
-	if offset != int(responseLen-1) {
-		_, nbytes = binary.Uvarint(responseBuf[offset:])
-		if nbytes <= 0 {
-			return errors.New("varint corruption")
-		}
-		offset = offset + nbytes
+	// The body length covers the response message and any sidecars that follow it
+	value, nbytes := binary.Uvarint(body)
+	if nbytes <= 0 {
+		return nil, nil, errors.New("varint corruption")
+	}
+	if nbytes+int(value) > len(body) {
+		return nil, nil, errors.New("response body exceeds message length")
+	}
+	body = body[nbytes : nbytes+int(value)]
 
-		err = proto.Unmarshal(responseBuf[offset:], response)
-		if err != nil {
-			return err
+	if header.GetIsError() {
+		return body, nil, nil
+	}
+
+	sidecars, err := splitSidecars(body, header.GetSidecarOffsets())
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(sidecars) > 0 {
+		body = body[:header.GetSidecarOffsets()[0]]
+	}
+	return body, sidecars, nil
+}
+
+func decodeResponse(service string, method string, r *callResult, response proto.Message) error {
+	if len(r.body) == 0 {
+		return nil
+	}
+
+	body, sidecars, err := SplitBody(r.header, r.body)
+	if err != nil {
+		return fmt.Errorf("%s.%s: %w", service, method, err)
+	}
+
+	if r.header.GetIsError() {
+		return yberrors.DecodeErrorStatus(service, method, body)
+	}
+
+	if withSidecars, ok := response.(*ResponseWithSidecars); ok {
+		withSidecars.Sidecars = sidecars
+		return proto.Unmarshal(body, withSidecars.Message)
+	}
+
+	return proto.Unmarshal(body, response)
+}
+
+// splitSidecars slices the sidecars out of a response body. Each offset marks
+// the start of a sidecar, which runs until the next offset or the end of the body.
+func splitSidecars(body []byte, offsets []uint32) ([][]byte, error) {
+	var sidecars [][]byte
+	for i, offset := range offsets {
+		end := uint32(len(body))
+		if i+1 < len(offsets) {
+			end = offsets[i+1]
+		}
+		if offset > end || end > uint32(len(body)) {
+			return nil, errors.Errorf("invalid sidecar offsets %v for body of %d bytes", offsets, len(body))
 		}
+		sidecars = append(sidecars, body[offset:end])
 	}
-	return nil
+	return sidecars, nil
 }
 
-func (m *MessengerImpl) getMessageLen() (uint32, error) {
+func getMessageLen(r io.Reader) (uint32, error) {
 	responseLenBuf := make([]byte, 4)
 
-	n, err := m.Session.Read(responseLenBuf)
+	_, err := io.ReadFull(r, responseLenBuf)
 	if err != nil {
 		return 0, err
 	}
-	if n != 4 {
-		return 0, errors.New("could not read response length")
-	}
 
 	// It is valid to receive an empty message, so if we get all zeros move on to read the next message
 	emptyMessage := true
@@ -147,7 +491,7 @@ func (m *MessengerImpl) getMessageLen() (uint32, error) {
 	}
 
 	if emptyMessage {
-		return m.getMessageLen()
+		return getMessageLen(r)
 	}
 
 	return binary.BigEndian.Uint32(responseLenBuf), nil
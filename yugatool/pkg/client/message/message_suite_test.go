@@ -0,0 +1,13 @@
+package message_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestMessage(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Message Suite")
+}
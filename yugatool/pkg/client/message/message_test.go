@@ -0,0 +1,366 @@
+package message_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/icza/gox/gox"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/common"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/rpc"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/server"
+	yberrors "github.com/yugabyte/yb-tools/yugatool/pkg/client/errors"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client/message"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client/session"
+	"google.golang.org/protobuf/proto"
+)
+
+type pipeDialer struct {
+	conn net.Conn
+}
+
+func (d *pipeDialer) Dial(_, _ string) (io.ReadWriteCloser, error) {
+	return d.conn, nil
+}
+
+type request struct {
+	header *rpc.RequestHeader
+	body   []byte
+}
+
+func readRequest(r *bufio.Reader) (*request, error) {
+	var packetLen [4]byte
+	if _, err := io.ReadFull(r, packetLen[:]); err != nil {
+		return nil, err
+	}
+	packet := make([]byte, binary.BigEndian.Uint32(packetLen[:]))
+	if _, err := io.ReadFull(r, packet); err != nil {
+		return nil, err
+	}
+
+	headerLen, n := binary.Uvarint(packet)
+	header := &rpc.RequestHeader{}
+	if err := proto.Unmarshal(packet[n:n+int(headerLen)], header); err != nil {
+		return nil, err
+	}
+	packet = packet[n+int(headerLen):]
+
+	bodyLen, n := binary.Uvarint(packet)
+	return &request{header: header, body: packet[n : n+int(bodyLen)]}, nil
+}
+
+func writeResponse(w io.Writer, header *rpc.ResponseHeader, body proto.Message, sidecars ...[]byte) error {
+	encodedBody, err := proto.Marshal(body)
+	if err != nil {
+		return err
+	}
+	for _, sidecar := range sidecars {
+		header.SidecarOffsets = append(header.SidecarOffsets, uint32(len(encodedBody)))
+		encodedBody = append(encodedBody, sidecar...)
+	}
+
+	encodedHeader, err := proto.Marshal(header)
+	if err != nil {
+		return err
+	}
+
+	var b bytes.Buffer
+	var buf [binary.MaxVarintLen32]byte
+	for _, encoded := range [][]byte{encodedHeader, encodedBody} {
+		b.Write(buf[:binary.PutUvarint(buf[:], uint64(len(encoded)))])
+		b.Write(encoded)
+	}
+
+	var packetLen [4]byte
+	binary.BigEndian.PutUint32(packetLen[:], uint32(b.Len()))
+	_, err = w.Write(append(packetLen[:], b.Bytes()...))
+	return err
+}
+
+var _ = Describe("Message", func() {
+	var (
+		clientConn, serverConn net.Conn
+		serverReader           *bufio.Reader
+		dialer                 *pipeDialer
+		messenger              *message.MessengerImpl
+	)
+
+	BeforeEach(func() {
+		clientConn, serverConn = net.Pipe()
+		serverReader = bufio.NewReader(serverConn)
+
+		// Consume the connection hello
+		helloRead := make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			defer close(helloRead)
+			hello := make([]byte, 3)
+			_, err := io.ReadFull(serverReader, hello)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hello).To(Equal([]byte("YB\001")))
+		}()
+
+		dialer = &pipeDialer{conn: clientConn}
+		s, err := session.NewSession(logr.Discard(), &common.HostPortPB{Host: NewString("tserver-1"), Port: NewUint32(9100)},
+			dialer, func(*session.Session) error { return nil })
+		Expect(err).NotTo(HaveOccurred())
+		<-helloRead
+
+		messenger = message.NewMessenger(s, message.DefaultTimeout)
+	})
+
+	AfterEach(func() {
+		_ = clientConn.Close()
+		_ = serverConn.Close()
+	})
+
+	When("several calls are in flight", func() {
+		const calls = 10
+
+		It("delivers responses that arrive out of order", func() {
+			go func() {
+				defer GinkgoRecover()
+				var requests []*request
+				for i := 0; i < calls; i++ {
+					req, err := readRequest(serverReader)
+					Expect(err).NotTo(HaveOccurred())
+					requests = append(requests, req)
+				}
+
+				// Reply in reverse order, echoing the method name so each caller can check it got its own response
+				for i := len(requests) - 1; i >= 0; i-- {
+					err := writeResponse(serverConn, &rpc.ResponseHeader{CallId: requests[i].header.CallId},
+						&server.GetFlagResponsePB{Value: NewString(requests[i].header.GetRemoteMethod().GetMethodName())})
+					Expect(err).NotTo(HaveOccurred())
+				}
+			}()
+
+			wg := &sync.WaitGroup{}
+			for i := 0; i < calls; i++ {
+				wg.Add(1)
+				go func(method string) {
+					defer GinkgoRecover()
+					defer wg.Done()
+					response := &server.GetFlagResponsePB{}
+					err := messenger.SendMessage(context.Background(), "yb.server.GenericService", method, &server.PingRequestPB{}, response)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(response.GetValue()).To(Equal(method))
+				}(string(rune('A' + i)))
+			}
+			wg.Wait()
+		})
+	})
+
+	When("the server returns an error response", func() {
+		It("returns the error message", func() {
+			go func() {
+				defer GinkgoRecover()
+				req, err := readRequest(serverReader)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = writeResponse(serverConn, &rpc.ResponseHeader{CallId: req.header.CallId, IsError: NewBool(true)},
+					&rpc.ErrorStatusPB{Message: NewString("no such method"), Code: rpc.ErrorStatusPB_ERROR_NO_SUCH_METHOD.Enum()})
+				Expect(err).NotTo(HaveOccurred())
+			}()
+
+			err := messenger.SendMessage(context.Background(), "yb.server.GenericService", "Missing", &server.PingRequestPB{}, &server.PingResponsePB{})
+			Expect(err).To(MatchError("yb.server.GenericService.Missing returned ERROR_NO_SUCH_METHOD: no such method"))
+
+			var rpcError *yberrors.RPCError
+			Expect(errors.As(err, &rpcError)).To(BeTrue())
+			Expect(rpcError.Status.GetCode()).To(Equal(rpc.ErrorStatusPB_ERROR_NO_SUCH_METHOD))
+		})
+	})
+
+	When("the response has sidecars", func() {
+		It("returns the sidecars alongside the response", func() {
+			go func() {
+				defer GinkgoRecover()
+				req, err := readRequest(serverReader)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = writeResponse(serverConn, &rpc.ResponseHeader{CallId: req.header.CallId},
+					&server.GetFlagResponsePB{Value: NewString("rows")}, []byte("first"), []byte{}, []byte("third"))
+				Expect(err).NotTo(HaveOccurred())
+			}()
+
+			response := &message.ResponseWithSidecars{Message: &server.GetFlagResponsePB{}}
+			err := messenger.SendMessage(context.Background(), "yb.tserver.TabletServerService", "Read", &server.PingRequestPB{}, response)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.Message.(*server.GetFlagResponsePB).GetValue()).To(Equal("rows"))
+			Expect(response.Sidecars).To(Equal([][]byte{[]byte("first"), {}, []byte("third")}))
+		})
+
+		It("ignores the sidecars of a plain response", func() {
+			go func() {
+				defer GinkgoRecover()
+				req, err := readRequest(serverReader)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = writeResponse(serverConn, &rpc.ResponseHeader{CallId: req.header.CallId},
+					&server.GetFlagResponsePB{Value: NewString("rows")}, []byte("sidecar"))
+				Expect(err).NotTo(HaveOccurred())
+			}()
+
+			response := &server.GetFlagResponsePB{}
+			err := messenger.SendMessage(context.Background(), "yb.tserver.TabletServerService", "Read", &server.PingRequestPB{}, response)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.GetValue()).To(Equal("rows"))
+		})
+	})
+
+	When("the context has a deadline", func() {
+		It("sends the remaining time as the RPC timeout", func() {
+			timeoutMillis := make(chan uint32, 1)
+			go func() {
+				defer GinkgoRecover()
+				req, err := readRequest(serverReader)
+				Expect(err).NotTo(HaveOccurred())
+				timeoutMillis <- req.header.GetTimeoutMillis()
+
+				err = writeResponse(serverConn, &rpc.ResponseHeader{CallId: req.header.CallId}, &server.PingResponsePB{})
+				Expect(err).NotTo(HaveOccurred())
+			}()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			err := messenger.SendMessage(ctx, "yb.server.GenericService", "Ping", &server.PingRequestPB{}, &server.PingResponsePB{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(<-timeoutMillis).To(And(BeNumerically(">", 9000), BeNumerically("<=", 10000)))
+		})
+
+		It("gives up when the deadline passes", func() {
+			go func() {
+				defer GinkgoRecover()
+				// Never respond
+				_, err := readRequest(serverReader)
+				Expect(err).NotTo(HaveOccurred())
+			}()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			err := messenger.SendMessage(ctx, "yb.server.GenericService", "Ping", &server.PingRequestPB{}, &server.PingResponsePB{})
+			Expect(errors.Is(err, context.DeadlineExceeded)).To(BeTrue())
+		})
+	})
+
+	When("the context is cancelled", func() {
+		It("does not send the request", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			err := messenger.SendMessage(ctx, "yb.server.GenericService", "Ping", &server.PingRequestPB{}, &server.PingResponsePB{})
+			Expect(errors.Is(err, context.Canceled)).To(BeTrue())
+		})
+	})
+
+	When("the connection is closed", func() {
+		It("fails the waiting call", func() {
+			go func() {
+				defer GinkgoRecover()
+				_, err := readRequest(serverReader)
+				Expect(err).NotTo(HaveOccurred())
+				_ = serverConn.Close()
+			}()
+
+			err := messenger.SendMessage(context.Background(), "yb.server.GenericService", "SetFlag", &server.PingRequestPB{}, &server.PingResponsePB{})
+			var connectionError *session.ConnectionError
+			Expect(errors.As(err, &connectionError)).To(BeTrue(), "%v", err)
+		})
+
+		DescribeTable("does not resend calls that are not idempotent",
+			func(service string, method string) {
+				// The session would dial this connection to resend the call
+				newClientConn, newServerConn := net.Pipe()
+				defer newServerConn.Close()
+				dialer.conn = newClientConn
+
+				go func() {
+					defer GinkgoRecover()
+					_, err := readRequest(serverReader)
+					Expect(err).NotTo(HaveOccurred())
+					_ = serverConn.Close()
+				}()
+
+				resent := make(chan struct{})
+				go func() {
+					newServerReader := bufio.NewReader(newServerConn)
+					hello := make([]byte, 3)
+					if _, err := io.ReadFull(newServerReader, hello); err != nil {
+						return
+					}
+					if _, err := readRequest(newServerReader); err == nil {
+						close(resent)
+					}
+				}()
+
+				err := messenger.SendMessage(context.Background(), service, method, &server.PingRequestPB{}, &server.PingResponsePB{})
+				var connectionError *session.ConnectionError
+				Expect(errors.As(err, &connectionError)).To(BeTrue(), "%v", err)
+				Consistently(resent, 200*time.Millisecond).ShouldNot(BeClosed())
+			},
+			Entry("GetChanges", "yb.cdc.CDCService", "GetChanges"),
+			Entry("SplitTablet", "yb.master.MasterService", "SplitTablet"),
+		)
+
+		It("retries idempotent calls on a new connection", func() {
+			// The session dials this connection once the first is lost
+			newClientConn, newServerConn := net.Pipe()
+			defer newServerConn.Close()
+			dialer.conn = newClientConn
+
+			go func() {
+				defer GinkgoRecover()
+				_, err := readRequest(serverReader)
+				Expect(err).NotTo(HaveOccurred())
+				_ = serverConn.Close()
+
+				newServerReader := bufio.NewReader(newServerConn)
+				hello := make([]byte, 3)
+				_, err = io.ReadFull(newServerReader, hello)
+				Expect(err).NotTo(HaveOccurred())
+
+				req, err := readRequest(newServerReader)
+				Expect(err).NotTo(HaveOccurred())
+				err = writeResponse(newServerConn, &rpc.ResponseHeader{CallId: req.header.CallId}, &server.GetFlagResponsePB{Value: NewString("retried")})
+				Expect(err).NotTo(HaveOccurred())
+			}()
+
+			response := &server.GetFlagResponsePB{}
+			err := messenger.SendMessage(context.Background(), "yb.server.GenericService", "GetFlag", &server.PingRequestPB{}, response)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.GetValue()).To(Equal("retried"))
+		})
+	})
+})
+
+var _ = Describe("IsIdempotent", func() {
+	DescribeTable("allows only methods that read to be retried",
+		func(service string, method string, idempotent bool) {
+			Expect(message.IsIdempotent(service, method)).To(Equal(idempotent))
+		},
+		Entry("GetStatus", "yb.server.GenericService", "GetStatus", true),
+		Entry("ListTablets", "yb.tserver.TabletServerService", "ListTablets", true),
+		Entry("IsLoadBalanced", "yb.master.MasterService", "IsLoadBalanced", true),
+		Entry("Read", "yb.tserver.TabletServerService", "Read", true),
+		Entry("GetCheckpoint", "yb.cdc.CDCService", "GetCheckpoint", true),
+		Entry("GetChanges, which moves the checkpoint of its stream", "yb.cdc.CDCService", "GetChanges", false),
+		Entry("SetFlag", "yb.server.GenericService", "SetFlag", false),
+		Entry("Write", "yb.tserver.TabletServerService", "Write", false),
+		Entry("LeaderStepDown", "yb.consensus.ConsensusService", "LeaderStepDown", false),
+		Entry("SplitTablet", "yb.master.MasterService", "SplitTablet", false),
+		Entry("a read of an unknown service", "yb.master.MasterBackupService", "ListSnapshots", false),
+	)
+})
@@ -0,0 +1,174 @@
+package message
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// LatencyBuckets are the upper bounds of the latency histogram kept for each
+// method. Slower calls are counted in a final bucket with no upper bound.
+var LatencyBuckets = []time.Duration{
+	time.Millisecond,
+	2 * time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	20 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	200 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2 * time.Second,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// Stats counts the calls made by messengers, and their errors and latency, for
+// each host, service and method. It is safe for concurrent use, so one Stats
+// may be shared by every messenger of a client.
+type Stats struct {
+	m       sync.Mutex
+	methods map[methodKey]*methodStats
+}
+
+type methodKey struct {
+	host    string
+	service string
+	method  string
+}
+
+type methodStats struct {
+	calls     int64
+	errors    int64
+	total     time.Duration
+	min       time.Duration
+	max       time.Duration
+	histogram []int64
+}
+
+// MethodStats are the calls made to one method of a host. Latencies are in
+// milliseconds. The percentiles are estimated from the histogram, so they are
+// the upper bound of the bucket they fall in, capped at the slowest call.
+type MethodStats struct {
+	Host      string            `json:"host"`
+	Service   string            `json:"service"`
+	Method    string            `json:"method"`
+	Calls     int64             `json:"calls"`
+	Errors    int64             `json:"errors"`
+	MinMs     float64           `json:"min_ms"`
+	MeanMs    float64           `json:"mean_ms"`
+	P50Ms     float64           `json:"p50_ms"`
+	P99Ms     float64           `json:"p99_ms"`
+	MaxMs     float64           `json:"max_ms"`
+	Histogram []HistogramBucket `json:"histogram"`
+}
+
+// HistogramBucket counts the calls no slower than LE, which is "+Inf" for the
+// last bucket.
+type HistogramBucket struct {
+	LE    string `json:"le"`
+	Count int64  `json:"count"`
+}
+
+func NewStats() *Stats {
+	return &Stats{methods: make(map[methodKey]*methodStats)}
+}
+
+// Record counts a call to the method of a host that took latency, and failed
+// if err is not nil.
+func (s *Stats) Record(host string, service string, method string, latency time.Duration, err error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	key := methodKey{host: host, service: service, method: method}
+	stats, ok := s.methods[key]
+	if !ok {
+		stats = &methodStats{min: latency, histogram: make([]int64, len(LatencyBuckets)+1)}
+		s.methods[key] = stats
+	}
+
+	stats.calls++
+	if err != nil {
+		stats.errors++
+	}
+	stats.total += latency
+	if latency < stats.min {
+		stats.min = latency
+	}
+	if latency > stats.max {
+		stats.max = latency
+	}
+	stats.histogram[sort.Search(len(LatencyBuckets), func(i int) bool { return latency <= LatencyBuckets[i] })]++
+}
+
+// Methods returns the stats of every method called, ordered by host, service
+// and method.
+func (s *Stats) Methods() []MethodStats {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	methods := make([]MethodStats, 0, len(s.methods))
+	for key, stats := range s.methods {
+		methods = append(methods, stats.export(key))
+	}
+
+	sort.Slice(methods, func(i, j int) bool {
+		if methods[i].Host != methods[j].Host {
+			return methods[i].Host < methods[j].Host
+		}
+		if methods[i].Service != methods[j].Service {
+			return methods[i].Service < methods[j].Service
+		}
+		return methods[i].Method < methods[j].Method
+	})
+	return methods
+}
+
+func (s *methodStats) export(key methodKey) MethodStats {
+	stats := MethodStats{
+		Host:    key.host,
+		Service: key.service,
+		Method:  key.method,
+		Calls:   s.calls,
+		Errors:  s.errors,
+		MinMs:   milliseconds(s.min),
+		MeanMs:  milliseconds(s.total / time.Duration(s.calls)),
+		P50Ms:   milliseconds(s.percentile(0.5)),
+		P99Ms:   milliseconds(s.percentile(0.99)),
+		MaxMs:   milliseconds(s.max),
+	}
+
+	for i, count := range s.histogram {
+		le := "+Inf"
+		if i < len(LatencyBuckets) {
+			le = LatencyBuckets[i].String()
+		}
+		stats.Histogram = append(stats.Histogram, HistogramBucket{LE: le, Count: count})
+	}
+	return stats
+}
+
+func (s *methodStats) percentile(p float64) time.Duration {
+	rank := int64(p * float64(s.calls))
+	if rank < 1 {
+		rank = 1
+	}
+
+	var seen int64
+	for i, count := range s.histogram {
+		seen += count
+		if seen < rank {
+			continue
+		}
+		if i < len(LatencyBuckets) && LatencyBuckets[i] < s.max {
+			return LatencyBuckets[i]
+		}
+		break
+	}
+	return s.max
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
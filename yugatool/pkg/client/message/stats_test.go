@@ -0,0 +1,69 @@
+package message_test
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client/message"
+)
+
+var _ = Describe("Stats", func() {
+	var stats *message.Stats
+
+	BeforeEach(func() {
+		stats = message.NewStats()
+	})
+
+	It("reports nothing before any call", func() {
+		Expect(stats.Methods()).To(BeEmpty())
+	})
+
+	It("counts calls and errors for each host, service and method", func() {
+		stats.Record("master-1:7100", "yb.master.MasterService", "ListMasters", time.Millisecond, nil)
+		stats.Record("master-1:7100", "yb.master.MasterService", "ListMasters", 3*time.Millisecond, errors.New("failed"))
+		stats.Record("master-1:7100", "yb.master.MasterService", "ListTables", time.Millisecond, nil)
+		stats.Record("master-2:7100", "yb.master.MasterService", "ListMasters", time.Millisecond, nil)
+
+		methods := stats.Methods()
+		Expect(methods).To(HaveLen(3))
+
+		Expect(methods[0].Host).To(Equal("master-1:7100"))
+		Expect(methods[0].Method).To(Equal("ListMasters"))
+		Expect(methods[0].Calls).To(BeEquivalentTo(2))
+		Expect(methods[0].Errors).To(BeEquivalentTo(1))
+		Expect(methods[0].MinMs).To(Equal(1.0))
+		Expect(methods[0].MeanMs).To(Equal(2.0))
+		Expect(methods[0].MaxMs).To(Equal(3.0))
+
+		Expect(methods[1].Method).To(Equal("ListTables"))
+		Expect(methods[2].Host).To(Equal("master-2:7100"))
+	})
+
+	It("keeps a latency histogram", func() {
+		for i := 0; i < 98; i++ {
+			stats.Record("tserver-1:9100", "yb.tserver.TabletServerService", "ListTablets", 4*time.Millisecond, nil)
+		}
+		stats.Record("tserver-1:9100", "yb.tserver.TabletServerService", "ListTablets", 150*time.Millisecond, nil)
+		stats.Record("tserver-1:9100", "yb.tserver.TabletServerService", "ListTablets", 30*time.Second, nil)
+
+		methods := stats.Methods()
+		Expect(methods).To(HaveLen(1))
+		Expect(methods[0].P50Ms).To(Equal(5.0))
+		Expect(methods[0].P99Ms).To(Equal(200.0))
+		Expect(methods[0].MaxMs).To(Equal(30000.0))
+
+		histogram := methods[0].Histogram
+		Expect(histogram).To(HaveLen(len(message.LatencyBuckets) + 1))
+		Expect(histogram[2]).To(Equal(message.HistogramBucket{LE: "5ms", Count: 98}))
+		Expect(histogram[7]).To(Equal(message.HistogramBucket{LE: "200ms", Count: 1}))
+		Expect(histogram[len(histogram)-1]).To(Equal(message.HistogramBucket{LE: "+Inf", Count: 1}))
+	})
+
+	It("caps percentiles at the slowest call", func() {
+		stats.Record("tserver-1:9100", "yb.tserver.TabletServerService", "ListTablets", 3*time.Millisecond, nil)
+
+		Expect(stats.Methods()[0].P99Ms).To(Equal(3.0))
+	})
+})
@@ -0,0 +1,295 @@
+package client
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/common"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/master"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client/dial"
+	yberrors "github.com/yugabyte/yb-tools/yugatool/pkg/client/errors"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/util"
+)
+
+const (
+	// MasterOK is a configured master that is a member of the raft config
+	MasterOK = "OK"
+	// MasterUnreachable is a configured member of the raft config that could not be reached
+	MasterUnreachable = "UNREACHABLE"
+	// MasterNotInRaftConfig is a configured address that is not a member of the raft config
+	MasterNotInRaftConfig = "NOT_IN_RAFT_CONFIG"
+	// MasterNotConfigured is a member of the raft config that is missing from the configured addresses
+	MasterNotConfigured = "NOT_CONFIGURED"
+)
+
+// MasterStatus is a configured master address, or a member of the raft config
+// that is missing from the configured addresses.
+type MasterStatus struct {
+	Address      string `json:"address"`
+	Configured   bool   `json:"configured"`
+	Reachable    bool   `json:"reachable"`
+	InRaftConfig bool   `json:"in_raft_config"`
+	UUID         string `json:"uuid"`
+	Role         string `json:"role"`
+	MemberType   string `json:"member_type"`
+	Status       string `json:"status"`
+	Error        string `json:"error"`
+}
+
+// MasterQuorumStatus compares the configured master addresses with the raft
+// config of the master leader.
+type MasterQuorumStatus struct {
+	Masters []*MasterStatus `json:"masters"`
+}
+
+// Mismatched reports whether the configured master addresses differ from the
+// members of the raft config, as happens when the list is stale.
+func (s *MasterQuorumStatus) Mismatched() bool {
+	for _, m := range s.Masters {
+		if m.Status == MasterNotInRaftConfig || m.Status == MasterNotConfigured {
+			return true
+		}
+	}
+	return false
+}
+
+// masterProbe is a configured master address, and what was found when
+// connecting to it. Only the probe of the leader keeps its connection.
+type masterProbe struct {
+	address   *common.HostPortPB
+	hostState *HostState
+	uuid      string
+	role      common.RaftPeerPB_Role
+	err       error
+}
+
+func (p *masterProbe) run(ctx context.Context, c *YBClient, dialer dial.Dialer) {
+	hostState, err := c.dialAddresses(ctx, []Address{c.addressPolicy.Address(p.address)}, dialer)
+	if err != nil {
+		p.err = err
+		return
+	}
+
+	registration, err := hostState.MasterService.GetMasterRegistrationWithContext(ctx, &master.GetMasterRegistrationRequestPB{})
+	if err == nil {
+		err = yberrors.FromResponse(registration)
+	}
+	if err != nil {
+		_ = hostState.Close()
+		p.err = err
+		return
+	}
+
+	p.uuid = string(registration.GetInstanceId().GetPermanentUuid())
+	p.role = registration.GetRole()
+	if p.role != common.RaftPeerPB_LEADER {
+		_ = hostState.Close()
+		return
+	}
+	p.hostState = hostState
+}
+
+// connectMasterLeader probes the configured masters concurrently, and returns
+// the connection to the first that reports itself the leader. When the
+// configured masters are reachable but none of them leads, the leader is looked
+// for among the masters they list. The probes left running finish in the
+// background, for MasterQuorumStatus.
+func (c *YBClient) connectMasterLeader(ctx context.Context, dialer dial.Dialer) (*HostState, error) {
+	c.masterProbes = make([]*masterProbe, len(c.Config.GetMasters()))
+	c.probes = &sync.WaitGroup{}
+
+	results := make(chan *masterProbe, len(c.masterProbes))
+	for i, m := range c.Config.GetMasters() {
+		probe := &masterProbe{address: m}
+		c.masterProbes[i] = probe
+
+		c.probes.Add(1)
+		go func() {
+			defer c.probes.Done()
+			probe.run(ctx, c, dialer)
+			results <- probe
+		}()
+	}
+
+	var leader *HostState
+	var lastErr error
+	reachable := false
+	remaining := len(c.masterProbes)
+	for ; remaining > 0 && leader == nil; remaining-- {
+		probe := <-results
+		switch {
+		case probe.err != nil:
+			c.Log.V(1).Info("could not probe master", "host", util.HostPortString(probe.address), "error", probe.err)
+			lastErr = probe.err
+		case probe.hostState != nil:
+			leader = probe.hostState
+		default:
+			reachable = true
+		}
+	}
+
+	// Another master may also claim leadership during an election
+	go func(remaining int) {
+		for ; remaining > 0; remaining-- {
+			if probe := <-results; probe.hostState != nil {
+				_ = probe.hostState.Close()
+			}
+		}
+	}(remaining)
+
+	if leader != nil {
+		return leader, nil
+	}
+
+	if reachable {
+		c.Log.V(1).Info("no configured master is the leader, looking for the leader among the masters they list")
+		return c.findMasterLeader(ctx)
+	}
+
+	if lastErr != nil {
+		return nil, errors.Wrap(lastErr, "could not connect to master leader")
+	}
+	return nil, errors.Errorf("could not connect to master leader")
+}
+
+// MasterQuorumStatus reports the reachability, role and uuid of each configured
+// master address, and compares them with the raft config of the leader. It
+// waits for the masters probed by Connect to answer.
+func (c *YBClient) MasterQuorumStatus(ctx context.Context) (*MasterQuorumStatus, error) {
+	if c.probes != nil {
+		c.probes.Wait()
+	}
+
+	listMasters, err := c.Master.MasterService.ListMastersWithContext(ctx, &master.ListMastersRequestPB{})
+	if err != nil {
+		return nil, err
+	}
+	if err := yberrors.FromResponse(listMasters); err != nil {
+		return nil, errors.Wrap(err, "could not list masters")
+	}
+
+	peers, err := c.masterRaftPeers(ctx, listMasters)
+	if err != nil {
+		return nil, err
+	}
+
+	roles := make(map[string]common.RaftPeerPB_Role)
+	for _, m := range listMasters.GetMasters() {
+		if m.Role != nil {
+			roles[string(m.GetInstanceId().GetPermanentUuid())] = m.GetRole()
+		}
+	}
+
+	status := &MasterQuorumStatus{}
+	configured := make(map[string]bool)
+	for _, probe := range c.masterProbes {
+		m := &MasterStatus{
+			Address:    util.HostPortString(probe.address),
+			Configured: true,
+			Reachable:  probe.err == nil,
+			UUID:       probe.uuid,
+		}
+		if probe.err != nil {
+			m.Error = probe.err.Error()
+		} else {
+			m.Role = probe.role.String()
+		}
+
+		peer := findRaftPeer(peers, m.UUID, probe.address)
+		if peer != nil {
+			m.UUID = string(peer.GetPermanentUuid())
+			m.InRaftConfig = true
+			m.MemberType = memberType(peer)
+			configured[m.UUID] = true
+		}
+		if role, ok := roles[m.UUID]; ok {
+			m.Role = role.String()
+		}
+
+		switch {
+		case !m.InRaftConfig:
+			m.Status = MasterNotInRaftConfig
+		case !m.Reachable:
+			m.Status = MasterUnreachable
+		default:
+			m.Status = MasterOK
+		}
+		status.Masters = append(status.Masters, m)
+	}
+
+	for _, peer := range peers {
+		uuid := string(peer.GetPermanentUuid())
+		if configured[uuid] {
+			continue
+		}
+
+		m := &MasterStatus{
+			InRaftConfig: true,
+			UUID:         uuid,
+			MemberType:   memberType(peer),
+			Status:       MasterNotConfigured,
+		}
+		if addresses := peer.GetLastKnownPrivateAddr(); len(addresses) > 0 {
+			m.Address = util.HostPortString(addresses[0])
+		}
+		if role, ok := roles[uuid]; ok {
+			m.Role = role.String()
+		}
+		status.Masters = append(status.Masters, m)
+	}
+
+	return status, nil
+}
+
+// masterRaftPeers returns the members of the leader's raft config. Masters that
+// do not implement ListMasterRaftPeers are described by their ListMasters entry.
+func (c *YBClient) masterRaftPeers(ctx context.Context, listMasters *master.ListMastersResponsePB) ([]*common.RaftPeerPB, error) {
+	raftPeers, err := c.Master.MasterService.ListMasterRaftPeersWithContext(ctx, &master.ListMasterRaftPeersRequestPB{})
+	if err == nil {
+		err = yberrors.FromResponse(raftPeers)
+	}
+	if err == nil {
+		return raftPeers.GetMasters(), nil
+	}
+	c.Log.V(1).Info("could not list master raft peers, using the master list", "error", err)
+
+	var peers []*common.RaftPeerPB
+	for _, m := range listMasters.GetMasters() {
+		peers = append(peers, &common.RaftPeerPB{
+			PermanentUuid:          m.GetInstanceId().GetPermanentUuid(),
+			LastKnownPrivateAddr:   m.GetRegistration().GetPrivateRpcAddresses(),
+			LastKnownBroadcastAddr: m.GetRegistration().GetBroadcastAddresses(),
+			CloudInfo:              m.GetRegistration().GetCloudInfo(),
+		})
+	}
+	return peers, nil
+}
+
+// findRaftPeer returns the peer with the uuid, or when the uuid is not known,
+// the peer last known at the address.
+func findRaftPeer(peers []*common.RaftPeerPB, uuid string, address *common.HostPortPB) *common.RaftPeerPB {
+	for _, peer := range peers {
+		if uuid != "" {
+			if string(peer.GetPermanentUuid()) == uuid {
+				return peer
+			}
+			continue
+		}
+
+		for _, hostPort := range append(append([]*common.HostPortPB{}, peer.GetLastKnownPrivateAddr()...), peer.GetLastKnownBroadcastAddr()...) {
+			if util.HostPortString(hostPort) == util.HostPortString(address) {
+				return peer
+			}
+		}
+	}
+	return nil
+}
+
+// memberType is empty for peers described by ListMasters, which does not give it
+func memberType(peer *common.RaftPeerPB) string {
+	if peer.MemberType == nil {
+		return ""
+	}
+	return peer.GetMemberType().String()
+}
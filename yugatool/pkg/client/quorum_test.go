@@ -0,0 +1,176 @@
+package client_test
+
+import (
+	"context"
+	"io"
+
+	"github.com/blang/vfs/memfs"
+	"github.com/go-logr/logr"
+	. "github.com/icza/gox/gox"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/common"
+	"github.com/yugabyte/yb-tools/yugatool/api/yugatool/config"
+	ybclient "github.com/yugabyte/yb-tools/yugatool/pkg/client"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client/dial"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/rpcserver"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/test/fakecluster"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/util"
+)
+
+// blockingDialer holds up dials to one address until it is released
+type blockingDialer struct {
+	network *rpcserver.Network
+	blocked string
+	release chan struct{}
+}
+
+var _ dial.Dialer = &blockingDialer{}
+
+func (d *blockingDialer) Dial(network, address string) (io.ReadWriteCloser, error) {
+	if address == d.blocked {
+		<-d.release
+	}
+	return d.network.Dial(network, address)
+}
+
+var _ = Describe("Master quorum", func() {
+	var (
+		cluster        *fakecluster.Cluster
+		yugabyteClient *ybclient.YBClient
+	)
+
+	BeforeEach(func() {
+		cluster = fakecluster.New(logr.Discard(), rpcserver.NewNetwork(), "quorum", 3, 1)
+
+		yugabyteClient = &ybclient.YBClient{
+			Log: logr.Discard(),
+			Fs:  memfs.Create(),
+			Config: &config.UniverseConfigPB{
+				Masters:        cluster.MasterHostPorts(),
+				TimeoutSeconds: NewInt64(1),
+			},
+		}
+		yugabyteClient.OverrideDialer(cluster.Network)
+	})
+
+	AfterEach(func() {
+		yugabyteClient.Close()
+	})
+
+	quorumStatus := func() map[string]*ybclient.MasterStatus {
+		status, err := yugabyteClient.MasterQuorumStatus(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+
+		masters := make(map[string]*ybclient.MasterStatus)
+		for _, m := range status.Masters {
+			masters[m.Address] = m
+		}
+		return masters
+	}
+
+	It("reports every configured master", func() {
+		Expect(yugabyteClient.Connect()).To(Succeed())
+
+		status, err := yugabyteClient.MasterQuorumStatus(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.Mismatched()).To(BeFalse())
+
+		masters := quorumStatus()
+		Expect(masters).To(HaveLen(3))
+		for _, node := range cluster.Masters {
+			m := masters[util.HostPortString(node.Address)]
+			Expect(m.Configured).To(BeTrue())
+			Expect(m.Reachable).To(BeTrue())
+			Expect(m.InRaftConfig).To(BeTrue())
+			Expect(m.UUID).To(Equal(node.UUID))
+			Expect(m.MemberType).To(Equal(common.RaftPeerPB_VOTER.String()))
+			Expect(m.Status).To(Equal(ybclient.MasterOK))
+		}
+		Expect(masters[util.HostPortString(cluster.Leader().Address)].Role).To(Equal(common.RaftPeerPB_LEADER.String()))
+		Expect(masters[util.HostPortString(cluster.Masters[1].Address)].Role).To(Equal(common.RaftPeerPB_FOLLOWER.String()))
+	})
+
+	It("does not wait for a slow master once the leader is found", func() {
+		slow := &blockingDialer{
+			network: cluster.Network,
+			blocked: util.HostPortString(cluster.Masters[1].Address),
+			release: make(chan struct{}),
+		}
+		yugabyteClient.OverrideDialer(slow)
+
+		Expect(yugabyteClient.Connect()).To(Succeed())
+		close(slow.release)
+
+		Expect(quorumStatus()[slow.blocked].Reachable).To(BeTrue())
+	})
+
+	When("a master is down", func() {
+		BeforeEach(func() {
+			cluster.Stop(cluster.Masters[2])
+		})
+
+		It("reports it as unreachable", func() {
+			Expect(yugabyteClient.Connect()).To(Succeed())
+
+			m := quorumStatus()[util.HostPortString(cluster.Masters[2].Address)]
+			Expect(m.Reachable).To(BeFalse())
+			Expect(m.InRaftConfig).To(BeTrue())
+			Expect(m.UUID).To(Equal(cluster.Masters[2].UUID))
+			Expect(m.Error).NotTo(BeEmpty())
+			Expect(m.Status).To(Equal(ybclient.MasterUnreachable))
+		})
+	})
+
+	When("the leader is not a configured master", func() {
+		BeforeEach(func() {
+			cluster.SetLeader(cluster.Masters[2])
+			yugabyteClient.Config.Masters = cluster.MasterHostPorts()[:2]
+		})
+
+		It("finds the leader and reports it as not configured", func() {
+			Expect(yugabyteClient.Connect()).To(Succeed())
+
+			status, err := yugabyteClient.MasterQuorumStatus(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status.Mismatched()).To(BeTrue())
+
+			m := quorumStatus()[util.HostPortString(cluster.Masters[2].Address)]
+			Expect(m.Configured).To(BeFalse())
+			Expect(m.UUID).To(Equal(cluster.Masters[2].UUID))
+			Expect(m.Role).To(Equal(common.RaftPeerPB_LEADER.String()))
+			Expect(m.Status).To(Equal(ybclient.MasterNotConfigured))
+		})
+	})
+
+	When("a configured address is not a member of the raft config", func() {
+		BeforeEach(func() {
+			yugabyteClient.Config.Masters = append(cluster.MasterHostPorts(), &common.HostPortPB{
+				Host: NewString("removed-master"),
+				Port: NewUint32(7100),
+			})
+		})
+
+		It("reports the stale address", func() {
+			Expect(yugabyteClient.Connect()).To(Succeed())
+
+			m := quorumStatus()["removed-master:7100"]
+			Expect(m.Configured).To(BeTrue())
+			Expect(m.Reachable).To(BeFalse())
+			Expect(m.InRaftConfig).To(BeFalse())
+			Expect(m.Status).To(Equal(ybclient.MasterNotInRaftConfig))
+		})
+	})
+
+	When("no master is reachable", func() {
+		BeforeEach(func() {
+			for _, node := range cluster.Masters {
+				cluster.Stop(node)
+			}
+		})
+
+		It("fails to connect", func() {
+			Expect(yugabyteClient.Connect()).To(MatchError(ContainSubstring("could not connect to master leader")))
+		})
+	})
+})
@@ -0,0 +1,142 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/blang/vfs/memfs"
+	"github.com/go-logr/logr"
+	. "github.com/icza/gox/gox"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/server"
+	"github.com/yugabyte/yb-tools/yugatool/api/yugatool/config"
+	ybclient "github.com/yugabyte/yb-tools/yugatool/pkg/client"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client/session"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/rpcserver"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/test/fakecluster"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/util"
+)
+
+var _ = Describe("Reconnect", func() {
+	var (
+		cluster        *fakecluster.Cluster
+		yugabyteClient *ybclient.YBClient
+		tserver        *fakecluster.Node
+	)
+
+	BeforeEach(func() {
+		cluster = fakecluster.New(logr.Discard(), rpcserver.NewNetwork(), "reconnect", 1, 1)
+		tserver = cluster.TabletServers[0]
+
+		yugabyteClient = &ybclient.YBClient{
+			Log: logr.Discard(),
+			Fs:  memfs.Create(),
+			Config: &config.UniverseConfigPB{
+				Masters:        cluster.MasterHostPorts(),
+				TimeoutSeconds: NewInt64(1),
+			},
+		}
+		yugabyteClient.OverrideDialer(cluster.Network)
+		Expect(yugabyteClient.Connect()).To(Succeed())
+	})
+
+	AfterEach(func() {
+		yugabyteClient.Close()
+	})
+
+	tserverHost := func() *ybclient.HostState {
+		hostState, err := yugabyteClient.GetHostByUUID([]byte(tserver.UUID))
+		Expect(err).NotTo(HaveOccurred())
+		return hostState
+	}
+
+	It("dials a tablet server within the context of the call", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := yugabyteClient.GetHostByUUIDWithContext(ctx, []byte(tserver.UUID))
+		Expect(err).To(MatchError(context.Canceled))
+
+		_, err = yugabyteClient.GetHostByUUIDWithContext(context.Background(), []byte(tserver.UUID))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	When("a tablet server restarts", func() {
+		var hostState *ybclient.HostState
+
+		BeforeEach(func() {
+			hostState = tserverHost()
+			cluster.Stop(tserver)
+			cluster.Start(tserver)
+		})
+
+		It("retries idempotent calls on a new connection", func() {
+			_, err := hostState.GenericService.GetStatus(&server.GetStatusRequestPB{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("fails other calls once, and reconnects for the next", func() {
+			_, err := hostState.GenericService.SetFlag(&server.SetFlagRequestPB{Flag: NewString("v"), Value: NewString("1")})
+			var connectionError *session.ConnectionError
+			Expect(errors.As(err, &connectionError)).To(BeTrue(), "%v", err)
+
+			_, err = hostState.GenericService.GetStatus(&server.GetStatusRequestPB{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hostState.Dead()).To(BeFalse())
+		})
+	})
+
+	When("a tablet server stays down", func() {
+		It("evicts the dead host once it comes back", func() {
+			hostState := tserverHost()
+			cluster.Stop(tserver)
+
+			_, err := hostState.GenericService.GetStatus(&server.GetStatusRequestPB{})
+			Expect(err).To(MatchError(ContainSubstring("could not reconnect")))
+			Expect(hostState.Dead()).To(BeTrue())
+
+			_, err = yugabyteClient.GetHostByUUID([]byte(tserver.UUID))
+			Expect(err).To(HaveOccurred())
+
+			cluster.Start(tserver)
+			newHostState := tserverHost()
+			Expect(newHostState).NotTo(BeIdenticalTo(hostState))
+			_, err = newHostState.GenericService.GetStatus(&server.GetStatusRequestPB{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	When("keepalive is enabled", func() {
+		BeforeEach(func() {
+			yugabyteClient.Close()
+			yugabyteClient.Config.KeepaliveSeconds = NewInt64(1)
+			yugabyteClient.Stats = nil
+			Expect(yugabyteClient.Connect()).To(Succeed())
+		})
+
+		It("pings idle servers, reconnecting lost connections", func() {
+			hostState := tserverHost()
+			cluster.Stop(tserver)
+			cluster.Start(tserver)
+
+			pings := func() int64 {
+				for _, stats := range yugabyteClient.RPCStats() {
+					if stats.Host == util.HostPortString(tserver.Address) && stats.Method == "Ping" {
+						return stats.Calls - stats.Errors
+					}
+				}
+				return 0
+			}
+			Eventually(pings, 3*time.Second).Should(BeNumerically(">=", 1))
+			Expect(hostState.Dead()).To(BeFalse())
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			_, err := hostState.GenericService.SetFlagWithContext(ctx, &server.SetFlagRequestPB{Flag: NewString("v"), Value: NewString("1")})
+			var connectionError *session.ConnectionError
+			Expect(errors.As(err, &connectionError)).To(BeFalse(), "%v", err)
+		})
+	})
+})
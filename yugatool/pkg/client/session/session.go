@@ -2,9 +2,11 @@ package session
 
 import (
 	"bytes"
+	"context"
 	"io"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
@@ -13,15 +15,40 @@ import (
 	"github.com/yugabyte/yb-tools/yugatool/pkg/util"
 )
 
+const (
+	// DefaultReconnectAttempts is the number of times a lost connection is
+	// dialed again before the session is considered dead
+	DefaultReconnectAttempts = 5
+
+	reconnectBackoff    = 100 * time.Millisecond
+	maxReconnectBackoff = 2 * time.Second
+)
+
+// ErrClosed is returned for calls on a session after it is closed
+var ErrClosed = errors.New("session is closed")
+
+// Session is the connection to a server. When the connection fails, the session
+// dials the server again, with exponential backoff, the next time a connection
+// is asked for.
 type Session struct {
 	m            sync.Mutex
-	conn         io.ReadWriteCloser
 	messageCount int32
 
+	connLock   sync.Mutex
+	conn       io.ReadWriteCloser
+	generation uint64
+	broken     bool
+	dead       bool
+	closed     bool
+
 	Host   *common.HostPortPB
 	Log    logr.Logger
 	Dialer dial.Dialer
 	Ping   func(*Session) error
+
+	// ReconnectAttempts bounds how many times a lost connection is dialed again.
+	// Zero means DefaultReconnectAttempts, and a negative value never reconnects.
+	ReconnectAttempts int
 }
 
 func NewSession(log logr.Logger, host *common.HostPortPB, dialer dial.Dialer, ping func(*Session) error) (*Session, error) {
@@ -35,6 +62,8 @@ func NewSession(log logr.Logger, host *common.HostPortPB, dialer dial.Dialer, pi
 	return s, err
 }
 
+// Lock is held while writing a request, so requests from concurrent callers are
+// never interleaved on the wire.
 func (s *Session) Lock() {
 	s.m.Lock()
 }
@@ -44,15 +73,45 @@ func (s *Session) Unlock() {
 }
 
 func (s *Session) Write(bytes []byte) (int, error) {
-	return s.conn.Write(bytes)
+	return s.current().Write(bytes)
 }
 
 func (s *Session) Read(b []byte) (int, error) {
-	return s.conn.Read(b)
+	return s.current().Read(b)
+}
+
+// SetWriteDeadline bounds how long a blocked Write may wait. It does nothing if
+// the underlying connection does not support deadlines.
+func (s *Session) SetWriteDeadline(t time.Time) error {
+	return SetWriteDeadline(s.current(), t)
 }
 
+// SetWriteDeadline bounds how long a blocked Write to conn may wait, if conn
+// supports deadlines.
+func SetWriteDeadline(conn io.Writer, t time.Time) error {
+	if conn, ok := conn.(interface{ SetWriteDeadline(time.Time) error }); ok {
+		return conn.SetWriteDeadline(t)
+	}
+	return nil
+}
+
+func (s *Session) current() io.ReadWriteCloser {
+	s.connLock.Lock()
+	defer s.connLock.Unlock()
+	return s.conn
+}
+
+// Close closes the connection, and stops the session from reconnecting.
 func (s *Session) Close() error {
 	s.Log.V(1).Info("closing connection")
+
+	s.connLock.Lock()
+	defer s.connLock.Unlock()
+
+	s.closed = true
+	if s.broken {
+		return nil
+	}
 	return s.conn.Close()
 }
 
@@ -61,30 +120,131 @@ func (s *Session) GenerateCallID() int32 {
 	return count
 }
 
+// Conn returns the connection to the server and its generation, which the
+// caller passes to Fail if the connection breaks. A broken connection is
+// replaced first, retrying with exponential backoff until the reconnect
+// attempts run out or the context is done.
+func (s *Session) Conn(ctx context.Context) (io.ReadWriteCloser, uint64, error) {
+	s.connLock.Lock()
+	defer s.connLock.Unlock()
+
+	if s.closed {
+		return nil, 0, ErrClosed
+	}
+	if !s.broken {
+		return s.conn, s.generation, nil
+	}
+
+	attempts := s.ReconnectAttempts
+	if attempts == 0 {
+		attempts = DefaultReconnectAttempts
+	}
+	if attempts < 0 {
+		return nil, 0, &ConnectionError{HostPortPB: s.Host, Err: errors.New("connection lost and reconnecting is disabled")}
+	}
+
+	backoff := reconnectBackoff
+	for attempt := 1; ; attempt++ {
+		s.Log.V(1).Info("reconnecting to host", "attempt", attempt)
+		conn, err := s.dial(s.Host)
+		if err == nil {
+			s.conn = conn
+			s.generation++
+			s.broken = false
+			s.dead = false
+			return s.conn, s.generation, nil
+		}
+
+		if attempt >= attempts {
+			s.dead = true
+			return nil, 0, errors.Wrapf(err, "could not reconnect after %d attempts", attempts)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, 0, errors.Wrap(ctx.Err(), "could not reconnect")
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > maxReconnectBackoff {
+			backoff = maxReconnectBackoff
+		}
+	}
+}
+
+// Fail marks the connection of the generation as broken and closes it, so the
+// next call to Conn reconnects. Failures of connections that were already
+// replaced are ignored.
+func (s *Session) Fail(generation uint64, err error) {
+	s.connLock.Lock()
+	defer s.connLock.Unlock()
+
+	if s.closed || s.broken || generation != s.generation {
+		return
+	}
+	s.Log.V(1).Info("connection lost", "error", err)
+	s.broken = true
+	_ = s.conn.Close()
+}
+
+// Dead reports whether the session is closed, or could not reconnect after
+// losing its connection.
+func (s *Session) Dead() bool {
+	s.connLock.Lock()
+	defer s.connLock.Unlock()
+
+	return s.closed || s.dead
+}
+
 func (s *Session) Connect(host *common.HostPortPB) error {
-	var err error
+	conn, err := s.dial(host)
+	if err != nil {
+		return err
+	}
+
+	s.connLock.Lock()
+	defer s.connLock.Unlock()
+
+	s.conn = conn
+	s.generation++
+	s.broken = false
+	s.dead = false
+	return nil
+}
+
+// dial connects to the host, says hello and pings it over the new connection.
+func (s *Session) dial(host *common.HostPortPB) (io.ReadWriteCloser, error) {
 	s.Log.V(1).Info("connecting to host")
-	s.conn, err = s.Dialer.Dial("tcp", util.HostPortString(host))
+	conn, err := s.Dialer.Dial("tcp", util.HostPortString(host))
 	if err != nil {
-		return DialError{
+		return nil, DialError{
 			HostPortPB: host,
 			Err:        err,
 		}
 	}
 
-	err = writeHello(s.conn)
+	err = writeHello(conn)
 	if err != nil {
-		_ = s.conn.Close()
-		return err
+		_ = conn.Close()
+		return nil, err
 	}
 
-	err = s.Ping(s)
+	// The ping is made on a session of its own, as the connection is not yet
+	// handed out to callers
+	err = s.Ping(&Session{
+		conn:              conn,
+		generation:        1,
+		Host:              host,
+		Log:               s.Log,
+		ReconnectAttempts: -1,
+	})
 	if err != nil {
-		_ = s.conn.Close()
-		return err
+		_ = conn.Close()
+		return nil, err
 	}
 
-	return nil
+	return conn, nil
 }
 
 func writeHello(conn io.ReadWriteCloser) error {
@@ -111,3 +271,22 @@ type DialError struct {
 func (e DialError) Error() string {
 	return "session.Dial " + e.String() + ": " + e.Err.Error()
 }
+
+func (e DialError) Unwrap() error {
+	return e.Err
+}
+
+// ConnectionError is the failure of a call because its connection was lost. The
+// session reconnects for the next call.
+type ConnectionError struct {
+	*common.HostPortPB
+	Err error
+}
+
+func (e *ConnectionError) Error() string {
+	return "connection to " + util.HostPortString(e.HostPortPB) + " lost: " + e.Err.Error()
+}
+
+func (e *ConnectionError) Unwrap() error {
+	return e.Err
+}
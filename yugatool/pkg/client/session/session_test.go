@@ -1,8 +1,140 @@
 package session_test
 
 import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sync"
+
+	"github.com/go-logr/logr"
+	. "github.com/icza/gox/gox"
 	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/common"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client/session"
 )
 
+// serverDialer connects to a server that discards everything it is sent, unless
+// the server is down
+type serverDialer struct {
+	m     sync.Mutex
+	down  bool
+	dials int
+}
+
+func (d *serverDialer) Dial(_, _ string) (io.ReadWriteCloser, error) {
+	d.m.Lock()
+	defer d.m.Unlock()
+
+	d.dials++
+	if d.down {
+		return nil, errors.New("connection refused")
+	}
+
+	clientConn, serverConn := net.Pipe()
+	go func() {
+		_, _ = io.Copy(io.Discard, serverConn)
+	}()
+	return clientConn, nil
+}
+
+func (d *serverDialer) setDown(down bool) {
+	d.m.Lock()
+	defer d.m.Unlock()
+	d.down = down
+}
+
 var _ = Describe("Session", func() {
+	var (
+		dialer *serverDialer
+		s      *session.Session
+	)
+
+	BeforeEach(func() {
+		dialer = &serverDialer{}
+
+		var err error
+		s, err = session.NewSession(logr.Discard(), &common.HostPortPB{Host: NewString("tserver-1"), Port: NewUint32(9100)},
+			dialer, func(*session.Session) error { return nil })
+		Expect(err).NotTo(HaveOccurred())
+		s.ReconnectAttempts = 2
+	})
+
+	AfterEach(func() {
+		_ = s.Close()
+	})
+
+	It("hands out the same connection until it fails", func() {
+		conn, generation, err := s.Conn(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+
+		again, againGeneration, err := s.Conn(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(again).To(BeIdenticalTo(conn))
+		Expect(againGeneration).To(Equal(generation))
+		Expect(dialer.dials).To(Equal(1))
+	})
+
+	When("the connection fails", func() {
+		var generation uint64
+
+		BeforeEach(func() {
+			var err error
+			_, generation, err = s.Conn(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			s.Fail(generation, errors.New("broken pipe"))
+		})
+
+		It("reconnects for the next caller", func() {
+			_, newGeneration, err := s.Conn(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(newGeneration).To(BeNumerically(">", generation))
+			Expect(dialer.dials).To(Equal(2))
+		})
+
+		It("ignores later failures of the lost connection", func() {
+			conn, _, err := s.Conn(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+
+			s.Fail(generation, errors.New("broken pipe"))
+
+			again, _, err := s.Conn(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(again).To(BeIdenticalTo(conn))
+		})
+
+		It("is dead once the reconnect attempts run out", func() {
+			dialer.setDown(true)
+
+			_, _, err := s.Conn(context.Background())
+			Expect(err).To(MatchError(ContainSubstring("could not reconnect after 2 attempts")))
+			Expect(s.Dead()).To(BeTrue())
+			Expect(dialer.dials).To(Equal(3))
+
+			dialer.setDown(false)
+			_, _, err = s.Conn(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(s.Dead()).To(BeFalse())
+		})
+
+		It("stops reconnecting when the context is done", func() {
+			dialer.setDown(true)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			_, _, err := s.Conn(ctx)
+			Expect(err).To(MatchError(context.Canceled))
+		})
+	})
+
+	When("the session is closed", func() {
+		It("does not reconnect", func() {
+			Expect(s.Close()).To(Succeed())
+
+			_, _, err := s.Conn(context.Background())
+			Expect(err).To(MatchError(session.ErrClosed))
+			Expect(s.Dead()).To(BeTrue())
+		})
+	})
 })
@@ -0,0 +1,57 @@
+package client
+
+import (
+	"context"
+
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/tserver"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client/message"
+	"google.golang.org/protobuf/proto"
+)
+
+// The generated services only return the response message, so RPCs that send
+// data back in sidecars are made directly through the host messenger.
+
+// CallWithSidecars sends an RPC to the host and returns the sidecars that
+// followed the response, in the order the server attached them.
+func (h *HostState) CallWithSidecars(ctx context.Context, service string, method string, request proto.Message, response proto.Message) ([][]byte, error) {
+	withSidecars := &message.ResponseWithSidecars{Message: response}
+
+	err := h.messenger.SendMessage(ctx, service, method, request, withSidecars)
+	if err != nil {
+		return nil, err
+	}
+	return withSidecars.Sidecars, nil
+}
+
+// ReadWithSidecars reads rows directly from a tablet. The rows_data_sidecar of
+// each QL or PGSQL response is an index into the returned sidecars.
+func (h *HostState) ReadWithSidecars(ctx context.Context, request *tserver.ReadRequestPB) (*tserver.ReadResponsePB, [][]byte, error) {
+	response := &tserver.ReadResponsePB{}
+	sidecars, err := h.CallWithSidecars(ctx, "yb.tserver.TabletServerService", "Read", request, response)
+	if err != nil {
+		return nil, nil, err
+	}
+	return response, sidecars, nil
+}
+
+// WriteWithSidecars writes to a tablet, returning the rows sidecars of any
+// statements that return rows.
+func (h *HostState) WriteWithSidecars(ctx context.Context, request *tserver.WriteRequestPB) (*tserver.WriteResponsePB, [][]byte, error) {
+	response := &tserver.WriteResponsePB{}
+	sidecars, err := h.CallWithSidecars(ctx, "yb.tserver.TabletServerService", "Write", request, response)
+	if err != nil {
+		return nil, nil, err
+	}
+	return response, sidecars, nil
+}
+
+// FetchDataWithSidecars fetches a chunk of a WAL segment or SST file from a
+// remote bootstrap session.
+func (h *HostState) FetchDataWithSidecars(ctx context.Context, request *tserver.FetchDataRequestPB) (*tserver.FetchDataResponsePB, [][]byte, error) {
+	response := &tserver.FetchDataResponsePB{}
+	sidecars, err := h.CallWithSidecars(ctx, "yb.tserver.RemoteBootstrapService", "FetchData", request, response)
+	if err != nil {
+		return nil, nil, err
+	}
+	return response, sidecars, nil
+}
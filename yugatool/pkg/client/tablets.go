@@ -0,0 +1,41 @@
+package client
+
+import (
+	"context"
+
+	. "github.com/icza/gox/gox"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/master"
+	yberrors "github.com/yugabyte/yb-tools/yugatool/pkg/client/errors"
+)
+
+// tabletLocationsBatchLimit is the number of tablet locations asked for at once
+const tabletLocationsBatchLimit = 100
+
+// TableLocations pages through the locations of every tablet of the table.
+func (c *YBClient) TableLocations(ctx context.Context, tableID []byte) ([]*master.TabletLocationsPB, error) {
+	var locations []*master.TabletLocationsPB
+	var start []byte
+	for {
+		response, err := c.Master.MasterService.GetTableLocationsWithContext(ctx, &master.GetTableLocationsRequestPB{
+			Table:                &master.TableIdentifierPB{TableId: tableID},
+			PartitionKeyStart:    start,
+			MaxReturnedLocations: NewUint32(tabletLocationsBatchLimit),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := yberrors.FromResponse(response); err != nil {
+			return nil, err
+		}
+
+		batch := response.GetTabletLocations()
+		locations = append(locations, batch...)
+		if len(batch) < tabletLocationsBatchLimit {
+			return locations, nil
+		}
+		start = batch[len(batch)-1].GetPartition().GetPartitionKeyEnd()
+		if len(start) == 0 {
+			return locations, nil
+		}
+	}
+}
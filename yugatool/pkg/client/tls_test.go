@@ -0,0 +1,267 @@
+package client_test
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"io"
+	"net"
+	"time"
+
+	"github.com/blang/vfs"
+	"github.com/blang/vfs/memfs"
+	"github.com/go-logr/logr"
+	. "github.com/icza/gox/gox"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/yugabyte/yb-tools/yugatool/api/yugatool/config"
+	ybclient "github.com/yugabyte/yb-tools/yugatool/pkg/client"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client/dial"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/rpcserver"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/test/fakecluster"
+	testutil "github.com/yugabyte/yb-tools/yugatool/pkg/test/util"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/util"
+)
+
+// networkProxy reaches the in-memory network the way a proxy reaches the
+// universe, so the client wraps its connections in TLS
+type networkProxy struct {
+	network *rpcserver.Network
+	config  *tls.Config
+}
+
+var (
+	_ dial.ProxyDialer      = &networkProxy{}
+	_ dial.ServerNameDialer = &networkProxy{}
+)
+
+func (p *networkProxy) Dial(network, address string) (io.ReadWriteCloser, error) {
+	conn, err := p.DialContext(context.Background(), network, address)
+	if err != nil || p.config == nil {
+		return conn, err
+	}
+
+	config := p.config.Clone()
+	if config.ServerName == "" {
+		config.ServerName, _, _ = net.SplitHostPort(address)
+	}
+	tlsConn := tls.Client(conn, config)
+	err = tlsConn.Handshake()
+	if err != nil {
+		_ = conn.Close()
+		return nil, dial.NewTLSError(address, err)
+	}
+	return tlsConn, nil
+}
+
+func (p *networkProxy) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	return p.network.DialContext(ctx, network, address)
+}
+
+func (p *networkProxy) WithTLS(config *tls.Config) dial.ProxyDialer {
+	return &networkProxy{network: p.network, config: config}
+}
+
+func (p *networkProxy) WithServerName(name string) dial.Dialer {
+	if p.config == nil {
+		return p
+	}
+	config := p.config.Clone()
+	config.ServerName = name
+	return &networkProxy{network: p.network, config: config}
+}
+
+func (p *networkProxy) Close() error {
+	return nil
+}
+
+// serveTLS serves each node of the cluster over TLS, with a certificate for its
+// host from the CA.
+func serveTLS(cluster *fakecluster.Cluster, caCert *x509.Certificate, key *rsa.PrivateKey, notAfter time.Time) {
+	for _, node := range append(append([]*fakecluster.Node{}, cluster.Masters...), cluster.TabletServers...) {
+		cluster.ServeTLS(node, serverCertificate(caCert, key, notAfter, node.Address.GetHost()))
+	}
+}
+
+func serverCertificate(caCert *x509.Certificate, key *rsa.PrivateKey, notAfter time.Time, hosts ...string) tls.Certificate {
+	certPEM, keyPEM, err := testutil.GenerateServerCertificate(caCert, key, notAfter, hosts...)
+	Expect(err).NotTo(HaveOccurred())
+	certificate, err := tls.X509KeyPair(certPEM, keyPEM)
+	Expect(err).NotTo(HaveOccurred())
+	return certificate
+}
+
+func generateCA() ([]byte, *x509.Certificate, *rsa.PrivateKey) {
+	caPEM, key, err := testutil.GenerateCACertificate()
+	Expect(err).NotTo(HaveOccurred())
+	block, _ := pem.Decode(caPEM)
+	caCert, err := x509.ParseCertificate(block.Bytes)
+	Expect(err).NotTo(HaveOccurred())
+	return caPEM, caCert, key
+}
+
+var _ = Describe("TLS", func() {
+	var (
+		cluster        *fakecluster.Cluster
+		yugabyteClient *ybclient.YBClient
+		fs             vfs.Filesystem
+
+		caCert *x509.Certificate
+		caKey  *rsa.PrivateKey
+	)
+
+	BeforeEach(func() {
+		cluster = fakecluster.New(logr.Discard(), rpcserver.NewNetwork(), "tls", 1, 1)
+
+		var caPEM []byte
+		caPEM, caCert, caKey = generateCA()
+		fs = memfs.Create()
+		Expect(vfs.WriteFile(fs, "/ca.crt", caPEM, 0600)).To(Succeed())
+
+		yugabyteClient = &ybclient.YBClient{
+			Log: logr.Discard(),
+			Fs:  fs,
+			Config: &config.UniverseConfigPB{
+				Masters:        cluster.MasterHostPorts(),
+				TimeoutSeconds: NewInt64(1),
+				TlsOpts:        &config.TlsOptionsPB{CaCertPath: NewString("/ca.crt")},
+			},
+			Proxy: &networkProxy{network: cluster.Network},
+		}
+	})
+
+	AfterEach(func() {
+		yugabyteClient.Close()
+	})
+
+	tserverUUID := func() []byte {
+		return []byte(cluster.TabletServers[0].UUID)
+	}
+
+	Context("TLSPolicy", func() {
+		It("requires TLS when a TLS option is set", func() {
+			Expect(yugabyteClient.TLSPolicy()).To(Equal(config.TlsPolicyPB_REQUIRED))
+		})
+
+		It("disables TLS without TLS options", func() {
+			yugabyteClient.Config.TlsOpts = nil
+			Expect(yugabyteClient.TLSPolicy()).To(Equal(config.TlsPolicyPB_DISABLED))
+
+			tlsConfig, err := yugabyteClient.TLSConfig()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tlsConfig).To(BeNil())
+		})
+
+		It("uses the system's trusted CAs when TLS is required without a CA certificate", func() {
+			yugabyteClient.Config.TlsOpts = &config.TlsOptionsPB{Policy: config.TlsPolicyPB_REQUIRED.Enum()}
+
+			tlsConfig, err := yugabyteClient.TLSConfig()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tlsConfig).NotTo(BeNil())
+			Expect(tlsConfig.RootCAs).To(BeNil())
+		})
+
+		It("connects in plaintext when TLS is disabled", func() {
+			yugabyteClient.Config.TlsOpts.Policy = config.TlsPolicyPB_DISABLED.Enum()
+
+			tlsConfig, err := yugabyteClient.TLSConfig()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tlsConfig).To(BeNil())
+			Expect(yugabyteClient.Connect()).To(Succeed())
+		})
+	})
+
+	When("the servers have valid certificates", func() {
+		BeforeEach(func() {
+			serveTLS(cluster, caCert, caKey, time.Now().AddDate(1, 0, 0))
+		})
+
+		It("connects to every server over TLS", func() {
+			Expect(yugabyteClient.Connect()).To(Succeed())
+
+			_, err := yugabyteClient.GetHostByUUID(tserverUUID())
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("verifies rewritten addresses against the registered host", func() {
+			tserver := cluster.TabletServers[0]
+			cluster.Network.Stop(util.HostPortString(tserver.Address))
+			cluster.Network.Listen("tserver-alias:19100", tserver.Server)
+			cluster.Network.ServeTLS("tserver-alias:19100", &tls.Config{
+				Certificates: []tls.Certificate{serverCertificate(caCert, caKey, time.Now().AddDate(1, 0, 0), tserver.Address.GetHost())},
+			})
+
+			yugabyteClient.Config.AddressPolicy = &config.AddressPolicyPB{
+				HostMap: map[string]string{tserver.Address.GetHost(): "tserver-alias:19100"},
+			}
+			Expect(yugabyteClient.Connect()).To(Succeed())
+
+			_, err := yugabyteClient.GetHostByUUID(tserverUUID())
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	When("the server certificates are signed by another CA", func() {
+		BeforeEach(func() {
+			_, otherCA, otherKey := generateCA()
+			serveTLS(cluster, otherCA, otherKey, time.Now().AddDate(1, 0, 0))
+		})
+
+		It("fails with an unknown authority error", func() {
+			err := yugabyteClient.Connect()
+			Expect(err).To(MatchError(dial.ErrUnknownAuthority))
+			Expect(err).To(MatchError(ContainSubstring("--cacert")))
+		})
+	})
+
+	When("the server certificates have expired", func() {
+		BeforeEach(func() {
+			serveTLS(cluster, caCert, caKey, time.Now().Add(-time.Hour))
+		})
+
+		It("fails with an expired certificate error", func() {
+			err := yugabyteClient.Connect()
+			Expect(err).To(MatchError(dial.ErrCertificateExpired))
+			Expect(err).To(MatchError(ContainSubstring("rotate the server certificates")))
+		})
+	})
+
+	When("the server certificates are for other hosts", func() {
+		BeforeEach(func() {
+			for _, node := range cluster.Masters {
+				cluster.ServeTLS(node, serverCertificate(caCert, caKey, time.Now().AddDate(1, 0, 0), "other-host"))
+			}
+		})
+
+		It("fails with a hostname mismatch error", func() {
+			err := yugabyteClient.Connect()
+			Expect(err).To(MatchError(dial.ErrHostnameMismatch))
+			Expect(err).To(MatchError(ContainSubstring("only for [other-host]")))
+		})
+
+		It("connects when host verification is skipped", func() {
+			yugabyteClient.Config.TlsOpts.SkipHostVerification = NewBool(true)
+			Expect(yugabyteClient.Connect()).To(Succeed())
+		})
+	})
+
+	When("the servers do not serve TLS", func() {
+		It("does not fall back to plaintext when TLS is required", func() {
+			Expect(yugabyteClient.Connect()).To(MatchError(ContainSubstring("could not connect to master leader")))
+		})
+
+		It("falls back to plaintext when TLS is preferred", func() {
+			yugabyteClient.Config.TlsOpts.Policy = config.TlsPolicyPB_PREFERRED.Enum()
+			Expect(yugabyteClient.Connect()).To(Succeed())
+
+			tlsConfig, err := yugabyteClient.TLSConfig()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tlsConfig).To(BeNil())
+
+			_, err = yugabyteClient.GetHostByUUID(tserverUUID())
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/common"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/master"
+	yberrors "github.com/yugabyte/yb-tools/yugatool/pkg/client/errors"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/util"
+)
+
+// FindTabletServer returns the tablet server named by its UUID, host:port or
+// host, along with every tablet server registered with the master leader.
+func (c *YBClient) FindTabletServer(ctx context.Context, name string) (*master.ListTabletServersResponsePB_Entry, []*master.ListTabletServersResponsePB_Entry, error) {
+	response, err := c.Master.MasterService.ListTabletServersWithContext(ctx, &master.ListTabletServersRequestPB{})
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := yberrors.FromResponse(response); err != nil {
+		return nil, nil, errors.Wrap(err, "could not list tablet servers")
+	}
+
+	var matches []*master.ListTabletServersResponsePB_Entry
+	for _, ts := range response.GetServers() {
+		if matchesTabletServer(ts, name) {
+			matches = append(matches, ts)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, nil, errors.Errorf("no tablet server %s is registered with the master", name)
+	case 1:
+		return matches[0], response.GetServers(), nil
+	}
+
+	var addresses []string
+	for _, ts := range matches {
+		addresses = append(addresses, TabletServerAddress(ts))
+	}
+	return nil, nil, errors.Errorf("%s names several tablet servers: %s", name, strings.Join(addresses, ", "))
+}
+
+// TabletServerAddress returns the first private address of the tablet server,
+// or its UUID when it has none.
+func TabletServerAddress(ts *master.ListTabletServersResponsePB_Entry) string {
+	if addresses := ts.GetRegistration().GetCommon().GetPrivateRpcAddresses(); len(addresses) > 0 {
+		return util.HostPortString(addresses[0])
+	}
+	return string(ts.GetInstanceId().GetPermanentUuid())
+}
+
+func matchesTabletServer(ts *master.ListTabletServersResponsePB_Entry, name string) bool {
+	if string(ts.GetInstanceId().GetPermanentUuid()) == name {
+		return true
+	}
+	registration := ts.GetRegistration().GetCommon()
+	for _, address := range append(append([]*common.HostPortPB{}, registration.GetPrivateRpcAddresses()...), registration.GetBroadcastAddresses()...) {
+		if util.HostPortString(address) == name || address.GetHost() == name {
+			return true
+		}
+	}
+	return false
+}
@@ -3,15 +3,23 @@ package cmdutil
 import (
 	"context"
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/blang/vfs"
 	"github.com/go-logr/logr"
+	. "github.com/icza/gox/gox"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/yugabyte/yb-tools/pkg/flag"
 	"github.com/yugabyte/yb-tools/pkg/format"
 	"github.com/yugabyte/yb-tools/yugatool/api/yb/common"
 	"github.com/yugabyte/yb-tools/yugatool/api/yugatool/config"
 	"github.com/yugabyte/yb-tools/yugatool/pkg/client"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client/dial"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client/message"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/recording"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/snapshot"
 )
 
 type CommandOptions interface {
@@ -27,6 +35,16 @@ type YugatoolContext struct {
 	CommandOptions CommandOptions
 	Fs             vfs.Filesystem
 
+	// Dialer replaces the dialer built from the global options when set
+	Dialer dial.Dialer
+
+	// WrapDialer, when set, wraps the dialer of every client the command
+	// creates, such as to record the calls made
+	WrapDialer func(dial.Dialer) dial.Dialer
+
+	// Stats counts the calls made by every client the command creates
+	Stats *message.Stats
+
 	Client *client.YBClient
 }
 
@@ -41,6 +59,11 @@ func (ctx *YugatoolContext) WithVFS(fs vfs.Filesystem) *YugatoolContext {
 	return ctx
 }
 
+func (ctx *YugatoolContext) WithDialer(dialer dial.Dialer) *YugatoolContext {
+	ctx.Dialer = dialer
+	return ctx
+}
+
 func (ctx *YugatoolContext) WithGlobalOptions(options *GlobalOptions) *YugatoolContext {
 	ctx.GlobalOptions = options
 	return ctx
@@ -56,7 +79,24 @@ func (ctx *YugatoolContext) WithOptions(options CommandOptions) *YugatoolContext
 	return ctx
 }
 
+// Setup prepares the command and connects to the universe.
 func (ctx *YugatoolContext) Setup() error {
+	err := ctx.Prepare()
+	if err != nil {
+		return err
+	}
+
+	err = ctx.Connect()
+	if err != nil {
+		return fmt.Errorf("failed to setup %s command: %w", ctx.Cmd.Name(), err)
+	}
+
+	return nil
+}
+
+// Prepare sets up logging and output, and completes and validates the options,
+// without connecting to the universe.
+func (ctx *YugatoolContext) Prepare() error {
 	if ctx.Cmd == nil {
 		panic("ctx.Cmd is not set")
 	}
@@ -73,6 +113,11 @@ func (ctx *YugatoolContext) Setup() error {
 
 	var err error
 
+	// Commands run with the context passed to cobra, which is cancelled on interrupt
+	if cmdContext := ctx.Cmd.Context(); cmdContext != nil {
+		ctx.Context = cmdContext
+	}
+
 	format.SetOut(ctx.Cmd.OutOrStdout())
 
 	ctx.Log, err = GetLogger(ctx.Cmd.Name(), ctx.GlobalOptions.Debug)
@@ -85,6 +130,11 @@ func (ctx *YugatoolContext) Setup() error {
 		return setupError(err)
 	}
 
+	err = ctx.loadSnapshot()
+	if err != nil {
+		return err
+	}
+
 	err = flag.ValidateRequiredFlags(ctx.Cmd.Flags())
 	if err != nil {
 		return err
@@ -104,11 +154,6 @@ func (ctx *YugatoolContext) Setup() error {
 
 	ctx.Cmd.SilenceUsage = true
 
-	err = ctx.Connect()
-	if err != nil {
-		return setupError(err)
-	}
-
 	return nil
 }
 
@@ -118,6 +163,58 @@ func (ctx *YugatoolContext) Connect() error {
 	return err
 }
 
+// PrintRPCStats prints the calls made to each method of each server, when
+// requested with --rpc-stats.
+func (ctx *YugatoolContext) PrintRPCStats() error {
+	if !ctx.GlobalOptions.RPCStats || ctx.Stats == nil {
+		return nil
+	}
+
+	report := format.Output{
+		OutputMessage: "RPC Stats",
+		JSONObject:    ctx.Stats.Methods(),
+		OutputType:    ctx.GlobalOptions.Output,
+		TableColumns: []format.Column{
+			{Name: "HOST", JSONPath: "$.host"},
+			{Name: "SERVICE", JSONPath: "$.service"},
+			{Name: "METHOD", JSONPath: "$.method"},
+			{Name: "CALLS", JSONPath: "$.calls"},
+			{Name: "ERRORS", JSONPath: "$.errors"},
+			{Name: "MEAN_MS", JSONPath: "$.mean_ms"},
+			{Name: "P50_MS", JSONPath: "$.p50_ms"},
+			{Name: "P99_MS", JSONPath: "$.p99_ms"},
+			{Name: "MAX_MS", JSONPath: "$.max_ms"},
+		},
+	}
+	return report.Println()
+}
+
+// loadSnapshot reads the snapshot given by --snapshot. The masters it was taken
+// through are used unless others are given.
+func (ctx *YugatoolContext) loadSnapshot() error {
+	o := ctx.GlobalOptions
+	if o.Snapshot == "" {
+		return nil
+	}
+
+	f, err := ctx.Fs.OpenFile(o.Snapshot, os.O_RDONLY, 0)
+	if err != nil {
+		return fmt.Errorf("unable to open snapshot: %w", err)
+	}
+	defer f.Close()
+
+	o.snapshot, err = snapshot.Load(f)
+	if err != nil {
+		return err
+	}
+
+	if o.MasterAddresses == "" {
+		o.MasterAddresses = strings.Join(o.snapshot.Manifest.Masters, ",")
+		return ctx.Cmd.Flags().Set("master-addresses", o.MasterAddresses)
+	}
+	return nil
+}
+
 func (ctx *YugatoolContext) complete() error {
 	flag.BindFlags(ctx.Cmd.Flags())
 
@@ -142,13 +239,36 @@ type GlobalOptions struct {
 	Debug                bool   `mapstructure:"debug"`
 	Output               string `mapstructure:"output"`
 	DialTimeout          int64  `mapstructure:"dial_timeout"`
+	RPCTimeout           int64  `mapstructure:"rpc_timeout"`
+	Keepalive            int64  `mapstructure:"keepalive"`
 	MasterAddresses      string `mapstructure:"master_addresses"`
 	CACert               string `mapstructure:"cacert"`
 	ClientCert           string `mapstructure:"client_cert"`
 	ClientKey            string `mapstructure:"client_key"`
 	SkipHostVerification bool   `mapstructure:"skiphostverification"`
-
-	hosts []*common.HostPortPB
+	TLSPolicy            string `mapstructure:"tls_policy"`
+	RecordRPCs           string `mapstructure:"record_rpcs"`
+	ReplayRPCs           string `mapstructure:"replay_rpcs"`
+	Snapshot             string `mapstructure:"snapshot"`
+	RPCStats             bool   `mapstructure:"rpc_stats"`
+
+	AddressPreference       []string          `mapstructure:"address_preference"`
+	RegionAddressPreference []string          `mapstructure:"region_address_preference"`
+	AddressRewrite          []string          `mapstructure:"address_rewrite"`
+	HostMap                 map[string]string `mapstructure:"host_map"`
+	AddressFallback         bool              `mapstructure:"address_fallback"`
+
+	SSHJumpHost    string `mapstructure:"ssh_jump_host"`
+	SSHKey         string `mapstructure:"ssh_key"`
+	SSHKnownHosts  string `mapstructure:"ssh_known_hosts"`
+	SOCKS5Proxy    string `mapstructure:"socks5_proxy"`
+	SOCKS5User     string `mapstructure:"socks5_user"`
+	SOCKS5Password string `mapstructure:"socks5_password"`
+
+	hosts         []*common.HostPortPB
+	addressPolicy *config.AddressPolicyPB
+	tlsPolicy     *config.TlsPolicyPB
+	snapshot      *snapshot.Snapshot
 }
 
 func (o *GlobalOptions) AddFlags(cmd *cobra.Command) {
@@ -157,42 +277,184 @@ func (o *GlobalOptions) AddFlags(cmd *cobra.Command) {
 	flags.StringVarP(&o.Output, "output", "o", "table", "Output options as one of: [table, json, yaml]")
 	flags.StringVarP(&o.MasterAddresses, "master-addresses", "m", "", "comma-separated list of YB Master server addresses (minimum of one)")
 	flags.Int64Var(&o.DialTimeout, "dial-timeout", 10, "number of seconds for dial timeouts")
+	flags.Int64Var(&o.RPCTimeout, "rpc-timeout", 30, "number of seconds for RPC timeouts")
+	flags.Int64Var(&o.Keepalive, "keepalive", 0, "number of seconds between pings that keep idle connections open and reconnect lost ones (0 disables them)")
 	flags.BoolVar(&o.SkipHostVerification, "skiphostverification", false, "skip tls host verification")
 	flags.StringVarP(&o.CACert, "cacert", "c", "", "the path to the CA certificate")
 	flags.StringVar(&o.ClientCert, "client-cert", "", "the path to the client certificate")
 	flags.StringVar(&o.ClientKey, "client-key", "", "the path to the client key file")
+	flags.StringVar(&o.TLSPolicy, "tls-policy", "", "whether to connect over TLS, as one of: [required, preferred, disabled] (default required when any TLS option is set, otherwise disabled)")
+	flags.StringVar(&o.RecordRPCs, "record-rpcs", "", "record every RPC request and response to this file")
+	flags.StringVar(&o.ReplayRPCs, "replay-rpcs", "", "answer RPCs from a file written by --record-rpcs instead of connecting to the universe")
+	flags.StringVar(&o.Snapshot, "snapshot", "", "answer RPCs from a file written by \"snapshot save\" instead of connecting to the universe")
+	flags.BoolVar(&o.RPCStats, "rpc-stats", false, "print the number of calls, errors and latency of each RPC method once the command finishes")
+	flags.StringSliceVar(&o.AddressPreference, "address-preference", nil, "order in which to try the addresses servers register, from [private, broadcast, public] (default private,broadcast,public)")
+	flags.StringArrayVar(&o.RegionAddressPreference, "region-address-preference", nil, "address preference for servers in a region, as <region>=<type>[,<type>...] (may be repeated)")
+	flags.StringSliceVar(&o.AddressRewrite, "address-rewrite", nil, "rewrite server addresses in one network to the same host in another, as <from-cidr>=<to-cidr>")
+	flags.StringToStringVar(&o.HostMap, "host-map", nil, "replace server addresses, as <host>[:<port>]=<host>[:<port>]")
+	flags.BoolVar(&o.AddressFallback, "address-fallback", true, "try the other addresses of a server when the preferred address cannot be reached")
+	flags.StringVar(&o.SSHJumpHost, "ssh-jump-host", "", "connect to the universe through an ssh jump host, given as [user@]host[:port]")
+	flags.StringVar(&o.SSHKey, "ssh-key", "", "the path to the private key for the ssh jump host (default uses ssh-agent)")
+	flags.StringVar(&o.SSHKnownHosts, "ssh-known-hosts", DefaultKnownHostsFile, "the known_hosts file to verify the ssh jump host with")
+	flags.StringVar(&o.SOCKS5Proxy, "socks5-proxy", "", "connect to the universe through a SOCKS5 proxy, given as host:port")
+	flags.StringVar(&o.SOCKS5User, "socks5-user", "", "the username for the SOCKS5 proxy")
+	flags.StringVar(&o.SOCKS5Password, "socks5-password", "", "the password for the SOCKS5 proxy")
 
 	flag.MarkFlagRequired("master-addresses", flags)
 }
 
 func (o *GlobalOptions) Validate() error {
+	if o.RPCTimeout < 1 {
+		return errors.New("rpc-timeout must be at least 1 second")
+	}
+
+	if o.Keepalive < 0 {
+		return errors.New("keepalive must not be negative")
+	}
+
+	if o.RecordRPCs != "" && o.ReplayRPCs != "" {
+		return errors.New("record-rpcs and replay-rpcs cannot be used together")
+	}
+
+	if o.Snapshot != "" && (o.RecordRPCs != "" || o.ReplayRPCs != "") {
+		return errors.New("snapshot cannot be used with record-rpcs or replay-rpcs")
+	}
+
+	err := o.validateProxy()
+	if err != nil {
+		return err
+	}
+
+	o.tlsPolicy, err = ParseTLSPolicy(o.TLSPolicy)
+	if err != nil {
+		return err
+	}
+
 	hosts, err := ValidateHostnameList(o.MasterAddresses, client.DefaultMasterPort)
 	if err != nil {
 		return err
 	}
 	o.hosts = hosts
-	return nil
+
+	o.addressPolicy, err = o.validateAddressPolicy()
+	return err
+}
+
+func (o *GlobalOptions) validateAddressPolicy() (*config.AddressPolicyPB, error) {
+	policy := &config.AddressPolicyPB{
+		HostMap:  o.HostMap,
+		Fallback: &o.AddressFallback,
+	}
+
+	var err error
+	policy.Preference, err = ParseAddressTypes(o.AddressPreference)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, region := range o.RegionAddressPreference {
+		name, types, ok := strings.Cut(region, "=")
+		if !ok || name == "" {
+			return nil, errors.Errorf("invalid region address preference %q, expected <region>=<type>[,<type>...]", region)
+		}
+		preference, err := ParseAddressTypes(strings.Split(types, ","))
+		if err != nil {
+			return nil, err
+		}
+		policy.Regions = append(policy.Regions, &config.RegionAddressPolicyPB{Region: NewString(name), Preference: preference})
+	}
+
+	for _, rewrite := range o.AddressRewrite {
+		from, to, ok := strings.Cut(rewrite, "=")
+		if !ok {
+			return nil, errors.Errorf("invalid address rewrite %q, expected <from-cidr>=<to-cidr>", rewrite)
+		}
+		policy.Rewrites = append(policy.Rewrites, &config.AddressRewritePB{FromCidr: NewString(from), ToCidr: NewString(to)})
+	}
+
+	_, err = client.NewAddressPolicy(policy)
+	if err != nil {
+		return nil, err
+	}
+	return policy, nil
 }
 
 func (o *GlobalOptions) Hosts() []*common.HostPortPB {
 	return o.hosts
 }
 
+func (o *GlobalOptions) AddressPolicy() *config.AddressPolicyPB {
+	return o.addressPolicy
+}
+
 func ConnectToYugabyte(ctx *YugatoolContext) (*client.YBClient, error) {
+	c, err := NewClient(ctx)
+	if err != nil {
+		return c, err
+	}
+	return c, c.ConnectWithContext(ctx)
+}
+
+// NewClient returns a client for the universe given by the global options,
+// without connecting it.
+func NewClient(ctx *YugatoolContext) (*client.YBClient, error) {
 	c := &client.YBClient{
 		Log: ctx.Log.WithName("client"),
 		Fs:  ctx.Fs,
 		Config: &config.UniverseConfigPB{
-			Masters:        ctx.GlobalOptions.Hosts(),
-			TimeoutSeconds: &ctx.GlobalOptions.DialTimeout,
+			Masters:           ctx.GlobalOptions.Hosts(),
+			TimeoutSeconds:    &ctx.GlobalOptions.DialTimeout,
+			RpcTimeoutSeconds: &ctx.GlobalOptions.RPCTimeout,
+			KeepaliveSeconds:  &ctx.GlobalOptions.Keepalive,
+			AddressPolicy:     ctx.GlobalOptions.AddressPolicy(),
 			TlsOpts: &config.TlsOptionsPB{
 				SkipHostVerification: &ctx.GlobalOptions.SkipHostVerification,
 				CaCertPath:           &ctx.GlobalOptions.CACert,
 				CertPath:             &ctx.GlobalOptions.ClientCert,
 				KeyPath:              &ctx.GlobalOptions.ClientKey,
+				Policy:               ctx.GlobalOptions.tlsPolicy,
 			},
 		},
 	}
 
-	return c, c.Connect()
+	if ctx.Stats == nil {
+		ctx.Stats = message.NewStats()
+	}
+	c.Stats = ctx.Stats
+	c.WrapDialer = ctx.WrapDialer
+
+	if ctx.GlobalOptions.RecordRPCs != "" {
+		f, err := ctx.Fs.OpenFile(ctx.GlobalOptions.RecordRPCs, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+		if err != nil {
+			return c, fmt.Errorf("unable to open RPC recording: %w", err)
+		}
+		recorder := recording.NewRecorder(ctx.Log.WithName("recorder"), f)
+		c.WrapDialer = recorder.Dialer
+	}
+
+	if ctx.GlobalOptions.ReplayRPCs != "" {
+		f, err := ctx.Fs.OpenFile(ctx.GlobalOptions.ReplayRPCs, os.O_RDONLY, 0)
+		if err != nil {
+			return c, fmt.Errorf("unable to open RPC recording: %w", err)
+		}
+		defer f.Close()
+
+		calls, err := recording.Load(f)
+		if err != nil {
+			return c, err
+		}
+		c.OverrideDialer(recording.NewReplayNetwork(ctx.Log.WithName("replay"), calls))
+	} else if ctx.GlobalOptions.snapshot != nil {
+		c.OverrideDialer(ctx.GlobalOptions.snapshot.Network(ctx.Log.WithName("snapshot")))
+	} else if ctx.Dialer != nil {
+		c.OverrideDialer(ctx.Dialer)
+	} else {
+		proxyDialer, err := ctx.GlobalOptions.ProxyDialer(ctx.Fs)
+		if err != nil {
+			return c, err
+		}
+		c.Proxy = proxyDialer
+	}
+
+	return c, nil
 }
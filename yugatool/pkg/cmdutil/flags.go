@@ -9,6 +9,7 @@ import (
 	. "github.com/icza/gox/gox"
 	"github.com/pkg/errors"
 	"github.com/yugabyte/yb-tools/yugatool/api/yb/common"
+	"github.com/yugabyte/yb-tools/yugatool/api/yugatool/config"
 	"github.com/yugabyte/yb-tools/yugatool/pkg/util"
 )
 
@@ -69,3 +70,29 @@ func SplitHostPort(h string, defaultPort int) (string, uint32, error) {
 	}
 	return host, uint32(port), nil
 }
+
+// ParseAddressTypes parses address types given by name, such as "private".
+func ParseAddressTypes(names []string) ([]config.AddressTypePB, error) {
+	var types []config.AddressTypePB
+	for _, name := range names {
+		addressType, ok := config.AddressTypePB_value[strings.ToUpper(strings.TrimSpace(name))]
+		if !ok {
+			return nil, errors.Errorf("invalid address type %q, expected one of [private, broadcast, public]", name)
+		}
+		types = append(types, config.AddressTypePB(addressType))
+	}
+	return types, nil
+}
+
+// ParseTLSPolicy parses a TLS policy given by name, such as "required". An
+// empty name leaves the policy to be derived from the other TLS options.
+func ParseTLSPolicy(name string) (*config.TlsPolicyPB, error) {
+	if name == "" {
+		return nil, nil
+	}
+	policy, ok := config.TlsPolicyPB_value[strings.ToUpper(strings.TrimSpace(name))]
+	if !ok {
+		return nil, errors.Errorf("invalid tls-policy %q, expected one of [required, preferred, disabled]", name)
+	}
+	return config.TlsPolicyPB(policy).Enum(), nil
+}
@@ -6,6 +6,7 @@ import (
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 	"github.com/yugabyte/yb-tools/yugatool/api/yb/common"
+	"github.com/yugabyte/yb-tools/yugatool/api/yugatool/config"
 	"github.com/yugabyte/yb-tools/yugatool/pkg/client"
 	"github.com/yugabyte/yb-tools/yugatool/pkg/cmdutil"
 )
@@ -103,4 +104,29 @@ var _ = Describe("Flags", func() {
 			})
 		})
 	})
+	Context("ParseAddressTypes()", func() {
+		It("parses address types regardless of case", func() {
+			types, err := cmdutil.ParseAddressTypes([]string{"public", " PRIVATE", "Broadcast"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(types).To(Equal([]config.AddressTypePB{config.AddressTypePB_PUBLIC, config.AddressTypePB_PRIVATE, config.AddressTypePB_BROADCAST}))
+		})
+		It("rejects unknown address types", func() {
+			_, err := cmdutil.ParseAddressTypes([]string{"private", "external"})
+			Expect(err).To(MatchError(`invalid address type "external", expected one of [private, broadcast, public]`))
+		})
+	})
+	Context("ParseTLSPolicy()", func() {
+		It("parses policies regardless of case", func() {
+			policy, err := cmdutil.ParseTLSPolicy("Preferred")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(policy).To(Equal(config.TlsPolicyPB_PREFERRED.Enum()))
+		})
+		It("leaves the policy unset without a name", func() {
+			Expect(cmdutil.ParseTLSPolicy("")).To(BeNil())
+		})
+		It("rejects unknown policies", func() {
+			_, err := cmdutil.ParseTLSPolicy("optional")
+			Expect(err).To(MatchError(`invalid tls-policy "optional", expected one of [required, preferred, disabled]`))
+		})
+	})
 })
@@ -0,0 +1,158 @@
+package cmdutil
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/user"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/blang/vfs"
+	homedir "github.com/mitchellh/go-homedir"
+	"github.com/pkg/errors"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client/dial"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+	"golang.org/x/net/proxy"
+)
+
+const (
+	DefaultSSHPort        = 22
+	DefaultKnownHostsFile = "~/.ssh/known_hosts"
+)
+
+func (o *GlobalOptions) validateProxy() error {
+	if o.SSHJumpHost != "" && o.SOCKS5Proxy != "" {
+		return errors.New("ssh-jump-host and socks5-proxy cannot be used together")
+	}
+
+	if o.SSHJumpHost != "" {
+		_, _, _, err := splitJumpHost(o.SSHJumpHost)
+		if err != nil {
+			return err
+		}
+	}
+
+	if o.SOCKS5Proxy != "" {
+		_, _, err := net.SplitHostPort(o.SOCKS5Proxy)
+		if err != nil {
+			return errors.Wrap(err, "invalid socks5-proxy")
+		}
+	}
+	return nil
+}
+
+// ProxyDialer returns the dialer for the jump host or SOCKS5 proxy given in the
+// options, or nil when the universe is reached directly.
+func (o *GlobalOptions) ProxyDialer(fs vfs.Filesystem) (dial.ProxyDialer, error) {
+	switch {
+	case o.SSHJumpHost != "":
+		dialer, err := o.sshDialer(fs)
+		if err != nil {
+			return nil, err
+		}
+		return dialer, nil
+	case o.SOCKS5Proxy != "":
+		dialer := &dial.SOCKS5Dialer{
+			ProxyAddress:   o.SOCKS5Proxy,
+			TimeoutSeconds: o.DialTimeout,
+		}
+		if o.SOCKS5User != "" {
+			dialer.Auth = &proxy.Auth{User: o.SOCKS5User, Password: o.SOCKS5Password}
+		}
+		return dialer, nil
+	}
+	return nil, nil
+}
+
+func (o *GlobalOptions) sshDialer(fs vfs.Filesystem) (*dial.SSHDialer, error) {
+	sshUser, host, port, err := splitJumpHost(o.SSHJumpHost)
+	if err != nil {
+		return nil, err
+	}
+
+	knownHostsFile, err := homedir.Expand(o.SSHKnownHosts)
+	if err != nil {
+		return nil, err
+	}
+	hostKeyCallback, err := knownhosts.New(knownHostsFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read ssh known hosts")
+	}
+
+	auth, agentConn, err := o.sshAuth(fs)
+	if err != nil {
+		return nil, err
+	}
+
+	dialer := dial.NewSSHDialer(net.JoinHostPort(host, strconv.Itoa(int(port))), &ssh.ClientConfig{
+		User:            sshUser,
+		Auth:            []ssh.AuthMethod{auth},
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         time.Duration(o.DialTimeout) * time.Second,
+	})
+	dialer.Agent = agentConn
+	return dialer, nil
+}
+
+// sshAuth authenticates with the ssh key if one is given, and with the keys
+// held by ssh-agent otherwise. The connection to ssh-agent is returned, so it
+// can be closed with the dialer that uses it.
+func (o *GlobalOptions) sshAuth(fs vfs.Filesystem) (ssh.AuthMethod, io.Closer, error) {
+	if o.SSHKey != "" {
+		keyFile, err := homedir.Expand(o.SSHKey)
+		if err != nil {
+			return nil, nil, err
+		}
+		key, err := vfs.ReadFile(fs, keyFile)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "unable to read ssh key")
+		}
+
+		signer, err := ssh.ParsePrivateKey(key)
+		if err != nil {
+			if _, ok := err.(*ssh.PassphraseMissingError); ok {
+				return nil, nil, errors.Errorf("ssh key %s is protected by a passphrase, add it to ssh-agent instead", o.SSHKey)
+			}
+			return nil, nil, errors.Wrapf(err, "unable to parse ssh key %s", o.SSHKey)
+		}
+		return ssh.PublicKeys(signer), nil, nil
+	}
+
+	socket := os.Getenv("SSH_AUTH_SOCK")
+	if socket == "" {
+		return nil, nil, errors.New("ssh-key is not set and ssh-agent is not running")
+	}
+	conn, err := net.Dial("unix", socket)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "unable to connect to ssh-agent")
+	}
+	return ssh.PublicKeysCallback(agent.NewClient(conn).Signers), conn, nil
+}
+
+// splitJumpHost splits a jump host given as [user@]host[:port]. The user
+// defaults to the current user.
+func splitJumpHost(jumpHost string) (string, string, uint32, error) {
+	sshUser := ""
+	if i := strings.LastIndex(jumpHost, "@"); i >= 0 {
+		sshUser, jumpHost = jumpHost[:i], jumpHost[i+1:]
+	}
+
+	host, port, err := SplitHostPort(jumpHost, DefaultSSHPort)
+	if err != nil {
+		return "", "", 0, fmt.Errorf("invalid ssh-jump-host: %w", err)
+	}
+
+	if sshUser == "" {
+		current, err := user.Current()
+		if err != nil {
+			return "", "", 0, fmt.Errorf("unable to find the ssh user: %w", err)
+		}
+		sshUser = current.Username
+	}
+	return sshUser, host, port, nil
+}
@@ -0,0 +1,158 @@
+package cmdutil_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+
+	"github.com/blang/vfs"
+	"github.com/blang/vfs/memfs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client/dial"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/cmdutil"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+	"golang.org/x/net/proxy"
+)
+
+var _ = Describe("Proxy options", func() {
+	var (
+		options *cmdutil.GlobalOptions
+		fs      vfs.Filesystem
+	)
+
+	BeforeEach(func() {
+		options = &cmdutil.GlobalOptions{
+			MasterAddresses: "master-1,master-2,master-3",
+			RPCTimeout:      30,
+			DialTimeout:     10,
+		}
+		fs = memfs.Create()
+	})
+
+	It("connects directly by default", func() {
+		Expect(options.Validate()).To(Succeed())
+
+		dialer, err := options.ProxyDialer(fs)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(dialer).To(BeNil())
+	})
+
+	It("rejects both a jump host and a SOCKS5 proxy", func() {
+		options.SSHJumpHost = "bastion"
+		options.SOCKS5Proxy = "localhost:1080"
+		Expect(options.Validate()).To(MatchError("ssh-jump-host and socks5-proxy cannot be used together"))
+	})
+
+	It("rejects a SOCKS5 proxy without a port", func() {
+		options.SOCKS5Proxy = "localhost"
+		Expect(options.Validate()).To(MatchError(ContainSubstring("invalid socks5-proxy")))
+	})
+
+	It("connects through a SOCKS5 proxy", func() {
+		options.SOCKS5Proxy = "localhost:1080"
+		options.SOCKS5User = "yugabyte"
+		options.SOCKS5Password = "secret"
+		Expect(options.Validate()).To(Succeed())
+
+		dialer, err := options.ProxyDialer(fs)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(dialer).To(Equal(&dial.SOCKS5Dialer{
+			ProxyAddress:   "localhost:1080",
+			Auth:           &proxy.Auth{User: "yugabyte", Password: "secret"},
+			TimeoutSeconds: 10,
+		}))
+	})
+
+	Context("with an ssh jump host", func() {
+		var (
+			dir        string
+			knownHosts string
+		)
+
+		BeforeEach(func() {
+			_, key, err := ed25519.GenerateKey(rand.Reader)
+			Expect(err).NotTo(HaveOccurred())
+			der, err := x509.MarshalPKCS8PrivateKey(key)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vfs.WriteFile(fs, "/id_ed25519", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600)).To(Succeed())
+
+			hostKey, err := ssh.NewPublicKey(key.Public())
+			Expect(err).NotTo(HaveOccurred())
+			dir, err = os.MkdirTemp("", "known_hosts")
+			Expect(err).NotTo(HaveOccurred())
+			knownHosts = filepath.Join(dir, "known_hosts")
+			Expect(os.WriteFile(knownHosts, []byte(knownhosts.Line([]string{"[bastion]:2222"}, hostKey)+"\n"), 0600)).To(Succeed())
+
+			options.SSHJumpHost = "admin@bastion:2222"
+			options.SSHKey = "/id_ed25519"
+			options.SSHKnownHosts = knownHosts
+		})
+
+		AfterEach(func() {
+			_ = os.RemoveAll(dir)
+		})
+
+		It("connects through the jump host", func() {
+			Expect(options.Validate()).To(Succeed())
+
+			dialer, err := options.ProxyDialer(fs)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(dialer).To(BeAssignableToTypeOf(&dial.SSHDialer{}))
+
+			sshDialer := dialer.(*dial.SSHDialer)
+			Expect(sshDialer.JumpHost).To(Equal("bastion:2222"))
+			Expect(sshDialer.ClientConfig.User).To(Equal("admin"))
+		})
+
+		It("closes the connection to ssh-agent with the dialer", func() {
+			socket := filepath.Join(dir, "agent.sock")
+			listener, err := net.Listen("unix", socket)
+			Expect(err).NotTo(HaveOccurred())
+			defer listener.Close()
+
+			oldSocket, set := os.LookupEnv("SSH_AUTH_SOCK")
+			Expect(os.Setenv("SSH_AUTH_SOCK", socket)).To(Succeed())
+			defer func() {
+				if set {
+					_ = os.Setenv("SSH_AUTH_SOCK", oldSocket)
+				} else {
+					_ = os.Unsetenv("SSH_AUTH_SOCK")
+				}
+			}()
+
+			options.SSHKey = ""
+			dialer, err := options.ProxyDialer(fs)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(dialer.(*dial.SSHDialer).Agent).NotTo(BeNil())
+
+			agentConn, err := listener.Accept()
+			Expect(err).NotTo(HaveOccurred())
+			defer agentConn.Close()
+
+			Expect(dialer.Close()).To(Succeed())
+			_, err = agentConn.Read(make([]byte, 1))
+			Expect(err).To(Equal(io.EOF))
+		})
+
+		It("fails without the known hosts file", func() {
+			options.SSHKnownHosts = knownHosts + ".missing"
+
+			_, err := options.ProxyDialer(fs)
+			Expect(err).To(MatchError(ContainSubstring("unable to read ssh known hosts")))
+		})
+
+		It("fails with an unreadable key", func() {
+			Expect(vfs.WriteFile(fs, "/id_ed25519", []byte("not a key"), 0600)).To(Succeed())
+
+			_, err := options.ProxyDialer(fs)
+			Expect(err).To(MatchError(ContainSubstring("unable to parse ssh key /id_ed25519")))
+		})
+	})
+})
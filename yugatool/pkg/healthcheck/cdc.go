@@ -1,6 +1,7 @@
 package healthcheck
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"reflect"
@@ -17,8 +18,8 @@ import (
 	"github.com/yugabyte/yb-tools/yugatool/api/yugatool/config"
 	"github.com/yugabyte/yb-tools/yugatool/api/yugatool/healthcheck"
 	"github.com/yugabyte/yb-tools/yugatool/pkg/client"
+	yberrors "github.com/yugabyte/yb-tools/yugatool/pkg/client/errors"
 	"google.golang.org/protobuf/encoding/protojson"
-	"google.golang.org/protobuf/encoding/prototext"
 )
 
 type CDCProducerReport struct {
@@ -45,7 +46,7 @@ func NewCDCProducerReport(log logr.Logger, consumerClient *client.YBClient, cons
 	}
 }
 
-func (r *CDCProducerReport) RunCheck() error {
+func (r *CDCProducerReport) RunCheck(ctx context.Context) error {
 	reportErrors := &healthcheck.CDCProducerReportPBErrorlist{}
 	errorSet := false
 	if r.GetProducerId() == r.ConsumerUUID {
@@ -61,7 +62,14 @@ func (r *CDCProducerReport) RunCheck() error {
 		Config: r.Config,
 	}
 
-	err := producerClient.Connect()
+	// The producer is reached the same way as the consumer
+	dialer, err := r.ConsumerClient.GetDialer()
+	if err != nil {
+		return err
+	}
+	producerClient.OverrideDialer(dialer)
+
+	err = producerClient.ConnectWithContext(ctx)
 	if err != nil {
 		return err
 	}
@@ -69,12 +77,12 @@ func (r *CDCProducerReport) RunCheck() error {
 	defer producerClient.Close()
 
 	for streamID, streamEntry := range r.Producer.GetStreamMap() {
-		streamReport, err := NewCDCProducerStreamReport(r.Log, r.ConsumerClient, producerClient, streamID, streamEntry)
+		streamReport, err := NewCDCProducerStreamReport(ctx, r.Log, r.ConsumerClient, producerClient, streamID, streamEntry)
 		if err != nil {
 			return err
 		}
 
-		err = streamReport.RunCheck()
+		err = streamReport.RunCheck(ctx)
 		if err != nil {
 			return err
 		}
@@ -101,8 +109,8 @@ type CDCProducerStreamReport struct {
 	ProducerSchema *master.GetTableSchemaResponsePB
 }
 
-func NewCDCProducerStreamReport(log logr.Logger, consumerClient *client.YBClient, producerClient *client.YBClient, streamID string, streamEntry *cdc.StreamEntryPB) (*CDCProducerStreamReport, error) {
-	consumerTableSchema, err := consumerClient.Master.MasterService.GetTableSchema(&master.GetTableSchemaRequestPB{
+func NewCDCProducerStreamReport(ctx context.Context, log logr.Logger, consumerClient *client.YBClient, producerClient *client.YBClient, streamID string, streamEntry *cdc.StreamEntryPB) (*CDCProducerStreamReport, error) {
+	consumerTableSchema, err := consumerClient.Master.MasterService.GetTableSchemaWithContext(ctx, &master.GetTableSchemaRequestPB{
 		Table: &master.TableIdentifierPB{
 			TableId: []byte(streamEntry.ConsumerTableId),
 		},
@@ -111,7 +119,7 @@ func NewCDCProducerStreamReport(log logr.Logger, consumerClient *client.YBClient
 		return nil, err
 	}
 
-	producerTableSchema, err := producerClient.Master.MasterService.GetTableSchema(&master.GetTableSchemaRequestPB{
+	producerTableSchema, err := producerClient.Master.MasterService.GetTableSchemaWithContext(ctx, &master.GetTableSchemaRequestPB{
 		Table: &master.TableIdentifierPB{
 			TableId: []byte(streamEntry.ProducerTableId),
 		},
@@ -147,7 +155,7 @@ func NewCDCProducerStreamReport(log logr.Logger, consumerClient *client.YBClient
 	return streamReport, nil
 }
 
-func (r *CDCProducerStreamReport) RunCheck() error {
+func (r *CDCProducerStreamReport) RunCheck(ctx context.Context) error {
 	reportErrors := &healthcheck.CDCProducerStreamReportPBErrorlist{}
 	errorSet := false
 	var consumerTablets, producerTablets []string
@@ -161,7 +169,7 @@ func (r *CDCProducerStreamReport) RunCheck() error {
 	r.ProducerTabletCount = NewUint32(uint32(len(producerTablets)))
 
 	// Get replication lag
-	replicatedIndexes, err := GetReplicatedIndexes(r.Log, r.ProducerClient, r.GetStreamId(), producerTablets...)
+	replicatedIndexes, err := GetReplicatedIndexes(ctx, r.Log, r.ProducerClient, HostCDCService(r.ProducerClient), r.GetStreamId(), producerTablets...)
 	if err != nil {
 		return err
 	}
@@ -189,7 +197,7 @@ func (r *CDCProducerStreamReport) RunCheck() error {
 		errorSet = true
 	}
 	// Check consumer tablets exist
-	missingConsumerTablets, err := GetMissingTablets(r.ConsumerClient, consumerTablets)
+	missingConsumerTablets, err := GetMissingTablets(ctx, r.ConsumerClient, consumerTablets)
 	if err != nil {
 		return err
 	}
@@ -200,7 +208,7 @@ func (r *CDCProducerStreamReport) RunCheck() error {
 	}
 
 	// Check producer tablets exist
-	missingProducerTablets, err := GetMissingTablets(r.ProducerClient, producerTablets)
+	missingProducerTablets, err := GetMissingTablets(ctx, r.ProducerClient, producerTablets)
 	if err != nil {
 		return err
 	}
@@ -260,36 +268,54 @@ func GetSchemaMismatchErrors(consumerSchema *master.GetTableSchemaResponsePB, pr
 	return nil
 }
 
-func GetMissingTablets(client *client.YBClient, tablets []string) ([]string, error) {
+func GetMissingTablets(ctx context.Context, client *client.YBClient, tablets []string) ([]string, error) {
 	var missingTablets []string
 	var tabletBytes [][]byte
 
 	for _, tablet := range tablets {
 		tabletBytes = append(tabletBytes, []byte(tablet))
 	}
-	response, err := client.Master.MasterService.GetTabletLocations(&master.GetTabletLocationsRequestPB{
+	response, err := client.Master.MasterService.GetTabletLocationsWithContext(ctx, &master.GetTabletLocationsRequestPB{
 		TabletIds: tabletBytes,
 	})
 	if err != nil {
 		return missingTablets, err
 	}
 
-	if response.Error != nil {
-		return missingTablets, errors.Errorf("master error: %s", prototext.Format(response.Error))
+	if err := yberrors.FromResponse(response); err != nil {
+		return missingTablets, err
 	}
 
 	for _, tabletError := range response.Errors {
-		if tabletError.Status.GetCode() == common.AppStatusPB_NOT_FOUND {
+		if err := yberrors.FromStatus(tabletError.GetStatus()); errors.Is(err, yberrors.ErrNotFound) {
 			missingTablets = append(missingTablets, string(tabletError.TabletId))
 		} else {
-			return missingTablets, errors.Errorf("unexpected error message: %s", tabletError)
+			return missingTablets, errors.Errorf("unexpected error for tablet %s: %v", tabletError.TabletId, err)
 		}
 	}
 
 	return missingTablets, nil
 }
 
-func GetReplicatedIndexes(log logr.Logger, client *client.YBClient, streamID string, tablets ...string) (*healthcheck.CDCReplicatedIndexListPB, error) {
+// CDCServiceLookup returns the CDCService of the tablet server with the UUID.
+type CDCServiceLookup func(ctx context.Context, uuid []byte) (cdc.CDCService, error)
+
+// HostCDCService looks up the CDCService of a tablet server through the client.
+func HostCDCService(c *client.YBClient) CDCServiceLookup {
+	return func(ctx context.Context, uuid []byte) (cdc.CDCService, error) {
+		host, err := c.GetHostByUUIDWithContext(ctx, uuid)
+		if err != nil {
+			return nil, err
+		}
+		return host.CDCService, nil
+	}
+}
+
+// GetReplicatedIndexes returns the checkpoint of the stream and the latest entry
+// of each tablet, as the leader of the tablet knows them. The CDCService of the
+// leader is found with cdcService. A tablet whose leader has no checkpoint for
+// the stream is left out.
+func GetReplicatedIndexes(ctx context.Context, log logr.Logger, client *client.YBClient, cdcService CDCServiceLookup, streamID string, tablets ...string) (*healthcheck.CDCReplicatedIndexListPB, error) {
 	replicatedIndexes := &healthcheck.CDCReplicatedIndexListPB{
 		ReplicatedIndexList: []*healthcheck.CDCReplicatedIndexPB{},
 	}
@@ -299,22 +325,25 @@ func GetReplicatedIndexes(log logr.Logger, client *client.YBClient, streamID str
 		tabletBytes = append(tabletBytes, []byte(tablet))
 	}
 
-	response, err := client.Master.MasterService.GetTabletLocations(&master.GetTabletLocationsRequestPB{
+	response, err := client.Master.MasterService.GetTabletLocationsWithContext(ctx, &master.GetTabletLocationsRequestPB{
 		TabletIds: tabletBytes,
 	})
 	if err != nil {
 		return replicatedIndexes, err
 	}
+	if err := yberrors.FromResponse(response); err != nil {
+		return replicatedIndexes, err
+	}
 	for _, tablet := range response.TabletLocations {
 		if len(tablet.Replicas) > 0 {
 			for _, replica := range tablet.Replicas {
 				if replica.GetRole() == common.RaftPeerPB_LEADER {
-					host, err := client.GetHostByUUID(replica.GetTsInfo().GetPermanentUuid())
+					service, err := cdcService(ctx, replica.GetTsInfo().GetPermanentUuid())
 					if err != nil {
 						return replicatedIndexes, err
 					}
 
-					checkpoint, err := host.CDCService.GetCheckpoint(&cdc.GetCheckpointRequestPB{
+					checkpoint, err := service.GetCheckpointWithContext(ctx, &cdc.GetCheckpointRequestPB{
 						StreamId: []byte(streamID),
 						TabletId: tablet.TabletId,
 					})
@@ -323,7 +352,7 @@ func GetReplicatedIndexes(log logr.Logger, client *client.YBClient, streamID str
 					}
 					// The checkpoint location of the stream will only show up on the producer
 					if checkpoint.Error == nil {
-						latestOpID, err := host.CDCService.GetLatestEntryOpId(&cdc.GetLatestEntryOpIdRequestPB{TabletId: tablet.TabletId})
+						latestOpID, err := service.GetLatestEntryOpIdWithContext(ctx, &cdc.GetLatestEntryOpIdRequestPB{TabletId: tablet.TabletId})
 						if err != nil {
 							return replicatedIndexes, err
 						}
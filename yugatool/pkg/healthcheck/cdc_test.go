@@ -0,0 +1,306 @@
+package healthcheck_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-logr/logr"
+	. "github.com/icza/gox/gox"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/cdc"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/common"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/master"
+	ybutil "github.com/yugabyte/yb-tools/yugatool/api/yb/util"
+	healthcheckpb "github.com/yugabyte/yb-tools/yugatool/api/yugatool/healthcheck"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/healthcheck"
+)
+
+// mockClient returns a client whose master leader is the mock
+func mockClient(masterService *master.MockMasterService) *client.YBClient {
+	return &client.YBClient{
+		Log:    logr.Discard(),
+		Master: &client.HostState{MasterService: masterService},
+	}
+}
+
+func tabletLocations(response *master.GetTabletLocationsResponsePB, err error) func(context.Context, *master.GetTabletLocationsRequestPB) (*master.GetTabletLocationsResponsePB, error) {
+	return func(context.Context, *master.GetTabletLocationsRequestPB) (*master.GetTabletLocationsResponsePB, error) {
+		return response, err
+	}
+}
+
+func tabletError(tabletID string, code common.AppStatusPB_ErrorCode) *master.GetTabletLocationsResponsePB_Error {
+	return &master.GetTabletLocationsResponsePB_Error{
+		TabletId: []byte(tabletID),
+		Status:   &common.AppStatusPB{Code: code.Enum()},
+	}
+}
+
+func schema(columns ...string) *common.SchemaPB {
+	schema := &common.SchemaPB{}
+	for i, column := range columns {
+		schema.Columns = append(schema.Columns, &common.ColumnSchemaPB{Id: NewUint32(uint32(i)), Name: NewString(column)})
+	}
+	return schema
+}
+
+func opID(term, index int64) *ybutil.OpIdPB {
+	return &ybutil.OpIdPB{Term: NewInt64(term), Index: NewInt64(index)}
+}
+
+var _ = Describe("CDC", func() {
+	Context("GetMissingTablets()", func() {
+		DescribeTable("finds the tablets the master does not know",
+			func(response *master.GetTabletLocationsResponsePB, err error, expectedMissing []string, expectedError string) {
+				masterService := &master.MockMasterService{GetTabletLocationsFunc: tabletLocations(response, err)}
+
+				missing, err := healthcheck.GetMissingTablets(context.Background(), mockClient(masterService), []string{"tablet-1", "tablet-2"})
+				if expectedError != "" {
+					Expect(err).To(MatchError(ContainSubstring(expectedError)))
+				} else {
+					Expect(err).NotTo(HaveOccurred())
+				}
+				Expect(missing).To(Equal(expectedMissing))
+
+				calls := masterService.CallsTo("GetTabletLocations")
+				Expect(calls).To(HaveLen(1))
+				Expect(calls[0].Request.(*master.GetTabletLocationsRequestPB).TabletIds).To(Equal([][]byte{[]byte("tablet-1"), []byte("tablet-2")}))
+			},
+			Entry("when every tablet exists", &master.GetTabletLocationsResponsePB{}, nil, nil, ""),
+			Entry("when a tablet is not found", &master.GetTabletLocationsResponsePB{
+				Errors: []*master.GetTabletLocationsResponsePB_Error{tabletError("tablet-2", common.AppStatusPB_NOT_FOUND)},
+			}, nil, []string{"tablet-2"}, ""),
+			Entry("when a tablet has another error", &master.GetTabletLocationsResponsePB{
+				Errors: []*master.GetTabletLocationsResponsePB_Error{tabletError("tablet-1", common.AppStatusPB_TIMED_OUT)},
+			}, nil, nil, "unexpected error for tablet tablet-1"),
+			Entry("when the master returns an error", &master.GetTabletLocationsResponsePB{
+				Error: &master.MasterErrorPB{
+					Code:   master.MasterErrorPB_NOT_THE_LEADER.Enum(),
+					Status: &common.AppStatusPB{Code: common.AppStatusPB_ILLEGAL_STATE.Enum(), Message: NewString("not the leader")},
+				},
+			}, nil, nil, "not the leader"),
+			Entry("when the call fails", nil, errors.New("connection refused"), nil, "connection refused"),
+		)
+	})
+
+	Context("GetReplicatedIndexes()", func() {
+		leaderLocations := &master.GetTabletLocationsResponsePB{
+			TabletLocations: []*master.TabletLocationsPB{{
+				TabletId: []byte("tablet-1"),
+				Replicas: []*master.TabletLocationsPB_ReplicaPB{
+					{TsInfo: &master.TSInfoPB{PermanentUuid: []byte("ts-1")}, Role: common.RaftPeerPB_FOLLOWER.Enum()},
+					{TsInfo: &master.TSInfoPB{PermanentUuid: []byte("ts-2")}, Role: common.RaftPeerPB_LEADER.Enum()},
+				},
+			}},
+		}
+
+		checkpointAt := func(checkpoint *ybutil.OpIdPB) func(context.Context, *cdc.GetCheckpointRequestPB) (*cdc.GetCheckpointResponsePB, error) {
+			return func(context.Context, *cdc.GetCheckpointRequestPB) (*cdc.GetCheckpointResponsePB, error) {
+				return &cdc.GetCheckpointResponsePB{Checkpoint: &cdc.CDCCheckpointPB{OpId: checkpoint}}, nil
+			}
+		}
+		latestAt := func(latest *ybutil.OpIdPB) func(context.Context, *cdc.GetLatestEntryOpIdRequestPB) (*cdc.GetLatestEntryOpIdResponsePB, error) {
+			return func(context.Context, *cdc.GetLatestEntryOpIdRequestPB) (*cdc.GetLatestEntryOpIdResponsePB, error) {
+				return &cdc.GetLatestEntryOpIdResponsePB{OpId: latest}, nil
+			}
+		}
+
+		DescribeTable("reads the checkpoint and latest entry from the tablet leader",
+			func(cdcService *cdc.MockCDCService, expectedIndexes []*healthcheckpb.CDCReplicatedIndexPB, expectedLag int, expectedError string) {
+				masterService := &master.MockMasterService{GetTabletLocationsFunc: tabletLocations(leaderLocations, nil)}
+
+				var lookedUp []string
+				lookup := func(_ context.Context, uuid []byte) (cdc.CDCService, error) {
+					lookedUp = append(lookedUp, string(uuid))
+					return cdcService, nil
+				}
+
+				indexes, err := healthcheck.GetReplicatedIndexes(context.Background(), logr.Discard(), mockClient(masterService), lookup, "stream-1", "tablet-1")
+				if expectedError != "" {
+					Expect(err).To(MatchError(ContainSubstring(expectedError)))
+				} else {
+					Expect(err).NotTo(HaveOccurred())
+				}
+				Expect(lookedUp).To(Equal([]string{"ts-2"}))
+				Expect(indexes.GetReplicatedIndexList()).To(Equal(expectedIndexes))
+				Expect(healthcheck.GetReplicationLag(indexes).GetReplicatedIndexList()).To(HaveLen(expectedLag))
+
+				calls := cdcService.CallsTo("GetCheckpoint")
+				Expect(calls).To(HaveLen(1))
+				request := calls[0].Request.(*cdc.GetCheckpointRequestPB)
+				Expect(request.GetStreamId()).To(Equal([]byte("stream-1")))
+				Expect(request.GetTabletId()).To(Equal([]byte("tablet-1")))
+			},
+			Entry("when the checkpoint is caught up", &cdc.MockCDCService{
+				GetCheckpointFunc:      checkpointAt(opID(2, 10)),
+				GetLatestEntryOpIdFunc: latestAt(opID(2, 10)),
+			}, []*healthcheckpb.CDCReplicatedIndexPB{{
+				Tablet:             NewString("tablet-1"),
+				LatestOpid:         opID(2, 10),
+				CheckpointLocation: opID(2, 10),
+			}}, 0, ""),
+			Entry("when the checkpoint lags the latest entry", &cdc.MockCDCService{
+				GetCheckpointFunc:      checkpointAt(opID(2, 10)),
+				GetLatestEntryOpIdFunc: latestAt(opID(2, 15)),
+			}, []*healthcheckpb.CDCReplicatedIndexPB{{
+				Tablet:             NewString("tablet-1"),
+				LatestOpid:         opID(2, 15),
+				CheckpointLocation: opID(2, 10),
+			}}, 1, ""),
+			Entry("when the leader has no checkpoint for the stream", &cdc.MockCDCService{
+				GetCheckpointFunc: func(context.Context, *cdc.GetCheckpointRequestPB) (*cdc.GetCheckpointResponsePB, error) {
+					return &cdc.GetCheckpointResponsePB{Error: &cdc.CDCErrorPB{
+						Code:   cdc.CDCErrorPB_TABLET_NOT_FOUND.Enum(),
+						Status: &common.AppStatusPB{Code: common.AppStatusPB_NOT_FOUND.Enum()},
+					}}, nil
+				},
+			}, []*healthcheckpb.CDCReplicatedIndexPB{}, 0, ""),
+			Entry("when the checkpoint call fails", &cdc.MockCDCService{
+				GetCheckpointFunc: func(context.Context, *cdc.GetCheckpointRequestPB) (*cdc.GetCheckpointResponsePB, error) {
+					return nil, errors.New("connection refused")
+				},
+			}, []*healthcheckpb.CDCReplicatedIndexPB{}, 0, "connection refused"),
+			Entry("when the latest entry call fails", &cdc.MockCDCService{
+				GetCheckpointFunc: checkpointAt(opID(2, 10)),
+				GetLatestEntryOpIdFunc: func(context.Context, *cdc.GetLatestEntryOpIdRequestPB) (*cdc.GetLatestEntryOpIdResponsePB, error) {
+					return nil, errors.New("connection reset")
+				},
+			}, []*healthcheckpb.CDCReplicatedIndexPB{}, 0, "connection reset"),
+		)
+
+		It("fails when the leader cannot be looked up", func() {
+			masterService := &master.MockMasterService{GetTabletLocationsFunc: tabletLocations(leaderLocations, nil)}
+			lookup := func(context.Context, []byte) (cdc.CDCService, error) {
+				return nil, errors.New("no such tablet server")
+			}
+
+			_, err := healthcheck.GetReplicatedIndexes(context.Background(), logr.Discard(), mockClient(masterService), lookup, "stream-1", "tablet-1")
+			Expect(err).To(MatchError("no such tablet server"))
+		})
+	})
+
+	Context("GetReplicationLag()", func() {
+		DescribeTable("reports the tablets behind their latest entry",
+			func(latest, checkpoint *ybutil.OpIdPB, expectedLag bool) {
+				index := &healthcheckpb.CDCReplicatedIndexPB{
+					Tablet:             NewString("tablet-1"),
+					LatestOpid:         latest,
+					CheckpointLocation: checkpoint,
+				}
+
+				lag := healthcheck.GetReplicationLag(&healthcheckpb.CDCReplicatedIndexListPB{
+					ReplicatedIndexList: []*healthcheckpb.CDCReplicatedIndexPB{index},
+				})
+				if expectedLag {
+					Expect(lag.GetReplicatedIndexList()).To(ConsistOf(index))
+				} else {
+					Expect(lag.GetReplicatedIndexList()).To(BeEmpty())
+				}
+			},
+			Entry("when the checkpoint is caught up", opID(2, 10), opID(2, 10), false),
+			Entry("when the checkpoint is behind in index", opID(2, 11), opID(2, 10), true),
+			Entry("when the checkpoint is behind in term", opID(3, 1), opID(2, 10), true),
+		)
+	})
+
+	Context("GetSchemaMismatchErrors()", func() {
+		DescribeTable("compares the consumer and producer schemas",
+			func(consumer, producer *master.GetTableSchemaResponsePB, expectedMismatch bool) {
+				mismatch := healthcheck.GetSchemaMismatchErrors(consumer, producer)
+				if expectedMismatch {
+					Expect(mismatch.GetCode()).To(Equal(master.MasterErrorPB_INVALID_SCHEMA))
+				} else {
+					Expect(mismatch).To(BeNil())
+				}
+			},
+			Entry("when the schemas match",
+				&master.GetTableSchemaResponsePB{Schema: schema("k", "v")},
+				&master.GetTableSchemaResponsePB{Schema: schema("k", "v")}, false),
+			Entry("when the schemas differ",
+				&master.GetTableSchemaResponsePB{Schema: schema("k", "v")},
+				&master.GetTableSchemaResponsePB{Schema: schema("k", "v", "w")}, true),
+			Entry("when a schema could not be read",
+				&master.GetTableSchemaResponsePB{Schema: schema("k", "v")},
+				&master.GetTableSchemaResponsePB{Error: &master.MasterErrorPB{Code: master.MasterErrorPB_OBJECT_NOT_FOUND.Enum()}}, false),
+		)
+	})
+
+	Context("CDCProducerStreamReport", func() {
+		var (
+			consumerMaster *master.MockMasterService
+			producerMaster *master.MockMasterService
+			streamEntry    *cdc.StreamEntryPB
+		)
+
+		BeforeEach(func() {
+			consumerMaster = &master.MockMasterService{
+				GetTableSchemaFunc: func(context.Context, *master.GetTableSchemaRequestPB) (*master.GetTableSchemaResponsePB, error) {
+					return &master.GetTableSchemaResponsePB{Schema: schema("k", "v")}, nil
+				},
+				GetTabletLocationsFunc: tabletLocations(&master.GetTabletLocationsResponsePB{}, nil),
+			}
+			producerMaster = &master.MockMasterService{
+				GetTableSchemaFunc: func(context.Context, *master.GetTableSchemaRequestPB) (*master.GetTableSchemaResponsePB, error) {
+					return &master.GetTableSchemaResponsePB{Schema: schema("k", "v")}, nil
+				},
+				GetTabletLocationsFunc: tabletLocations(&master.GetTabletLocationsResponsePB{}, nil),
+			}
+			streamEntry = &cdc.StreamEntryPB{
+				ConsumerTableId: "consumer-table",
+				ProducerTableId: "producer-table",
+				ConsumerProducerTabletMap: map[string]*cdc.ProducerTabletListPB{
+					"consumer-tablet": {Tablets: []string{"producer-tablet"}},
+				},
+			}
+		})
+
+		runCheck := func() *healthcheck.CDCProducerStreamReport {
+			report, err := healthcheck.NewCDCProducerStreamReport(context.Background(), logr.Discard(), mockClient(consumerMaster), mockClient(producerMaster), "stream-1", streamEntry)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report.RunCheck(context.Background())).To(Succeed())
+			return report
+		}
+
+		It("reports no errors for a healthy stream", func() {
+			report := runCheck()
+			Expect(report.Errors).To(BeNil())
+			Expect(report.GetConsumerTabletCount()).To(BeEquivalentTo(1))
+			Expect(report.GetProducerTabletCount()).To(BeEquivalentTo(1))
+
+			calls := consumerMaster.CallsTo("GetTableSchema")
+			Expect(calls).To(HaveLen(1))
+			Expect(calls[0].Request.(*master.GetTableSchemaRequestPB).GetTable().GetTableId()).To(Equal([]byte("consumer-table")))
+		})
+
+		It("reports tablets missing on the producer", func() {
+			producerMaster.GetTabletLocationsFunc = tabletLocations(&master.GetTabletLocationsResponsePB{
+				Errors: []*master.GetTabletLocationsResponsePB_Error{tabletError("producer-tablet", common.AppStatusPB_NOT_FOUND)},
+			}, nil)
+
+			report := runCheck()
+			Expect(report.Errors.GetMissingTabletsProducer()).To(ConsistOf("producer-tablet"))
+			Expect(report.Errors.GetMissingTabletsConsumer()).To(BeEmpty())
+		})
+
+		It("reports a table missing on the consumer", func() {
+			consumerMaster.GetTableSchemaFunc = func(context.Context, *master.GetTableSchemaRequestPB) (*master.GetTableSchemaResponsePB, error) {
+				return &master.GetTableSchemaResponsePB{Error: &master.MasterErrorPB{Code: master.MasterErrorPB_OBJECT_NOT_FOUND.Enum()}}, nil
+			}
+
+			report := runCheck()
+			Expect(report.Errors.GetConsumerSchemaError().GetCode()).To(Equal(master.MasterErrorPB_OBJECT_NOT_FOUND))
+			Expect(report.Errors.GetSchemaMismatchError()).To(BeNil())
+		})
+
+		It("fails when a master method is not stubbed", func() {
+			producerMaster.GetTableSchemaFunc = nil
+
+			_, err := healthcheck.NewCDCProducerStreamReport(context.Background(), logr.Discard(), mockClient(consumerMaster), mockClient(producerMaster), "stream-1", streamEntry)
+			Expect(err).To(HaveOccurred())
+			Expect(producerMaster.CallsTo("GetTableSchema")).To(HaveLen(1))
+		})
+	})
+})
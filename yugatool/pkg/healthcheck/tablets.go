@@ -0,0 +1,358 @@
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/icza/gox/gox"
+	"github.com/pkg/errors"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/common"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/consensus"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/master"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/tserver"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client"
+	yberrors "github.com/yugabyte/yb-tools/yugatool/pkg/client/errors"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/util"
+	"golang.org/x/sync/errgroup"
+)
+
+// Problems found with tablets
+const (
+	ProblemUnderReplicated   = "under-replicated"
+	ProblemOverReplicated    = "over-replicated"
+	ProblemNoLeader          = "no leader"
+	ProblemDeadTabletServer  = "dead tserver"
+	ProblemUnreachable       = "unreachable tserver"
+	ProblemMissingReplica    = "missing replica"
+	ProblemConfigMismatch    = "config mismatch"
+	ProblemReplicaNotRunning = "replica not running"
+	ProblemUnknownRF         = "unknown replication factor"
+)
+
+// TabletProblem is a problem with a tablet, or with one of its replicas.
+type TabletProblem struct {
+	TableID      string `json:"table_id"`
+	Table        string `json:"table"`
+	Tablet       string `json:"tablet"`
+	TabletServer string `json:"tserver,omitempty"`
+	Problem      string `json:"problem"`
+	Detail       string `json:"detail"`
+}
+
+type TabletCheckOptions struct {
+	// NamespaceFilter and TableFilter limit the check to matching tables
+	NamespaceFilter string
+	TableFilter     string
+	// HeartbeatTimeout is how long since its last heartbeat a tablet server is
+	// considered dead, even if the master has not yet marked it so
+	HeartbeatTimeout time.Duration
+	// Concurrency is the maximum number of concurrent requests to each tablet server
+	Concurrency int
+}
+
+// tabletReplica is a replica of a tablet as the master places it
+type tabletReplica struct {
+	table    *master.ListTablesResponsePB_TableInfo
+	location *master.TabletLocationsPB
+	uuid     string
+}
+
+// CheckTablets compares the replicas of each tablet, as the master places them,
+// with the replication factor of its table, the tablet servers that are alive,
+// and the state and committed Raft config each replica reports.
+func CheckTablets(ctx context.Context, log logr.Logger, c *client.YBClient, options TabletCheckOptions) ([]TabletProblem, error) {
+	clusterConfig, err := c.GetClusterConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	clusterReplicationFactor, err := c.ReplicationFactor(ctx, clusterConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get the replication factor")
+	}
+
+	tabletServers, err := c.Master.MasterService.ListTabletServersWithContext(ctx, &master.ListTabletServersRequestPB{})
+	if err != nil {
+		return nil, err
+	}
+	if err := yberrors.FromResponse(tabletServers); err != nil {
+		return nil, errors.Wrap(err, "could not list tablet servers")
+	}
+	servers := make(map[string]*master.ListTabletServersResponsePB_Entry)
+	for _, ts := range tabletServers.GetServers() {
+		servers[string(ts.GetInstanceId().GetPermanentUuid())] = ts
+	}
+
+	request := &master.ListTablesRequestPB{NameFilter: NewString(options.TableFilter)}
+	if options.NamespaceFilter != "" {
+		request.Namespace = &master.NamespaceIdentifierPB{Name: NewString(options.NamespaceFilter)}
+	}
+	tables, err := c.Master.MasterService.ListTablesWithContext(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	if err := yberrors.FromResponse(tables); err != nil {
+		return nil, errors.Wrap(err, "could not list tables")
+	}
+
+	var problems []TabletProblem
+	problem := func(table *master.ListTablesResponsePB_TableInfo, tabletID string, uuid string, kind string, format string, args ...interface{}) {
+		p := TabletProblem{
+			TableID: string(table.GetId()),
+			Table:   table.GetNamespace().GetName() + "." + table.GetName(),
+			Tablet:  tabletID,
+			Problem: kind,
+			Detail:  fmt.Sprintf(format, args...),
+		}
+		if uuid != "" {
+			p.TabletServer = tabletServerName(servers, uuid)
+		}
+		problems = append(problems, p)
+	}
+
+	// Replicas on live tablet servers, by tablet server UUID
+	liveReplicas := make(map[string][]*tabletReplica)
+	seen := make(map[string]bool)
+	for _, table := range tables.GetTables() {
+		// System tables are hosted by the masters
+		if table.GetRelationType() == master.RelationType_SYSTEM_TABLE_RELATION {
+			continue
+		}
+
+		replicationFactor, err := tableReplicationFactor(ctx, c, table, clusterReplicationFactor)
+		if err != nil {
+			return nil, err
+		}
+		if replicationFactor == 0 {
+			problem(table, "", "", ProblemUnknownRF, "neither the table nor the universe has a replication factor, so replica counts are not checked")
+		}
+
+		locations, err := c.TableLocations(ctx, table.GetId())
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get the tablets of table %s", table.GetName())
+		}
+
+		for _, location := range locations {
+			tabletID := string(location.GetTabletId())
+			// Colocated tables share their tablets
+			if seen[tabletID] {
+				continue
+			}
+			seen[tabletID] = true
+
+			var voters int
+			var hasLeader bool
+			for _, replica := range location.GetReplicas() {
+				uuid := string(replica.GetTsInfo().GetPermanentUuid())
+				switch replica.GetMemberType() {
+				case common.RaftPeerPB_VOTER, common.RaftPeerPB_PRE_VOTER:
+					voters++
+				}
+				if replica.GetRole() == common.RaftPeerPB_LEADER {
+					hasLeader = true
+				}
+
+				ts, ok := servers[uuid]
+				switch {
+				case !ok:
+					problem(table, tabletID, uuid, ProblemDeadTabletServer, "replica is on a tablet server the master does not know")
+				case !ts.GetAlive():
+					problem(table, tabletID, uuid, ProblemDeadTabletServer, "replica is on a tablet server the master considers dead")
+				case time.Duration(ts.GetMillisSinceHeartbeat())*time.Millisecond > options.HeartbeatTimeout:
+					problem(table, tabletID, uuid, ProblemDeadTabletServer, "replica is on a tablet server last heard from %s ago", time.Duration(ts.GetMillisSinceHeartbeat())*time.Millisecond)
+				default:
+					liveReplicas[uuid] = append(liveReplicas[uuid], &tabletReplica{table: table, location: location, uuid: uuid})
+				}
+			}
+
+			if replicationFactor > 0 && voters < replicationFactor {
+				problem(table, tabletID, "", ProblemUnderReplicated, "%d of %d replicas", voters, replicationFactor)
+			} else if replicationFactor > 0 && voters > replicationFactor {
+				problem(table, tabletID, "", ProblemOverReplicated, "%d of %d replicas", voters, replicationFactor)
+			}
+			if !hasLeader {
+				problem(table, tabletID, "", ProblemNoLeader, "no replica is the leader")
+			}
+		}
+	}
+
+	replicaProblems, err := checkReplicas(ctx, log, c, liveReplicas, servers, options.Concurrency)
+	if err != nil {
+		return nil, err
+	}
+	problems = append(problems, replicaProblems...)
+
+	sort.SliceStable(problems, func(i, j int) bool {
+		if problems[i].Table != problems[j].Table {
+			return problems[i].Table < problems[j].Table
+		}
+		if problems[i].Tablet != problems[j].Tablet {
+			return problems[i].Tablet < problems[j].Tablet
+		}
+		if problems[i].TabletServer != problems[j].TabletServer {
+			return problems[i].TabletServer < problems[j].TabletServer
+		}
+		return problems[i].Problem < problems[j].Problem
+	})
+	return problems, nil
+}
+
+// tableReplicationFactor returns the number of live replicas of the table's own
+// placement policy, or clusterReplicationFactor when it has none
+func tableReplicationFactor(ctx context.Context, c *client.YBClient, table *master.ListTablesResponsePB_TableInfo, clusterReplicationFactor int) (int, error) {
+	schema, err := c.Master.MasterService.GetTableSchemaWithContext(ctx, &master.GetTableSchemaRequestPB{
+		Table: &master.TableIdentifierPB{TableId: table.GetId()},
+	})
+	if err != nil {
+		return 0, err
+	}
+	if err := yberrors.FromResponse(schema); err != nil {
+		return 0, errors.Wrapf(err, "could not get the schema of table %s", table.GetName())
+	}
+	if numReplicas := schema.GetReplicationInfo().GetLiveReplicas().GetNumReplicas(); numReplicas > 0 {
+		return int(numReplicas), nil
+	}
+	return clusterReplicationFactor, nil
+}
+
+// replicaConfig is the committed Raft config a replica reports
+type replicaConfig struct {
+	*tabletReplica
+	config string
+}
+
+// checkReplicas asks each live tablet server for the state and committed Raft
+// config of the replicas the master places on it
+func checkReplicas(ctx context.Context, log logr.Logger, c *client.YBClient, replicas map[string][]*tabletReplica, servers map[string]*master.ListTabletServersResponsePB_Entry, concurrency int) ([]TabletProblem, error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var problems []TabletProblem
+	m := &sync.Mutex{}
+	problem := func(replica *tabletReplica, kind string, format string, args ...interface{}) {
+		m.Lock()
+		defer m.Unlock()
+		problems = append(problems, TabletProblem{
+			TableID:      string(replica.table.GetId()),
+			Table:        replica.table.GetNamespace().GetName() + "." + replica.table.GetName(),
+			Tablet:       string(replica.location.GetTabletId()),
+			TabletServer: tabletServerName(servers, replica.uuid),
+			Problem:      kind,
+			Detail:       fmt.Sprintf(format, args...),
+		})
+	}
+
+	// Committed configs of each tablet, by tablet ID and then the replica reporting it
+	configs := make(map[string][]*replicaConfig)
+
+	g := &errgroup.Group{}
+	for uuid, hosted := range replicas {
+		uuid, hosted := uuid, hosted
+		g.Go(func() error {
+			host, err := c.GetHostByUUIDWithContext(ctx, []byte(uuid))
+			if err != nil {
+				log.Error(err, "could not connect to tablet server", "uuid", uuid)
+				for _, replica := range hosted {
+					problem(replica, ProblemUnreachable, "could not connect to the tablet server: %s", err)
+				}
+				return nil
+			}
+
+			tablets, err := host.TabletServerService.ListTabletsWithContext(ctx, &tserver.ListTabletsRequestPB{})
+			if err != nil {
+				return err
+			}
+			if err := yberrors.FromResponse(tablets); err != nil {
+				return errors.Wrapf(err, "could not list the tablets of tablet server %s", uuid)
+			}
+			states := make(map[string]common.RaftGroupStatePB)
+			for _, status := range tablets.GetStatusAndSchema() {
+				states[status.GetTabletStatus().GetTabletId()] = status.GetTabletStatus().GetState()
+			}
+
+			// The consensus state requests are pipelined over the tserver connection
+			tg := &errgroup.Group{}
+			tg.SetLimit(concurrency)
+			for _, replica := range hosted {
+				replica := replica
+				tg.Go(func() error {
+					tabletID := string(replica.location.GetTabletId())
+					state, ok := states[tabletID]
+					if !ok {
+						problem(replica, ProblemMissingReplica, "the tablet server does not host the replica")
+						return nil
+					}
+					if state != common.RaftGroupStatePB_RUNNING {
+						problem(replica, ProblemReplicaNotRunning, "replica is %s", state)
+					}
+
+					consensusState, err := host.ConsensusService.GetConsensusStateWithContext(ctx, &consensus.GetConsensusStateRequestPB{
+						DestUuid: host.Status.GetNodeInstance().GetPermanentUuid(),
+						TabletId: replica.location.GetTabletId(),
+						Type:     common.ConsensusConfigType_CONSENSUS_CONFIG_COMMITTED.Enum(),
+					})
+					if err != nil {
+						return err
+					}
+					if err := yberrors.FromResponse(consensusState); err != nil {
+						problem(replica, ProblemMissingReplica, "could not get the consensus state: %s", err)
+						return nil
+					}
+
+					m.Lock()
+					configs[tabletID] = append(configs[tabletID], &replicaConfig{
+						tabletReplica: replica,
+						config:        describeConfig(consensusState.GetCstate().GetConfig()),
+					})
+					m.Unlock()
+					return nil
+				})
+			}
+			return tg.Wait()
+		})
+	}
+	err := g.Wait()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, peers := range configs {
+		distinct := make(map[string]bool)
+		for _, peer := range peers {
+			distinct[peer.config] = true
+		}
+		if len(distinct) < 2 {
+			continue
+		}
+		for _, peer := range peers {
+			problem(peer.tabletReplica, ProblemConfigMismatch, "committed config %s differs from other peers", peer.config)
+		}
+	}
+
+	return problems, nil
+}
+
+// describeConfig describes a Raft config by its index and sorted peers, so the
+// configs of two peers are the same when their descriptions are
+func describeConfig(config *common.RaftConfigPB) string {
+	var peers []string
+	for _, peer := range config.GetPeers() {
+		peers = append(peers, fmt.Sprintf("%s(%s)", peer.GetPermanentUuid(), peer.GetMemberType()))
+	}
+	sort.Strings(peers)
+	return fmt.Sprintf("at index %d with peers [%s]", config.GetOpidIndex(), strings.Join(peers, ", "))
+}
+
+func tabletServerName(servers map[string]*master.ListTabletServersResponsePB_Entry, uuid string) string {
+	if ts, ok := servers[uuid]; ok {
+		if addresses := ts.GetRegistration().GetCommon().GetPrivateRpcAddresses(); len(addresses) > 0 {
+			return util.HostPortString(addresses[0])
+		}
+	}
+	return uuid
+}
@@ -0,0 +1,179 @@
+package healthcheck_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/blang/vfs/memfs"
+	"github.com/go-logr/logr"
+	. "github.com/icza/gox/gox"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/common"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/master"
+	"github.com/yugabyte/yb-tools/yugatool/api/yugatool/config"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/healthcheck"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/rpcserver"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/test/fakecluster"
+)
+
+var _ = Describe("CheckTablets", func() {
+	var (
+		cluster *fakecluster.Cluster
+		table   *fakecluster.Table
+		c       *client.YBClient
+		options healthcheck.TabletCheckOptions
+	)
+
+	BeforeEach(func() {
+		cluster = fakecluster.New(logr.Discard(), rpcserver.NewNetwork(), "tablets", 1, 4)
+		table = cluster.AddTable("yugabyte", "test_table", 2)
+		options = healthcheck.TabletCheckOptions{HeartbeatTimeout: time.Minute, Concurrency: 2}
+
+		c = &client.YBClient{
+			Log: logr.Discard(),
+			Fs:  memfs.Create(),
+			Config: &config.UniverseConfigPB{
+				Masters:        cluster.MasterHostPorts(),
+				TimeoutSeconds: NewInt64(1),
+			},
+		}
+		c.OverrideDialer(cluster.Network)
+		Expect(c.Connect()).To(Succeed())
+	})
+
+	AfterEach(func() {
+		c.Close()
+	})
+
+	checkTablets := func() []healthcheck.TabletProblem {
+		problems, err := healthcheck.CheckTablets(context.Background(), logr.Discard(), c, options)
+		Expect(err).NotTo(HaveOccurred())
+		return problems
+	}
+
+	problem := func(tablet *fakecluster.Tablet, tserver string, kind string, detail string) healthcheck.TabletProblem {
+		return healthcheck.TabletProblem{
+			TableID:      table.ID,
+			Table:        "yugabyte.test_table",
+			Tablet:       tablet.ID,
+			TabletServer: tserver,
+			Problem:      kind,
+			Detail:       detail,
+		}
+	}
+
+	It("finds no problems with healthy tablets", func() {
+		Expect(checkTablets()).To(BeEmpty())
+	})
+
+	It("finds under- and over-replicated tablets", func() {
+		cluster.Lock()
+		table.Tablets[0].Replicas = table.Tablets[0].Replicas[:2]
+		table.Tablets[1].Replicas = append(table.Tablets[1].Replicas, cluster.TabletServers[0])
+		cluster.Unlock()
+
+		Expect(checkTablets()).To(ConsistOf(
+			problem(table.Tablets[0], "", healthcheck.ProblemUnderReplicated, "2 of 3 replicas"),
+			problem(table.Tablets[1], "", healthcheck.ProblemOverReplicated, "4 of 3 replicas"),
+		))
+	})
+
+	It("checks the replication factor of a universe without a placement policy", func() {
+		cluster.Lock()
+		cluster.ClusterConfig.ReplicationInfo = &master.ReplicationInfoPB{}
+		table.Tablets[0].Replicas = table.Tablets[0].Replicas[:2]
+		cluster.Unlock()
+
+		Expect(checkTablets()).To(ConsistOf(
+			problem(table.Tablets[0], "", healthcheck.ProblemUnderReplicated, "2 of 3 replicas"),
+		))
+	})
+
+	It("checks the replication factor of a table with its own placement policy", func() {
+		cluster.Lock()
+		table.ReplicationInfo = &master.ReplicationInfoPB{
+			LiveReplicas: &master.PlacementInfoPB{NumReplicas: NewInt32(4)},
+		}
+		table.Tablets[1].Replicas = append(table.Tablets[1].Replicas, cluster.TabletServers[0])
+		cluster.Unlock()
+
+		Expect(checkTablets()).To(ConsistOf(
+			problem(table.Tablets[0], "", healthcheck.ProblemUnderReplicated, "3 of 4 replicas"),
+		))
+	})
+
+	It("reports an unknown replication factor", func() {
+		cluster.Lock()
+		cluster.ClusterConfig.ReplicationInfo = &master.ReplicationInfoPB{}
+		for _, m := range cluster.Masters {
+			delete(m.Flags, "replication_factor")
+		}
+		table.Tablets[0].Replicas = table.Tablets[0].Replicas[:2]
+		cluster.Unlock()
+
+		Expect(checkTablets()).To(ConsistOf(
+			problem(&fakecluster.Tablet{}, "", healthcheck.ProblemUnknownRF, "neither the table nor the universe has a replication factor, so replica counts are not checked"),
+		))
+	})
+
+	It("finds tablets without a leader", func() {
+		cluster.Lock()
+		table.Tablets[1].Leader = nil
+		cluster.Unlock()
+
+		Expect(checkTablets()).To(ConsistOf(
+			problem(table.Tablets[1], "", healthcheck.ProblemNoLeader, "no replica is the leader"),
+		))
+	})
+
+	It("finds replicas on dead tablet servers", func() {
+		cluster.Stop(cluster.TabletServers[2])
+
+		Expect(checkTablets()).To(ConsistOf(
+			problem(table.Tablets[0], "tablets-tserver-3:9100", healthcheck.ProblemDeadTabletServer, "replica is on a tablet server the master considers dead"),
+			problem(table.Tablets[1], "tablets-tserver-3:9100", healthcheck.ProblemDeadTabletServer, "replica is on a tablet server the master considers dead"),
+		))
+	})
+
+	It("finds replicas on tablet servers that have not heartbeated", func() {
+		options.HeartbeatTimeout = 50 * time.Millisecond
+
+		problems := checkTablets()
+		Expect(problems).To(HaveLen(6))
+		Expect(problems).To(HaveEach(MatchFields(IgnoreExtras, Fields{
+			"Problem": Equal(healthcheck.ProblemDeadTabletServer),
+			"Detail":  Equal("replica is on a tablet server last heard from 100ms ago"),
+		})))
+	})
+
+	It("finds replicas that are not running", func() {
+		cluster.Lock()
+		table.Tablets[0].State = common.RaftGroupStatePB_BOOTSTRAPPING
+		cluster.Unlock()
+
+		problems := checkTablets()
+		Expect(problems).To(HaveLen(3))
+		Expect(problems).To(ContainElement(problem(table.Tablets[0], "tablets-tserver-1:9100", healthcheck.ProblemReplicaNotRunning, "replica is BOOTSTRAPPING")))
+	})
+
+	It("finds peers whose committed configs disagree", func() {
+		cluster.Lock()
+		tablet := table.Tablets[0]
+		tablet.CommittedConfigs = map[string][]*fakecluster.Node{
+			tablet.Replicas[2].UUID: {tablet.Replicas[0], tablet.Replicas[1], cluster.TabletServers[3]},
+		}
+		cluster.Unlock()
+
+		problems := checkTablets()
+		Expect(problems).To(HaveLen(3))
+		for _, p := range problems {
+			Expect(p.Tablet).To(Equal(tablet.ID))
+			Expect(p.Problem).To(Equal(healthcheck.ProblemConfigMismatch))
+		}
+		Expect(problems[2].TabletServer).To(Equal("tablets-tserver-3:9100"))
+		Expect(problems[2].Detail).To(ContainSubstring(cluster.TabletServers[3].UUID))
+	})
+})
@@ -0,0 +1,270 @@
+// Package recording records the RPCs yugatool makes, and replays them later
+// without network access.
+//
+// A Recorder wraps a dial.Dialer, and watches the traffic on every connection
+// made through it. Each call is written as a line of JSON holding the host,
+// service, method, timing, and the request and response. The replay network
+// loads those lines and answers calls with the recorded responses, so a command
+// run against a customer's universe can be rerun locally against the recording.
+package recording
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/yugabyte/yb-tools/protoc-gen-ybrpc/pkg/dispatch"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/rpc"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client/dial"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client/message"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/rpcserver"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Call is a recorded RPC. Request and Response hold the messages in protobuf
+// JSON. Calls that failed with an RPC error have the error status instead of
+// a response.
+type Call struct {
+	Host     string             `json:"host"`
+	Service  string             `json:"service"`
+	Method   string             `json:"method"`
+	Start    time.Time          `json:"start"`
+	Duration time.Duration      `json:"duration"`
+	Request  json.RawMessage    `json:"request,omitempty"`
+	Response json.RawMessage    `json:"response,omitempty"`
+	Error    *rpc.ErrorStatusPB `json:"error,omitempty"`
+	Sidecars [][]byte           `json:"sidecars,omitempty"`
+}
+
+type Recorder struct {
+	Log logr.Logger
+
+	m   sync.Mutex
+	enc *json.Encoder
+}
+
+// NewRecorder returns a recorder that writes calls to w as they complete. Each
+// call is written with a single Write, so w needs no flushing.
+func NewRecorder(log logr.Logger, w io.Writer) *Recorder {
+	return &Recorder{
+		Log: log,
+		enc: json.NewEncoder(w),
+	}
+}
+
+func (r *Recorder) Record(call *Call) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	err := r.enc.Encode(call)
+	if err != nil {
+		r.Log.Error(err, "could not record call", "service", call.Service, "method", call.Method)
+	}
+}
+
+// Dialer returns a dialer that records the calls made over the connections of d.
+func (r *Recorder) Dialer(d dial.Dialer) dial.Dialer {
+	return &recordingDialer{recorder: r, dialer: d}
+}
+
+type recordingDialer struct {
+	recorder *Recorder
+	dialer   dial.Dialer
+}
+
+func (d *recordingDialer) WithServerName(name string) dial.Dialer {
+	return &recordingDialer{recorder: d.recorder, dialer: dial.WithServerName(d.dialer, name)}
+}
+
+func (d *recordingDialer) Dial(network, address string) (io.ReadWriteCloser, error) {
+	conn, err := d.dialer.Dial(network, address)
+	if err != nil {
+		return nil, err
+	}
+
+	return &recordingConn{
+		ReadWriteCloser: conn,
+		recorder:        d.recorder,
+		log:             d.recorder.Log.WithValues("host", address),
+		host:            address,
+		// The client starts the connection with the "YB\001" hello
+		requests: packetBuffer{skip: 3},
+		pending:  make(map[int32]*Call),
+	}, nil
+}
+
+// recordingConn splits the traffic in each direction into packets, and pairs
+// requests with their responses by call ID.
+type recordingConn struct {
+	io.ReadWriteCloser
+
+	recorder *Recorder
+	log      logr.Logger
+	host     string
+
+	m         sync.Mutex
+	requests  packetBuffer
+	responses packetBuffer
+	pending   map[int32]*Call
+	broken    bool
+}
+
+func (c *recordingConn) Write(b []byte) (int, error) {
+	// Requests are noted before they are sent, so the response cannot arrive first
+	c.m.Lock()
+	c.requests.write(b)
+	c.readRequests()
+	c.m.Unlock()
+
+	return c.ReadWriteCloser.Write(b)
+}
+
+func (c *recordingConn) Read(b []byte) (int, error) {
+	n, err := c.ReadWriteCloser.Read(b)
+	if n > 0 {
+		c.m.Lock()
+		c.responses.write(b[:n])
+		c.readResponses()
+		c.m.Unlock()
+	}
+	return n, err
+}
+
+func (c *recordingConn) readRequests() {
+	for !c.broken {
+		packet, ok := c.requests.next()
+		if !ok {
+			return
+		}
+
+		header, body, err := rpcserver.ReadRequest(bytes.NewReader(packet))
+		if err != nil {
+			c.fail(err)
+			return
+		}
+
+		call := &Call{
+			Host:    c.host,
+			Service: header.GetRemoteMethod().GetServiceName(),
+			Method:  header.GetRemoteMethod().GetMethodName(),
+			Start:   time.Now(),
+		}
+		if method, err := call.method(); err == nil {
+			call.Request = c.toJSON(body, method.NewRequest())
+		}
+		c.pending[header.GetCallId()] = call
+	}
+}
+
+func (c *recordingConn) readResponses() {
+	for !c.broken {
+		packet, ok := c.responses.next()
+		if !ok {
+			return
+		}
+
+		header, body, err := message.ReadResponse(bytes.NewReader(packet))
+		if err != nil {
+			c.fail(err)
+			return
+		}
+
+		call, ok := c.pending[header.GetCallId()]
+		if !ok {
+			continue
+		}
+		delete(c.pending, header.GetCallId())
+		call.Duration = time.Since(call.Start)
+
+		body, sidecars, err := message.SplitBody(header, body)
+		if err != nil {
+			c.fail(err)
+			return
+		}
+
+		if header.GetIsError() {
+			call.Error = &rpc.ErrorStatusPB{}
+			err = proto.Unmarshal(body, call.Error)
+			if err != nil {
+				c.log.Error(err, "could not decode error status", "service", call.Service, "method", call.Method)
+			}
+		} else {
+			if method, err := call.method(); err == nil {
+				call.Response = c.toJSON(body, method.NewResponse())
+			}
+			call.Sidecars = sidecars
+		}
+
+		c.recorder.Record(call)
+	}
+}
+
+// fail stops recording the connection, whose traffic could not be understood
+func (c *recordingConn) fail(err error) {
+	c.log.Error(err, "could not parse RPC traffic, no longer recording the connection")
+	c.broken = true
+}
+
+func (c *recordingConn) toJSON(body []byte, m proto.Message) json.RawMessage {
+	err := proto.Unmarshal(body, m)
+	if err != nil {
+		c.log.Error(err, "could not decode message", "message", m.ProtoReflect().Descriptor().FullName())
+		return nil
+	}
+
+	b, err := protojson.Marshal(m)
+	if err != nil {
+		c.log.Error(err, "could not encode message", "message", m.ProtoReflect().Descriptor().FullName())
+		return nil
+	}
+	return b
+}
+
+func (call *Call) method() (*dispatch.MethodDesc, error) {
+	_, method, err := dispatch.FindMethod(call.Service + "." + call.Method)
+	return method, err
+}
+
+// packetBuffer collects one direction of a connection and splits it into
+// length prefixed packets.
+type packetBuffer struct {
+	buf  []byte
+	skip int
+}
+
+func (p *packetBuffer) write(b []byte) {
+	p.buf = append(p.buf, b...)
+}
+
+// next returns the next complete packet, with its length prefix.
+func (p *packetBuffer) next() ([]byte, bool) {
+	if p.skip > 0 {
+		if len(p.buf) < p.skip {
+			return nil, false
+		}
+		p.buf = p.buf[p.skip:]
+		p.skip = 0
+	}
+
+	for len(p.buf) >= 4 {
+		length := int(binary.BigEndian.Uint32(p.buf))
+		// Empty packets carry nothing
+		if length == 0 {
+			p.buf = p.buf[4:]
+			continue
+		}
+		if len(p.buf) < 4+length {
+			return nil, false
+		}
+
+		packet := p.buf[:4+length]
+		p.buf = p.buf[4+length:]
+		return packet, true
+	}
+	return nil, false
+}
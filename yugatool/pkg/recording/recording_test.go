@@ -0,0 +1,140 @@
+package recording_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+
+	"github.com/go-logr/logr"
+	. "github.com/icza/gox/gox"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/common"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/rpc"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/server"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client"
+	yberrors "github.com/yugabyte/yb-tools/yugatool/pkg/client/errors"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client/message"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/recording"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/rpcserver"
+)
+
+type genericHandler struct {
+	server.UnimplementedGenericServiceServer
+
+	flags map[string]string
+}
+
+func (h *genericHandler) Ping(_ context.Context, _ *server.PingRequestPB) (*server.PingResponsePB, error) {
+	return &server.PingResponsePB{}, nil
+}
+
+func (h *genericHandler) GetStatus(_ context.Context, _ *server.GetStatusRequestPB) (*server.GetStatusResponsePB, error) {
+	return &server.GetStatusResponsePB{
+		Status: &server.ServerStatusPB{
+			NodeInstance: &common.NodeInstancePB{PermanentUuid: []byte("0123456789abcdef0123456789abcdef"), InstanceSeqno: NewInt64(1)},
+		},
+	}, nil
+}
+
+func (h *genericHandler) GetFlag(_ context.Context, request *server.GetFlagRequestPB) (*server.GetFlagResponsePB, error) {
+	value, ok := h.flags[request.GetFlag()]
+	return &server.GetFlagResponsePB{Valid: NewBool(ok), Value: NewString(value)}, nil
+}
+
+func (h *genericHandler) SetFlag(_ context.Context, _ *server.SetFlagRequestPB) (*server.SetFlagResponsePB, error) {
+	return nil, rpcserver.Errorf(rpc.ErrorStatusPB_ERROR_SERVER_TOO_BUSY, "too busy")
+}
+
+var _ = Describe("Recording", func() {
+	var (
+		hostPort = &common.HostPortPB{Host: NewString("tserver-1"), Port: NewUint32(9100)}
+		recorded bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		recorded.Reset()
+
+		network := rpcserver.NewNetwork()
+		s := rpcserver.NewServer(logr.Discard())
+		server.RegisterGenericService(s, &genericHandler{flags: map[string]string{
+			"max_clock_skew_usec": "500000",
+			"log_dir":             "/mnt/disk0/yb-data/tserver/logs",
+		}})
+		network.Listen("tserver-1:9100", s)
+
+		recorder := recording.NewRecorder(logr.Discard(), &recorded)
+		host, err := client.NewHostState(context.Background(), logr.Discard(), hostPort, recorder.Dialer(network), message.DefaultTimeout)
+		Expect(err).NotTo(HaveOccurred())
+		defer host.Close()
+
+		for _, flag := range []string{"max_clock_skew_usec", "log_dir"} {
+			_, err = host.GenericService.GetFlag(&server.GetFlagRequestPB{Flag: NewString(flag)})
+			Expect(err).NotTo(HaveOccurred())
+		}
+		_, err = host.GenericService.SetFlag(&server.SetFlagRequestPB{Flag: NewString("log_dir"), Value: NewString("/tmp")})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("records each call with its request and response", func() {
+		calls, err := recording.Load(&recorded)
+		Expect(err).NotTo(HaveOccurred())
+
+		var methods []string
+		for _, call := range calls {
+			Expect(call.Host).To(Equal("tserver-1:9100"))
+			Expect(call.Service).To(Equal("yb.server.GenericService"))
+			methods = append(methods, call.Method)
+		}
+		Expect(methods).To(Equal([]string{"Ping", "GetStatus", "GetFlag", "GetFlag", "SetFlag"}))
+
+		Expect(calls[2].Request).To(MatchJSON(`{"flag":"max_clock_skew_usec"}`))
+		Expect(calls[2].Response).To(MatchJSON(`{"valid":true,"value":"500000"}`))
+		Expect(calls[4].Response).To(BeEmpty())
+		Expect(calls[4].Error.GetCode()).To(Equal(rpc.ErrorStatusPB_ERROR_SERVER_TOO_BUSY))
+	})
+
+	Context("when replayed", func() {
+		var host *client.HostState
+
+		BeforeEach(func() {
+			calls, err := recording.Load(&recorded)
+			Expect(err).NotTo(HaveOccurred())
+
+			host, err = client.NewHostState(context.Background(), logr.Discard(), hostPort,
+				recording.NewReplayNetwork(logr.Discard(), calls), message.DefaultTimeout)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			_ = host.Close()
+		})
+
+		It("answers calls with the response recorded for the same request", func() {
+			Expect(host.Status.GetNodeInstance().GetPermanentUuid()).To(BeEquivalentTo("0123456789abcdef0123456789abcdef"))
+
+			response, err := host.GenericService.GetFlag(&server.GetFlagRequestPB{Flag: NewString("log_dir")})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.GetValue()).To(Equal("/mnt/disk0/yb-data/tserver/logs"))
+
+			response, err = host.GenericService.GetFlag(&server.GetFlagRequestPB{Flag: NewString("max_clock_skew_usec")})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.GetValue()).To(Equal("500000"))
+
+			// Repeated requests get the last recorded response again
+			response, err = host.GenericService.GetFlag(&server.GetFlagRequestPB{Flag: NewString("log_dir")})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.GetValue()).To(Equal("/mnt/disk0/yb-data/tserver/logs"))
+		})
+
+		It("returns recorded errors", func() {
+			_, err := host.GenericService.SetFlag(&server.SetFlagRequestPB{Flag: NewString("log_dir"), Value: NewString("/tmp")})
+			Expect(errors.Is(err, yberrors.ErrServiceUnavailable)).To(BeTrue())
+		})
+
+		It("fails calls that were not recorded", func() {
+			_, err := host.GenericService.ServerClock(&server.ServerClockRequestPB{})
+			Expect(err).To(MatchError(ContainSubstring("no recorded response for yb.server.GenericService.ServerClock")))
+		})
+	})
+})
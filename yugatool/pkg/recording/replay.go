@@ -0,0 +1,146 @@
+package recording
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"github.com/yugabyte/yb-tools/protoc-gen-ybrpc/pkg/dispatch"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/rpc"
+	yberrors "github.com/yugabyte/yb-tools/yugatool/pkg/client/errors"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client/message"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/rpcserver"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Load reads the calls written by a Recorder.
+func Load(r io.Reader) ([]*Call, error) {
+	var calls []*Call
+
+	dec := json.NewDecoder(r)
+	for dec.More() {
+		call := &Call{}
+		err := dec.Decode(call)
+		if err != nil {
+			return nil, fmt.Errorf("invalid recording: %w", err)
+		}
+		calls = append(calls, call)
+	}
+	return calls, nil
+}
+
+// NewReplayNetwork returns a network where each recorded host listens at its
+// recorded address, and answers calls with the recorded responses. Hosts that
+// were not recorded refuse connections.
+func NewReplayNetwork(log logr.Logger, calls []*Call) *rpcserver.Network {
+	hosts := make(map[string]*replayHandler)
+	for _, call := range calls {
+		if hosts[call.Host] == nil {
+			hosts[call.Host] = &replayHandler{host: call.Host}
+		}
+		hosts[call.Host].calls = append(hosts[call.Host].calls, call)
+	}
+
+	network := rpcserver.NewNetwork()
+	for host, handler := range hosts {
+		handler.used = make([]bool, len(handler.calls))
+
+		server := rpcserver.NewServer(log.WithValues("host", host))
+		server.Handler = handler
+		network.Listen(host, server)
+	}
+	return network
+}
+
+type replayHandler struct {
+	host string
+
+	m     sync.Mutex
+	calls []*Call
+	used  []bool
+}
+
+func (h *replayHandler) Dispatch(_ context.Context, service, method string, body []byte) (proto.Message, error) {
+	_, desc, err := dispatch.FindMethod(service + "." + method)
+	if err != nil {
+		return nil, err
+	}
+
+	request := desc.NewRequest()
+	err = proto.Unmarshal(body, request)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", dispatch.ErrInvalidRequest, err)
+	}
+
+	call := h.find(service, method, desc, request)
+	if call == nil {
+		return nil, rpcserver.Errorf(rpc.ErrorStatusPB_ERROR_APPLICATION, "no recorded response for %s.%s on %s", service, method, h.host)
+	}
+
+	if call.Error != nil {
+		return nil, &yberrors.RPCError{Service: service, Method: method, Status: call.Error}
+	}
+
+	response := desc.NewResponse()
+	if len(call.Response) > 0 {
+		err = protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(call.Response, response)
+		if err != nil {
+			return nil, rpcserver.Errorf(rpc.ErrorStatusPB_FATAL_UNKNOWN, "invalid recorded response for %s.%s: %s", service, method, err)
+		}
+	}
+
+	if len(call.Sidecars) > 0 {
+		return &message.ResponseWithSidecars{Message: response, Sidecars: call.Sidecars}, nil
+	}
+	return response, nil
+}
+
+// find returns the recorded call that answers the request. Calls are replayed in
+// the order they were recorded, preferring a call with the same request. Once
+// all such calls have been replayed, the last of them is repeated.
+func (h *replayHandler) find(service, method string, desc *dispatch.MethodDesc, request proto.Message) *Call {
+	h.m.Lock()
+	defer h.m.Unlock()
+
+	var unused, repeat *Call
+	unusedIndex := -1
+	for i, call := range h.calls {
+		if call.Service != service || call.Method != method {
+			continue
+		}
+
+		if sameRequest(desc, call, request) {
+			if !h.used[i] {
+				h.used[i] = true
+				return call
+			}
+			repeat = call
+		} else if unused == nil && !h.used[i] {
+			unused, unusedIndex = call, i
+		}
+	}
+
+	if repeat != nil {
+		return repeat
+	}
+	// A request that differs from the recording still gets an answer of the right kind
+	if unused != nil {
+		h.used[unusedIndex] = true
+	}
+	return unused
+}
+
+func sameRequest(desc *dispatch.MethodDesc, call *Call, request proto.Message) bool {
+	recorded := desc.NewRequest()
+	if len(call.Request) > 0 {
+		err := protojson.Unmarshal(call.Request, recorded)
+		if err != nil {
+			return false
+		}
+	}
+	return proto.Equal(recorded, request)
+}
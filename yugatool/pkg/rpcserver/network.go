@@ -0,0 +1,133 @@
+package rpcserver
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"io"
+	"net"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client/dial"
+)
+
+var _ dial.Dialer = &Network{}
+
+// Network connects clients to servers listening on in-memory addresses. It is a
+// dial.Dialer, so a YBClient can be pointed at it with OverrideDialer.
+type Network struct {
+	m       sync.Mutex
+	servers map[string]*Server
+	conns   map[string][]net.Conn
+	tls     map[string]*tls.Config
+}
+
+func NewNetwork() *Network {
+	return &Network{
+		servers: make(map[string]*Server),
+		conns:   make(map[string][]net.Conn),
+		tls:     make(map[string]*tls.Config),
+	}
+}
+
+// Listen serves connections to the address, given as host:port, with the server.
+func (n *Network) Listen(address string, server *Server) {
+	n.m.Lock()
+	defer n.m.Unlock()
+
+	n.servers[address] = server
+}
+
+// ServeTLS serves connections to the address that start with a TLS handshake
+// over TLS with the config. Plaintext connections are still served, as by
+// servers that allow insecure connections. A nil config stops serving TLS.
+func (n *Network) ServeTLS(address string, config *tls.Config) {
+	n.m.Lock()
+	defer n.m.Unlock()
+
+	n.tls[address] = config
+}
+
+// Stop takes the address off the network and closes its connections, as if the
+// server had crashed. Dialing the address fails until it listens again.
+func (n *Network) Stop(address string) {
+	n.m.Lock()
+	conns := n.conns[address]
+	delete(n.servers, address)
+	delete(n.conns, address)
+	n.m.Unlock()
+
+	for _, conn := range conns {
+		_ = conn.Close()
+	}
+}
+
+func (n *Network) Dial(network, address string) (io.ReadWriteCloser, error) {
+	return n.DialContext(context.Background(), network, address)
+}
+
+// DialContext connects to the address like Dial, for callers that need a
+// net.Conn.
+func (n *Network) DialContext(_ context.Context, network, address string) (net.Conn, error) {
+	n.m.Lock()
+	defer n.m.Unlock()
+
+	server, ok := n.servers[address]
+	if !ok {
+		return nil, &net.OpError{Op: "dial", Net: network, Err: errors.Errorf("connection refused by %s", address)}
+	}
+
+	clientConn, serverConn := net.Pipe()
+	n.conns[address] = append(n.conns[address], serverConn)
+	tlsConfig := n.tls[address]
+
+	go func() {
+		var conn net.Conn = serverConn
+		if tlsConfig != nil {
+			conn = acceptTLS(serverConn, tlsConfig)
+		}
+
+		err := server.Serve(conn)
+		if err != nil {
+			server.Log.V(1).Info("connection closed", "address", address, "error", err)
+		}
+		n.forget(address, serverConn)
+	}()
+
+	return clientConn, nil
+}
+
+func (n *Network) forget(address string, conn net.Conn) {
+	n.m.Lock()
+	defer n.m.Unlock()
+
+	conns := n.conns[address]
+	for i := range conns {
+		if conns[i] == conn {
+			n.conns[address] = append(conns[:i], conns[i+1:]...)
+			return
+		}
+	}
+}
+
+// acceptTLS returns conn wrapped in TLS when the client starts with a TLS
+// handshake record, and conn unchanged otherwise.
+func acceptTLS(conn net.Conn, config *tls.Config) net.Conn {
+	peeked := &peekedConn{Conn: conn, r: bufio.NewReader(conn)}
+
+	b, err := peeked.r.Peek(1)
+	if err == nil && b[0] == 0x16 {
+		return tls.Server(peeked, config)
+	}
+	return peeked
+}
+
+type peekedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c *peekedConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
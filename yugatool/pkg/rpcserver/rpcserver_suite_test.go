@@ -0,0 +1,13 @@
+package rpcserver_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestRpcserver(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Rpcserver Suite")
+}
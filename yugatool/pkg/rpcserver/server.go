@@ -0,0 +1,277 @@
+// Package rpcserver serves YugabyteDB RPCs in-process, so clients can be tested,
+// or recorded calls replayed, without a running universe.
+//
+// A Server speaks the same framing as a master or tablet server: the "YB\001"
+// hello, followed by length prefixed packets holding a varint delimited header
+// and body. Calls are dispatched to implementations of the generated server
+// interfaces, registered with the generated helpers:
+//
+//	master.RegisterMasterService(s, &masterHandler{})
+//
+// Implementations that embed the generated Unimplemented<Service>Server answer
+// the methods they do not override with ERROR_NO_SUCH_METHOD, like a real server.
+// A server can instead pass every call to a Handler of its own.
+package rpcserver
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"github.com/yugabyte/yb-tools/protoc-gen-ybrpc/pkg/dispatch"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/rpc"
+	yberrors "github.com/yugabyte/yb-tools/yugatool/pkg/client/errors"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client/message"
+	"google.golang.org/protobuf/proto"
+)
+
+// maxPacketLen bounds the packets the server accepts, to fail fast on corrupt framing
+const maxPacketLen = 64 * 1024 * 1024
+
+// Handler answers the calls of a server. The response may be a
+// *message.ResponseWithSidecars to send sidecars after the response message.
+type Handler interface {
+	Dispatch(ctx context.Context, service, method string, body []byte) (proto.Message, error)
+}
+
+// Server is a dispatch.Registrar. A later registration of the same service
+// replaces the implementation.
+type Server struct {
+	Log logr.Logger
+
+	*dispatch.Dispatcher
+
+	// Handler answers all calls in place of the registered services when set
+	Handler Handler
+}
+
+func NewServer(log logr.Logger) *Server {
+	return &Server{
+		Log:        log,
+		Dispatcher: dispatch.NewDispatcher(),
+	}
+}
+
+func (s *Server) handler() Handler {
+	if s.Handler != nil {
+		return s.Handler
+	}
+	return s.Dispatcher
+}
+
+// Serve answers calls on the connection until it is closed. Each call is
+// handled in its own goroutine, so responses may be sent out of order.
+func (s *Server) Serve(conn io.ReadWriteCloser) error {
+	var calls sync.WaitGroup
+	defer func() {
+		// Closing the connection first unblocks any response still being written
+		_ = conn.Close()
+		calls.Wait()
+	}()
+
+	r := bufio.NewReader(conn)
+
+	hello := make([]byte, 3)
+	_, err := io.ReadFull(r, hello)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(hello, []byte("YB\001")) {
+		return errors.Errorf("unexpected connection hello %q", hello)
+	}
+
+	var writeLock sync.Mutex
+
+	for {
+		header, body, err := ReadRequest(r)
+		if err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrClosedPipe) {
+				return nil
+			}
+			return err
+		}
+
+		calls.Add(1)
+		go func() {
+			defer calls.Done()
+
+			packet, err := s.handle(header, body)
+			if err != nil {
+				s.Log.Error(err, "could not encode response", "callID", header.GetCallId())
+				return
+			}
+
+			writeLock.Lock()
+			defer writeLock.Unlock()
+			_, err = conn.Write(packet)
+			if err != nil {
+				s.Log.V(1).Info("could not write response", "callID", header.GetCallId(), "error", err)
+			}
+		}()
+	}
+}
+
+// ReadRequest reads the next request packet, returning its header and the
+// encoded request message.
+func ReadRequest(r io.Reader) (*rpc.RequestHeader, []byte, error) {
+	var packetLen [4]byte
+	_, err := io.ReadFull(r, packetLen[:])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	length := binary.BigEndian.Uint32(packetLen[:])
+	if length > maxPacketLen {
+		return nil, nil, errors.Errorf("request of %d bytes exceeds the maximum packet length", length)
+	}
+
+	packet := make([]byte, length)
+	_, err = io.ReadFull(r, packet)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	encodedHeader, packet, err := readDelimited(packet)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid request header: %w", err)
+	}
+
+	header := &rpc.RequestHeader{}
+	err = proto.Unmarshal(encodedHeader, header)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	body, _, err := readDelimited(packet)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid request body: %w", err)
+	}
+
+	return header, body, nil
+}
+
+func readDelimited(b []byte) ([]byte, []byte, error) {
+	value, n := binary.Uvarint(b)
+	if n <= 0 {
+		return nil, nil, errors.New("varint corruption")
+	}
+	if uint64(len(b)-n) < value {
+		return nil, nil, errors.New("length exceeds packet")
+	}
+	end := n + int(value)
+	return b[n:end], b[end:], nil
+}
+
+// handle runs the call and returns the encoded response packet.
+func (s *Server) handle(header *rpc.RequestHeader, body []byte) ([]byte, error) {
+	service := header.GetRemoteMethod().GetServiceName()
+	method := header.GetRemoteMethod().GetMethodName()
+	log := s.Log.WithValues("service", service, "method", method, "callID", header.GetCallId())
+
+	ctx := context.Background()
+	if header.GetTimeoutMillis() > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(header.GetTimeoutMillis())*time.Millisecond)
+		defer cancel()
+	}
+
+	response, err := s.handler().Dispatch(ctx, service, method, body)
+	if err != nil {
+		log.V(1).Info("call failed", "error", err)
+		return encodeResponse(header.GetCallId(), errorStatus(err), true)
+	}
+
+	packet, err := encodeResponse(header.GetCallId(), response, false)
+	if err != nil {
+		// Usually a handler that left a required field unset
+		log.Error(err, "could not encode response")
+		return encodeResponse(header.GetCallId(), errorStatus(Errorf(rpc.ErrorStatusPB_FATAL_UNKNOWN, "could not encode response: %s", err)), true)
+	}
+
+	log.V(1).Info("call succeeded")
+	return packet, nil
+}
+
+// Errorf returns an error that a handler may return to reject the call with the
+// RPC error code, such as ERROR_SERVER_TOO_BUSY.
+func Errorf(code rpc.ErrorStatusPB_RpcErrorCodePB, format string, args ...interface{}) error {
+	return &yberrors.RPCError{
+		Status: &rpc.ErrorStatusPB{
+			Message: proto.String(fmt.Sprintf(format, args...)),
+			Code:    code.Enum(),
+		},
+	}
+}
+
+// errorStatus converts a dispatch or handler error to the status sent to the
+// client. A handler may return an *errors.RPCError to choose the code, and any
+// other error is sent as an application error.
+func errorStatus(err error) *rpc.ErrorStatusPB {
+	var rpcError *yberrors.RPCError
+	if errors.As(err, &rpcError) {
+		return rpcError.Status
+	}
+
+	code := rpc.ErrorStatusPB_ERROR_APPLICATION
+	switch {
+	case errors.Is(err, dispatch.ErrNoSuchService):
+		code = rpc.ErrorStatusPB_ERROR_NO_SUCH_SERVICE
+	case errors.Is(err, dispatch.ErrNoSuchMethod):
+		code = rpc.ErrorStatusPB_ERROR_NO_SUCH_METHOD
+	case errors.Is(err, dispatch.ErrInvalidRequest):
+		code = rpc.ErrorStatusPB_FATAL_DESERIALIZING_REQUEST
+	}
+	return &rpc.ErrorStatusPB{
+		Message: proto.String(err.Error()),
+		Code:    code.Enum(),
+	}
+}
+
+func encodeResponse(callID int32, body proto.Message, isError bool) ([]byte, error) {
+	header := &rpc.ResponseHeader{CallId: &callID, IsError: &isError}
+
+	var sidecars [][]byte
+	if withSidecars, ok := body.(*message.ResponseWithSidecars); ok {
+		body = withSidecars.Message
+		sidecars = withSidecars.Sidecars
+	}
+
+	encodedBody, err := proto.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sidecars follow the response message, at offsets from the start of the body
+	for _, sidecar := range sidecars {
+		header.SidecarOffsets = append(header.SidecarOffsets, uint32(len(encodedBody)))
+		encodedBody = append(encodedBody, sidecar...)
+	}
+
+	encodedHeader, err := proto.Marshal(header)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	var buf [binary.MaxVarintLen32]byte
+
+	// Reserve space for the packet length, which is filled in once the rest of the packet is known
+	b.Write(make([]byte, 4))
+
+	for _, encoded := range [][]byte{encodedHeader, encodedBody} {
+		b.Write(buf[:binary.PutUvarint(buf[:], uint64(len(encoded)))])
+		b.Write(encoded)
+	}
+
+	packet := b.Bytes()
+	binary.BigEndian.PutUint32(packet[0:4], uint32(len(packet)-4))
+
+	return packet, nil
+}
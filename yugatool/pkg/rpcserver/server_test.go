@@ -0,0 +1,134 @@
+package rpcserver_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-logr/logr"
+	. "github.com/icza/gox/gox"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/common"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/rpc"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/server"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client"
+	yberrors "github.com/yugabyte/yb-tools/yugatool/pkg/client/errors"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client/message"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client/session"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/rpcserver"
+)
+
+type genericHandler struct {
+	server.UnimplementedGenericServiceServer
+
+	flags map[string]string
+}
+
+func (h *genericHandler) Ping(_ context.Context, _ *server.PingRequestPB) (*server.PingResponsePB, error) {
+	return &server.PingResponsePB{}, nil
+}
+
+func (h *genericHandler) GetStatus(_ context.Context, _ *server.GetStatusRequestPB) (*server.GetStatusResponsePB, error) {
+	return &server.GetStatusResponsePB{
+		Status: &server.ServerStatusPB{
+			NodeInstance: &common.NodeInstancePB{PermanentUuid: []byte("0123456789abcdef0123456789abcdef"), InstanceSeqno: NewInt64(1)},
+		},
+	}, nil
+}
+
+func (h *genericHandler) GetFlag(_ context.Context, request *server.GetFlagRequestPB) (*server.GetFlagResponsePB, error) {
+	value, ok := h.flags[request.GetFlag()]
+	if !ok {
+		return nil, errors.New("no such flag")
+	}
+	return &server.GetFlagResponsePB{Valid: NewBool(true), Value: NewString(value)}, nil
+}
+
+func (h *genericHandler) SetFlag(_ context.Context, _ *server.SetFlagRequestPB) (*server.SetFlagResponsePB, error) {
+	return nil, rpcserver.Errorf(rpc.ErrorStatusPB_ERROR_SERVER_TOO_BUSY, "too busy")
+}
+
+var _ = Describe("Server", func() {
+	var (
+		network *rpcserver.Network
+		host    *client.HostState
+	)
+
+	BeforeEach(func() {
+		network = rpcserver.NewNetwork()
+
+		s := rpcserver.NewServer(logr.Discard())
+		server.RegisterGenericService(s, &genericHandler{flags: map[string]string{"max_clock_skew_usec": "500000"}})
+		network.Listen("tserver-1:9100", s)
+
+		var err error
+		host, err = client.NewHostState(context.Background(), logr.Discard(),
+			&common.HostPortPB{Host: NewString("tserver-1"), Port: NewUint32(9100)}, network, message.DefaultTimeout)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		_ = host.Close()
+	})
+
+	It("serves the connection hello, ping and status", func() {
+		Expect(host.Status.GetNodeInstance().GetPermanentUuid()).To(BeEquivalentTo("0123456789abcdef0123456789abcdef"))
+	})
+
+	It("dispatches calls to the handler", func() {
+		response, err := host.GenericService.GetFlag(&server.GetFlagRequestPB{Flag: NewString("max_clock_skew_usec")})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response.GetValue()).To(Equal("500000"))
+	})
+
+	It("sends handler errors as application errors", func() {
+		_, err := host.GenericService.GetFlag(&server.GetFlagRequestPB{Flag: NewString("missing")})
+
+		var rpcError *yberrors.RPCError
+		Expect(errors.As(err, &rpcError)).To(BeTrue())
+		Expect(rpcError.Status.GetCode()).To(Equal(rpc.ErrorStatusPB_ERROR_APPLICATION))
+		Expect(rpcError.Status.GetMessage()).To(Equal("no such flag"))
+	})
+
+	It("sends RPC errors returned by handlers with their code", func() {
+		_, err := host.GenericService.SetFlag(&server.SetFlagRequestPB{Flag: NewString("max_clock_skew_usec"), Value: NewString("1")})
+		Expect(errors.Is(err, yberrors.ErrServiceUnavailable)).To(BeTrue())
+	})
+
+	It("rejects methods the handler does not implement", func() {
+		_, err := host.GenericService.RefreshFlags(&server.RefreshFlagsRequestPB{})
+		Expect(err).To(MatchError(ContainSubstring("ERROR_NO_SUCH_METHOD")))
+	})
+
+	It("rejects methods the service does not have", func() {
+		s, err := session.NewSession(logr.Discard(), &common.HostPortPB{Host: NewString("tserver-1"), Port: NewUint32(9100)},
+			network, func(*session.Session) error { return nil })
+		Expect(err).NotTo(HaveOccurred())
+		defer s.Close()
+
+		err = message.NewMessenger(s, message.DefaultTimeout).SendMessage(context.Background(), "yb.server.GenericService", "NoSuchMethod",
+			&server.PingRequestPB{}, &server.PingResponsePB{})
+		Expect(err).To(MatchError(ContainSubstring("ERROR_NO_SUCH_METHOD")))
+	})
+
+	It("rejects services that are not registered", func() {
+		_, err := host.MasterService.ListMasters(nil)
+		Expect(err).To(MatchError(ContainSubstring("ERROR_NO_SUCH_SERVICE")))
+	})
+
+	When("the address is stopped", func() {
+		BeforeEach(func() {
+			network.Stop("tserver-1:9100")
+		})
+
+		It("fails calls on open connections", func() {
+			_, err := host.GenericService.Ping(&server.PingRequestPB{})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("refuses new connections", func() {
+			_, err := network.Dial("tcp", "tserver-1:9100")
+			Expect(err).To(MatchError(ContainSubstring("connection refused")))
+		})
+	})
+})
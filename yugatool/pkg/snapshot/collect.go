@@ -0,0 +1,231 @@
+package snapshot
+
+import (
+	"context"
+	"sync"
+
+	"github.com/go-logr/logr"
+	. "github.com/icza/gox/gox"
+	"github.com/pkg/errors"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/common"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/consensus"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/master"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/server"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/tablet"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/tserver"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client"
+	yberrors "github.com/yugabyte/yb-tools/yugatool/pkg/client/errors"
+	"golang.org/x/sync/errgroup"
+)
+
+// DefaultFlags are the gflags saved when no others are asked for
+var DefaultFlags = []string{
+	"enable_load_balancing",
+	"load_balancer_max_concurrent_adds",
+	"load_balancer_max_concurrent_moves",
+	"load_balancer_max_concurrent_removals",
+	"replication_factor",
+	"placement_cloud",
+	"placement_region",
+	"placement_zone",
+	"memory_limit_hard_bytes",
+	"ysql_max_connections",
+	"log_min_seconds_to_retain",
+	"timestamp_history_retention_interval_sec",
+	"follower_unavailable_considered_failed_sec",
+	"tserver_unresponsive_timeout_ms",
+	"v",
+}
+
+// State is the state of a universe as saved in a snapshot.
+type State struct {
+	ClusterConfig *master.SysClusterConfigEntryPB
+	Masters       []*common.ServerEntryPB
+	TabletServers []*master.ListTabletServersResponsePB_Entry
+	Tables        []*master.ListTablesResponsePB_TableInfo
+
+	// Schemas of the tables, by table ID
+	Schemas map[string]*master.GetTableSchemaResponsePB
+
+	// Tablets hosted by each tablet server, by the server's UUID. Tablet
+	// servers that could not be reached are left out.
+	Tablets map[string][]*TabletReplica
+
+	// Flags of each server, by the server's UUID and then the flag name. Flags
+	// a server does not have are left out.
+	Flags map[string]map[string]string
+}
+
+// TabletReplica is a tablet replica hosted by a tablet server.
+type TabletReplica struct {
+	Status         *tablet.TabletStatusPB
+	ConsensusState *consensus.GetConsensusStateResponsePB
+}
+
+type CollectOptions struct {
+	// Flags are the gflags to save from each server
+	Flags []string
+	// Concurrency is the maximum number of concurrent requests to each tablet server
+	Concurrency int
+}
+
+// Collect reads the state of the universe the client is connected to. The calls
+// made are those cluster_info makes, so a recording of them can answer it.
+func Collect(ctx context.Context, log logr.Logger, c *client.YBClient, options CollectOptions) (*State, error) {
+	state := &State{
+		Schemas: make(map[string]*master.GetTableSchemaResponsePB),
+		Tablets: make(map[string][]*TabletReplica),
+		Flags:   make(map[string]map[string]string),
+	}
+
+	clusterConfig, err := c.Master.MasterService.GetMasterClusterConfigWithContext(ctx, &master.GetMasterClusterConfigRequestPB{})
+	if err != nil {
+		return nil, err
+	}
+	if err := yberrors.FromResponse(clusterConfig); err != nil {
+		return nil, errors.Wrap(err, "could not get cluster config")
+	}
+	state.ClusterConfig = clusterConfig.GetClusterConfig()
+
+	masters, err := c.Master.MasterService.ListMastersWithContext(ctx, &master.ListMastersRequestPB{})
+	if err != nil {
+		return nil, err
+	}
+	if err := yberrors.FromResponse(masters); err != nil {
+		return nil, errors.Wrap(err, "could not list masters")
+	}
+	state.Masters = masters.GetMasters()
+
+	// The quorum status is not part of the state, but is collected for cluster_info
+	_, err = c.MasterQuorumStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tabletServers, err := c.Master.MasterService.ListTabletServersWithContext(ctx, &master.ListTabletServersRequestPB{})
+	if err != nil {
+		return nil, err
+	}
+	if err := yberrors.FromResponse(tabletServers); err != nil {
+		return nil, errors.Wrap(err, "could not list tablet servers")
+	}
+	state.TabletServers = tabletServers.GetServers()
+
+	tables, err := c.Master.MasterService.ListTablesWithContext(ctx, &master.ListTablesRequestPB{})
+	if err != nil {
+		return nil, err
+	}
+	if err := yberrors.FromResponse(tables); err != nil {
+		return nil, errors.Wrap(err, "could not list tables")
+	}
+
+	for _, table := range tables.GetTables() {
+		schema, err := c.Master.MasterService.GetTableSchemaWithContext(ctx, &master.GetTableSchemaRequestPB{
+			Table: &master.TableIdentifierPB{TableId: table.GetId()},
+		})
+		if err != nil {
+			return nil, err
+		}
+		// The table may have been dropped since it was listed
+		if err := yberrors.FromResponse(schema); err != nil {
+			log.Info("skipping table without a schema", "table", table.GetName(), "id", string(table.GetId()), "error", err.Error())
+			continue
+		}
+		state.Tables = append(state.Tables, table)
+		state.Schemas[string(table.GetId())] = schema
+	}
+
+	for _, ts := range state.TabletServers {
+		uuid := string(ts.GetInstanceId().GetPermanentUuid())
+		host, err := c.GetHostByUUIDWithContext(ctx, ts.GetInstanceId().GetPermanentUuid())
+		if err != nil {
+			log.Error(err, "could not connect to tablet server", "uuid", uuid)
+			continue
+		}
+
+		state.Tablets[uuid], err = collectTablets(ctx, host, options.Concurrency)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not list the tablets of tablet server %s", uuid)
+		}
+		state.Flags[uuid], err = collectFlags(ctx, host, options.Flags)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get the flags of tablet server %s", uuid)
+		}
+	}
+
+	masterHosts, errs := c.AllMasters(ctx)
+	for _, err := range errs {
+		log.Error(err, "could not connect to master")
+	}
+	for _, host := range masterHosts {
+		uuid := string(host.Status.GetNodeInstance().GetPermanentUuid())
+		state.Flags[uuid], err = collectFlags(ctx, host, options.Flags)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get the flags of master %s", uuid)
+		}
+	}
+
+	return state, nil
+}
+
+// collectTablets lists the tablets of the tablet server with their consensus
+// state, pipelining the consensus state requests over the connection
+func collectTablets(ctx context.Context, host *client.HostState, concurrency int) ([]*TabletReplica, error) {
+	tablets, err := host.TabletServerService.ListTabletsWithContext(ctx, &tserver.ListTabletsRequestPB{})
+	if err != nil {
+		return nil, err
+	}
+	if err := yberrors.FromResponse(tablets); err != nil {
+		return nil, err
+	}
+
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	replicas := make([]*TabletReplica, len(tablets.GetStatusAndSchema()))
+	g := &errgroup.Group{}
+	g.SetLimit(concurrency)
+	for i, t := range tablets.GetStatusAndSchema() {
+		i, t := i, t
+		g.Go(func() error {
+			consensusState, err := host.ConsensusService.GetConsensusStateWithContext(ctx, &consensus.GetConsensusStateRequestPB{
+				DestUuid: host.Status.GetNodeInstance().GetPermanentUuid(),
+				TabletId: []byte(t.GetTabletStatus().GetTabletId()),
+				Type:     common.ConsensusConfigType_CONSENSUS_CONFIG_COMMITTED.Enum(),
+			})
+			if err != nil {
+				return err
+			}
+			replicas[i] = &TabletReplica{
+				Status:         t.GetTabletStatus(),
+				ConsensusState: consensusState,
+			}
+			return nil
+		})
+	}
+	return replicas, g.Wait()
+}
+
+func collectFlags(ctx context.Context, host *client.HostState, flags []string) (map[string]string, error) {
+	values := make(map[string]string)
+
+	m := &sync.Mutex{}
+	g := &errgroup.Group{}
+	for _, flag := range flags {
+		flag := flag
+		g.Go(func() error {
+			response, err := host.GenericService.GetFlagWithContext(ctx, &server.GetFlagRequestPB{Flag: NewString(flag)})
+			if err != nil {
+				return err
+			}
+			if response.GetValid() {
+				m.Lock()
+				values[flag] = response.GetValue()
+				m.Unlock()
+			}
+			return nil
+		})
+	}
+	return values, g.Wait()
+}
@@ -0,0 +1,355 @@
+package snapshot
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/common"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/util"
+)
+
+// Kinds of changes between two snapshots, in the order they are reported
+const (
+	TableAdded          = "table added"
+	TableRemoved        = "table removed"
+	ServerUnreachable   = "tserver unreachable"
+	TabletMoved         = "tablet moved"
+	ReplicaCountChanged = "replica count changed"
+	LeaderChanged       = "leader changed"
+	FlagChanged         = "flag changed"
+	SSTSizeChanged      = "sst size changed"
+)
+
+var kindOrder = map[string]int{
+	TableAdded:          0,
+	TableRemoved:        1,
+	ServerUnreachable:   2,
+	TabletMoved:         3,
+	ReplicaCountChanged: 4,
+	LeaderChanged:       5,
+	FlagChanged:         6,
+	SSTSizeChanged:      7,
+}
+
+// Change is a difference between the states of a universe at two points in time.
+type Change struct {
+	Kind   string `json:"kind"`
+	Object string `json:"object"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// Diff returns the changes from the before state to the after state.
+func Diff(before, after *State) []Change {
+	var changes []Change
+
+	names := serverNames(before, after)
+	unreachable := unreachableServers(before, after)
+	changes = append(changes, diffTables(before, after)...)
+	changes = append(changes, diffUnreachable(before, after, names)...)
+	changes = append(changes, diffTablets(before, after, names, unreachable)...)
+	changes = append(changes, diffFlags(before, after, names)...)
+	changes = append(changes, diffSSTSizes(before, after, names, unreachable)...)
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].Kind != changes[j].Kind {
+			return kindOrder[changes[i].Kind] < kindOrder[changes[j].Kind]
+		}
+		return changes[i].Object < changes[j].Object
+	})
+	return changes
+}
+
+// serverNames returns the address of each server by UUID, so changes name the
+// servers by address
+func serverNames(states ...*State) map[string]string {
+	names := make(map[string]string)
+	for _, state := range states {
+		for _, m := range state.Masters {
+			if addresses := m.GetRegistration().GetPrivateRpcAddresses(); len(addresses) > 0 {
+				names[string(m.GetInstanceId().GetPermanentUuid())] = util.HostPortString(addresses[0])
+			}
+		}
+		for _, ts := range state.TabletServers {
+			if addresses := ts.GetRegistration().GetCommon().GetPrivateRpcAddresses(); len(addresses) > 0 {
+				names[string(ts.GetInstanceId().GetPermanentUuid())] = util.HostPortString(addresses[0])
+			}
+		}
+	}
+	return names
+}
+
+func serverName(names map[string]string, uuid string) string {
+	if name, ok := names[uuid]; ok {
+		return name
+	}
+	return uuid
+}
+
+func tableName(namespace, name, id string) string {
+	return fmt.Sprintf("%s.%s (%s)", namespace, name, id)
+}
+
+func diffTables(before, after *State) []Change {
+	tables := func(state *State) map[string]string {
+		names := make(map[string]string)
+		for _, table := range state.Tables {
+			id := string(table.GetId())
+			names[id] = tableName(table.GetNamespace().GetName(), table.GetName(), id)
+		}
+		return names
+	}
+	beforeTables, afterTables := tables(before), tables(after)
+
+	var changes []Change
+	for id, name := range afterTables {
+		if _, ok := beforeTables[id]; !ok {
+			changes = append(changes, Change{Kind: TableAdded, Object: name})
+		}
+	}
+	for id, name := range beforeTables {
+		if _, ok := afterTables[id]; !ok {
+			changes = append(changes, Change{Kind: TableRemoved, Object: name})
+		}
+	}
+	return changes
+}
+
+// unreachable returns the UUIDs of the tablet servers whose tablets could not
+// be listed when the state was collected
+func unreachable(state *State) map[string]bool {
+	servers := make(map[string]bool)
+	for _, ts := range state.TabletServers {
+		uuid := string(ts.GetInstanceId().GetPermanentUuid())
+		if _, ok := state.Tablets[uuid]; !ok {
+			servers[uuid] = true
+		}
+	}
+	return servers
+}
+
+// unreachableServers returns the UUIDs of the tablet servers that were
+// unreachable in either state. Their replicas are left out of the comparison,
+// as the replicas missing from one state have not moved.
+func unreachableServers(before, after *State) map[string]bool {
+	servers := unreachable(before)
+	for uuid := range unreachable(after) {
+		servers[uuid] = true
+	}
+	return servers
+}
+
+func diffUnreachable(before, after *State, names map[string]string) []Change {
+	beforeServers, afterServers := unreachable(before), unreachable(after)
+	reachability := func(unreachable bool) string {
+		if unreachable {
+			return "unreachable"
+		}
+		return "reachable"
+	}
+
+	var changes []Change
+	for uuid := range unreachableServers(before, after) {
+		changes = append(changes, Change{
+			Kind:   ServerUnreachable,
+			Object: "tablet server " + serverName(names, uuid),
+			Before: reachability(beforeServers[uuid]),
+			After:  reachability(afterServers[uuid]),
+		})
+	}
+	return changes
+}
+
+// tabletState is a tablet as seen from all of its replicas
+type tabletState struct {
+	table    string
+	replicas []string
+	leader   string
+}
+
+// tabletStates gathers the replicas of each tablet from the tablet servers that
+// host them, except the skipped ones. Tombstoned and deleted replicas are not
+// counted.
+func tabletStates(state *State, skipped map[string]bool) map[string]*tabletState {
+	tablets := make(map[string]*tabletState)
+	for uuid, replicas := range state.Tablets {
+		if skipped[uuid] {
+			continue
+		}
+		for _, replica := range replicas {
+			switch replica.Status.GetTabletDataState() {
+			case common.TabletDataState_TABLET_DATA_TOMBSTONED, common.TabletDataState_TABLET_DATA_DELETED:
+				continue
+			}
+
+			id := replica.Status.GetTabletId()
+			t, ok := tablets[id]
+			if !ok {
+				t = &tabletState{
+					table: tableName(replica.Status.GetNamespaceName(), replica.Status.GetTableName(), replica.Status.GetTableId()),
+				}
+				tablets[id] = t
+			}
+			t.replicas = append(t.replicas, uuid)
+			if leader := replica.ConsensusState.GetCstate().GetLeaderUuid(); leader != "" {
+				t.leader = leader
+			}
+		}
+	}
+	return tablets
+}
+
+func diffTablets(before, after *State, names map[string]string, unreachable map[string]bool) []Change {
+	beforeTablets, afterTablets := tabletStates(before, unreachable), tabletStates(after, unreachable)
+
+	replicaNames := func(uuids []string) string {
+		var replicas []string
+		for _, uuid := range uuids {
+			replicas = append(replicas, serverName(names, uuid))
+		}
+		sort.Strings(replicas)
+		return strings.Join(replicas, ",")
+	}
+
+	var changes []Change
+	for id, b := range beforeTablets {
+		a, ok := afterTablets[id]
+		if !ok {
+			continue
+		}
+		object := fmt.Sprintf("tablet %s of %s", id, b.table)
+
+		if len(a.replicas) != len(b.replicas) {
+			changes = append(changes, Change{
+				Kind:   ReplicaCountChanged,
+				Object: object,
+				Before: fmt.Sprintf("%d (%s)", len(b.replicas), replicaNames(b.replicas)),
+				After:  fmt.Sprintf("%d (%s)", len(a.replicas), replicaNames(a.replicas)),
+			})
+		} else if removed, added := difference(b.replicas, a.replicas), difference(a.replicas, b.replicas); len(added) > 0 {
+			changes = append(changes, Change{
+				Kind:   TabletMoved,
+				Object: object,
+				Before: replicaNames(removed),
+				After:  replicaNames(added),
+			})
+		}
+
+		if a.leader != b.leader && a.leader != "" && b.leader != "" {
+			changes = append(changes, Change{
+				Kind:   LeaderChanged,
+				Object: object,
+				Before: serverName(names, b.leader),
+				After:  serverName(names, a.leader),
+			})
+		}
+	}
+	return changes
+}
+
+// difference returns the elements of a that are not in b
+func difference(a, b []string) []string {
+	inB := make(map[string]bool)
+	for _, s := range b {
+		inB[s] = true
+	}
+
+	var diff []string
+	for _, s := range a {
+		if !inB[s] {
+			diff = append(diff, s)
+		}
+	}
+	return diff
+}
+
+// diffFlags compares the flags of the servers saved in both snapshots
+func diffFlags(before, after *State, names map[string]string) []Change {
+	var changes []Change
+	for uuid, beforeFlags := range before.Flags {
+		afterFlags, ok := after.Flags[uuid]
+		if !ok {
+			continue
+		}
+
+		flags := make(map[string]bool)
+		for flag := range beforeFlags {
+			flags[flag] = true
+		}
+		for flag := range afterFlags {
+			flags[flag] = true
+		}
+
+		for flag := range flags {
+			beforeValue, inBefore := beforeFlags[flag]
+			afterValue, inAfter := afterFlags[flag]
+			// A flag only saved in one snapshot was not asked for in the other
+			if !inBefore || !inAfter || beforeValue == afterValue {
+				continue
+			}
+			changes = append(changes, Change{
+				Kind:   FlagChanged,
+				Object: fmt.Sprintf("%s on %s", flag, serverName(names, uuid)),
+				Before: beforeValue,
+				After:  afterValue,
+			})
+		}
+	}
+	return changes
+}
+
+// diffSSTSizes compares the SST file sizes of each tablet server, and of each
+// table summed over its replicas on the tablet servers reachable in both states
+func diffSSTSizes(before, after *State, names map[string]string, unreachable map[string]bool) []Change {
+	var changes []Change
+	sizeChange := func(object string, beforeSize, afterSize int64) {
+		if beforeSize == afterSize {
+			return
+		}
+		changes = append(changes, Change{
+			Kind:   SSTSizeChanged,
+			Object: object,
+			Before: strconv.FormatInt(beforeSize, 10),
+			After:  fmt.Sprintf("%d (%+d)", afterSize, afterSize-beforeSize),
+		})
+	}
+
+	serverSizes := func(state *State) map[string]int64 {
+		sizes := make(map[string]int64)
+		for _, ts := range state.TabletServers {
+			if ts.GetMetrics() != nil {
+				sizes[string(ts.GetInstanceId().GetPermanentUuid())] = ts.GetMetrics().GetTotalSstFileSize()
+			}
+		}
+		return sizes
+	}
+	beforeServers, afterServers := serverSizes(before), serverSizes(after)
+	for uuid, beforeSize := range beforeServers {
+		if afterSize, ok := afterServers[uuid]; ok {
+			sizeChange("tablet server "+serverName(names, uuid), beforeSize, afterSize)
+		}
+	}
+
+	tableSizes := func(state *State) map[string]int64 {
+		sizes := make(map[string]int64)
+		for uuid, replicas := range state.Tablets {
+			if unreachable[uuid] {
+				continue
+			}
+			for _, replica := range replicas {
+				table := tableName(replica.Status.GetNamespaceName(), replica.Status.GetTableName(), replica.Status.GetTableId())
+				sizes[table] += replica.Status.GetSstFilesDiskSize()
+			}
+		}
+		return sizes
+	}
+	beforeTables, afterTables := tableSizes(before), tableSizes(after)
+	for table, beforeSize := range beforeTables {
+		if afterSize, ok := afterTables[table]; ok {
+			sizeChange("table "+table, beforeSize, afterSize)
+		}
+	}
+	return changes
+}
@@ -0,0 +1,117 @@
+package snapshot_test
+
+import (
+	"context"
+
+	"github.com/blang/vfs/memfs"
+	"github.com/go-logr/logr"
+	. "github.com/icza/gox/gox"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/yugabyte/yb-tools/yugatool/api/yugatool/config"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/rpcserver"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/snapshot"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/test/fakecluster"
+)
+
+var _ = Describe("Diff", func() {
+	var (
+		cluster *fakecluster.Cluster
+		table   *fakecluster.Table
+	)
+
+	BeforeEach(func() {
+		cluster = fakecluster.New(logr.Discard(), rpcserver.NewNetwork(), "diff", 1, 4)
+		table = cluster.AddTable("yugabyte", "test_table", 2)
+	})
+
+	collect := func() *snapshot.State {
+		c := &client.YBClient{
+			Log: logr.Discard(),
+			Fs:  memfs.Create(),
+			Config: &config.UniverseConfigPB{
+				Masters:        cluster.MasterHostPorts(),
+				TimeoutSeconds: NewInt64(1),
+			},
+		}
+		c.OverrideDialer(cluster.Network)
+		Expect(c.Connect()).To(Succeed())
+		defer c.Close()
+
+		state, err := snapshot.Collect(context.Background(), logr.Discard(), c, snapshot.CollectOptions{
+			Flags:       []string{"v", "ysql_max_connections"},
+			Concurrency: 2,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		return state
+	}
+
+	It("collects the state of the universe", func() {
+		state := collect()
+		Expect(state.Masters).To(HaveLen(1))
+		Expect(state.TabletServers).To(HaveLen(4))
+		Expect(state.Tables).To(HaveLen(1))
+		Expect(state.Schemas).To(HaveKey(table.ID))
+		Expect(state.Tablets[cluster.TabletServers[0].UUID]).To(HaveLen(1))
+		Expect(state.Tablets[cluster.TabletServers[1].UUID]).To(HaveLen(2))
+		Expect(state.Flags[cluster.Masters[0].UUID]).To(Equal(map[string]string{"v": "0"}))
+	})
+
+	It("finds no changes in an unchanged universe", func() {
+		Expect(snapshot.Diff(collect(), collect())).To(BeEmpty())
+	})
+
+	It("leaves out the tablets of tablet servers that were unreachable", func() {
+		before := collect()
+
+		cluster.Stop(cluster.TabletServers[1])
+		after := collect()
+		Expect(after.Tablets).NotTo(HaveKey(cluster.TabletServers[1].UUID))
+
+		Expect(snapshot.Diff(before, after)).To(Equal([]snapshot.Change{
+			{Kind: snapshot.ServerUnreachable, Object: "tablet server diff-tserver-2:9100", Before: "reachable", After: "unreachable"},
+		}))
+	})
+
+	It("skips tables dropped while the state is collected", func() {
+		dropped := cluster.AddTable("yugabyte", "dropped_table", 1)
+		cluster.Lock()
+		dropped.Dropped = true
+		cluster.Unlock()
+
+		state := collect()
+		Expect(state.Tables).To(HaveLen(1))
+		Expect(state.Schemas).To(HaveKey(table.ID))
+		Expect(state.Schemas).NotTo(HaveKey(dropped.ID))
+	})
+
+	It("reports the changes to the universe", func() {
+		before := collect()
+
+		added := cluster.AddTable("yugabyte", "new_table", 1)
+		cluster.Lock()
+		// Move the last replica of the first tablet to the tablet server without one
+		table.Tablets[0].Replicas[2] = cluster.TabletServers[3]
+		table.Tablets[1].Leader = table.Tablets[1].Replicas[1]
+		table.Tablets[1].Replicas = table.Tablets[1].Replicas[:2]
+		table.Tablets[1].SSTSize = 100
+		cluster.TabletServers[0].Flags["v"] = "2"
+		cluster.Unlock()
+
+		tableName := "yugabyte.test_table (" + table.ID + ")"
+		tablet0 := "tablet " + table.Tablets[0].ID + " of " + tableName
+		tablet1 := "tablet " + table.Tablets[1].ID + " of " + tableName
+
+		Expect(snapshot.Diff(before, collect())).To(Equal([]snapshot.Change{
+			{Kind: snapshot.TableAdded, Object: "yugabyte.new_table (" + added.ID + ")"},
+			{Kind: snapshot.TabletMoved, Object: tablet0, Before: "diff-tserver-3:9100", After: "diff-tserver-4:9100"},
+			{Kind: snapshot.ReplicaCountChanged, Object: tablet1, Before: "3 (diff-tserver-2:9100,diff-tserver-3:9100,diff-tserver-4:9100)", After: "2 (diff-tserver-2:9100,diff-tserver-3:9100)"},
+			{Kind: snapshot.LeaderChanged, Object: tablet1, Before: "diff-tserver-2:9100", After: "diff-tserver-3:9100"},
+			{Kind: snapshot.FlagChanged, Object: "v on diff-tserver-1:9100", Before: "0", After: "2"},
+			{Kind: snapshot.SSTSizeChanged, Object: "table " + tableName, Before: "0", After: "200 (+200)"},
+			{Kind: snapshot.SSTSizeChanged, Object: "tablet server diff-tserver-2:9100", Before: "0", After: "100 (+100)"},
+			{Kind: snapshot.SSTSizeChanged, Object: "tablet server diff-tserver-3:9100", Before: "0", After: "100 (+100)"},
+		}))
+	})
+})
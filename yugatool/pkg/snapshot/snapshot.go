@@ -0,0 +1,145 @@
+// Package snapshot saves the state of a universe to an archive, and compares the
+// states saved at two points in time.
+//
+// A snapshot archive holds a manifest and the RPCs made while collecting the
+// state, as written by a recording.Recorder. The state is read back by
+// replaying those RPCs, so any command whose calls were recorded, such as
+// cluster_info, can run against a snapshot as it would against the universe.
+package snapshot
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/recording"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/rpcserver"
+)
+
+// FormatVersion is the version of the archive format written by Save. Load
+// reads archives of this version and older.
+const FormatVersion = 1
+
+const (
+	manifestFile = "manifest.json"
+	callsFile    = "rpcs.jsonl"
+)
+
+// Manifest describes a snapshot.
+type Manifest struct {
+	Version      int       `json:"version"`
+	Created      time.Time `json:"created"`
+	ToolsVersion string    `json:"tools_version"`
+	// Masters are the addresses, as host:port, of the masters the snapshot was
+	// taken through, which the recorded calls are answered at
+	Masters []string `json:"masters"`
+	// Flags are the gflags saved from each server
+	Flags []string `json:"flags"`
+}
+
+type Snapshot struct {
+	Manifest Manifest
+	Calls    []*recording.Call
+}
+
+// Save writes the snapshot to w as a gzipped tar archive.
+func Save(w io.Writer, s *Snapshot) error {
+	manifest, err := json.MarshalIndent(s.Manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	calls := &bytes.Buffer{}
+	enc := json.NewEncoder(calls)
+	for _, call := range s.Calls {
+		err := enc.Encode(call)
+		if err != nil {
+			return err
+		}
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	for _, file := range []struct {
+		name     string
+		contents []byte
+	}{
+		{manifestFile, manifest},
+		{callsFile, calls.Bytes()},
+	} {
+		err := tw.WriteHeader(&tar.Header{
+			Name:    file.name,
+			Mode:    0644,
+			Size:    int64(len(file.contents)),
+			ModTime: s.Manifest.Created,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = tw.Write(file.contents)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = tw.Close()
+	if err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// Load reads a snapshot written by Save.
+func Load(r io.Reader) (*Snapshot, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid snapshot")
+	}
+	defer gz.Close()
+
+	s := &Snapshot{}
+	var haveManifest bool
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid snapshot")
+		}
+
+		switch header.Name {
+		case manifestFile:
+			err = json.NewDecoder(tr).Decode(&s.Manifest)
+			if err != nil {
+				return nil, errors.Wrap(err, "invalid snapshot manifest")
+			}
+			haveManifest = true
+		case callsFile:
+			s.Calls, err = recording.Load(tr)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if !haveManifest {
+		return nil, errors.New("invalid snapshot: no manifest")
+	}
+	if s.Manifest.Version > FormatVersion {
+		return nil, errors.Errorf("snapshot version %d is newer than the supported version %d, upgrade yugatool to read it", s.Manifest.Version, FormatVersion)
+	}
+	return s, nil
+}
+
+// Network returns a network that answers the recorded calls at the addresses
+// they were made to.
+func (s *Snapshot) Network(log logr.Logger) *rpcserver.Network {
+	return recording.NewReplayNetwork(log, s.Calls)
+}
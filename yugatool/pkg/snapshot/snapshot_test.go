@@ -0,0 +1,80 @@
+package snapshot_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/recording"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/snapshot"
+)
+
+// archive writes a snapshot archive holding the files
+func archive(files map[string][]byte) *bytes.Buffer {
+	buf := &bytes.Buffer{}
+	gz := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gz)
+	for name, contents := range files {
+		Expect(tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(contents))})).To(Succeed())
+		_, err := tw.Write(contents)
+		Expect(err).NotTo(HaveOccurred())
+	}
+	Expect(tw.Close()).To(Succeed())
+	Expect(gz.Close()).To(Succeed())
+	return buf
+}
+
+var _ = Describe("Snapshot", func() {
+	It("loads the snapshot it saved", func() {
+		s := &snapshot.Snapshot{
+			Manifest: snapshot.Manifest{
+				Version:      snapshot.FormatVersion,
+				Created:      time.Date(2022, 3, 4, 5, 6, 7, 0, time.UTC),
+				ToolsVersion: "v1.2.3",
+				Masters:      []string{"master-1:7100", "master-2:7100"},
+				Flags:        []string{"v"},
+			},
+			Calls: []*recording.Call{
+				{
+					Host:     "master-1:7100",
+					Service:  "yb.master.MasterService",
+					Method:   "ListMasters",
+					Request:  json.RawMessage(`{}`),
+					Response: json.RawMessage(`{"masters":[]}`),
+				},
+			},
+		}
+
+		buf := &bytes.Buffer{}
+		Expect(snapshot.Save(buf, s)).To(Succeed())
+
+		loaded, err := snapshot.Load(buf)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(loaded.Manifest).To(Equal(s.Manifest))
+		Expect(loaded.Calls).To(HaveLen(1))
+		Expect(loaded.Calls[0].Method).To(Equal("ListMasters"))
+		Expect(loaded.Calls[0].Response).To(MatchJSON(`{"masters":[]}`))
+	})
+
+	It("rejects snapshots of a newer version", func() {
+		manifest, err := json.Marshal(snapshot.Manifest{Version: snapshot.FormatVersion + 1})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = snapshot.Load(archive(map[string][]byte{"manifest.json": manifest}))
+		Expect(err).To(MatchError(ContainSubstring("upgrade yugatool")))
+	})
+
+	It("rejects archives without a manifest", func() {
+		_, err := snapshot.Load(archive(map[string][]byte{"rpcs.jsonl": nil}))
+		Expect(err).To(MatchError("invalid snapshot: no manifest"))
+	})
+
+	It("rejects files that are not archives", func() {
+		_, err := snapshot.Load(bytes.NewBufferString("not a snapshot"))
+		Expect(err).To(MatchError(ContainSubstring("invalid snapshot")))
+	})
+})
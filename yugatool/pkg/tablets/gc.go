@@ -0,0 +1,216 @@
+package tablets
+
+import (
+	"context"
+	"sort"
+
+	"github.com/go-logr/logr"
+	. "github.com/icza/gox/gox"
+	"github.com/pkg/errors"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/common"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/master"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/tserver"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client"
+	yberrors "github.com/yugabyte/yb-tools/yugatool/pkg/client/errors"
+)
+
+// Results of a tombstoned replica
+const (
+	ResultPending = "pending"
+	ResultDeleted = "deleted"
+	ResultSkipped = "skipped"
+	ResultFailed  = "failed"
+)
+
+// TombstonedReplica is a tombstoned replica of a tablet left on a tablet server,
+// and what became of it.
+type TombstonedReplica struct {
+	TabletServer     string `json:"tserver"`
+	TabletServerUUID string `json:"tserver_uuid"`
+	Tablet           string `json:"tablet"`
+	Namespace        string `json:"namespace"`
+	Table            string `json:"table"`
+	WALSize          int64  `json:"wal_size"`
+	SSTSize          int64  `json:"sst_size"`
+	Result           string `json:"result"`
+	Error            string `json:"error,omitempty"`
+
+	// ConfigOpidIndex is the OpId index of the committed Raft config of the
+	// tablet when the replica was found, which left the replica out. It is unset
+	// for a tablet the master does not know of.
+	ConfigOpidIndex *int64 `json:"config_opid_index,omitempty"`
+}
+
+// FindTombstoned lists the tombstoned replicas on the live tablet servers. The
+// master is asked for the locations of their tablets, and a replica on a tablet
+// server that is still a peer of its tablet, such as one being remote
+// bootstrapped again, is skipped. A tablet the master does not know of, such as
+// one of a deleted table, has no peers. The committed Raft config of each other
+// tablet is read from its leader, so the replica is only deleted while that
+// config is the newest the tablet server knows of.
+func FindTombstoned(ctx context.Context, log logr.Logger, c *client.YBClient) ([]*TombstonedReplica, error) {
+	tabletServers, err := c.Master.MasterService.ListTabletServersWithContext(ctx, &master.ListTabletServersRequestPB{})
+	if err != nil {
+		return nil, err
+	}
+	if err := yberrors.FromResponse(tabletServers); err != nil {
+		return nil, errors.Wrap(err, "could not list tablet servers")
+	}
+
+	replicas := []*TombstonedReplica{}
+	for _, ts := range tabletServers.GetServers() {
+		uuid := string(ts.GetInstanceId().GetPermanentUuid())
+		if !ts.GetAlive() {
+			log.Info("skipping tablet server that is not alive", "uuid", uuid)
+			continue
+		}
+		host, err := c.GetHostByUUIDWithContext(ctx, ts.GetInstanceId().GetPermanentUuid())
+		if err != nil {
+			log.Error(err, "could not connect to tablet server", "uuid", uuid)
+			continue
+		}
+
+		tablets, err := host.TabletServerService.ListTabletsWithContext(ctx, &tserver.ListTabletsRequestPB{})
+		if err != nil {
+			return nil, err
+		}
+		if err := yberrors.FromResponse(tablets); err != nil {
+			return nil, errors.Wrapf(err, "could not list the tablets of tablet server %s", uuid)
+		}
+
+		for _, t := range tablets.GetStatusAndSchema() {
+			status := t.GetTabletStatus()
+			if status.GetTabletDataState() != common.TabletDataState_TABLET_DATA_TOMBSTONED {
+				continue
+			}
+			replicas = append(replicas, &TombstonedReplica{
+				TabletServer:     client.TabletServerAddress(ts),
+				TabletServerUUID: uuid,
+				Tablet:           status.GetTabletId(),
+				Namespace:        status.GetNamespaceName(),
+				Table:            status.GetTableName(),
+				WALSize:          status.GetWalFilesDiskSize(),
+				SSTSize:          status.GetSstFilesDiskSize(),
+				Result:           ResultPending,
+			})
+		}
+	}
+
+	if len(replicas) == 0 {
+		return replicas, nil
+	}
+
+	configs, err := tabletConfigs(ctx, c, replicas)
+	if err != nil {
+		return nil, err
+	}
+	for _, replica := range replicas {
+		config := configs[replica.Tablet]
+		switch {
+		case config.peers[replica.TabletServerUUID]:
+			replica.Result = ResultSkipped
+			replica.Error = "tablet server is a peer of the tablet"
+		case config.err != nil:
+			replica.Result = ResultSkipped
+			replica.Error = config.err.Error()
+		default:
+			replica.ConfigOpidIndex = config.opidIndex
+		}
+	}
+
+	sort.SliceStable(replicas, func(i, j int) bool {
+		if replicas[i].TabletServer != replicas[j].TabletServer {
+			return replicas[i].TabletServer < replicas[j].TabletServer
+		}
+		return replicas[i].Tablet < replicas[j].Tablet
+	})
+	return replicas, nil
+}
+
+// DeleteTombstoned deletes the pending replicas, returning the number that could
+// not be deleted.
+func DeleteTombstoned(ctx context.Context, log logr.Logger, c *client.YBClient, replicas []*TombstonedReplica) int {
+	failed := 0
+	for _, replica := range replicas {
+		if replica.Result != ResultPending {
+			continue
+		}
+
+		err := DeleteReplica(ctx, c, replica.Tablet, replica.TabletServerUUID, replica.ConfigOpidIndex, "tombstoned replica deleted by yugatool tablet gc")
+		if err != nil {
+			failed++
+			replica.Result = ResultFailed
+			replica.Error = err.Error()
+			log.Info("could not delete tombstoned replica", "tablet", replica.Tablet, "tserver", replica.TabletServer, "error", err.Error())
+			continue
+		}
+		replica.Result = ResultDeleted
+		log.Info("deleted tombstoned replica", "tablet", replica.Tablet, "tserver", replica.TabletServer,
+			"wal_size", replica.WALSize, "sst_size", replica.SSTSize)
+	}
+	return failed
+}
+
+// tabletConfig is the Raft config of a tablet, as far as a tombstoned replica
+// needs it
+type tabletConfig struct {
+	peers     map[string]bool
+	opidIndex *int64
+
+	// err is why the config could not be read
+	err error
+}
+
+// tabletConfigs returns the peers of each tablet the replicas belong to, as the
+// master leader knows them, and the OpId index of its committed config, as its
+// leader knows it
+func tabletConfigs(ctx context.Context, c *client.YBClient, replicas []*TombstonedReplica) (map[string]*tabletConfig, error) {
+	request := &master.GetTabletLocationsRequestPB{}
+	configs := make(map[string]*tabletConfig)
+	for _, replica := range replicas {
+		if _, ok := configs[replica.Tablet]; !ok {
+			configs[replica.Tablet] = &tabletConfig{peers: make(map[string]bool)}
+			request.TabletIds = append(request.TabletIds, []byte(replica.Tablet))
+		}
+	}
+
+	response, err := c.Master.MasterService.GetTabletLocationsWithContext(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	if err := yberrors.FromResponse(response); err != nil {
+		return nil, errors.Wrap(err, "could not get tablet locations")
+	}
+	for _, tabletError := range response.GetErrors() {
+		if err := yberrors.FromStatus(tabletError.GetStatus()); !errors.Is(err, yberrors.ErrNotFound) {
+			return nil, errors.Wrapf(err, "could not get the locations of tablet %s", tabletError.GetTabletId())
+		}
+	}
+	for _, locations := range response.GetTabletLocations() {
+		tablet := string(locations.GetTabletId())
+		config := configs[tablet]
+		if config == nil {
+			continue
+		}
+
+		leader := ""
+		for _, replica := range locations.GetReplicas() {
+			uuid := string(replica.GetTsInfo().GetPermanentUuid())
+			config.peers[uuid] = true
+			if replica.GetRole() == common.RaftPeerPB_LEADER {
+				leader = uuid
+			}
+		}
+		if leader == "" {
+			config.err = errors.Errorf("tablet %s has no leader to read its config from", tablet)
+			continue
+		}
+		state, err := consensusState(ctx, c, tablet, leader, common.ConsensusConfigType_CONSENSUS_CONFIG_COMMITTED)
+		if err != nil {
+			config.err = err
+			continue
+		}
+		config.opidIndex = NewInt64(state.GetConfig().GetOpidIndex())
+	}
+	return configs, nil
+}
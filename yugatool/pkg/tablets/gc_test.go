@@ -0,0 +1,149 @@
+package tablets_test
+
+import (
+	"context"
+
+	"github.com/blang/vfs/memfs"
+	"github.com/go-logr/logr"
+	. "github.com/icza/gox/gox"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/common"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/consensus"
+	"github.com/yugabyte/yb-tools/yugatool/api/yugatool/config"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/rpcserver"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/tablets"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/test/fakecluster"
+)
+
+var _ = Describe("GC", func() {
+	var (
+		cluster *fakecluster.Cluster
+		table   *fakecluster.Table
+		c       *client.YBClient
+	)
+
+	BeforeEach(func() {
+		cluster = fakecluster.New(logr.Discard(), rpcserver.NewNetwork(), "gc", 1, 5)
+		table = cluster.AddTable("yugabyte", "test_table", 2)
+
+		cluster.Lock()
+		for _, tablet := range table.Tablets {
+			tablet.Tombstoned = []*fakecluster.Node{cluster.TabletServers[4]}
+			tablet.WALSize = 1000
+			tablet.SSTSize = 2000
+		}
+		cluster.Unlock()
+
+		c = &client.YBClient{
+			Log: logr.Discard(),
+			Fs:  memfs.Create(),
+			Config: &config.UniverseConfigPB{
+				Masters:        cluster.MasterHostPorts(),
+				TimeoutSeconds: NewInt64(1),
+			},
+		}
+		c.OverrideDialer(cluster.Network)
+		Expect(c.Connect()).To(Succeed())
+	})
+
+	AfterEach(func() {
+		c.Close()
+	})
+
+	It("finds the tombstoned replicas", func() {
+		replicas, err := tablets.FindTombstoned(context.Background(), logr.Discard(), c)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(replicas).To(HaveLen(2))
+		for _, replica := range replicas {
+			Expect(replica.TabletServerUUID).To(Equal(cluster.TabletServers[4].UUID))
+			Expect(replica.Table).To(Equal("test_table"))
+			Expect(replica.WALSize).To(Equal(int64(1000)))
+			Expect(replica.SSTSize).To(Equal(int64(2000)))
+			Expect(replica.Result).To(Equal(tablets.ResultPending))
+		}
+	})
+
+	It("deletes the tombstoned replicas", func() {
+		replicas, err := tablets.FindTombstoned(context.Background(), logr.Discard(), c)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tablets.DeleteTombstoned(context.Background(), logr.Discard(), c, replicas)).To(BeZero())
+		for _, replica := range replicas {
+			Expect(replica.Result).To(Equal(tablets.ResultDeleted))
+		}
+
+		cluster.Lock()
+		defer cluster.Unlock()
+		for _, tablet := range table.Tablets {
+			Expect(tablet.Tombstoned).To(BeEmpty())
+		}
+	})
+
+	It("skips replicas on tablet servers that are still peers", func() {
+		cluster.Lock()
+		table.Tablets[0].DataState = common.TabletDataState_TABLET_DATA_TOMBSTONED
+		cluster.Unlock()
+
+		replicas, err := tablets.FindTombstoned(context.Background(), logr.Discard(), c)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(replicas).To(HaveLen(5))
+
+		skipped := 0
+		for _, replica := range replicas {
+			if replica.Result == tablets.ResultSkipped {
+				Expect(replica.Tablet).To(Equal(table.Tablets[0].ID))
+				skipped++
+			}
+		}
+		Expect(skipped).To(Equal(3))
+
+		Expect(tablets.DeleteTombstoned(context.Background(), logr.Discard(), c, replicas)).To(BeZero())
+		cluster.Lock()
+		defer cluster.Unlock()
+		Expect(table.Tablets[0].Replicas).To(HaveLen(3))
+	})
+
+	It("does not delete a replica added back to its tablet after it was found", func() {
+		replicas, err := tablets.FindTombstoned(context.Background(), logr.Discard(), c)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(replicas).To(HaveLen(2))
+
+		tablet := table.Tablets[0]
+		host, err := c.GetHostByUUID([]byte(cluster.TabletServers[0].UUID))
+		Expect(err).NotTo(HaveOccurred())
+		response, err := host.ConsensusService.ChangeConfig(&consensus.ChangeConfigRequestPB{
+			DestUuid: []byte(cluster.TabletServers[0].UUID),
+			TabletId: []byte(tablet.ID),
+			Type:     consensus.ChangeConfigType_ADD_SERVER.Enum(),
+			Server: &common.RaftPeerPB{
+				PermanentUuid: []byte(cluster.TabletServers[4].UUID),
+				MemberType:    common.RaftPeerPB_PRE_VOTER.Enum(),
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response.GetError()).To(BeNil())
+
+		Expect(tablets.DeleteTombstoned(context.Background(), logr.Discard(), c, replicas)).To(Equal(1))
+		for _, replica := range replicas {
+			if replica.Tablet == tablet.ID {
+				Expect(replica.Result).To(Equal(tablets.ResultFailed))
+				Expect(replica.Error).To(ContainSubstring("config has changed"))
+			} else {
+				Expect(replica.Result).To(Equal(tablets.ResultDeleted))
+			}
+		}
+
+		cluster.Lock()
+		defer cluster.Unlock()
+		Expect(tablet.Replicas).To(ContainElement(cluster.TabletServers[4]))
+	})
+
+	It("skips tablet servers that are not alive", func() {
+		cluster.Stop(cluster.TabletServers[4])
+
+		replicas, err := tablets.FindTombstoned(context.Background(), logr.Discard(), c)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(replicas).To(BeEmpty())
+	})
+})
@@ -0,0 +1,568 @@
+// Package tablets moves the replicas of tablets between tablet servers, through
+// the Raft config of each tablet, deletes the tombstoned replicas left behind,
+// and splits tablets that have grown too large.
+package tablets
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/icza/gox/gox"
+	"github.com/pkg/errors"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/common"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/consensus"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/master"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/tserver"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/balance"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client"
+	yberrors "github.com/yugabyte/yb-tools/yugatool/pkg/client/errors"
+)
+
+// catchUpPollInterval is how often the new peer is checked while it catches up
+// with the leader
+const catchUpPollInterval = 200 * time.Millisecond
+
+// rollBackTimeout is how long the new peer is given to be removed from the Raft
+// config when a move is rolled back. The rollback does not share the context of
+// the move, which may have been cancelled or timed out.
+const rollBackTimeout = 30 * time.Second
+
+// Steps of a tablet move
+const (
+	StepAddPeer       = "add pre-voter"
+	StepCatchUp       = "catch up"
+	StepPromote       = "promote to voter"
+	StepStepDown      = "step down leader"
+	StepRemovePeer    = "remove peer"
+	StepDeleteReplica = "delete replica"
+	StepRollBack      = "roll back"
+)
+
+// MoveStep is a step of a tablet move, as planned or as it was run.
+type MoveStep struct {
+	Time   string `json:"time,omitempty"`
+	Step   string `json:"step"`
+	Detail string `json:"detail"`
+	Error  string `json:"error,omitempty"`
+}
+
+// MoveOptions are the replica to move and how to move it. The tablet servers
+// are named by their UUID, host:port or host.
+type MoveOptions struct {
+	Tablet string
+	From   string
+	To     string
+
+	// CatchUpTimeout is how long the new peer has to catch up with the leader
+	// before the move is rolled back
+	CatchUpTimeout time.Duration
+
+	// StepDownTimeout is how long a new leader has to take over when the
+	// replica moved leads the tablet
+	StepDownTimeout time.Duration
+
+	// DeleteReplica deletes the tombstoned replica left on the tablet server
+	// moved from
+	DeleteReplica bool
+}
+
+// Move moves a replica of a tablet from one tablet server to another. The new
+// peer joins the Raft config of the tablet as a PRE_VOTER, is remote
+// bootstrapped by the leader and is promoted to a VOTER once it has caught up,
+// and only then is the old peer removed, so the tablet never has fewer voters
+// than before.
+type Move struct {
+	MoveOptions
+
+	// Steps are the steps that have been run
+	Steps []*MoveStep
+
+	log    logr.Logger
+	client *client.YBClient
+
+	from, to               *master.ListTabletServersResponsePB_Entry
+	fromUUID, toUUID       string
+	fromAddress, toAddress string
+	leader                 string
+	replicationFactor      int
+}
+
+// PlanMove checks that the replica of the tablet can be moved, and returns the
+// move to run. The tablet must have a leader and a replica on the tablet server
+// moved from but not on the live tablet server moved to, and moving it must not
+// leave the tablet with fewer voters than its replication factor.
+func PlanMove(ctx context.Context, log logr.Logger, c *client.YBClient, options MoveOptions) (*Move, error) {
+	m := &Move{
+		MoveOptions: options,
+		log:         log.WithValues("tablet", options.Tablet),
+		client:      c,
+	}
+
+	var err error
+	m.from, _, err = c.FindTabletServer(ctx, options.From)
+	if err != nil {
+		return nil, err
+	}
+	m.to, _, err = c.FindTabletServer(ctx, options.To)
+	if err != nil {
+		return nil, err
+	}
+	m.fromUUID, m.fromAddress = string(m.from.GetInstanceId().GetPermanentUuid()), client.TabletServerAddress(m.from)
+	m.toUUID, m.toAddress = string(m.to.GetInstanceId().GetPermanentUuid()), client.TabletServerAddress(m.to)
+
+	if m.fromUUID == m.toUUID {
+		return nil, errors.Errorf("cannot move tablet %s to the tablet server it is on", options.Tablet)
+	}
+	if !m.to.GetAlive() {
+		return nil, errors.Errorf("tablet server %s is not alive", m.toAddress)
+	}
+
+	locations, err := tabletLocations(ctx, c, options.Tablet)
+	if err != nil {
+		return nil, err
+	}
+
+	var fromVoter, hasFrom, hasTo bool
+	voters := 0
+	for _, replica := range locations.GetReplicas() {
+		uuid := string(replica.GetTsInfo().GetPermanentUuid())
+		voter := replica.GetMemberType() == common.RaftPeerPB_VOTER
+		if voter {
+			voters++
+		}
+		switch uuid {
+		case m.fromUUID:
+			hasFrom, fromVoter = true, voter
+		case m.toUUID:
+			hasTo = true
+		}
+		if replica.GetRole() == common.RaftPeerPB_LEADER {
+			m.leader = uuid
+		}
+	}
+	if !hasFrom {
+		return nil, errors.Errorf("tablet %s has no replica on %s", options.Tablet, m.fromAddress)
+	}
+	if hasTo {
+		return nil, errors.Errorf("tablet %s already has a replica on %s", options.Tablet, m.toAddress)
+	}
+	if m.leader == "" {
+		return nil, errors.Errorf("tablet %s has no leader to change its config", options.Tablet)
+	}
+
+	m.replicationFactor, err = replicationFactor(ctx, c, string(locations.GetTableId()))
+	if err != nil {
+		return nil, err
+	}
+	if m.replicationFactor == 0 {
+		// Without a placement policy, the tablet keeps the voters it has
+		m.replicationFactor = voters
+	}
+
+	remaining := voters + 1
+	if fromVoter {
+		remaining--
+	}
+	if remaining < m.replicationFactor {
+		return nil, errors.Errorf("moving the replica would leave tablet %s with %d voters, the replication factor is %d", options.Tablet, remaining, m.replicationFactor)
+	}
+
+	return m, nil
+}
+
+// Plan returns the steps the move will run.
+func (m *Move) Plan() []*MoveStep {
+	steps := []*MoveStep{
+		{Step: StepAddPeer, Detail: fmt.Sprintf("add %s to the Raft config as a PRE_VOTER", m.toAddress)},
+		{Step: StepCatchUp, Detail: fmt.Sprintf("wait up to %s for %s to catch up with the leader", m.CatchUpTimeout, m.toAddress)},
+		{Step: StepPromote, Detail: fmt.Sprintf("promote %s to a VOTER", m.toAddress)},
+	}
+	if m.leader == m.fromUUID {
+		steps = append(steps, &MoveStep{Step: StepStepDown, Detail: fmt.Sprintf("step down the leader on %s", m.fromAddress)})
+	}
+	steps = append(steps, &MoveStep{Step: StepRemovePeer, Detail: fmt.Sprintf("remove %s from the Raft config", m.fromAddress)})
+	if m.DeleteReplica {
+		steps = append(steps, &MoveStep{Step: StepDeleteReplica, Detail: fmt.Sprintf("delete the tombstoned replica on %s", m.fromAddress)})
+	}
+	return steps
+}
+
+// Run moves the replica, recording each step in Steps. A new peer that does not
+// catch up or cannot be promoted is removed from the Raft config again.
+func (m *Move) Run(ctx context.Context) error {
+	err := m.run(StepAddPeer, fmt.Sprintf("add %s to the Raft config as a PRE_VOTER", m.toAddress), func() error {
+		return m.changeConfig(ctx, consensus.ChangeConfigType_ADD_SERVER, &common.RaftPeerPB{
+			PermanentUuid:          []byte(m.toUUID),
+			MemberType:             common.RaftPeerPB_PRE_VOTER.Enum(),
+			LastKnownPrivateAddr:   m.to.GetRegistration().GetCommon().GetPrivateRpcAddresses(),
+			LastKnownBroadcastAddr: m.to.GetRegistration().GetCommon().GetBroadcastAddresses(),
+			CloudInfo:              m.to.GetRegistration().GetCommon().GetCloudInfo(),
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	err = m.run(StepCatchUp, fmt.Sprintf("wait up to %s for %s to catch up with the leader", m.CatchUpTimeout, m.toAddress), func() error {
+		return m.catchUp(ctx)
+	})
+	if err == nil {
+		err = m.run(StepPromote, fmt.Sprintf("promote %s to a VOTER", m.toAddress), func() error {
+			return m.promote(ctx)
+		})
+	}
+	if err != nil {
+		if rollBackErr := m.rollBack(); rollBackErr != nil {
+			return errors.Wrapf(rollBackErr, "%s, and %s could not be removed from the Raft config", err, m.toAddress)
+		}
+		return err
+	}
+
+	if m.leader == m.fromUUID {
+		err = m.run(StepStepDown, fmt.Sprintf("step down the leader on %s", m.fromAddress), func() error {
+			return m.stepDown(ctx)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	err = m.run(StepRemovePeer, fmt.Sprintf("remove %s from the Raft config", m.fromAddress), func() error {
+		if err := m.checkRemove(ctx); err != nil {
+			return err
+		}
+		return m.changeConfig(ctx, consensus.ChangeConfigType_REMOVE_SERVER, &common.RaftPeerPB{
+			PermanentUuid: []byte(m.fromUUID),
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	if m.DeleteReplica {
+		return m.run(StepDeleteReplica, fmt.Sprintf("delete the tombstoned replica on %s", m.fromAddress), func() error {
+			state, err := consensusState(ctx, m.client, m.Tablet, m.leader, common.ConsensusConfigType_CONSENSUS_CONFIG_COMMITTED)
+			if err != nil {
+				return err
+			}
+			return DeleteReplica(ctx, m.client, m.Tablet, m.fromUUID, NewInt64(state.GetConfig().GetOpidIndex()), "moved by yugatool tablet move")
+		})
+	}
+	return nil
+}
+
+// run runs a step of the move, logging it for audit and recording it in Steps
+func (m *Move) run(step string, detail string, f func() error) error {
+	m.log.Info("running tablet move step", "step", step, "detail", detail)
+
+	err := f()
+
+	moveStep := &MoveStep{
+		Time:   time.Now().UTC().Format(time.RFC3339),
+		Step:   step,
+		Detail: detail,
+	}
+	if err != nil {
+		moveStep.Error = err.Error()
+		m.log.Error(err, "tablet move step failed", "step", step)
+	} else {
+		m.log.Info("tablet move step done", "step", step)
+	}
+	m.Steps = append(m.Steps, moveStep)
+	return err
+}
+
+// rollBack removes the new peer from the Raft config, leaving the tablet as it
+// was before the move
+func (m *Move) rollBack() error {
+	ctx, cancel := context.WithTimeout(context.Background(), rollBackTimeout)
+	defer cancel()
+
+	return m.run(StepRollBack, fmt.Sprintf("remove %s from the Raft config", m.toAddress), func() error {
+		return m.changeConfig(ctx, consensus.ChangeConfigType_REMOVE_SERVER, &common.RaftPeerPB{
+			PermanentUuid: []byte(m.toUUID),
+		})
+	})
+}
+
+// changeConfig changes the Raft config through the leader, only if the committed
+// config has not changed since it was read. A leader that has lost its
+// leadership is looked up again from the master once.
+func (m *Move) changeConfig(ctx context.Context, changeType consensus.ChangeConfigType, peer *common.RaftPeerPB) error {
+	err := m.tryChangeConfig(ctx, changeType, peer)
+	if errors.Is(err, yberrors.ErrNotTheLeader) {
+		m.log.Info("leader has changed, looking it up again", "leader", m.leader)
+		if err := m.refreshLeader(ctx); err != nil {
+			return err
+		}
+		err = m.tryChangeConfig(ctx, changeType, peer)
+	}
+	return err
+}
+
+func (m *Move) tryChangeConfig(ctx context.Context, changeType consensus.ChangeConfigType, peer *common.RaftPeerPB) error {
+	state, err := consensusState(ctx, m.client, m.Tablet, m.leader, common.ConsensusConfigType_CONSENSUS_CONFIG_COMMITTED)
+	if err != nil {
+		return err
+	}
+
+	host, err := m.client.GetHostByUUIDWithContext(ctx, []byte(m.leader))
+	if err != nil {
+		return err
+	}
+	response, err := host.ConsensusService.ChangeConfigWithContext(ctx, &consensus.ChangeConfigRequestPB{
+		DestUuid:           []byte(m.leader),
+		TabletId:           []byte(m.Tablet),
+		Type:               changeType.Enum(),
+		Server:             peer,
+		CasConfigOpidIndex: NewInt64(state.GetConfig().GetOpidIndex()),
+	})
+	if err != nil {
+		return err
+	}
+	if err := yberrors.FromResponse(response); err != nil {
+		return errors.Wrapf(err, "could not %s tablet server %s", changeType, peer.GetPermanentUuid())
+	}
+	return nil
+}
+
+func (m *Move) refreshLeader(ctx context.Context) error {
+	locations, err := tabletLocations(ctx, m.client, m.Tablet)
+	if err != nil {
+		return err
+	}
+	for _, replica := range locations.GetReplicas() {
+		if replica.GetRole() == common.RaftPeerPB_LEADER {
+			m.leader = string(replica.GetTsInfo().GetPermanentUuid())
+			return nil
+		}
+	}
+	return errors.Errorf("tablet %s has no leader to change its config", m.Tablet)
+}
+
+// catchUp waits for the new peer to be in the active config of the leader and
+// to have received every operation the leader has committed
+func (m *Move) catchUp(ctx context.Context) error {
+	deadline := time.Now().Add(m.CatchUpTimeout)
+	for {
+		behind, err := m.behind(ctx)
+		if err != nil {
+			return err
+		}
+		if behind == "" {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return errors.Errorf("%s did not catch up with the leader within %s: it %s", m.toAddress, m.CatchUpTimeout, behind)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(catchUpPollInterval):
+		}
+	}
+}
+
+// behind returns how the new peer is behind the leader, or nothing once it has
+// caught up
+func (m *Move) behind(ctx context.Context) (string, error) {
+	state, err := consensusState(ctx, m.client, m.Tablet, m.leader, common.ConsensusConfigType_CONSENSUS_CONFIG_ACTIVE)
+	if err != nil {
+		return "", err
+	}
+	if findPeer(state, m.toUUID) == nil {
+		return "is not in the Raft config", nil
+	}
+
+	committed, err := lastOpID(ctx, m.client, m.Tablet, m.leader, consensus.OpIdType_COMMITTED_OPID)
+	if err != nil {
+		return "", err
+	}
+
+	// The replica does not exist on the new peer until its remote bootstrap
+	// starts
+	received, err := lastOpID(ctx, m.client, m.Tablet, m.toUUID, consensus.OpIdType_RECEIVED_OPID)
+	var tserverErr *yberrors.TabletServerError
+	if errors.As(err, &tserverErr) {
+		return fmt.Sprintf("has no replica yet: %s", err), nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if received < committed {
+		return fmt.Sprintf("is %d operations behind", committed-received), nil
+	}
+	return "", nil
+}
+
+func (m *Move) promote(ctx context.Context) error {
+	state, err := consensusState(ctx, m.client, m.Tablet, m.leader, common.ConsensusConfigType_CONSENSUS_CONFIG_COMMITTED)
+	if err != nil {
+		return err
+	}
+	if findPeer(state, m.toUUID).GetMemberType() == common.RaftPeerPB_VOTER {
+		return nil
+	}
+	return m.changeConfig(ctx, consensus.ChangeConfigType_CHANGE_ROLE, &common.RaftPeerPB{
+		PermanentUuid: []byte(m.toUUID),
+		MemberType:    common.RaftPeerPB_VOTER.Enum(),
+	})
+}
+
+// stepDown moves the leadership off the peer to be removed, which cannot remove
+// itself
+func (m *Move) stepDown(ctx context.Context) error {
+	if err := balance.StepDown(ctx, m.client, m.Tablet, m.fromUUID, ""); err != nil {
+		return err
+	}
+	leader, err := balance.WaitForLeader(ctx, m.client, m.Tablet, m.toUUID, m.fromUUID, "", m.StepDownTimeout)
+	if err != nil {
+		return err
+	}
+	m.leader = leader
+	return nil
+}
+
+// checkRemove checks the committed config once more before the old peer is
+// removed: the new peer must be a voter, and enough voters must remain
+func (m *Move) checkRemove(ctx context.Context) error {
+	state, err := consensusState(ctx, m.client, m.Tablet, m.leader, common.ConsensusConfigType_CONSENSUS_CONFIG_COMMITTED)
+	if err != nil {
+		return err
+	}
+	if findPeer(state, m.toUUID).GetMemberType() != common.RaftPeerPB_VOTER {
+		return errors.Errorf("%s is not a voter of tablet %s", m.toAddress, m.Tablet)
+	}
+
+	voters := 0
+	for _, peer := range state.GetConfig().GetPeers() {
+		if string(peer.GetPermanentUuid()) != m.fromUUID && peer.GetMemberType() == common.RaftPeerPB_VOTER {
+			voters++
+		}
+	}
+	if voters < m.replicationFactor {
+		return errors.Errorf("removing %s would leave tablet %s with %d voters, the replication factor is %d", m.fromAddress, m.Tablet, voters, m.replicationFactor)
+	}
+	return nil
+}
+
+// DeleteReplica deletes the replica of the tablet on the tablet server, with its
+// data and metadata. When configOpidIndex is given, the tablet server refuses
+// to delete a replica whose Raft config is newer, as when the replica was added
+// back to the tablet after the config with that index left it out.
+func DeleteReplica(ctx context.Context, c *client.YBClient, tablet string, tabletServer string, configOpidIndex *int64, reason string) error {
+	host, err := c.GetHostByUUIDWithContext(ctx, []byte(tabletServer))
+	if err != nil {
+		return err
+	}
+	response, err := host.TabletServerAdminService.DeleteTabletWithContext(ctx, &tserver.DeleteTabletRequestPB{
+		DestUuid:   []byte(tabletServer),
+		TabletId:   []byte(tablet),
+		Reason:     NewString(reason),
+		DeleteType: common.TabletDataState_TABLET_DATA_DELETED.Enum(),
+
+		CasConfigOpidIndexLessOrEqual: configOpidIndex,
+	})
+	if err != nil {
+		return err
+	}
+	if err := yberrors.FromResponse(response); err != nil {
+		return errors.Wrapf(err, "could not delete the replica of tablet %s", tablet)
+	}
+	return nil
+}
+
+// tabletLocations returns the replicas of the tablet as the master leader knows
+// them
+func tabletLocations(ctx context.Context, c *client.YBClient, tablet string) (*master.TabletLocationsPB, error) {
+	response, err := c.Master.MasterService.GetTabletLocationsWithContext(ctx, &master.GetTabletLocationsRequestPB{
+		TabletIds: [][]byte{[]byte(tablet)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := yberrors.FromResponse(response); err != nil {
+		return nil, errors.Wrap(err, "could not get tablet locations")
+	}
+	if tabletErrors := response.GetErrors(); len(tabletErrors) > 0 {
+		return nil, errors.Wrapf(yberrors.FromStatus(tabletErrors[0].GetStatus()), "could not get the locations of tablet %s", tablet)
+	}
+	if len(response.GetTabletLocations()) == 0 {
+		return nil, errors.Errorf("tablet %s not found", tablet)
+	}
+	return response.GetTabletLocations()[0], nil
+}
+
+// replicationFactor returns the number of live replicas of the table's placement
+// policy, or of the universe's when the table has none
+func replicationFactor(ctx context.Context, c *client.YBClient, tableID string) (int, error) {
+	schema, err := c.Master.MasterService.GetTableSchemaWithContext(ctx, &master.GetTableSchemaRequestPB{
+		Table: &master.TableIdentifierPB{TableId: []byte(tableID)},
+	})
+	if err != nil {
+		return 0, err
+	}
+	if err := yberrors.FromResponse(schema); err != nil {
+		return 0, errors.Wrapf(err, "could not get the schema of table %s", tableID)
+	}
+	if numReplicas := schema.GetReplicationInfo().GetLiveReplicas().GetNumReplicas(); numReplicas > 0 {
+		return int(numReplicas), nil
+	}
+
+	config, err := c.GetClusterConfig(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return c.ReplicationFactor(ctx, config)
+}
+
+func consensusState(ctx context.Context, c *client.YBClient, tablet string, replica string, configType common.ConsensusConfigType) (*common.ConsensusStatePB, error) {
+	host, err := c.GetHostByUUIDWithContext(ctx, []byte(replica))
+	if err != nil {
+		return nil, err
+	}
+	response, err := host.ConsensusService.GetConsensusStateWithContext(ctx, &consensus.GetConsensusStateRequestPB{
+		DestUuid: []byte(replica),
+		TabletId: []byte(tablet),
+		Type:     configType.Enum(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := yberrors.FromResponse(response); err != nil {
+		return nil, errors.Wrapf(err, "could not get the consensus state of tablet %s", tablet)
+	}
+	return response.GetCstate(), nil
+}
+
+func lastOpID(ctx context.Context, c *client.YBClient, tablet string, replica string, opIDType consensus.OpIdType) (int64, error) {
+	host, err := c.GetHostByUUIDWithContext(ctx, []byte(replica))
+	if err != nil {
+		return 0, err
+	}
+	response, err := host.ConsensusService.GetLastOpIdWithContext(ctx, &consensus.GetLastOpIdRequestPB{
+		DestUuid: []byte(replica),
+		TabletId: []byte(tablet),
+		OpidType: opIDType.Enum(),
+	})
+	if err != nil {
+		return 0, err
+	}
+	if err := yberrors.FromResponse(response); err != nil {
+		return 0, errors.Wrapf(err, "could not get the last OpId of tablet %s", tablet)
+	}
+	return response.GetOpid().GetIndex(), nil
+}
+
+func findPeer(state *common.ConsensusStatePB, uuid string) *common.RaftPeerPB {
+	for _, peer := range state.GetConfig().GetPeers() {
+		if string(peer.GetPermanentUuid()) == uuid {
+			return peer
+		}
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package tablets_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/blang/vfs/memfs"
+	"github.com/go-logr/logr"
+	. "github.com/icza/gox/gox"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/yugabyte/yb-tools/yugatool/api/yugatool/config"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/rpcserver"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/tablets"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/test/fakecluster"
+)
+
+func stepNames(steps []*tablets.MoveStep) []string {
+	var names []string
+	for _, step := range steps {
+		names = append(names, step.Step)
+	}
+	return names
+}
+
+var _ = Describe("Move", func() {
+	var (
+		cluster *fakecluster.Cluster
+		tablet  *fakecluster.Tablet
+		c       *client.YBClient
+		options tablets.MoveOptions
+	)
+
+	BeforeEach(func() {
+		cluster = fakecluster.New(logr.Discard(), rpcserver.NewNetwork(), "move", 1, 4)
+		tablet = cluster.AddTable("yugabyte", "test_table", 1).Tablets[0]
+
+		c = &client.YBClient{
+			Log: logr.Discard(),
+			Fs:  memfs.Create(),
+			Config: &config.UniverseConfigPB{
+				Masters:        cluster.MasterHostPorts(),
+				TimeoutSeconds: NewInt64(1),
+			},
+		}
+		c.OverrideDialer(cluster.Network)
+		Expect(c.Connect()).To(Succeed())
+
+		options = tablets.MoveOptions{
+			Tablet:          tablet.ID,
+			From:            cluster.TabletServers[1].UUID,
+			To:              cluster.TabletServers[3].UUID,
+			CatchUpTimeout:  5 * time.Second,
+			StepDownTimeout: time.Second,
+		}
+	})
+
+	AfterEach(func() {
+		c.Close()
+	})
+
+	replicas := func() []*fakecluster.Node {
+		cluster.Lock()
+		defer cluster.Unlock()
+		return append([]*fakecluster.Node{}, tablet.Replicas...)
+	}
+	tombstoned := func() []*fakecluster.Node {
+		cluster.Lock()
+		defer cluster.Unlock()
+		return append([]*fakecluster.Node{}, tablet.Tombstoned...)
+	}
+
+	It("moves a follower once the new peer has caught up", func() {
+		cluster.BootstrapLag = 3
+
+		move, err := tablets.PlanMove(context.Background(), logr.Discard(), c, options)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stepNames(move.Plan())).To(Equal([]string{tablets.StepAddPeer, tablets.StepCatchUp, tablets.StepPromote, tablets.StepRemovePeer}))
+
+		Expect(move.Run(context.Background())).To(Succeed())
+		Expect(stepNames(move.Steps)).To(Equal(stepNames(move.Plan())))
+		for _, step := range move.Steps {
+			Expect(step.Time).NotTo(BeEmpty())
+			Expect(step.Error).To(BeEmpty())
+		}
+
+		Expect(replicas()).To(ConsistOf(cluster.TabletServers[0], cluster.TabletServers[2], cluster.TabletServers[3]))
+		Expect(tombstoned()).To(ConsistOf(cluster.TabletServers[1]))
+	})
+
+	It("steps down the leader before removing it", func() {
+		options.From = cluster.TabletServers[0].UUID
+
+		move, err := tablets.PlanMove(context.Background(), logr.Discard(), c, options)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(move.Run(context.Background())).To(Succeed())
+		Expect(stepNames(move.Steps)).To(ContainElement(tablets.StepStepDown))
+
+		Expect(replicas()).NotTo(ContainElement(cluster.TabletServers[0]))
+		cluster.Lock()
+		defer cluster.Unlock()
+		Expect(tablet.Leader).NotTo(BeNil())
+		Expect(tablet.Leader).NotTo(Equal(cluster.TabletServers[0]))
+	})
+
+	It("deletes the tombstoned replica", func() {
+		options.DeleteReplica = true
+
+		move, err := tablets.PlanMove(context.Background(), logr.Discard(), c, options)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(move.Run(context.Background())).To(Succeed())
+		Expect(move.Steps[len(move.Steps)-1].Step).To(Equal(tablets.StepDeleteReplica))
+		Expect(tombstoned()).To(BeEmpty())
+	})
+
+	It("looks up the leader again when it has changed", func() {
+		move, err := tablets.PlanMove(context.Background(), logr.Discard(), c, options)
+		Expect(err).NotTo(HaveOccurred())
+
+		cluster.Lock()
+		tablet.Leader = cluster.TabletServers[2]
+		cluster.Unlock()
+
+		Expect(move.Run(context.Background())).To(Succeed())
+		Expect(replicas()).To(ConsistOf(cluster.TabletServers[0], cluster.TabletServers[2], cluster.TabletServers[3]))
+	})
+
+	It("rolls back when the new peer does not catch up", func() {
+		cluster.BootstrapLag = 1000
+		options.CatchUpTimeout = 500 * time.Millisecond
+
+		move, err := tablets.PlanMove(context.Background(), logr.Discard(), c, options)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(move.Run(context.Background())).To(MatchError(ContainSubstring("did not catch up with the leader within 500ms")))
+		Expect(stepNames(move.Steps)).To(Equal([]string{tablets.StepAddPeer, tablets.StepCatchUp, tablets.StepRollBack}))
+
+		Expect(replicas()).To(ConsistOf(cluster.TabletServers[0], cluster.TabletServers[1], cluster.TabletServers[2]))
+	})
+
+	It("rolls back when the move is cancelled while the new peer catches up", func() {
+		cluster.BootstrapLag = 1000
+
+		move, err := tablets.PlanMove(context.Background(), logr.Discard(), c, options)
+		Expect(err).NotTo(HaveOccurred())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(300*time.Millisecond, cancel)
+		Expect(move.Run(ctx)).To(MatchError(context.Canceled))
+		Expect(stepNames(move.Steps)).To(Equal([]string{tablets.StepAddPeer, tablets.StepCatchUp, tablets.StepRollBack}))
+		Expect(move.Steps[2].Error).To(BeEmpty())
+
+		Expect(replicas()).To(ConsistOf(cluster.TabletServers[0], cluster.TabletServers[1], cluster.TabletServers[2]))
+	})
+
+	It("refuses to move to a tablet server with a replica", func() {
+		options.To = cluster.TabletServers[2].UUID
+		_, err := tablets.PlanMove(context.Background(), logr.Discard(), c, options)
+		Expect(err).To(MatchError(ContainSubstring("already has a replica on")))
+	})
+
+	It("refuses to move from a tablet server without a replica", func() {
+		options.From = cluster.TabletServers[3].UUID
+		options.To = cluster.TabletServers[1].UUID
+		_, err := tablets.PlanMove(context.Background(), logr.Discard(), c, options)
+		Expect(err).To(MatchError(ContainSubstring("has no replica on")))
+	})
+
+	It("refuses to move to a dead tablet server", func() {
+		cluster.Stop(cluster.TabletServers[3])
+
+		_, err := tablets.PlanMove(context.Background(), logr.Discard(), c, options)
+		Expect(err).To(MatchError(ContainSubstring("is not alive")))
+	})
+
+	It("refuses to leave the tablet below its replication factor", func() {
+		cluster.Lock()
+		tablet.Replicas = tablet.Replicas[:2]
+		cluster.Unlock()
+
+		_, err := tablets.PlanMove(context.Background(), logr.Discard(), c, options)
+		Expect(err).To(MatchError(ContainSubstring("would leave tablet " + tablet.ID + " with 2 voters, the replication factor is 3")))
+	})
+})
@@ -0,0 +1,458 @@
+package tablets
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/icza/gox/gox"
+	"github.com/pkg/errors"
+	"github.com/yugabyte/yb-tools/pkg/ybversion"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/common"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/master"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/tablet"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/tserver"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client"
+	yberrors "github.com/yugabyte/yb-tools/yugatool/pkg/client/errors"
+	"golang.org/x/sync/errgroup"
+)
+
+// splitPollInterval is how often the children of a split tablet are checked
+// while waiting for them to run
+const splitPollInterval = 500 * time.Millisecond
+
+// ResultSplit is the result of a tablet that was split
+const ResultSplit = "split"
+
+var (
+	// MinSplitVersion is the first version whose master splits a tablet on
+	// request
+	MinSplitVersion = ybversion.YBVersion{Major: 2, Minor: 2}
+
+	// MinCDCSplitVersion is the first version that splits the tablets of a
+	// table with CDC streams
+	MinCDCSplitVersion = ybversion.YBVersion{Major: 2, Minor: 18}
+)
+
+// Partition is the hash range of a tablet. The fields are named as protojson
+// names those of a PartitionPB, which partition_key_to_hex relies on to print
+// an empty key as the start or end of the hash range.
+type Partition struct {
+	PartitionKeyStart []byte `json:"partitionKeyStart"`
+	PartitionKeyEnd   []byte `json:"partitionKeyEnd"`
+}
+
+func newPartition(partition *common.PartitionPB) *Partition {
+	// Empty keys are kept as empty strings rather than null
+	return &Partition{
+		PartitionKeyStart: append([]byte{}, partition.GetPartitionKeyStart()...),
+		PartitionKeyEnd:   append([]byte{}, partition.GetPartitionKeyEnd()...),
+	}
+}
+
+// TabletSplit is a tablet to split, and what became of it.
+type TabletSplit struct {
+	TableID   string        `json:"table_id"`
+	Table     string        `json:"table"`
+	Tablet    string        `json:"tablet"`
+	SSTSize   int64         `json:"sst_size"`
+	Partition *Partition    `json:"partition"`
+	Result    string        `json:"result"`
+	Error     string        `json:"error,omitempty"`
+	Children  []*SplitChild `json:"children,omitempty"`
+}
+
+// SplitChild is a tablet a split tablet was split into.
+type SplitChild struct {
+	Tablet    string     `json:"tablet"`
+	Partition *Partition `json:"partition"`
+	State     string     `json:"state"`
+}
+
+type SplitOptions struct {
+	// Tablets are split whatever their size. Otherwise, the tablets of the
+	// tables named Table, in Namespace if given, are split when their SST files
+	// are larger than TargetSize.
+	Tablets    []string
+	Namespace  string
+	Table      string
+	TargetSize int64
+}
+
+// splitTable is what is needed of a table to check that its tablets can be split
+type splitTable struct {
+	name            string
+	hashPartitioned bool
+	cdcStreams      int
+}
+
+// PlanSplits returns the tablets to split, after checking that the universe
+// runs a version that can split tablets. A tablet is skipped, with the reason
+// why, when its table is range partitioned, when it is not running or is
+// already splitting, or when its table has CDC streams and the universe runs a
+// version that cannot split their tablets.
+func PlanSplits(ctx context.Context, log logr.Logger, c *client.YBClient, options SplitOptions) ([]*TabletSplit, error) {
+	version, err := lowestVersion(ctx, log, c)
+	if err != nil {
+		return nil, err
+	}
+	if version.Lt(MinSplitVersion) {
+		return nil, errors.Errorf("the universe runs version %d.%d.%d.%d, tablets can only be split from version %d.%d",
+			version.Major, version.Minor, version.Patch, version.Hotfix, MinSplitVersion.Major, MinSplitVersion.Minor)
+	}
+
+	var locations []*master.TabletLocationsPB
+	if len(options.Tablets) > 0 {
+		locations, err = findTablets(ctx, c, options.Tablets)
+	} else {
+		locations, err = findTableTablets(ctx, c, options.Namespace, options.Table)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	statuses, err := leaderStatuses(ctx, log, c, locations)
+	if err != nil {
+		return nil, err
+	}
+
+	tables := make(map[string]*splitTable)
+	splits := []*TabletSplit{}
+	for _, location := range locations {
+		tableID := string(location.GetTableId())
+		t, ok := tables[tableID]
+		if !ok {
+			t, err = getSplitTable(ctx, c, tableID)
+			if err != nil {
+				return nil, err
+			}
+			tables[tableID] = t
+		}
+
+		tabletID := string(location.GetTabletId())
+		status := statuses[tabletID]
+		if len(options.Tablets) == 0 && status.GetSstFilesDiskSize() <= options.TargetSize {
+			continue
+		}
+
+		split := &TabletSplit{
+			TableID:   tableID,
+			Table:     t.name,
+			Tablet:    tabletID,
+			SSTSize:   status.GetSstFilesDiskSize(),
+			Partition: newPartition(location.GetPartition()),
+			Result:    ResultPending,
+		}
+		switch {
+		case !t.hashPartitioned:
+			split.Error = "table is range partitioned"
+		case len(location.GetSplitTabletIds()) > 0 || status.GetTabletDataState() == common.TabletDataState_TABLET_DATA_SPLIT_COMPLETED:
+			split.Error = "tablet is already splitting"
+		case status == nil:
+			split.Error = "tablet has no leader"
+		case status.GetState() != common.RaftGroupStatePB_RUNNING:
+			split.Error = fmt.Sprintf("tablet is %s", status.GetState())
+		case t.cdcStreams > 0 && version.Lt(MinCDCSplitVersion):
+			split.Error = fmt.Sprintf("table has %d CDC streams, whose tablets can only be split from version %d.%d",
+				t.cdcStreams, MinCDCSplitVersion.Major, MinCDCSplitVersion.Minor)
+		}
+		if split.Error != "" {
+			split.Result = ResultSkipped
+		}
+		splits = append(splits, split)
+	}
+
+	sort.SliceStable(splits, func(i, j int) bool {
+		if splits[i].Table != splits[j].Table {
+			return splits[i].Table < splits[j].Table
+		}
+		return string(splits[i].Partition.PartitionKeyStart) < string(splits[j].Partition.PartitionKeyStart)
+	})
+	return splits, nil
+}
+
+// Split splits the pending tablets, no more than concurrency at once, and waits
+// up to timeout for the children of each to be running. The number of tablets
+// that could not be split is returned.
+func Split(ctx context.Context, log logr.Logger, c *client.YBClient, splits []*TabletSplit, concurrency int, timeout time.Duration) int {
+	g := &errgroup.Group{}
+	g.SetLimit(concurrency)
+	for _, split := range splits {
+		if split.Result != ResultPending {
+			continue
+		}
+		split := split
+		g.Go(func() error {
+			log.Info("splitting tablet", "table", split.Table, "tablet", split.Tablet, "sst_size", split.SSTSize)
+			err := splitTablet(ctx, c, split, timeout)
+			if err != nil {
+				split.Result = ResultFailed
+				split.Error = err.Error()
+				log.Info("could not split tablet", "tablet", split.Tablet, "error", err.Error())
+				return nil
+			}
+			split.Result = ResultSplit
+			log.Info("split tablet", "tablet", split.Tablet, "children", len(split.Children))
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	failed := 0
+	for _, split := range splits {
+		if split.Result == ResultFailed {
+			failed++
+		}
+	}
+	return failed
+}
+
+func splitTablet(ctx context.Context, c *client.YBClient, split *TabletSplit, timeout time.Duration) error {
+	response, err := c.Master.MasterService.SplitTabletWithContext(ctx, &master.SplitTabletRequestPB{
+		TabletId: []byte(split.Tablet),
+	})
+	if err != nil {
+		return err
+	}
+	if err := yberrors.FromResponse(response); err != nil {
+		return errors.Wrapf(err, "could not split tablet %s", split.Tablet)
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		running, err := splitChildren(ctx, c, split)
+		if err != nil {
+			return err
+		}
+		if running {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return errors.Errorf("the children of tablet %s are not running after %s", split.Tablet, timeout)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(splitPollInterval):
+		}
+	}
+}
+
+// splitChildren records the children of the split tablet, and returns whether
+// they are all running
+func splitChildren(ctx context.Context, c *client.YBClient, split *TabletSplit) (bool, error) {
+	parent, err := tabletLocations(ctx, c, split.Tablet)
+	if err != nil {
+		return false, err
+	}
+	if len(parent.GetSplitTabletIds()) == 0 {
+		return false, nil
+	}
+
+	var ids []string
+	for _, id := range parent.GetSplitTabletIds() {
+		ids = append(ids, string(id))
+	}
+	children, err := findTablets(ctx, c, ids)
+	if err != nil {
+		return false, err
+	}
+	statuses, err := leaderStatuses(ctx, logr.Discard(), c, children)
+	if err != nil {
+		return false, err
+	}
+
+	running := true
+	split.Children = nil
+	for _, child := range children {
+		id := string(child.GetTabletId())
+		state := "NO_LEADER"
+		if status, ok := statuses[id]; ok {
+			state = status.GetState().String()
+		}
+		if state != common.RaftGroupStatePB_RUNNING.String() {
+			running = false
+		}
+		split.Children = append(split.Children, &SplitChild{
+			Tablet:    id,
+			Partition: newPartition(child.GetPartition()),
+			State:     state,
+		})
+	}
+	sort.SliceStable(split.Children, func(i, j int) bool {
+		return string(split.Children[i].Partition.PartitionKeyStart) < string(split.Children[j].Partition.PartitionKeyStart)
+	})
+	return running, nil
+}
+
+// lowestVersion returns the lowest version run by the master leader and the
+// live tablet servers
+func lowestVersion(ctx context.Context, log logr.Logger, c *client.YBClient) (ybversion.YBVersion, error) {
+	versions := []string{c.Master.Status.GetVersionInfo().GetVersionNumber()}
+
+	tabletServers, err := c.Master.MasterService.ListTabletServersWithContext(ctx, &master.ListTabletServersRequestPB{})
+	if err != nil {
+		return ybversion.YBVersion{}, err
+	}
+	if err := yberrors.FromResponse(tabletServers); err != nil {
+		return ybversion.YBVersion{}, errors.Wrap(err, "could not list tablet servers")
+	}
+	for _, ts := range tabletServers.GetServers() {
+		if !ts.GetAlive() {
+			continue
+		}
+		host, err := c.GetHostByUUIDWithContext(ctx, ts.GetInstanceId().GetPermanentUuid())
+		if err != nil {
+			log.Error(err, "could not connect to tablet server", "uuid", string(ts.GetInstanceId().GetPermanentUuid()))
+			continue
+		}
+		versions = append(versions, host.Status.GetVersionInfo().GetVersionNumber())
+	}
+
+	var lowest ybversion.YBVersion
+	for i, v := range versions {
+		version, err := ybversion.New(v)
+		if err != nil {
+			return ybversion.YBVersion{}, err
+		}
+		if i == 0 || version.Lt(lowest) {
+			lowest = version
+		}
+	}
+	return lowest, nil
+}
+
+// findTablets returns the locations of the tablets
+func findTablets(ctx context.Context, c *client.YBClient, tablets []string) ([]*master.TabletLocationsPB, error) {
+	request := &master.GetTabletLocationsRequestPB{}
+	for _, id := range tablets {
+		request.TabletIds = append(request.TabletIds, []byte(id))
+	}
+	response, err := c.Master.MasterService.GetTabletLocationsWithContext(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	if err := yberrors.FromResponse(response); err != nil {
+		return nil, errors.Wrap(err, "could not get tablet locations")
+	}
+	if tabletErrors := response.GetErrors(); len(tabletErrors) > 0 {
+		return nil, errors.Wrapf(yberrors.FromStatus(tabletErrors[0].GetStatus()), "could not get the locations of tablet %s", tabletErrors[0].GetTabletId())
+	}
+	return response.GetTabletLocations(), nil
+}
+
+// findTableTablets returns the locations of the tablets of the user tables
+// named table, in the namespace if it is given
+func findTableTablets(ctx context.Context, c *client.YBClient, namespace string, table string) ([]*master.TabletLocationsPB, error) {
+	request := &master.ListTablesRequestPB{NameFilter: NewString(table)}
+	if namespace != "" {
+		request.Namespace = &master.NamespaceIdentifierPB{Name: NewString(namespace)}
+	}
+	tables, err := c.Master.MasterService.ListTablesWithContext(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	if err := yberrors.FromResponse(tables); err != nil {
+		return nil, errors.Wrap(err, "could not list tables")
+	}
+
+	var locations []*master.TabletLocationsPB
+	found := false
+	for _, t := range tables.GetTables() {
+		// The name filter matches any table whose name contains it
+		if t.GetName() != table || t.GetRelationType() == master.RelationType_SYSTEM_TABLE_RELATION {
+			continue
+		}
+		found = true
+
+		tableLocations, err := c.TableLocations(ctx, t.GetId())
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get the tablets of table %s", t.GetName())
+		}
+		locations = append(locations, tableLocations...)
+	}
+	if !found {
+		return nil, errors.Errorf("no table %s found", table)
+	}
+	return locations, nil
+}
+
+func getSplitTable(ctx context.Context, c *client.YBClient, tableID string) (*splitTable, error) {
+	schema, err := c.Master.MasterService.GetTableSchemaWithContext(ctx, &master.GetTableSchemaRequestPB{
+		Table: &master.TableIdentifierPB{TableId: []byte(tableID)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := yberrors.FromResponse(schema); err != nil {
+		return nil, errors.Wrapf(err, "could not get the schema of table %s", tableID)
+	}
+
+	streams, err := c.Master.MasterService.ListCDCStreamsWithContext(ctx, &master.ListCDCStreamsRequestPB{
+		TableId: NewString(tableID),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := yberrors.FromResponse(streams); err != nil {
+		return nil, errors.Wrapf(err, "could not list the CDC streams of table %s", tableID)
+	}
+
+	// The getter defaults an unset hash schema, so a range partitioned table is
+	// told apart by the field itself, on a schema that may be missing altogether
+	partitionSchema := schema.GetPartitionSchema()
+	return &splitTable{
+		name:            schema.GetIdentifier().GetNamespace().GetName() + "." + schema.GetIdentifier().GetTableName(),
+		hashPartitioned: partitionSchema != nil && partitionSchema.HashSchema != nil,
+		cdcStreams:      len(streams.GetStreams()),
+	}, nil
+}
+
+// leaderStatuses returns the status of the leader replica of each tablet, as
+// listed by the tablet server that hosts it. Tablets without a leader are left
+// out.
+func leaderStatuses(ctx context.Context, log logr.Logger, c *client.YBClient, locations []*master.TabletLocationsPB) (map[string]*tablet.TabletStatusPB, error) {
+	leaders := make(map[string]bool)
+	for _, location := range locations {
+		for _, replica := range location.GetReplicas() {
+			if replica.GetRole() == common.RaftPeerPB_LEADER {
+				leaders[string(location.GetTabletId())+"/"+string(replica.GetTsInfo().GetPermanentUuid())] = true
+			}
+		}
+	}
+
+	statuses := make(map[string]*tablet.TabletStatusPB)
+	listed := make(map[string]bool)
+	for _, location := range locations {
+		for _, replica := range location.GetReplicas() {
+			uuid := string(replica.GetTsInfo().GetPermanentUuid())
+			if replica.GetRole() != common.RaftPeerPB_LEADER || listed[uuid] {
+				continue
+			}
+			listed[uuid] = true
+
+			host, err := c.GetHostByUUIDWithContext(ctx, []byte(uuid))
+			if err != nil {
+				log.Error(err, "could not connect to tablet server", "uuid", uuid)
+				continue
+			}
+			tablets, err := host.TabletServerService.ListTabletsWithContext(ctx, &tserver.ListTabletsRequestPB{})
+			if err != nil {
+				return nil, err
+			}
+			if err := yberrors.FromResponse(tablets); err != nil {
+				return nil, errors.Wrapf(err, "could not list the tablets of tablet server %s", uuid)
+			}
+			for _, t := range tablets.GetStatusAndSchema() {
+				status := t.GetTabletStatus()
+				if leaders[status.GetTabletId()+"/"+uuid] {
+					statuses[status.GetTabletId()] = status
+				}
+			}
+		}
+	}
+	return statuses, nil
+}
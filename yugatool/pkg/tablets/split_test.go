@@ -0,0 +1,159 @@
+package tablets_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/blang/vfs/memfs"
+	"github.com/go-logr/logr"
+	. "github.com/icza/gox/gox"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/common"
+	"github.com/yugabyte/yb-tools/yugatool/api/yugatool/config"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/rpcserver"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/tablets"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/test/fakecluster"
+)
+
+var _ = Describe("Split", func() {
+	var (
+		cluster *fakecluster.Cluster
+		table   *fakecluster.Table
+		c       *client.YBClient
+	)
+
+	BeforeEach(func() {
+		cluster = fakecluster.New(logr.Discard(), rpcserver.NewNetwork(), "split", 1, 3)
+		table = cluster.AddTable("yugabyte", "test_table", 4)
+
+		cluster.Lock()
+		for i, tablet := range table.Tablets {
+			tablet.SSTSize = int64(i+1) * 1000
+		}
+		cluster.Unlock()
+
+		c = &client.YBClient{
+			Log: logr.Discard(),
+			Fs:  memfs.Create(),
+			Config: &config.UniverseConfigPB{
+				Masters:        cluster.MasterHostPorts(),
+				TimeoutSeconds: NewInt64(1),
+			},
+		}
+	})
+
+	connect := func() {
+		c.OverrideDialer(cluster.Network)
+		Expect(c.Connect()).To(Succeed())
+	}
+
+	AfterEach(func() {
+		c.Close()
+	})
+
+	It("plans the tablets larger than the target size", func() {
+		connect()
+		splits, err := tablets.PlanSplits(context.Background(), logr.Discard(), c, tablets.SplitOptions{
+			Table:      "test_table",
+			TargetSize: 2500,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(splits).To(HaveLen(2))
+		for _, split := range splits {
+			Expect(split.SSTSize).To(BeNumerically(">", 2500))
+			Expect(split.Table).To(Equal("yugabyte.test_table"))
+			Expect(split.Result).To(Equal(tablets.ResultPending))
+		}
+	})
+
+	It("splits the tablets at the middle of their hash range", func() {
+		connect()
+		tablet := table.Tablets[0]
+		splits, err := tablets.PlanSplits(context.Background(), logr.Discard(), c, tablets.SplitOptions{
+			Tablets: []string{tablet.ID},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(splits).To(HaveLen(1))
+
+		failed := tablets.Split(context.Background(), logr.Discard(), c, splits, 2, 5*time.Second)
+		Expect(failed).To(BeZero())
+		Expect(splits[0].Result).To(Equal(tablets.ResultSplit))
+
+		children := splits[0].Children
+		Expect(children).To(HaveLen(2))
+		Expect(children[0].Partition.PartitionKeyStart).To(Equal(tablet.Partition.GetPartitionKeyStart()))
+		Expect(children[0].Partition.PartitionKeyEnd).To(Equal(children[1].Partition.PartitionKeyStart))
+		Expect(children[1].Partition.PartitionKeyEnd).To(Equal(tablet.Partition.GetPartitionKeyEnd()))
+		for _, child := range children {
+			Expect(child.State).To(Equal(common.RaftGroupStatePB_RUNNING.String()))
+		}
+	})
+
+	It("skips a tablet that has already been split", func() {
+		connect()
+		tablet := table.Tablets[0]
+		splits, err := tablets.PlanSplits(context.Background(), logr.Discard(), c, tablets.SplitOptions{Tablets: []string{tablet.ID}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tablets.Split(context.Background(), logr.Discard(), c, splits, 1, 5*time.Second)).To(BeZero())
+
+		splits, err = tablets.PlanSplits(context.Background(), logr.Discard(), c, tablets.SplitOptions{Tablets: []string{tablet.ID}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(splits[0].Result).To(Equal(tablets.ResultSkipped))
+		Expect(splits[0].Error).To(Equal("tablet is already splitting"))
+	})
+
+	DescribeTable("skips the tablets of a table that is not hash partitioned",
+		func(partitionSchema *common.PartitionSchemaPB) {
+			cluster.Lock()
+			table.PartitionSchema = partitionSchema
+			cluster.Unlock()
+			connect()
+
+			splits, err := tablets.PlanSplits(context.Background(), logr.Discard(), c, tablets.SplitOptions{Tablets: []string{table.Tablets[0].ID}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(splits[0].Result).To(Equal(tablets.ResultSkipped))
+			Expect(splits[0].Error).To(Equal("table is range partitioned"))
+		},
+		Entry("range partitioned", &common.PartitionSchemaPB{}),
+		Entry("without a partition schema", nil),
+	)
+
+	It("skips the tablets of a table with CDC streams on older versions", func() {
+		cluster.Lock()
+		table.CDCStreams = []string{"stream"}
+		cluster.Unlock()
+		connect()
+
+		splits, err := tablets.PlanSplits(context.Background(), logr.Discard(), c, tablets.SplitOptions{Tablets: []string{table.Tablets[0].ID}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(splits[0].Result).To(Equal(tablets.ResultSkipped))
+		Expect(splits[0].Error).To(ContainSubstring("CDC streams"))
+	})
+
+	It("refuses a universe that cannot split tablets", func() {
+		cluster.Version = "2.1.8.0"
+		connect()
+
+		_, err := tablets.PlanSplits(context.Background(), logr.Discard(), c, tablets.SplitOptions{Tablets: []string{table.Tablets[0].ID}})
+		Expect(err).To(MatchError(ContainSubstring("tablets can only be split from version 2.2")))
+	})
+
+	It("fails when the children do not start running", func() {
+		cluster.SplitBootstrapping = true
+		connect()
+
+		splits, err := tablets.PlanSplits(context.Background(), logr.Discard(), c, tablets.SplitOptions{Tablets: []string{table.Tablets[0].ID}})
+		Expect(err).NotTo(HaveOccurred())
+
+		failed := tablets.Split(context.Background(), logr.Discard(), c, splits, 1, time.Second)
+		Expect(failed).To(Equal(1))
+		Expect(splits[0].Result).To(Equal(tablets.ResultFailed))
+		Expect(splits[0].Error).To(ContainSubstring("are not running"))
+		for _, child := range splits[0].Children {
+			Expect(child.State).To(Equal(common.RaftGroupStatePB_BOOTSTRAPPING.String()))
+		}
+	})
+})
@@ -0,0 +1,13 @@
+package tablets_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestTablets(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Tablets Suite")
+}
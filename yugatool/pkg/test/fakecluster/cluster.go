@@ -0,0 +1,443 @@
+// Package fakecluster builds an in-memory universe of masters and tablet servers
+// served by rpcserver, so yugatool commands can be tested without a real
+// universe.
+//
+// The cluster state is held in plain Go structs that tests may read and modify
+// between calls. Handlers answer from that state the way a healthy universe
+// would: only the master leader serves catalog calls, and tablet servers report
+// the tablets they host a replica of.
+package fakecluster
+
+import (
+	"crypto/tls"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"github.com/google/uuid"
+	. "github.com/icza/gox/gox"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/cdc"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/common"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/consensus"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/master"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/server"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/tserver"
+	ybutil "github.com/yugabyte/yb-tools/yugatool/api/yb/util"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/client"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/rpcserver"
+	"github.com/yugabyte/yb-tools/yugatool/pkg/util"
+)
+
+// Zones that nodes are spread across, in order
+var Zones = []string{"zone-1", "zone-2", "zone-3"}
+
+const (
+	Cloud  = "cloud-1"
+	Region = "region-1"
+)
+
+type Cluster struct {
+	Log     logr.Logger
+	Network *rpcserver.Network
+	Name    string
+
+	// Lock must be held while changing the cluster state once the cluster is serving calls
+	sync.Mutex
+
+	ClusterConfig *master.SysClusterConfigEntryPB
+	Masters       []*Node
+	TabletServers []*Node
+	Tables        []*Table
+
+	// Checkpoints of CDC streams, by stream ID and then tablet ID
+	Checkpoints map[string]map[string]*ybutil.OpIdPB
+
+	// UnsafeFlags can only be changed by SetFlag when it is forced
+	UnsafeFlags map[string]bool
+
+	// LoadBalancer is the state the load balancer calls report
+	LoadBalancer LoadBalancerState
+
+	// BootstrapLag is the Lag of a peer added to a tablet by ChangeConfig, as
+	// if it were being remote bootstrapped
+	BootstrapLag int64
+
+	// Version is the version number every node reports in GetStatus
+	Version string
+
+	// SplitBootstrapping leaves the children of a tablet split by SplitTablet
+	// BOOTSTRAPPING, as if the split had not finished
+	SplitBootstrapping bool
+
+	// ConcurrentConfigChange changes the cluster config, as another client
+	// would, just before the next ChangeMasterClusterConfig, which then fails
+	// with CONFIG_VERSION_MISMATCH. It is cleared once it has run.
+	ConcurrentConfigChange func(config *master.SysClusterConfigEntryPB)
+
+	leader *Node
+}
+
+// Node is a master or tablet server.
+type Node struct {
+	UUID      string
+	Address   *common.HostPortPB
+	CloudInfo *common.CloudInfoPB
+
+	// BroadcastAddresses are registered alongside Address, but are not listened
+	// on unless a test does so
+	BroadcastAddresses []*common.HostPortPB
+
+	// Alive is reported in ListTabletServers, and is cleared when the node is stopped
+	Alive  bool
+	Server *rpcserver.Server
+
+	// Flags are the gflags of the node, by name
+	Flags map[string]string
+}
+
+// LoadBalancerState is the state of the load balancer of the master leader. The
+// zero value is a balanced cluster with an idle load balancer.
+type LoadBalancerState struct {
+	Unbalanced          bool
+	Active              bool
+	LeadersNotPreferred bool
+
+	// MovesRemaining of MovesTotal are reported by GetLoadMoveCompletion
+	MovesRemaining uint64
+	MovesTotal     uint64
+
+	// LeaderMovesRemaining of LeaderMovesTotal are reported by
+	// GetLeaderBlacklistCompletion
+	LeaderMovesRemaining uint64
+	LeaderMovesTotal     uint64
+
+	// MovesPerCall of the remaining moves complete each time their completion
+	// is asked for, as if the load balancer were making them
+	MovesPerCall uint64
+}
+
+type Table struct {
+	ID        string
+	Name      string
+	Namespace string
+	TableType common.TableType
+	Schema    *common.SchemaPB
+	Tablets   []*Tablet
+
+	// ReplicationInfo is the placement policy of the table, which overrides that
+	// of the cluster config when set
+	ReplicationInfo *master.ReplicationInfoPB
+
+	PartitionSchema *common.PartitionSchemaPB
+
+	// CDCStreams are the IDs of the CDC streams of the table
+	CDCStreams []string
+
+	// Dropped tables are still listed, but their schema is not found, as if
+	// the table were dropped after it was listed
+	Dropped bool
+}
+
+type Tablet struct {
+	ID        string
+	Table     *Table
+	Partition *common.PartitionPB
+	Replicas  []*Node
+	Leader    *Node
+	Term      int64
+	LastOpID  *ybutil.OpIdPB
+	State     common.RaftGroupStatePB
+	DataState common.TabletDataState
+
+	// SSTSize is the size of the SST files of each replica, in bytes
+	SSTSize int64
+
+	// CommittedConfigs override the peers of the committed config reported by
+	// the replica on a node, by node UUID, as when a config change has not yet
+	// reached every peer
+	CommittedConfigs map[string][]*Node
+
+	// LeaderPinned keeps the leader in place, though step-downs and elections
+	// succeed, as when the new leader loses its election
+	LeaderPinned bool
+
+	// MemberTypes override the member type of the replica on a node, by node
+	// UUID. Replicas are voters otherwise.
+	MemberTypes map[string]common.RaftPeerPB_MemberType
+
+	// Lag is the number of operations the replica on a node is behind the
+	// leader, by node UUID. A lagging replica catches up by one operation each
+	// time its last OpId is asked for.
+	Lag map[string]int64
+
+	// Tombstoned are the nodes that keep a tombstoned replica of the tablet once
+	// they are removed from its Raft config, until the replica is deleted
+	Tombstoned []*Node
+
+	// WALSize is the size of the WAL files of each replica, in bytes
+	WALSize int64
+
+	// SplitTablets are the children of a tablet that has been split
+	SplitTablets []*Tablet
+}
+
+// New creates a cluster and starts serving its nodes on the network. Node
+// addresses are derived from the cluster name, so several clusters, such as an
+// xCluster producer and consumer, can share one network. The first master is
+// the leader.
+func New(log logr.Logger, network *rpcserver.Network, name string, masters int, tabletServers int) *Cluster {
+	c := &Cluster{
+		Log:     log,
+		Network: network,
+		Name:    name,
+		ClusterConfig: &master.SysClusterConfigEntryPB{
+			Version:     NewInt32(1),
+			ClusterUuid: NewString(uuid.New().String()),
+			ReplicationInfo: &master.ReplicationInfoPB{
+				LiveReplicas: &master.PlacementInfoPB{
+					NumReplicas: NewInt32(int32(replicationFactor(tabletServers))),
+				},
+			},
+		},
+		Checkpoints: make(map[string]map[string]*ybutil.OpIdPB),
+		UnsafeFlags: make(map[string]bool),
+		Version:     "2.14.1.0",
+	}
+
+	for i := 0; i < masters; i++ {
+		node := c.newNode(fmt.Sprintf("%s-master-%d", name, i+1), client.DefaultMasterPort, i)
+		node.Flags["replication_factor"] = strconv.Itoa(replicationFactor(tabletServers))
+		server.RegisterGenericService(node.Server, &genericHandler{cluster: c, node: node})
+		master.RegisterMasterService(node.Server, &masterHandler{cluster: c, node: node})
+		c.Masters = append(c.Masters, node)
+	}
+
+	for i := 0; i < tabletServers; i++ {
+		node := c.newNode(fmt.Sprintf("%s-tserver-%d", name, i+1), client.DefaultTserverPort, i)
+		server.RegisterGenericService(node.Server, &genericHandler{cluster: c, node: node})
+		tserver.RegisterTabletServerService(node.Server, &tabletServerHandler{cluster: c, node: node})
+		tserver.RegisterTabletServerAdminService(node.Server, &tabletServerAdminHandler{cluster: c, node: node})
+		consensus.RegisterConsensusService(node.Server, &consensusHandler{cluster: c, node: node})
+		cdc.RegisterCDCService(node.Server, &cdcHandler{cluster: c, node: node})
+		c.TabletServers = append(c.TabletServers, node)
+	}
+
+	if len(c.Masters) > 0 {
+		c.leader = c.Masters[0]
+	}
+
+	for _, node := range append(append([]*Node{}, c.Masters...), c.TabletServers...) {
+		network.Listen(util.HostPortString(node.Address), node.Server)
+	}
+
+	return c
+}
+
+func (c *Cluster) newNode(host string, port uint32, i int) *Node {
+	return &Node{
+		UUID:    newUUID(),
+		Address: &common.HostPortPB{Host: NewString(host), Port: NewUint32(port)},
+		CloudInfo: &common.CloudInfoPB{
+			PlacementCloud:  NewString(Cloud),
+			PlacementRegion: NewString(Region),
+			PlacementZone:   NewString(Zones[i%len(Zones)]),
+		},
+		Alive:  true,
+		Server: rpcserver.NewServer(c.Log.WithValues("host", host)),
+		Flags: map[string]string{
+			"v":                "0",
+			"rpc_bind_address": fmt.Sprintf("%s:%d", host, port),
+		},
+	}
+}
+
+// MasterAddresses returns the master addresses as accepted by --master-addresses.
+func (c *Cluster) MasterAddresses() string {
+	var addresses []string
+	for _, m := range c.Masters {
+		addresses = append(addresses, util.HostPortString(m.Address))
+	}
+	return strings.Join(addresses, ",")
+}
+
+func (c *Cluster) MasterHostPorts() []*common.HostPortPB {
+	var hostPorts []*common.HostPortPB
+	for _, m := range c.Masters {
+		hostPorts = append(hostPorts, m.Address)
+	}
+	return hostPorts
+}
+
+// Leader returns the master leader.
+func (c *Cluster) Leader() *Node {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.leader
+}
+
+// SetLeader makes the master the leader, as if an election had taken place.
+func (c *Cluster) SetLeader(leader *Node) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.leader = leader
+}
+
+// Stop takes the node off the network, closing its connections, and marks it
+// as no longer alive.
+func (c *Cluster) Stop(node *Node) {
+	c.Lock()
+	node.Alive = false
+	c.Unlock()
+
+	c.Network.Stop(util.HostPortString(node.Address))
+}
+
+// Start puts a stopped node back on the network.
+func (c *Cluster) Start(node *Node) {
+	c.Lock()
+	node.Alive = true
+	c.Unlock()
+
+	c.Network.Listen(util.HostPortString(node.Address), node.Server)
+}
+
+// ServeTLS serves connections to the node that start a TLS handshake with the
+// certificate. The node still serves plaintext connections, as servers that
+// allow insecure connections do.
+func (c *Cluster) ServeTLS(node *Node, certificate tls.Certificate) {
+	c.Network.ServeTLS(util.HostPortString(node.Address), &tls.Config{Certificates: []tls.Certificate{certificate}})
+}
+
+// AddTable creates a hash partitioned table with the given number of tablets.
+// Replicas are placed on consecutive tablet servers, up to a replication factor
+// of three, and the first replica of each tablet is its leader.
+func (c *Cluster) AddTable(namespace string, name string, tablets int) *Table {
+	c.Lock()
+	defer c.Unlock()
+
+	table := &Table{
+		ID:        newUUID(),
+		Name:      name,
+		Namespace: namespace,
+		TableType: common.TableType_YQL_TABLE_TYPE,
+		Schema: &common.SchemaPB{
+			Columns: []*common.ColumnSchemaPB{
+				{Id: NewUint32(0), Name: NewString("k"), Type: &common.QLTypePB{Main: common.DataType_INT32.Enum()}, IsKey: NewBool(true), IsHashKey: NewBool(true), IsNullable: NewBool(false)},
+				{Id: NewUint32(1), Name: NewString("v"), Type: &common.QLTypePB{Main: common.DataType_STRING.Enum()}, IsKey: NewBool(false), IsNullable: NewBool(true)},
+			},
+		},
+		PartitionSchema: &common.PartitionSchemaPB{
+			HashSchema: common.PartitionSchemaPB_MULTI_COLUMN_HASH_SCHEMA.Enum(),
+		},
+	}
+
+	rf := replicationFactor(len(c.TabletServers))
+	for i := 0; i < tablets; i++ {
+		tablet := &Tablet{
+			ID:        newUUID(),
+			Table:     table,
+			Partition: hashPartition(i, tablets),
+			Term:      1,
+			LastOpID:  &ybutil.OpIdPB{Term: NewInt64(1), Index: NewInt64(1)},
+			State:     common.RaftGroupStatePB_RUNNING,
+			DataState: common.TabletDataState_TABLET_DATA_READY,
+		}
+		for r := 0; r < rf; r++ {
+			tablet.Replicas = append(tablet.Replicas, c.TabletServers[(i+r)%len(c.TabletServers)])
+		}
+		if len(tablet.Replicas) > 0 {
+			tablet.Leader = tablet.Replicas[0]
+		}
+		table.Tablets = append(table.Tablets, tablet)
+	}
+
+	c.Tables = append(c.Tables, table)
+	return table
+}
+
+// SetCheckpoint records the position a CDC stream has replicated a tablet up to.
+func (c *Cluster) SetCheckpoint(streamID string, tablet *Tablet, opID *ybutil.OpIdPB) {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.Checkpoints[streamID] == nil {
+		c.Checkpoints[streamID] = make(map[string]*ybutil.OpIdPB)
+	}
+	c.Checkpoints[streamID][tablet.ID] = opID
+}
+
+func (c *Cluster) findTable(id string, namespace string, name string) *Table {
+	for _, table := range c.Tables {
+		if table.ID == id || (id == "" && table.Name == name && (namespace == "" || table.Namespace == namespace)) {
+			return table
+		}
+	}
+	return nil
+}
+
+func (c *Cluster) findTablet(id string) *Tablet {
+	for _, table := range c.Tables {
+		for _, tablet := range table.Tablets {
+			if tablet.ID == id {
+				return tablet
+			}
+		}
+	}
+	return nil
+}
+
+func (c *Cluster) findTabletServer(uuid string) *Node {
+	for _, ts := range c.TabletServers {
+		if ts.UUID == uuid {
+			return ts
+		}
+	}
+	return nil
+}
+
+// sstSize returns the size of the SST files of the replicas on the tablet server
+func (c *Cluster) sstSize(node *Node) int64 {
+	var size int64
+	for _, table := range c.Tables {
+		for _, tablet := range table.Tablets {
+			if tablet.hasReplica(node) {
+				size += tablet.SSTSize
+			}
+		}
+	}
+	return size
+}
+
+func replicationFactor(tabletServers int) int {
+	if tabletServers < 3 {
+		return tabletServers
+	}
+	return 3
+}
+
+// hashPartition splits the 16 bit hash space evenly between the tablets.
+func hashPartition(i int, tablets int) *common.PartitionPB {
+	partition := &common.PartitionPB{}
+	if i > 0 {
+		partition.PartitionKeyStart = hashKey(i * 0x10000 / tablets)
+	}
+	if i < tablets-1 {
+		partition.PartitionKeyEnd = hashKey((i + 1) * 0x10000 / tablets)
+	}
+	return partition
+}
+
+func hashKey(hash int) []byte {
+	key := make([]byte, 2)
+	binary.BigEndian.PutUint16(key, uint16(hash))
+	return key
+}
+
+func newUUID() string {
+	return strings.ReplaceAll(uuid.New().String(), "-", "")
+}
@@ -0,0 +1,581 @@
+package fakecluster
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	. "github.com/icza/gox/gox"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/common"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/master"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/server"
+	ybutil "github.com/yugabyte/yb-tools/yugatool/api/yb/util"
+	"google.golang.org/protobuf/proto"
+)
+
+type genericHandler struct {
+	server.UnimplementedGenericServiceServer
+
+	cluster *Cluster
+	node    *Node
+}
+
+func (h *genericHandler) Ping(_ context.Context, _ *server.PingRequestPB) (*server.PingResponsePB, error) {
+	return &server.PingResponsePB{}, nil
+}
+
+func (h *genericHandler) GetStatus(_ context.Context, _ *server.GetStatusRequestPB) (*server.GetStatusResponsePB, error) {
+	return &server.GetStatusResponsePB{
+		Status: &server.ServerStatusPB{
+			NodeInstance:      h.node.instance(),
+			BoundRpcAddresses: []*common.HostPortPB{h.node.Address},
+			VersionInfo: &ybutil.VersionInfoPB{
+				VersionNumber: NewString(h.cluster.Version),
+				BuildNumber:   NewString("1"),
+			},
+		},
+	}, nil
+}
+
+func (h *genericHandler) GetFlag(_ context.Context, request *server.GetFlagRequestPB) (*server.GetFlagResponsePB, error) {
+	h.cluster.Lock()
+	defer h.cluster.Unlock()
+
+	value, ok := h.node.Flags[request.GetFlag()]
+	if !ok {
+		return &server.GetFlagResponsePB{Valid: NewBool(false)}, nil
+	}
+	return &server.GetFlagResponsePB{Valid: NewBool(true), Value: NewString(value)}, nil
+}
+
+func (h *genericHandler) SetFlag(_ context.Context, request *server.SetFlagRequestPB) (*server.SetFlagResponsePB, error) {
+	h.cluster.Lock()
+	defer h.cluster.Unlock()
+
+	oldValue, ok := h.node.Flags[request.GetFlag()]
+	switch {
+	case !ok:
+		return &server.SetFlagResponsePB{Result: server.SetFlagResponsePB_NO_SUCH_FLAG.Enum()}, nil
+	case h.cluster.UnsafeFlags[request.GetFlag()] && !request.GetForce():
+		return &server.SetFlagResponsePB{Result: server.SetFlagResponsePB_NOT_SAFE.Enum()}, nil
+	}
+
+	h.node.Flags[request.GetFlag()] = request.GetValue()
+	return &server.SetFlagResponsePB{
+		Result:   server.SetFlagResponsePB_SUCCESS.Enum(),
+		Msg:      NewString(fmt.Sprintf("%s set to %s", request.GetFlag(), request.GetValue())),
+		OldValue: NewString(oldValue),
+	}, nil
+}
+
+type masterHandler struct {
+	master.UnimplementedMasterServiceServer
+
+	cluster *Cluster
+	node    *Node
+}
+
+// notTheLeader returns the error followers send for calls only the leader serves
+func (h *masterHandler) notTheLeader() *master.MasterErrorPB {
+	if h.cluster.leader == h.node {
+		return nil
+	}
+	return &master.MasterErrorPB{
+		Code: master.MasterErrorPB_NOT_THE_LEADER.Enum(),
+		Status: &common.AppStatusPB{
+			Code:    common.AppStatusPB_ILLEGAL_STATE.Enum(),
+			Message: NewString("not the leader"),
+		},
+	}
+}
+
+func notFound(message string) *master.MasterErrorPB {
+	return &master.MasterErrorPB{
+		Code: master.MasterErrorPB_OBJECT_NOT_FOUND.Enum(),
+		Status: &common.AppStatusPB{
+			Code:    common.AppStatusPB_NOT_FOUND.Enum(),
+			Message: NewString(message),
+		},
+	}
+}
+
+func (h *masterHandler) role(node *Node) *common.RaftPeerPB_Role {
+	if h.cluster.leader == node {
+		return common.RaftPeerPB_LEADER.Enum()
+	}
+	return common.RaftPeerPB_FOLLOWER.Enum()
+}
+
+func (h *masterHandler) GetMasterRegistration(_ context.Context, _ *master.GetMasterRegistrationRequestPB) (*master.GetMasterRegistrationResponsePB, error) {
+	h.cluster.Lock()
+	defer h.cluster.Unlock()
+
+	return &master.GetMasterRegistrationResponsePB{
+		InstanceId:   h.node.instance(),
+		Registration: h.node.registration(),
+		Role:         h.role(h.node),
+	}, nil
+}
+
+func (h *masterHandler) ListMasters(_ context.Context, _ *master.ListMastersRequestPB) (*master.ListMastersResponsePB, error) {
+	h.cluster.Lock()
+	defer h.cluster.Unlock()
+
+	response := &master.ListMastersResponsePB{}
+	for _, m := range h.cluster.Masters {
+		response.Masters = append(response.Masters, &common.ServerEntryPB{
+			InstanceId:   m.instance(),
+			Registration: m.registration(),
+			Role:         h.role(m),
+		})
+	}
+	return response, nil
+}
+
+func (h *masterHandler) ListMasterRaftPeers(_ context.Context, _ *master.ListMasterRaftPeersRequestPB) (*master.ListMasterRaftPeersResponsePB, error) {
+	h.cluster.Lock()
+	defer h.cluster.Unlock()
+
+	response := &master.ListMasterRaftPeersResponsePB{}
+	for _, m := range h.cluster.Masters {
+		response.Masters = append(response.Masters, &common.RaftPeerPB{
+			PermanentUuid:          []byte(m.UUID),
+			MemberType:             common.RaftPeerPB_VOTER.Enum(),
+			LastKnownPrivateAddr:   []*common.HostPortPB{m.Address},
+			LastKnownBroadcastAddr: m.BroadcastAddresses,
+			CloudInfo:              m.CloudInfo,
+		})
+	}
+	return response, nil
+}
+
+func (h *masterHandler) ListTabletServers(_ context.Context, _ *master.ListTabletServersRequestPB) (*master.ListTabletServersResponsePB, error) {
+	h.cluster.Lock()
+	defer h.cluster.Unlock()
+
+	if err := h.notTheLeader(); err != nil {
+		return &master.ListTabletServersResponsePB{Error: err}, nil
+	}
+
+	response := &master.ListTabletServersResponsePB{}
+	for _, ts := range h.cluster.TabletServers {
+		millisSinceHeartbeat := int32(100)
+		if !ts.Alive {
+			millisSinceHeartbeat = 60000
+		}
+		response.Servers = append(response.Servers, &master.ListTabletServersResponsePB_Entry{
+			InstanceId:           ts.instance(),
+			Registration:         &master.TSRegistrationPB{Common: ts.registration()},
+			MillisSinceHeartbeat: NewInt32(millisSinceHeartbeat),
+			Alive:                NewBool(ts.Alive),
+			Metrics:              &master.TServerMetricsPB{TotalSstFileSize: NewInt64(h.cluster.sstSize(ts))},
+		})
+	}
+	return response, nil
+}
+
+func (h *masterHandler) GetMasterClusterConfig(_ context.Context, _ *master.GetMasterClusterConfigRequestPB) (*master.GetMasterClusterConfigResponsePB, error) {
+	h.cluster.Lock()
+	defer h.cluster.Unlock()
+
+	if err := h.notTheLeader(); err != nil {
+		return &master.GetMasterClusterConfigResponsePB{Error: err}, nil
+	}
+
+	return &master.GetMasterClusterConfigResponsePB{
+		ClusterConfig: proto.Clone(h.cluster.ClusterConfig).(*master.SysClusterConfigEntryPB),
+	}, nil
+}
+
+func (h *masterHandler) ListTables(_ context.Context, request *master.ListTablesRequestPB) (*master.ListTablesResponsePB, error) {
+	h.cluster.Lock()
+	defer h.cluster.Unlock()
+
+	if err := h.notTheLeader(); err != nil {
+		return &master.ListTablesResponsePB{Error: err}, nil
+	}
+
+	response := &master.ListTablesResponsePB{}
+	for _, table := range h.cluster.Tables {
+		if !strings.Contains(table.Name, request.GetNameFilter()) {
+			continue
+		}
+		if request.GetNamespace().GetName() != "" && request.GetNamespace().GetName() != table.Namespace {
+			continue
+		}
+		response.Tables = append(response.Tables, &master.ListTablesResponsePB_TableInfo{
+			Id:           []byte(table.ID),
+			Name:         NewString(table.Name),
+			TableType:    table.TableType.Enum(),
+			Namespace:    table.namespace(),
+			RelationType: master.RelationType_USER_TABLE_RELATION.Enum(),
+		})
+	}
+	return response, nil
+}
+
+func (h *masterHandler) GetTableSchema(_ context.Context, request *master.GetTableSchemaRequestPB) (*master.GetTableSchemaResponsePB, error) {
+	h.cluster.Lock()
+	defer h.cluster.Unlock()
+
+	if err := h.notTheLeader(); err != nil {
+		return &master.GetTableSchemaResponsePB{Error: err}, nil
+	}
+
+	table := h.cluster.findTable(string(request.GetTable().GetTableId()), request.GetTable().GetNamespace().GetName(), request.GetTable().GetTableName())
+	if table == nil || table.Dropped {
+		return &master.GetTableSchemaResponsePB{Error: notFound("table not found")}, nil
+	}
+
+	response := &master.GetTableSchemaResponsePB{
+		Schema:          proto.Clone(table.Schema).(*common.SchemaPB),
+		Version:         NewUint32(0),
+		CreateTableDone: NewBool(true),
+		TableType:       table.TableType.Enum(),
+		Identifier:      table.identifier(),
+	}
+	if table.ReplicationInfo != nil {
+		response.ReplicationInfo = proto.Clone(table.ReplicationInfo).(*master.ReplicationInfoPB)
+	}
+	if table.PartitionSchema != nil {
+		response.PartitionSchema = proto.Clone(table.PartitionSchema).(*common.PartitionSchemaPB)
+	}
+	return response, nil
+}
+
+// loadBalancerError returns the error the load balancer checks fail with
+func loadBalancerError(code master.MasterErrorPB_Code, message string) *master.MasterErrorPB {
+	return &master.MasterErrorPB{
+		Code: code.Enum(),
+		Status: &common.AppStatusPB{
+			Code:    common.AppStatusPB_TRY_AGAIN_CODE.Enum(),
+			Message: NewString(message),
+		},
+	}
+}
+
+func (h *masterHandler) IsLoadBalanced(_ context.Context, _ *master.IsLoadBalancedRequestPB) (*master.IsLoadBalancedResponsePB, error) {
+	h.cluster.Lock()
+	defer h.cluster.Unlock()
+
+	if err := h.notTheLeader(); err != nil {
+		return &master.IsLoadBalancedResponsePB{Error: err}, nil
+	}
+	if h.cluster.LoadBalancer.Unbalanced {
+		return &master.IsLoadBalancedResponsePB{
+			Error: loadBalancerError(master.MasterErrorPB_CAN_RETRY_LOAD_BALANCE_CHECK, "cluster is not balanced"),
+		}, nil
+	}
+	return &master.IsLoadBalancedResponsePB{}, nil
+}
+
+func (h *masterHandler) IsLoadBalancerIdle(_ context.Context, _ *master.IsLoadBalancerIdleRequestPB) (*master.IsLoadBalancerIdleResponsePB, error) {
+	h.cluster.Lock()
+	defer h.cluster.Unlock()
+
+	if err := h.notTheLeader(); err != nil {
+		return &master.IsLoadBalancerIdleResponsePB{Error: err}, nil
+	}
+	if h.cluster.LoadBalancer.Active {
+		return &master.IsLoadBalancerIdleResponsePB{
+			Error: loadBalancerError(master.MasterErrorPB_LOAD_BALANCER_RECENTLY_ACTIVE, "load balancer is active"),
+		}, nil
+	}
+	return &master.IsLoadBalancerIdleResponsePB{}, nil
+}
+
+func (h *masterHandler) AreLeadersOnPreferredOnly(_ context.Context, _ *master.AreLeadersOnPreferredOnlyRequestPB) (*master.AreLeadersOnPreferredOnlyResponsePB, error) {
+	h.cluster.Lock()
+	defer h.cluster.Unlock()
+
+	if err := h.notTheLeader(); err != nil {
+		return &master.AreLeadersOnPreferredOnlyResponsePB{Error: err}, nil
+	}
+	if h.cluster.LoadBalancer.LeadersNotPreferred {
+		return &master.AreLeadersOnPreferredOnlyResponsePB{
+			Error: loadBalancerError(master.MasterErrorPB_CAN_RETRY_ARE_LEADERS_ON_PREFERRED_ONLY_CHECK, "leaders are not on the preferred zones only"),
+		}, nil
+	}
+	return &master.AreLeadersOnPreferredOnlyResponsePB{}, nil
+}
+
+func (h *masterHandler) GetLoadMoveCompletion(_ context.Context, _ *master.GetLoadMovePercentRequestPB) (*master.GetLoadMovePercentResponsePB, error) {
+	h.cluster.Lock()
+	defer h.cluster.Unlock()
+
+	if err := h.notTheLeader(); err != nil {
+		return &master.GetLoadMovePercentResponsePB{Error: err}, nil
+	}
+
+	state := &h.cluster.LoadBalancer
+	return loadMoveCompletion(&state.MovesRemaining, state.MovesTotal, state.MovesPerCall), nil
+}
+
+func (h *masterHandler) GetLeaderBlacklistCompletion(_ context.Context, _ *master.GetLeaderBlacklistPercentRequestPB) (*master.GetLoadMovePercentResponsePB, error) {
+	h.cluster.Lock()
+	defer h.cluster.Unlock()
+
+	if err := h.notTheLeader(); err != nil {
+		return &master.GetLoadMovePercentResponsePB{Error: err}, nil
+	}
+
+	state := &h.cluster.LoadBalancer
+	return loadMoveCompletion(&state.LeaderMovesRemaining, state.LeaderMovesTotal, state.MovesPerCall), nil
+}
+
+// loadMoveCompletion reports the moves remaining, then completes the moves made
+// per call
+func loadMoveCompletion(remaining *uint64, total uint64, movesPerCall uint64) *master.GetLoadMovePercentResponsePB {
+	percent := 100.0
+	if total > 0 {
+		percent = 100 * float64(total-*remaining) / float64(total)
+	}
+	response := &master.GetLoadMovePercentResponsePB{
+		Percent:   NewFloat64(percent),
+		Remaining: NewUint64(*remaining),
+		Total:     NewUint64(total),
+	}
+
+	if *remaining > movesPerCall {
+		*remaining -= movesPerCall
+	} else if movesPerCall > 0 {
+		*remaining = 0
+	}
+	return response
+}
+
+func (h *masterHandler) ChangeMasterClusterConfig(_ context.Context, request *master.ChangeMasterClusterConfigRequestPB) (*master.ChangeMasterClusterConfigResponsePB, error) {
+	h.cluster.Lock()
+	defer h.cluster.Unlock()
+
+	if err := h.notTheLeader(); err != nil {
+		return &master.ChangeMasterClusterConfigResponsePB{Error: err}, nil
+	}
+
+	if change := h.cluster.ConcurrentConfigChange; change != nil {
+		h.cluster.ConcurrentConfigChange = nil
+		change(h.cluster.ClusterConfig)
+		h.cluster.ClusterConfig.Version = NewInt32(h.cluster.ClusterConfig.GetVersion() + 1)
+	}
+
+	// The change only applies to the version of the config it was made to
+	if request.GetClusterConfig().GetVersion() != h.cluster.ClusterConfig.GetVersion() {
+		return &master.ChangeMasterClusterConfigResponsePB{
+			Error: &master.MasterErrorPB{
+				Code: master.MasterErrorPB_CONFIG_VERSION_MISMATCH.Enum(),
+				Status: &common.AppStatusPB{
+					Code: common.AppStatusPB_INVALID_ARGUMENT.Enum(),
+					Message: NewString(fmt.Sprintf("config version does not match, got %d, but most recent one is %d",
+						request.GetClusterConfig().GetVersion(), h.cluster.ClusterConfig.GetVersion())),
+				},
+			},
+		}, nil
+	}
+
+	config := proto.Clone(request.GetClusterConfig()).(*master.SysClusterConfigEntryPB)
+	config.Version = NewInt32(h.cluster.ClusterConfig.GetVersion() + 1)
+	h.cluster.ClusterConfig = config
+	return &master.ChangeMasterClusterConfigResponsePB{}, nil
+}
+
+func (h *masterHandler) GetTableLocations(_ context.Context, request *master.GetTableLocationsRequestPB) (*master.GetTableLocationsResponsePB, error) {
+	h.cluster.Lock()
+	defer h.cluster.Unlock()
+
+	if err := h.notTheLeader(); err != nil {
+		return &master.GetTableLocationsResponsePB{Error: err}, nil
+	}
+
+	table := h.cluster.findTable(string(request.GetTable().GetTableId()), request.GetTable().GetNamespace().GetName(), request.GetTable().GetTableName())
+	if table == nil {
+		return &master.GetTableLocationsResponsePB{Error: notFound("table not found")}, nil
+	}
+
+	response := &master.GetTableLocationsResponsePB{TableType: table.TableType.Enum()}
+	for _, tablet := range table.Tablets {
+		// Split tablets are replaced by their children
+		if len(tablet.SplitTablets) > 0 {
+			continue
+		}
+		// Skip tablets that end before the requested start key
+		end := tablet.Partition.GetPartitionKeyEnd()
+		if len(end) > 0 && string(end) <= string(request.GetPartitionKeyStart()) {
+			continue
+		}
+		if request.MaxReturnedLocations != nil && len(response.TabletLocations) >= int(request.GetMaxReturnedLocations()) {
+			break
+		}
+		response.TabletLocations = append(response.TabletLocations, tablet.locations())
+	}
+	return response, nil
+}
+
+func (h *masterHandler) ListCDCStreams(_ context.Context, request *master.ListCDCStreamsRequestPB) (*master.ListCDCStreamsResponsePB, error) {
+	h.cluster.Lock()
+	defer h.cluster.Unlock()
+
+	if err := h.notTheLeader(); err != nil {
+		return &master.ListCDCStreamsResponsePB{Error: err}, nil
+	}
+
+	response := &master.ListCDCStreamsResponsePB{}
+	for _, table := range h.cluster.Tables {
+		if request.TableId != nil && table.ID != request.GetTableId() {
+			continue
+		}
+		for _, stream := range table.CDCStreams {
+			response.Streams = append(response.Streams, &master.CDCStreamInfoPB{
+				StreamId: []byte(stream),
+				TableId:  []byte(table.ID),
+			})
+		}
+	}
+	return response, nil
+}
+
+// SplitTablet splits the tablet into two children at the middle of its hash
+// range, hosted by the same replicas. The parent is kept, as the master keeps
+// it until its children have compacted.
+func (h *masterHandler) SplitTablet(_ context.Context, request *master.SplitTabletRequestPB) (*master.SplitTabletResponsePB, error) {
+	h.cluster.Lock()
+	defer h.cluster.Unlock()
+
+	if err := h.notTheLeader(); err != nil {
+		return &master.SplitTabletResponsePB{Error: err}, nil
+	}
+
+	parent := h.cluster.findTablet(string(request.GetTabletId()))
+	if parent == nil {
+		return &master.SplitTabletResponsePB{Error: notFound("tablet not found")}, nil
+	}
+	if len(parent.SplitTablets) > 0 {
+		return &master.SplitTabletResponsePB{Error: &master.MasterErrorPB{
+			Code: master.MasterErrorPB_SPLIT_OR_BACKFILL_IN_PROGRESS.Enum(),
+			Status: &common.AppStatusPB{
+				Code:    common.AppStatusPB_ILLEGAL_STATE.Enum(),
+				Message: NewString("tablet has already been split"),
+			},
+		}}, nil
+	}
+
+	start, end := 0, 0x10000
+	if key := parent.Partition.GetPartitionKeyStart(); len(key) > 0 {
+		start = int(binary.BigEndian.Uint16(key))
+	}
+	if key := parent.Partition.GetPartitionKeyEnd(); len(key) > 0 {
+		end = int(binary.BigEndian.Uint16(key))
+	}
+	middle := hashKey((start + end) / 2)
+
+	state := common.RaftGroupStatePB_RUNNING
+	if h.cluster.SplitBootstrapping {
+		state = common.RaftGroupStatePB_BOOTSTRAPPING
+	}
+	for _, partition := range []*common.PartitionPB{
+		{PartitionKeyStart: parent.Partition.GetPartitionKeyStart(), PartitionKeyEnd: middle},
+		{PartitionKeyStart: middle, PartitionKeyEnd: parent.Partition.GetPartitionKeyEnd()},
+	} {
+		child := &Tablet{
+			ID:        newUUID(),
+			Table:     parent.Table,
+			Partition: partition,
+			Replicas:  append([]*Node{}, parent.Replicas...),
+			Leader:    parent.Leader,
+			Term:      parent.Term,
+			LastOpID:  &ybutil.OpIdPB{Term: NewInt64(parent.Term), Index: NewInt64(1)},
+			State:     state,
+			DataState: common.TabletDataState_TABLET_DATA_READY,
+			SSTSize:   parent.SSTSize / 2,
+		}
+		parent.SplitTablets = append(parent.SplitTablets, child)
+		parent.Table.Tablets = append(parent.Table.Tablets, child)
+	}
+	parent.DataState = common.TabletDataState_TABLET_DATA_SPLIT_COMPLETED
+	return &master.SplitTabletResponsePB{}, nil
+}
+
+func (h *masterHandler) GetTabletLocations(_ context.Context, request *master.GetTabletLocationsRequestPB) (*master.GetTabletLocationsResponsePB, error) {
+	h.cluster.Lock()
+	defer h.cluster.Unlock()
+
+	if err := h.notTheLeader(); err != nil {
+		return &master.GetTabletLocationsResponsePB{Error: err}, nil
+	}
+
+	response := &master.GetTabletLocationsResponsePB{}
+	for _, tabletID := range request.GetTabletIds() {
+		tablet := h.cluster.findTablet(string(tabletID))
+		if tablet == nil {
+			response.Errors = append(response.Errors, &master.GetTabletLocationsResponsePB_Error{
+				TabletId: tabletID,
+				Status: &common.AppStatusPB{
+					Code:    common.AppStatusPB_NOT_FOUND.Enum(),
+					Message: NewString("unknown tablet"),
+				},
+			})
+			continue
+		}
+		response.TabletLocations = append(response.TabletLocations, tablet.locations())
+	}
+	return response, nil
+}
+
+func (n *Node) instance() *common.NodeInstancePB {
+	return &common.NodeInstancePB{
+		PermanentUuid: []byte(n.UUID),
+		InstanceSeqno: NewInt64(1),
+	}
+}
+
+func (n *Node) registration() *common.ServerRegistrationPB {
+	return &common.ServerRegistrationPB{
+		PrivateRpcAddresses: []*common.HostPortPB{n.Address},
+		BroadcastAddresses:  n.BroadcastAddresses,
+		CloudInfo:           n.CloudInfo,
+	}
+}
+
+func (t *Table) namespace() *master.NamespaceIdentifierPB {
+	return &master.NamespaceIdentifierPB{
+		Id:           []byte(t.Namespace),
+		Name:         NewString(t.Namespace),
+		DatabaseType: common.YQLDatabase_YQL_DATABASE_CQL.Enum(),
+	}
+}
+
+func (t *Table) identifier() *master.TableIdentifierPB {
+	return &master.TableIdentifierPB{
+		TableId:   []byte(t.ID),
+		TableName: NewString(t.Name),
+		Namespace: t.namespace(),
+	}
+}
+
+func (t *Tablet) locations() *master.TabletLocationsPB {
+	locations := &master.TabletLocationsPB{
+		TabletId:  []byte(t.ID),
+		Partition: t.Partition,
+		TableId:   []byte(t.Table.ID),
+		Stale:     NewBool(false),
+	}
+	for _, child := range t.SplitTablets {
+		locations.SplitTabletIds = append(locations.SplitTabletIds, []byte(child.ID))
+	}
+	for _, replica := range t.Replicas {
+		role := common.RaftPeerPB_FOLLOWER
+		if replica == t.Leader {
+			role = common.RaftPeerPB_LEADER
+		}
+		locations.Replicas = append(locations.Replicas, &master.TabletLocationsPB_ReplicaPB{
+			TsInfo: &master.TSInfoPB{
+				PermanentUuid:       []byte(replica.UUID),
+				PrivateRpcAddresses: []*common.HostPortPB{replica.Address},
+				CloudInfo:           replica.CloudInfo,
+			},
+			Role:       role.Enum(),
+			MemberType: t.memberType(replica).Enum(),
+		})
+	}
+	return locations
+}
@@ -0,0 +1,393 @@
+package fakecluster
+
+import (
+	"context"
+
+	. "github.com/icza/gox/gox"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/cdc"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/common"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/consensus"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/tablet"
+	"github.com/yugabyte/yb-tools/yugatool/api/yb/tserver"
+	ybutil "github.com/yugabyte/yb-tools/yugatool/api/yb/util"
+	"google.golang.org/protobuf/proto"
+)
+
+type tabletServerHandler struct {
+	tserver.UnimplementedTabletServerServiceServer
+
+	cluster *Cluster
+	node    *Node
+}
+
+func (h *tabletServerHandler) ListTablets(_ context.Context, _ *tserver.ListTabletsRequestPB) (*tserver.ListTabletsResponsePB, error) {
+	h.cluster.Lock()
+	defer h.cluster.Unlock()
+
+	response := &tserver.ListTabletsResponsePB{}
+	for _, table := range h.cluster.Tables {
+		for _, t := range table.Tablets {
+			state, dataState := t.State, t.DataState
+			if !t.hasReplica(h.node) {
+				if !t.isTombstoned(h.node) {
+					continue
+				}
+				state, dataState = common.RaftGroupStatePB_SHUTDOWN, common.TabletDataState_TABLET_DATA_TOMBSTONED
+			}
+			response.StatusAndSchema = append(response.StatusAndSchema, &tserver.ListTabletsResponsePB_StatusAndSchemaPB{
+				TabletStatus: &tablet.TabletStatusPB{
+					TabletId:         NewString(t.ID),
+					NamespaceName:    NewString(table.Namespace),
+					TableName:        NewString(table.Name),
+					TableId:          NewString(table.ID),
+					LastStatus:       NewString(state.String()),
+					State:            state.Enum(),
+					TabletDataState:  dataState.Enum(),
+					Partition:        t.Partition,
+					SstFilesDiskSize: NewInt64(t.SSTSize),
+					WalFilesDiskSize: NewInt64(t.WALSize),
+				},
+				Schema: proto.Clone(table.Schema).(*common.SchemaPB),
+			})
+		}
+	}
+	return response, nil
+}
+
+type tabletServerAdminHandler struct {
+	tserver.UnimplementedTabletServerAdminServiceServer
+
+	cluster *Cluster
+	node    *Node
+}
+
+// DeleteTablet deletes the replica on the node, or tombstones it when it is
+// still a peer and a tombstone is asked for.
+func (h *tabletServerAdminHandler) DeleteTablet(_ context.Context, request *tserver.DeleteTabletRequestPB) (*tserver.DeleteTabletResponsePB, error) {
+	h.cluster.Lock()
+	defer h.cluster.Unlock()
+
+	t := h.cluster.findTablet(string(request.GetTabletId()))
+	if t == nil || !(t.hasReplica(h.node) || t.isTombstoned(h.node)) {
+		return &tserver.DeleteTabletResponsePB{Error: tabletNotFound()}, nil
+	}
+
+	if !t.hasReplica(h.node) {
+		if request.GetDeleteType() == common.TabletDataState_TABLET_DATA_DELETED {
+			t.Tombstoned = removeNode(t.Tombstoned, h.node)
+		}
+		return &tserver.DeleteTabletResponsePB{}, nil
+	}
+
+	if request.CasConfigOpidIndexLessOrEqual != nil && t.LastOpID.GetIndex() > request.GetCasConfigOpidIndexLessOrEqual() {
+		return &tserver.DeleteTabletResponsePB{
+			Error: tabletServerError(tserver.TabletServerErrorPB_CAS_FAILED, common.AppStatusPB_ILLEGAL_STATE, "config has changed"),
+		}, nil
+	}
+	t.removeReplica(h.node)
+	if request.GetDeleteType() == common.TabletDataState_TABLET_DATA_TOMBSTONED {
+		t.Tombstoned = append(t.Tombstoned, h.node)
+	}
+	return &tserver.DeleteTabletResponsePB{}, nil
+}
+
+type consensusHandler struct {
+	consensus.UnimplementedConsensusServiceServer
+
+	cluster *Cluster
+	node    *Node
+}
+
+func (h *consensusHandler) GetConsensusState(_ context.Context, request *consensus.GetConsensusStateRequestPB) (*consensus.GetConsensusStateResponsePB, error) {
+	h.cluster.Lock()
+	defer h.cluster.Unlock()
+
+	t := h.cluster.findTablet(string(request.GetTabletId()))
+	if t == nil || !t.hasReplica(h.node) {
+		return &consensus.GetConsensusStateResponsePB{Error: tabletNotFound()}, nil
+	}
+
+	peers := t.Replicas
+	if committed, ok := t.CommittedConfigs[h.node.UUID]; ok {
+		peers = committed
+	}
+
+	config := &common.RaftConfigPB{OpidIndex: NewInt64(t.LastOpID.GetIndex())}
+	for _, replica := range peers {
+		config.Peers = append(config.Peers, &common.RaftPeerPB{
+			PermanentUuid:        []byte(replica.UUID),
+			MemberType:           t.memberType(replica).Enum(),
+			LastKnownPrivateAddr: []*common.HostPortPB{replica.Address},
+			CloudInfo:            replica.CloudInfo,
+		})
+	}
+
+	leaseStatus := consensus.LeaderLeaseStatus_NO_MAJORITY_REPLICATED_LEASE
+	if t.Leader == h.node {
+		leaseStatus = consensus.LeaderLeaseStatus_HAS_LEASE
+	}
+
+	response := &consensus.GetConsensusStateResponsePB{
+		Cstate: &common.ConsensusStatePB{
+			CurrentTerm: NewInt64(t.Term),
+			Config:      config,
+		},
+		LeaderLeaseStatus: leaseStatus.Enum(),
+	}
+	if t.Leader != nil {
+		response.Cstate.LeaderUuid = NewString(t.Leader.UUID)
+	}
+	return response, nil
+}
+
+type cdcHandler struct {
+	cdc.UnimplementedCDCServiceServer
+
+	cluster *Cluster
+	node    *Node
+}
+
+// ChangeConfig adds, removes or changes the role of a peer. Each change is a
+// new operation, which the OpId index of the config follows.
+func (h *consensusHandler) ChangeConfig(_ context.Context, request *consensus.ChangeConfigRequestPB) (*consensus.ChangeConfigResponsePB, error) {
+	h.cluster.Lock()
+	defer h.cluster.Unlock()
+
+	changeConfigError := func(code tserver.TabletServerErrorPB_Code, message string) (*consensus.ChangeConfigResponsePB, error) {
+		return &consensus.ChangeConfigResponsePB{
+			Error: tabletServerError(code, common.AppStatusPB_ILLEGAL_STATE, message),
+		}, nil
+	}
+
+	t := h.cluster.findTablet(string(request.GetTabletId()))
+	if t == nil || !t.hasReplica(h.node) {
+		return &consensus.ChangeConfigResponsePB{Error: tabletNotFound()}, nil
+	}
+	if t.Leader != h.node {
+		return changeConfigError(tserver.TabletServerErrorPB_NOT_THE_LEADER, "not the leader")
+	}
+	if request.CasConfigOpidIndex != nil && request.GetCasConfigOpidIndex() != t.LastOpID.GetIndex() {
+		return changeConfigError(tserver.TabletServerErrorPB_CAS_FAILED, "committed config has changed")
+	}
+
+	peer := h.cluster.findTabletServer(string(request.GetServer().GetPermanentUuid()))
+	if peer == nil {
+		return changeConfigError(tserver.TabletServerErrorPB_INVALID_CONFIG, "unknown peer")
+	}
+
+	switch request.GetType() {
+	case consensus.ChangeConfigType_ADD_SERVER:
+		if t.hasReplica(peer) {
+			return changeConfigError(tserver.TabletServerErrorPB_ADD_CHANGE_CONFIG_ALREADY_PRESENT, "peer is already in the config")
+		}
+		t.Replicas = append(t.Replicas, peer)
+		t.Tombstoned = removeNode(t.Tombstoned, peer)
+		t.setMemberType(peer, request.GetServer().GetMemberType())
+		if t.Lag == nil {
+			t.Lag = make(map[string]int64)
+		}
+		t.Lag[peer.UUID] = h.cluster.BootstrapLag
+	case consensus.ChangeConfigType_REMOVE_SERVER:
+		if !t.hasReplica(peer) {
+			return changeConfigError(tserver.TabletServerErrorPB_REMOVE_CHANGE_CONFIG_NOT_PRESENT, "peer is not in the config")
+		}
+		if peer == t.Leader {
+			return changeConfigError(tserver.TabletServerErrorPB_LEADER_NEEDS_STEP_DOWN, "cannot remove the leader")
+		}
+		t.removeReplica(peer)
+		t.Tombstoned = append(t.Tombstoned, peer)
+	case consensus.ChangeConfigType_CHANGE_ROLE:
+		if !t.hasReplica(peer) {
+			return changeConfigError(tserver.TabletServerErrorPB_REMOVE_CHANGE_CONFIG_NOT_PRESENT, "peer is not in the config")
+		}
+		t.setMemberType(peer, request.GetServer().GetMemberType())
+	default:
+		return changeConfigError(tserver.TabletServerErrorPB_INVALID_CONFIG, "unknown change type")
+	}
+
+	t.LastOpID = &ybutil.OpIdPB{Term: NewInt64(t.Term), Index: NewInt64(t.LastOpID.GetIndex() + 1)}
+	return &consensus.ChangeConfigResponsePB{}, nil
+}
+
+// GetLastOpId reports the last operation of the tablet less the lag of the
+// replica, which then catches up by one operation
+func (h *consensusHandler) GetLastOpId(_ context.Context, request *consensus.GetLastOpIdRequestPB) (*consensus.GetLastOpIdResponsePB, error) {
+	h.cluster.Lock()
+	defer h.cluster.Unlock()
+
+	t := h.cluster.findTablet(string(request.GetTabletId()))
+	if t == nil || !t.hasReplica(h.node) {
+		return &consensus.GetLastOpIdResponsePB{Error: tabletNotFound()}, nil
+	}
+
+	lag := t.Lag[h.node.UUID]
+	if lag > 0 {
+		t.Lag[h.node.UUID]--
+	}
+	return &consensus.GetLastOpIdResponsePB{
+		Opid: &ybutil.OpIdPB{Term: NewInt64(t.LastOpID.GetTerm()), Index: NewInt64(t.LastOpID.GetIndex() - lag)},
+	}, nil
+}
+
+func (h *consensusHandler) LeaderStepDown(_ context.Context, request *consensus.LeaderStepDownRequestPB) (*consensus.LeaderStepDownResponsePB, error) {
+	h.cluster.Lock()
+	defer h.cluster.Unlock()
+
+	t := h.cluster.findTablet(string(request.GetTabletId()))
+	if t == nil || !t.hasReplica(h.node) {
+		return &consensus.LeaderStepDownResponsePB{Error: tabletNotFound()}, nil
+	}
+	if t.Leader != h.node {
+		return &consensus.LeaderStepDownResponsePB{
+			Error: tabletServerError(tserver.TabletServerErrorPB_NOT_THE_LEADER, common.AppStatusPB_ILLEGAL_STATE, "not the leader"),
+		}, nil
+	}
+
+	// Without a new leader, the next replica is chosen
+	var newLeader *Node
+	if request.NewLeaderUuid != nil {
+		for _, replica := range t.Replicas {
+			if replica.UUID == string(request.GetNewLeaderUuid()) {
+				newLeader = replica
+			}
+		}
+		if newLeader == nil {
+			return &consensus.LeaderStepDownResponsePB{
+				Error: tabletServerError(tserver.TabletServerErrorPB_INVALID_CONFIG, common.AppStatusPB_INVALID_ARGUMENT, "new leader is not a peer"),
+			}, nil
+		}
+	} else {
+		for i, replica := range t.Replicas {
+			if replica == h.node {
+				newLeader = t.Replicas[(i+1)%len(t.Replicas)]
+			}
+		}
+	}
+
+	t.elect(newLeader)
+	return &consensus.LeaderStepDownResponsePB{}, nil
+}
+
+func (h *consensusHandler) RunLeaderElection(_ context.Context, request *consensus.RunLeaderElectionRequestPB) (*consensus.RunLeaderElectionResponsePB, error) {
+	h.cluster.Lock()
+	defer h.cluster.Unlock()
+
+	t := h.cluster.findTablet(string(request.GetTabletId()))
+	if t == nil || !t.hasReplica(h.node) {
+		return &consensus.RunLeaderElectionResponsePB{Error: tabletNotFound()}, nil
+	}
+
+	t.elect(h.node)
+	return &consensus.RunLeaderElectionResponsePB{}, nil
+}
+
+func (h *cdcHandler) GetCheckpoint(_ context.Context, request *cdc.GetCheckpointRequestPB) (*cdc.GetCheckpointResponsePB, error) {
+	h.cluster.Lock()
+	defer h.cluster.Unlock()
+
+	opID, ok := h.cluster.Checkpoints[string(request.GetStreamId())][string(request.GetTabletId())]
+	if !ok {
+		return &cdc.GetCheckpointResponsePB{
+			Error: &cdc.CDCErrorPB{
+				Code: cdc.CDCErrorPB_INTERNAL_ERROR.Enum(),
+				Status: &common.AppStatusPB{
+					Code:    common.AppStatusPB_NOT_FOUND.Enum(),
+					Message: NewString("no checkpoint for stream"),
+				},
+			},
+		}, nil
+	}
+
+	return &cdc.GetCheckpointResponsePB{
+		Checkpoint: &cdc.CDCCheckpointPB{OpId: proto.Clone(opID).(*ybutil.OpIdPB)},
+	}, nil
+}
+
+func (h *cdcHandler) GetLatestEntryOpId(_ context.Context, request *cdc.GetLatestEntryOpIdRequestPB) (*cdc.GetLatestEntryOpIdResponsePB, error) {
+	h.cluster.Lock()
+	defer h.cluster.Unlock()
+
+	t := h.cluster.findTablet(string(request.GetTabletId()))
+	if t == nil || !t.hasReplica(h.node) {
+		return &cdc.GetLatestEntryOpIdResponsePB{
+			Error: &cdc.CDCErrorPB{Code: cdc.CDCErrorPB_TABLET_NOT_FOUND.Enum()},
+		}, nil
+	}
+
+	return &cdc.GetLatestEntryOpIdResponsePB{
+		OpId: proto.Clone(t.LastOpID).(*ybutil.OpIdPB),
+	}, nil
+}
+
+func tabletNotFound() *tserver.TabletServerErrorPB {
+	return tabletServerError(tserver.TabletServerErrorPB_TABLET_NOT_FOUND, common.AppStatusPB_NOT_FOUND, "tablet not found")
+}
+
+func tabletServerError(code tserver.TabletServerErrorPB_Code, status common.AppStatusPB_ErrorCode, message string) *tserver.TabletServerErrorPB {
+	return &tserver.TabletServerErrorPB{
+		Code: code.Enum(),
+		Status: &common.AppStatusPB{
+			Code:    status.Enum(),
+			Message: NewString(message),
+		},
+	}
+}
+
+// elect makes the node the leader in a new term, unless the leader is pinned
+func (t *Tablet) elect(node *Node) {
+	t.Term++
+	if !t.LeaderPinned {
+		t.Leader = node
+	}
+}
+
+func (t *Tablet) isTombstoned(node *Node) bool {
+	for _, tombstoned := range t.Tombstoned {
+		if tombstoned == node {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *Tablet) memberType(node *Node) common.RaftPeerPB_MemberType {
+	if memberType, ok := t.MemberTypes[node.UUID]; ok {
+		return memberType
+	}
+	return common.RaftPeerPB_VOTER
+}
+
+func (t *Tablet) setMemberType(node *Node, memberType common.RaftPeerPB_MemberType) {
+	if t.MemberTypes == nil {
+		t.MemberTypes = make(map[string]common.RaftPeerPB_MemberType)
+	}
+	t.MemberTypes[node.UUID] = memberType
+}
+
+func (t *Tablet) removeReplica(node *Node) {
+	t.Replicas = removeNode(t.Replicas, node)
+	delete(t.MemberTypes, node.UUID)
+	delete(t.Lag, node.UUID)
+	if t.Leader == node {
+		t.Leader = nil
+	}
+}
+
+func removeNode(nodes []*Node, node *Node) []*Node {
+	var remaining []*Node
+	for _, n := range nodes {
+		if n != node {
+			remaining = append(remaining, n)
+		}
+	}
+	return remaining
+}
+
+func (t *Tablet) hasReplica(node *Node) bool {
+	for _, replica := range t.Replicas {
+		if replica == node {
+			return true
+		}
+	}
+	return false
+}
@@ -7,6 +7,7 @@ import (
 	"crypto/x509/pkix"
 	"encoding/pem"
 	"math/big"
+	"net"
 	"time"
 
 	"github.com/pkg/errors"
@@ -93,3 +94,47 @@ func GenerateClientCertificate(CACert *x509.Certificate, key *rsa.PrivateKey) (c
 	privateKey = pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: privateKeyBytes})
 	return
 }
+
+// GenerateServerCertificate creates a certificate signed by the CA that is valid
+// for the hosts until notAfter. Hosts that are IP addresses are added as IP
+// SANs.
+func GenerateServerCertificate(CACert *x509.Certificate, key *rsa.PrivateKey, notAfter time.Time, hosts ...string) (cert, privateKey []byte, err error) {
+	notBefore := time.Now().Add(-1 * time.Minute)
+	if notAfter.Before(notBefore) {
+		notBefore = notAfter.AddDate(-1, 0, 0)
+	}
+
+	certTemplate := &x509.Certificate{
+		Version:      3,
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage: []x509.ExtKeyUsage{
+			x509.ExtKeyUsageServerAuth,
+		},
+	}
+	if len(hosts) > 0 {
+		certTemplate.Subject.CommonName = hosts[0]
+	}
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			certTemplate.IPAddresses = append(certTemplate.IPAddresses, ip)
+		} else {
+			certTemplate.DNSNames = append(certTemplate.DNSNames, host)
+		}
+	}
+
+	certBytes, err := x509.CreateCertificate(rand.Reader, certTemplate, CACert, &key.PublicKey, key)
+	if err != nil {
+		return
+	}
+
+	privateKeyBytes, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return
+	}
+	cert = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certBytes})
+	privateKey = pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: privateKeyBytes})
+	return
+}
@@ -4,6 +4,8 @@ import (
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/pem"
+	"net"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -86,4 +88,32 @@ var _ = Describe("Certificate", func() {
 		})
 
 	})
+	Context("GenerateServerCertificate", func() {
+		It("creates a certificate for the hosts", func() {
+			notAfter := time.Now().Add(time.Hour).Truncate(time.Second)
+			serverCertPEM, _, err := util2.GenerateServerCertificate(CACert, privateKey, notAfter, "yb-master-0", "10.0.0.1")
+			Expect(err).NotTo(HaveOccurred())
+
+			decodedPEM, restOfPEM = pem.Decode(serverCertPEM)
+			Expect(restOfPEM).To(HaveLen(0))
+			serverCert, err := x509.ParseCertificate(decodedPEM.Bytes)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(serverCert.Subject.CommonName).To(Equal("yb-master-0"))
+			Expect(serverCert.DNSNames).To(Equal([]string{"yb-master-0"}))
+			Expect(serverCert.IPAddresses).To(HaveLen(1))
+			Expect(serverCert.IPAddresses[0].Equal(net.ParseIP("10.0.0.1"))).To(BeTrue())
+			Expect(serverCert.NotAfter).To(BeTemporally("==", notAfter))
+			Expect(serverCert.CheckSignatureFrom(CACert)).To(Succeed())
+		})
+		It("creates expired certificates", func() {
+			serverCertPEM, _, err := util2.GenerateServerCertificate(CACert, privateKey, time.Now().Add(-time.Hour), "yb-master-0")
+			Expect(err).NotTo(HaveOccurred())
+
+			decodedPEM, _ = pem.Decode(serverCertPEM)
+			serverCert, err := x509.ParseCertificate(decodedPEM.Bytes)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(serverCert.NotBefore).To(BeTemporally("<", serverCert.NotAfter))
+		})
+	})
 })
@@ -37,7 +37,7 @@ func HasTLS(tlsOptions *config.TlsOptionsPB) bool {
 	if tlsOptions.GetSkipHostVerification() ||
 		tlsOptions.GetCaCertPath() != "" ||
 		tlsOptions.GetCertPath() != "" ||
-		tlsOptions.GetCaCertPath() != "" {
+		tlsOptions.GetKeyPath() != "" {
 		return true
 	}
 